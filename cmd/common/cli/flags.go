@@ -7,8 +7,25 @@ const (
 	TrustDomainAFlagName           = "trustDomainA"
 	TrustDomainBFlagName           = "trustDomainB"
 	TrustDomainDescriptionFlagName = "trustDomainDescription"
+	HarvesterSPIFFEIDFlagName      = "harvesterSpiffeID"
+	OnboardingBundleFlagName       = "onboardingBundle"
 	ConsentStatusFlagName          = "status"
 	TTLFlagName                    = "ttl"
 	RelationshipIDFlagName         = "relationshipID"
 	JoinTokenFlagName              = "joinToken"
+	JoinTokenIDFlagName            = "joinTokenID"
+	MaxUsesFlagName                = "maxUses"
+	SourceCIDRFlagName             = "sourceCIDR"
+	SilentThresholdFlagName        = "silentThreshold"
+	BundleEndpointURLFlagName      = "bundleEndpointURL"
+	BundleEndpointProfileFlagName  = "bundleEndpointProfile"
+	EndpointSPIFFEIDFlagName       = "endpointSpiffeID"
+	BootstrapBundleFlagName        = "bootstrapBundle"
+	BundleFileFlagName             = "file"
+	SignatureFileFlagName          = "signature"
+	SigningCertificateFlagName     = "signingCertificate"
+	DirectionFlagName              = "direction"
+	FederationGroupFlagName        = "group"
+	GroupDescriptionFlagName       = "description"
+	DefaultConsentFlagName         = "defaultConsent"
 )
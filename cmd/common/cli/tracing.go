@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+)
+
+const defaultTracingSampleRatio = 1.0
+
+// TracingConfig holds the HCL `tracing` block shared by the Galadriel Server and Harvester configurations.
+type TracingConfig struct {
+	Exporter    string   `hcl:"exporter"`
+	Endpoint    string   `hcl:"endpoint,optional"`
+	Insecure    bool     `hcl:"insecure,optional"`
+	FilePath    string   `hcl:"file_path,optional"`
+	SampleRatio *float64 `hcl:"sample_ratio,optional"`
+}
+
+// ToTelemetryConfig converts the HCL tracing configuration to a telemetry.TracingConfig for the given service.
+func (c *TracingConfig) ToTelemetryConfig(serviceName string) *telemetry.TracingConfig {
+	sampleRatio := defaultTracingSampleRatio
+	if c.SampleRatio != nil {
+		sampleRatio = *c.SampleRatio
+	}
+
+	return &telemetry.TracingConfig{
+		ServiceName:  serviceName,
+		Exporter:     c.Exporter,
+		OTLPEndpoint: c.Endpoint,
+		OTLPInsecure: c.Insecure,
+		FilePath:     c.FilePath,
+		SampleRatio:  sampleRatio,
+	}
+}
@@ -7,6 +7,8 @@ import (
 const (
 	defaultSocketPath = "/tmp/galadriel-harvester/api.sock"
 	defaultConfigPath = "conf/harvester/harvester.conf"
+
+	defaultWorkloadAPISocketPath = "/tmp/spire-agent/public/api.sock"
 )
 
 type harvesterCLI struct {
@@ -6,6 +6,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
@@ -30,10 +31,23 @@ type harvesterConfig struct {
 	SpireSocketPath              string `hcl:"spire_socket_path,optional"`
 	GaladrielServerAddress       string `hcl:"galadriel_server_address"`
 	ServerTrustBundlePath        string `hcl:"server_trust_bundle_path"`
+	ServerName                   string `hcl:"server_name,optional"`
 	FederatedBundlesPollInterval string `hcl:"federated_bundles_poll_interval,optional"`
 	SpireBundlePollInterval      string `hcl:"spire_bundle_poll_interval,optional"`
 	LogLevel                     string `hcl:"log_level,optional"`
 	DataDir                      string `hcl:"data_dir"`
+	InstanceID                   string `hcl:"instance_id,optional"`
+	MetricsAddress               string `hcl:"metrics_address,optional"`
+
+	Tracing        *cli.TracingConfig    `hcl:"tracing,block"`
+	SVIDOnboarding *svidOnboardingConfig `hcl:"svid_onboarding,block"`
+}
+
+// svidOnboardingConfig holds the configuration of the onboarding of the Harvester with an X509-SVID.
+type svidOnboardingConfig struct {
+	Source                string `hcl:"source"`
+	SPIFFEID              string `hcl:"spiffe_id,optional"`
+	WorkloadAPISocketPath string `hcl:"workload_api_socket_path,optional"`
 }
 
 // providersBlock holds the Providers HCL block body.
@@ -104,6 +118,25 @@ func NewHarvesterConfig(c *Config) (*harvester.Config, error) {
 	}
 	hc.GaladrielServerAddress = serverTCPAddress
 
+	if c.Harvester.MetricsAddress != "" {
+		metricsAddress, err := net.ResolveTCPAddr(constants.TCPProtocol, c.Harvester.MetricsAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve metrics address: %v", err)
+		}
+		hc.MetricsAddress = metricsAddress
+	}
+
+	if c.Harvester.Tracing != nil {
+		hc.Tracing = c.Harvester.Tracing.ToTelemetryConfig(constants.GaladrielHarvesterName)
+	}
+
+	if c.Harvester.SVIDOnboarding != nil {
+		hc.SVIDOnboarding, err = newSVIDOnboardingConfig(c.Harvester.SVIDOnboarding, trustDomain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SVID onboarding configuration: %v", err)
+		}
+	}
+
 	logLevel, err := logrus.ParseLevel(c.Harvester.LogLevel)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse log level: %v", err)
@@ -113,7 +146,9 @@ func NewHarvesterConfig(c *Config) (*harvester.Config, error) {
 	hc.Logger = logger.WithField(telemetry.SubsystemName, telemetry.Harvester)
 
 	hc.DataDir = c.Harvester.DataDir
+	hc.InstanceID = c.Harvester.InstanceID
 	hc.ServerTrustBundlePath = c.Harvester.ServerTrustBundlePath
+	hc.ServerName = c.Harvester.ServerName
 
 	hc.ProvidersConfig, err = catalog.ProvidersConfigsFromHCLBody(c.Providers.Body)
 	if err != nil {
@@ -123,6 +158,42 @@ func NewHarvesterConfig(c *Config) (*harvester.Config, error) {
 	return hc, nil
 }
 
+func newSVIDOnboardingConfig(c *svidOnboardingConfig, td spiffeid.TrustDomain) (*harvester.SVIDOnboardingConfig, error) {
+	sc := &harvester.SVIDOnboardingConfig{Source: c.Source}
+
+	if c.SPIFFEID != "" {
+		id, err := spiffeid.FromString(c.SPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SPIFFE ID: %v", err)
+		}
+		if !id.MemberOf(td) {
+			return nil, fmt.Errorf("SPIFFE ID %q is not a member of trust domain %q", id, td)
+		}
+		sc.SPIFFEID = id
+	}
+
+	switch c.Source {
+	case harvester.SVIDSourceSpireServer:
+		if sc.SPIFFEID.IsZero() {
+			return nil, errors.New("spiffe_id is required by the spire_server source")
+		}
+	case harvester.SVIDSourceWorkloadAPI:
+		socketPath := c.WorkloadAPISocketPath
+		if socketPath == "" {
+			socketPath = defaultWorkloadAPISocketPath
+		}
+		addr, err := util.GetUnixAddrWithAbsPath(socketPath)
+		if err != nil {
+			return nil, err
+		}
+		sc.WorkloadAPISocketPath = addr
+	default:
+		return nil, fmt.Errorf("unknown source %q, expected %q or %q", c.Source, harvester.SVIDSourceSpireServer, harvester.SVIDSourceWorkloadAPI)
+	}
+
+	return sc, nil
+}
+
 func newConfig(configBytes []byte) (*Config, error) {
 	var config Config
 
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"net"
 	"testing"
 
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/harvester"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,10 +20,24 @@ var hclConfig = `harvester {
     spire_socket_path = "/tmp/api.sock"
     galadriel_server_address = "localhost:7000"
     server_trust_bundle_path = "root_ca.crt"
+    server_name = "galadriel.example.org"
     federated_bundles_poll_interval = "2h"
     spire_bundle_poll_interval = "1h"
     log_level = "DEBUG"
 	data_dir = "/test"
+	instance_id = "spire-server-1"
+	metrics_address = "127.0.0.1:9989"
+
+	tracing {
+		exporter = "file"
+		file_path = "/tmp/traces.json"
+		sample_ratio = 0.5
+	}
+
+	svid_onboarding {
+		source = "spire_server"
+		spiffe_id = "spiffe://example.org/galadriel-harvester"
+	}
 }
 `
 
@@ -30,6 +48,7 @@ func (fakeReader) Read(p []byte) (n int, err error) {
 }
 
 func TestNew(t *testing.T) {
+	sampleRatio := 0.5
 	tests := []struct {
 		name     string
 		config   io.Reader
@@ -46,10 +65,22 @@ func TestNew(t *testing.T) {
 					SpireSocketPath:              "/tmp/api.sock",
 					GaladrielServerAddress:       "localhost:7000",
 					ServerTrustBundlePath:        "root_ca.crt",
+					ServerName:                   "galadriel.example.org",
 					FederatedBundlesPollInterval: "2h",
 					SpireBundlePollInterval:      "1h",
 					LogLevel:                     "DEBUG",
 					DataDir:                      "/test",
+					InstanceID:                   "spire-server-1",
+					MetricsAddress:               "127.0.0.1:9989",
+					Tracing: &cli.TracingConfig{
+						Exporter:    "file",
+						FilePath:    "/tmp/traces.json",
+						SampleRatio: &sampleRatio,
+					},
+					SVIDOnboarding: &svidOnboardingConfig{
+						Source:   "spire_server",
+						SPIFFEID: "spiffe://example.org/galadriel-harvester",
+					},
 				},
 			},
 		},
@@ -116,3 +147,58 @@ spire_bundle_poll_interval = "30m"
 		})
 	}
 }
+
+func TestNewSVIDOnboardingConfig(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	harvesterID := spiffeid.RequireFromPath(td, "/galadriel-harvester")
+
+	tests := []struct {
+		name     string
+		config   *svidOnboardingConfig
+		expected *harvester.SVIDOnboardingConfig
+		err      string
+	}{
+		{
+			name:     "spire_server",
+			config:   &svidOnboardingConfig{Source: "spire_server", SPIFFEID: harvesterID.String()},
+			expected: &harvester.SVIDOnboardingConfig{Source: harvester.SVIDSourceSpireServer, SPIFFEID: harvesterID},
+		},
+		{
+			name:   "workload_api_defaults",
+			config: &svidOnboardingConfig{Source: "workload_api"},
+			expected: &harvester.SVIDOnboardingConfig{
+				Source:                harvester.SVIDSourceWorkloadAPI,
+				WorkloadAPISocketPath: &net.UnixAddr{Net: "unix", Name: defaultWorkloadAPISocketPath},
+			},
+		},
+		{
+			name:   "err_spire_server_without_spiffe_id",
+			config: &svidOnboardingConfig{Source: "spire_server"},
+			err:    "spiffe_id is required by the spire_server source",
+		},
+		{
+			name:   "err_spiffe_id_of_other_trust_domain",
+			config: &svidOnboardingConfig{Source: "spire_server", SPIFFEID: "spiffe://other.org/galadriel-harvester"},
+			err:    `SPIFFE ID "spiffe://other.org/galadriel-harvester" is not a member of trust domain "example.org"`,
+		},
+		{
+			name:   "err_unknown_source",
+			config: &svidOnboardingConfig{Source: "disk"},
+			err:    `unknown source "disk"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := newSVIDOnboardingConfig(tt.config, td)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Nil(t, sc)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sc)
+		})
+	}
+}
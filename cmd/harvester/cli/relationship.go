@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/HewlettPackard/galadriel/cmd/harvester/util"
@@ -130,6 +131,68 @@ before denying it.
 	},
 }
 
+var consentsRelationshipCmd = &cobra.Command{
+	Use:   "consents",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show the signed consents of a relationship",
+	Long: `
+The 'consents' command shows the consent statements signed by both trust domains of a relationship,
+as stored by the Galadriel Server.
+
+The signature of each statement is verified by this Harvester using its configured bundle verifiers,
+which allows proving the consent of the other trust domain independently of the Galadriel Server.
+`,
+	Example: "relationship consents --relationshipID <relationshipID>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		idStr, err := cmd.Flags().GetString(cli.RelationshipIDFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get relationship ID flag: %v", err)
+		}
+		relID, err := uuid.Parse(idStr)
+		if err != nil {
+			return fmt.Errorf("cannot parse relationship ID: %v", err)
+		}
+
+		client, err := util.NewUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		consents, err := client.GetRelationshipConsents(ctx, relID)
+		if err != nil {
+			return err
+		}
+
+		if len(consents) == 0 {
+			fmt.Println("No signed consents found")
+			return nil
+		}
+
+		fmt.Println()
+		for _, c := range consents {
+			verified := "yes"
+			if !c.Verified {
+				verified = "no"
+				if c.VerificationError != nil {
+					verified = fmt.Sprintf("no (%s)", *c.VerificationError)
+				}
+			}
+			fmt.Printf("Consent:\n  Trust Domain: %s\n  Consent Status: %s\n  Updated At: %s\n  Verified: %s\n\n",
+				c.TrustDomainName, c.ConsentStatus, c.UpdatedAt.Format(time.RFC3339), verified)
+		}
+
+		return nil
+	},
+}
+
 func modifyRelationship(cmd *cobra.Command, args []string, action api.ConsentStatus) error {
 	socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
 	if err != nil {
@@ -174,6 +237,7 @@ func init() {
 	relationshipCmd.AddCommand(listRelationshipCmd)
 	relationshipCmd.AddCommand(approveRelationshipCmd)
 	relationshipCmd.AddCommand(denyRelationshipCmd)
+	relationshipCmd.AddCommand(consentsRelationshipCmd)
 
 	approveRelationshipCmd.Flags().StringP(cli.RelationshipIDFlagName, "r", "", "Relationship ID to approve")
 	err := approveRelationshipCmd.MarkFlagRequired(cli.RelationshipIDFlagName)
@@ -187,6 +251,12 @@ func init() {
 		fmt.Printf("cannot mark relationshipID flag as required: %v", err)
 	}
 
+	consentsRelationshipCmd.Flags().StringP(cli.RelationshipIDFlagName, "r", "", "Relationship ID to show the consents of")
+	err = consentsRelationshipCmd.MarkFlagRequired(cli.RelationshipIDFlagName)
+	if err != nil {
+		fmt.Printf("cannot mark relationshipID flag as required: %v", err)
+	}
+
 	listRelationshipCmd.Flags().StringP(cli.ConsentStatusFlagName, "s", "", fmt.Sprintf("Consent status to filter relationships by. Valid values: %s", strings.Join(validConsentStatusValues, ", ")))
 	listRelationshipCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		status, err := cmd.Flags().GetString(cli.ConsentStatusFlagName)
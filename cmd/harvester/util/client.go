@@ -23,6 +23,7 @@ const (
 type HarvesterAPIClient interface {
 	GetRelationships(context.Context, api.ConsentStatus) ([]*entity.Relationship, error)
 	UpdateRelationship(context.Context, uuid.UUID, api.ConsentStatus) (*entity.Relationship, error)
+	GetRelationshipConsents(context.Context, uuid.UUID) ([]*admin.RelationshipConsent, error)
 }
 
 type harvesterAPIClient struct {
@@ -105,3 +106,23 @@ func (h harvesterAPIClient) UpdateRelationship(ctx context.Context, relationship
 
 	return rel, nil
 }
+
+func (h harvesterAPIClient) GetRelationshipConsents(ctx context.Context, relationshipID uuid.UUID) ([]*admin.RelationshipConsent, error) {
+	res, err := h.client.GetRelationshipConsents(ctx, relationshipID)
+	if err != nil {
+		return nil, fmt.Errorf(errFailedRequest, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var consents []*admin.RelationshipConsent
+	if err := json.Unmarshal(body, &consents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal relationship consents: %v", err)
+	}
+
+	return consents, nil
+}
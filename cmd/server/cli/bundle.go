@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Manage the trust bundles of trust domains without a Harvester",
+	Long: `
+The 'bundle' command is used for managing the trust bundles received out of band, e.g. from
+air-gapped trust domains that can't run a Harvester. A bundle set by an admin is validated like
+the bundles uploaded by Harvesters and is admin-managed from then on: the uploads of the
+Harvesters of its trust domain are refused until the bundle is deleted.
+`,
+}
+
+var setBundleCmd = &cobra.Command{
+	Use:   "set",
+	Args:  cobra.ExactArgs(0),
+	Short: "Set the trust bundle of a trust domain",
+	Long: `
+The 'set' command uploads the SPIFFE bundle in the given file as the bundle of the trust domain,
+replacing the stored one. A signature and the signing certificate can be attached, to be verified
+by the bundle verifiers of the server.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		req, err := bundleRequestFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bundle, err := client.SetTrustDomainBundle(ctx, trustDomainName, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Bundle of trust domain %q set (verification: %s)\n", bundle.TrustDomainName, bundle.VerificationStatus)
+
+		return nil
+	},
+}
+
+var showBundleCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show the trust bundle of a trust domain",
+	Long:  `The 'show' command shows the stored trust bundle of a trust domain and whether it is admin-managed.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bundle, err := client.GetTrustDomainBundle(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf("%s\n", bundleConsoleString(bundle))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var deleteBundleCmd = &cobra.Command{
+	Use:   "delete",
+	Args:  cobra.ExactArgs(0),
+	Short: "Delete the admin-managed trust bundle of a trust domain",
+	Long: `The 'delete' command deletes the admin-managed trust bundle of a trust domain, so that its
+Harvester can upload it again. Bundles uploaded by Harvesters can't be deleted.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err = client.DeleteTrustDomainBundle(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Bundle of trust domain %q deleted\n", trustDomainName)
+
+		return nil
+	},
+}
+
+func bundleRequestFromFlags(cmd *cobra.Command) (*admin.PutTrustDomainBundleRequest, error) {
+	bundlePath, err := cmd.Flags().GetString(cli.BundleFileFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get bundle file flag: %v", err)
+	}
+
+	signaturePath, err := cmd.Flags().GetString(cli.SignatureFileFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get signature flag: %v", err)
+	}
+
+	certPath, err := cmd.Flags().GetString(cli.SigningCertificateFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get signing certificate flag: %v", err)
+	}
+
+	return newBundleRequest(bundlePath, signaturePath, certPath)
+}
+
+// newBundleRequest reads the bundle in the given file, and the optional raw signature and PEM signing certificate
+// chain, and encodes them like the bundle uploads of the Harvesters.
+func newBundleRequest(bundlePath, signaturePath, certPath string) (*admin.PutTrustDomainBundleRequest, error) {
+	if signaturePath == "" && certPath != "" || signaturePath != "" && certPath == "" {
+		return nil, fmt.Errorf("the signature and the signing certificate must be set together")
+	}
+
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read bundle: %v", err)
+	}
+
+	req := &admin.PutTrustDomainBundleRequest{
+		TrustBundle: string(data),
+		Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest(data)),
+	}
+
+	if signaturePath != "" {
+		signature, err := os.ReadFile(signaturePath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read signature: %v", err)
+		}
+
+		chain, err := cryptoutil.LoadCertificates(certPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load signing certificate: %v", err)
+		}
+		var der []byte
+		for _, cert := range chain {
+			der = append(der, cert.Raw...)
+		}
+
+		sig := encoding.EncodeToBase64(signature)
+		cert := encoding.EncodeToBase64(der)
+		req.Signature = &sig
+		req.SigningCertificate = &cert
+	}
+
+	return req, nil
+}
+
+func bundleConsoleString(b *admin.TrustDomainBundle) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Bundle:\n%sTrust Domain: %s", indent, b.TrustDomainName)
+	fmt.Fprintf(&sb, "\n%sDigest: %s", indent, b.Digest)
+	fmt.Fprintf(&sb, "\n%sAdmin Managed: %t", indent, b.AdminManaged)
+	fmt.Fprintf(&sb, "\n%sVerification: %s", indent, b.VerificationStatus)
+	fmt.Fprintf(&sb, "\n%sUpdated At: %s", indent, b.UpdatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "\n%sTrust Bundle: %s", indent, b.TrustBundle)
+
+	return sb.String()
+}
+
+func init() {
+	RootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(setBundleCmd)
+	bundleCmd.AddCommand(showBundleCmd)
+	bundleCmd.AddCommand(deleteBundleCmd)
+
+	for _, cmd := range []*cobra.Command{setBundleCmd, showBundleCmd, deleteBundleCmd} {
+		cmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The trust domain name.")
+		if err := cmd.MarkFlagRequired(cli.TrustDomainFlagName); err != nil {
+			fmt.Printf(errMarkFlagAsRequired, cli.TrustDomainFlagName, err)
+		}
+	}
+
+	setBundleCmd.Flags().StringP(cli.BundleFileFlagName, "f", "", "The path to the SPIFFE bundle of the trust domain, in JSON format.")
+	err := setBundleCmd.MarkFlagRequired(cli.BundleFileFlagName)
+	if err != nil {
+		fmt.Printf(errMarkFlagAsRequired, cli.BundleFileFlagName, err)
+	}
+
+	setBundleCmd.Flags().StringP(cli.SignatureFileFlagName, "s", "", "The path to the raw signature of the bundle file.")
+	setBundleCmd.Flags().StringP(cli.SigningCertificateFlagName, "c", "", "The path to the PEM certificate chain of the signing key, leaf first.")
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/jmhodges/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBundleRequest(t *testing.T) {
+	dir := t.TempDir()
+	bundle := []byte(`{"keys":[]}`)
+	bundlePath := filepath.Join(dir, "bundle.json")
+	require.NoError(t, os.WriteFile(bundlePath, bundle, 0600))
+
+	t.Run("Unsigned bundle", func(t *testing.T) {
+		req, err := newBundleRequest(bundlePath, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, string(bundle), req.TrustBundle)
+		assert.Equal(t, encoding.EncodeToBase64(cryptoutil.CalculateDigest(bundle)), req.Digest)
+		assert.Nil(t, req.Signature)
+		assert.Nil(t, req.SigningCertificate)
+	})
+
+	t.Run("Signed bundle", func(t *testing.T) {
+		cert, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+		certPath := filepath.Join(dir, "signing.crt")
+		require.NoError(t, os.WriteFile(certPath, cryptoutil.EncodeCertificate(cert), 0600))
+		signaturePath := filepath.Join(dir, "bundle.sig")
+		require.NoError(t, os.WriteFile(signaturePath, []byte("signature"), 0600))
+
+		req, err := newBundleRequest(bundlePath, signaturePath, certPath)
+		require.NoError(t, err)
+		require.NotNil(t, req.Signature)
+		assert.Equal(t, encoding.EncodeToBase64([]byte("signature")), *req.Signature)
+		require.NotNil(t, req.SigningCertificate)
+		assert.Equal(t, encoding.EncodeToBase64(cert.Raw), *req.SigningCertificate)
+	})
+
+	t.Run("Signature without signing certificate", func(t *testing.T) {
+		_, err := newBundleRequest(bundlePath, filepath.Join(dir, "bundle.sig"), "")
+		assert.EqualError(t, err, "the signature and the signing certificate must be set together")
+	})
+
+	t.Run("Missing bundle file", func(t *testing.T) {
+		_, err := newBundleRequest(filepath.Join(dir, "missing.json"), "", "")
+		assert.ErrorContains(t, err, "cannot read bundle")
+	})
+}
+
+func TestBundleConsoleString(t *testing.T) {
+	b := &admin.TrustDomainBundle{
+		TrustDomainName:    "td1.org",
+		TrustBundle:        `{"keys":[]}`,
+		Digest:             "ZGlnZXN0",
+		AdminManaged:       true,
+		VerificationStatus: "skipped",
+		UpdatedAt:          time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	expected := "Bundle:\n" +
+		"  Trust Domain: td1.org\n" +
+		"  Digest: ZGlnZXN0\n" +
+		"  Admin Managed: true\n" +
+		"  Verification: skipped\n" +
+		"  Updated At: 2023-07-01T10:00:00Z\n" +
+		"  Trust Bundle: {\"keys\":[]}"
+	assert.Equal(t, expected, bundleConsoleString(b))
+}
@@ -4,17 +4,24 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/server"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleendpoint"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
 	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
+	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
 const (
@@ -30,10 +37,72 @@ type Config struct {
 }
 
 type serverConfig struct {
-	ListenAddress string `hcl:"listen_address,optional"`
-	ListenPort    int    `hcl:"listen_port,optional"`
-	SocketPath    string `hcl:"socket_path,optional"`
-	LogLevel      string `hcl:"log_level,optional"`
+	ListenAddress  string `hcl:"listen_address,optional"`
+	ListenPort     int    `hcl:"listen_port,optional"`
+	SocketPath     string `hcl:"socket_path,optional"`
+	LogLevel       string `hcl:"log_level,optional"`
+	MetricsAddress string `hcl:"metrics_address,optional"`
+
+	Tracing       *cli.TracingConfig   `hcl:"tracing,block"`
+	Notifications *notificationsConfig `hcl:"notifications,block"`
+	BundlePolicy  *bundlePolicyConfig  `hcl:"bundle_policy,block"`
+
+	HighAvailability *highAvailabilityConfig `hcl:"high_availability,block"`
+	BundleEndpoint   *bundleEndpointConfig   `hcl:"bundle_endpoint,block"`
+	TLS              *tlsConfig              `hcl:"tls,block"`
+}
+
+// tlsConfig holds the HCL block of the TLS certificate presented to the Harvesters.
+type tlsConfig struct {
+	DNSNames       []string `hcl:"dns_names,optional"`
+	IPAddresses    []string `hcl:"ip_addresses,optional"`
+	TTL            string   `hcl:"ttl,optional"`
+	CertFilePath   string   `hcl:"cert_file_path,optional"`
+	KeyFilePath    string   `hcl:"key_file_path,optional"`
+	ReloadInterval string   `hcl:"reload_interval,optional"`
+}
+
+// bundleEndpointConfig holds the SPIFFE bundle endpoint HCL block.
+type bundleEndpointConfig struct {
+	ListenAddress string `hcl:"listen_address"`
+	Profile       string `hcl:"profile"`
+	CertFilePath  string `hcl:"cert_file_path,optional"`
+	KeyFilePath   string `hcl:"key_file_path,optional"`
+	SPIFFEID      string `hcl:"spiffe_id,optional"`
+}
+
+// highAvailabilityConfig holds the high availability HCL block.
+type highAvailabilityConfig struct {
+	PollInterval     string   `hcl:"poll_interval,optional"`
+	JWTKeyID         string   `hcl:"jwt_key_id,optional"`
+	RetiredJWTKeyIDs []string `hcl:"retired_jwt_key_ids,optional"`
+}
+
+// bundlePolicyConfig holds the bundle validation policy HCL block.
+type bundlePolicyConfig struct {
+	MinRSAKeySize        int      `hcl:"min_rsa_key_size,optional"`
+	MinECKeySize         int      `hcl:"min_ec_key_size,optional"`
+	AllowedKeyAlgorithms []string `hcl:"allowed_key_algorithms,optional"`
+	MaxX509Authorities   int      `hcl:"max_x509_authorities,optional"`
+	MaxJWTAuthorities    int      `hcl:"max_jwt_authorities,optional"`
+	MaxAuthoritySize     int      `hcl:"max_authority_size,optional"`
+	MaxBundleSize        int      `hcl:"max_bundle_size,optional"`
+}
+
+// notificationsConfig holds the webhook notifications HCL block.
+type notificationsConfig struct {
+	MaxAttempts    int              `hcl:"max_attempts,optional"`
+	InitialBackoff string           `hcl:"initial_backoff,optional"`
+	MaxBackoff     string           `hcl:"max_backoff,optional"`
+	Webhooks       []*webhookConfig `hcl:"webhook,block"`
+}
+
+type webhookConfig struct {
+	Name         string   `hcl:",label"`
+	URL          string   `hcl:"url"`
+	Secret       string   `hcl:"secret"`
+	Events       []string `hcl:"events,optional"`
+	TrustDomains []string `hcl:"trust_domains,optional"`
 }
 
 // providersBlock holds the Providers HCL block body.
@@ -76,6 +145,50 @@ func NewServerConfig(c *Config) (*server.Config, error) {
 
 	sc.LocalAddress = socketAddr
 
+	if c.Server.MetricsAddress != "" {
+		metricsAddr, err := net.ResolveTCPAddr(constants.TCPProtocol, c.Server.MetricsAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve metrics address %s: %w", c.Server.MetricsAddress, err)
+		}
+		sc.MetricsAddress = metricsAddr
+	}
+
+	if c.Server.Tracing != nil {
+		sc.Tracing = c.Server.Tracing.ToTelemetryConfig(constants.GaladrielServerName)
+	}
+
+	if c.Server.Notifications != nil {
+		sc.Notifications, err = c.Server.Notifications.toNotificationConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse notifications configuration: %w", err)
+		}
+	}
+
+	if c.Server.BundlePolicy != nil {
+		sc.BundlePolicy = c.Server.BundlePolicy.toBundlePolicyConfig()
+	}
+
+	if c.Server.HighAvailability != nil {
+		sc.HighAvailability, err = c.Server.HighAvailability.toHighAvailabilityConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse high availability configuration: %w", err)
+		}
+	}
+
+	if c.Server.BundleEndpoint != nil {
+		sc.BundleEndpoint, err = c.Server.BundleEndpoint.toBundleEndpointConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bundle endpoint configuration: %w", err)
+		}
+	}
+
+	if c.Server.TLS != nil {
+		sc.TLS, err = c.Server.TLS.toTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse TLS configuration: %w", err)
+		}
+	}
+
 	logLevel, err := logrus.ParseLevel(c.Server.LogLevel)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse log level %s: %w", c.Server.LogLevel, err)
@@ -126,3 +239,148 @@ func (c *Config) setDefaults() {
 		c.Server.LogLevel = constants.DefaultLogLevel
 	}
 }
+
+func (c *notificationsConfig) toNotificationConfig() (*notification.Config, error) {
+	nc := &notification.Config{
+		MaxAttempts: c.MaxAttempts,
+	}
+
+	if c.InitialBackoff != "" {
+		initialBackoff, err := time.ParseDuration(c.InitialBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse initial backoff: %v", err)
+		}
+		nc.InitialBackoff = initialBackoff
+	}
+
+	if c.MaxBackoff != "" {
+		maxBackoff, err := time.ParseDuration(c.MaxBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse max backoff: %v", err)
+		}
+		nc.MaxBackoff = maxBackoff
+	}
+
+	for _, w := range c.Webhooks {
+		webhook := &notification.WebhookConfig{
+			Name:   w.Name,
+			URL:    w.URL,
+			Secret: w.Secret,
+		}
+
+		for _, e := range w.Events {
+			webhook.Events = append(webhook.Events, notification.EventType(e))
+		}
+
+		for _, name := range w.TrustDomains {
+			td, err := spiffeid.TrustDomainFromString(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trust domain %q for webhook %q: %v", name, w.Name, err)
+			}
+			webhook.TrustDomains = append(webhook.TrustDomains, td)
+		}
+
+		nc.Webhooks = append(nc.Webhooks, webhook)
+	}
+
+	return nc, nil
+}
+
+func (c *bundlePolicyConfig) toBundlePolicyConfig() *bundlepolicy.Config {
+	return &bundlepolicy.Config{
+		MinRSAKeySize:        c.MinRSAKeySize,
+		MinECKeySize:         c.MinECKeySize,
+		AllowedKeyAlgorithms: c.AllowedKeyAlgorithms,
+		MaxX509Authorities:   c.MaxX509Authorities,
+		MaxJWTAuthorities:    c.MaxJWTAuthorities,
+		MaxAuthoritySize:     c.MaxAuthoritySize,
+		MaxBundleSize:        c.MaxBundleSize,
+	}
+}
+
+func (c *highAvailabilityConfig) toHighAvailabilityConfig() (*server.HighAvailabilityConfig, error) {
+	hc := &server.HighAvailabilityConfig{
+		JWTKeyID:         c.JWTKeyID,
+		RetiredJWTKeyIDs: c.RetiredJWTKeyIDs,
+	}
+
+	if c.PollInterval != "" {
+		pollInterval, err := time.ParseDuration(c.PollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse poll interval: %v", err)
+		}
+		hc.PollInterval = pollInterval
+	}
+
+	return hc, nil
+}
+
+func (c *bundleEndpointConfig) toBundleEndpointConfig() (*bundleendpoint.Config, error) {
+	addr, err := net.ResolveTCPAddr(constants.TCPProtocol, c.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve listen address %s: %v", c.ListenAddress, err)
+	}
+
+	bc := &bundleendpoint.Config{
+		Address:      addr,
+		Profile:      bundleendpoint.Profile(c.Profile),
+		CertFilePath: c.CertFilePath,
+		KeyFilePath:  c.KeyFilePath,
+	}
+
+	if c.SPIFFEID != "" {
+		bc.SPIFFEID, err = spiffeid.FromString(c.SPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPIFFE ID %q: %v", c.SPIFFEID, err)
+		}
+	}
+
+	return bc, nil
+}
+
+func (c *tlsConfig) toTLSConfig() (*endpoints.TLSConfig, error) {
+	if (c.CertFilePath == "") != (c.KeyFilePath == "") {
+		return nil, errors.New("cert_file_path and key_file_path must be set together")
+	}
+
+	tc := &endpoints.TLSConfig{
+		DNSNames:     c.DNSNames,
+		CertFilePath: c.CertFilePath,
+		KeyFilePath:  c.KeyFilePath,
+	}
+
+	if c.CertFilePath != "" {
+		// the SANs and lifetime of the certificate are set by its issuer
+		if len(c.DNSNames) > 0 || len(c.IPAddresses) > 0 || c.TTL != "" {
+			return nil, errors.New("dns_names, ip_addresses and ttl cannot be set along with cert_file_path")
+		}
+	} else if c.ReloadInterval != "" {
+		return nil, errors.New("reload_interval requires cert_file_path")
+	}
+
+	for _, ip := range c.IPAddresses {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, fmt.Errorf("invalid IP address %q", ip)
+		}
+		tc.IPAddresses = append(tc.IPAddresses, parsed)
+	}
+
+	if c.TTL != "" {
+		ttl, err := time.ParseDuration(c.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ttl: %v", err)
+		}
+		tc.TTL = ttl
+	}
+
+	if c.ReloadInterval != "" {
+		reloadInterval, err := time.ParseDuration(c.ReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reload interval: %v", err)
+		}
+		tc.ReloadInterval = reloadInterval
+	}
+
+	return tc, nil
+}
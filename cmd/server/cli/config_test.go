@@ -4,12 +4,21 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"net"
 	"testing"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/server"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleendpoint"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +28,51 @@ var hclConfigWithProviders = `server {
     listen_port = "2222"
     socket_path = "/tmp/api.sock"
 	log_level = "DEBUG"
+	metrics_address = "127.0.0.1:9988"
+
+	tracing {
+		exporter = "otlp"
+		endpoint = "localhost:4318"
+		insecure = true
+	}
+
+	notifications {
+		max_attempts = 3
+		initial_backoff = "2s"
+		max_backoff = "30s"
+
+		webhook "ops" {
+			url = "https://hooks.example.org/galadriel"
+			secret = "s3cr3t"
+			events = ["relationship.created", "bundle.updated"]
+			trust_domains = ["td-a.org"]
+		}
+	}
+
+	bundle_policy {
+		min_rsa_key_size = 3072
+		allowed_key_algorithms = ["rsa"]
+		max_x509_authorities = 4
+		max_bundle_size = 65536
+	}
+
+	high_availability {
+		poll_interval = "2s"
+		jwt_key_id = "jwt-2"
+		retired_jwt_key_ids = ["jwt-1"]
+	}
+
+	bundle_endpoint {
+		listen_address = "127.0.0.1:8443"
+		profile = "https_spiffe"
+		spiffe_id = "spiffe://galadriel.org/bundle-endpoint"
+	}
+
+	tls {
+		dns_names = ["galadriel.example.org"]
+		ip_addresses = ["10.0.0.1"]
+		ttl = "6h"
+	}
 }
 
 providers {
@@ -53,10 +107,51 @@ func TestNew(t *testing.T) {
 			config: bytes.NewBuffer([]byte(hclConfigWithProviders)),
 			expected: &Config{
 				Server: &serverConfig{
-					ListenAddress: "127.0.0.1",
-					ListenPort:    2222,
-					SocketPath:    "/tmp/api.sock",
-					LogLevel:      "DEBUG",
+					ListenAddress:  "127.0.0.1",
+					ListenPort:     2222,
+					SocketPath:     "/tmp/api.sock",
+					LogLevel:       "DEBUG",
+					MetricsAddress: "127.0.0.1:9988",
+					Tracing: &cli.TracingConfig{
+						Exporter: "otlp",
+						Endpoint: "localhost:4318",
+						Insecure: true,
+					},
+					Notifications: &notificationsConfig{
+						MaxAttempts:    3,
+						InitialBackoff: "2s",
+						MaxBackoff:     "30s",
+						Webhooks: []*webhookConfig{
+							{
+								Name:         "ops",
+								URL:          "https://hooks.example.org/galadriel",
+								Secret:       "s3cr3t",
+								Events:       []string{"relationship.created", "bundle.updated"},
+								TrustDomains: []string{"td-a.org"},
+							},
+						},
+					},
+					BundlePolicy: &bundlePolicyConfig{
+						MinRSAKeySize:        3072,
+						AllowedKeyAlgorithms: []string{"rsa"},
+						MaxX509Authorities:   4,
+						MaxBundleSize:        65536,
+					},
+					HighAvailability: &highAvailabilityConfig{
+						PollInterval:     "2s",
+						JWTKeyID:         "jwt-2",
+						RetiredJWTKeyIDs: []string{"jwt-1"},
+					},
+					BundleEndpoint: &bundleEndpointConfig{
+						ListenAddress: "127.0.0.1:8443",
+						Profile:       "https_spiffe",
+						SPIFFEID:      "spiffe://galadriel.org/bundle-endpoint",
+					},
+					TLS: &tlsConfig{
+						DNSNames:    []string{"galadriel.example.org"},
+						IPAddresses: []string{"10.0.0.1"},
+						TTL:         "6h",
+					},
 				},
 			},
 		},
@@ -120,3 +215,146 @@ func TestParseHCLConfigWithProviders(t *testing.T) {
 
 	require.NotNil(t, config.Providers)
 }
+
+func TestNewServerConfigTracing(t *testing.T) {
+	config, err := ParseConfig(bytes.NewBufferString(hclConfigWithProviders))
+	require.NoError(t, err)
+
+	sc, err := NewServerConfig(config)
+	require.NoError(t, err)
+
+	require.NotNil(t, sc.Tracing)
+	assert.Equal(t, constants.GaladrielServerName, sc.Tracing.ServiceName)
+	assert.Equal(t, "otlp", sc.Tracing.Exporter)
+	assert.Equal(t, "localhost:4318", sc.Tracing.OTLPEndpoint)
+	assert.True(t, sc.Tracing.OTLPInsecure)
+	assert.Equal(t, 1.0, sc.Tracing.SampleRatio)
+	assert.Equal(t, "127.0.0.1:9988", sc.MetricsAddress.String())
+}
+
+func TestNewServerConfigBundlePolicy(t *testing.T) {
+	config, err := ParseConfig(bytes.NewBufferString(hclConfigWithProviders))
+	require.NoError(t, err)
+
+	sc, err := NewServerConfig(config)
+	require.NoError(t, err)
+
+	expected := &bundlepolicy.Config{
+		MinRSAKeySize:        3072,
+		AllowedKeyAlgorithms: []string{bundlepolicy.KeyAlgorithmRSA},
+		MaxX509Authorities:   4,
+		MaxBundleSize:        65536,
+	}
+	assert.Equal(t, expected, sc.BundlePolicy)
+}
+
+func TestNewServerConfigHighAvailability(t *testing.T) {
+	config, err := ParseConfig(bytes.NewBufferString(hclConfigWithProviders))
+	require.NoError(t, err)
+
+	sc, err := NewServerConfig(config)
+	require.NoError(t, err)
+
+	expected := &server.HighAvailabilityConfig{
+		PollInterval:     2 * time.Second,
+		JWTKeyID:         "jwt-2",
+		RetiredJWTKeyIDs: []string{"jwt-1"},
+	}
+	assert.Equal(t, expected, sc.HighAvailability)
+
+	config.Server.HighAvailability.PollInterval = "often"
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, "failed to parse high availability configuration: failed to parse poll interval")
+}
+
+func TestNewServerConfigBundleEndpoint(t *testing.T) {
+	config, err := ParseConfig(bytes.NewBufferString(hclConfigWithProviders))
+	require.NoError(t, err)
+
+	sc, err := NewServerConfig(config)
+	require.NoError(t, err)
+
+	expected := &bundleendpoint.Config{
+		Address:  &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8443},
+		Profile:  bundleendpoint.ProfileHTTPSSPIFFE,
+		SPIFFEID: spiffeid.RequireFromString("spiffe://galadriel.org/bundle-endpoint"),
+	}
+	assert.Equal(t, expected, sc.BundleEndpoint)
+
+	config.Server.BundleEndpoint.SPIFFEID = "galadriel.org/bundle-endpoint"
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, "failed to parse bundle endpoint configuration: invalid SPIFFE ID")
+
+	config.Server.BundleEndpoint.ListenAddress = "127.0.0.1:port"
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, "failed to parse bundle endpoint configuration: failed to resolve listen address")
+}
+
+func TestNewServerConfigTLS(t *testing.T) {
+	config, err := ParseConfig(bytes.NewBufferString(hclConfigWithProviders))
+	require.NoError(t, err)
+
+	sc, err := NewServerConfig(config)
+	require.NoError(t, err)
+
+	expected := &endpoints.TLSConfig{
+		DNSNames:    []string{"galadriel.example.org"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		TTL:         6 * time.Hour,
+	}
+	assert.Equal(t, expected, sc.TLS)
+
+	config.Server.TLS.IPAddresses = []string{"galadriel.example.org"}
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, `failed to parse TLS configuration: invalid IP address "galadriel.example.org"`)
+
+	config.Server.TLS = &tlsConfig{CertFilePath: "./server.crt", KeyFilePath: "./server.key", ReloadInterval: "30s"}
+	sc, err = NewServerConfig(config)
+	require.NoError(t, err)
+	expected = &endpoints.TLSConfig{
+		CertFilePath:   "./server.crt",
+		KeyFilePath:    "./server.key",
+		ReloadInterval: 30 * time.Second,
+	}
+	assert.Equal(t, expected, sc.TLS)
+
+	config.Server.TLS.DNSNames = []string{"galadriel.example.org"}
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, "failed to parse TLS configuration: dns_names, ip_addresses and ttl cannot be set along with cert_file_path")
+
+	config.Server.TLS = &tlsConfig{CertFilePath: "./server.crt"}
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, "failed to parse TLS configuration: cert_file_path and key_file_path must be set together")
+}
+
+func TestNewServerConfigNotifications(t *testing.T) {
+	config, err := ParseConfig(bytes.NewBufferString(hclConfigWithProviders))
+	require.NoError(t, err)
+
+	sc, err := NewServerConfig(config)
+	require.NoError(t, err)
+
+	expected := &notification.Config{
+		MaxAttempts:    3,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     30 * time.Second,
+		Webhooks: []*notification.WebhookConfig{
+			{
+				Name:         "ops",
+				URL:          "https://hooks.example.org/galadriel",
+				Secret:       "s3cr3t",
+				Events:       []notification.EventType{notification.EventRelationshipCreated, notification.EventBundleUpdated},
+				TrustDomains: []spiffeid.TrustDomain{spiffeid.RequireTrustDomainFromString("td-a.org")},
+			},
+		},
+	}
+	assert.Equal(t, expected, sc.Notifications)
+
+	config.Server.Notifications.Webhooks[0].TrustDomains = []string{"Invalid TD"}
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, `failed to parse notifications configuration: invalid trust domain "Invalid TD" for webhook "ops"`)
+
+	config.Server.Notifications.MaxBackoff = "forever"
+	_, err = NewServerConfig(config)
+	require.ErrorContains(t, err, "failed to parse notifications configuration: failed to parse max backoff")
+}
@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/spf13/cobra"
+)
+
+var externalTrustDomainCmd = &cobra.Command{
+	Use:   "external",
+	Short: "Manage external trust domains",
+	Long: `
+The 'external' command is used for managing the trust domains that don't run a Harvester.
+The Galadriel Server fetches the bundle of an external trust domain periodically from its
+SPIFFE bundle endpoint, validates it against the bundle policy, and distributes it to the
+trust domains it has relationships with, like the bundles uploaded by Harvesters.
+`,
+}
+
+var setExternalTrustDomainCmd = &cobra.Command{
+	Use:   "set",
+	Args:  cobra.ExactArgs(0),
+	Short: "Configure a trust domain as external",
+	Long: `
+The 'set' command configures the SPIFFE bundle endpoint the bundle of a trust domain is
+fetched from. The 'https_web' profile authenticates the endpoint with Web PKI, while the
+'https_spiffe' profile authenticates it with the SPIFFE ID of the endpoint server and the
+bundle of the trust domain, which requires a bootstrap bundle until the first one is fetched.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		req, err := externalTrustDomainRequestFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		externalTD, err := client.SetExternalTrustDomain(ctx, trustDomainName, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Trust Domain %q fetches its bundle from %s\n", externalTD.TrustDomainName, externalTD.BundleEndpointUrl)
+
+		return nil
+	},
+}
+
+var showExternalTrustDomainCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show the bundle endpoint and refresh status of an external trust domain",
+	Long:  `The 'show' command shows the bundle endpoint of an external trust domain and the outcome of the last fetch of its bundle.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		externalTD, err := client.GetExternalTrustDomain(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf("%s\n", externalTrustDomainConsoleString(externalTD))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var deleteExternalTrustDomainCmd = &cobra.Command{
+	Use:   "delete",
+	Args:  cobra.ExactArgs(0),
+	Short: "Stop fetching the bundle of an external trust domain",
+	Long: `The 'delete' command stops fetching the bundle of an external trust domain.
+The trust domain and its last fetched bundle are kept.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err = client.DeleteExternalTrustDomain(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Trust Domain %q is no longer external\n", trustDomainName)
+
+		return nil
+	},
+}
+
+func externalTrustDomainRequestFromFlags(cmd *cobra.Command) (*admin.PutExternalTrustDomainRequest, error) {
+	url, err := cmd.Flags().GetString(cli.BundleEndpointURLFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get bundle endpoint URL flag: %v", err)
+	}
+
+	profile, err := cmd.Flags().GetString(cli.BundleEndpointProfileFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get bundle endpoint profile flag: %v", err)
+	}
+
+	endpointID, err := cmd.Flags().GetString(cli.EndpointSPIFFEIDFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get endpoint SPIFFE ID flag: %v", err)
+	}
+
+	bootstrapBundlePath, err := cmd.Flags().GetString(cli.BootstrapBundleFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get bootstrap bundle flag: %v", err)
+	}
+
+	req := &admin.PutExternalTrustDomainRequest{
+		BundleEndpointUrl:     url,
+		BundleEndpointProfile: profile,
+	}
+	if endpointID != "" {
+		req.EndpointSpiffeId = &endpointID
+	}
+	if bootstrapBundlePath != "" {
+		data, err := os.ReadFile(bootstrapBundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read bootstrap bundle: %v", err)
+		}
+		bootstrapBundle := string(data)
+		req.BootstrapBundle = &bootstrapBundle
+	}
+
+	return req, nil
+}
+
+func externalTrustDomainConsoleString(e *admin.ExternalTrustDomain) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "External Trust Domain:\n%sTrust Domain: %s", indent, e.TrustDomainName)
+	fmt.Fprintf(&sb, "\n%sBundle Endpoint URL: %s", indent, e.BundleEndpointUrl)
+	fmt.Fprintf(&sb, "\n%sBundle Endpoint Profile: %s", indent, e.BundleEndpointProfile)
+	if e.EndpointSpiffeId != nil {
+		fmt.Fprintf(&sb, "\n%sEndpoint SPIFFE ID: %s", indent, *e.EndpointSpiffeId)
+	}
+
+	switch {
+	case e.LastRefreshAt == nil:
+		fmt.Fprintf(&sb, "\n%sLast Refresh: never", indent)
+	case e.LastRefreshError != nil:
+		fmt.Fprintf(&sb, "\n%sLast Refresh: %s (failed: %s)", indent, e.LastRefreshAt.Format(time.RFC3339), *e.LastRefreshError)
+	default:
+		fmt.Fprintf(&sb, "\n%sLast Refresh: %s (succeeded)", indent, e.LastRefreshAt.Format(time.RFC3339))
+	}
+
+	return sb.String()
+}
+
+func init() {
+	trustDomainCmd.AddCommand(externalTrustDomainCmd)
+	externalTrustDomainCmd.AddCommand(setExternalTrustDomainCmd)
+	externalTrustDomainCmd.AddCommand(showExternalTrustDomainCmd)
+	externalTrustDomainCmd.AddCommand(deleteExternalTrustDomainCmd)
+
+	for _, cmd := range []*cobra.Command{setExternalTrustDomainCmd, showExternalTrustDomainCmd, deleteExternalTrustDomainCmd} {
+		cmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The trust domain name.")
+		if err := cmd.MarkFlagRequired(cli.TrustDomainFlagName); err != nil {
+			fmt.Printf(errMarkFlagAsRequired, cli.TrustDomainFlagName, err)
+		}
+	}
+
+	setExternalTrustDomainCmd.Flags().StringP(cli.BundleEndpointURLFlagName, "u", "", "The HTTPS URL of the SPIFFE bundle endpoint of the trust domain.")
+	err := setExternalTrustDomainCmd.MarkFlagRequired(cli.BundleEndpointURLFlagName)
+	if err != nil {
+		fmt.Printf(errMarkFlagAsRequired, cli.BundleEndpointURLFlagName, err)
+	}
+
+	setExternalTrustDomainCmd.Flags().StringP(cli.BundleEndpointProfileFlagName, "p", "https_spiffe", "The SPIFFE bundle endpoint profile, 'https_web' or 'https_spiffe'.")
+	setExternalTrustDomainCmd.Flags().StringP(cli.EndpointSPIFFEIDFlagName, "i", "", "The SPIFFE ID of the bundle endpoint server, required by the 'https_spiffe' profile.")
+	setExternalTrustDomainCmd.Flags().StringP(cli.BootstrapBundleFlagName, "b", "", "The path to the SPIFFE bundle of the trust domain, in JSON format.")
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalTrustDomainConsoleString(t *testing.T) {
+	endpointID := "spiffe://td1.org/spire/server"
+	refreshedAt := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	refreshError := "failed to fetch bundle: connection refused"
+
+	t.Run("Never refreshed", func(t *testing.T) {
+		e := &admin.ExternalTrustDomain{TrustDomainName: "td1.org", BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_web"}
+
+		expected := "External Trust Domain:\n" +
+			"  Trust Domain: td1.org\n" +
+			"  Bundle Endpoint URL: https://bundle.td1.org\n" +
+			"  Bundle Endpoint Profile: https_web\n" +
+			"  Last Refresh: never"
+		assert.Equal(t, expected, externalTrustDomainConsoleString(e))
+	})
+
+	t.Run("Failed refresh", func(t *testing.T) {
+		e := &admin.ExternalTrustDomain{
+			TrustDomainName:       "td1.org",
+			BundleEndpointUrl:     "https://spire.td1.org:8443",
+			BundleEndpointProfile: "https_spiffe",
+			EndpointSpiffeId:      &endpointID,
+			LastRefreshAt:         &refreshedAt,
+			LastRefreshError:      &refreshError,
+		}
+
+		expected := "External Trust Domain:\n" +
+			"  Trust Domain: td1.org\n" +
+			"  Bundle Endpoint URL: https://spire.td1.org:8443\n" +
+			"  Bundle Endpoint Profile: https_spiffe\n" +
+			"  Endpoint SPIFFE ID: spiffe://td1.org/spire/server\n" +
+			"  Last Refresh: 2023-07-01T10:00:00Z (failed: failed to fetch bundle: connection refused)"
+		assert.Equal(t, expected, externalTrustDomainConsoleString(e))
+	})
+
+	t.Run("Successful refresh", func(t *testing.T) {
+		e := &admin.ExternalTrustDomain{TrustDomainName: "td1.org", BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_web", LastRefreshAt: &refreshedAt}
+
+		assert.Contains(t, externalTrustDomainConsoleString(e), "Last Refresh: 2023-07-01T10:00:00Z (succeeded)")
+	})
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/spf13/cobra"
+)
+
+var federationCmd = &cobra.Command{
+	Use:   "federation",
+	Short: "Inspect the state of the federation",
+	Long: `
+The 'federation' command is used for inspecting how the bundles of the registered trust
+domains are distributed across the federation.
+`,
+}
+
+var federationStatusCmd = &cobra.Command{
+	Use:   "status",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show the bundle distribution status of the federation",
+	Long: `
+The 'status' command shows, for every approved relationship, whether each side holds the
+latest bundle of its peer, as last reported by its Harvester, and for how long it has
+lagged behind. It also lists the bundles held by Harvesters that no approved relationship
+justifies.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		status, err := client.GetFederationStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf("%s\n", federationStatusConsoleString(status))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+func federationStatusConsoleString(status *admin.FederationStatus) string {
+	var sb strings.Builder
+
+	sb.WriteString("Relationships:")
+	if len(status.Relationships) == 0 {
+		fmt.Fprintf(&sb, "\n%snone", indent)
+	}
+	for _, r := range status.Relationships {
+		fmt.Fprintf(&sb, "\n%sRelationship ID: %s", indent, r.RelationshipId)
+		for _, d := range []*admin.BundleDistribution{r.TrustDomainA, r.TrustDomainB} {
+			// the side that does not trust its peer in a one-way relationship has no distribution
+			if d != nil {
+				fmt.Fprintf(&sb, "\n%s", bundleDistributionConsoleString(d))
+			}
+		}
+	}
+
+	sb.WriteString("\nUnexpected Bundles:")
+	if len(status.UnexpectedBundles) == 0 {
+		fmt.Fprintf(&sb, "\n%snone", indent)
+	}
+	for _, b := range status.UnexpectedBundles {
+		fmt.Fprintf(&sb, "\n%s%s holds the bundle of %s (reported at %s)", indent, b.TrustDomainName, b.FederatedTrustDomainName, b.ReportedAt.Format(time.RFC3339))
+	}
+
+	return sb.String()
+}
+
+func bundleDistributionConsoleString(d *admin.BundleDistribution) string {
+	state := "in sync"
+	switch {
+	case d.ReportedAt == nil:
+		state = "never reported"
+	case !d.InSync:
+		state = fmt.Sprintf("lagging for %s", time.Duration(d.LagSeconds)*time.Second)
+	}
+
+	return fmt.Sprintf("%s%sBundle of %s held by %s: %s", indent, indent, d.PeerTrustDomainName, d.TrustDomainName, state)
+}
+
+func init() {
+	RootCmd.AddCommand(federationCmd)
+	federationCmd.AddCommand(federationStatusCmd)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFederationStatusConsoleString(t *testing.T) {
+	t.Run("Empty federation", func(t *testing.T) {
+		expected := "Relationships:\n  none\nUnexpected Bundles:\n  none"
+		assert.Equal(t, expected, federationStatusConsoleString(&admin.FederationStatus{}))
+	})
+
+	t.Run("Federation with relationships, one-way relationships and unexpected bundles", func(t *testing.T) {
+		relID := uuid.MustParse("4a2a3a1e-7c4b-4b9e-9d7c-0c4c2e3b5a61")
+		oneWayRelID := uuid.MustParse("0b6e2f4c-3d1a-4f8e-a2c5-7e9d1b3f5a72")
+		reportedAt := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+		status := &admin.FederationStatus{
+			Relationships: []admin.RelationshipDistribution{
+				{
+					RelationshipId: relID,
+					TrustDomainA:   &admin.BundleDistribution{TrustDomainName: "td1.org", PeerTrustDomainName: "td2.org", ReportedAt: &reportedAt, InSync: true},
+					TrustDomainB:   &admin.BundleDistribution{TrustDomainName: "td2.org", PeerTrustDomainName: "td1.org", ReportedAt: &reportedAt, LagSeconds: 90},
+				},
+				{
+					RelationshipId: oneWayRelID,
+					TrustDomainB:   &admin.BundleDistribution{TrustDomainName: "td3.org", PeerTrustDomainName: "td1.org"},
+				},
+			},
+			UnexpectedBundles: []admin.UnexpectedBundle{
+				{TrustDomainName: "td2.org", FederatedTrustDomainName: "td3.org", ReportedAt: reportedAt},
+			},
+		}
+
+		expected := "Relationships:\n" +
+			"  Relationship ID: 4a2a3a1e-7c4b-4b9e-9d7c-0c4c2e3b5a61\n" +
+			"    Bundle of td2.org held by td1.org: in sync\n" +
+			"    Bundle of td1.org held by td2.org: lagging for 1m30s\n" +
+			"  Relationship ID: 0b6e2f4c-3d1a-4f8e-a2c5-7e9d1b3f5a72\n" +
+			"    Bundle of td1.org held by td3.org: never reported\n" +
+			"Unexpected Bundles:\n" +
+			"  td2.org holds the bundle of td3.org (reported at 2023-07-01T10:00:00Z)"
+		assert.Equal(t, expected, federationStatusConsoleString(status))
+	})
+}
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/spf13/cobra"
+)
+
+var federationGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Manage federation groups",
+	Long: `
+The 'group' command is used for managing federation groups. Every trust domain of a group
+is federated with every other member: the Galadriel Server creates the relationships between
+the members as they join, with the default consent of the group, and deletes them as they
+leave, unless another group still federates them. Relationships created with the
+'relationship' command are never changed by the groups.
+`,
+}
+
+var createFederationGroupCmd = &cobra.Command{
+	Use:   "create",
+	Args:  cobra.ExactArgs(0),
+	Short: "Create or update a federation group",
+	Long: `
+The 'create' command creates a federation group, or updates the description and default
+consent of an existing one. The default consent is the consent of both trust domains on the
+relationships created for the group, 'pending' or 'approved', and applies to the
+relationships created from then on.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		req, err := federationGroupRequestFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		group, err := client.SetFederationGroup(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Federation group %q stored with default consent %q\n", group.Name, group.DefaultConsent)
+
+		return nil
+	},
+}
+
+var listFederationGroupsCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "List the federation groups and their members",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		groups, err := client.ListFederationGroups(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(groups) == 0 {
+			fmt.Println("No federation groups found")
+			return nil
+		}
+
+		fmt.Println()
+		for _, g := range groups {
+			fmt.Printf("%s\n", federationGroupConsoleString(g))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+var showFederationGroupCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show a federation group and its members",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		groupName, err := cmd.Flags().GetString(cli.FederationGroupFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get federation group flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		group, err := client.GetFederationGroup(ctx, groupName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf("%s\n", federationGroupConsoleString(group))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var deleteFederationGroupCmd = &cobra.Command{
+	Use:   "delete",
+	Args:  cobra.ExactArgs(0),
+	Short: "Delete a federation group",
+	Long: `The 'delete' command deletes a federation group. The relationships created for the group
+are deleted, unless another group still federates their trust domains.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		groupName, err := cmd.Flags().GetString(cli.FederationGroupFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get federation group flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err = client.DeleteFederationGroup(ctx, groupName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Federation group %q deleted\n", groupName)
+
+		return nil
+	},
+}
+
+var addFederationGroupMemberCmd = &cobra.Command{
+	Use:   "add",
+	Args:  cobra.ExactArgs(0),
+	Short: "Add a trust domain to a federation group",
+	Long: `The 'add' command adds a trust domain to a federation group. Its relationships with the
+other members are created shortly after.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFederationGroupMemberCmd(cmd, func(ctx context.Context, client util.GaladrielAPIClient, groupName, trustDomainName string) (*admin.FederationGroup, error) {
+			return client.AddFederationGroupMember(ctx, groupName, trustDomainName)
+		})
+	},
+}
+
+var removeFederationGroupMemberCmd = &cobra.Command{
+	Use:   "remove",
+	Args:  cobra.ExactArgs(0),
+	Short: "Remove a trust domain from a federation group",
+	Long: `The 'remove' command removes a trust domain from a federation group. Its relationships with
+the other members are deleted shortly after, unless another group still federates them.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFederationGroupMemberCmd(cmd, func(ctx context.Context, client util.GaladrielAPIClient, groupName, trustDomainName string) (*admin.FederationGroup, error) {
+			return client.RemoveFederationGroupMember(ctx, groupName, trustDomainName)
+		})
+	},
+}
+
+func runFederationGroupMemberCmd(cmd *cobra.Command, call func(context.Context, util.GaladrielAPIClient, string, string) (*admin.FederationGroup, error)) error {
+	socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+	if err != nil {
+		return fmt.Errorf("cannot get socket path flag: %v", err)
+	}
+
+	groupName, err := cmd.Flags().GetString(cli.FederationGroupFlagName)
+	if err != nil {
+		return fmt.Errorf("cannot get federation group flag: %v", err)
+	}
+
+	trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+	if err != nil {
+		return fmt.Errorf("cannot get trust domain flag: %v", err)
+	}
+
+	client, err := util.NewGaladrielUDSClient(socketPath, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	group, err := call(ctx, client, groupName, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("%s\n", federationGroupConsoleString(group))
+	fmt.Println()
+
+	return nil
+}
+
+func federationGroupRequestFromFlags(cmd *cobra.Command) (*admin.PutFederationGroupRequest, error) {
+	groupName, err := cmd.Flags().GetString(cli.FederationGroupFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get federation group flag: %v", err)
+	}
+
+	description, err := cmd.Flags().GetString(cli.GroupDescriptionFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get description flag: %v", err)
+	}
+
+	defaultConsent, err := cmd.Flags().GetString(cli.DefaultConsentFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get default consent flag: %v", err)
+	}
+
+	req := &admin.PutFederationGroupRequest{Name: groupName}
+	if description != "" {
+		req.Description = &description
+	}
+	if defaultConsent != "" {
+		consent := api.ConsentStatus(defaultConsent)
+		req.DefaultConsent = &consent
+	}
+
+	return req, nil
+}
+
+func federationGroupConsoleString(g *admin.FederationGroup) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Federation Group:\n%sName: %s", indent, g.Name)
+	if g.Description != nil {
+		fmt.Fprintf(&sb, "\n%sDescription: %s", indent, *g.Description)
+	}
+	fmt.Fprintf(&sb, "\n%sDefault Consent: %s", indent, g.DefaultConsent)
+	fmt.Fprintf(&sb, "\n%sCreated At: %s", indent, g.CreatedAt.Format(time.RFC3339))
+
+	sb.WriteString("\n" + indent + "Members:")
+	if len(g.Members) == 0 {
+		fmt.Fprintf(&sb, "\n%s%snone", indent, indent)
+	}
+	for _, m := range g.Members {
+		fmt.Fprintf(&sb, "\n%s%s%s", indent, indent, m)
+	}
+
+	return sb.String()
+}
+
+func init() {
+	federationCmd.AddCommand(federationGroupCmd)
+	federationGroupCmd.AddCommand(createFederationGroupCmd)
+	federationGroupCmd.AddCommand(listFederationGroupsCmd)
+	federationGroupCmd.AddCommand(showFederationGroupCmd)
+	federationGroupCmd.AddCommand(deleteFederationGroupCmd)
+	federationGroupCmd.AddCommand(addFederationGroupMemberCmd)
+	federationGroupCmd.AddCommand(removeFederationGroupMemberCmd)
+
+	groupCmds := []*cobra.Command{createFederationGroupCmd, showFederationGroupCmd, deleteFederationGroupCmd, addFederationGroupMemberCmd, removeFederationGroupMemberCmd}
+	for _, cmd := range groupCmds {
+		cmd.Flags().StringP(cli.FederationGroupFlagName, "g", "", "The federation group name.")
+		if err := cmd.MarkFlagRequired(cli.FederationGroupFlagName); err != nil {
+			fmt.Printf(errMarkFlagAsRequired, cli.FederationGroupFlagName, err)
+		}
+	}
+
+	for _, cmd := range []*cobra.Command{addFederationGroupMemberCmd, removeFederationGroupMemberCmd} {
+		cmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The trust domain name.")
+		if err := cmd.MarkFlagRequired(cli.TrustDomainFlagName); err != nil {
+			fmt.Printf(errMarkFlagAsRequired, cli.TrustDomainFlagName, err)
+		}
+	}
+
+	createFederationGroupCmd.Flags().StringP(cli.GroupDescriptionFlagName, "d", "", "The description of the federation group.")
+	createFederationGroupCmd.Flags().StringP(cli.DefaultConsentFlagName, "c", "", "The consent of both trust domains on the relationships created for the group, 'pending' (default) or 'approved'.")
+}
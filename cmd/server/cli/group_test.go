@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFederationGroupConsoleString(t *testing.T) {
+	createdAt := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Without members", func(t *testing.T) {
+		g := &admin.FederationGroup{Name: "mesh", DefaultConsent: api.Pending, CreatedAt: createdAt}
+
+		expected := "Federation Group:\n" +
+			"  Name: mesh\n" +
+			"  Default Consent: pending\n" +
+			"  Created At: 2023-07-01T10:00:00Z\n" +
+			"  Members:\n" +
+			"    none"
+		assert.Equal(t, expected, federationGroupConsoleString(g))
+	})
+
+	t.Run("With members", func(t *testing.T) {
+		description := "partner organizations"
+		g := &admin.FederationGroup{
+			Name:           "partners",
+			Description:    &description,
+			DefaultConsent: api.Approved,
+			CreatedAt:      createdAt,
+			Members:        []api.TrustDomainName{"td1.org", "td2.org"},
+		}
+
+		expected := "Federation Group:\n" +
+			"  Name: partners\n" +
+			"  Description: partner organizations\n" +
+			"  Default Consent: approved\n" +
+			"  Created At: 2023-07-01T10:00:00Z\n" +
+			"  Members:\n" +
+			"    td1.org\n" +
+			"    td2.org"
+		assert.Equal(t, expected, federationGroupConsoleString(g))
+	})
+}
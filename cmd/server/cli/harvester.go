@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
+	"github.com/spf13/cobra"
+)
+
+const indent = "  "
+
+var harvesterCmd = &cobra.Command{
+	Use:   "harvester",
+	Short: "Inspect the Harvesters of the registered trust domains",
+	Long: `
+The 'harvester' command is used for inspecting the Harvesters connected to the Galadriel 
+Server. Every authenticated call of a Harvester is recorded along with the version and 
+configuration it reports.
+`,
+}
+
+var listHarvesterCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "List the Harvester instances of all trust domains",
+	Long: `
+The 'list' command allows you to retrieve the Harvester instances of each registered trust 
+domain, including the time each was last seen, its source address, version, poll intervals, 
+the expiry of its JWT and the outcome of its last bundle upload.
+
+Trust domains whose Harvester was never seen, or has been silent longer than the 
+silent threshold, are flagged as silent.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		thresholdStr, err := cmd.Flags().GetString(cli.SilentThresholdFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get silent threshold flag: %v", err)
+		}
+
+		threshold, err := strconv.ParseInt(thresholdStr, 10, 32)
+		if err != nil || threshold <= 0 {
+			return errors.New("invalid silent threshold")
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		harvesters, err := client.ListHarvesters(ctx, int32(threshold))
+		if err != nil {
+			return err
+		}
+
+		if len(harvesters) == 0 {
+			fmt.Println("No harvesters registered.")
+			return nil
+		}
+
+		fmt.Println()
+		for _, h := range harvesters {
+			fmt.Printf("%s\n", harvesterConsoleString(h))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+func harvesterConsoleString(h *admin.Harvester) string {
+	status := "active"
+	if h.Silent {
+		status = "silent"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Harvester:\n%sTrust Domain: %s\n%sStatus: %s", indent, h.TrustDomainName, indent, status)
+	if h.LastSeenAt == nil {
+		fmt.Fprintf(&sb, "\n%sLast Seen: never", indent)
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "\n%sInstance: %s", indent, valueOrUnknown(h.InstanceId))
+	fmt.Fprintf(&sb, "\n%sLast Seen: %s", indent, h.LastSeenAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "\n%sSource Address: %s", indent, valueOrUnknown(h.SourceAddress))
+	fmt.Fprintf(&sb, "\n%sVersion: %s", indent, valueOrUnknown(h.Version))
+	fmt.Fprintf(&sb, "\n%sSPIRE Bundle Poll Interval: %s", indent, secondsOrUnknown(h.SpireBundlePollInterval))
+	fmt.Fprintf(&sb, "\n%sFederated Bundles Poll Interval: %s", indent, secondsOrUnknown(h.FederatedBundlesPollInterval))
+	if h.TokenExpiresAt != nil {
+		fmt.Fprintf(&sb, "\n%sToken Expires At: %s", indent, h.TokenExpiresAt.Format(time.RFC3339))
+	}
+	if h.LastBundleUploadAt != nil {
+		fmt.Fprintf(&sb, "\n%sLast Bundle Upload: %s (%s)", indent, h.LastBundleUploadAt.Format(time.RFC3339), valueOrUnknown(h.LastBundleUploadStatus))
+	}
+
+	return sb.String()
+}
+
+func valueOrUnknown(s *string) string {
+	if s == nil || *s == "" {
+		return "unknown"
+	}
+	return *s
+}
+
+func secondsOrUnknown(seconds *int64) string {
+	if seconds == nil {
+		return "unknown"
+	}
+	return (time.Duration(*seconds) * time.Second).String()
+}
+
+func init() {
+	RootCmd.AddCommand(harvesterCmd)
+	harvesterCmd.AddCommand(listHarvesterCmd)
+
+	listHarvesterCmd.Flags().StringP(cli.SilentThresholdFlagName, "", fmt.Sprintf("%d", endpoints.DefaultHarvesterSilentThreshold), "Time in seconds without calls after which a Harvester is flagged as silent")
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHarvesterConsoleString(t *testing.T) {
+	t.Run("Harvester never seen", func(t *testing.T) {
+		h := &admin.Harvester{TrustDomainName: "td1.org", Silent: true}
+
+		assert.Equal(t, "Harvester:\n  Trust Domain: td1.org\n  Status: silent\n  Last Seen: never", harvesterConsoleString(h))
+	})
+
+	t.Run("Harvester seen", func(t *testing.T) {
+		lastSeen := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+		expiresAt := lastSeen.Add(time.Hour)
+		address := "10.0.0.1:4321"
+		interval := int64(30)
+		instanceID := "spire-server-1"
+		uploadStatus := "stale"
+		h := &admin.Harvester{
+			TrustDomainName:         "td1.org",
+			InstanceId:              &instanceID,
+			LastSeenAt:              &lastSeen,
+			SourceAddress:           &address,
+			SpireBundlePollInterval: &interval,
+			TokenExpiresAt:          &expiresAt,
+			LastBundleUploadAt:      &lastSeen,
+			LastBundleUploadStatus:  &uploadStatus,
+		}
+
+		expected := "Harvester:\n" +
+			"  Trust Domain: td1.org\n" +
+			"  Status: active\n" +
+			"  Instance: spire-server-1\n" +
+			"  Last Seen: 2023-07-01T10:00:00Z\n" +
+			"  Source Address: 10.0.0.1:4321\n" +
+			"  Version: unknown\n" +
+			"  SPIRE Bundle Poll Interval: 30s\n" +
+			"  Federated Bundles Poll Interval: unknown\n" +
+			"  Token Expires At: 2023-07-01T11:00:00Z\n" +
+			"  Last Bundle Upload: 2023-07-01T10:00:00Z (stale)"
+		assert.Equal(t, expected, harvesterConsoleString(h))
+	})
+}
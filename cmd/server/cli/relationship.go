@@ -32,6 +32,9 @@ The 'create' command initiates a new federation relationship between two specifi
 
 Importantly, the initiation of a federation relationship is a two-party agreement: it needs to be approved by both trust domains involved.
 
+By default both trust domains receive the bundle of their peer. A one-way relationship, created with the direction
+'a_trusts_b' or 'b_trusts_a', only distributes the bundle of the trusted trust domain to the trusting one.
+
 ` + relationshipCommonText + `
 `,
 	Args: cobra.ExactArgs(0),
@@ -75,18 +78,29 @@ Importantly, the initiation of a federation relationship is a two-party agreemen
 			return err
 		}
 
+		d, err := cmd.Flags().GetString(cli.DirectionFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get direction flag: %v", err)
+		}
+
+		direction, err := entity.ParseRelationshipDirection(d)
+		if err != nil {
+			return err
+		}
+
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
 		_, err = client.CreateRelationship(ctx, &entity.Relationship{
 			TrustDomainAName: trustDomain1,
 			TrustDomainBName: trustDomain2,
+			Direction:        direction,
 		})
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Relationship created between trust domains %q and %q\n", tdA, tdB)
+		fmt.Printf("Relationship created between trust domains %q and %q (%s)\n", tdA, tdB, direction)
 		return nil
 	},
 }
@@ -141,4 +155,5 @@ func init() {
 
 	createRelationshipCmd.Flags().StringP(cli.TrustDomainAFlagName, "a", "", "The name of a SPIFFE trust domain to participate in the relationship.")
 	createRelationshipCmd.Flags().StringP(cli.TrustDomainBFlagName, "b", "", "The name of a SPIFFE trust domain to participate in the relationship.")
+	createRelationshipCmd.Flags().StringP(cli.DirectionFlagName, "d", string(entity.RelationshipBidirectional), "The direction of the relationship, 'bidirectional', 'a_trusts_b' or 'b_trusts_a'.")
 }
@@ -5,16 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 
 	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
 	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
 var tokenCmd = &cobra.Command{
-	Use: "token",
+	Use:   "token",
+	Short: "Manage join tokens",
 }
 
 var generateTokenCmd = &cobra.Command{
@@ -33,6 +38,10 @@ ensure compatibility and successful connection.
 
 Please exercise caution when handling and sharing join tokens, as they grant access to the 
 trust domain and should only be shared with authorized individuals or entities.
+
+A join token is single-use unless '--maxUses' allows more onboardings, for instance to
+reinstall Harvesters on autoscaled hosts, and can be bound with '--sourceCIDR' to the network
+the Harvesters onboard from.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
@@ -55,6 +64,24 @@ trust domain and should only be shared with authorized individuals or entities.
 			return errors.New("invalid TTL")
 		}
 
+		maxUses, err := cmd.Flags().GetInt32(cli.MaxUsesFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get max uses flag: %v", err)
+		}
+
+		sourceCIDR, err := cmd.Flags().GetString(cli.SourceCIDRFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get source CIDR flag: %v", err)
+		}
+
+		params := &admin.GetJoinTokenParams{Ttl: int32(ttl)}
+		if maxUses != 0 {
+			params.MaxUses = &maxUses
+		}
+		if sourceCIDR != "" {
+			params.SourceCidr = &sourceCIDR
+		}
+
 		client, err := util.NewGaladrielUDSClient(socketPath, nil)
 		if err != nil {
 			return err
@@ -63,7 +90,7 @@ trust domain and should only be shared with authorized individuals or entities.
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		joinToken, err := client.GetJoinToken(ctx, trustDomain, int32(ttl))
+		joinToken, err := client.GetJoinToken(ctx, trustDomain, params)
 		if err != nil {
 			return err
 		}
@@ -73,6 +100,162 @@ trust domain and should only be shared with authorized individuals or entities.
 	},
 }
 
+var listTokensCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "List the join tokens",
+	Long: `
+The 'list' command lists the join tokens, newest first, optionally only those of a trust domain.
+The tokens themselves are never shown, only their first characters, their ID and state.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomain, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var trustDomainName *string
+		if trustDomain != "" {
+			trustDomainName = &trustDomain
+		}
+		tokens, err := client.ListJoinTokens(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		if len(tokens) == 0 {
+			fmt.Println("No join tokens found")
+			return nil
+		}
+
+		fmt.Println()
+		for _, jt := range tokens {
+			fmt.Printf("%s\n", joinTokenConsoleString(jt))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+var showTokenCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show the state of a join token",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		joinTokenID, err := joinTokenIDFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		jt, err := client.GetJoinTokenByID(ctx, joinTokenID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf("%s\n", joinTokenConsoleString(jt))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke",
+	Args:  cobra.ExactArgs(0),
+	Short: "Revoke a join token",
+	Long: `
+The 'revoke' command deletes a join token, so that no Harvester can onboard with it anymore,
+for instance when it leaked. Harvesters that already onboarded with it are not affected.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		joinTokenID, err := joinTokenIDFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if err := client.RevokeJoinToken(ctx, joinTokenID); err != nil {
+			return err
+		}
+
+		fmt.Printf("Join token %q revoked\n", joinTokenID)
+
+		return nil
+	},
+}
+
+func joinTokenIDFromFlags(cmd *cobra.Command) (uuid.UUID, error) {
+	idStr, err := cmd.Flags().GetString(cli.JoinTokenIDFlagName)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("cannot get join token ID flag: %v", err)
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("cannot parse join token ID: %v", err)
+	}
+
+	return id, nil
+}
+
+func joinTokenConsoleString(jt *admin.JoinTokenInfo) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Join Token:\n%sID: %s", indent, jt.Id)
+	fmt.Fprintf(&sb, "\n%sTrust Domain: %s", indent, jt.TrustDomainName)
+	fmt.Fprintf(&sb, "\n%sToken Prefix: %s", indent, jt.TokenPrefix)
+	fmt.Fprintf(&sb, "\n%sStatus: %s", indent, jt.Status)
+	fmt.Fprintf(&sb, "\n%sUses: %d/%d", indent, jt.UseCount, jt.MaxUses)
+	if jt.SourceCidr != nil {
+		fmt.Fprintf(&sb, "\n%sSource CIDR: %s", indent, *jt.SourceCidr)
+	}
+	fmt.Fprintf(&sb, "\n%sExpires At: %s", indent, jt.ExpiresAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "\n%sCreated At: %s", indent, jt.CreatedAt.Format(time.RFC3339))
+
+	return sb.String()
+}
+
 func init() {
 	RootCmd.AddCommand(tokenCmd)
 
@@ -84,4 +267,19 @@ func init() {
 		fmt.Printf("Error marking trustDomain flag as required: %v\n", err)
 	}
 	generateTokenCmd.Flags().StringP(cli.TTLFlagName, "", fmt.Sprintf("%d", endpoints.DefaultTokenTTL), "Token TTL in seconds")
+	generateTokenCmd.Flags().Int32P(cli.MaxUsesFlagName, "", 1, "Number of Harvester onboardings the token allows")
+	generateTokenCmd.Flags().StringP(cli.SourceCIDRFlagName, "", "", "Network in CIDR notation the Harvesters must onboard from, any if not set")
+
+	tokenCmd.AddCommand(listTokensCmd)
+	tokenCmd.AddCommand(showTokenCmd)
+	tokenCmd.AddCommand(revokeTokenCmd)
+
+	listTokensCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "Only list the join tokens of this trust domain")
+
+	for _, cmd := range []*cobra.Command{showTokenCmd, revokeTokenCmd} {
+		cmd.Flags().StringP(cli.JoinTokenIDFlagName, "i", "", "The join token ID.")
+		if err := cmd.MarkFlagRequired(cli.JoinTokenIDFlagName); err != nil {
+			fmt.Printf(errMarkFlagAsRequired, cli.JoinTokenIDFlagName, err)
+		}
+	}
 }
@@ -2,7 +2,10 @@ package cli
 
 import (
 	"testing"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,3 +18,32 @@ func TestTokenCmd(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestJoinTokenConsoleString(t *testing.T) {
+	id := uuid.MustParse("5a8c3f4e-0d2b-4c1a-9f6e-7b3d2a1c0e9f")
+	createdAt := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	jt := &admin.JoinTokenInfo{
+		Id:              id,
+		TrustDomainName: "td1.org",
+		TokenPrefix:     "0f1e2d3c",
+		Status:          admin.JoinTokenStatusActive,
+		MaxUses:         5,
+		UseCount:        2,
+		ExpiresAt:       createdAt.Add(time.Hour),
+		CreatedAt:       createdAt,
+	}
+
+	expected := "Join Token:\n" +
+		"  ID: 5a8c3f4e-0d2b-4c1a-9f6e-7b3d2a1c0e9f\n" +
+		"  Trust Domain: td1.org\n" +
+		"  Token Prefix: 0f1e2d3c\n" +
+		"  Status: active\n" +
+		"  Uses: 2/5\n" +
+		"  Expires At: 2023-07-01T11:00:00Z\n" +
+		"  Created At: 2023-07-01T10:00:00Z"
+	assert.Equal(t, expected, joinTokenConsoleString(jt))
+
+	sourceCIDR := "10.0.0.0/8"
+	jt.SourceCidr = &sourceCIDR
+	assert.Contains(t, joinTokenConsoleString(jt), "\n  Uses: 2/5\n  Source CIDR: 10.0.0.0/8\n")
+}
@@ -3,10 +3,13 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/spf13/cobra"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
 var (
@@ -169,11 +172,6 @@ in the Galadriel Server.`,
 			return fmt.Errorf("cannot get trust domain flag: %v", err)
 		}
 
-		description, err := cmd.Flags().GetString(cli.TrustDomainDescriptionFlagName)
-		if err != nil {
-			return fmt.Errorf("cannot get description flag: %v", err)
-		}
-
 		client, err := util.NewGaladrielUDSClient(socketPath, nil)
 		if err != nil {
 			return err
@@ -182,7 +180,17 @@ in the Galadriel Server.`,
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		_, err = client.UpdateTrustDomainByName(ctx, trustDomainName, description)
+		// the trust domain settings are replaced as a whole, keep the ones that are not being updated
+		td, err := client.GetTrustDomainByName(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		if err := applyTrustDomainUpdateFlags(cmd, td); err != nil {
+			return err
+		}
+
+		_, err = client.UpdateTrustDomainByName(ctx, td)
 		if err != nil {
 			return err
 		}
@@ -219,8 +227,52 @@ func init() {
 	}
 
 	updateTrustDomainCmd.Flags().StringP(cli.TrustDomainDescriptionFlagName, "d", "", "The trust domain description.")
-	err = updateTrustDomainCmd.MarkFlagRequired(cli.TrustDomainDescriptionFlagName)
-	if err != nil {
-		fmt.Printf(errMarkFlagAsRequired, cli.TrustDomainDescriptionFlagName, err)
+	updateTrustDomainCmd.Flags().String(cli.HarvesterSPIFFEIDFlagName, "", "The SPIFFE ID the X509-SVID of a harvester must have to onboard without a join token. An empty value unpins it.")
+	updateTrustDomainCmd.Flags().String(cli.OnboardingBundleFlagName, "", "The path to the SPIFFE bundle, in JSON format, that verifies the X509-SVID of a harvester onboarding without a join token. An empty value unsets it.")
+}
+
+// applyTrustDomainUpdateFlags sets the trust domain settings given by the flags of the update command,
+// the settings whose flags are not given are left as they are.
+func applyTrustDomainUpdateFlags(cmd *cobra.Command, td *entity.TrustDomain) error {
+	flags := cmd.Flags()
+
+	if flags.Changed(cli.TrustDomainDescriptionFlagName) {
+		description, err := flags.GetString(cli.TrustDomainDescriptionFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get description flag: %v", err)
+		}
+		td.Description = description
+	}
+
+	if flags.Changed(cli.HarvesterSPIFFEIDFlagName) {
+		harvesterID, err := flags.GetString(cli.HarvesterSPIFFEIDFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get harvester SPIFFE ID flag: %v", err)
+		}
+		td.HarvesterSPIFFEID = spiffeid.ID{}
+		if harvesterID != "" {
+			id, err := spiffeid.FromString(harvesterID)
+			if err != nil {
+				return fmt.Errorf("invalid harvester SPIFFE ID: %v", err)
+			}
+			td.HarvesterSPIFFEID = id
+		}
 	}
+
+	if flags.Changed(cli.OnboardingBundleFlagName) {
+		bundlePath, err := flags.GetString(cli.OnboardingBundleFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get onboarding bundle flag: %v", err)
+		}
+		td.OnboardingBundle = nil
+		if bundlePath != "" {
+			data, err := os.ReadFile(bundlePath)
+			if err != nil {
+				return fmt.Errorf("cannot read onboarding bundle: %v", err)
+			}
+			td.OnboardingBundle = data
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/spf13/cobra"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTrustDomainUpdateFlags(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("td1.org")
+	harvesterID := spiffeid.RequireFromPath(td, "/galadriel-harvester")
+
+	newCmd := func(t *testing.T, args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String(cli.TrustDomainDescriptionFlagName, "", "")
+		cmd.Flags().String(cli.HarvesterSPIFFEIDFlagName, "", "")
+		cmd.Flags().String(cli.OnboardingBundleFlagName, "", "")
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	t.Run("Keeps the settings whose flags are not given", func(t *testing.T) {
+		etd := &entity.TrustDomain{Name: td, Description: "description", HarvesterSPIFFEID: harvesterID, OnboardingBundle: []byte("bundle")}
+
+		err := applyTrustDomainUpdateFlags(newCmd(t, "--trustDomainDescription", "updated"), etd)
+		require.NoError(t, err)
+		assert.Equal(t, "updated", etd.Description)
+		assert.Equal(t, harvesterID, etd.HarvesterSPIFFEID)
+		assert.Equal(t, []byte("bundle"), etd.OnboardingBundle)
+	})
+
+	t.Run("Sets the SVID onboarding settings", func(t *testing.T) {
+		bundlePath := filepath.Join(t.TempDir(), "bundle.json")
+		require.NoError(t, os.WriteFile(bundlePath, []byte(`{"keys":[]}`), 0600))
+		etd := &entity.TrustDomain{Name: td, Description: "description"}
+
+		err := applyTrustDomainUpdateFlags(newCmd(t, "--harvesterSpiffeID", harvesterID.String(), "--onboardingBundle", bundlePath), etd)
+		require.NoError(t, err)
+		assert.Equal(t, "description", etd.Description)
+		assert.Equal(t, harvesterID, etd.HarvesterSPIFFEID)
+		assert.Equal(t, []byte(`{"keys":[]}`), etd.OnboardingBundle)
+	})
+
+	t.Run("Unsets the SVID onboarding settings with empty values", func(t *testing.T) {
+		etd := &entity.TrustDomain{Name: td, HarvesterSPIFFEID: harvesterID, OnboardingBundle: []byte("bundle")}
+
+		err := applyTrustDomainUpdateFlags(newCmd(t, "--harvesterSpiffeID", "", "--onboardingBundle", ""), etd)
+		require.NoError(t, err)
+		assert.True(t, etd.HarvesterSPIFFEID.IsZero())
+		assert.Nil(t, etd.OnboardingBundle)
+	})
+
+	t.Run("Fails with an invalid harvester SPIFFE ID", func(t *testing.T) {
+		err := applyTrustDomainUpdateFlags(newCmd(t, "--harvesterSpiffeID", "not a spiffe id"), &entity.TrustDomain{Name: td})
+		assert.ErrorContains(t, err, "invalid harvester SPIFFE ID")
+	})
+}
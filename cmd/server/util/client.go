@@ -19,6 +19,12 @@ const (
 	errUnmarshalRelationships = "failed to unmarshal relationships: %v"
 	errUnmarshalTrustDomains  = "failed to unmarshal trust domain: %v"
 	errUnmarshalJoinToken     = "failed to unmarshal join token: %v"
+	errUnmarshalJoinTokens    = "failed to unmarshal join tokens: %v"
+	errUnmarshalHarvesters    = "failed to unmarshal harvesters: %v"
+	errUnmarshalFedStatus     = "failed to unmarshal federation status: %v"
+	errUnmarshalExternalTD    = "failed to unmarshal external trust domain: %v"
+	errUnmarshalBundle        = "failed to unmarshal bundle: %v"
+	errUnmarshalFedGroups     = "failed to unmarshal federation groups: %v"
 )
 
 // GaladrielAPIClient represents an API client for the Galadriel Server API.
@@ -27,11 +33,28 @@ type GaladrielAPIClient interface {
 	GetTrustDomainByName(context.Context, api.TrustDomainName) (*entity.TrustDomain, error)
 	ListTrustDomains(context.Context) ([]*entity.TrustDomain, error)
 	DeleteTrustDomainByName(context.Context, api.TrustDomainName) error
-	UpdateTrustDomainByName(context.Context, api.TrustDomainName, string) (*entity.TrustDomain, error)
+	UpdateTrustDomainByName(context.Context, *entity.TrustDomain) (*entity.TrustDomain, error)
 	CreateRelationship(context.Context, *entity.Relationship) (*entity.Relationship, error)
 	GetRelationshipByID(context.Context, uuid.UUID) (*entity.Relationship, error)
 	GetRelationships(context.Context, api.ConsentStatus, api.TrustDomainName) (*entity.Relationship, error)
-	GetJoinToken(context.Context, api.TrustDomainName, int32) (*entity.JoinToken, error)
+	GetJoinToken(context.Context, api.TrustDomainName, *admin.GetJoinTokenParams) (*entity.JoinToken, error)
+	ListJoinTokens(context.Context, *api.TrustDomainName) ([]*admin.JoinTokenInfo, error)
+	GetJoinTokenByID(context.Context, api.UUID) (*admin.JoinTokenInfo, error)
+	RevokeJoinToken(context.Context, api.UUID) error
+	ListHarvesters(context.Context, int32) ([]*admin.Harvester, error)
+	GetFederationStatus(context.Context) (*admin.FederationStatus, error)
+	SetExternalTrustDomain(context.Context, api.TrustDomainName, *admin.PutExternalTrustDomainRequest) (*admin.ExternalTrustDomain, error)
+	GetExternalTrustDomain(context.Context, api.TrustDomainName) (*admin.ExternalTrustDomain, error)
+	DeleteExternalTrustDomain(context.Context, api.TrustDomainName) error
+	SetTrustDomainBundle(context.Context, api.TrustDomainName, *admin.PutTrustDomainBundleRequest) (*admin.TrustDomainBundle, error)
+	GetTrustDomainBundle(context.Context, api.TrustDomainName) (*admin.TrustDomainBundle, error)
+	DeleteTrustDomainBundle(context.Context, api.TrustDomainName) error
+	SetFederationGroup(context.Context, *admin.PutFederationGroupRequest) (*admin.FederationGroup, error)
+	GetFederationGroup(context.Context, admin.FederationGroupName) (*admin.FederationGroup, error)
+	ListFederationGroups(context.Context) ([]*admin.FederationGroup, error)
+	DeleteFederationGroup(context.Context, admin.FederationGroupName) error
+	AddFederationGroupMember(context.Context, admin.FederationGroupName, api.TrustDomainName) (*admin.FederationGroup, error)
+	RemoveFederationGroupMember(context.Context, admin.FederationGroupName, api.TrustDomainName) (*admin.FederationGroup, error)
 }
 
 type galadrielAdminClient struct {
@@ -121,9 +144,10 @@ func (g *galadrielAdminClient) DeleteTrustDomainByName(ctx context.Context, trus
 	return nil
 }
 
-func (g *galadrielAdminClient) UpdateTrustDomainByName(ctx context.Context, trustDomainName api.TrustDomainName, description string) (*entity.TrustDomain, error) {
-	payload := api.TrustDomain{Name: trustDomainName, Description: &description}
-	res, err := g.client.PutTrustDomainByName(ctx, trustDomainName, payload)
+// UpdateTrustDomainByName replaces the settings of the trust domain with the ones of the given trust domain.
+func (g *galadrielAdminClient) UpdateTrustDomainByName(ctx context.Context, td *entity.TrustDomain) (*entity.TrustDomain, error) {
+	payload := api.TrustDomainFromEntity(td)
+	res, err := g.client.PutTrustDomainByName(ctx, td.Name.String(), *payload)
 	if err != nil {
 		return nil, fmt.Errorf(errorRequestFailed, err)
 	}
@@ -166,6 +190,10 @@ func (g *galadrielAdminClient) CreateTrustDomain(ctx context.Context, trustDomai
 
 func (g *galadrielAdminClient) CreateRelationship(ctx context.Context, rel *entity.Relationship) (*entity.Relationship, error) {
 	payload := admin.PutRelationshipJSONRequestBody{TrustDomainAName: rel.TrustDomainAName.String(), TrustDomainBName: rel.TrustDomainBName.String()}
+	if rel.Direction != "" {
+		direction := api.RelationshipDirection(rel.Direction)
+		payload.Direction = &direction
+	}
 	res, err := g.client.PutRelationship(ctx, payload)
 	if err != nil {
 		return nil, fmt.Errorf(errorRequestFailed, err)
@@ -227,8 +255,7 @@ func (g *galadrielAdminClient) GetRelationshipByID(ctx context.Context, relID uu
 	return relationship, nil
 }
 
-func (g *galadrielAdminClient) GetJoinToken(ctx context.Context, trustDomainName api.TrustDomainName, ttl int32) (*entity.JoinToken, error) {
-	params := &admin.GetJoinTokenParams{Ttl: ttl}
+func (g *galadrielAdminClient) GetJoinToken(ctx context.Context, trustDomainName api.TrustDomainName, params *admin.GetJoinTokenParams) (*entity.JoinToken, error) {
 	res, err := g.client.GetJoinToken(ctx, trustDomainName, params)
 	if err != nil {
 		return nil, fmt.Errorf(errorRequestFailed, err)
@@ -248,6 +275,316 @@ func (g *galadrielAdminClient) GetJoinToken(ctx context.Context, trustDomainName
 	return joinToken, nil
 }
 
+func (g *galadrielAdminClient) ListJoinTokens(ctx context.Context, trustDomainName *api.TrustDomainName) ([]*admin.JoinTokenInfo, error) {
+	params := &admin.ListJoinTokensParams{TrustDomainName: trustDomainName}
+	res, err := g.client.ListJoinTokens(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []*admin.JoinTokenInfo
+	if err = json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf(errUnmarshalJoinTokens, err)
+	}
+
+	return tokens, nil
+}
+
+func (g *galadrielAdminClient) GetJoinTokenByID(ctx context.Context, joinTokenID api.UUID) (*admin.JoinTokenInfo, error) {
+	res, err := g.client.GetJoinTokenByID(ctx, joinTokenID)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var token *admin.JoinTokenInfo
+	if err = json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf(errUnmarshalJoinToken, err)
+	}
+
+	return token, nil
+}
+
+func (g *galadrielAdminClient) RevokeJoinToken(ctx context.Context, joinTokenID api.UUID) error {
+	res, err := g.client.RevokeJoinToken(ctx, joinTokenID)
+	if err != nil {
+		return fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	_, err = httputil.ReadResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *galadrielAdminClient) ListHarvesters(ctx context.Context, silentThreshold int32) ([]*admin.Harvester, error) {
+	params := &admin.ListHarvestersParams{SilentThreshold: &silentThreshold}
+	res, err := g.client.ListHarvesters(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var harvesters []*admin.Harvester
+	if err = json.Unmarshal(body, &harvesters); err != nil {
+		return nil, fmt.Errorf(errUnmarshalHarvesters, err)
+	}
+
+	return harvesters, nil
+}
+
+func (g *galadrielAdminClient) GetFederationStatus(ctx context.Context) (*admin.FederationStatus, error) {
+	res, err := g.client.GetFederationStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *admin.FederationStatus
+	if err = json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf(errUnmarshalFedStatus, err)
+	}
+
+	return status, nil
+}
+
+func (g *galadrielAdminClient) SetExternalTrustDomain(ctx context.Context, trustDomainName api.TrustDomainName, req *admin.PutExternalTrustDomainRequest) (*admin.ExternalTrustDomain, error) {
+	res, err := g.client.PutExternalTrustDomain(ctx, trustDomainName, *req)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToExternalTrustDomain(body)
+}
+
+func (g *galadrielAdminClient) GetExternalTrustDomain(ctx context.Context, trustDomainName api.TrustDomainName) (*admin.ExternalTrustDomain, error) {
+	res, err := g.client.GetExternalTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToExternalTrustDomain(body)
+}
+
+func (g *galadrielAdminClient) DeleteExternalTrustDomain(ctx context.Context, trustDomainName api.TrustDomainName) error {
+	res, err := g.client.DeleteExternalTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	_, err = httputil.ReadResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *galadrielAdminClient) SetTrustDomainBundle(ctx context.Context, trustDomainName api.TrustDomainName, req *admin.PutTrustDomainBundleRequest) (*admin.TrustDomainBundle, error) {
+	res, err := g.client.PutTrustDomainBundle(ctx, trustDomainName, *req)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToTrustDomainBundle(body)
+}
+
+func (g *galadrielAdminClient) GetTrustDomainBundle(ctx context.Context, trustDomainName api.TrustDomainName) (*admin.TrustDomainBundle, error) {
+	res, err := g.client.GetTrustDomainBundle(ctx, trustDomainName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToTrustDomainBundle(body)
+}
+
+func (g *galadrielAdminClient) DeleteTrustDomainBundle(ctx context.Context, trustDomainName api.TrustDomainName) error {
+	res, err := g.client.DeleteTrustDomainBundle(ctx, trustDomainName)
+	if err != nil {
+		return fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	_, err = httputil.ReadResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *galadrielAdminClient) SetFederationGroup(ctx context.Context, req *admin.PutFederationGroupRequest) (*admin.FederationGroup, error) {
+	res, err := g.client.PutFederationGroup(ctx, *req)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToFederationGroup(body)
+}
+
+func (g *galadrielAdminClient) GetFederationGroup(ctx context.Context, groupName admin.FederationGroupName) (*admin.FederationGroup, error) {
+	res, err := g.client.GetFederationGroup(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToFederationGroup(body)
+}
+
+func (g *galadrielAdminClient) ListFederationGroups(ctx context.Context) ([]*admin.FederationGroup, error) {
+	res, err := g.client.ListFederationGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []*admin.FederationGroup
+	if err = json.Unmarshal(body, &groups); err != nil {
+		return nil, fmt.Errorf(errUnmarshalFedGroups, err)
+	}
+
+	return groups, nil
+}
+
+func (g *galadrielAdminClient) DeleteFederationGroup(ctx context.Context, groupName admin.FederationGroupName) error {
+	res, err := g.client.DeleteFederationGroup(ctx, groupName)
+	if err != nil {
+		return fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	_, err = httputil.ReadResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *galadrielAdminClient) AddFederationGroupMember(ctx context.Context, groupName admin.FederationGroupName, trustDomainName api.TrustDomainName) (*admin.FederationGroup, error) {
+	req := admin.PutFederationGroupMemberRequest{TrustDomainName: trustDomainName}
+	res, err := g.client.PutFederationGroupMember(ctx, groupName, req)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToFederationGroup(body)
+}
+
+func (g *galadrielAdminClient) RemoveFederationGroupMember(ctx context.Context, groupName admin.FederationGroupName, trustDomainName api.TrustDomainName) (*admin.FederationGroup, error) {
+	res, err := g.client.DeleteFederationGroupMember(ctx, groupName, trustDomainName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSONToFederationGroup(body)
+}
+
+func unmarshalJSONToFederationGroup(body []byte) (*admin.FederationGroup, error) {
+	var group *admin.FederationGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, fmt.Errorf(errUnmarshalFedGroups, err)
+	}
+
+	return group, nil
+}
+
+func unmarshalJSONToTrustDomainBundle(body []byte) (*admin.TrustDomainBundle, error) {
+	var bundle *admin.TrustDomainBundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		return nil, fmt.Errorf(errUnmarshalBundle, err)
+	}
+
+	return bundle, nil
+}
+
+func unmarshalJSONToExternalTrustDomain(body []byte) (*admin.ExternalTrustDomain, error) {
+	var externalTD *admin.ExternalTrustDomain
+	if err := json.Unmarshal(body, &externalTD); err != nil {
+		return nil, fmt.Errorf(errUnmarshalExternalTD, err)
+	}
+
+	return externalTD, nil
+}
+
 func unmarshalJSONToTrustDomain(body []byte) (*entity.TrustDomain, error) {
 	var trustDomain *entity.TrustDomain
 	if err := json.Unmarshal(body, &trustDomain); err != nil {
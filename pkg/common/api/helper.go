@@ -1,10 +1,12 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
@@ -19,28 +21,64 @@ func (td TrustDomain) ToEntity() (*entity.TrustDomain, error) {
 		description = *td.Description
 	}
 
+	var harvesterID spiffeid.ID
+	if td.HarvesterSpiffeId != nil && *td.HarvesterSpiffeId != "" {
+		harvesterID, err = spiffeid.FromString(*td.HarvesterSpiffeId)
+		if err != nil {
+			return nil, fmt.Errorf("malformed harvester SPIFFE ID[%v]: %w", *td.HarvesterSpiffeId, err)
+		}
+		if !harvesterID.MemberOf(tdName) {
+			return nil, fmt.Errorf("harvester SPIFFE ID %q is not a member of trust domain %q", harvesterID, tdName)
+		}
+	}
+
+	var onboardingBundle []byte
+	if td.OnboardingBundle != nil && *td.OnboardingBundle != "" {
+		onboardingBundle = []byte(*td.OnboardingBundle)
+		if _, err := spiffebundle.Parse(tdName, onboardingBundle); err != nil {
+			return nil, fmt.Errorf("malformed onboarding bundle: %w", err)
+		}
+		if harvesterID.IsZero() {
+			return nil, errors.New("an onboarding bundle requires a harvester SPIFFE ID")
+		}
+	}
+
 	id := uuid.NullUUID{
 		UUID:  td.Id,
 		Valid: true,
 	}
 
 	return &entity.TrustDomain{
-		ID:          id,
-		Name:        tdName,
-		Description: description,
-		CreatedAt:   td.CreatedAt,
-		UpdatedAt:   td.UpdatedAt,
+		ID:                id,
+		Name:              tdName,
+		Description:       description,
+		HarvesterSPIFFEID: harvesterID,
+		OnboardingBundle:  onboardingBundle,
+		CreatedAt:         td.CreatedAt,
+		UpdatedAt:         td.UpdatedAt,
 	}, nil
 }
 
 func TrustDomainFromEntity(entity *entity.TrustDomain) *TrustDomain {
-	return &TrustDomain{
+	td := &TrustDomain{
 		Id:          entity.ID.UUID,
 		Name:        entity.Name.String(),
 		Description: &entity.Description,
 		UpdatedAt:   entity.UpdatedAt,
 		CreatedAt:   entity.CreatedAt,
 	}
+
+	if !entity.HarvesterSPIFFEID.IsZero() {
+		harvesterID := entity.HarvesterSPIFFEID.String()
+		td.HarvesterSpiffeId = &harvesterID
+	}
+
+	if len(entity.OnboardingBundle) > 0 {
+		onboardingBundle := string(entity.OnboardingBundle)
+		td.OnboardingBundle = &onboardingBundle
+	}
+
+	return td
 }
 
 func (r Relationship) ToEntity() (*entity.Relationship, error) {
@@ -59,6 +97,14 @@ func (r Relationship) ToEntity() (*entity.Relationship, error) {
 		return nil, fmt.Errorf("malformed trust domain[%v]: %w", r.TrustDomainBName, err)
 	}
 
+	direction := entity.RelationshipBidirectional
+	if r.Direction != nil {
+		direction, err = entity.ParseRelationshipDirection(string(*r.Direction))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &entity.Relationship{
 		ID:                  id,
 		TrustDomainAID:      r.TrustDomainAId,
@@ -67,6 +113,7 @@ func (r Relationship) ToEntity() (*entity.Relationship, error) {
 		TrustDomainBName:    tdBName,
 		TrustDomainAConsent: entity.ConsentStatus(r.TrustDomainAConsent),
 		TrustDomainBConsent: entity.ConsentStatus(r.TrustDomainBConsent),
+		Direction:           direction,
 		CreatedAt:           r.CreatedAt,
 		UpdatedAt:           r.UpdatedAt,
 	}, nil
@@ -75,6 +122,10 @@ func (r Relationship) ToEntity() (*entity.Relationship, error) {
 func RelationshipFromEntity(entity *entity.Relationship) *Relationship {
 	trustDomainAName := entity.TrustDomainAName.String()
 	trustDomainBName := entity.TrustDomainBName.String()
+	direction := RelationshipDirection(entity.Direction)
+	if direction == "" {
+		direction = Bidirectional
+	}
 
 	return &Relationship{
 		Id:                  entity.ID.UUID,
@@ -84,6 +135,7 @@ func RelationshipFromEntity(entity *entity.Relationship) *Relationship {
 		TrustDomainBName:    &trustDomainBName,
 		TrustDomainAConsent: ConsentStatus(entity.TrustDomainAConsent),
 		TrustDomainBConsent: ConsentStatus(entity.TrustDomainBConsent),
+		Direction:           &direction,
 		CreatedAt:           entity.CreatedAt,
 		UpdatedAt:           entity.UpdatedAt,
 	}
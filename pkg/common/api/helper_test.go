@@ -53,6 +53,59 @@ func TestTrustDomainToEntity(t *testing.T) {
 		assert.Equal(t, td.CreatedAt, etd.CreatedAt)
 		assert.Equal(t, td.UpdatedAt, etd.UpdatedAt)
 		assert.Equal(t, *td.Description, etd.Description)
+		assert.True(t, etd.HarvesterSPIFFEID.IsZero())
+		assert.Nil(t, etd.OnboardingBundle)
+	})
+
+	t.Run("Maps the SVID onboarding settings", func(t *testing.T) {
+		harvesterSpiffeID := "spiffe://trust.com/galadriel-harvester"
+		onboardingBundle := `{"keys":[]}`
+		td := TrustDomain{
+			Name:              "trust.com",
+			HarvesterSpiffeId: &harvesterSpiffeID,
+			OnboardingBundle:  &onboardingBundle,
+		}
+
+		etd, err := td.ToEntity()
+		assert.NoError(t, err)
+		assert.Equal(t, harvesterSpiffeID, etd.HarvesterSPIFFEID.String())
+		assert.Equal(t, []byte(onboardingBundle), etd.OnboardingBundle)
+	})
+
+	t.Run("Does not allow a harvester spiffe id of another trust domain", func(t *testing.T) {
+		harvesterSpiffeID := "spiffe://other.com/galadriel-harvester"
+		td := TrustDomain{
+			Name:              "trust.com",
+			HarvesterSpiffeId: &harvesterSpiffeID,
+		}
+
+		etd, err := td.ToEntity()
+		assert.EqualError(t, err, `harvester SPIFFE ID "spiffe://other.com/galadriel-harvester" is not a member of trust domain "trust.com"`)
+		assert.Nil(t, etd)
+	})
+
+	t.Run("Does not allow a malformed onboarding bundle", func(t *testing.T) {
+		onboardingBundle := "not a bundle"
+		td := TrustDomain{
+			Name:             "trust.com",
+			OnboardingBundle: &onboardingBundle,
+		}
+
+		etd, err := td.ToEntity()
+		assert.ErrorContains(t, err, "malformed onboarding bundle")
+		assert.Nil(t, etd)
+	})
+
+	t.Run("Does not allow an onboarding bundle without harvester spiffe id", func(t *testing.T) {
+		onboardingBundle := `{"keys":[]}`
+		td := TrustDomain{
+			Name:             "trust.com",
+			OnboardingBundle: &onboardingBundle,
+		}
+
+		etd, err := td.ToEntity()
+		assert.EqualError(t, err, "an onboarding bundle requires a harvester SPIFFE ID")
+		assert.Nil(t, etd)
 	})
 }
 
@@ -77,6 +130,15 @@ func TestTrustDomainFromEntity(t *testing.T) {
 	assert.Equal(t, etd.CreatedAt, td.CreatedAt)
 	assert.Equal(t, etd.UpdatedAt, td.UpdatedAt)
 	assert.Equal(t, etd.Description, *td.Description)
+	assert.Nil(t, td.HarvesterSpiffeId)
+	assert.Nil(t, td.OnboardingBundle)
+
+	etd.HarvesterSPIFFEID = spiffeid.RequireFromPath(trustDomain, "/galadriel-harvester")
+	etd.OnboardingBundle = []byte(`{"keys":[]}`)
+
+	td = TrustDomainFromEntity(&etd)
+	assert.Equal(t, "spiffe://trust.com/galadriel-harvester", *td.HarvesterSpiffeId)
+	assert.Equal(t, `{"keys":[]}`, *td.OnboardingBundle)
 }
 
 func TestRelationshipToEntity(t *testing.T) {
@@ -109,6 +171,21 @@ func TestRelationshipToEntity(t *testing.T) {
 	require.Equal(t, trustDomainBName, ent.TrustDomainBName.String())
 	require.Equal(t, entity.ConsentStatusApproved, ent.TrustDomainAConsent)
 	require.Equal(t, entity.ConsentStatusDenied, ent.TrustDomainBConsent)
+	require.Equal(t, entity.RelationshipBidirectional, ent.Direction)
+
+	// Test direction
+	direction := BTrustsA
+	r.Direction = &direction
+	ent, err = r.ToEntity()
+	require.NoError(t, err)
+	require.Equal(t, entity.RelationshipBTrustsA, ent.Direction)
+
+	// Test invalid direction
+	invalidDirection := RelationshipDirection("sideways")
+	r.Direction = &invalidDirection
+	_, err = r.ToEntity()
+	require.Error(t, err)
+	r.Direction = nil
 
 	// Test invalid trust domain A name
 	invalidTrustDomainAName := "invalid trust domain"
@@ -135,6 +212,7 @@ func TestRelationshipFromEntity(t *testing.T) {
 		TrustDomainBID:      uuid.New(),
 		TrustDomainAConsent: entity.ConsentStatusPending,
 		TrustDomainBConsent: entity.ConsentStatusApproved,
+		Direction:           entity.RelationshipATrustsB,
 	}
 
 	r := RelationshipFromEntity(&eRelationship)
@@ -147,6 +225,7 @@ func TestRelationshipFromEntity(t *testing.T) {
 	assert.Equal(t, eRelationship.TrustDomainBID, r.TrustDomainBId)
 	assert.Equal(t, string(eRelationship.TrustDomainAConsent), string(r.TrustDomainAConsent))
 	assert.Equal(t, string(eRelationship.TrustDomainBConsent), string(r.TrustDomainBConsent))
+	assert.Equal(t, ATrustsB, *r.Direction)
 }
 
 func TestMapRelationships(t *testing.T) {
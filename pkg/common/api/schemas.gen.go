@@ -24,6 +24,13 @@ const (
 	Pending  ConsentStatus = "pending"
 )
 
+// Defines values for RelationshipDirection.
+const (
+	ATrustsB      RelationshipDirection = "a_trusts_b"
+	BTrustsA      RelationshipDirection = "b_trusts_a"
+	Bidirectional RelationshipDirection = "bidirectional"
+)
+
 // ApiError defines model for ApiError.
 type ApiError struct {
 	Code    int64  `json:"code"`
@@ -45,8 +52,8 @@ type Date = openapi_types.Date
 // JWT defines model for JWT.
 type JWT = string
 
-// JoinToken defines model for JoinToken.
-type JoinToken = UUID
+// JoinToken Join token of the form <prefix>.<secret>, where only the prefix identifies the token
+type JoinToken = string
 
 // PageNumber The number of items to skip before starting to collect the result set.
 type PageNumber = int
@@ -56,17 +63,23 @@ type PageSize = int
 
 // Relationship defines model for Relationship.
 type Relationship struct {
-	CreatedAt           time.Time        `json:"created_at"`
-	Id                  UUID             `json:"id"`
-	TrustDomainAConsent ConsentStatus    `json:"trust_domain_a_consent"`
-	TrustDomainAId      UUID             `json:"trust_domain_a_id"`
-	TrustDomainAName    *TrustDomainName `json:"trust_domain_a_name,omitempty"`
-	TrustDomainBConsent ConsentStatus    `json:"trust_domain_b_consent"`
-	TrustDomainBId      UUID             `json:"trust_domain_b_id"`
-	TrustDomainBName    *TrustDomainName `json:"trust_domain_b_name,omitempty"`
-	UpdatedAt           time.Time        `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Direction Which trust domains of the relationship receive the bundle of their peer. With a_trusts_b, only trust domain A receives the bundle of trust domain B.
+	Direction           *RelationshipDirection `json:"direction,omitempty"`
+	Id                  UUID                   `json:"id"`
+	TrustDomainAConsent ConsentStatus          `json:"trust_domain_a_consent"`
+	TrustDomainAId      UUID                   `json:"trust_domain_a_id"`
+	TrustDomainAName    *TrustDomainName       `json:"trust_domain_a_name,omitempty"`
+	TrustDomainBConsent ConsentStatus          `json:"trust_domain_b_consent"`
+	TrustDomainBId      UUID                   `json:"trust_domain_b_id"`
+	TrustDomainBName    *TrustDomainName       `json:"trust_domain_b_name,omitempty"`
+	UpdatedAt           time.Time              `json:"updated_at"`
 }
 
+// RelationshipDirection Which trust domains of the relationship receive the bundle of their peer. With a_trusts_b, only trust domain A receives the bundle of trust domain B.
+type RelationshipDirection string
+
 // SPIFFEID defines model for SPIFFEID.
 type SPIFFEID = string
 
@@ -98,69 +111,73 @@ type UUID = openapi_types.UUID
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9R5a5PiuJL2XyF4z4dzDlWNL9hARUyckHzDBht8A8x43gpf5AvYMviCgYn+7xuGnu6q",
-	"meqdOR27sbv1pYSUSj2PpMxUpn/tB0V+LDDCddV/+bVfBQnKvXsTHFOhLIuya3thmNZpgb1sVRZHVNYp",
-	"qvovkZdV6Kl/fNPV6QtR9z8qytyr+y/9FNfsqP/Uz71Lmjd5/4WZTp/6eYofv0iCeOrX1yN6iKIYlf3P",
-	"T/0cVZUX3zWhi5cfs24c9HzkNXUaNVkPddh6v4k9fVuvqssUx48FFwjHddJ/od4s8mX88+enfolOTVqi",
-	"sP/y8wP3t3V/+Spf+HsU1B0m2OAwQ3wao6rugIWoCsr02G1M/6XvexViRz2EO01hz5yBZ4phe+FdvFdE",
-	"vTpBPf+uov/0hlREjBg2HHsoJCYTNJ6SaMSSREAHlBeytBehEYsoNB6Pp5NJFPrBlBoTEcmgYDomSX9E",
-	"9f/A7KnPdecRpYFXoz8C3X5iiGkv+CbSS3FvJai9L1v4Ftxz9wcFSdZ6nGBYsihzwBLuvS5WZXk2tDgO",
-	"ok0MWhmCWNY96Aj0UCUm25nDYW2dByIM9kCD8eGUHFJp2hIQ6JUIeHh1sapXLac7/FrXJaFV1vZNWKqg",
-	"lQBpCxxoxbW0Hjlb9SLwYAljbQ1BoEIiOYdbjfCp0cXFggVWj5FC5UTNsjjI+7TSquaoXYC7Zp7n1pZN",
-	"tI1DTWtZWG/kh5ziYyNzcZCT2U7KklCyY50QYjvToCzKNxWOtrwltyqvt6oFWs2KbypZdH0XlQ8u2v7R",
-	"52KVLNrYJy7cDSgPLI4FsrWl6qOWf2CQebC2d9skCW6CroLRnSFs25kpTUkXB7Rx9veCoYLJg3vcyjap",
-	"qbKgnQMMLuIe2A/NtsXbzEbdg3bJC5Rq6VeNVy8uFnlgPiRUlaNDOrwyt4B6cFYNopXaO44VDw09yDPK",
-	"2RqZLEyvO0psvO0xcXEoZR2GrQptibtWEtB1GO+DCYgFjge75W67S3aScBFuwIBxVcJYEIAj0ysgQ3BR",
-	"ORev12obx0KqAkLizJNkyj7N6wIEug3ASIZ8C7rxOShkCHR+liDj4PukyAXjizGvahe3c0KRJW/uTOqx",
-	"4puUr1M+68gKH+NZIzuzEyw5ez2eFihLD4fiYBzEc3A+evMUizNen7nYPm4EmTVswXByk4vp5WSTjqhm",
-	"GawpyOw8P99yhza8OIwQZAwJfXViYyksgOSHWp4auYvN3NoH1SBL1Es8ikSHzeAxFdZiKtl7yTAGLGmw",
-	"48WNtUdzBS20gMuJsd6Kzhzmx5SYxC4Or7F5NkK7ZRilOJYo3A/WUr23D3CUiNZI0rfDOKnZqZGdbsPB",
-	"pCFCQT8kjd00QXnysg6DdB3RM6OFET8XWwdt1DG3UkMGDcPloCYm9WTl729ryzozic5zleDIa8oaA1Ge",
-	"moF2UV18SMZDEKsQAGkfxxpUZZlfWSDq7sjMVAWJB5sYmsN2fZoNr3tWt+hpTQwPM28QO+v46OKzBYcw",
-	"jrtzFqEeQKAbN3UmtJbuyPPWgVC3ZyqYS/omIcIZYBfXKR3SQRPQWrXItbOLfXN63W3hOaAywqcVZkFq",
-	"liVpZ98krXCj8LpJiuuU7Gyz7qxuYent0nJqe682Dq0QLlY5IHFcdxdtEd4ATBKjCGdGu0wnZ5/SbsFM",
-	"/bqe/xs7Q3iwi2vaxW8R+Y48+yYNv+wFEDY83KggkOAGQR4I8H5/ryfBA5Lk4ikOOKgLUOVbiee+2MXp",
-	"0AJdhZAHlcoV3zC2MhQT5o4xuBXnBR12GN7Y4oJWskCa3rytcQ7woZ113s8gMgidVgTfdha08letLoat",
-	"ClUh7nxDOGsNqPKTduWBccHnkkZ93f99kF9uC6zdfI7Z+xRx7nxIt6qLF2uNdA4aXNjrzWLd+T/StAmh",
-	"1njAaClpqldmH+Ttb3iWEDqCCHgg2rJ3a5nSxTt55h2xcZFtfGwH0uKLFwv5VoDDVhdAK4sFz3FgS0hc",
-	"+tgnEh84CGQhjsXaxVCWoaeLGMwCMM2u9mIq0ion22sYy6pibPaNpgmXw+08naiLK1jchPFlt1QBAOJF",
-	"JZLCxX4LAAQqMHkogVQA7AVlqWZMpMOQpY9OiM3heXkZcvtjLajCeTLdbBJy2JQbWeBknb+6GJZoZlMM",
-	"f2ubg+4Z+r7dsAyzWxxOHL74F31jpEuU76cKgCRQ9PgM2SXpkFU6U6O4qFIXLwBtUAcS+cLAXm1mvpVO",
-	"HctbcAAAGFia7GktAEDngeC0BpBjyRBG7c3zNSPkJ4fT0MVncUXXOqKSnLgweNtkRZuMZL+lswMni44/",
-	"pDOTP2bmGATGqBxsj5tamJuWuFFyjTP8wMVbpSkpQ4JgZoNxxa3HBXndgYE5miwZaRKYBZWduG298JLC",
-	"Xi53s6o615fo8GYnJ1920thDAaSQlc9+sakq2hjJ9brdIz8b8/S1EL0tofEJFW6SJG65Szlr5ZiLTmMX",
-	"F4HKMfWA3KeMyly8Rb7iRvJgs6XlITAOG/OaLseyHrS87ijzYicn50ADurCAOuDjWIYuBhxqmnKk42Z/",
-	"yuPGLGc2nSfRIFCK8Gbp2qkY1SEarHhyiMTQAcKimVzEAQHq8UVJV46LU8aYt2l2XTHseUCnDmVNs3Zs",
-	"TiyFGJHrReLJ8yM5Um+mfTOuqFiCShnrgFe5bDa3+ayLFzZ11JpiMnHYNC7OFu1XuFW0VNC10zU3TSc5",
-	"1C1Re2FTnPanLSbYuFqnxcZa89trFTIuPgmXUc1WciwHak6xzow8K0dOF5L5MaCuxDg2DocM7oxa3VvJ",
-	"eRRsr1d1O26sILTGQIErFzcojbhiTTHKZdsUk5Ah6WncrkgI0FiG69WFasZzbWhfl9twl7dqNLRyUWr5",
-	"kIuq6ywaunhXQapdzIqb5RRgnetTsbBJZREH6/R8UgZnLYPJbJtkFzXUiP2EMKbajRXkONP3aE4vJy6W",
-	"h4Eo5UM4GYyoZJlxcjjdhTUOlcBQ1vuUaHni1KIz50Vguley2Xm4r4SBPLVvbHDkromLq3aQlWJ4seOT",
-	"zUy8ywnNJ1PRGGjF6ETI8nKgpGSpzMspPpiQgKdtcVtjgXTgcL44h3Ll4sbZKc3Jp47zQzO43Sw2ttuZ",
-	"be3OMNWW9XYx0i5tMJxb481taYZUuyIJXZ7w83h0jlKNr1w82+SQDEbzfcrGyxgwjWnfPCk/Dc+jNQ7m",
-	"jF0O8HThRzhaBNREYaJ6KBV1itUrf6BTr3SxSBJOdgqWOdqSjZjP/TAdbotSyg5coYq0xV8mZX6c8jCF",
-	"QxffH8KCxn/wOH6bkhxR/uErvcAVwrVZe3Vzz50Q7jKin/ve8VgWZxT2n/ohwum9cUQ47Ob98oEi/ss7",
-	"/9u7nSIo8pkgn2niLY6wk3ufGJEfqFM21ntt6KokvhSky1SR7ZtMaqlcydhgAk5m5cNxu+aU6Sd0VW7h",
-	"Rk6XqXxR9yqhWQ695A+tnLapn4v1zrwLnz1pFBvSNOv6vY1IyPviolkCpe5VRuXla6R/MqNsfmkNxVTR",
-	"fC5SujWK2qOKlIhmV8sDe1XWr16oV1XLBG/p7dv6PbsRMWWf+kevrlHZZT7//2fv+Qaed8Tz1HWfX38Z",
-	"/Mt1P33U9/ffd/7jX3/76ASVIsVWcUD4/X7RkTdhInb0zIzJ8fOIYalnn46CZyqYsnTEsl7ksW+BN00a",
-	"vkdO/w438Tz1nqNffp18fv7aHv2FNkl9/hD4youR1uQ+Kv+YH1oJ6uH7WJe1pjXKq15d9KpDeuz5KCpK",
-	"1Ktqr6xTHHf9QZFlKKjv6W2JqiarexWqP/Xf5PgfZvgdBDO9fUlQI6/J6i5Rf/oumuodnBLVTYk/vSss",
-	"EG/rCh+taaDM6/RWSXr8d+saJfJqFL569fcszSImLzTxQhC739vcc53mf8Xw0rDT/bcSRf2X/v8bfivN",
-	"DL/UZYa2LfOdZF02Vf0aFrmX4lfvNXh4kj+b/d7h/FHND6+PvRz92VSrm8LfZ2id+O+1+P81LPwfZeH/",
-	"KIvmGP4334zflaruzuLNfXwH4aND/WiLvnuHvnssH5XEzJUsioLMv2deHdMoQi/D4VtNw7YoD1nhha9p",
-	"iHCdRikq/7xuN5p8YCdmGmOvbkr0p1W46jfJ/6QA50nsbkt7u2jA1vHwavC70DC1WqWn2W230a67raHs",
-	"eFJxNqT19Te324db5brbMMRayurdWiOcDdmuLIHUbsJVtex2adn5bpu03lbJ7jIWcVnyMaVZAanyB1LB",
-	"SuLnxtm3iKu6B5S6t3/6yFffr9yj9vhHvo8D6N1lvpDrpbinmEvto3rer24XJV+9pk6KMu18m9t/+flX",
-	"t48ux7RE1atXu/0Xt0+ykxFDsvSIdvtPbv+Arq9peB8BobULiGB8q6ZswMZn/aJAVg8Flr+ajRad7/LH",
-	"xs/S4PWArvc5qnhohdaZdWnAbU9wQHfkL20e6AGvx0C4kKud0UYCze+q5YlSIbFkVpvIr26ld5S0KGcE",
-	"cUgW7ZbBMq/leyv1h9o1GnOIO5uLQAhowjl6/hn48WI2CSoq4W8k+Oknt//56Xv8JuQf+UXx2uMDzwKO",
-	"dztI1Caa0ptauuRGuI0AocEf5Vfy5j4NSnwybSxQV0QqRRNBXlr4tazuFXEtzdFsWc8tpjllcDi3JhpF",
-	"M9uq2sbWQjfU5HYEfKCqI3voZMG5uB5mTB7f+f3y5PZLFJWoSl6TFD8YEnegFTo1CAfo9RFC7yPj+8hb",
-	"07x31yHp9j9/9wI+fN7/wqD5zh7eLvKwiQfDXp14da9ExxJ1vuzuCjonVF9727/08eDNY+zvvX/+/Hgb",
-	"es+3X3r//Mc/P3xiJV55RlWNyteHO/wLcemrN/233gI/GLYK7Bde2eUUr/5X5/KnOr74of+xsHcn+/3o",
-	"91GM+j33d2jvZvDpcUk+BUX+gxHpfhb/p3KAz0/9CgVNmdZXszvgh8F+u7RdiOh6fOSVqBR/g9nlhk+P",
-	"z4SdtsfoN+1JXR/7nzvlKY6K/gtusuypXxwR9o5p/6Xfv1NKqsfI5/8IAAD//4mnT+R/HAAA",
+	"H4sIAAAAAAAC/8xZ6ZLiSJJ+FUw7P2aGzEIH4kiztrEIXUggkRISIFq9aTpCB0ghoQMBbfXua4I6qKqs",
+	"7Z62XdvNPxmK8HD/vjg83J3fCT/PihwjXFfEy+9E5ccoc29NUCRCWeZl13aDIKmTHLvpa5kXqKwTVBEv",
+	"oZtW6IkoHro6fQHq/od5mbk18UIkuB4NiScic89J1mTECzudPhFZgu9fFEk+EfWlQHdRFKGS+PhEZKiq",
+	"3OimCZ3drEi7cdDzkNvUSdikPdRh630We/pqr6rLBEd3gwuEozomXugHI5/GP358Ikp0bJISBcTLr3fc",
+	"X+3+9kU+9/bIrztMsMFBivgkQlXdAQtQ5ZdJ0S0M8UJ4boVGwx7Cnaagt5qBZ5od9YKbeC8Pe3WMet5N",
+	"BfH0QCokh+woGLsoICcTNJ5SaDiiSJ/xaTcYMW6IhiNEo/F4PJ1MwsDzp/SYDCkW+dMxRXlDmviB2RPB",
+	"dfsRJr5box+Bbj+w5LTnfxXpJbj3Kqi9T0v4CO65+4OCJGs9TjBMWZQ5YAq3XgersjwbmBwH0SYCrQxB",
+	"JOsutAVmoJKT7czmsLbOfBH6e6DB6HCMD4k0bUkI9EoEPLw4WNWrltNtfq3rktAqa+sqLFXQSoCyBA60",
+	"4lpaD+2tehZ4sISRtobAVyEZn4KtRnr08OxgwQSv95Fc5UTNNDnIe4zSqqthuwA3zTzPrU2LbBubntay",
+	"sN7IdznFw0bqYD+j0p2UxoFkRTopRFaqQVmUryocbnlTblVeb1UTtJoZXVUq7/rOKu+ftf29z8EqlbeR",
+	"R565K1DuWGwTpGtT1Yctf8cg82Bt7bZx7F8FXQXDG0PYtrOVNKUc7DPGydsLhgomd+5RK1uUpsqCdvIx",
+	"OIt7YN01WyZvsRt1D9olL9CqqV80Xj07WOTB6i6hqhwTMMGFvfr0nbNqkK3U3nC88tDQ/Syl7a2RysL0",
+	"sqPFxt0WsYMDKe0wbFVoSdylkoCuw2jvT0AkcDzYLXfbXbyThLNwBQaMqhJGggBsmXkFMgRnlXPweq22",
+	"USQkKiAlbnWUVrLH8LoAgW4BMJQh34JufA5yGQKdn8XIOHgeJXL++GzMq9rB7ZxUZMmd25N6rHgr2tNp",
+	"b2TLCh/hWSPbsyMsOWs9nuYoTQ6H/GAcxJN/Ktx5gsUZr88cbBUbQR4ZlmDY2YqLmOVkkwzpZumvacju",
+	"XC/bcoc2ONus4KcsBT11YmEpyIHkBVqWGJmDV5m596t+GqvnaBiK9iiFRSKsxUSy9pJh9EeUMRovriNr",
+	"OFfQQvO5jBzrrWjPYVYk5CRycHCJVicjsFqWVfKiRMG+v5bqvXWAw1g0h5K+HURxPZoa6fE66E8aMhD0",
+	"Q9xYTeOXRzftMEiXITMzWhjyc7G10UYdc69qwKJBsOzX5KSevHr769o0T2ys81wl2PKaNsdAlKcrXzur",
+	"Dj7E4wGIVAiAtI8iDaqyzL+aIOzOyGylChIPNhFcDdr1cTa47Ee6yUxrcnCYuf3IXkeFg08mHMAo6vZZ",
+	"hLoPgW5c1ZnQmrotz1sbQt2aqWAu6ZuYDGZgtLhMmYDxG5/RqkWmnRzsraaX3RaefDolPUZhF5RmmpJ2",
+	"8laUGWwUXl9R4jqhurtZd7duYert0rRra682NqOQDlY5IHFcdxYtEV4BjGMjD2ZGu0wmJ4/Wrv5M/WLP",
+	"+8zOEO7soppx8CMiz5ZnX6Xhp7UAwoaHGxX4EtwgyAMB3s7v5Si4QJIcPMU+B3UBqnwr8dyne3E8tEBX",
+	"IeRBpXL5V4ytDMWYvWH0r/lpwQQdhoe7uGCU1JemV3drnHx8aGed9zPIFEK7FcHXlQWt/EWrg2GrQlWI",
+	"Ot8QzFoDqvykfXXBOOczSaO/rP/ez87XBdauHsfuPZo8dT6ks+rgxVqj7IMGF9Z6s1h3/o9aWaRQazxg",
+	"tYRaqRd272ftZzxLCG1BBDwQLdm9tmzp4J08cwtsnGULF21fWnzyYgHfCnDQ6gJoZTHnOQ5sSYlL7utE",
+	"4QMHgSxEkVg7GMoydHURg5kPpunFWkxFRuVkaw0jWVWMzb7RNOF8uJ6mE3VxAYurMD7vlioAQDyrZJw7",
+	"2GsBgEAFKx5KIBHA6IzSRDMm0mEwYgo7wKvBaXkecPuiFlThNJluNjE1aMqNLHCyzl8cDEs0s2iWv7bN",
+	"QXcNfd9uRiy7WxyOHD57Z31jJEuU7acKgBRQ9OgER0vKpqpkpoZRXiUOXgDGoA8U8oS+9bqZeWYytU13",
+	"wQEAoG9qsqu1AACdB4LdGkCOJEMYtlfX04yAnxyOAwefxFem1hEdZ+SZxdsmzdt4KHstkx44WbS9AZOu",
+	"+CJdjYFvDMv+ttjUwnxlihsl0zjD8x28VZqSNiQIZhYYV9x6nFOXHeivhpMlK038VU6nR25bL9w4t5bL",
+	"3ayqTvU5PDys5OTTShp7KIAEjuSTl2+qijGGcr1u98hLxzxzyUV3S2p8TAebOI5a7lzOWjniwuPYwbmv",
+	"cmzdp/YJq7Jnd5G9ckO5v9ky8gAYh83qkizHsu63vG4r83wnxydfA7qwgDrgo0iGDgYcappyqONmf8yi",
+	"ZlXOLCaLw76v5MHV1LVjPqwD1H/lqQESAxsIi2ZyFvskqMdnJXm1HZywxrxN0ssrOzr1mcSmzWnajlcT",
+	"UyGH1HoRu/K8oIbqdWVdjQvKl6BSxjrgVS6dzS0+7d4Liy60Jp9M7FES5SeT8SrcKloi6Nrxkq1Wdnyo",
+	"W7J2gyY/7o9bTI6iap3kG3PNby9VwDr4KJyH9aiSI9lXM3pkz6iTUnC6EM8Ln76Q48g4HFK4M2p1b8an",
+	"ob+9XNTtuDH9wBwDBb46uEFJyOVrmlXO2yafBCzFTKP2lYIAjWW4fj3TzXiuDazLchvsslYNB2YmSi0f",
+	"cGF1mYUDB+8qSLeLWX417RysM30q5halLCJ/nZyOSv+kpTCebeP0rAYauZ+QxlS7jgQ5SvU9mjPLiYPl",
+	"gS9K2QBO+kM6XqacHEx3QY0DxTeU9T4hW548tujEuSGY7pV0dhrsK6EvT63ryC+4S+zgqu2npRicreho",
+	"sRP3fETzyVQ0+lo+PJKyvOwrCVUq83KKDytIwuM2v66xQNlwMF+cArlycGPvlObo0cX80PSvV3MUWe3M",
+	"MncnmGjLersYaufWH8zN8ea6XAV0+0qRujzh59HwFCYaXzl4tskg5Q/n+2QULSPANivr6krZcXAarrE/",
+	"Z62yj6cLL8ThwqcnChvWAymvE6xe+AOTuKWDRYq006O/zNCWasRs7gXJYJuXUnrgclVkTP48KbNiysME",
+	"Dhx8C4QFjX8nOH5MSQqUvRul57hCuF7Vbt3ccieEu4zoV8ItijI/oYB4IgKEk1ujQDjo5v32jiL+U5z/",
+	"NW6nSZp6JqlnhnzEEXRy3yZG1DvqlI35rTZ0UWJP8pNlosjWVaa0RK5kbLA+J4/kQ7Fdc8r0A7oo12Aj",
+	"J8tEPqt7ldRMm1nyh1ZO2sTLxHq3ugmfXGkYGdI07frdjUjK+/ysmQKt7lVW5eVLqH9Yhen83BrKSkXz",
+	"uUjr5jBsCxUpITN6XR5GF2X95gZ6VbWs/0hv39bfshuS09ETUbh1jcou8/nPX93nK3jekc9Tx3l++63/",
+	"L8f58F7f37/v/Me//vbeDip5gs38gPCPWVY31Ku7sc+5X4ez5zQkyfhFicLkfGujD/euCvklqu9dT702",
+	"RiXq5Ti93Kbe5XtJgHCXtKHq1nvT/k26xoRTl/LHiPXoYOiOyA8HZZKt6bM+LhZkpQ1rY9Ta0yukfJ5B",
+	"IhvNxokyPSyoTGPy9/i9uhHSmsxD5Y8EzRj18G2sI5jUKKt6dd6rDknR81CYl6hX1W5ZJzjq+v08TZFf",
+	"34CXqGrSuleh+gPxUAp4txDQQVgl1095bOg2ad3l808/RVN9A6dEdVPiD9/UH8jH8sN7Ng2Uup3eKk6K",
+	"f7f8USK3RsGbW//sQprk5IUhX0hy9/3VfK6T7M/czyApkX/n/TvxtxKFxAvxH4OvhZzBpyrO4JEH/2XS",
+	"xyciCf5oqmXJfCdZl01VvwV55ib4zX3z7y7rj2Z/69l+VPOX7WM3Q3801eym8LcZWif+vRbvf4aF91dZ",
+	"eH+VRVME/8tn67uaWNI9PA8n+hsI723qe0v00zP00215r/b2/lF+9AmEl3y5GG5KfO8gNnHix72bxd7d",
+	"YvXZMZcPunsl8lFyQg/Vuk9iSdkrECo/9DZJHffct5uq6s17+uSmHzT3wGc11fd6HqVg55Y+v/nfo/9q",
+	"gHgivM8f7rvv/+pVFkVB5r89FlWRhCF6GQwel3nQ5uUhzd3g7ctjUv5x9XQ4ec9qEmG3bkr0h7XQ6rPk",
+	"f1MGdaXRbsu4u7A/qqPBxeB3gbHSapWZptfdRrvstoay4ynF3lDml29utw+2ymW3Ycm1lNa7tUbaG6p9",
+	"NQVKuwoX1bTapWllu23culslvcmY5HnJR7Rm+pTKHygFK7GXGSfPJC/qHtDq3vrlvafwdh/vFeAf+d43",
+	"oHeT+bzbCe4pq6X2XlX1d6eLVd7cpo7zMumeDod4+fV3h0DnIilR9ebWDvHiENRoMmSpETNkHOLJIQ7o",
+	"8pYEtxEQmDuf9MfXajryR9FJPytwpAfCiL+sGi083eSLxksT/+2ALrc5qnhohdaedcnYdU9yoCvk3Ns8",
+	"0H1ej4Bwpl53RhsKDL+rlkdaheSSfd2EXnUt3ULSwowVxAGVt1sWy7yW7c3EG2iXcMwh7rRa+ILPkHbh",
+	"eifgRYvZxK/omL9S4JdfHOLj08/4Tagf+YXR2uV91wS2ez1I9CacMptaOmdGsA0BqcG/yq/kV/vEL/Fx",
+	"ZWGBviBKyZsQ8tLCq2V1r4hraY5my3puss0xhYO5OdFoht1W1TYyF7qhxtcC8L6qDq2Bnfqn/HKYsVl0",
+	"4/fbk0OUKCxRFb/FCb4zJG9AK3RsEPbR2z1CuY2MbyOPV/PWXQeUQ3z86QG8Pwj/H2OSx/vwaMR89Hh1",
+	"7Na9EhUl6hz9zRV0Tqi+9LZ/6iech1D+771//nqP0N3n62+9f/7jn+9G6LFbnlBVo/Lt7g7/xKP9xZv+",
+	"W4HSX3zTc+zlbtlldm/eF+fyhzo++aH/s5jgRvbnocF7D/j33L9Be7sGH+6H5IOfZ3/xRbrtxTeKmdCd",
+	"sOFo+MyOqfHzkB3Rzx4T+s+0Px0x4Wjkhu7o0VjTJMG3ppjvMsjbgQt/+33y8flLe/gn2hT98Z0D+vGJ",
+	"qJDflEl9WXUbfL+wXw9t90R0PR5yS1SKn2F2GfrT/cfaTtt99Kv2uK4L4mOnPMFhTrzgJk2fiLxA2C0S",
+	"4oUgbpTi6j7y8b8GAP2/W7AFHgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,132 @@
+// Package consent defines the statements signed by trust domains when they consent to a relationship.
+// Those signed statements are stored by Galadriel Server and shared with the peer trust domain, so that
+// each side can prove the approval of the other independently of the server.
+package consent
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// MaxClockSkew is the maximum difference allowed between the timestamp of a statement and the time it is validated.
+const MaxClockSkew = 5 * time.Minute
+
+// Statement is the payload signed by a trust domain to state its consent to a relationship.
+// The exact bytes that were signed are stored and transmitted along with the signature.
+type Statement struct {
+	RelationshipID uuid.UUID `json:"relationship_id"`
+	TrustDomainA   string    `json:"trust_domain_a"`
+	TrustDomainB   string    `json:"trust_domain_b"`
+	// Direction is the direction of the relationship the trust domain consents to, bidirectional when empty.
+	Direction     entity.RelationshipDirection `json:"direction,omitempty"`
+	TrustDomain   string                       `json:"trust_domain"` // Trust domain giving its consent, one of A or B.
+	ConsentStatus entity.ConsentStatus         `json:"consent_status"`
+	Timestamp     time.Time                    `json:"timestamp"`
+}
+
+// NewStatement creates the statement of the trust domain td for the relationship, which must have the trust domain names populated.
+func NewStatement(relationship *entity.Relationship, td spiffeid.TrustDomain, status entity.ConsentStatus, now time.Time) *Statement {
+	return &Statement{
+		RelationshipID: relationship.ID.UUID,
+		TrustDomainA:   relationship.TrustDomainAName.String(),
+		TrustDomainB:   relationship.TrustDomainBName.String(),
+		Direction:      directionOrDefault(relationship.Direction),
+		TrustDomain:    td.String(),
+		ConsentStatus:  status,
+		Timestamp:      now.UTC().Truncate(time.Second),
+	}
+}
+
+// ParseStatement parses a JSON encoded statement.
+func ParseStatement(data []byte) (*Statement, error) {
+	s := &Statement{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse consent statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Marshal returns the JSON encoding of the statement, which is the payload to be signed.
+func (s *Statement) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// Validate checks that the statement was made by the trust domain td for the given relationship and status,
+// and that its timestamp is within MaxClockSkew of now.
+func (s *Statement) Validate(relationship *entity.Relationship, td spiffeid.TrustDomain, status entity.ConsentStatus, now time.Time) error {
+	if s.RelationshipID != relationship.ID.UUID {
+		return fmt.Errorf("statement relationship ID %q does not match relationship ID %q", s.RelationshipID, relationship.ID.UUID)
+	}
+
+	if s.TrustDomainA != relationship.TrustDomainAName.String() || s.TrustDomainB != relationship.TrustDomainBName.String() {
+		return fmt.Errorf("statement trust domains %q and %q do not match the relationship trust domains", s.TrustDomainA, s.TrustDomainB)
+	}
+
+	if directionOrDefault(s.Direction) != directionOrDefault(relationship.Direction) {
+		return fmt.Errorf("statement direction %q does not match relationship direction %q", directionOrDefault(s.Direction), directionOrDefault(relationship.Direction))
+	}
+
+	if s.TrustDomain != td.String() {
+		return fmt.Errorf("statement trust domain %q does not match trust domain %q", s.TrustDomain, td)
+	}
+
+	if s.ConsentStatus != status {
+		return fmt.Errorf("statement consent status %q does not match consent status %q", s.ConsentStatus, status)
+	}
+
+	skew := now.Sub(s.Timestamp)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > MaxClockSkew {
+		return fmt.Errorf("statement timestamp %s is not within %s of the current time", s.Timestamp.Format(time.RFC3339), MaxClockSkew)
+	}
+
+	return nil
+}
+
+// directionOrDefault returns the direction, defaulting to bidirectional, so that the statements made before
+// relationships had a direction match bidirectional relationships.
+func directionOrDefault(d entity.RelationshipDirection) entity.RelationshipDirection {
+	if d == "" {
+		return entity.RelationshipBidirectional
+	}
+	return d
+}
+
+// VerifySignature checks that the signature was computed over the statement with the key of the leaf certificate of the chain.
+// It does not verify the chain itself, which is left to the peers as they decide which signing authorities they trust.
+func VerifySignature(statement, signature []byte, chain []*x509.Certificate) error {
+	if len(signature) == 0 {
+		return errors.New("consent signature is missing")
+	}
+
+	if len(chain) == 0 || chain[0] == nil {
+		return errors.New("signing certificate is missing")
+	}
+
+	if err := cryptoutil.VerifySignature(chain[0].PublicKey, statement, signature); err != nil {
+		return fmt.Errorf("failed to verify consent signature: %w", err)
+	}
+
+	return nil
+}
+
+// EncodeCertificateChain concatenates the DER encoding of the certificates of the chain.
+func EncodeCertificateChain(chain []*x509.Certificate) []byte {
+	var der []byte
+	for _, cert := range chain {
+		der = append(der, cert.Raw...)
+	}
+
+	return der
+}
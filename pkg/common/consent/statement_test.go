@@ -0,0 +1,144 @@
+package consent
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tdA = spiffeid.RequireTrustDomainFromString("td-a.org")
+	tdB = spiffeid.RequireTrustDomainFromString("td-b.org")
+	tdC = spiffeid.RequireTrustDomainFromString("td-c.org")
+)
+
+func TestStatementMarshalAndParse(t *testing.T) {
+	now := time.Now()
+	relationship := newRelationship()
+
+	statement := NewStatement(relationship, tdA, entity.ConsentStatusApproved, now)
+	assert.Equal(t, relationship.ID.UUID, statement.RelationshipID)
+	assert.Equal(t, tdA.String(), statement.TrustDomainA)
+	assert.Equal(t, tdB.String(), statement.TrustDomainB)
+	assert.Equal(t, entity.RelationshipBidirectional, statement.Direction)
+	assert.Equal(t, tdA.String(), statement.TrustDomain)
+	assert.Equal(t, entity.ConsentStatusApproved, statement.ConsentStatus)
+	assert.Equal(t, now.UTC().Truncate(time.Second), statement.Timestamp)
+
+	data, err := statement.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := ParseStatement(data)
+	require.NoError(t, err)
+	assert.Equal(t, statement, parsed)
+
+	_, err = ParseStatement([]byte("not json"))
+	assert.ErrorContains(t, err, "failed to parse consent statement")
+}
+
+func TestStatementValidate(t *testing.T) {
+	now := time.Now()
+	relationship := newRelationship()
+
+	testCases := []struct {
+		name   string
+		modify func(s *Statement)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(s *Statement) {},
+		},
+		{
+			name:   "other relationship",
+			modify: func(s *Statement) { s.RelationshipID = uuid.New() },
+			err:    "statement relationship ID",
+		},
+		{
+			name:   "other trust domains",
+			modify: func(s *Statement) { s.TrustDomainB = tdC.String() },
+			err:    `statement trust domains "td-a.org" and "td-c.org" do not match the relationship trust domains`,
+		},
+		{
+			name:   "statement made before relationships had a direction",
+			modify: func(s *Statement) { s.Direction = "" },
+		},
+		{
+			name:   "other direction",
+			modify: func(s *Statement) { s.Direction = entity.RelationshipBTrustsA },
+			err:    `statement direction "b_trusts_a" does not match relationship direction "bidirectional"`,
+		},
+		{
+			name:   "other consenting trust domain",
+			modify: func(s *Statement) { s.TrustDomain = tdB.String() },
+			err:    `statement trust domain "td-b.org" does not match trust domain "td-a.org"`,
+		},
+		{
+			name:   "other consent status",
+			modify: func(s *Statement) { s.ConsentStatus = entity.ConsentStatusDenied },
+			err:    `statement consent status "denied" does not match consent status "approved"`,
+		},
+		{
+			name:   "stale timestamp",
+			modify: func(s *Statement) { s.Timestamp = now.Add(-MaxClockSkew - time.Minute) },
+			err:    "is not within 5m0s of the current time",
+		},
+		{
+			name:   "future timestamp",
+			modify: func(s *Statement) { s.Timestamp = now.Add(MaxClockSkew + time.Minute) },
+			err:    "is not within 5m0s of the current time",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			statement := NewStatement(relationship, tdA, entity.ConsentStatusApproved, now)
+			tc.modify(statement)
+
+			err := statement.Validate(relationship, tdA, entity.ConsentStatusApproved, now)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	cert, key := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	signer, ok := key.(crypto.Signer)
+	require.True(t, ok)
+
+	statement := []byte(`{"consent_status":"approved"}`)
+	signature, err := signer.Sign(rand.Reader, cryptoutil.CalculateDigest(statement), crypto.SHA256)
+	require.NoError(t, err)
+
+	chain, err := x509.ParseCertificates(EncodeCertificateChain([]*x509.Certificate{cert}))
+	require.NoError(t, err)
+
+	assert.NoError(t, VerifySignature(statement, signature, chain))
+	assert.ErrorContains(t, VerifySignature([]byte("tampered"), signature, chain), "failed to verify consent signature")
+	assert.EqualError(t, VerifySignature(statement, nil, chain), "consent signature is missing")
+	assert.EqualError(t, VerifySignature(statement, signature, nil), "signing certificate is missing")
+}
+
+func newRelationship() *entity.Relationship {
+	return &entity.Relationship{
+		ID:               uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAName: tdA,
+		TrustDomainBName: tdB,
+	}
+}
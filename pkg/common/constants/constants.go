@@ -1,10 +1,27 @@
 package constants
 
 const (
-	TCPProtocol         = "tcp"
-	HTTPSScheme         = "https"
-	DefaultLogLevel     = "INFO"
-	JSONContentType     = "application/json"
-	Galadriel           = "galadriel"
-	GaladrielServerName = "galadriel-server"
+	TCPProtocol            = "tcp"
+	HTTPSScheme            = "https"
+	DefaultLogLevel        = "INFO"
+	JSONContentType        = "application/json"
+	Galadriel              = "galadriel"
+	GaladrielServerName    = "galadriel-server"
+	GaladrielHarvesterName = "galadriel-harvester"
 )
+
+// Headers sent by the Harvester on every call to Galadriel Server, reporting its version and configuration.
+// Poll intervals are Go duration strings, e.g. "30s".
+const (
+	HarvesterVersionHeader                      = "X-Galadriel-Harvester-Version"
+	HarvesterSpireBundlePollIntervalHeader      = "X-Galadriel-Spire-Bundle-Poll-Interval"
+	HarvesterFederatedBundlesPollIntervalHeader = "X-Galadriel-Federated-Bundles-Poll-Interval"
+)
+
+// DefaultHarvesterInstanceID identifies the harvester instance of the trust domains whose harvester did not report
+// an instance ID when onboarding, i.e. trust domains with a single harvester.
+const DefaultHarvesterInstanceID = "default"
+
+// HarvesterClientIDPathPrefix is the path prefix of the SPIFFE IDs, in the trust domain of the harvester, carried by
+// the TLS client certificates that Galadriel Server issues to harvesters. It is followed by the harvester instance ID.
+const HarvesterClientIDPathPrefix = "/galadriel/harvester/"
@@ -0,0 +1,30 @@
+package cryptoutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+)
+
+// VerifySignature verifies that the signature was computed over the SHA256 digest of the payload
+// with the private key matching the given public key. RSA PKCS #1 v1.5 and ECDSA ASN.1 signatures are supported.
+func VerifySignature(publicKey crypto.PublicKey, payload, signature []byte) error {
+	digest := CalculateDigest(payload)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return fmt.Errorf("invalid RSA signature: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package cryptoutil
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte("payload")
+
+	for _, keyType := range []KeyType{RSA2048, ECP256} {
+		keyType := keyType
+		t.Run(keyType.String(), func(t *testing.T) {
+			signer, err := GenerateSigner(keyType)
+			require.NoError(t, err)
+
+			signature, err := signer.Sign(rand.Reader, CalculateDigest(payload), crypto.SHA256)
+			require.NoError(t, err)
+
+			assert.NoError(t, VerifySignature(signer.Public(), payload, signature))
+			assert.Error(t, VerifySignature(signer.Public(), []byte("tampered"), signature))
+
+			other, err := GenerateSigner(keyType)
+			require.NoError(t, err)
+			assert.Error(t, VerifySignature(other.Public(), payload, signature))
+		})
+	}
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		err = VerifySignature(publicKey, payload, []byte("signature"))
+		assert.EqualError(t, err, "unsupported public key type ed25519.PublicKey")
+	})
+}
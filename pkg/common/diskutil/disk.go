@@ -5,9 +5,10 @@ import (
 	"path/filepath"
 )
 
-// Define the file mode for private files.
+// Define the file modes for private and publicly readable files.
 const (
-	fileModePrivate = 0600
+	fileModePrivate          = 0600
+	fileModePubliclyReadable = 0644
 )
 
 // AtomicWritePrivateFile writes data to a file atomically.
@@ -16,6 +17,12 @@ func AtomicWritePrivateFile(path string, data []byte) error {
 	return atomicWrite(path, data, fileModePrivate)
 }
 
+// AtomicWritePubliclyReadableFile writes data to a file atomically.
+// The file is created with publicly readable permissions (0644).
+func AtomicWritePubliclyReadableFile(path string, data []byte) error {
+	return atomicWrite(path, data, fileModePubliclyReadable)
+}
+
 func atomicWrite(path string, data []byte, mode os.FileMode) error {
 	tmpPath := path + ".tmp"
 
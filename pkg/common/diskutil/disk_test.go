@@ -39,6 +39,12 @@ func TestWriteFile(t *testing.T) {
 			atomicWriteFunc: AtomicWritePrivateFile,
 			expectMode:      0600,
 		},
+		{
+			name:            "basic - AtomicWritePubliclyReadableFile",
+			data:            []byte("Hello, World"),
+			atomicWriteFunc: AtomicWritePubliclyReadableFile,
+			expectMode:      0644,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -0,0 +1,275 @@
+// Package dpop implements proofs of possession of the key that the JWT tokens issued by Galadriel Server are bound to,
+// in the style of OAuth 2.0 Demonstrating Proof of Possession (DPoP, RFC 9449).
+//
+// A proof is a JWS, signed with the bound key and embedding its public JWK, over the method and URL of the HTTP
+// request, the hash of the access token sent along with it, a unique identifier and its issuance time. The tokens
+// are bound to the key by the SHA-256 JWK thumbprint of the key, carried in their `cnf` claim.
+package dpop
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/cryptosigner"
+	"github.com/jmhodges/clock"
+)
+
+const (
+	// HeaderName is the HTTP header carrying the proof.
+	HeaderName = "DPoP"
+
+	// DefaultMaxAge is the default maximum age of the proofs accepted by a Verifier.
+	DefaultMaxAge = 1 * time.Minute
+
+	proofType = "dpop+jwt"
+
+	// clockSkew is the tolerated difference between the clocks of the proof signer and verifier.
+	clockSkew = 5 * time.Second
+)
+
+// supportedAlgorithms are the signature algorithms accepted in proofs, the ones that NewProof signs with.
+var supportedAlgorithms = map[string]bool{
+	string(jose.RS256): true,
+	string(jose.ES256): true,
+}
+
+// ErrRecordProof is returned by a Verifier that failed to record a proof in its ProofStore,
+// which does not tell whether the proof is valid.
+var ErrRecordProof = errors.New("failed to record proof")
+
+// Claims are the claims of a proof.
+type Claims struct {
+	// ID uniquely identifies the proof, acting as a nonce that a Verifier only accepts once.
+	ID string `json:"jti"`
+	// HTTPMethod is the method of the request the proof is sent with.
+	HTTPMethod string `json:"htm"`
+	// HTTPURI is the URL of the request the proof is sent with, without query and fragment.
+	HTTPURI string `json:"htu"`
+	// IssuedAt is the time the proof was created, in seconds since the epoch.
+	IssuedAt int64 `json:"iat"`
+	// AccessTokenHash is the base64url encoded SHA-256 hash of the access token sent with the request, if any.
+	AccessTokenHash string `json:"ath,omitempty"`
+}
+
+// NewProof creates a proof for a request with the given method and URL, signed with the given key.
+// The proof is bound to the access token sent along with the request, when it is not empty.
+func NewProof(signer crypto.Signer, method, requestURL, accessToken string) (string, error) {
+	alg, err := signatureAlgorithm(signer.Public())
+	if err != nil {
+		return "", err
+	}
+
+	htu, err := normalizeURL(requestURL)
+	if err != nil {
+		return "", err
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("failed to generate proof ID: %w", err)
+	}
+
+	claims := Claims{
+		ID:         base64.RawURLEncoding.EncodeToString(jti),
+		HTTPMethod: method,
+		HTTPURI:    htu,
+		IssuedAt:   time.Now().Unix(),
+	}
+	if accessToken != "" {
+		claims.AccessTokenHash = AccessTokenHash(accessToken)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal proof claims: %w", err)
+	}
+
+	opts := (&jose.SignerOptions{EmbedJWK: true}).WithType(proofType)
+	joseSigner, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: cryptosigner.Opaque(signer)}, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create proof signer: %w", err)
+	}
+
+	jws, err := joseSigner.Sign(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign proof: %w", err)
+	}
+
+	return jws.CompactSerialize()
+}
+
+// Thumbprint returns the base64url encoded SHA-256 JWK thumbprint (RFC 7638) of the public key, which tokens
+// bound to the key carry in their `cnf` claim.
+func Thumbprint(publicKey crypto.PublicKey) (string, error) {
+	jwk := jose.JSONWebKey{Key: publicKey}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// AccessTokenHash returns the base64url encoded SHA-256 hash of the access token, which proofs carry in their
+// `ath` claim.
+func AccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// ProofStore records the IDs of the proofs accepted by a Verifier while they are fresh.
+type ProofStore interface {
+	// RecordProof records the ID of a proof until expiresAt. It returns false if the ID is already recorded.
+	RecordProof(ctx context.Context, id string, expiresAt time.Time) (bool, error)
+}
+
+// Verifier verifies proofs, refusing the proofs recorded in its ProofStore, the ones already accepted while
+// they are fresh. Verifiers sharing a ProofStore accept a proof once among them.
+type Verifier struct {
+	maxAge time.Duration
+	store  ProofStore
+	clock  clock.Clock
+}
+
+// NewVerifier creates a Verifier that accepts proofs issued at most maxAge ago, recording them in the given store.
+func NewVerifier(maxAge time.Duration, store ProofStore) *Verifier {
+	return &Verifier{
+		maxAge: maxAge,
+		store:  store,
+		clock:  clock.New(),
+	}
+}
+
+// Verify verifies that the proof was sent with a request with the given method and URL, and along with the given
+// access token, if it is not empty. It returns the JWK thumbprint of the key the proof was signed with.
+func (v *Verifier) Verify(ctx context.Context, proof, method, requestURL, accessToken string) (string, error) {
+	if proof == "" {
+		return "", errors.New("proof is missing")
+	}
+
+	jws, err := jose.ParseSigned(proof)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse proof: %w", err)
+	}
+	if len(jws.Signatures) != 1 {
+		return "", errors.New("proof must have a single signature")
+	}
+
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != proofType {
+		return "", fmt.Errorf("invalid proof type %q", typ)
+	}
+	if !supportedAlgorithms[header.Algorithm] {
+		return "", fmt.Errorf("unsupported proof algorithm %q", header.Algorithm)
+	}
+
+	jwk := header.JSONWebKey
+	if jwk == nil || !jwk.IsPublic() {
+		return "", errors.New("proof must embed the public JWK of its key")
+	}
+
+	payload, err := jws.Verify(jwk.Key)
+	if err != nil {
+		return "", fmt.Errorf("invalid proof signature: %w", err)
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("failed to unmarshal proof claims: %w", err)
+	}
+
+	if claims.HTTPMethod != method {
+		return "", fmt.Errorf("proof method %q does not match request method %q", claims.HTTPMethod, method)
+	}
+
+	htu, err := normalizeURL(requestURL)
+	if err != nil {
+		return "", err
+	}
+	if claims.HTTPURI != htu {
+		return "", fmt.Errorf("proof URL %q does not match request URL %q", claims.HTTPURI, htu)
+	}
+
+	if accessToken != "" {
+		expected := AccessTokenHash(accessToken)
+		if subtle.ConstantTimeCompare([]byte(claims.AccessTokenHash), []byte(expected)) != 1 {
+			return "", errors.New("proof is not bound to the access token")
+		}
+	}
+
+	if err := v.checkFreshness(ctx, &claims); err != nil {
+		return "", err
+	}
+
+	return Thumbprint(jwk.Key)
+}
+
+// checkFreshness checks that the proof was issued recently and was not accepted before.
+func (v *Verifier) checkFreshness(ctx context.Context, claims *Claims) error {
+	if claims.ID == "" {
+		return errors.New("proof ID is missing")
+	}
+
+	now := v.clock.Now()
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if issuedAt.After(now.Add(clockSkew)) {
+		return fmt.Errorf("proof issued in the future at %s", issuedAt)
+	}
+	if issuedAt.Before(now.Add(-v.maxAge - clockSkew)) {
+		return fmt.Errorf("proof issued at %s is too old", issuedAt)
+	}
+
+	recorded, err := v.store.RecordProof(ctx, claims.ID, issuedAt.Add(v.maxAge+clockSkew))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRecordProof, err)
+	}
+	if !recorded {
+		return errors.New("proof was already used")
+	}
+
+	return nil
+}
+
+// signatureAlgorithm returns the algorithm that proofs signed with the key use.
+func signatureAlgorithm(publicKey crypto.PublicKey) (jose.SignatureAlgorithm, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return jose.RS256, nil
+	case *ecdsa.PublicKey:
+		if key.Curve == elliptic.P256() {
+			return jose.ES256, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported proof key type %T", publicKey)
+}
+
+// normalizeURL returns the URL without its query and fragment, as carried by proofs.
+func normalizeURL(requestURL string) (string, error) {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid request URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid request URL %q: scheme and host are required", requestURL)
+	}
+
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String(), nil
+}
@@ -0,0 +1,181 @@
+package dpop
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/cryptosigner"
+	"github.com/jmhodges/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	requestURL  = "https://galadriel-server:8085/trust-domain/td1.org/bundles/sync"
+	accessToken = "access-token"
+)
+
+func TestVerify(t *testing.T) {
+	for _, keyType := range []cryptoutil.KeyType{cryptoutil.RSA2048, cryptoutil.ECP256} {
+		t.Run(keyType.String(), func(t *testing.T) {
+			key, err := cryptoutil.GenerateSigner(keyType)
+			require.NoError(t, err)
+
+			proof, err := NewProof(key, http.MethodPost, requestURL+"?param=value", accessToken)
+			require.NoError(t, err)
+
+			thumbprint, err := NewVerifier(DefaultMaxAge, newMemoryProofStore()).Verify(context.Background(), proof, http.MethodPost, requestURL, accessToken)
+			require.NoError(t, err)
+
+			expected, err := Thumbprint(key.Public())
+			require.NoError(t, err)
+			assert.Equal(t, expected, thumbprint)
+		})
+	}
+}
+
+func TestVerifyFailures(t *testing.T) {
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+
+	newProof := func(t *testing.T, method, url, token string) string {
+		proof, err := NewProof(key, method, url, token)
+		require.NoError(t, err)
+		return proof
+	}
+
+	t.Run("Missing proof", func(t *testing.T) {
+		_, err := NewVerifier(DefaultMaxAge, newMemoryProofStore()).Verify(context.Background(), "", http.MethodGet, requestURL, "")
+		assert.EqualError(t, err, "proof is missing")
+	})
+
+	t.Run("Method mismatch", func(t *testing.T) {
+		_, err := NewVerifier(DefaultMaxAge, newMemoryProofStore()).Verify(context.Background(), newProof(t, http.MethodGet, requestURL, ""), http.MethodPost, requestURL, "")
+		assert.EqualError(t, err, `proof method "GET" does not match request method "POST"`)
+	})
+
+	t.Run("URL mismatch", func(t *testing.T) {
+		_, err := NewVerifier(DefaultMaxAge, newMemoryProofStore()).Verify(context.Background(), newProof(t, http.MethodGet, "https://attacker:8085/trust-domain/td1.org/bundles/sync", ""), http.MethodGet, requestURL, "")
+		assert.ErrorContains(t, err, "does not match request URL")
+	})
+
+	t.Run("Access token mismatch", func(t *testing.T) {
+		_, err := NewVerifier(DefaultMaxAge, newMemoryProofStore()).Verify(context.Background(), newProof(t, http.MethodGet, requestURL, "other-token"), http.MethodGet, requestURL, accessToken)
+		assert.EqualError(t, err, "proof is not bound to the access token")
+	})
+
+	t.Run("Replayed proof", func(t *testing.T) {
+		verifier := NewVerifier(DefaultMaxAge, newMemoryProofStore())
+		proof := newProof(t, http.MethodGet, requestURL, accessToken)
+
+		_, err := verifier.Verify(context.Background(), proof, http.MethodGet, requestURL, accessToken)
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(context.Background(), proof, http.MethodGet, requestURL, accessToken)
+		assert.EqualError(t, err, "proof was already used")
+	})
+
+	t.Run("Proof replayed to another verifier sharing the store", func(t *testing.T) {
+		store := newMemoryProofStore()
+		proof := newProof(t, http.MethodGet, requestURL, accessToken)
+
+		_, err := NewVerifier(DefaultMaxAge, store).Verify(context.Background(), proof, http.MethodGet, requestURL, accessToken)
+		require.NoError(t, err)
+
+		_, err = NewVerifier(DefaultMaxAge, store).Verify(context.Background(), proof, http.MethodGet, requestURL, accessToken)
+		assert.EqualError(t, err, "proof was already used")
+	})
+
+	t.Run("Proof that cannot be recorded", func(t *testing.T) {
+		verifier := NewVerifier(DefaultMaxAge, failingProofStore{err: errors.New("datastore is down")})
+
+		_, err := verifier.Verify(context.Background(), newProof(t, http.MethodGet, requestURL, ""), http.MethodGet, requestURL, "")
+		assert.ErrorIs(t, err, ErrRecordProof)
+		assert.EqualError(t, err, "failed to record proof: datastore is down")
+	})
+
+	t.Run("Stale proof", func(t *testing.T) {
+		clk := clock.NewFake()
+		clk.Set(time.Now().Add(DefaultMaxAge + time.Minute))
+		verifier := NewVerifier(DefaultMaxAge, newMemoryProofStore())
+		verifier.clock = clk
+
+		_, err := verifier.Verify(context.Background(), newProof(t, http.MethodGet, requestURL, ""), http.MethodGet, requestURL, "")
+		assert.ErrorContains(t, err, "is too old")
+	})
+
+	t.Run("Proof issued in the future", func(t *testing.T) {
+		clk := clock.NewFake()
+		clk.Set(time.Now().Add(-time.Minute))
+		verifier := NewVerifier(DefaultMaxAge, newMemoryProofStore())
+		verifier.clock = clk
+
+		_, err := verifier.Verify(context.Background(), newProof(t, http.MethodGet, requestURL, ""), http.MethodGet, requestURL, "")
+		assert.ErrorContains(t, err, "proof issued in the future")
+	})
+
+	t.Run("Not a proof", func(t *testing.T) {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: cryptosigner.Opaque(key)}, &jose.SignerOptions{EmbedJWK: true})
+		require.NoError(t, err)
+		jws, err := signer.Sign([]byte(`{"jti":"id","htm":"GET","htu":"` + requestURL + `"}`))
+		require.NoError(t, err)
+		token, err := jws.CompactSerialize()
+		require.NoError(t, err)
+
+		_, err = NewVerifier(DefaultMaxAge, newMemoryProofStore()).Verify(context.Background(), token, http.MethodGet, requestURL, "")
+		assert.EqualError(t, err, `invalid proof type ""`)
+	})
+
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		rsaKey, err := cryptoutil.GenerateSigner(cryptoutil.RSA2048)
+		require.NoError(t, err)
+
+		opts := (&jose.SignerOptions{EmbedJWK: true}).WithType(proofType)
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS256, Key: cryptosigner.Opaque(rsaKey)}, opts)
+		require.NoError(t, err)
+		jws, err := signer.Sign([]byte(`{"jti":"id","htm":"GET","htu":"` + requestURL + `"}`))
+		require.NoError(t, err)
+		token, err := jws.CompactSerialize()
+		require.NoError(t, err)
+
+		_, err = NewVerifier(DefaultMaxAge, newMemoryProofStore()).Verify(context.Background(), token, http.MethodGet, requestURL, "")
+		assert.EqualError(t, err, `unsupported proof algorithm "PS256"`)
+	})
+}
+
+// memoryProofStore is a ProofStore that records the proofs in memory.
+type memoryProofStore struct {
+	mu     sync.Mutex
+	proofs map[string]time.Time
+}
+
+func newMemoryProofStore() *memoryProofStore {
+	return &memoryProofStore{proofs: make(map[string]time.Time)}
+}
+
+func (s *memoryProofStore) RecordProof(_ context.Context, id string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.proofs[id]; ok {
+		return false, nil
+	}
+	s.proofs[id] = expiresAt
+
+	return true, nil
+}
+
+// failingProofStore is a ProofStore that fails to record the proofs.
+type failingProofStore struct {
+	err error
+}
+
+func (s failingProofStore) RecordProof(context.Context, string, time.Time) (bool, error) {
+	return false, s.err
+}
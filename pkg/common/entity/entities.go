@@ -15,12 +15,59 @@ const (
 	ConsentStatusPending  ConsentStatus = "pending"
 )
 
+// RelationshipDirection tells which trust domains of a relationship trust the other one, i.e. get its bundle.
+type RelationshipDirection string
+
+const (
+	// RelationshipBidirectional means that both trust domains of the relationship trust each other.
+	RelationshipBidirectional RelationshipDirection = "bidirectional"
+	// RelationshipATrustsB means that trust domain A trusts trust domain B, which doesn't trust A.
+	RelationshipATrustsB RelationshipDirection = "a_trusts_b"
+	// RelationshipBTrustsA means that trust domain B trusts trust domain A, which doesn't trust B.
+	RelationshipBTrustsA RelationshipDirection = "b_trusts_a"
+)
+
+// BundleVerificationStatus is the result of the verification of a bundle signature by the Galadriel Server.
+type BundleVerificationStatus string
+
+const (
+	// BundleVerificationSkipped means that no bundle verifiers are configured for the trust domain of the bundle.
+	BundleVerificationSkipped BundleVerificationStatus = "skipped"
+	// BundleVerificationVerified means that the bundle signature was verified by one of the configured verifiers.
+	BundleVerificationVerified BundleVerificationStatus = "verified"
+)
+
+// BundleUploadStatus is the outcome of the last bundle upload of a harvester instance.
+type BundleUploadStatus string
+
+const (
+	// BundleUploadAccepted means that the uploaded bundle was stored as the bundle of the trust domain.
+	BundleUploadAccepted BundleUploadStatus = "accepted"
+	// BundleUploadStale means that the uploaded bundle was discarded because the stored bundle of the trust domain,
+	// uploaded by another harvester instance, has a higher sequence number.
+	BundleUploadStale BundleUploadStatus = "stale"
+	// BundleUploadRejected means that the uploaded bundle did not pass the validation or verification of the server.
+	BundleUploadRejected BundleUploadStatus = "rejected"
+)
+
 type TrustDomain struct {
 	ID          uuid.NullUUID
 	Name        spiffeid.TrustDomain
 	Description string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// HarvesterSPIFFEID is the SPIFFE ID that a harvester of the trust domain must present in its X509-SVID
+	// to onboard without a join token. It is the zero ID when it is not pinned, and SVID onboarding is disabled.
+	HarvesterSPIFFEID spiffeid.ID
+	// OnboardingBundle is the SPIFFE bundle used to verify the X509-SVID that a harvester presents to onboard.
+	// When empty, the bundle of the trust domain stored by the server is used instead.
+	OnboardingBundle []byte
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// SVIDOnboardingEnabled tells if harvesters of the trust domain can onboard by presenting an X509-SVID, which
+// requires a pinned harvester SPIFFE ID.
+func (td *TrustDomain) SVIDOnboardingEnabled() bool {
+	return !td.HarvesterSPIFFEID.IsZero()
 }
 
 type Relationship struct {
@@ -31,21 +78,43 @@ type Relationship struct {
 	TrustDomainBName    spiffeid.TrustDomain
 	TrustDomainAConsent ConsentStatus
 	TrustDomainBConsent ConsentStatus
+	Direction           RelationshipDirection // Bidirectional when empty.
+	FederationGroupID   uuid.NullUUID         // Group whose reconciler manages the relationship, invalid if created by an admin.
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
 
 type JoinToken struct {
 	ID              uuid.NullUUID
-	Token           string
-	Used            bool
+	Token           string // Token handed over to the Harvester, only known when generated and never stored.
+	TokenPrefix     string // Non-secret part of the token, by which it is looked up.
+	TokenSalt       []byte
+	TokenHash       []byte // Salted hash of the secret part of the token.
+	Used            bool   // Set once the token was used MaxUses times.
 	TrustDomainID   uuid.UUID
 	TrustDomainName spiffeid.TrustDomain
+	MaxUses         int    // Number of onboardings the token allows, 1 when zero.
+	UseCount        int    // Number of onboardings done with the token.
+	SourceCIDR      string // Network the onboarding calls must come from, any when empty.
 	ExpiresAt       time.Time
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
+// RelationshipConsent is the signed statement with which a trust domain consented to a relationship.
+type RelationshipConsent struct {
+	ID                 uuid.NullUUID
+	RelationshipID     uuid.UUID
+	TrustDomainID      uuid.UUID
+	TrustDomainName    spiffeid.TrustDomain
+	ConsentStatus      ConsentStatus
+	Statement          []byte // JSON encoded consent statement, as signed by the trust domain.
+	Signature          []byte // Raw signature of the statement.
+	SigningCertificate []byte // DER encoded certificate chain, leaf first.
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
 // Bundle represents a SPIFFE Trust bundle along with its digest.
 type Bundle struct {
 	ID                 uuid.NullUUID
@@ -55,6 +124,100 @@ type Bundle struct {
 	SigningCertificate []byte
 	TrustDomainID      uuid.UUID
 	TrustDomainName    spiffeid.TrustDomain
+	VerificationStatus BundleVerificationStatus // Result of the signature verification done by the server.
+	VerifiedBy         string                   // Name of the verifier that verified the signature, if any.
+	AdminManaged       bool                     // Set by an admin out of band, Harvester uploads are refused.
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
+
+// WebhookDeadLetter is a webhook notification that could not be delivered after exhausting all the attempts.
+type WebhookDeadLetter struct {
+	ID        uuid.NullUUID
+	Webhook   string // Name of the webhook target.
+	URL       string
+	EventID   uuid.UUID
+	EventType string
+	Payload   []byte // JSON payload of the notification.
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+}
+
+// BundleSyncState is the digest of a federated bundle that a harvester instance of a trust domain reported holding
+// in SPIRE on its last bundle sync.
+type BundleSyncState struct {
+	ID                   uuid.NullUUID
+	TrustDomainID        uuid.UUID            // Trust domain whose harvester holds the bundle.
+	HarvesterInstanceID  string               // Harvester instance that holds the bundle.
+	FederatedTrustDomain spiffeid.TrustDomain // Trust domain of the held bundle.
+	Digest               []byte               // SHA-256 digest of the held bundle.
+	DigestUpdatedAt      time.Time            // Time the harvester first reported the current digest.
+	ReportedAt           time.Time            // Time of the last bundle sync that reported the bundle.
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// Harvester is the metadata that a harvester instance of a trust domain reported on its last authenticated call to
+// the server. A trust domain has one harvester instance per SPIRE Server of its deployment.
+type Harvester struct {
+	ID                           uuid.NullUUID
+	TrustDomainID                uuid.UUID
+	TrustDomainName              spiffeid.TrustDomain
+	InstanceID                   string // Identifies the instance among the harvesters of the trust domain.
+	LastSeenAt                   time.Time
+	SourceAddress                string // Remote address of the last call.
+	Version                      string // Version reported by the harvester, empty if it didn't report one.
+	SpireBundlePollInterval      time.Duration
+	FederatedBundlesPollInterval time.Duration
+	TokenExpiresAt               time.Time // Expiry of the JWT used on the last call.
+	LastBundleUploadAt           time.Time // Zero if the instance never uploaded a bundle.
+	LastBundleDigest             []byte
+	LastBundleUploadStatus       BundleUploadStatus
+	CreatedAt                    time.Time
+	UpdatedAt                    time.Time
+}
+
+// SigningKey is a private key of the server KeyManager stored in the datastore, so that it is shared by all the
+// Galadriel Server replicas.
+type SigningKey struct {
+	ID         string
+	PrivateKey []byte // PEM encoded PKCS #8 private key.
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ExternalTrustDomain is a trust domain that doesn't run a Harvester, whose bundle the Galadriel Server fetches
+// periodically from the SPIFFE bundle endpoint of the trust domain.
+type ExternalTrustDomain struct {
+	ID                    uuid.NullUUID
+	TrustDomainID         uuid.UUID
+	BundleEndpointURL     string
+	BundleEndpointProfile string // SPIFFE bundle endpoint profile, https_web or https_spiffe.
+	EndpointSPIFFEID      string // SPIFFE ID of the bundle endpoint server, required by the https_spiffe profile.
+	BootstrapBundle       []byte // SPIFFE bundle of the trust domain used until a bundle is fetched.
+	LastRefreshAt         time.Time
+	LastRefreshError      string // Error of the last refresh, empty if it succeeded.
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// FederationGroup is a set of trust domains that are kept fully federated: the federation group reconciler creates
+// a relationship between each pair of members, and removes it when one of them leaves the group.
+type FederationGroup struct {
+	ID             uuid.NullUUID
+	Name           string
+	Description    string
+	DefaultConsent ConsentStatus // Consent status of both members in the relationships created for the group.
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FederationGroupMember is the membership of a trust domain in a federation group.
+type FederationGroupMember struct {
+	ID                uuid.NullUUID
+	FederationGroupID uuid.UUID
+	TrustDomainID     uuid.UUID
+	TrustDomainName   spiffeid.TrustDomain
+	CreatedAt         time.Time
+}
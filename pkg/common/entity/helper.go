@@ -1,9 +1,37 @@
 package entity
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
 )
 
+// ParseRelationshipDirection parses a relationship direction, bidirectional when empty.
+func ParseRelationshipDirection(s string) (RelationshipDirection, error) {
+	switch d := RelationshipDirection(s); d {
+	case "":
+		return RelationshipBidirectional, nil
+	case RelationshipBidirectional, RelationshipATrustsB, RelationshipBTrustsA:
+		return d, nil
+	default:
+		return "", fmt.Errorf("invalid relationship direction %q, must be one of %q, %q or %q", s, RelationshipBidirectional, RelationshipATrustsB, RelationshipBTrustsA)
+	}
+}
+
+// Trusts tells whether the trust domain with the given ID, which is one of the trust domains of the relationship,
+// trusts its peer in the relationship, i.e. gets the bundle of its peer, according to the direction of the relationship.
+// It does not take the consent of the trust domains into account.
+func (r *Relationship) Trusts(trustDomainID uuid.UUID) bool {
+	switch r.Direction {
+	case RelationshipATrustsB:
+		return trustDomainID == r.TrustDomainAID
+	case RelationshipBTrustsA:
+		return trustDomainID == r.TrustDomainBID
+	default:
+		return trustDomainID == r.TrustDomainAID || trustDomainID == r.TrustDomainBID
+	}
+}
+
 // FilterRelationships filters a slice of Relationship entities based on a trust domain ID and consent status.
 // If the trust domain ID is nil, it filters based on the consent status only.
 // If the trust domain ID is not nil, it filters based on both the trust domain ID and the consent status.
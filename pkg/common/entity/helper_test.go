@@ -38,3 +38,39 @@ func TestFilterRelationships(t *testing.T) {
 	assert.Equal(t, relationships[0].TrustDomainAConsent, filtered[0].TrustDomainAConsent)
 	assert.Equal(t, relationships[0].TrustDomainBConsent, filtered[0].TrustDomainBConsent)
 }
+
+func TestRelationshipTrusts(t *testing.T) {
+	tdA, tdB, other := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		direction RelationshipDirection
+		aTrustsB  bool
+		bTrustsA  bool
+	}{
+		{direction: "", aTrustsB: true, bTrustsA: true},
+		{direction: RelationshipBidirectional, aTrustsB: true, bTrustsA: true},
+		{direction: RelationshipATrustsB, aTrustsB: true, bTrustsA: false},
+		{direction: RelationshipBTrustsA, aTrustsB: false, bTrustsA: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.direction), func(t *testing.T) {
+			r := &Relationship{TrustDomainAID: tdA, TrustDomainBID: tdB, Direction: tt.direction}
+			assert.Equal(t, tt.aTrustsB, r.Trusts(tdA))
+			assert.Equal(t, tt.bTrustsA, r.Trusts(tdB))
+			assert.False(t, r.Trusts(other))
+		})
+	}
+}
+
+func TestParseRelationshipDirection(t *testing.T) {
+	d, err := ParseRelationshipDirection("")
+	assert.NoError(t, err)
+	assert.Equal(t, RelationshipBidirectional, d)
+
+	d, err = ParseRelationshipDirection("a_trusts_b")
+	assert.NoError(t, err)
+	assert.Equal(t, RelationshipATrustsB, d)
+
+	_, err = ParseRelationshipDirection("sideways")
+	assert.EqualError(t, err, `invalid relationship direction "sideways", must be one of "bidirectional", "a_trusts_b" or "b_trusts_a"`)
+}
@@ -19,13 +19,22 @@ func (td *TrustDomain) String() string {
 }
 
 func (td *TrustDomain) ConsoleString() string {
-	return fmt.Sprintf(`TrustDomain:
+	str := fmt.Sprintf(`TrustDomain:
 %sID: %s
 %sName: %s
 %sDescription: %s`,
 		indent, td.ID.UUID,
 		indent, td.Name,
 		indent, td.Description)
+
+	if !td.HarvesterSPIFFEID.IsZero() {
+		str += fmt.Sprintf("\n%sHarvester SPIFFE ID: %s", indent, td.HarvesterSPIFFEID)
+	}
+	if len(td.OnboardingBundle) > 0 {
+		str += fmt.Sprintf("\n%sOnboarding Bundle: set", indent)
+	}
+
+	return str
 }
 
 func (rel *Relationship) String() string {
@@ -37,6 +46,7 @@ func (rel *Relationship) String() string {
 %sTrustDomainBName: %s
 %sTrustDomainAConsent: %s
 %sTrustDomainBConsent: %s
+%sDirection: %s
 %sCreatedAt: %s
 %sUpdatedAt: %s`,
 		indent, rel.ID.UUID,
@@ -46,28 +56,36 @@ func (rel *Relationship) String() string {
 		indent, rel.TrustDomainBName,
 		indent, rel.TrustDomainAConsent,
 		indent, rel.TrustDomainBConsent,
+		indent, rel.Direction,
 		indent, rel.CreatedAt,
 		indent, rel.UpdatedAt)
 }
 
 func (rel *Relationship) ConsoleString() string {
+	direction := rel.Direction
+	if direction == "" {
+		direction = RelationshipBidirectional
+	}
+
 	return fmt.Sprintf(`Relationship:
 %sID: %s
 %sTrust Domain A: %s
 %sTrust Domain A Consent Status: %s
 %sTrust Domain B: %s
-%sTrust Domain B Consent Status: %s`,
+%sTrust Domain B Consent Status: %s
+%sDirection: %s`,
 		indent, rel.ID.UUID,
 		indent, rel.TrustDomainAName,
 		indent, rel.TrustDomainAConsent,
 		indent, rel.TrustDomainBName,
-		indent, rel.TrustDomainBConsent)
+		indent, rel.TrustDomainBConsent,
+		indent, direction)
 }
 
 func (jt *JoinToken) String() string {
 	return fmt.Sprintf(`JoinToken:
 %sID: %s
-%sToken: %s
+%sTokenPrefix: %s
 %sUsed: %t
 %sTrustDomainID: %s
 %sTrustDomainName: %s
@@ -75,7 +93,7 @@ func (jt *JoinToken) String() string {
 %sCreatedAt: %s
 %sUpdatedAt: %s`,
 		indent, jt.ID.UUID,
-		indent, jt.Token,
+		indent, jt.TokenPrefix,
 		indent, jt.Used,
 		indent, jt.TrustDomainID,
 		indent, jt.TrustDomainName,
@@ -85,7 +103,10 @@ func (jt *JoinToken) String() string {
 }
 
 func (jt *JoinToken) ConsoleString() string {
-	return fmt.Sprintf("Token: %s\n", jt.Token)
+	if !jt.ID.Valid {
+		return fmt.Sprintf("Token: %s\n", jt.Token)
+	}
+	return fmt.Sprintf("Token: %s\nID: %s\n", jt.Token, jt.ID.UUID)
 }
 
 func (b *Bundle) String() string {
@@ -28,6 +28,38 @@ type JWTParams struct {
 	Subject  spiffeid.TrustDomain
 	Audience []string
 	TTL      time.Duration
+
+	// InstanceID identifies the harvester instance of the subject trust domain the token is issued to.
+	// It is omitted from the token when empty.
+	InstanceID string
+
+	// KeyThumbprint is the JWK thumbprint of the harvester key the token is bound to, which the harvester proves the
+	// possession of on every request. The token is not bound to a key when it is empty.
+	KeyThumbprint string
+}
+
+// Claims are the claims of the JWT tokens issued by Galadriel Server.
+type Claims struct {
+	jwt.RegisteredClaims
+
+	InstanceID string `json:"instance_id,omitempty"`
+
+	// Confirmation binds the token to the key of the harvester it was issued to, if any.
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+}
+
+// Confirmation is the confirmation claim (RFC 7800) of a token bound to a key.
+type Confirmation struct {
+	// JWKThumbprint is the base64url encoded SHA-256 JWK thumbprint of the key.
+	JWKThumbprint string `json:"jkt"`
+}
+
+// KeyThumbprint returns the JWK thumbprint of the key the token is bound to, empty if it is not bound to a key.
+func (c *Claims) KeyThumbprint() string {
+	if c.Confirmation == nil {
+		return ""
+	}
+	return c.Confirmation.JWKThumbprint
 }
 
 // Config is the configuration for the JWTCA
@@ -75,15 +107,21 @@ func (ca *JWTCA) IssueJWT(ctx context.Context, params *JWTParams) (string, error
 	expiresAt := ca.clk.Now().Add(params.TTL)
 	now := ca.clk.Now()
 
-	registeredClaims := jwt.RegisteredClaims{
-		Issuer:    params.Issuer,
-		Subject:   params.Subject.String(),
-		Audience:  params.Audience,
-		IssuedAt:  jwt.NewNumericDate(now),
-		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    params.Issuer,
+			Subject:   params.Subject.String(),
+			Audience:  params.Audience,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		InstanceID: params.InstanceID,
+	}
+	if params.KeyThumbprint != "" {
+		claims.Confirmation = &Confirmation{JWKThumbprint: params.KeyThumbprint}
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, registeredClaims)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
 	token.Header[kidHeader] = ca.kid
 	signedToken, err := token.SignedString(ca.signer)
 	if err != nil {
@@ -59,17 +59,19 @@ func TestJWTCAIssueJWT(t *testing.T) {
 	require.NoError(t, err)
 
 	params := &JWTParams{
-		Issuer:   testIssuer,
-		Subject:  spiffeid.RequireTrustDomainFromString("test-domain"),
-		Audience: []string{"test-audience-1", "test-audience-2"},
-		TTL:      time.Minute,
+		Issuer:        testIssuer,
+		Subject:       spiffeid.RequireTrustDomainFromString("test-domain"),
+		Audience:      []string{"test-audience-1", "test-audience-2"},
+		TTL:           time.Minute,
+		InstanceID:    "test-instance",
+		KeyThumbprint: "test-thumbprint",
 	}
 
 	token, err := ca.IssueJWT(context.Background(), params)
 	require.NoError(t, err)
 	require.NotNil(t, token)
 
-	claims := &jwt.RegisteredClaims{}
+	claims := &Claims{}
 	getServerPublicKey := func(token *jwt.Token) (interface{}, error) { return ca.signer.Public(), nil }
 	parsed, err := jwt.ParseWithClaims(token, claims, getServerPublicKey)
 
@@ -87,4 +89,6 @@ func TestJWTCAIssueJWT(t *testing.T) {
 	assert.Equal(t, params.Subject.String(), claims.Subject)
 	assert.Equal(t, params.Audience, audience)
 	assert.Equal(t, jwt.NewNumericDate(ca.clk.Now()), claims.IssuedAt)
+	assert.Equal(t, params.InstanceID, claims.InstanceID)
+	assert.Equal(t, params.KeyThumbprint, claims.KeyThumbprint())
 }
@@ -13,7 +13,7 @@ import (
 // Validator validates JWT tokens using a public key.
 type Validator interface {
 	// ValidateToken ValidateJWT validates a JWT and returns the claims.
-	ValidateToken(context.Context, string) (*jwt.RegisteredClaims, error)
+	ValidateToken(context.Context, string) (*Claims, error)
 }
 
 type ValidatorConfig struct {
@@ -34,12 +34,12 @@ func NewDefaultJWTValidator(c *ValidatorConfig) *DefaultJWTValidator {
 	}
 }
 
-func (v *DefaultJWTValidator) ValidateToken(ctx context.Context, token string) (*jwt.RegisteredClaims, error) {
+func (v *DefaultJWTValidator) ValidateToken(ctx context.Context, token string) (*Claims, error) {
 	if token == "" {
 		return nil, errors.New("token is empty")
 	}
 
-	claims := &jwt.RegisteredClaims{}
+	claims := &Claims{}
 	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
 		return v.getPublicKey(ctx, token)
 	})
@@ -59,7 +59,7 @@ func (v *DefaultJWTValidator) ValidateToken(ctx context.Context, token string) (
 	return claims, nil
 }
 
-func (v *DefaultJWTValidator) validAudience(claims *jwt.RegisteredClaims) bool {
+func (v *DefaultJWTValidator) validAudience(claims *Claims) bool {
 	for _, aud := range v.expectedAudience {
 		ok := claims.VerifyAudience(aud, true)
 		if !ok {
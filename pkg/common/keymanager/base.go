@@ -96,7 +96,7 @@ func (b *base) GetKey(ctx context.Context, id string) (Key, error) {
 
 	entry, ok := b.entries[id]
 	if !ok {
-		return nil, fmt.Errorf("no such key %q", id)
+		return nil, fmt.Errorf("%w %q", ErrKeyNotFound, id)
 	}
 
 	return entry, nil
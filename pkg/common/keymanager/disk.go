@@ -95,16 +95,10 @@ func (d *Disk) saveKeysToDisk() error {
 
 	keys := make(map[string]string)
 	for id, entry := range d.entries {
-		keyBytes, err := x509.MarshalPKCS8PrivateKey(entry.PrivateKey)
+		keyPEM, err := encodePrivateKey(entry.PrivateKey)
 		if err != nil {
-			return fmt.Errorf("failed to marshal private key: %w", err)
+			return err
 		}
-
-		// Encode PEM block as string
-		keyPEM := pem.EncodeToMemory(&pem.Block{
-			Type:  "PRIVATE KEY",
-			Bytes: keyBytes,
-		})
 		keys[id] = string(keyPEM)
 	}
 
@@ -120,6 +114,19 @@ func (d *Disk) saveKeysToDisk() error {
 	return nil
 }
 
+// encodePrivateKey encodes the private key as a PKCS #8 PEM block.
+func encodePrivateKey(signer crypto.Signer) ([]byte, error) {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: keyBytes,
+	}), nil
+}
+
 func convertToSigner(keyBytes []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(keyBytes)
 	if block == nil {
@@ -0,0 +1,100 @@
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeyEncryptionKeySize is the size in bytes of the key-encryption key of an encrypted Store (AES-256).
+const KeyEncryptionKeySize = 32
+
+// encryptedKeyPrefix identifies the format of the keys encrypted by an encrypted Store.
+var encryptedKeyPrefix = []byte("galadriel-aes256gcm-v1:")
+
+// encryptedStore is a Store that encrypts the keys with a key-encryption key before handing them to
+// the wrapped Store, so that the wrapped Store, e.g. the datastore, never holds plaintext private keys.
+type encryptedStore struct {
+	store Store
+	aead  cipher.AEAD
+}
+
+// NewEncryptedStore creates a Store that encrypts the keys stored in the given Store with AES-256-GCM,
+// using the given 32 bytes key-encryption key. The ID of a key is authenticated along with it, so that
+// an encrypted key cannot be swapped for another one in the wrapped Store.
+func NewEncryptedStore(store Store, kek []byte) (Store, error) {
+	if len(kek) != KeyEncryptionKeySize {
+		return nil, fmt.Errorf("key-encryption key must be %d bytes long, got %d bytes", KeyEncryptionKeySize, len(kek))
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AEAD: %w", err)
+	}
+
+	return &encryptedStore{
+		store: store,
+		aead:  aead,
+	}, nil
+}
+
+// PutKey encrypts the key and stores it in the wrapped Store.
+func (s *encryptedStore) PutKey(ctx context.Context, id string, keyPEM []byte) error {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := append([]byte{}, encryptedKeyPrefix...)
+	sealed = append(sealed, nonce...)
+	sealed = s.aead.Seal(sealed, nonce, keyPEM, []byte(id))
+
+	return s.store.PutKey(ctx, id, sealed)
+}
+
+// GetKeys returns the keys of the wrapped Store, decrypted.
+func (s *encryptedStore) GetKeys(ctx context.Context) (map[string][]byte, error) {
+	sealedKeys, err := s.store.GetKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string][]byte, len(sealedKeys))
+	for id, sealed := range sealedKeys {
+		keyPEM, err := s.open(id, sealed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key %q: %w", id, err)
+		}
+		keys[id] = keyPEM
+	}
+
+	return keys, nil
+}
+
+// DeleteKey deletes the key from the wrapped Store.
+func (s *encryptedStore) DeleteKey(ctx context.Context, id string) error {
+	return s.store.DeleteKey(ctx, id)
+}
+
+func (s *encryptedStore) open(id string, sealed []byte) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, encryptedKeyPrefix) {
+		return nil, errors.New("key is not encrypted")
+	}
+	sealed = sealed[len(encryptedKeyPrefix):]
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("encrypted key is too short")
+	}
+
+	return s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(id))
+}
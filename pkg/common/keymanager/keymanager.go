@@ -5,10 +5,14 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 )
 
+// ErrKeyNotFound is returned when the requested key does not exist.
+var ErrKeyNotFound = errors.New("no such key")
+
 // KeyManager provides a common interface for managing keys.
 type KeyManager interface {
 	// GenerateKey generates a new key with the given ID and key type.
@@ -16,7 +20,7 @@ type KeyManager interface {
 	GenerateKey(ctx context.Context, id string, keyType cryptoutil.KeyType) (Key, error)
 
 	// GetKey returns the key with the given ID. If the key id does not exist,
-	// an error is returned.
+	// an error wrapping ErrKeyNotFound is returned.
 	GetKey(ctx context.Context, id string) (Key, error)
 
 	// GetKeys returns all keys managed by the Memory.
@@ -0,0 +1,103 @@
+package keymanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+)
+
+// Store persists the keys of a Shared key manager.
+type Store interface {
+	// PutKey stores the PEM encoded private key with the given ID, overwriting the key with that ID if it exists.
+	PutKey(ctx context.Context, id string, keyPEM []byte) error
+
+	// GetKeys returns all the stored PEM encoded private keys, indexed by ID.
+	GetKeys(ctx context.Context) (map[string][]byte, error)
+
+	// DeleteKey deletes the key with the given ID. Deleting a key that does not exist is not an error.
+	DeleteKey(ctx context.Context, id string) error
+}
+
+// Shared extends the base KeyManager to store keys in a Store shared by several processes,
+// e.g. the replicas of a highly available Galadriel Server. The keys are cached in memory and
+// looked up in the cache only, the keys generated or deleted by another process are loaded from
+// the Store when Reload is called.
+type Shared struct {
+	*base
+
+	store Store
+}
+
+// NewSharedKeyManager creates a new Shared key manager that stores keys in the given Store.
+func NewSharedKeyManager(generator Generator, store Store) *Shared {
+	return &Shared{
+		base: newBase(&Config{
+			Generator: generator,
+		}),
+		store: store,
+	}
+}
+
+// GenerateKey generates a new key and stores it in the Store.
+func (s *Shared) GenerateKey(ctx context.Context, keyID string, keyType cryptoutil.KeyType) (Key, error) {
+	key, err := s.base.GenerateKey(ctx, keyID, keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := encodePrivateKey(key.Signer())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.PutKey(ctx, keyID, keyPEM); err != nil {
+		return nil, fmt.Errorf("failed to store key %q: %w", keyID, err)
+	}
+
+	return key, nil
+}
+
+// DeleteKey deletes the key with the given ID from the Store and from the cache.
+// The other processes drop the key from their cache when they reload the keys.
+func (s *Shared) DeleteKey(ctx context.Context, id string) error {
+	if err := s.store.DeleteKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete key %q: %w", id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, id)
+
+	return nil
+}
+
+// Reload replaces the cached keys with the keys in the Store. The cached keys are kept when it fails.
+func (s *Shared) Reload(ctx context.Context) error {
+	keys, err := s.store.GetKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load keys from store: %w", err)
+	}
+
+	entries := make(map[string]*KeyEntry, len(keys))
+	for id, keyPEM := range keys {
+		signer, err := convertToSigner(keyPEM)
+		if err != nil {
+			return fmt.Errorf("failed to create key entry %q: %w", id, err)
+		}
+
+		entries[id] = &KeyEntry{
+			PrivateKey: signer,
+			PublicKey:  signer.Public(),
+			id:         id,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = entries
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package keymanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedKeyManager(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+
+	// Two key managers sharing the same store, as two Galadriel Server replicas do
+	km1 := NewSharedKeyManager(nil, store)
+	km2 := NewSharedKeyManager(nil, store)
+
+	key1, err := km1.GenerateKey(ctx, "key1", cryptoutil.RSA2048)
+	require.NoError(t, err)
+	assert.Equal(t, "key1", key1.ID())
+	assert.Contains(t, store.keys, "key1")
+
+	// A key that is not cached is not looked up in the store, it is loaded on reload
+	_, err = km2.GetKey(ctx, "key1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	require.NoError(t, km2.Reload(ctx))
+	key, err := km2.GetKey(ctx, "key1")
+	require.NoError(t, err)
+	assert.Equal(t, "key1", key.ID())
+	assert.Equal(t, key1.Signer().Public(), key.Signer().Public())
+
+	_, err = km2.GetKey(ctx, "unknown")
+	require.EqualError(t, err, `no such key "unknown"`)
+
+	// Overwriting a key is picked up by the other key manager on reload
+	key1, err = km1.GenerateKey(ctx, "key1", cryptoutil.RSA2048)
+	require.NoError(t, err)
+	key, err = km2.GetKey(ctx, "key1")
+	require.NoError(t, err)
+	assert.NotEqual(t, key1.Signer().Public(), key.Signer().Public())
+
+	require.NoError(t, km2.Reload(ctx))
+	key, err = km2.GetKey(ctx, "key1")
+	require.NoError(t, err)
+	assert.Equal(t, key1.Signer().Public(), key.Signer().Public())
+
+	keys, err := km2.GetKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	// A deleted key is dropped from the cache of the other key manager on reload
+	require.NoError(t, km1.DeleteKey(ctx, "key1"))
+	assert.NotContains(t, store.keys, "key1")
+	_, err = km1.GetKey(ctx, "key1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	require.NoError(t, km2.Reload(ctx))
+	_, err = km2.GetKey(ctx, "key1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestSharedKeyManagerStoreError(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	store.err = errors.New("store error")
+
+	km := NewSharedKeyManager(nil, store)
+
+	_, err := km.GenerateKey(ctx, "key1", cryptoutil.RSA2048)
+	require.EqualError(t, err, `failed to store key "key1": store error`)
+
+	err = km.Reload(ctx)
+	require.EqualError(t, err, "failed to load keys from store: store error")
+
+	err = km.DeleteKey(ctx, "key1")
+	require.EqualError(t, err, `failed to delete key "key1": store error`)
+}
+
+func TestSharedKeyManagerReloadCorruptKey(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+
+	km := NewSharedKeyManager(nil, store)
+	key1, err := km.GenerateKey(ctx, "key1", cryptoutil.RSA2048)
+	require.NoError(t, err)
+
+	// A corrupt stored key fails the reload, the cached keys are kept
+	store.keys["corrupt"] = []byte("not a key")
+	err = km.Reload(ctx)
+	require.ErrorContains(t, err, `failed to create key entry "corrupt"`)
+
+	key, err := km.GetKey(ctx, "key1")
+	require.NoError(t, err)
+	assert.Equal(t, key1.Signer().Public(), key.Signer().Public())
+}
+
+type fakeStore struct {
+	mu   sync.Mutex
+	keys map[string][]byte
+	err  error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{keys: make(map[string][]byte)}
+}
+
+func (s *fakeStore) PutKey(_ context.Context, id string, keyPEM []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.keys[id] = keyPEM
+	return nil
+}
+
+func (s *fakeStore) GetKeys(context.Context) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	keys := make(map[string][]byte, len(s.keys))
+	for id, keyPEM := range s.keys {
+		keys[id] = keyPEM
+	}
+	return keys, nil
+}
+
+func (s *fakeStore) DeleteKey(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.keys, id)
+	return nil
+}
@@ -0,0 +1,113 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MetricsNamespace is the Prometheus namespace shared by all Galadriel metrics.
+	MetricsNamespace = "galadriel"
+
+	// MetricsPath is the HTTP path the Prometheus metrics are exposed on.
+	MetricsPath = "/metrics"
+
+	metricsReadHeaderTimeout = 10 * time.Second
+)
+
+// MetricsServer exposes the metrics registered in a Prometheus registry over HTTP.
+type MetricsServer struct {
+	address  *net.TCPAddr
+	gatherer prometheus.Gatherer
+	logger   logrus.FieldLogger
+
+	hooks struct {
+		// test hook used to signal that the listener is ready
+		listening chan net.Addr
+	}
+}
+
+// MetricsServerConfig conveys the configuration of the MetricsServer.
+type MetricsServerConfig struct {
+	// Address is the TCP address the metrics endpoint listens on.
+	Address *net.TCPAddr
+
+	// Gatherer is the registry that is exposed. Defaults to the Prometheus default registry.
+	Gatherer prometheus.Gatherer
+
+	Logger logrus.FieldLogger
+}
+
+// NewMetricsServer creates a new MetricsServer.
+func NewMetricsServer(c *MetricsServerConfig) (*MetricsServer, error) {
+	if c.Address == nil {
+		return nil, errors.New("metrics address is required")
+	}
+
+	gatherer := c.Gatherer
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+
+	return &MetricsServer{
+		address:  c.Address,
+		gatherer: gatherer,
+		logger:   c.Logger,
+	}, nil
+}
+
+// ListenAndServe serves the metrics endpoint until the context is canceled.
+func (m *MetricsServer) ListenAndServe(ctx context.Context) error {
+	l, err := net.Listen(m.address.Network(), m.address.String())
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", m.address, err)
+	}
+	defer l.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, promhttp.HandlerFor(m.gatherer, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+
+	log := m.logger.WithFields(logrus.Fields{
+		Network: l.Addr().Network(),
+		Address: l.Addr().String()})
+
+	errChan := make(chan error)
+	go func() {
+		m.triggerListeningHook(l.Addr())
+		log.Info("Started metrics listener")
+		errChan <- server.Serve(l)
+	}()
+
+	select {
+	case err = <-errChan:
+		log.WithError(err).Error("Metrics listener stopped prematurely")
+		return err
+	case <-ctx.Done():
+		log.Info("Stopping metrics listener")
+		if err := server.Close(); err != nil {
+			log.WithError(err).Error("Error closing metrics listener")
+		}
+		<-errChan
+		log.Info("Metrics listener stopped")
+		return nil
+	}
+}
+
+func (m *MetricsServer) triggerListeningHook(addr net.Addr) {
+	if m.hooks.listening != nil {
+		m.hooks.listening <- addr
+	}
+}
@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetricsServer(t *testing.T) {
+	_, err := NewMetricsServer(&MetricsServerConfig{Logger: logrus.New()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metrics address is required")
+
+	m, err := NewMetricsServer(&MetricsServerConfig{
+		Address: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)},
+		Logger:  logrus.New(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prometheus.DefaultGatherer, m.gatherer)
+}
+
+func TestMetricsServerListenAndServe(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "test_total",
+		Help:      "Test counter.",
+	})
+	registry.MustRegister(counter)
+	counter.Inc()
+
+	m, err := NewMetricsServer(&MetricsServerConfig{
+		Address:  &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)},
+		Gatherer: registry,
+		Logger:   logrus.New(),
+	})
+	require.NoError(t, err)
+	m.hooks.listening = make(chan net.Addr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		errCh <- m.ListenAndServe(ctx)
+	}()
+
+	addr := <-m.hooks.listening
+
+	resp, err := http.Get("http://" + addr.String() + MetricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "galadriel_test_total 1")
+
+	cancel()
+	assert.NoError(t, <-errCh)
+}
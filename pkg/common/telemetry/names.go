@@ -28,6 +28,12 @@ const (
 	// Address represents a network address.
 	Address = "address"
 
+	// BundleEndpoint represents the SPIFFE bundle endpoint subsystem.
+	BundleEndpoint = "bundle_endpoint"
+
+	// BundleFetcher represents the fetcher of the bundles of the external trust domains.
+	BundleFetcher = "bundle_fetcher"
+
 	// BundleOpStatus represents a bundle operation status.
 	BundleOpStatus = "bundle_op_status"
 
@@ -37,15 +43,51 @@ const (
 	// Endpoints represents functionality related to agent/server endpoints.
 	Endpoints = "endpoints"
 
+	// Event tags the type of a notified event.
+	Event = "event"
+
+	// ExpiresAt tags the expiration time of a credential.
+	ExpiresAt = "expires_at"
+
 	// FederatedBundlesSynchronizer represents the Federated Bundles Synchronizer subsystem.
 	FederatedBundlesSynchronizer = "federated_bundles_synchronizer"
 
+	// FederationGroup tags the name of a federation group.
+	FederationGroup = "federation_group"
+
+	// FederationGroupReconciler represents the reconciler of the relationships of the federation groups.
+	FederationGroupReconciler = "federation_group_reconciler"
+
 	// GaladrielServer represents the Galadriel server subsystem.
 	GaladrielServer = "galadriel_server"
 
+	// HighAvailability represents the coordination of the replicas of a highly available Galadriel Server.
+	HighAvailability = "high_availability"
+
+	// HarvesterInstance tags the instance ID of a harvester of a trust domain.
+	HarvesterInstance = "harvester_instance"
+
+	// Job tags the name of a job that runs on a single Galadriel Server replica at a time.
+	Job = "job"
+
+	// KeyID tags the ID of a key of the KeyManager.
+	KeyID = "key_id"
+
+	// MemoryX509CA represents an in-memory X509 CA.
+	MemoryX509CA = "memory_x509_ca"
+
+	// Metrics represents the Prometheus metrics subsystem.
+	Metrics = "metrics"
+
 	// Network represents a network name ("tcp", "udp").
 	Network = "network"
 
+	// Notifications represents the webhook notifications subsystem.
+	Notifications = "notifications"
+
+	// SPIFFEID tags a SPIFFE ID, such as the one of an X509-SVID.
+	SPIFFEID = "spiffe_id"
+
 	// SpireBundleSynchronizer represents the SPIRE Bundle Synchronizer subsystem.
 	SpireBundleSynchronizer = "spire_bundle_synchronizer"
 
@@ -54,4 +96,10 @@ const (
 
 	// TrustDomain tags the name of some trust domain
 	TrustDomain = "trust_domain"
+
+	// VaultX509CA represents an X509 CA backed by the Vault PKI secrets engine.
+	VaultX509CA = "vault_x509_ca"
+
+	// Webhook tags the name of a webhook target.
+	Webhook = "webhook"
 )
@@ -0,0 +1,151 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Supported tracing exporters.
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+)
+
+// TracingConfig conveys the configuration of the OpenTelemetry tracing.
+type TracingConfig struct {
+	// ServiceName is reported as the service.name resource attribute of the spans.
+	ServiceName string
+
+	// Exporter is one of "otlp", "stdout" or "file".
+	Exporter string
+
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector, used by the "otlp" exporter.
+	// When empty, the OTEL_EXPORTER_OTLP_* environment variables are honoured.
+	OTLPEndpoint string
+
+	// OTLPInsecure disables TLS when connecting to the OTLP collector.
+	OTLPInsecure bool
+
+	// FilePath is the file the spans are appended to, used by the "file" exporter.
+	FilePath string
+
+	// SampleRatio is the fraction of the root traces that are sampled, between 0 and 1.
+	// Traces started by a remote parent follow the sampling decision of the parent.
+	SampleRatio float64
+}
+
+// SetupTracing installs a global OpenTelemetry tracer provider that exports spans using the configured exporter,
+// and the W3C trace context propagator. It returns a function that flushes and shuts down the tracer provider.
+func SetupTracing(ctx context.Context, c *TracingConfig) (func(context.Context) error, error) {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid sample ratio %v: must be between 0 and 1", c.SampleRatio)
+	}
+
+	exporter, closer, err := newSpanExporter(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(c.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	shutdown := func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}
+
+	return shutdown, nil
+}
+
+// Tracer returns a tracer with the given instrumentation name from the global tracer provider.
+// When tracing is not set up, the returned tracer does not record any span.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// RecordError records the error in the span and sets the span status to error. It's a no-op if err is nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// EndSpan records the error, if any, in the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	RecordError(span, err)
+	span.End()
+}
+
+func newSpanExporter(ctx context.Context, c *TracingConfig) (sdktrace.SpanExporter, io.Closer, error) {
+	switch c.Exporter {
+	case TracingExporterOTLP:
+		var opts []otlptracehttp.Option
+		if c.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(c.OTLPEndpoint))
+		}
+		if c.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case TracingExporterFile:
+		if c.FilePath == "" {
+			return nil, nil, errors.New("file path is required for the file exporter")
+		}
+		f, err := os.OpenFile(c.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open traces file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+		return exporter, f, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported tracing exporter %q, available values [%s, %s, %s]",
+			c.Exporter, TracingExporterOTLP, TracingExporterStdout, TracingExporterFile)
+	}
+}
@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetupTracingFileExporter(t *testing.T) {
+	ctx := context.Background()
+	filePath := filepath.Join(t.TempDir(), "traces.json")
+
+	shutdown, err := SetupTracing(ctx, &TracingConfig{
+		ServiceName: "test-service",
+		Exporter:    TracingExporterFile,
+		FilePath:    filePath,
+		SampleRatio: 1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+
+	_, span := Tracer("test").Start(ctx, "test-span")
+	span.End()
+
+	require.NoError(t, shutdown(ctx))
+
+	traces, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(traces), `"Name":"test-span"`)
+	assert.Contains(t, string(traces), "test-service")
+}
+
+func TestSetupTracingErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := SetupTracing(ctx, &TracingConfig{Exporter: "unknown"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported tracing exporter "unknown"`)
+
+	_, err = SetupTracing(ctx, &TracingConfig{Exporter: TracingExporterFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file path is required for the file exporter")
+
+	_, err = SetupTracing(ctx, &TracingConfig{Exporter: TracingExporterStdout, SampleRatio: 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid sample ratio 2: must be between 0 and 1")
+}
@@ -0,0 +1,5 @@
+package version
+
+// Version is the version of the Galadriel binaries.
+// It can be overridden at build time with -ldflags "-X github.com/HewlettPackard/galadriel/pkg/common/version.Version=<version>".
+var Version = "0.1.0-dev"
@@ -14,14 +14,26 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// X509CA is a CA that signs X509 certificates using a disk-based private key and ROOT CA certificate.
+// X509CA is a CA that signs X509 certificates using a disk-based private key and CA certificate, which is either a
+// ROOT CA certificate or an intermediate CA certificate chaining up to the ROOT CA certificates of a bundle.
 type X509CA struct {
 	// Interface for an opaque private key that can be used for signing operations.
 	signer crypto.Signer
 
-	// ROOT CA certificate for signing X509 certificates.
+	// CA certificate for signing X509 certificates.
 	certificate *x509.Certificate
 
+	// Intermediate CA certificates returned along with the issued certificates, starting with the CA certificate
+	// itself. Empty when the CA certificate is a ROOT CA certificate.
+	chain []*x509.Certificate
+
+	// ROOT CA certificates that the issued certificates chain up to.
+	bundle []*x509.Certificate
+
+	// ROOT CA certificate that the CA rolls over to. It is trusted along with the current one, but not used for
+	// signing, so that it is distributed to the Harvesters before the rollover.
+	nextCertificate *x509.Certificate
+
 	clock clock.Clock
 
 	logger logrus.FieldLogger
@@ -29,10 +41,24 @@ type X509CA struct {
 
 // Config is the configuration for a disk-based X509CA.
 type Config struct {
-	// The path to the file containing the X.509 ROOT CA certificate.
+	// The path to the file containing the X.509 CA certificate, either a ROOT CA or an intermediate CA certificate.
 	CertFilePath string `hcl:"cert_file_path"`
-	// The path to the file containing the X.509 ROOT CA private key.
+	// The path to the file containing the X.509 CA private key.
 	KeyFilePath string `hcl:"key_file_path"`
+
+	// The path to the file containing the X.509 intermediate CA certificates that chain the intermediate CA
+	// certificate up to a ROOT CA certificate of the bundle, each one signed by the next one. The file may start with
+	// the CA certificate itself and end with the ROOT CA certificate. Optional, the CA certificate must be signed by a
+	// ROOT CA certificate of the bundle when it is not set.
+	ChainFilePath string `hcl:"chain_file_path,optional"`
+	// The path to the file containing the X.509 ROOT CA certificates that the intermediate CA certificate chains up
+	// to. Required when the CA certificate is not a ROOT CA certificate.
+	BundleFilePath string `hcl:"bundle_file_path,optional"`
+
+	// The paths to the files containing the X.509 ROOT CA certificate and private key that the CA rolls over to.
+	// Optional, they must be set together.
+	NextCertFilePath string `hcl:"next_cert_file_path,optional"`
+	NextKeyFilePath  string `hcl:"next_key_file_path,optional"`
 }
 
 // New creates a new disk-based X509CA.
@@ -55,34 +81,175 @@ func (ca *X509CA) Configure(config *Config) error {
 		return errors.New("private key file path is required")
 	}
 
-	key, err := cryptoutil.LoadPrivateKey(config.KeyFilePath)
-	if err != nil {
-		return fmt.Errorf("unable to load private key: %v", err)
+	var (
+		cert   *x509.Certificate
+		signer crypto.Signer
+		chain  []*x509.Certificate
+		bundle []*x509.Certificate
+		err    error
+	)
+	if config.BundleFilePath == "" {
+		if config.ChainFilePath != "" {
+			return errors.New("bundle file path is required when the chain file path is set")
+		}
+
+		cert, signer, err = loadCA(config.CertFilePath, config.KeyFilePath)
+		if err != nil {
+			return err
+		}
+		bundle = []*x509.Certificate{cert}
+	} else {
+		cert, signer, chain, bundle, err = ca.loadIntermediateCA(config)
+		if err != nil {
+			return err
+		}
 	}
 
-	cert, err := cryptoutil.LoadCertificate(config.CertFilePath)
+	var nextCert *x509.Certificate
+	if config.NextCertFilePath != "" || config.NextKeyFilePath != "" {
+		if config.NextCertFilePath == "" || config.NextKeyFilePath == "" {
+			return errors.New("next certificate and private key file paths must be set together")
+		}
+
+		nextCert, _, err = loadCA(config.NextCertFilePath, config.NextKeyFilePath)
+		if err != nil {
+			return fmt.Errorf("invalid next CA: %w", err)
+		}
+		if nextCert.Equal(cert) {
+			return errors.New("next CA is the same as the current CA")
+		}
+	}
+
+	ca.certificate = cert
+	ca.signer = signer
+	ca.chain = chain
+	ca.bundle = bundle
+	ca.nextCertificate = nextCert
+
+	return nil
+}
+
+// loadCA loads a ROOT CA certificate and its private key, verifying that they match.
+func loadCA(certFilePath, keyFilePath string) (*x509.Certificate, crypto.Signer, error) {
+	cert, signer, err := loadKeyPair(certFilePath, keyFilePath)
 	if err != nil {
-		return fmt.Errorf("unable to load certificate: %v", err)
+		return nil, nil, err
 	}
 
 	// verify the certificate is self-signed (i.e. ROOT CA)
 	if err := cert.CheckSignatureFrom(cert); err != nil {
-		return fmt.Errorf("certificate is not self-signed")
+		return nil, nil, fmt.Errorf("certificate is not self-signed")
+	}
+
+	return cert, signer, nil
+}
+
+// loadIntermediateCA loads a CA certificate, its private key, its chain and the bundle of ROOT CA certificates it
+// chains up to, verifying that the key matches the certificate and that the chain is valid.
+func (ca *X509CA) loadIntermediateCA(config *Config) (*x509.Certificate, crypto.Signer, []*x509.Certificate, []*x509.Certificate, error) {
+	cert, signer, err := loadKeyPair(config.CertFilePath, config.KeyFilePath)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if !cert.IsCA {
+		return nil, nil, nil, nil, errors.New("certificate is not a CA certificate")
+	}
+
+	bundle, err := cryptoutil.LoadCertificates(config.BundleFilePath)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("unable to load bundle: %v", err)
+	}
+
+	var chainFile []*x509.Certificate
+	if config.ChainFilePath != "" {
+		chainFile, err = cryptoutil.LoadCertificates(config.ChainFilePath)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("unable to load chain: %v", err)
+		}
+	}
+
+	// the CA certificate and the ROOT CA certificates are not part of the intermediates, even if the file has them
+	var intermediates []*x509.Certificate
+	for _, c := range chainFile {
+		if c.Equal(cert) || containsCertificate(bundle, c) {
+			continue
+		}
+		intermediates = append(intermediates, c)
+	}
+
+	if err := ca.verifyChain(cert, intermediates, bundle); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("invalid certificate chain: %w", err)
+	}
+
+	// a ROOT CA certificate of the bundle is not returned along with the issued certificates
+	var chain []*x509.Certificate
+	if !containsCertificate(bundle, cert) {
+		chain = append([]*x509.Certificate{cert}, intermediates...)
+	}
+
+	return cert, signer, chain, bundle, nil
+}
+
+// verifyChain verifies that the CA certificate chains up to a ROOT CA certificate of the bundle through the given
+// intermediates, in order, so that the chain returned along with the issued certificates is valid.
+func (ca *X509CA) verifyChain(cert *x509.Certificate, intermediates, bundle []*x509.Certificate) error {
+	issued := cert
+	for _, intermediate := range intermediates {
+		if err := issued.CheckSignatureFrom(intermediate); err != nil {
+			return fmt.Errorf("certificate %q is not signed by the next certificate of the chain %q: %w", issued.Subject, intermediate.Subject, err)
+		}
+		issued = intermediate
+	}
+
+	roots := x509.NewCertPool()
+	for _, root := range bundle {
+		roots.AddCert(root)
+	}
+	intermediatesPool := x509.NewCertPool()
+	for _, intermediate := range intermediates {
+		intermediatesPool.AddCert(intermediate)
+	}
+
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediatesPool,
+		CurrentTime:   ca.clock.Now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+// loadKeyPair loads a CA certificate and its private key, verifying that they match.
+func loadKeyPair(certFilePath, keyFilePath string) (*x509.Certificate, crypto.Signer, error) {
+	key, err := cryptoutil.LoadPrivateKey(keyFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load private key: %v", err)
+	}
+
+	cert, err := cryptoutil.LoadCertificate(certFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load certificate: %v", err)
 	}
 
 	// verify the certificate public key matches the private key
 	if err := cryptoutil.VerifyCertificatePrivateKey(cert, key); err != nil {
-		return fmt.Errorf("certificate verification failed: %w", err)
+		return nil, nil, fmt.Errorf("certificate verification failed: %w", err)
 	}
 
-	ca.certificate = cert
-	ca.signer = key.(crypto.Signer)
+	return cert, key.(crypto.Signer), nil
+}
 
-	return nil
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
 }
 
-// IssueX509Certificate issues an X509 certificate using the disk-based private key and ROOT CA certificate. The certificate
-// is bound to the given public key and subject.
+// IssueX509Certificate issues an X509 certificate using the disk-based private key and CA certificate. The certificate
+// is bound to the given public key and subject, and is returned along with the intermediate CA certificates.
 func (ca *X509CA) IssueX509Certificate(ctx context.Context, params *x509ca.X509CertificateParams) ([]*x509.Certificate, error) {
 	if params.PublicKey == nil {
 		return nil, errors.New("public key is required")
@@ -95,11 +262,27 @@ func (ca *X509CA) IssueX509Certificate(ctx context.Context, params *x509ca.X509C
 	if err != nil {
 		return nil, fmt.Errorf("failed to create template for Server certificate: %w", err)
 	}
+	template.IPAddresses = params.IPAddresses
 
 	cert, err := cryptoutil.SignX509(template, ca.certificate, ca.signer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign X509 certificate: %w", err)
 	}
 
-	return []*x509.Certificate{cert}, nil
+	return append([]*x509.Certificate{cert}, ca.chain...), nil
+}
+
+// GetX509Authorities returns the ROOT CA certificates that the issued X509 certificates chain up to, followed by the
+// next ROOT CA certificate if there is one.
+func (ca *X509CA) GetX509Authorities(ctx context.Context) ([]*x509.Certificate, error) {
+	if ca.certificate == nil {
+		return nil, errors.New("X509 CA is not configured")
+	}
+
+	authorities := append([]*x509.Certificate{}, ca.bundle...)
+	if ca.nextCertificate != nil {
+		authorities = append(authorities, ca.nextCertificate)
+	}
+
+	return authorities, nil
 }
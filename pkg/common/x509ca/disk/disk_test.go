@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"net"
 	"net/url"
 	"os"
 	"testing"
@@ -22,6 +23,7 @@ var (
 	clk                 = clock.NewFake()
 	uris                = []*url.URL{spiffeid.RequireFromString("spiffe://domain/test").URL()}
 	dnsNames            = []string{"dns-test"}
+	ipAddresses         = []net.IP{net.ParseIP("10.0.0.1")}
 	expectedKeyUsage    = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageKeyAgreement
 	expectedExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
 	fiveHours           = time.Hour * 5
@@ -128,11 +130,12 @@ func TestIssueX509Certificate(t *testing.T) {
 	require.NoError(t, err)
 
 	params := &x509ca.X509CertificateParams{
-		PublicKey: signer.Public(),
-		Subject:   pkix.Name{CommonName: "test"},
-		DNSNames:  dnsNames,
-		URIs:      uris,
-		TTL:       fiveHours,
+		PublicKey:   signer.Public(),
+		Subject:     pkix.Name{CommonName: "test"},
+		DNSNames:    dnsNames,
+		IPAddresses: ipAddresses,
+		URIs:        uris,
+		TTL:         fiveHours,
 	}
 	certChain, err := ca.IssueX509Certificate(context.Background(), params)
 	require.NoError(t, err)
@@ -141,6 +144,8 @@ func TestIssueX509Certificate(t *testing.T) {
 
 	leaf := certChain[0]
 	assert.Equal(t, dnsNames, leaf.DNSNames)
+	assert.Len(t, leaf.IPAddresses, 1)
+	assert.True(t, ipAddresses[0].Equal(leaf.IPAddresses[0]))
 	assert.Equal(t, uris, leaf.URIs)
 	assert.Equal(t, clk.Now().Add(fiveHours), leaf.NotAfter)
 	assert.Equal(t, clk.Now().Add(-cryptoutil.NotBeforeTolerance), leaf.NotBefore)
@@ -159,6 +164,206 @@ func TestIssueX509Certificate(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetX509Authorities(t *testing.T) {
+	ca := newCA(t)
+
+	_, err := ca.GetX509Authorities(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "X509 CA is not configured", err.Error())
+
+	tempDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	config := Config{
+		KeyFilePath:  tempDir + "/root-ca.key",
+		CertFilePath: tempDir + "/root-ca.crt",
+	}
+	err = ca.Configure(&config)
+	require.NoError(t, err)
+
+	authorities, err := ca.GetX509Authorities(context.Background())
+	require.NoError(t, err)
+	require.Len(t, authorities, 1)
+	assert.Equal(t, ca.certificate, authorities[0])
+}
+
+func TestNextCA(t *testing.T) {
+	tempDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	config := Config{
+		KeyFilePath:      tempDir + "/root-ca.key",
+		CertFilePath:     tempDir + "/root-ca.crt",
+		NextKeyFilePath:  tempDir + "/other-ca.key",
+		NextCertFilePath: tempDir + "/other-ca.crt",
+	}
+	ca := newCA(t)
+	err := ca.Configure(&config)
+	require.NoError(t, err)
+
+	// both CAs are trusted
+	authorities, err := ca.GetX509Authorities(context.Background())
+	require.NoError(t, err)
+	require.Len(t, authorities, 2)
+	assert.Equal(t, ca.certificate, authorities[0])
+	assert.Equal(t, ca.nextCertificate, authorities[1])
+
+	// certificates are issued by the current CA
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.RSA2048)
+	require.NoError(t, err)
+	certChain, err := ca.IssueX509Certificate(context.Background(), &x509ca.X509CertificateParams{
+		PublicKey: signer.Public(),
+		Subject:   pkix.Name{CommonName: "test"},
+		TTL:       fiveHours,
+	})
+	require.NoError(t, err)
+	require.NoError(t, certChain[0].CheckSignatureFrom(ca.certificate))
+
+	config.NextKeyFilePath = ""
+	err = newCA(t).Configure(&config)
+	assert.EqualError(t, err, "next certificate and private key file paths must be set together")
+
+	config.NextKeyFilePath = tempDir + "/root-ca.key"
+	err = newCA(t).Configure(&config)
+	assert.EqualError(t, err, "invalid next CA: certificate verification failed: certificate public key does not match private key")
+
+	config.NextCertFilePath = tempDir + "/root-ca.crt"
+	err = newCA(t).Configure(&config)
+	assert.EqualError(t, err, "next CA is the same as the current CA")
+}
+
+func TestConfigureIntermediateCA(t *testing.T) {
+	tempDir, cleanup := setupTest(t)
+	defer cleanup()
+
+	rootCA, err := cryptoutil.LoadCertificate(tempDir + "/root-ca.crt")
+	require.NoError(t, err)
+	intermediateCA, err := cryptoutil.LoadCertificate(tempDir + "/intermediate-ca.crt")
+	require.NoError(t, err)
+	intermediateCA2, err := cryptoutil.LoadCertificate(tempDir + "/intermediate-ca-2.crt")
+	require.NoError(t, err)
+
+	// full chain, from the CA certificate up to the ROOT CA certificate
+	fullChainFilePath := tempDir + "/full-chain.crt"
+	var fullChain []byte
+	for _, cert := range []*x509.Certificate{intermediateCA2, intermediateCA, rootCA} {
+		fullChain = append(fullChain, cryptoutil.EncodeCertificate(cert)...)
+	}
+	require.NoError(t, os.WriteFile(fullChainFilePath, fullChain, 0600))
+
+	issue := func(t *testing.T, ca *X509CA) []*x509.Certificate {
+		signer, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		certChain, err := ca.IssueX509Certificate(context.Background(), &x509ca.X509CertificateParams{
+			PublicKey: signer.Public(),
+			Subject:   pkix.Name{CommonName: "test"},
+			TTL:       fiveHours,
+		})
+		require.NoError(t, err)
+
+		// the issued certificate chains up to the authorities with the returned intermediates
+		authorities, err := ca.GetX509Authorities(context.Background())
+		require.NoError(t, err)
+		roots := x509.NewCertPool()
+		for _, authority := range authorities {
+			roots.AddCert(authority)
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certChain[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err = certChain[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, CurrentTime: clk.Now()})
+		require.NoError(t, err)
+
+		return certChain
+	}
+
+	for _, chainFilePath := range []string{tempDir + "/intermediate-ca.crt", fullChainFilePath} {
+		ca := newCA(t)
+		err := ca.Configure(&Config{
+			KeyFilePath:    tempDir + "/intermediate-ca-2.key",
+			CertFilePath:   tempDir + "/intermediate-ca-2.crt",
+			ChainFilePath:  chainFilePath,
+			BundleFilePath: tempDir + "/root-ca.crt",
+		})
+		require.NoError(t, err)
+
+		authorities, err := ca.GetX509Authorities(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []*x509.Certificate{rootCA}, authorities)
+
+		certChain := issue(t, ca)
+		require.Len(t, certChain, 3)
+		assert.Equal(t, intermediateCA2, certChain[1])
+		assert.Equal(t, intermediateCA, certChain[2])
+	}
+
+	t.Run("CA signed by a ROOT CA of the bundle", func(t *testing.T) {
+		ca := newCA(t)
+		err := ca.Configure(&Config{
+			KeyFilePath:    tempDir + "/intermediate-ca.key",
+			CertFilePath:   tempDir + "/intermediate-ca.crt",
+			BundleFilePath: tempDir + "/root-ca.crt",
+		})
+		require.NoError(t, err)
+
+		certChain := issue(t, ca)
+		require.Len(t, certChain, 2)
+		assert.Equal(t, intermediateCA, certChain[1])
+	})
+
+	t.Run("Missing intermediate", func(t *testing.T) {
+		err := newCA(t).Configure(&Config{
+			KeyFilePath:    tempDir + "/intermediate-ca-2.key",
+			CertFilePath:   tempDir + "/intermediate-ca-2.crt",
+			BundleFilePath: tempDir + "/root-ca.crt",
+		})
+		assert.ErrorContains(t, err, "invalid certificate chain: x509: certificate signed by unknown authority")
+	})
+
+	t.Run("Chain not ordered", func(t *testing.T) {
+		chainFilePath := tempDir + "/unordered-chain.crt"
+		require.NoError(t, os.WriteFile(chainFilePath, cryptoutil.EncodeCertificate(intermediateCA2), 0600))
+
+		err := newCA(t).Configure(&Config{
+			KeyFilePath:    tempDir + "/intermediate-ca.key",
+			CertFilePath:   tempDir + "/intermediate-ca.crt",
+			ChainFilePath:  chainFilePath,
+			BundleFilePath: tempDir + "/root-ca.crt",
+		})
+		assert.ErrorContains(t, err, `invalid certificate chain: certificate "CN=intermediate-ca" is not signed by the next certificate of the chain "CN=intermediate-ca-2"`)
+	})
+
+	t.Run("Chain up to another ROOT CA", func(t *testing.T) {
+		err := newCA(t).Configure(&Config{
+			KeyFilePath:    tempDir + "/intermediate-ca-2.key",
+			CertFilePath:   tempDir + "/intermediate-ca-2.crt",
+			ChainFilePath:  tempDir + "/intermediate-ca.crt",
+			BundleFilePath: tempDir + "/other-ca.crt",
+		})
+		assert.ErrorContains(t, err, "invalid certificate chain: x509: certificate signed by unknown authority")
+	})
+
+	t.Run("Key not matching the CA certificate", func(t *testing.T) {
+		err := newCA(t).Configure(&Config{
+			KeyFilePath:    tempDir + "/intermediate-ca.key",
+			CertFilePath:   tempDir + "/intermediate-ca-2.crt",
+			ChainFilePath:  tempDir + "/intermediate-ca.crt",
+			BundleFilePath: tempDir + "/root-ca.crt",
+		})
+		assert.EqualError(t, err, "certificate verification failed: certificate public key does not match private key")
+	})
+
+	t.Run("Chain without bundle", func(t *testing.T) {
+		err := newCA(t).Configure(&Config{
+			KeyFilePath:   tempDir + "/intermediate-ca-2.key",
+			CertFilePath:  tempDir + "/intermediate-ca-2.crt",
+			ChainFilePath: tempDir + "/intermediate-ca.crt",
+		})
+		assert.EqualError(t, err, "bundle file path is required when the chain file path is set")
+	})
+}
+
 func newCA(t *testing.T) *X509CA {
 	ca, err := New()
 	require.NoError(t, err)
@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultCommonName = "Galadriel Server Root CA"
+	defaultTTL        = 24 * time.Hour
+)
+
+// X509CA is a CA that signs X509 certificates using an ephemeral self-signed ROOT CA certificate, created when it is
+// configured and kept in memory. It is meant for development and tests: a new ROOT CA is created on every start, so
+// the certificates issued before a restart are no longer trusted.
+type X509CA struct {
+	// Interface for an opaque private key that can be used for signing operations.
+	signer crypto.Signer
+
+	// ROOT CA certificate for signing X509 certificates.
+	certificate *x509.Certificate
+
+	clock clock.Clock
+
+	logger logrus.FieldLogger
+}
+
+// Config is the configuration for an in-memory X509CA.
+type Config struct {
+	// The common name and organizations of the subject of the ROOT CA certificate.
+	// The common name defaults to "Galadriel Server Root CA".
+	CommonName   string   `hcl:"common_name,optional"`
+	Organization []string `hcl:"organization,optional"`
+
+	// The TTL of the ROOT CA certificate, as a duration string (e.g. "24h"). Defaults to 24 hours.
+	// The certificates issued by the CA do not outlive it.
+	TTL string `hcl:"ttl,optional"`
+
+	// The path to the file where the ROOT CA certificate is written, so that it can be used as the trust bundle of
+	// the Harvesters. Optional.
+	CertFilePath string `hcl:"cert_file_path,optional"`
+}
+
+// New creates a new in-memory X509CA.
+// The returned X509CA is not configured.
+// Call Configure() to configure it passing the HCL configuration.
+func New() (*X509CA, error) {
+	return &X509CA{
+		clock:  clock.New(),
+		logger: logrus.WithField(telemetry.SubsystemName, telemetry.MemoryX509CA),
+	}, nil
+}
+
+// Configure creates the ROOT CA certificate and private key of the in-memory X509CA from the given configuration.
+func (ca *X509CA) Configure(config *Config) error {
+	ttl := defaultTTL
+	if config.TTL != "" {
+		var err error
+		ttl, err = time.ParseDuration(config.TTL)
+		if err != nil {
+			return fmt.Errorf("invalid TTL: %w", err)
+		}
+		if ttl <= 0 {
+			return errors.New("TTL must be positive")
+		}
+	}
+
+	subject := pkix.Name{
+		CommonName:   config.CommonName,
+		Organization: config.Organization,
+	}
+	if subject.CommonName == "" {
+		subject.CommonName = defaultCommonName
+	}
+
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	if err != nil {
+		return fmt.Errorf("failed to create private key: %w", err)
+	}
+
+	template, err := cryptoutil.CreateRootCATemplate(ca.clock, subject, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to create template for ROOT CA certificate: %w", err)
+	}
+	template.PublicKey = signer.Public()
+
+	cert, err := cryptoutil.SignX509(template, template, signer)
+	if err != nil {
+		return fmt.Errorf("failed to self-sign ROOT CA certificate: %w", err)
+	}
+
+	if config.CertFilePath != "" {
+		if err := diskutil.AtomicWritePubliclyReadableFile(config.CertFilePath, cryptoutil.EncodeCertificate(cert)); err != nil {
+			return fmt.Errorf("failed to write ROOT CA certificate: %w", err)
+		}
+	}
+
+	ca.certificate = cert
+	ca.signer = signer
+
+	ca.logger.WithField(telemetry.ExpiresAt, cert.NotAfter).Warn("Using an ephemeral in-memory ROOT CA, which is not meant for production")
+	if config.CertFilePath != "" {
+		ca.logger.Infof("ROOT CA certificate written to %s", config.CertFilePath)
+	}
+
+	return nil
+}
+
+// IssueX509Certificate issues an X509 certificate using the in-memory private key and ROOT CA certificate. The
+// certificate is bound to the given public key and subject, and expires at the latest when the ROOT CA certificate does.
+// It fails once the ROOT CA certificate expired.
+func (ca *X509CA) IssueX509Certificate(ctx context.Context, params *x509ca.X509CertificateParams) ([]*x509.Certificate, error) {
+	if ca.certificate == nil {
+		return nil, errors.New("X509 CA is not configured")
+	}
+	if params.PublicKey == nil {
+		return nil, errors.New("public key is required")
+	}
+	if params.TTL == 0 {
+		return nil, errors.New("TTL is required")
+	}
+	if !ca.clock.Now().Before(ca.certificate.NotAfter) {
+		return nil, fmt.Errorf("ROOT CA certificate expired at %s, restart the server to create a new one", ca.certificate.NotAfter.Format(time.RFC3339))
+	}
+
+	template, err := cryptoutil.CreateX509Template(ca.clock, params.PublicKey, params.Subject, params.URIs, params.DNSNames, params.TTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template for Server certificate: %w", err)
+	}
+	template.IPAddresses = params.IPAddresses
+	if template.NotAfter.After(ca.certificate.NotAfter) {
+		template.NotAfter = ca.certificate.NotAfter
+	}
+
+	cert, err := cryptoutil.SignX509(template, ca.certificate, ca.signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign X509 certificate: %w", err)
+	}
+
+	return []*x509.Certificate{cert}, nil
+}
+
+// GetX509Authorities returns the ROOT CA certificate used for signing X509 certificates.
+func (ca *X509CA) GetX509Authorities(ctx context.Context) ([]*x509.Certificate, error) {
+	if ca.certificate == nil {
+		return nil, errors.New("X509 CA is not configured")
+	}
+
+	return []*x509.Certificate{ca.certificate}, nil
+}
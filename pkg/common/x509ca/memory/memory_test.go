@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/jmhodges/clock"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		ca := newCA(t)
+		require.NoError(t, ca.Configure(&Config{}))
+
+		authorities, err := ca.GetX509Authorities(context.Background())
+		require.NoError(t, err)
+		require.Len(t, authorities, 1)
+
+		root := authorities[0]
+		assert.True(t, root.IsCA)
+		assert.NoError(t, root.CheckSignatureFrom(root))
+		assert.Equal(t, defaultCommonName, root.Subject.CommonName)
+		assert.Equal(t, ca.clock.Now().Add(defaultTTL), root.NotAfter)
+	})
+
+	t.Run("Configured subject and TTL", func(t *testing.T) {
+		ca := newCA(t)
+		require.NoError(t, ca.Configure(&Config{CommonName: "Local CA", Organization: []string{"Galadriel"}, TTL: "2h"}))
+
+		authorities, err := ca.GetX509Authorities(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Local CA", authorities[0].Subject.CommonName)
+		assert.Equal(t, []string{"Galadriel"}, authorities[0].Subject.Organization)
+		assert.Equal(t, ca.clock.Now().Add(2*time.Hour), authorities[0].NotAfter)
+	})
+
+	t.Run("ROOT CA certificate written to disk", func(t *testing.T) {
+		certFilePath := filepath.Join(t.TempDir(), "root-ca.crt")
+		ca := newCA(t)
+		require.NoError(t, ca.Configure(&Config{CertFilePath: certFilePath}))
+
+		cert, err := cryptoutil.LoadCertificate(certFilePath)
+		require.NoError(t, err)
+		assert.Equal(t, ca.certificate, cert)
+
+		info, err := os.Stat(certFilePath)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0644, info.Mode())
+	})
+
+	t.Run("A new ROOT CA is created every time", func(t *testing.T) {
+		ca1 := newCA(t)
+		require.NoError(t, ca1.Configure(&Config{}))
+		ca2 := newCA(t)
+		require.NoError(t, ca2.Configure(&Config{}))
+		assert.False(t, ca1.certificate.Equal(ca2.certificate))
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		err := newCA(t).Configure(&Config{TTL: "one day"})
+		assert.ErrorContains(t, err, "invalid TTL")
+
+		err = newCA(t).Configure(&Config{TTL: "-1h"})
+		assert.EqualError(t, err, "TTL must be positive")
+	})
+
+	t.Run("Unwritable certificate file", func(t *testing.T) {
+		err := newCA(t).Configure(&Config{CertFilePath: filepath.Join(t.TempDir(), "missing", "root-ca.crt")})
+		assert.ErrorContains(t, err, "failed to write ROOT CA certificate")
+	})
+}
+
+func TestIssueX509Certificate(t *testing.T) {
+	ca := newCA(t)
+
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	params := &x509ca.X509CertificateParams{
+		PublicKey: signer.Public(),
+		Subject:   pkix.Name{CommonName: "test"},
+		DNSNames:  []string{"galadriel-server"},
+		URIs:      []*url.URL{spiffeid.RequireFromString("spiffe://domain/test").URL()},
+		TTL:       time.Hour,
+	}
+
+	_, err = ca.IssueX509Certificate(context.Background(), params)
+	assert.EqualError(t, err, "X509 CA is not configured")
+
+	require.NoError(t, ca.Configure(&Config{TTL: "90m"}))
+
+	certChain, err := ca.IssueX509Certificate(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, certChain, 1)
+
+	leaf := certChain[0]
+	assert.Equal(t, params.DNSNames, leaf.DNSNames)
+	assert.Equal(t, params.URIs, leaf.URIs)
+	assert.Equal(t, ca.clock.Now().Add(time.Hour), leaf.NotAfter)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, CurrentTime: ca.clock.Now()})
+	require.NoError(t, err)
+
+	// issued certificates do not outlive the ROOT CA certificate
+	params.TTL = 2 * time.Hour
+	certChain, err = ca.IssueX509Certificate(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, ca.certificate.NotAfter, certChain[0].NotAfter)
+
+	// no certificate is issued once the ROOT CA certificate expired
+	ca.clock.(clock.FakeClock).Add(90 * time.Minute)
+	_, err = ca.IssueX509Certificate(context.Background(), params)
+	assert.EqualError(t, err, "ROOT CA certificate expired at "+ca.certificate.NotAfter.Format(time.RFC3339)+", restart the server to create a new one")
+}
+
+func newCA(t *testing.T) *X509CA {
+	ca, err := New()
+	require.NoError(t, err)
+	ca.clock = clock.NewFake()
+	return ca
+}
@@ -0,0 +1,230 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+const (
+	defaultAppRoleMountPoint = "approle"
+	defaultK8sMountPoint     = "kubernetes"
+	defaultK8sTokenPath      = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// tokenRenewalInterval is how often the Vault token is checked for renewal.
+	tokenRenewalInterval = 1 * time.Minute
+)
+
+// TokenAuthConfig authenticates to Vault with a token.
+type TokenAuthConfig struct {
+	// The Vault token. Defaults to the VAULT_TOKEN environment variable.
+	Token string `hcl:"token,optional"`
+}
+
+// AppRoleAuthConfig authenticates to Vault with the AppRole auth method.
+type AppRoleAuthConfig struct {
+	// The mount point of the AppRole auth method. Defaults to "approle".
+	MountPoint string `hcl:"approle_auth_mount_point,optional"`
+	RoleID     string `hcl:"approle_id"`
+	SecretID   string `hcl:"approle_secret_id"`
+}
+
+// K8sAuthConfig authenticates to Vault with the Kubernetes auth method, using the service account token of the pod.
+type K8sAuthConfig struct {
+	// The mount point of the Kubernetes auth method. Defaults to "kubernetes".
+	MountPoint string `hcl:"k8s_auth_mount_point,optional"`
+	// The Vault role the service account is bound to.
+	RoleName string `hcl:"k8s_auth_role_name"`
+	// The path to the service account token file. Defaults to the token mounted in the pod.
+	TokenPath string `hcl:"token_path,optional"`
+}
+
+// tokenState holds the Vault token of the client and its lease.
+type tokenState struct {
+	mu sync.Mutex
+	// renewAt is when the token is renewed, once half of its TTL has elapsed. Zero when the token does not expire.
+	renewAt   time.Time
+	expiresAt time.Time
+	ttl       time.Duration
+	renewable bool
+}
+
+func validateAuth(config *Config) error {
+	count := 0
+	if config.TokenAuth != nil {
+		count++
+	}
+	if config.AppRoleAuth != nil {
+		count++
+		if config.AppRoleAuth.RoleID == "" || config.AppRoleAuth.SecretID == "" {
+			return errors.New("AppRole ID and secret ID are required")
+		}
+	}
+	if config.K8sAuth != nil {
+		count++
+		if config.K8sAuth.RoleName == "" {
+			return errors.New("Kubernetes auth role name is required")
+		}
+	}
+
+	if count != 1 {
+		return errors.New("exactly one of token_auth, approle_auth and k8s_auth must be configured")
+	}
+
+	return nil
+}
+
+// login authenticates to Vault with the configured auth method and sets the token of the client.
+func (ca *X509CA) login(ctx context.Context) error {
+	var (
+		secret *vaultapi.Secret
+		err    error
+	)
+	switch {
+	case ca.config.TokenAuth != nil:
+		token := ca.config.TokenAuth.Token
+		if token == "" {
+			token = os.Getenv(vaultapi.EnvVaultToken)
+		}
+		if token == "" {
+			return errors.New("Vault token is required")
+		}
+		ca.client.SetToken(token)
+
+		secret, err = ca.client.Auth().Token().LookupSelfWithContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to look up Vault token: %w", err)
+		}
+	case ca.config.AppRoleAuth != nil:
+		mountPoint := mountPointOrDefault(ca.config.AppRoleAuth.MountPoint, defaultAppRoleMountPoint)
+		secret, err = ca.client.Logical().WriteWithContext(ctx, "auth/"+mountPoint+"/login", map[string]interface{}{
+			"role_id":   ca.config.AppRoleAuth.RoleID,
+			"secret_id": ca.config.AppRoleAuth.SecretID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log in to Vault with AppRole: %w", err)
+		}
+	case ca.config.K8sAuth != nil:
+		tokenPath := ca.config.K8sAuth.TokenPath
+		if tokenPath == "" {
+			tokenPath = defaultK8sTokenPath
+		}
+		jwt, err := os.ReadFile(tokenPath)
+		if err != nil {
+			return fmt.Errorf("failed to read Kubernetes service account token: %w", err)
+		}
+
+		mountPoint := mountPointOrDefault(ca.config.K8sAuth.MountPoint, defaultK8sMountPoint)
+		secret, err = ca.client.Logical().WriteWithContext(ctx, "auth/"+mountPoint+"/login", map[string]interface{}{
+			"role": ca.config.K8sAuth.RoleName,
+			"jwt":  strings.TrimSpace(string(jwt)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log in to Vault with Kubernetes auth: %w", err)
+		}
+	}
+
+	return ca.setToken(secret)
+}
+
+// setToken sets the token of the client from the response of a login, a token look up or a token renewal.
+func (ca *X509CA) setToken(secret *vaultapi.Secret) error {
+	if secret == nil {
+		return errors.New("empty response from Vault")
+	}
+
+	token, err := secret.TokenID()
+	if err != nil || token == "" {
+		return errors.New("no token in the response of Vault")
+	}
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return fmt.Errorf("invalid token TTL in the response of Vault: %w", err)
+	}
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		return fmt.Errorf("invalid token renewable flag in the response of Vault: %w", err)
+	}
+
+	ca.client.SetToken(token)
+
+	ca.token.ttl = ttl
+	ca.token.renewable = renewable
+	ca.token.renewAt = time.Time{}
+	ca.token.expiresAt = time.Time{}
+	if ttl > 0 {
+		now := ca.clock.Now()
+		ca.token.renewAt = now.Add(ttl / 2)
+		ca.token.expiresAt = now.Add(ttl)
+	}
+
+	return nil
+}
+
+// renewToken renews the Vault token once half of its TTL has elapsed. Tokens that cannot be renewed are replaced
+// by logging in again, when the auth method allows it.
+func (ca *X509CA) renewToken(ctx context.Context) error {
+	ca.token.mu.Lock()
+	defer ca.token.mu.Unlock()
+
+	now := ca.clock.Now()
+	if ca.token.renewAt.IsZero() || now.Before(ca.token.renewAt) {
+		return nil
+	}
+
+	if ca.token.renewable {
+		secret, err := ca.client.Auth().Token().RenewSelfWithContext(ctx, int(ca.token.ttl.Seconds()))
+		if err == nil {
+			err = ca.setToken(secret)
+		}
+		if err == nil {
+			ca.logger.WithField(telemetry.ExpiresAt, ca.token.expiresAt).Debug("Renewed Vault token")
+			return nil
+		}
+		ca.logger.WithError(err).Warn("Failed to renew Vault token")
+	}
+
+	if ca.config.TokenAuth == nil {
+		if err := ca.login(ctx); err != nil {
+			return err
+		}
+		ca.logger.WithField(telemetry.ExpiresAt, ca.token.expiresAt).Debug("Logged in to Vault again")
+		return nil
+	}
+
+	if now.Before(ca.token.expiresAt) {
+		return nil
+	}
+
+	return errors.New("Vault token expired and cannot be renewed")
+}
+
+// startTokenRenewal renews the Vault token in the background, so that it does not expire between two calls to Vault.
+func (ca *X509CA) startTokenRenewal(ctx context.Context) {
+	ticker := time.NewTicker(tokenRenewalInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ca.renewToken(ctx); err != nil {
+				ca.logger.WithError(err).Error("Failed to renew Vault token")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func mountPointOrDefault(mountPoint, defaultMountPoint string) string {
+	if mountPoint == "" {
+		return defaultMountPoint
+	}
+	return strings.Trim(mountPoint, "/")
+}
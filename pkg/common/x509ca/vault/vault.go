@@ -0,0 +1,329 @@
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultPKIMountPoint = "pki"
+
+// X509CA is a CA that has X509 certificates signed by the PKI secrets engine of HashiCorp Vault, so that the CA
+// private key never leaves Vault. The certificates are signed from the certificate requests of the issued keys, with
+// the role of the PKI secrets engine.
+type X509CA struct {
+	client *vaultapi.Client
+	config *Config
+
+	// token is the Vault token of the client and its lease
+	token tokenState
+
+	mu sync.RWMutex
+	// ROOT CA certificates that the certificates signed by Vault chain up to.
+	authorities []*x509.Certificate
+
+	// stops the token renewal
+	cancel context.CancelFunc
+
+	clock clock.Clock
+
+	logger logrus.FieldLogger
+}
+
+// Config is the configuration for a Vault X509CA.
+type Config struct {
+	// The address of the Vault server. Defaults to the VAULT_ADDR environment variable.
+	VaultAddr string `hcl:"vault_addr,optional"`
+	// The Vault Enterprise namespace. Optional, defaults to the VAULT_NAMESPACE environment variable.
+	Namespace string `hcl:"namespace,optional"`
+	// The path to the file containing the CA certificates that the Vault server certificate is verified with.
+	// Optional, defaults to the VAULT_CACERT environment variable or the system roots.
+	CACertPath string `hcl:"ca_cert_path,optional"`
+	// Skips the verification of the Vault server certificate. Only meant for tests.
+	InsecureSkipVerify bool `hcl:"insecure_skip_verify,optional"`
+
+	// The mount point of the PKI secrets engine. Defaults to "pki".
+	PKIMountPoint string `hcl:"pki_mount_point,optional"`
+	// The role of the PKI secrets engine the certificates are signed with.
+	PKIRole string `hcl:"pki_role"`
+
+	// The method used to authenticate to Vault. Exactly one must be set.
+	TokenAuth   *TokenAuthConfig   `hcl:"token_auth,block"`
+	AppRoleAuth *AppRoleAuthConfig `hcl:"approle_auth,block"`
+	K8sAuth     *K8sAuthConfig     `hcl:"k8s_auth,block"`
+}
+
+// New creates a new Vault X509CA.
+// The returned X509CA is not configured.
+// Call Configure() to configure it passing the HCL configuration.
+func New() (*X509CA, error) {
+	return &X509CA{
+		clock:  clock.New(),
+		logger: logrus.WithField(telemetry.SubsystemName, telemetry.VaultX509CA),
+	}, nil
+}
+
+// Configure authenticates to Vault with the configured auth method and loads the CA certificates of the PKI secrets
+// engine. The Vault token is renewed in the background until Close is called.
+func (ca *X509CA) Configure(config *Config) error {
+	if config.PKIRole == "" {
+		return errors.New("PKI role is required")
+	}
+	if err := validateAuth(config); err != nil {
+		return err
+	}
+
+	cfg := *config
+	if cfg.PKIMountPoint == "" {
+		cfg.PKIMountPoint = defaultPKIMountPoint
+	}
+	cfg.PKIMountPoint = strings.Trim(cfg.PKIMountPoint, "/")
+
+	client, err := newClient(&cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Vault client: %w", err)
+	}
+	ca.client = client
+	ca.config = &cfg
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := ca.login(ctx); err != nil {
+		cancel()
+		return err
+	}
+
+	if err := ca.loadAuthorities(ctx); err != nil {
+		cancel()
+		return err
+	}
+
+	ca.cancel = cancel
+	go ca.startTokenRenewal(ctx)
+
+	return nil
+}
+
+// Close stops the renewal of the Vault token.
+func (ca *X509CA) Close() error {
+	if ca.cancel != nil {
+		ca.cancel()
+	}
+	return nil
+}
+
+// IssueX509Certificate has Vault sign an X509 certificate from the certificate request of the params, with the
+// subject, SANs and TTL of the params. The certificate is returned along with the intermediate CA certificates.
+func (ca *X509CA) IssueX509Certificate(ctx context.Context, params *x509ca.X509CertificateParams) ([]*x509.Certificate, error) {
+	if ca.client == nil {
+		return nil, errors.New("X509 CA is not configured")
+	}
+	if len(params.CertificateRequest) == 0 {
+		return nil, errors.New("certificate request is required")
+	}
+	if params.TTL == 0 {
+		return nil, errors.New("TTL is required")
+	}
+
+	if err := ca.renewToken(ctx); err != nil {
+		return nil, err
+	}
+
+	uriSANs := make([]string, 0, len(params.URIs))
+	for _, uri := range params.URIs {
+		uriSANs = append(uriSANs, uri.String())
+	}
+	ipSANs := make([]string, 0, len(params.IPAddresses))
+	for _, ip := range params.IPAddresses {
+		ipSANs = append(ipSANs, ip.String())
+	}
+
+	data := map[string]interface{}{
+		"csr":                  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: params.CertificateRequest})),
+		"common_name":          params.Subject.CommonName,
+		"alt_names":            strings.Join(params.DNSNames, ","),
+		"ip_sans":              strings.Join(ipSANs, ","),
+		"uri_sans":             strings.Join(uriSANs, ","),
+		"ttl":                  fmt.Sprintf("%ds", int64(params.TTL.Seconds())),
+		"exclude_cn_from_sans": true,
+		"format":               "pem",
+	}
+
+	secret, err := ca.client.Logical().WriteWithContext(ctx, ca.config.PKIMountPoint+"/sign/"+ca.config.PKIRole, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign X509 certificate: %w", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, errors.New("failed to sign X509 certificate: empty response from Vault")
+	}
+
+	certPEM, _ := secret.Data["certificate"].(string)
+	cert, err := cryptoutil.ParseCertificate([]byte(certPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signed X509 certificate: %w", err)
+	}
+
+	caChain, err := parseCAChain(secret.Data)
+	if err != nil {
+		return nil, err
+	}
+	authorities, intermediates := splitCAChain(caChain)
+
+	// the role may sign with another issuer than the default one, whose ROOT CA is added to the authorities
+	ca.mu.Lock()
+	ca.authorities = mergeAuthorities(ca.authorities, authorities)
+	ca.mu.Unlock()
+
+	return append([]*x509.Certificate{cert}, intermediates...), nil
+}
+
+// GetX509Authorities returns the ROOT CA certificates that the certificates signed by Vault chain up to.
+func (ca *X509CA) GetX509Authorities(ctx context.Context) ([]*x509.Certificate, error) {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	if len(ca.authorities) == 0 {
+		return nil, errors.New("X509 CA is not configured")
+	}
+
+	return append([]*x509.Certificate{}, ca.authorities...), nil
+}
+
+// loadAuthorities loads the CA certificates of the default issuer of the PKI secrets engine.
+func (ca *X509CA) loadAuthorities(ctx context.Context) error {
+	secret, err := ca.client.Logical().ReadWithContext(ctx, ca.config.PKIMountPoint+"/cert/ca_chain")
+	if err != nil {
+		return fmt.Errorf("failed to read CA chain from Vault: %w", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return fmt.Errorf("no CA chain found in Vault PKI mount %q", ca.config.PKIMountPoint)
+	}
+
+	chainPEM, _ := secret.Data["certificate"].(string)
+	caChain, err := cryptoutil.ParseCertificates([]byte(chainPEM))
+	if err != nil {
+		return fmt.Errorf("failed to parse CA chain from Vault: %w", err)
+	}
+	if len(caChain) == 0 {
+		return fmt.Errorf("no CA chain found in Vault PKI mount %q", ca.config.PKIMountPoint)
+	}
+
+	authorities, _ := splitCAChain(caChain)
+
+	ca.mu.Lock()
+	ca.authorities = authorities
+	ca.mu.Unlock()
+
+	return nil
+}
+
+// parseCAChain parses the CA chain of a certificate signed by Vault, which is made of the issuing CA certificate
+// up to the ROOT CA certificate.
+func parseCAChain(data map[string]interface{}) ([]*x509.Certificate, error) {
+	var chainPEM []byte
+	if caChain, ok := data["ca_chain"].([]interface{}); ok {
+		for _, c := range caChain {
+			if s, ok := c.(string); ok {
+				chainPEM = append(chainPEM, []byte(s+"\n")...)
+			}
+		}
+	}
+	if len(chainPEM) == 0 {
+		issuingCA, _ := data["issuing_ca"].(string)
+		chainPEM = []byte(issuingCA)
+	}
+
+	caChain, err := cryptoutil.ParseCertificates(chainPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA chain of signed X509 certificate: %w", err)
+	}
+	if len(caChain) == 0 {
+		return nil, errors.New("no CA chain in the response of Vault")
+	}
+
+	return caChain, nil
+}
+
+// splitCAChain splits a CA chain into the ROOT CA certificates and the intermediate CA certificates. The last
+// certificate of the chain is used as ROOT CA when the chain has no self-signed certificate, which happens when
+// the issuing CA of Vault was imported without its ROOT CA.
+func splitCAChain(caChain []*x509.Certificate) ([]*x509.Certificate, []*x509.Certificate) {
+	var authorities, intermediates []*x509.Certificate
+	for _, cert := range caChain {
+		if cert.CheckSignatureFrom(cert) == nil {
+			authorities = append(authorities, cert)
+			continue
+		}
+		intermediates = append(intermediates, cert)
+	}
+
+	if len(authorities) == 0 {
+		last := len(intermediates) - 1
+		authorities = intermediates[last:]
+		intermediates = intermediates[:last]
+	}
+
+	return authorities, intermediates
+}
+
+// mergeAuthorities returns the authorities along with the new ones that they don't hold yet.
+func mergeAuthorities(authorities, newAuthorities []*x509.Certificate) []*x509.Certificate {
+	merged := append([]*x509.Certificate{}, authorities...)
+	for _, newAuthority := range newAuthorities {
+		found := false
+		for _, authority := range authorities {
+			if authority.Equal(newAuthority) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, newAuthority)
+		}
+	}
+
+	return merged
+}
+
+func newClient(config *Config) (*vaultapi.Client, error) {
+	clientConfig := vaultapi.DefaultConfig()
+	if clientConfig.Error != nil {
+		return nil, clientConfig.Error
+	}
+	if config.VaultAddr != "" {
+		clientConfig.Address = config.VaultAddr
+	}
+	if config.CACertPath != "" || config.InsecureSkipVerify {
+		if err := clientConfig.ConfigureTLS(&vaultapi.TLSConfig{
+			CACert:   config.CACertPath,
+			Insecure: config.InsecureSkipVerify,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	clientConfig.Timeout = 30 * time.Second
+
+	client, err := vaultapi.NewClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token of the VAULT_TOKEN environment variable is only used by the token auth method
+	client.ClearToken()
+	if config.Namespace != "" {
+		client.SetNamespace(config.Namespace)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,529 @@
+package vault
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/jmhodges/clock"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vaultDevEnv is the environment variable holding the address of the local Vault dev server, started with
+// `vault server -dev -dev-root-token-id=root`, the dev server tests run against. The tests are skipped when it is not set.
+const vaultDevEnv = "GALADRIEL_TEST_VAULT_ADDR"
+
+const (
+	testRole     = "galadriel"
+	testRoleID   = "role-id"
+	testSecretID = "secret-id"
+	testK8sRole  = "galadriel-server"
+	testK8sJWT   = "service-account-token"
+	rootToken    = "root-token"
+	devRootToken = "root"
+)
+
+// fakeVault emulates the endpoints of Vault used by the X509CA: the auth methods, the token renewal and the PKI
+// secrets engine, with an intermediate CA signed by a ROOT CA.
+type fakeVault struct {
+	t *testing.T
+
+	rootCA          *x509.Certificate
+	intermediateCA  *x509.Certificate
+	intermediateKey crypto.PrivateKey
+
+	mu           sync.Mutex
+	tokens       map[string]bool
+	tokenTTL     int
+	renewable    bool
+	logins       int
+	renewals     int
+	signRequests []map[string]interface{}
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	rootCA, rootKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	intermediateCA, intermediateKey := certtest.CreateTestIntermediateCACertificate(t, clock.New(), rootCA, rootKey, "vault-intermediate-ca")
+
+	v := &fakeVault{
+		t:               t,
+		rootCA:          rootCA,
+		intermediateCA:  intermediateCA,
+		intermediateKey: intermediateKey,
+		tokens:          map[string]bool{rootToken: true},
+		tokenTTL:        3600,
+		renewable:       true,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(v.serveHTTP))
+	t.Cleanup(server.Close)
+
+	return v, server
+}
+
+func (v *fakeVault) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	body := map[string]interface{}{}
+	if r.Body != nil && r.ContentLength != 0 {
+		require.NoError(v.t, json.NewDecoder(r.Body).Decode(&body))
+	}
+
+	switch r.URL.Path {
+	case "/v1/auth/approle/login":
+		if body["role_id"] != testRoleID || body["secret_id"] != testSecretID {
+			v.writeError(w, http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+		v.writeAuth(w, v.newToken())
+		return
+	case "/v1/auth/kubernetes/login":
+		if body["role"] != testK8sRole || body["jwt"] != testK8sJWT {
+			v.writeError(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		v.writeAuth(w, v.newToken())
+		return
+	}
+
+	token := r.Header.Get("X-Vault-Token")
+	if !v.tokens[token] {
+		v.writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v1/auth/token/lookup-self":
+		v.writeJSON(w, map[string]interface{}{
+			"data": map[string]interface{}{"id": token, "ttl": v.tokenTTL, "renewable": v.renewable},
+		})
+	case "/v1/auth/token/renew-self":
+		v.renewals++
+		v.writeAuth(w, token)
+	case "/v1/pki/cert/ca_chain":
+		v.writeJSON(w, map[string]interface{}{
+			"data": map[string]interface{}{"certificate": string(v.caChainPEM())},
+		})
+	case "/v1/pki/sign/" + testRole:
+		v.signRequests = append(v.signRequests, body)
+		v.sign(w, body)
+	default:
+		v.writeError(w, http.StatusNotFound, "no handler for route")
+	}
+}
+
+func (v *fakeVault) sign(w http.ResponseWriter, body map[string]interface{}) {
+	csrPEM, _ := body["csr"].(string)
+	block, _ := pem.Decode([]byte(csrPEM))
+	require.NotNil(v.t, block)
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	require.NoError(v.t, err)
+	require.NoError(v.t, csr.CheckSignature())
+
+	ttl, err := time.ParseDuration(body["ttl"].(string))
+	require.NoError(v.t, err)
+
+	var dnsNames []string
+	if altNames := body["alt_names"].(string); altNames != "" {
+		dnsNames = strings.Split(altNames, ",")
+	}
+	var uris []*url.URL
+	if uriSANs := body["uri_sans"].(string); uriSANs != "" {
+		for _, uri := range strings.Split(uriSANs, ",") {
+			u, err := url.Parse(uri)
+			require.NoError(v.t, err)
+			uris = append(uris, u)
+		}
+	}
+
+	template, err := cryptoutil.CreateX509Template(clock.New(), csr.PublicKey, pkix.Name{CommonName: body["common_name"].(string)}, uris, dnsNames, ttl)
+	require.NoError(v.t, err)
+	if ipSANs := body["ip_sans"].(string); ipSANs != "" {
+		for _, ip := range strings.Split(ipSANs, ",") {
+			template.IPAddresses = append(template.IPAddresses, net.ParseIP(ip))
+		}
+	}
+	cert, err := cryptoutil.SignX509(template, v.intermediateCA, v.intermediateKey)
+	require.NoError(v.t, err)
+
+	v.writeJSON(w, map[string]interface{}{
+		"data": map[string]interface{}{
+			"certificate": string(cryptoutil.EncodeCertificate(cert)),
+			"issuing_ca":  string(cryptoutil.EncodeCertificate(v.intermediateCA)),
+			"ca_chain": []string{
+				string(cryptoutil.EncodeCertificate(v.intermediateCA)),
+				string(cryptoutil.EncodeCertificate(v.rootCA)),
+			},
+		},
+	})
+}
+
+func (v *fakeVault) newToken() string {
+	v.logins++
+	token := "token-" + strings.Repeat("x", v.logins)
+	v.tokens[token] = true
+	return token
+}
+
+// expireTokens revokes all the tokens, except the root token.
+func (v *fakeVault) expireTokens() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tokens = map[string]bool{rootToken: true}
+}
+
+func (v *fakeVault) caChainPEM() []byte {
+	return append(cryptoutil.EncodeCertificate(v.intermediateCA), cryptoutil.EncodeCertificate(v.rootCA)...)
+}
+
+func (v *fakeVault) writeAuth(w http.ResponseWriter, token string) {
+	v.writeJSON(w, map[string]interface{}{
+		"auth": map[string]interface{}{"client_token": token, "lease_duration": v.tokenTTL, "renewable": v.renewable},
+	})
+}
+
+func (v *fakeVault) writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(v.t, json.NewEncoder(w).Encode(body))
+}
+
+func (v *fakeVault) writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(v.t, json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{msg}}))
+}
+
+func TestConfigure(t *testing.T) {
+	vault, server := newFakeVault(t)
+
+	k8sTokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(k8sTokenPath, []byte(testK8sJWT+"\n"), 0600))
+
+	for name, config := range map[string]*Config{
+		"token":      {TokenAuth: &TokenAuthConfig{Token: rootToken}},
+		"AppRole":    {AppRoleAuth: &AppRoleAuthConfig{RoleID: testRoleID, SecretID: testSecretID}},
+		"Kubernetes": {K8sAuth: &K8sAuthConfig{RoleName: testK8sRole, TokenPath: k8sTokenPath}},
+	} {
+		config := config
+		t.Run("Authenticates with "+name, func(t *testing.T) {
+			config.VaultAddr = server.URL
+			config.PKIRole = testRole
+			ca := newCA(t, config)
+
+			authorities, err := ca.GetX509Authorities(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, []*x509.Certificate{vault.rootCA}, authorities)
+		})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config *Config
+		err    string
+	}{
+		{
+			name:   "Missing role",
+			config: &Config{VaultAddr: server.URL, TokenAuth: &TokenAuthConfig{Token: rootToken}},
+			err:    "PKI role is required",
+		},
+		{
+			name:   "Missing auth method",
+			config: &Config{VaultAddr: server.URL, PKIRole: testRole},
+			err:    "exactly one of token_auth, approle_auth and k8s_auth must be configured",
+		},
+		{
+			name: "Several auth methods",
+			config: &Config{
+				VaultAddr:   server.URL,
+				PKIRole:     testRole,
+				TokenAuth:   &TokenAuthConfig{Token: rootToken},
+				AppRoleAuth: &AppRoleAuthConfig{RoleID: testRoleID, SecretID: testSecretID},
+			},
+			err: "exactly one of token_auth, approle_auth and k8s_auth must be configured",
+		},
+		{
+			name:   "Invalid token",
+			config: &Config{VaultAddr: server.URL, PKIRole: testRole, TokenAuth: &TokenAuthConfig{Token: "invalid"}},
+			err:    "failed to look up Vault token",
+		},
+		{
+			name:   "Invalid AppRole secret ID",
+			config: &Config{VaultAddr: server.URL, PKIRole: testRole, AppRoleAuth: &AppRoleAuthConfig{RoleID: testRoleID, SecretID: "invalid"}},
+			err:    "failed to log in to Vault with AppRole",
+		},
+		{
+			name:   "Missing service account token",
+			config: &Config{VaultAddr: server.URL, PKIRole: testRole, K8sAuth: &K8sAuthConfig{RoleName: testK8sRole, TokenPath: "/not-found"}},
+			err:    "failed to read Kubernetes service account token",
+		},
+		{
+			name:   "Unknown PKI mount",
+			config: &Config{VaultAddr: server.URL, PKIRole: testRole, PKIMountPoint: "other-pki", TokenAuth: &TokenAuthConfig{Token: rootToken}},
+			err:    `no CA chain found in Vault PKI mount "other-pki"`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ca, err := New()
+			require.NoError(t, err)
+			err = ca.Configure(tc.config)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestIssueX509Certificate(t *testing.T) {
+	vault, server := newFakeVault(t)
+	ca := newCA(t, &Config{VaultAddr: server.URL, PKIRole: testRole, TokenAuth: &TokenAuthConfig{Token: rootToken}})
+
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	params := &x509ca.X509CertificateParams{
+		PublicKey:   signer.Public(),
+		Subject:     pkix.Name{CommonName: "td1.org"},
+		DNSNames:    []string{"galadriel-server"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:        []*url.URL{spiffeid.RequireFromString("spiffe://td1.org/galadriel/harvester/default").URL()},
+		TTL:         time.Hour,
+	}
+
+	_, err = ca.IssueX509Certificate(context.Background(), params)
+	assert.EqualError(t, err, "certificate request is required")
+
+	require.NoError(t, params.SetCertificateRequest(signer))
+	certChain, err := ca.IssueX509Certificate(context.Background(), params)
+	require.NoError(t, err)
+
+	// the certificate is returned along with the intermediate CA of Vault
+	require.Len(t, certChain, 2)
+	assert.Equal(t, vault.intermediateCA, certChain[1])
+
+	leaf := certChain[0]
+	assert.Equal(t, "td1.org", leaf.Subject.CommonName)
+	assert.Equal(t, params.DNSNames, leaf.DNSNames)
+	assert.Equal(t, params.URIs, leaf.URIs)
+	require.Len(t, leaf.IPAddresses, 1)
+	assert.True(t, params.IPAddresses[0].Equal(leaf.IPAddresses[0]))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), leaf.NotAfter, time.Minute)
+
+	require.Len(t, vault.signRequests, 1)
+	assert.Equal(t, "3600s", vault.signRequests[0]["ttl"])
+	assert.Equal(t, true, vault.signRequests[0]["exclude_cn_from_sans"])
+
+	authorities, err := ca.GetX509Authorities(context.Background())
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	for _, authority := range authorities {
+		roots.AddCert(authority)
+	}
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(certChain[1])
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	require.NoError(t, err)
+}
+
+func TestIssueX509CertificateWithAnotherIssuer(t *testing.T) {
+	vault, server := newFakeVault(t)
+	ca := newCA(t, &Config{VaultAddr: server.URL, PKIRole: testRole, TokenAuth: &TokenAuthConfig{Token: rootToken}})
+	defaultRootCA := vault.rootCA
+
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	params := &x509ca.X509CertificateParams{
+		PublicKey: signer.Public(),
+		Subject:   pkix.Name{CommonName: "td1.org"},
+		TTL:       time.Hour,
+	}
+	require.NoError(t, params.SetCertificateRequest(signer))
+
+	_, err = ca.IssueX509Certificate(context.Background(), params)
+	require.NoError(t, err)
+
+	authorities, err := ca.GetX509Authorities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*x509.Certificate{defaultRootCA}, authorities)
+
+	// the role now signs with an issuer chaining up to another ROOT CA than the default issuer
+	otherRootCA, otherRootKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	otherIntermediateCA, otherIntermediateKey := certtest.CreateTestIntermediateCACertificate(t, clock.New(), otherRootCA, otherRootKey, "vault-other-intermediate-ca")
+	vault.mu.Lock()
+	vault.rootCA, vault.intermediateCA, vault.intermediateKey = otherRootCA, otherIntermediateCA, otherIntermediateKey
+	vault.mu.Unlock()
+
+	for i := 0; i < 2; i++ {
+		_, err = ca.IssueX509Certificate(context.Background(), params)
+		require.NoError(t, err)
+	}
+
+	// the ROOT CA of the other issuer is added once, the certificates of the default issuer are still trusted
+	authorities, err = ca.GetX509Authorities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*x509.Certificate{defaultRootCA, otherRootCA}, authorities)
+}
+
+func TestRenewToken(t *testing.T) {
+	t.Run("Renewable tokens are renewed once half of their TTL has elapsed", func(t *testing.T) {
+		vault, server := newFakeVault(t)
+		ca := newCA(t, &Config{VaultAddr: server.URL, PKIRole: testRole, TokenAuth: &TokenAuthConfig{Token: rootToken}})
+		clk := ca.clock.(clock.FakeClock)
+
+		require.NoError(t, ca.renewToken(context.Background()))
+		assert.Equal(t, 0, vault.renewals)
+
+		clk.Add(31 * time.Minute)
+		require.NoError(t, ca.renewToken(context.Background()))
+		assert.Equal(t, 1, vault.renewals)
+		assert.Equal(t, clk.Now().Add(time.Hour), ca.token.expiresAt)
+	})
+
+	t.Run("Expired AppRole tokens are replaced by logging in again", func(t *testing.T) {
+		vault, server := newFakeVault(t)
+		vault.renewable = false
+		ca := newCA(t, &Config{VaultAddr: server.URL, PKIRole: testRole, AppRoleAuth: &AppRoleAuthConfig{RoleID: testRoleID, SecretID: testSecretID}})
+		clk := ca.clock.(clock.FakeClock)
+		assert.Equal(t, 1, vault.logins)
+
+		vault.expireTokens()
+		clk.Add(time.Hour)
+
+		signer, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		params := &x509ca.X509CertificateParams{PublicKey: signer.Public(), Subject: pkix.Name{CommonName: "td1.org"}, TTL: time.Hour}
+		require.NoError(t, params.SetCertificateRequest(signer))
+
+		_, err = ca.IssueX509Certificate(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, 2, vault.logins)
+		assert.Equal(t, 0, vault.renewals)
+	})
+
+	t.Run("Expired tokens that cannot be renewed are reported", func(t *testing.T) {
+		vault, server := newFakeVault(t)
+		vault.renewable = false
+		ca := newCA(t, &Config{VaultAddr: server.URL, PKIRole: testRole, TokenAuth: &TokenAuthConfig{Token: rootToken}})
+		clk := ca.clock.(clock.FakeClock)
+
+		clk.Add(45 * time.Minute)
+		require.NoError(t, ca.renewToken(context.Background()))
+
+		clk.Add(15 * time.Minute)
+		err := ca.renewToken(context.Background())
+		assert.EqualError(t, err, "Vault token expired and cannot be renewed")
+	})
+}
+
+func TestVaultDevServer(t *testing.T) {
+	vaultAddr := os.Getenv(vaultDevEnv)
+	if vaultAddr == "" {
+		t.Skipf("%s is not set", vaultDevEnv)
+	}
+	ctx := context.Background()
+
+	// sets up a PKI secrets engine with a ROOT CA and a role for Galadriel
+	clientConfig := vaultapi.DefaultConfig()
+	clientConfig.Address = vaultAddr
+	client, err := vaultapi.NewClient(clientConfig)
+	require.NoError(t, err)
+	client.SetToken(devRootToken)
+
+	mountPoint := fmt.Sprintf("pki-galadriel-test-%d", time.Now().UnixNano())
+	require.NoError(t, client.Sys().MountWithContext(ctx, mountPoint, &vaultapi.MountInput{
+		Type:   "pki",
+		Config: vaultapi.MountConfigInput{MaxLeaseTTL: "24h"},
+	}))
+	t.Cleanup(func() {
+		require.NoError(t, client.Sys().UnmountWithContext(ctx, mountPoint))
+	})
+	_, err = client.Logical().WriteWithContext(ctx, mountPoint+"/root/generate/internal", map[string]interface{}{
+		"common_name": "Galadriel Test Root CA",
+		"ttl":         "24h",
+	})
+	require.NoError(t, err)
+	_, err = client.Logical().WriteWithContext(ctx, mountPoint+"/roles/"+testRole, map[string]interface{}{
+		"allow_any_name":      true,
+		"enforce_hostnames":   false,
+		"allowed_uri_sans":    "spiffe://*",
+		"use_csr_common_name": false,
+		"use_csr_sans":        false,
+		"max_ttl":             "2h",
+	})
+	require.NoError(t, err)
+
+	ca := newCA(t, &Config{
+		VaultAddr:     vaultAddr,
+		PKIMountPoint: mountPoint,
+		PKIRole:       testRole,
+		TokenAuth:     &TokenAuthConfig{Token: devRootToken},
+	})
+
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	params := &x509ca.X509CertificateParams{
+		PublicKey: signer.Public(),
+		Subject:   pkix.Name{CommonName: "td1.org"},
+		URIs:      []*url.URL{spiffeid.RequireFromString("spiffe://td1.org/galadriel/harvester/default").URL()},
+		TTL:       time.Hour,
+	}
+	require.NoError(t, params.SetCertificateRequest(signer))
+
+	certChain, err := ca.IssueX509Certificate(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, params.URIs, certChain[0].URIs)
+
+	authorities, err := ca.GetX509Authorities(ctx)
+	require.NoError(t, err)
+	require.Len(t, authorities, 1)
+	assert.Equal(t, "Galadriel Test Root CA", authorities[0].Subject.CommonName)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(authorities[0])
+	_, err = certChain[0].Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	require.NoError(t, err)
+}
+
+func TestSplitCAChain(t *testing.T) {
+	rootCA, rootKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	intermediateCA, intermediateKey := certtest.CreateTestIntermediateCACertificate(t, clock.New(), rootCA, rootKey, "intermediate-ca")
+	intermediateCA2, _ := certtest.CreateTestIntermediateCACertificate(t, clock.New(), intermediateCA, intermediateKey, "intermediate-ca-2")
+
+	authorities, intermediates := splitCAChain([]*x509.Certificate{intermediateCA2, intermediateCA, rootCA})
+	assert.Equal(t, []*x509.Certificate{rootCA}, authorities)
+	assert.Equal(t, []*x509.Certificate{intermediateCA2, intermediateCA}, intermediates)
+
+	// the issuing CA was imported without its ROOT CA
+	authorities, intermediates = splitCAChain([]*x509.Certificate{intermediateCA2, intermediateCA})
+	assert.Equal(t, []*x509.Certificate{intermediateCA}, authorities)
+	assert.Equal(t, []*x509.Certificate{intermediateCA2}, intermediates)
+}
+
+func newCA(t *testing.T, config *Config) *X509CA {
+	ca, err := New()
+	require.NoError(t, err)
+	ca.clock = clock.NewFake()
+
+	require.NoError(t, ca.Configure(config))
+	t.Cleanup(func() { require.NoError(t, ca.Close()) })
+
+	return ca
+}
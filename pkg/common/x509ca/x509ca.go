@@ -3,8 +3,11 @@ package x509ca
 import (
 	"context"
 	"crypto"
+	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"fmt"
+	"net"
 	"net/url"
 	"time"
 )
@@ -13,6 +16,9 @@ import (
 type X509CA interface {
 	// IssueX509Certificate issues an X509 certificate and returns the leaf certificate and the certificate chain.
 	IssueX509Certificate(context.Context, *X509CertificateParams) ([]*x509.Certificate, error)
+
+	// GetX509Authorities returns the root certificates that the certificates issued by the CA chain up to.
+	GetX509Authorities(context.Context) ([]*x509.Certificate, error)
 }
 
 // X509CertificateParams holds the parameters for issuing an X509 certificate.
@@ -22,5 +28,28 @@ type X509CertificateParams struct {
 	Subject   pkix.Name
 	URIs      []*url.URL
 	DNSNames  []string
-	TTL       time.Duration
+	// IPAddresses to be set as IP SANs in the certificate
+	IPAddresses []net.IP
+	TTL         time.Duration
+
+	// CertificateRequest is the DER encoded certificate request for the public key, signed with its private key.
+	// It is required by the X509CAs that have the certificates signed from a certificate request, such as Vault.
+	CertificateRequest []byte
+}
+
+// SetCertificateRequest sets the certificate request of the params, for their subject and SANs, signed with the
+// given private key of their public key.
+func (p *X509CertificateParams) SetCertificateRequest(signer crypto.Signer) error {
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     p.Subject,
+		DNSNames:    p.DNSNames,
+		IPAddresses: p.IPAddresses,
+		URIs:        p.URIs,
+	}, signer)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate request: %w", err)
+	}
+
+	p.CertificateRequest = csr
+	return nil
 }
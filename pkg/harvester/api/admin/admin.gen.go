@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	externalRef0 "github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
@@ -31,6 +32,28 @@ type PatchRelationshipRequest struct {
 	ConsentStatus externalRef0.ConsentStatus `json:"consent_status"`
 }
 
+// RelationshipConsent defines model for RelationshipConsent.
+type RelationshipConsent struct {
+	ConsentStatus externalRef0.ConsentStatus `json:"consent_status"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature externalRef0.Signature `json:"signature"`
+
+	// SigningCertificate base64 encoded DER certificate chain of the signing key, leaf first
+	SigningCertificate string `json:"signing_certificate"`
+
+	// Statement base64 encoded JSON consent statement, as signed
+	Statement       string                       `json:"statement"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+	UpdatedAt       time.Time                    `json:"updated_at"`
+
+	// VerificationError Reason why the signature could not be verified
+	VerificationError *string `json:"verification_error,omitempty"`
+
+	// Verified Whether the signature was verified by any of the configured bundle verifiers
+	Verified bool `json:"verified"`
+}
+
 // Default defines model for Default.
 type Default = externalRef0.ApiError
 
@@ -122,6 +145,9 @@ type ClientInterface interface {
 	PatchRelationshipWithBody(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PatchRelationship(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsents(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -160,6 +186,18 @@ func (c *Client) PatchRelationship(ctx context.Context, relationshipID externalR
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetRelationshipConsents(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipConsentsRequest(c.Server, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetRelationshipsRequest generates requests for GetRelationships
 func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (*http.Request, error) {
 	var err error
@@ -256,6 +294,40 @@ func NewPatchRelationshipRequestWithBody(server string, relationshipID externalR
 	return req, nil
 }
 
+// NewGetRelationshipConsentsRequest generates requests for GetRelationshipConsents
+func NewGetRelationshipConsentsRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/relationships/%s/consents", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -306,6 +378,9 @@ type ClientWithResponsesInterface interface {
 	PatchRelationshipWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
 
 	PatchRelationshipWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsentsWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error)
 }
 
 type GetRelationshipsResponse struct {
@@ -354,6 +429,29 @@ func (r PatchRelationshipResponse) StatusCode() int {
 	return 0
 }
 
+type GetRelationshipConsentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RelationshipConsent
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipConsentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipConsentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetRelationshipsWithResponse request returning *GetRelationshipsResponse
 func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
 	rsp, err := c.GetRelationships(ctx, params, reqEditors...)
@@ -380,6 +478,15 @@ func (c *ClientWithResponses) PatchRelationshipWithResponse(ctx context.Context,
 	return ParsePatchRelationshipResponse(rsp)
 }
 
+// GetRelationshipConsentsWithResponse request returning *GetRelationshipConsentsResponse
+func (c *ClientWithResponses) GetRelationshipConsentsWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error) {
+	rsp, err := c.GetRelationshipConsents(ctx, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipConsentsResponse(rsp)
+}
+
 // ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
 func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -446,6 +553,39 @@ func ParsePatchRelationshipResponse(rsp *http.Response) (*PatchRelationshipRespo
 	return response, nil
 }
 
+// ParseGetRelationshipConsentsResponse parses an HTTP response from a GetRelationshipConsentsWithResponse call
+func ParseGetRelationshipConsentsResponse(rsp *http.Response) (*GetRelationshipConsentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRelationshipConsentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RelationshipConsent
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// List the relationships.
@@ -454,6 +594,9 @@ type ServerInterface interface {
 	// Accept/Denies relationship requests
 	// (PATCH /relationships/{relationshipID})
 	PatchRelationship(ctx echo.Context, relationshipID externalRef0.UUID) error
+	// Get the signed consents of both trust domains of a relationship, verified with the configured bundle verifiers
+	// (GET /relationships/{relationshipID}/consents)
+	GetRelationshipConsents(ctx echo.Context, relationshipID externalRef0.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -499,6 +642,24 @@ func (w *ServerInterfaceWrapper) PatchRelationship(ctx echo.Context) error {
 	return err
 }
 
+// GetRelationshipConsents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRelationshipConsents(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "relationshipID" -------------
+	var relationshipID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, ctx.Param("relationshipID"), &relationshipID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter relationshipID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRelationshipConsents(ctx, relationshipID)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -529,31 +690,39 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 
 	router.GET(baseURL+"/relationships", wrapper.GetRelationships)
 	router.PATCH(baseURL+"/relationships/:relationshipID", wrapper.PatchRelationship)
+	router.GET(baseURL+"/relationships/:relationshipID/consents", wrapper.GetRelationshipConsents)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xWW2/bOBP9KwK/PupmO3ETvfmr09ZAtxs07csGXoOWRhK7EsWQo7hGoP++GMoXyXaa",
-	"y3aBfRLFy8yZOYeceWBxVapKgkTDogemwahKGrA/U0h5XSAN40oiSDvkShUi5igqGXw3laQ5E+dQchq9",
-	"0ZCyiP0v2NsN2lUTTJS40rrSrGkalyVgYi0U2WERswvO5Hrm7CHQrs1ZMr07TiCSRNBJXlzrSoFGQZBT",
-	"XhhwmepMEfQE6JtWuuTIIiYkjs+Yy0r+Q5R1yaLzy0uXlUK2f4MwdBmuFbRbIQPNGpeVYAzPrCX4wUtV",
-	"0PrEWQKvUaR14YCNYLvN3fszqIXMWoefQGaYs2jYcbJZp2g13NVCQ8Ki2xb33u98t79afocYCdM7ypPE",
-	"G+RY21hBUgS3xJGu7iFhlGYp7ECBTMjP/Mixy645xvkXKCyrJhfqC9zVYPDFqbZ4FmYH6Gdy6KM/Dr9n",
-	"61T4XcAvRaqBIyQLjn1Ch+Fw4IUDbxR+DS+iURiF4R9dLhOO4KEo4YDOwYmkiuSpDHz7NpvSTtS1wUVS",
-	"lVzIBV9sQn9h/o7MvNq/5CU8dfQrHZnaE59p+6GV5a+JYvnaKJavjaJWyb+sjAOhC7qcHT32IJwi9VSK",
-	"HtXQo7SculCH6eglwNrxWzt+XJVPP3FnFyduhWWrZ3iU8ovzdHzmnb8dvPXOzsdDbzlKY28YX45H6XjM",
-	"Uz7uOqtrG3DH1WjsMsURQVMp+fM29C65l84fLhpvNz57xngwbN6wY77oLsu02tZBHltptPpiHwTm9ZJY",
-	"0wWLWI6oTBQEmZ2mPAUfYVUA4jWP/+I6CTJe8EQLKNhREfywXXI+cn0PBkE7v3HJMyhBoq2ORkEs0k39",
-	"9ZnLChGDNNBBNFE8zsEZ+mEPVRQEq9XK53bVr3QWbI6a4NPs3dXnmytv6Id+jqVFhgItO6cwERDP+V2B",
-	"pNHIOroHbdooBn7oDwZko1IguRLEsR/6I2ZZyu0DHOjO421nMrBppVfaLswS8g74pbeRTGheAoI2LLp9",
-	"YIJc3tWg18zdZiDuvSnuM9uTw3o0d/vt0DAMX9QKCYTyySLYq2HNTntca74+1Sfd1HEMxlDDsctUK6Rd",
-	"r3bK3S6QYNvU2eYK4loLXNtE5lt6F7ymS3U7pwyYuiy5XrOIfRIGHczB6TFHEkSeEResz9ScPPRpDh66",
-	"v7NpQ3AV9R7HzB+1JMfU9zMzmzpVegSQua1CSHh7gfRhsO6DjLqG5yqmrTytUGy/9P8qWf+ydvnRpuyE",
-	"LLrbnLZlcrByluBsislRjM0/VPfzRf1fEvEkjkFhMKWm2PSE4mwoND8TtHWn77cC7D/5RRXzIq8M+mbF",
-	"swy0L6qAKxHcjxih2Fg91O3EubmevX9/5djq67Tldy/V3mzjHp/uBSHM5gYoDfSY2RW6F3zr5T0km4w7",
-	"PdEsAVcA0sFV1UNi9lD66Wjmzd8BAAD//0kOZEY+DgAA",
+	"H4sIAAAAAAAC/8xXX3PauBf9Khr9+vYz2EBCE2b2IRvSNJmGZIA0KRmWEfa1rdSWXEkOdTN89x3JxthA",
+	"mqTtdvqEsKT755wj6d5H7PI44QyYkrj3iAXIhDMJ5k8ffJJGSg9dzhQwMyRJElGXKMqZfS8509+kG0JM",
+	"9OiNAB/38P/stV07n5X2UUJPhOACL5dLC3sgXUETbQf3sJlAR1dnaB2CXlXs1abL7ToIz6N6J4muBE9A",
+	"KKpD9kkkwcJJ5ZMO3QP963MRE4V7mDLV3cMWjslXGqcx7u0fHlo4piz/13IcC6ssgXwpBCDw0sIxSEkC",
+	"Ywm+kjiJ9PwRmgNJFfXTCIHJYLXMWvuTSlAW5A4/AAtUiHvtipNiXmcr4EtKBXi4d5fHvfY7Ldfz+T24",
+	"Ssd0rHFiaqSISk2uwHQGd5ojwR/AwxpmRs0gAeZpP9Mtxxa+IsoNhxAZVmVIkyF8SUGqV0Nt4pnJMqDv",
+	"yaEe/Xb6NVu70q8G/NpIBRAF3oyoOqFtp91qOK1Gxxk7B72O03OcSZVLjyhoKBrDBp2tHaBS7zkErq/P",
+	"+nqlEqlUM4/HhLIZmRWpvxK/LTM/7J+RGJ7bOtZb+mbHQC/ftDL/NVnMfzSL+Y9mkSbef6yMDaFTfTgr",
+	"eqyFsIvUXRA9qaEnaXnuQB2v6ftdN4CFJQ0YUal4lrhRubDYRVkwc3Ucvn6bzP76AzMnErp7CJi+Vz3U",
+	"PxmiynrkhoQyxH2kQkCFQfQZMgtFQHzkUyEVLiFbH3KdKMQFUN/1eD66HKACH1RusxCRxh94u8zXyPsl",
+	"it4p2C2/DyByZChnM1i9uvX8hkAkZ2gRZiVohhLk8jTyEOMKzQHllnZnV85t2b4JQYUgNgwviCztoXmG",
+	"CMtWlLmc+TRIhZ5ImReVjoVce55zHgFhW0dwG2VrU8lVpqs63a2+jTNc5rnr0I2qmv+ugtZAFEnnmWKr",
+	"ck2R0+7ktkMm/v+7KrCzYX/iDUcDddE5jL5NbgbZ5HZ4Pum3zj/dtMbl/+PJvXd7nk1u9p2Pp5GafBw4",
+	"n25ai6vxSWvw7SS7GF8vLsfX8eQ2XJDb88isGTtfL/tBezB2Wxf9z61zdh7O4+HDfOxkF/dH7Yv76792",
+	"Ub6pz9oda3ho5jw0XR4/X0XtHezwYR6EmuGOTw72/e5eY/9t621jb7/bbsw7vttou4fdjt/tEp90q87S",
+	"lHp1V52uhROiFAhNzD93TuOQNPzp48GyUY73XjButZdvtnHRgqTM56tSm7jmrOYHHp9SFaZzLSoR4R4O",
+	"lUpkz7YD81njZL+HRQRKXRH3MxGeHZCIeIJChLfq7NPVFHpPxANIBQJdEEYCI21TgMsE3PLwN7GFI+oC",
+	"k1CJ6Cghbgio3XRqUfVse7FYNImZbXIR2MVWaX84Oz4ZjE4a7abTDFVsIlNURfBETDqQBrpMgOlRxzh6",
+	"ACHzLFpNp9lqaRs8AUYSqjluOs0ONiyF5uWxReU5M18CMLDqB8tMnHnaO6hhbaE2IUgMCoTEvbtHTLXL",
+	"LymIDFsrBNza02W9sAPaLHmnVr3jajvOq7otqiB+9pWtZoeXpfaIECTb1YqNUtcFKXVPUyKVC6lsB3e5",
+	"KxOxV32jNi3BTQVVmQEyXNE7I6k+VHdTjYBM45iIDPfwByqVudhqzGkJKhJoLnCdqan2UKfZfqz+Pesv",
+	"dbiJbm+2md/qerapryNz1l/dvKK+yyhEC28tkHoYuPrgKJHCSxWTF7e5UExL9jf3sl/WkT/Z9+2QRXUZ",
+	"yl9FpLh+4ou3bivH5U+q++Wi/pNEfOS6kCi7D4yCrAkFFRTKnxK0XVw9L77Rjlfr/2x1/85rsIDkz74N",
+	"T0GV9S94q9ZBapLmXIXIVEsor5bMV1IjzlqXyguqwpdUyU9p0kQuHlayqZchEXdJFHKpmnJBggBEk3Kb",
+	"JNR+6ODltLS6qbYjNLo6e/fuBJmKEOUl4Vpgta9La3t37WBRWeg2EaBBMjM5JIWXd+AV5KHaRTYHtQBg",
+	"SC14LRK5DqUOx3K6/HcA/wxfKTUVAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -69,7 +69,12 @@ func (s *FederatedBundlesSynchronizer) StartSyncing(ctx context.Context) error {
 	for {
 		select {
 		case <-ticker.C:
-			if err := s.synchronizeFederatedBundles(ctx); err != nil {
+			start := time.Now()
+			syncCtx, span := tracer.Start(ctx, "FederatedBundlesSynchronizer.Sync")
+			err := s.synchronizeFederatedBundles(syncCtx)
+			telemetry.EndSpan(span, err)
+			observeSync(telemetry.FederatedBundlesSynchronizer, start, err)
+			if err != nil {
 				s.logger.Errorf("Failed to sync federated bundles with Galadriel Server: %v", err)
 			}
 		case <-ctx.Done():
@@ -183,6 +188,7 @@ func (s *FederatedBundlesSynchronizer) validateBundleIntegrity(bundle *entity.Bu
 			return nil
 		}
 		s.logger.Warnf("Bundle for trust domain %q failed verification using %T verifier: %v ", bundle.TrustDomainName, verifier, err)
+		incVerificationFailure(verifier)
 	}
 
 	return fmt.Errorf("no verifier could verify the bundle")
@@ -208,6 +214,7 @@ func (s *FederatedBundlesSynchronizer) fetchSPIREFederatedBundles(ctx context.Co
 
 func (s *FederatedBundlesSynchronizer) logFederatedBundleSetStatuses(federatedBundleStatuses []*spireclient.BatchSetFederatedBundleStatus) {
 	for _, status := range federatedBundleStatuses {
+		incSpireBatchStatus(spireOperationSet, status.Status.Code)
 		if status.Status.Code != codes.OK {
 			s.logger.WithFields(logrus.Fields{
 				telemetry.TrustDomain:    status.Bundle.TrustDomain(),
@@ -221,6 +228,7 @@ func (s *FederatedBundlesSynchronizer) logFederatedBundleSetStatuses(federatedBu
 
 func (s *FederatedBundlesSynchronizer) logFederatedBundleDeleteStatuses(deleteStatuses []*spireclient.BatchDeleteFederatedBundleStatus) {
 	for _, status := range deleteStatuses {
+		incSpireBatchStatus(spireOperationDelete, status.Status.Code)
 		if status.Status.Code != codes.OK {
 			s.logger.WithFields(logrus.Fields{
 				telemetry.TrustDomain:    status.TrustDomain,
@@ -18,8 +18,11 @@ const (
 	defaultSpireBundlesPollInterval     = 1 * time.Minute
 	spireCallTimeout                    = 10 * time.Second
 	galadrielCallTimeout                = 2 * time.Minute
+	tracerName                          = "github.com/HewlettPackard/galadriel/pkg/harvester/bundlemanager"
 )
 
+var tracer = telemetry.Tracer(tracerName)
+
 // BundleManager is responsible for managing the synchronization and watching of bundles.
 type BundleManager struct {
 	federatedBundlesSynchronizer *FederatedBundlesSynchronizer
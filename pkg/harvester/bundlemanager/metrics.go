@@ -0,0 +1,69 @@
+package bundlemanager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	metricsSubsystem = "harvester"
+
+	syncOutcomeSuccess = "success"
+	syncOutcomeFailure = "failure"
+
+	spireOperationSet    = "set"
+	spireOperationDelete = "delete"
+)
+
+var (
+	syncDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: telemetry.MetricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sync_duration_seconds",
+		Help:      "Duration of the bundle synchronizations, by synchronizer and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"synchronizer", "outcome"})
+
+	verificationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: telemetry.MetricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "bundle_verification_failures_total",
+		Help:      "Number of federated bundles that failed verification, by verifier type.",
+	}, []string{"verifier"})
+
+	spireBatchStatuses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: telemetry.MetricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "spire_batch_statuses_total",
+		Help:      "Status codes returned by the SPIRE Server for each item of the federated bundles batch operations.",
+	}, []string{"operation", "code"})
+)
+
+// observeSync records the duration and outcome of a synchronization that started at the given time.
+func observeSync(synchronizer string, start time.Time, err error) {
+	outcome := syncOutcomeSuccess
+	if err != nil {
+		outcome = syncOutcomeFailure
+	}
+	syncDuration.WithLabelValues(synchronizer, outcome).Observe(time.Since(start).Seconds())
+}
+
+func incVerificationFailure(verifier integrity.Verifier) {
+	verificationFailures.WithLabelValues(verifierType(verifier)).Inc()
+}
+
+func incSpireBatchStatus(operation string, code codes.Code) {
+	spireBatchStatuses.WithLabelValues(operation, code.String()).Inc()
+}
+
+// verifierType returns the type name of the verifier without the pointer prefix, e.g. "integrity.DiskVerifier".
+func verifierType(verifier integrity.Verifier) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", verifier), "*")
+}
@@ -0,0 +1,33 @@
+package bundlemanager
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestObserveSync(t *testing.T) {
+	observeSync(telemetry.SpireBundleSynchronizer, time.Now(), nil)
+	observeSync(telemetry.SpireBundleSynchronizer, time.Now(), errors.New("sync failed"))
+
+	count := testutil.CollectAndCount(syncDuration, "galadriel_harvester_sync_duration_seconds")
+	assert.GreaterOrEqual(t, count, 2)
+}
+
+func TestIncVerificationFailure(t *testing.T) {
+	before := testutil.ToFloat64(verificationFailures.WithLabelValues("integrity.NoOpVerifier"))
+	incVerificationFailure(integrity.NewNoOpVerifier())
+	assert.Equal(t, before+1, testutil.ToFloat64(verificationFailures.WithLabelValues("integrity.NoOpVerifier")))
+}
+
+func TestIncSpireBatchStatus(t *testing.T) {
+	before := testutil.ToFloat64(spireBatchStatuses.WithLabelValues(spireOperationSet, "NotFound"))
+	incSpireBatchStatus(spireOperationSet, codes.NotFound)
+	assert.Equal(t, before+1, testutil.ToFloat64(spireBatchStatuses.WithLabelValues(spireOperationSet, "NotFound")))
+}
@@ -2,11 +2,13 @@ package bundlemanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
@@ -57,7 +59,11 @@ func (s *SpireBundleSynchronizer) StartSyncing(ctx context.Context) error {
 	for {
 		select {
 		case <-ticker.C:
-			err := s.syncSpireBundleToGaladriel(ctx)
+			start := time.Now()
+			syncCtx, span := tracer.Start(ctx, "SpireBundleSynchronizer.Sync")
+			err := s.syncSpireBundleToGaladriel(syncCtx)
+			telemetry.EndSpan(span, err)
+			observeSync(telemetry.SpireBundleSynchronizer, start, err)
 			if err != nil {
 				s.logger.Errorf("Failed to sync SPIRE bundle: %v", err)
 			}
@@ -106,6 +112,13 @@ func (s *SpireBundleSynchronizer) syncSpireBundleToGaladriel(ctx context.Context
 
 	// Upload the bundle to Galadriel Server
 	err = s.galadrielClient.PostBundle(galadrielCallCtx, bundleToUpload)
+	if errors.Is(err, galadrielclient.StaleBundleErr) {
+		// the SPIRE Server of this instance lags behind the one of another instance of the trust domain,
+		// the bundle is uploaded again once SPIRE Server returns a new one
+		s.logger.Infof("SPIRE bundle not uploaded: %v", err)
+		s.lastSpireBundle = bundle
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to upload bundle to Galadriel Server: %w", err)
 	}
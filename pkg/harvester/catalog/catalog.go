@@ -1,8 +1,10 @@
 package catalog
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
@@ -12,18 +14,21 @@ import (
 type Catalog interface {
 	GetBundleSigner() []integrity.Signer
 	GetBundleVerifiers() []integrity.Verifier
+	GetKeyManager() keymanager.KeyManager
 }
 
 // ProvidersRepository is the implementation of the Catalog interface.
 type ProvidersRepository struct {
 	bundleSigner    integrity.Signer
 	bundleVerifiers []integrity.Verifier
+	keyManager      keymanager.KeyManager
 }
 
 // ProvidersConfig holds the HCL configuration for the providers.
 type ProvidersConfig struct {
 	BundleSigner    *providerConfig   `hcl:"BundleSigner,block"`
 	BundleVerifiers []*providerConfig `hcl:"BundleVerifier,block"`
+	KeyManager      *providerConfig   `hcl:"KeyManager,block"`
 }
 
 // providerConfig holds the HCL configuration options for a single provider.
@@ -41,6 +46,10 @@ type diskBundleVerifierConfig struct {
 	TrustBundlePath string `hcl:"trust_bundle_path"`
 }
 
+type diskKeyManagerConfig struct {
+	KeysFilePath string `hcl:"keys_file_path"`
+}
+
 // New creates a new ProvidersRepository.
 // It is the responsibility of the caller to load the catalog with providers using LoadFromProvidersConfig.
 func New() *ProvidersRepository {
@@ -84,6 +93,14 @@ func (c *ProvidersRepository) LoadFromProvidersConfig(config *ProvidersConfig) e
 		c.bundleVerifiers = append(c.bundleVerifiers, bundleVerifier)
 	}
 
+	// the KeyManager is optional, the Harvester stores its keys in its data dir by default
+	if config.KeyManager != nil {
+		c.keyManager, err = loadKeyManager(config.KeyManager)
+		if err != nil {
+			return fmt.Errorf("error loading KeyManager: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -95,6 +112,11 @@ func (c *ProvidersRepository) GetBundleVerifiers() []integrity.Verifier {
 	return c.bundleVerifiers
 }
 
+// GetKeyManager returns the configured KeyManager, or nil if none is configured.
+func (c *ProvidersRepository) GetKeyManager() keymanager.KeyManager {
+	return c.keyManager
+}
+
 func loadBundleSigner(config *providerConfig) (integrity.Signer, error) {
 	switch config.Name {
 	case "disk":
@@ -153,3 +175,31 @@ func decodeDiskBundleVerifierConfig(config *providerConfig) (*diskBundleVerifier
 	}
 	return &dsConfig, nil
 }
+
+func loadKeyManager(config *providerConfig) (keymanager.KeyManager, error) {
+	switch config.Name {
+	case "memory":
+		// the JWT token persisted in the data dir is bound to the key, which a memory KeyManager loses on restart
+		return nil, errors.New("the memory KeyManager cannot keep the key the JWT token is bound to across restarts, use the disk KeyManager")
+	case "disk":
+		c, err := decodeDiskKeyManagerConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding disk KeyManager config: %w", err)
+		}
+		km, err := keymanager.NewDiskKeyManager(nil, c.KeysFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("error creating disk KeyManager: %w", err)
+		}
+		return km, nil
+	}
+
+	return nil, fmt.Errorf("unknown KeyManager provider: %s", config.Name)
+}
+
+func decodeDiskKeyManagerConfig(config *providerConfig) (*diskKeyManagerConfig, error) {
+	var kmConfig diskKeyManagerConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &kmConfig); err != nil {
+		return nil, err
+	}
+	return &kmConfig, nil
+}
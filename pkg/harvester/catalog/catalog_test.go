@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/test/certtest"
 	"github.com/hashicorp/hcl/v2"
@@ -74,6 +75,7 @@ func TestLoadFromProvidersConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cat.GetBundleSigner())
 	require.NotNil(t, cat.GetBundleVerifiers())
+	require.Nil(t, cat.GetKeyManager())
 
 	_, ok := cat.GetBundleSigner().(*integrity.DiskSigner)
 	require.True(t, ok)
@@ -83,6 +85,30 @@ func TestLoadFromProvidersConfig(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestLoadKeyManager(t *testing.T) {
+	keysFilePath := t.TempDir() + "/keys.json"
+
+	km, err := loadKeyManager(&providerConfig{
+		Name:    "disk",
+		Options: parseOptions(t, fmt.Sprintf(`keys_file_path = "%s"`, keysFilePath)),
+	})
+	require.NoError(t, err)
+	_, ok := km.(*keymanager.Disk)
+	require.True(t, ok)
+
+	_, err = loadKeyManager(&providerConfig{Name: "memory", Options: parseOptions(t, "")})
+	require.EqualError(t, err, "the memory KeyManager cannot keep the key the JWT token is bound to across restarts, use the disk KeyManager")
+
+	_, err = loadKeyManager(&providerConfig{Name: "vault", Options: parseOptions(t, "")})
+	require.EqualError(t, err, "unknown KeyManager provider: vault")
+}
+
+func parseOptions(t *testing.T, options string) hcl.Body {
+	file, diagErr := hclsyntax.ParseConfig([]byte(options), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+	return file.Body
+}
+
 func setupTest(t *testing.T) (string, func()) {
 	tempDir := certtest.CreateTestCACertificates(t, clk)
 	cleanup := func() {
@@ -1,27 +1,34 @@
 package endpoints
 
 import (
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/consent"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/api/admin"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
 
 type AdminAPIHandlers struct {
-	client galadrielclient.Client
-	logger logrus.FieldLogger
+	client           galadrielclient.Client
+	consentVerifiers []integrity.Verifier
+	logger           logrus.FieldLogger
 }
 
-func NewAdminAPIHandlers(logger logrus.FieldLogger, client galadrielclient.Client) *AdminAPIHandlers {
+func NewAdminAPIHandlers(logger logrus.FieldLogger, client galadrielclient.Client, consentVerifiers []integrity.Verifier) *AdminAPIHandlers {
 	return &AdminAPIHandlers{
-		client: client,
-		logger: logger,
+		client:           client,
+		consentVerifiers: consentVerifiers,
+		logger:           logger,
 	}
 }
 
@@ -68,3 +75,74 @@ func (h AdminAPIHandlers) PatchRelationship(echoCtx echo.Context, relationshipID
 
 	return nil
 }
+
+// GetRelationshipConsents gets the signed consents of the relationship from Galadriel Server, and verifies them
+// using the consent verifiers, so that the consent of the other trust domain can be proven independently of the server.
+func (h AdminAPIHandlers) GetRelationshipConsents(echoCtx echo.Context, relationshipID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	consents, err := h.client.GetRelationshipConsents(ctx, relationshipID)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	resp := make([]admin.RelationshipConsent, 0, len(consents))
+	for _, c := range consents {
+		rc := admin.RelationshipConsent{
+			TrustDomainName:    c.TrustDomainName.String(),
+			ConsentStatus:      api.ConsentStatus(c.ConsentStatus),
+			Statement:          encoding.EncodeToBase64(c.Statement),
+			Signature:          encoding.EncodeToBase64(c.Signature),
+			SigningCertificate: encoding.EncodeToBase64(c.SigningCertificate),
+			UpdatedAt:          c.UpdatedAt,
+			Verified:           true,
+		}
+		if err := h.verifyConsent(c); err != nil {
+			msg := err.Error()
+			rc.Verified = false
+			rc.VerificationError = &msg
+		}
+		resp = append(resp, rc)
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// verifyConsent checks that the signed statement matches the consent record, and that its signature
+// is verified by any of the consent verifiers.
+func (h AdminAPIHandlers) verifyConsent(c *entity.RelationshipConsent) error {
+	statement, err := consent.ParseStatement(c.Statement)
+	if err != nil {
+		return err
+	}
+
+	if statement.RelationshipID != c.RelationshipID {
+		return fmt.Errorf("statement relationship ID %q does not match relationship ID %q", statement.RelationshipID, c.RelationshipID)
+	}
+	if statement.TrustDomain != c.TrustDomainName.String() {
+		return fmt.Errorf("statement trust domain %q does not match trust domain %q", statement.TrustDomain, c.TrustDomainName)
+	}
+	if statement.ConsentStatus != c.ConsentStatus {
+		return fmt.Errorf("statement consent status %q does not match consent status %q", statement.ConsentStatus, c.ConsentStatus)
+	}
+
+	chain, err := x509.ParseCertificates(c.SigningCertificate)
+	if err != nil {
+		return fmt.Errorf("failed to parse signing certificate chain: %w", err)
+	}
+
+	for _, verifier := range h.consentVerifiers {
+		err := verifier.Verify(c.Statement, c.Signature, chain)
+		if err == nil {
+			return nil
+		}
+		h.logger.Warnf("Consent of trust domain %q failed verification using %T verifier: %v", c.TrustDomainName, verifier, err)
+	}
+
+	return errors.New("no verifier could verify the consent signature")
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/api/admin"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 type Server interface {
@@ -21,16 +24,18 @@ type Server interface {
 }
 
 type Endpoints struct {
-	localAddress net.Addr
-	client       galadrielclient.Client
-	logger       logrus.FieldLogger
+	localAddress     net.Addr
+	client           galadrielclient.Client
+	consentVerifiers []integrity.Verifier
+	logger           logrus.FieldLogger
 }
 
 // Config represents the configuration of the Harvester Endpoints.
 type Config struct {
-	LocalAddress net.Addr // UDS socket address the Harvester will listen on
-	Client       galadrielclient.Client
-	Logger       logrus.FieldLogger
+	LocalAddress     net.Addr // UDS socket address the Harvester will listen on
+	Client           galadrielclient.Client
+	ConsentVerifiers []integrity.Verifier // Verifiers of the signed consents of the federated trust domains
+	Logger           logrus.FieldLogger
 }
 
 func New(cfg *Config) (*Endpoints, error) {
@@ -39,9 +44,10 @@ func New(cfg *Config) (*Endpoints, error) {
 	}
 
 	return &Endpoints{
-		localAddress: cfg.LocalAddress,
-		client:       cfg.Client,
-		logger:       cfg.Logger,
+		localAddress:     cfg.LocalAddress,
+		client:           cfg.Client,
+		consentVerifiers: cfg.ConsentVerifiers,
+		logger:           cfg.Logger,
 	}, nil
 }
 
@@ -66,6 +72,7 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 	}
 	defer l.Close()
 
+	server.Use(otelecho.Middleware(constants.GaladrielHarvesterName))
 	e.addUDSHandlers(server)
 
 	log := e.logger.WithFields(logrus.Fields{
@@ -95,5 +102,5 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 }
 
 func (e *Endpoints) addUDSHandlers(server *echo.Echo) {
-	admin.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.client))
+	admin.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.client, e.consentVerifiers))
 }
@@ -3,7 +3,6 @@ package galadrielclient
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,17 +16,27 @@ import (
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/consent"
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
+	"github.com/HewlettPackard/galadriel/pkg/common/version"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
+	tracerName          = "github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
 	jwtRotationInterval = 5 * time.Minute
 	onboardPath         = "/trust-domain/onboard"
 	tokenFile           = "jwt-token"
@@ -35,16 +44,43 @@ const (
 
 var (
 	NotOnboardedErr = errors.New("client has not been onboarded to Galadriel Server")
+
+	// StaleBundleErr is returned when Galadriel Server discards an uploaded bundle because the stored bundle of the
+	// trust domain, uploaded by another Harvester instance, has a higher sequence number.
+	StaleBundleErr = errors.New("a bundle with a higher sequence number was uploaded by another harvester instance")
 )
 
+// BundleValidationError is returned when Galadriel Server rejects a bundle that does not comply with its bundle
+// validation policy. The report lists the policy violations.
+type BundleValidationError struct {
+	Report *harvester.BundleValidationReport
+}
+
+func (e *BundleValidationError) Error() string {
+	violations := make([]string, 0, len(e.Report.Violations))
+	for _, v := range e.Report.Violations {
+		if v.Authority != nil {
+			violations = append(violations, fmt.Sprintf("%s: %s: %s", v.Rule, *v.Authority, v.Message))
+			continue
+		}
+		violations = append(violations, fmt.Sprintf("%s: %s", v.Rule, v.Message))
+	}
+
+	return fmt.Sprintf("bundle rejected by the server validation policy: %s", strings.Join(violations, "; "))
+}
+
 // Client represents a client to interact with the Galadriel Server API.
 type Client interface {
 	SyncBundles(context.Context, []*entity.Bundle) ([]*entity.Bundle, map[spiffeid.TrustDomain][]byte, error)
 	PostBundle(context.Context, *entity.Bundle) error
 	GetRelationships(context.Context, entity.ConsentStatus) ([]*entity.Relationship, error)
 	UpdateRelationship(context.Context, uuid.UUID, entity.ConsentStatus) (*entity.Relationship, error)
+	GetRelationshipConsents(context.Context, uuid.UUID) ([]*entity.RelationshipConsent, error)
 }
 
+// SVIDFetcher fetches the X509-SVID that the Harvester presents to Galadriel Server to onboard without a join token.
+type SVIDFetcher func(context.Context) (*x509svid.SVID, error)
+
 // Config is a struct that holds the configuration for the Galadriel Server client.
 type Config struct {
 	TrustDomain            spiffeid.TrustDomain
@@ -53,14 +89,50 @@ type Config struct {
 	DataDir                string
 	JoinToken              string
 	Logger                 logrus.FieldLogger
+
+	// ServerName is the name the Galadriel Server certificate is verified for, one of its DNS or IP SANs.
+	// Defaults to galadriel-server.
+	ServerName string
+
+	// SVIDFetcher is used to onboard the Harvester with an X509-SVID when no join token is given
+	// and the Harvester is not onboarded yet. SVID onboarding is disabled when it is nil.
+	SVIDFetcher SVIDFetcher
+
+	// InstanceID identifies the Harvester among the Harvesters of the trust domain, one per SPIRE Server.
+	// It is sent when onboarding, Galadriel Server uses its default instance ID when it is empty.
+	InstanceID string
+
+	// ProofKeyManager holds the key the JWT tokens issued to the Harvester are bound to. The Harvester sends a
+	// proof of possession of the key with every request, so that stolen tokens cannot be used without the key.
+	// Tokens are not bound to a key when it is nil.
+	ProofKeyManager keymanager.KeyManager
+
+	// ConsentSigner signs the consent statements sent when updating relationships.
+	// Consents are sent unsigned when it is nil or does not produce a signature.
+	ConsentSigner integrity.Signer
+
+	// Poll intervals of the Harvester, reported to Galadriel Server on every call along with the Harvester version.
+	SpireBundlePollInterval      time.Duration
+	FederatedBundlesPollInterval time.Duration
 }
 
 // client is a struct that implements the Client interface
 type client struct {
-	client      harvester.ClientInterface
-	trustDomain spiffeid.TrustDomain
-	jwtStore    *jwtStore
-	logger      logrus.FieldLogger
+	client        harvester.ClientInterface
+	trustDomain   spiffeid.TrustDomain
+	instanceID    string
+	jwtStore      *jwtStore
+	clientCerts   *clientCertStore
+	trustBundle   *serverTrustBundle
+	consentSigner integrity.Signer
+	logger        logrus.FieldLogger
+	tracer        trace.Tracer
+
+	// closeIdleConnections closes the connections to Galadriel Server authenticated with a previous client certificate.
+	closeIdleConnections func()
+
+	// newSVIDOnboardingClient creates the client used to onboard presenting the given X509-SVID as client certificate.
+	newSVIDOnboardingClient func(svid *tls.Certificate) (harvester.ClientInterface, error)
 }
 
 // jwtStore is a struct that holds the JWT access token
@@ -93,26 +165,73 @@ func NewClient(ctx context.Context, cfg *Config) (Client, error) {
 		return nil, fmt.Errorf("failed to create JWT provider: %w", err)
 	}
 
-	c, err := createTLSClient(cfg.TrustBundlePath)
+	clientID, err := harvesterClientID(cfg.TrustDomain, cfg.InstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid harvester instance: %w", err)
+	}
+	clientCerts := newClientCertStore(cfg.DataDir, clientID, cfg.Logger)
+
+	serverName := cfg.ServerName
+	if serverName == "" {
+		serverName = constants.GaladrielServerName
+	}
+
+	trustBundle, err := newServerTrustBundle(cfg.TrustBundlePath, cfg.DataDir, cfg.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create TLS client for server %s: %w", cfg.GaladrielServerAddress, err)
 	}
 
+	// the Harvester presents the client certificate issued by Galadriel Server, once it has one
+	transport := createTLSTransport(trustBundle, serverName, clientCerts.getClientCertificate)
+	c := newHTTPClient(transport)
+
 	serverAddress := fmt.Sprintf("%s://%s", constants.HTTPSScheme, cfg.GaladrielServerAddress.String())
 
-	// Create harvester client
-	harvesterClient, err := harvester.NewClient(serverAddress,
+	// the proof of possession is created last, binding it to the JWT token set by the previous editors
+	reqEditors := []harvester.RequestEditorFn{createMetadataReqEditor(cfg)}
+	if cfg.ProofKeyManager != nil {
+		proofKey, err := loadProofKey(ctx, cfg.ProofKeyManager)
+		if err != nil {
+			return nil, err
+		}
+		reqEditors = append(reqEditors, createProofReqEditor(proofKey))
+	}
+
+	clientOpts := []harvester.ClientOption{
 		harvester.WithHTTPClient(c),
-		harvester.WithRequestEditorFn(createJWTTokenReqEditor(jwtProvider)))
+		harvester.WithRequestEditorFn(createJWTTokenReqEditor(jwtProvider)),
+	}
+	for _, reqEditor := range reqEditors {
+		clientOpts = append(clientOpts, harvester.WithRequestEditorFn(reqEditor))
+	}
+
+	// Create harvester client
+	harvesterClient, err := harvester.NewClient(serverAddress, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create harvester client: %w", err)
 	}
 
 	client := &client{
-		trustDomain: cfg.TrustDomain,
-		client:      harvesterClient,
-		logger:      cfg.Logger,
-		jwtStore:    jwtProvider,
+		trustDomain:          cfg.TrustDomain,
+		instanceID:           cfg.InstanceID,
+		client:               harvesterClient,
+		logger:               cfg.Logger,
+		jwtStore:             jwtProvider,
+		clientCerts:          clientCerts,
+		trustBundle:          trustBundle,
+		consentSigner:        cfg.ConsentSigner,
+		tracer:               telemetry.Tracer(tracerName),
+		closeIdleConnections: transport.CloseIdleConnections,
+		newSVIDOnboardingClient: func(svid *tls.Certificate) (harvester.ClientInterface, error) {
+			transport := createTLSTransport(trustBundle, serverName, func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return svid, nil
+			})
+			opts := []harvester.ClientOption{harvester.WithHTTPClient(newHTTPClient(transport))}
+			for _, reqEditor := range reqEditors {
+				opts = append(opts, harvester.WithRequestEditorFn(reqEditor))
+			}
+			return harvester.NewClient(serverAddress, opts...)
+		},
 	}
 
 	// if the user provided a join token, try to onboard the Harvester to Galadriel Server
@@ -122,9 +241,16 @@ func NewClient(ctx context.Context, cfg *Config) (Client, error) {
 		}
 	}
 
+	// otherwise, onboard it with an X509-SVID if it is not onboarded yet
+	if !client.isClientOnboarded() && cfg.SVIDFetcher != nil {
+		if err := client.onboardWithSVID(ctx, cfg.SVIDFetcher); err != nil {
+			return nil, fmt.Errorf("failed to onboard client with X509-SVID: %w", err)
+		}
+	}
+
 	if !client.isClientOnboarded() {
-		// this happens if the user did not provide a join token and the Harvester cannot find a stored jwt token
-		return nil, errors.New("harvester is not onboarded to Galadriel Server. A join token is required")
+		// this happens if the user did not provide a join token nor SVID onboarding, and the Harvester cannot find a stored jwt token
+		return nil, errors.New("harvester is not onboarded to Galadriel Server. A join token or SVID onboarding is required")
 	}
 
 	client.logger.Debug("Requesting a new JWT token from Galadriel Server")
@@ -133,6 +259,35 @@ func NewClient(ctx context.Context, cfg *Config) (Client, error) {
 	}
 	go client.startJWTTokenRotation(ctx)
 
+	// the Harvester keeps trusting the fetched trust bundle, so it can verify the certificates issued by the next
+	// Galadriel Server CA once it becomes the current one
+	client.logger.Debug("Requesting the server trust bundle from Galadriel Server")
+	err = client.updateServerTrustBundle(ctx)
+	switch {
+	case errors.Is(err, ServerTrustBundleNotSupportedErr):
+		client.logger.Warn("Galadriel Server does not distribute its trust bundle, using the configured trust bundle only")
+	case err != nil:
+		client.logger.Errorf("Error getting server trust bundle: %v", err)
+		go client.startServerTrustBundleRefresh(ctx)
+	default:
+		go client.startServerTrustBundleRefresh(ctx)
+	}
+
+	// the Harvester keeps sending its JWT token along with the client certificate, so it can still authenticate
+	// with Galadriel Servers that do not issue client certificates
+	if client.clientCerts.shouldRenew(time.Now()) {
+		client.logger.Debug("Requesting a client certificate from Galadriel Server")
+		err := client.renewClientCertificate(ctx)
+		switch {
+		case errors.Is(err, ClientCertificateNotSupportedErr):
+			client.logger.Warn("Galadriel Server does not issue client certificates, authenticating with JWT token only")
+			return client, nil
+		case err != nil:
+			client.logger.Errorf("Error getting client certificate: %v", err)
+		}
+	}
+	go client.startClientCertificateRotation(ctx)
+
 	return client, nil
 }
 
@@ -142,7 +297,10 @@ func NewClient(ctx context.Context, cfg *Config) (Client, error) {
 // The method returns a slice of entity.Relationship representing the filtered relationships.
 // If the client is not onboarded, it returns NotOnboardedErr.
 // Any other errors encountered during the operation are returned as well.
-func (c *client) GetRelationships(ctx context.Context, consentStatus entity.ConsentStatus) ([]*entity.Relationship, error) {
+func (c *client) GetRelationships(ctx context.Context, consentStatus entity.ConsentStatus) (_ []*entity.Relationship, err error) {
+	ctx, span := c.startSpan(ctx, "GetRelationships")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	if c.jwtStore == nil {
 		return nil, NotOnboardedErr
 	}
@@ -191,10 +349,14 @@ func (c *client) GetRelationships(ctx context.Context, consentStatus entity.Cons
 // and the consentStatus parameter, which indicates the new consent status for the relationship.
 // The consentStatus must not be empty, and the relationshipID must not be empty.
 // If any of these conditions are not met, the method returns an error.
+// If the client has a consent signer, the consent statement is signed and sent along with the new status.
 // If the operation succeeds, it returns nil.
 // If the client is not onboarded, it returns NotOnboardedErr.
 // Any other errors encountered during the operation are returned as well.
-func (c *client) UpdateRelationship(ctx context.Context, relationshipID uuid.UUID, consentStatus entity.ConsentStatus) (*entity.Relationship, error) {
+func (c *client) UpdateRelationship(ctx context.Context, relationshipID uuid.UUID, consentStatus entity.ConsentStatus) (_ *entity.Relationship, err error) {
+	ctx, span := c.startSpan(ctx, "UpdateRelationship")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	if c.jwtStore == nil {
 		return nil, NotOnboardedErr
 	}
@@ -205,8 +367,14 @@ func (c *client) UpdateRelationship(ctx context.Context, relationshipID uuid.UUI
 		return nil, errors.New("relationship id cannot be empty")
 	}
 
+	consentSignature, err := c.signConsent(ctx, relationshipID, consentStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign consent: %w", err)
+	}
+
 	request := harvester.PatchRelationshipRequest{
-		ConsentStatus: api.ConsentStatus(consentStatus),
+		ConsentStatus:    api.ConsentStatus(consentStatus),
+		ConsentSignature: consentSignature,
 	}
 
 	resp, err := c.client.PatchRelationship(ctx, c.trustDomain.String(), relationshipID, request)
@@ -237,9 +405,56 @@ func (c *client) UpdateRelationship(ctx context.Context, relationshipID uuid.UUI
 	return ent, nil
 }
 
+// GetRelationshipConsents retrieves the signed consents of both trust domains of the relationship identified by the given relationshipID.
+// The consents are returned as stored by the Galadriel Server, it is up to the caller to verify their signatures.
+// If the client is not onboarded, it returns NotOnboardedErr.
+func (c *client) GetRelationshipConsents(ctx context.Context, relationshipID uuid.UUID) (_ []*entity.RelationshipConsent, err error) {
+	ctx, span := c.startSpan(ctx, "GetRelationshipConsents")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if c.jwtStore == nil {
+		return nil, NotOnboardedErr
+	}
+
+	resp, err := c.client.GetRelationshipConsents(ctx, c.trustDomain.String(), relationshipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship consents: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get relationship consents: %s", string(body))
+	}
+
+	var consents harvester.RelationshipConsents
+	if err := json.Unmarshal(body, &consents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %v", err)
+	}
+
+	result := make([]*entity.RelationshipConsent, 0, len(consents))
+	for _, rc := range consents {
+		ent, err := rc.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert relationship consent to entity: %v", err)
+		}
+		ent.RelationshipID = relationshipID
+		result = append(result, ent)
+	}
+
+	return result, nil
+}
+
 // SyncBundles synchronizes the given bundles with the Galadriel Server. It returns the updated bundles and the
 // map of all federated trust domains with active relationships and their bundle digests.
-func (c *client) SyncBundles(ctx context.Context, bundles []*entity.Bundle) ([]*entity.Bundle, map[spiffeid.TrustDomain][]byte, error) {
+func (c *client) SyncBundles(ctx context.Context, bundles []*entity.Bundle) (_ []*entity.Bundle, _ map[spiffeid.TrustDomain][]byte, err error) {
+	ctx, span := c.startSpan(ctx, "SyncBundles")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	if c.jwtStore == nil {
 		return nil, nil, NotOnboardedErr
 	}
@@ -299,7 +514,10 @@ func (c *client) SyncBundles(ctx context.Context, bundles []*entity.Bundle) ([]*
 	return updates, state, nil
 }
 
-func (c *client) PostBundle(ctx context.Context, bundle *entity.Bundle) error {
+func (c *client) PostBundle(ctx context.Context, bundle *entity.Bundle) (err error) {
+	ctx, span := c.startSpan(ctx, "PostBundle")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	if c.jwtStore == nil {
 		return NotOnboardedErr
 	}
@@ -325,6 +543,18 @@ func (c *client) PostBundle(ctx context.Context, bundle *entity.Bundle) error {
 		return fmt.Errorf("failed to read response body: %v", err)
 	}
 
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		report := &harvester.BundleValidationReport{}
+		if err := json.Unmarshal(body, report); err != nil {
+			return fmt.Errorf("failed to unmarshal bundle validation report: %v", err)
+		}
+		return &BundleValidationError{Report: report}
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return StaleBundleErr
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("failed to post bundle: %s", string(body))
 	}
@@ -332,6 +562,63 @@ func (c *client) PostBundle(ctx context.Context, bundle *entity.Bundle) error {
 	return nil
 }
 
+// signConsent signs the statement of the consent of the trust domain to the relationship.
+// It returns nil if there is no consent signer or if it does not produce signatures.
+func (c *client) signConsent(ctx context.Context, relationshipID uuid.UUID, consentStatus entity.ConsentStatus) (*harvester.ConsentSignature, error) {
+	if c.consentSigner == nil {
+		return nil, nil
+	}
+
+	// the statement covers both trust domains of the relationship, so it is looked up first
+	relationship, err := c.getRelationship(ctx, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := consent.NewStatement(relationship, c.trustDomain, consentStatus, time.Now()).Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal consent statement: %w", err)
+	}
+
+	signature, chain, err := c.consentSigner.Sign(statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) == 0 {
+		return nil, nil
+	}
+
+	return &harvester.ConsentSignature{
+		Statement:          util.EncodeToString(statement),
+		Signature:          util.EncodeToString(signature),
+		SigningCertificate: util.EncodeToString(consent.EncodeCertificateChain(chain)),
+	}, nil
+}
+
+func (c *client) getRelationship(ctx context.Context, relationshipID uuid.UUID) (*entity.Relationship, error) {
+	resp, err := c.client.GetRelationshipByID(ctx, c.trustDomain.String(), relationshipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get relationship: %s", string(body))
+	}
+
+	var r api.Relationship
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %v", err)
+	}
+
+	return r.ToEntity()
+}
+
 // isClientOnboarded Check if the client has been onboarded by checking if there is a JWT token
 func (c *client) isClientOnboarded() bool {
 	return c.jwtStore.getToken() != ""
@@ -342,14 +629,70 @@ func (c *client) isClientOnboarded() bool {
 // If the JWT token in the onboard response is empty, an error is returned.
 // If the JWT token is valid, it caches in the client jwtStore.
 // Finally, it starts the JWT token rotator.
-func (c *client) onboard(ctx context.Context, token string) error {
+func (c *client) onboard(ctx context.Context, token string) (err error) {
+	ctx, span := c.startSpan(ctx, "Onboard")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	c.logger.Info("Onboarding Harvester")
 
 	params := harvester.OnboardParams{JoinToken: token}
+	if c.instanceID != "" {
+		params.InstanceId = &c.instanceID
+	}
 	resp, err := c.client.Onboard(ctx, c.trustDomain.String(), &params)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
+
+	return c.handleOnboardResponse(resp)
+}
+
+// onboardWithSVID initiates the onboarding process of the client with the server presenting the X509-SVID
+// got from the given fetcher as TLS client certificate, instead of a join token.
+// If the onboarding succeeds, the JWT token in the response is cached in the client jwtStore.
+func (c *client) onboardWithSVID(ctx context.Context, fetchSVID SVIDFetcher) (err error) {
+	ctx, span := c.startSpan(ctx, "OnboardWithSVID")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	c.logger.Info("Onboarding Harvester with an X509-SVID")
+
+	svid, err := fetchSVID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch X509-SVID: %w", err)
+	}
+	if len(svid.Certificates) == 0 {
+		return errors.New("fetched X509-SVID has no certificates")
+	}
+
+	certificate := &tls.Certificate{
+		PrivateKey: svid.PrivateKey,
+		Leaf:       svid.Certificates[0],
+	}
+	for _, cert := range svid.Certificates {
+		certificate.Certificate = append(certificate.Certificate, cert.Raw)
+	}
+
+	onboardingClient, err := c.newSVIDOnboardingClient(certificate)
+	if err != nil {
+		return fmt.Errorf("failed to create onboarding client: %w", err)
+	}
+
+	params := harvester.OnboardWithSVIDParams{}
+	if c.instanceID != "" {
+		params.InstanceId = &c.instanceID
+	}
+	resp, err := onboardingClient.OnboardWithSVID(ctx, c.trustDomain.String(), &params)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	c.logger.WithField(telemetry.SPIFFEID, svid.ID.String()).Debug("Presented X509-SVID to Galadriel Server")
+
+	return c.handleOnboardResponse(resp)
+}
+
+// handleOnboardResponse caches the JWT token of a successful onboarding response in the client jwtStore.
+func (c *client) handleOnboardResponse(resp *http.Response) error {
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
@@ -377,7 +720,10 @@ func (c *client) onboard(ctx context.Context, token string) error {
 	return nil
 }
 
-func (c *client) getNewJWTToken(ctx context.Context) error {
+func (c *client) getNewJWTToken(ctx context.Context) (err error) {
+	ctx, span := c.startSpan(ctx, "GetNewJWTToken")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	resp, err := c.client.GetNewJWTToken(ctx, c.trustDomain.String())
 	if err != nil {
 		return fmt.Errorf("failed to send request: %v", err)
@@ -405,28 +751,36 @@ func (c *client) getNewJWTToken(ctx context.Context) error {
 	return nil
 }
 
-func createTLSClient(trustBundlePath string) (*http.Client, error) {
-	caCert, err := os.ReadFile(trustBundlePath)
-	if err != nil {
-		return nil, fmt.Errorf("createTLSClient: failed to read trust bundle: %w", err)
+// createTLSTransport creates an HTTP transport that validates the server certificate with the given trust bundle,
+// for the given server name. The transport presents the client certificate returned by getClientCertificate to the server.
+func createTLSTransport(trustBundle *serverTrustBundle, serverName string, getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)) *http.Transport {
+	tlsConfig := &tls.Config{
+		// the default verification is replaced by verifyConnection, which uses the CA certificates of the trust bundle
+		// at the time of the handshake, so the updates of the trust bundle apply without recreating the transport
+		InsecureSkipVerify:   true,
+		VerifyConnection:     trustBundle.verifyConnection(serverName),
+		ServerName:           serverName,
+		GetClientCertificate: getClientCertificate,
 	}
 
-	caCertPool := x509.NewCertPool()
-	ok := caCertPool.AppendCertsFromPEM(caCert)
-	if !ok {
-		return nil, fmt.Errorf("failed to append CA certificates")
+	return &http.Transport{
+		TLSClientConfig: tlsConfig,
 	}
+}
 
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			RootCAs:    caCertPool,
-			ServerName: constants.GaladrielServerName,
-		},
+// newHTTPClient creates an HTTP client that uses the given transport.
+func newHTTPClient(transport *http.Transport) *http.Client {
+	return &http.Client{
+		// propagates the W3C trace context to the Galadriel Server
+		Transport: otelhttp.NewTransport(transport),
 	}
+}
 
-	return &http.Client{
-		Transport: transport,
-	}, nil
+// startSpan starts a client span for the given Galadriel Server operation.
+func (c *client) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return c.tracer.Start(ctx, "GaladrielClient."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String(telemetry.TrustDomain, c.trustDomain.String())))
 }
 
 // createEmptyTokenFile creates an empty token file
@@ -455,6 +809,21 @@ func createJWTTokenReqEditor(jp *jwtStore) harvester.RequestEditorFn {
 	}
 }
 
+// createMetadataReqEditor returns a request editor that reports the Harvester version and poll intervals,
+// which Galadriel Server records to keep track of the Harvesters of each trust domain.
+func createMetadataReqEditor(cfg *Config) harvester.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(constants.HarvesterVersionHeader, version.Version)
+		if cfg.SpireBundlePollInterval > 0 {
+			req.Header.Set(constants.HarvesterSpireBundlePollIntervalHeader, cfg.SpireBundlePollInterval.String())
+		}
+		if cfg.FederatedBundlesPollInterval > 0 {
+			req.Header.Set(constants.HarvesterFederatedBundlesPollIntervalHeader, cfg.FederatedBundlesPollInterval.String())
+		}
+		return nil
+	}
+}
+
 func (c *client) startJWTTokenRotation(ctx context.Context) {
 	c.logger.Info("Started JWT token rotator")
 
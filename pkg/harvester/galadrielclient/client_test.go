@@ -1,2 +1,260 @@
 // TODO: add tests
 package galadrielclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/common/version"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleValidationError(t *testing.T) {
+	authority := "x509_authorities[0]"
+	err := &BundleValidationError{
+		Report: &harvester.BundleValidationReport{
+			TrustDomain: "td1.org",
+			Violations: []harvester.BundlePolicyViolation{
+				{Rule: "sequence_number", Message: "sequence number 1 is lower than the sequence number 2 of the current bundle"},
+				{Rule: "validity", Authority: &authority, Message: "authority expired at 2023-01-01T00:00:00Z"},
+			},
+		},
+	}
+
+	assert.EqualError(t, err, "bundle rejected by the server validation policy: "+
+		"sequence_number: sequence number 1 is lower than the sequence number 2 of the current bundle; "+
+		"validity: x509_authorities[0]: authority expired at 2023-01-01T00:00:00Z")
+}
+
+func TestMetadataReqEditor(t *testing.T) {
+	editor := createMetadataReqEditor(&Config{
+		SpireBundlePollInterval:      10 * time.Second,
+		FederatedBundlesPollInterval: time.Minute,
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "https://localhost/trust-domain/td1.org/bundles/sync", nil)
+	require.NoError(t, err)
+	require.NoError(t, editor(context.Background(), req))
+
+	assert.Equal(t, version.Version, req.Header.Get(constants.HarvesterVersionHeader))
+	assert.Equal(t, "10s", req.Header.Get(constants.HarvesterSpireBundlePollIntervalHeader))
+	assert.Equal(t, "1m0s", req.Header.Get(constants.HarvesterFederatedBundlesPollIntervalHeader))
+
+	// intervals that are not configured are not reported
+	editor = createMetadataReqEditor(&Config{})
+	req, err = http.NewRequest(http.MethodGet, "https://localhost/trust-domain/td1.org/bundles/sync", nil)
+	require.NoError(t, err)
+	require.NoError(t, editor(context.Background(), req))
+
+	assert.Equal(t, version.Version, req.Header.Get(constants.HarvesterVersionHeader))
+	assert.Empty(t, req.Header.Get(constants.HarvesterSpireBundlePollIntervalHeader))
+	assert.Empty(t, req.Header.Get(constants.HarvesterFederatedBundlesPollIntervalHeader))
+}
+
+// fakeHarvesterClient responds to the calls it implements with the configured status code.
+type fakeHarvesterClient struct {
+	harvester.ClientInterface
+
+	statusCode        int
+	body              string
+	onboardParams     *harvester.OnboardParams
+	svidOnboardParams *harvester.OnboardWithSVIDParams
+}
+
+func (f *fakeHarvesterClient) BundlePut(context.Context, string, harvester.BundlePutJSONRequestBody, ...harvester.RequestEditorFn) (*http.Response, error) {
+	return f.response(), nil
+}
+
+func (f *fakeHarvesterClient) Onboard(_ context.Context, _ string, params *harvester.OnboardParams, _ ...harvester.RequestEditorFn) (*http.Response, error) {
+	f.onboardParams = params
+	return f.response(), nil
+}
+
+func (f *fakeHarvesterClient) OnboardWithSVID(_ context.Context, _ string, params *harvester.OnboardWithSVIDParams, _ ...harvester.RequestEditorFn) (*http.Response, error) {
+	f.svidOnboardParams = params
+	return f.response(), nil
+}
+
+func (f *fakeHarvesterClient) response() *http.Response {
+	return &http.Response{
+		StatusCode: f.statusCode,
+		Body:       io.NopCloser(strings.NewReader(f.body)),
+	}
+}
+
+func TestPostBundle(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("td1.org")
+	bundle := &entity.Bundle{TrustDomainName: td, Data: []byte("bundle"), Digest: []byte("digest")}
+
+	t.Run("Bundle accepted", func(t *testing.T) {
+		c := &client{client: &fakeHarvesterClient{statusCode: http.StatusOK}, trustDomain: td, jwtStore: &jwtStore{}, tracer: telemetry.Tracer(tracerName)}
+		require.NoError(t, c.PostBundle(context.Background(), bundle))
+	})
+
+	t.Run("Stale bundle", func(t *testing.T) {
+		c := &client{client: &fakeHarvesterClient{statusCode: http.StatusConflict}, trustDomain: td, jwtStore: &jwtStore{}, tracer: telemetry.Tracer(tracerName)}
+		err := c.PostBundle(context.Background(), bundle)
+		assert.ErrorIs(t, err, StaleBundleErr)
+	})
+}
+
+func TestOnboardInstanceID(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("td1.org")
+
+	for _, instanceID := range []string{"", "spire-server-1"} {
+		fake := &fakeHarvesterClient{statusCode: http.StatusOK, body: `{"token": "jwt", "trustDomainID": "4a2a3a1e-7c4b-4b9e-9d7c-0c4c2e3b5a61", "trustDomainName": "td1.org", "instanceID": "default"}`}
+		c := &client{
+			client:      fake,
+			trustDomain: td,
+			instanceID:  instanceID,
+			jwtStore:    &jwtStore{tokenFilePath: filepath.Join(t.TempDir(), tokenFile)},
+			logger:      logrus.New(),
+			tracer:      telemetry.Tracer(tracerName),
+		}
+
+		require.NoError(t, c.onboard(context.Background(), "join-token"))
+		assert.Equal(t, "join-token", fake.onboardParams.JoinToken)
+		if instanceID == "" {
+			assert.Nil(t, fake.onboardParams.InstanceId)
+			continue
+		}
+		require.NotNil(t, fake.onboardParams.InstanceId)
+		assert.Equal(t, instanceID, *fake.onboardParams.InstanceId)
+	}
+}
+
+func TestOnboardWithSVID(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("td1.org")
+	clk := clock.New()
+	ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	svidCert, svidKey := certtest.CreateTestX509SVID(t, clk, ca, caKey, spiffeid.RequireFromPath(td, "/galadriel-harvester"))
+	svid := &x509svid.SVID{
+		ID:           spiffeid.RequireFromPath(td, "/galadriel-harvester"),
+		Certificates: []*x509.Certificate{svidCert},
+		PrivateKey:   svidKey.(crypto.Signer),
+	}
+
+	newClient := func(t *testing.T, fake *fakeHarvesterClient, presented **tls.Certificate) *client {
+		return &client{
+			trustDomain: td,
+			instanceID:  "spire-server-1",
+			jwtStore:    &jwtStore{tokenFilePath: filepath.Join(t.TempDir(), tokenFile)},
+			logger:      logrus.New(),
+			tracer:      telemetry.Tracer(tracerName),
+			newSVIDOnboardingClient: func(cert *tls.Certificate) (harvester.ClientInterface, error) {
+				*presented = cert
+				return fake, nil
+			},
+		}
+	}
+
+	t.Run("Onboards presenting the X509-SVID", func(t *testing.T) {
+		fake := &fakeHarvesterClient{statusCode: http.StatusOK, body: `{"token": "jwt", "trustDomainID": "4a2a3a1e-7c4b-4b9e-9d7c-0c4c2e3b5a61", "trustDomainName": "td1.org", "instanceID": "spire-server-1"}`}
+		var presented *tls.Certificate
+		c := newClient(t, fake, &presented)
+
+		err := c.onboardWithSVID(context.Background(), func(context.Context) (*x509svid.SVID, error) { return svid, nil })
+		require.NoError(t, err)
+		assert.Equal(t, "jwt", c.jwtStore.getToken())
+
+		require.NotNil(t, presented)
+		assert.Equal(t, [][]byte{svidCert.Raw}, presented.Certificate)
+		assert.Equal(t, svidKey, presented.PrivateKey)
+		require.NotNil(t, fake.svidOnboardParams.InstanceId)
+		assert.Equal(t, "spire-server-1", *fake.svidOnboardParams.InstanceId)
+	})
+
+	t.Run("Fails when the X509-SVID cannot be fetched", func(t *testing.T) {
+		var presented *tls.Certificate
+		c := newClient(t, &fakeHarvesterClient{}, &presented)
+
+		err := c.onboardWithSVID(context.Background(), func(context.Context) (*x509svid.SVID, error) { return nil, errors.New("no identity issued") })
+		assert.EqualError(t, err, "failed to fetch X509-SVID: no identity issued")
+		assert.Nil(t, presented)
+		assert.False(t, c.isClientOnboarded())
+	})
+
+	t.Run("Fails when the server refuses the X509-SVID", func(t *testing.T) {
+		fake := &fakeHarvesterClient{statusCode: http.StatusUnauthorized, body: `invalid X509-SVID`}
+		var presented *tls.Certificate
+		c := newClient(t, fake, &presented)
+
+		err := c.onboardWithSVID(context.Background(), func(context.Context) (*x509svid.SVID, error) { return svid, nil })
+		assert.EqualError(t, err, "failed to onboard: invalid X509-SVID")
+		assert.False(t, c.isClientOnboarded())
+	})
+}
+
+func TestCreateTLSTransport(t *testing.T) {
+	ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	trustBundlePath := filepath.Join(t.TempDir(), "root-ca.crt")
+	require.NoError(t, os.WriteFile(trustBundlePath, cryptoutil.EncodeCertificate(ca), 0600))
+
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	template, err := cryptoutil.CreateX509Template(clock.New(), key.Public(), pkix.Name{CommonName: "galadriel.example.org"}, nil, []string{"galadriel.example.org"}, time.Hour)
+	require.NoError(t, err)
+	cert, err := cryptoutil.SignX509(template, ca, caKey)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}}
+	server.StartTLS()
+	defer server.Close()
+
+	noClientCert := func(*tls.CertificateRequestInfo) (*tls.Certificate, error) { return &tls.Certificate{}, nil }
+	trustBundle, err := newServerTrustBundle(trustBundlePath, t.TempDir(), logrus.New())
+	require.NoError(t, err)
+
+	// the server certificate is verified for the configured server name
+	transport := createTLSTransport(trustBundle, "galadriel.example.org", noClientCert)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	transport = createTLSTransport(trustBundle, constants.GaladrielServerName, noClientCert)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.ErrorContains(t, err, "certificate is valid for galadriel.example.org, not galadriel-server")
+
+	// certificates issued by an unknown CA are refused
+	otherTrustBundlePath := filepath.Join(t.TempDir(), "other-ca.crt")
+	otherCA, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	require.NoError(t, os.WriteFile(otherTrustBundlePath, cryptoutil.EncodeCertificate(otherCA), 0600))
+	otherTrustBundle, err := newServerTrustBundle(otherTrustBundlePath, t.TempDir(), logrus.New())
+	require.NoError(t, err)
+
+	transport = createTLSTransport(otherTrustBundle, "galadriel.example.org", noClientCert)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.ErrorContains(t, err, "certificate signed by unknown authority")
+
+	// until the CA is fetched from the server, without recreating the transport
+	_, err = otherTrustBundle.setFetched([]*x509.Certificate{ca})
+	require.NoError(t, err)
+	transport.CloseIdleConnections()
+	resp, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
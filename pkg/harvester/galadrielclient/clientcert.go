@@ -0,0 +1,264 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+const (
+	clientCertFile = "client-cert.pem"
+	clientKeyFile  = "client-key.pem"
+
+	// clientCertRotationCheckInterval is how often the client certificate is checked for renewal. It is renewed
+	// once half of its lifetime has elapsed, like the Galadriel Server certificate.
+	clientCertRotationCheckInterval = 1 * time.Minute
+)
+
+// ClientCertificateNotSupportedErr is returned when Galadriel Server does not issue client certificates.
+var ClientCertificateNotSupportedErr = errors.New("galadriel server does not issue client certificates")
+
+// clientCertStore holds the TLS client certificate issued by Galadriel Server, which the Harvester presents to
+// authenticate with mutual TLS. The certificate and its private key are persisted in the data dir.
+type clientCertStore struct {
+	mu           sync.RWMutex
+	cert         *tls.Certificate
+	certFilePath string
+	keyFilePath  string
+	logger       logrus.FieldLogger
+}
+
+// newClientCertStore creates a clientCertStore, loading the client certificate persisted in the data dir if it
+// is still valid and was issued to the given SPIFFE ID.
+func newClientCertStore(dataDir string, id spiffeid.ID, logger logrus.FieldLogger) *clientCertStore {
+	s := &clientCertStore{
+		certFilePath: filepath.Join(dataDir, clientCertFile),
+		keyFilePath:  filepath.Join(dataDir, clientKeyFile),
+		logger:       logger,
+	}
+
+	cert, err := s.load(id)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		logger.WithError(err).Warn("Ignoring stored client certificate")
+	default:
+		s.cert = cert
+	}
+
+	return s
+}
+
+// getClientCertificate returns the client certificate to present in the TLS handshake. No certificate is
+// presented when there is none.
+func (s *clientCertStore) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.cert == nil {
+		return &tls.Certificate{}, nil
+	}
+	return s.cert, nil
+}
+
+// shouldRenew tells if there is no client certificate, or half of its lifetime has elapsed.
+func (s *clientCertStore) shouldRenew(now time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.cert == nil {
+		return true
+	}
+	leaf := s.cert.Leaf
+	return now.After(leaf.NotBefore.Add(leaf.NotAfter.Sub(leaf.NotBefore) / 2))
+}
+
+// setCertificate sets the client certificate and persists it in the data dir.
+func (s *clientCertStore) setCertificate(chain []*x509.Certificate, key *ecdsa.PrivateKey) error {
+	var certPEM []byte
+	rawCerts := make([][]byte, 0, len(chain))
+	for _, cert := range chain {
+		certPEM = append(certPEM, cryptoutil.EncodeCertificate(cert)...)
+		rawCerts = append(rawCerts, cert.Raw)
+	}
+
+	keyPEM, err := cryptoutil.EncodeECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cert = &tls.Certificate{
+		Certificate: rawCerts,
+		PrivateKey:  key,
+		Leaf:        chain[0],
+	}
+	s.mu.Unlock()
+
+	if err := diskutil.AtomicWritePrivateFile(s.keyFilePath, keyPEM); err != nil {
+		return fmt.Errorf("failed to save client certificate key: %w", err)
+	}
+	if err := diskutil.AtomicWritePrivateFile(s.certFilePath, certPEM); err != nil {
+		return fmt.Errorf("failed to save client certificate: %w", err)
+	}
+
+	return nil
+}
+
+// load loads the client certificate persisted in the data dir.
+func (s *clientCertStore) load(id spiffeid.ID) (*tls.Certificate, error) {
+	chain, err := cryptoutil.LoadCertificates(s.certFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := cryptoutil.LoadECPrivateKey(s.keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cryptoutil.VerifyCertificatePrivateKey(chain[0], key); err != nil {
+		return nil, err
+	}
+
+	certID, err := x509svid.IDFromCert(chain[0])
+	if err != nil {
+		return nil, err
+	}
+	if certID != id {
+		return nil, fmt.Errorf("certificate issued to %q instead of %q", certID, id)
+	}
+
+	if time.Now().After(chain[0].NotAfter) {
+		return nil, fmt.Errorf("certificate expired at %s", chain[0].NotAfter)
+	}
+
+	rawCerts := make([][]byte, 0, len(chain))
+	for _, cert := range chain {
+		rawCerts = append(rawCerts, cert.Raw)
+	}
+
+	return &tls.Certificate{
+		Certificate: rawCerts,
+		PrivateKey:  key,
+		Leaf:        chain[0],
+	}, nil
+}
+
+// harvesterClientID returns the SPIFFE ID that Galadriel Server sets in the client certificates issued to the
+// harvester instance.
+func harvesterClientID(td spiffeid.TrustDomain, instanceID string) (spiffeid.ID, error) {
+	if instanceID == "" {
+		instanceID = constants.DefaultHarvesterInstanceID
+	}
+	return spiffeid.FromPath(td, constants.HarvesterClientIDPathPrefix+instanceID)
+}
+
+// renewClientCertificate requests a new client certificate to Galadriel Server, for a newly generated key.
+func (c *client) renewClientCertificate(ctx context.Context) (err error) {
+	ctx, span := c.startSpan(ctx, "IssueClientCertificate")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate signing request: %w", err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})
+
+	resp, err := c.client.IssueClientCertificate(ctx, c.trustDomain.String(), harvester.IssueClientCertificateRequest{Csr: string(csrPEM)})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return ClientCertificateNotSupportedErr
+	default:
+		return fmt.Errorf("failed to issue client certificate: %s", string(body))
+	}
+
+	certResponse := &harvester.ClientCertificateResponse{}
+	if err := json.Unmarshal(body, certResponse); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	chain, err := cryptoutil.ParseCertificates([]byte(certResponse.CertificateChain))
+	if err != nil {
+		return fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+	if len(chain) == 0 {
+		return errors.New("empty client certificate chain in response")
+	}
+	if !reflect.DeepEqual(chain[0].PublicKey, key.Public()) {
+		return errors.New("client certificate does not match the requested key")
+	}
+
+	if err := c.clientCerts.setCertificate(chain, key.(*ecdsa.PrivateKey)); err != nil {
+		c.logger.WithError(err).Error("Failed to save client certificate to disk")
+	}
+
+	// connections authenticated with the previous certificate are not reused
+	if c.closeIdleConnections != nil {
+		c.closeIdleConnections()
+	}
+
+	c.logger.WithField(telemetry.ExpiresAt, chain[0].NotAfter).Info("Client certificate updated")
+
+	return nil
+}
+
+func (c *client) startClientCertificateRotation(ctx context.Context) {
+	c.logger.Info("Started client certificate rotator")
+
+	ticker := time.NewTicker(clientCertRotationCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !c.clientCerts.shouldRenew(time.Now()) {
+				continue
+			}
+			c.logger.Debug("Requesting a new client certificate from Galadriel Server")
+			if err := c.renewClientCertificate(ctx); err != nil {
+				c.logger.Errorf("Error getting new client certificate: %v", err)
+			}
+		case <-ctx.Done():
+			c.logger.Info("Client certificate rotator stopped")
+			return
+		}
+	}
+}
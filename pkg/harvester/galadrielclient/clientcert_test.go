@@ -0,0 +1,133 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCertIssuerClient issues client certificates signed by the given CA, like Galadriel Server.
+type fakeCertIssuerClient struct {
+	harvester.ClientInterface
+
+	t          *testing.T
+	ca         *x509.Certificate
+	caKey      crypto.PrivateKey
+	id         spiffeid.ID
+	statusCode int
+}
+
+func (f *fakeCertIssuerClient) IssueClientCertificate(_ context.Context, _ string, body harvester.IssueClientCertificateJSONRequestBody, _ ...harvester.RequestEditorFn) (*http.Response, error) {
+	if f.statusCode != http.StatusOK {
+		return &http.Response{StatusCode: f.statusCode, Body: io.NopCloser(strings.NewReader("not issued"))}, nil
+	}
+
+	block, _ := pem.Decode([]byte(body.Csr))
+	require.NotNil(f.t, block)
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	require.NoError(f.t, err)
+
+	template, err := cryptoutil.CreateX509Template(clock.New(), csr.PublicKey, pkix.Name{CommonName: f.id.TrustDomain().String()}, []*url.URL{f.id.URL()}, nil, time.Hour)
+	require.NoError(f.t, err)
+	cert, err := cryptoutil.SignX509(template, f.ca, f.caKey)
+	require.NoError(f.t, err)
+
+	resp, err := json.Marshal(harvester.ClientCertificateResponse{
+		CertificateChain: string(cryptoutil.EncodeCertificate(cert)),
+		ExpiresAt:        cert.NotAfter,
+	})
+	require.NoError(f.t, err)
+
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(resp)))}, nil
+}
+
+func TestRenewClientCertificate(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("td1.org")
+	id, err := harvesterClientID(td, "spire-server-1")
+	require.NoError(t, err)
+	assert.Equal(t, "spiffe://td1.org/galadriel/harvester/spire-server-1", id.String())
+
+	ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+
+	newClient := func(t *testing.T, dataDir string, statusCode int) *client {
+		return &client{
+			client:      &fakeCertIssuerClient{t: t, ca: ca, caKey: caKey, id: id, statusCode: statusCode},
+			trustDomain: td,
+			instanceID:  "spire-server-1",
+			clientCerts: newClientCertStore(dataDir, id, logrus.New()),
+			logger:      logrus.New(),
+			tracer:      telemetry.Tracer(tracerName),
+		}
+	}
+
+	t.Run("Presents and persists the issued client certificate", func(t *testing.T) {
+		dataDir := t.TempDir()
+		c := newClient(t, dataDir, http.StatusOK)
+
+		presented, err := c.clientCerts.getClientCertificate(nil)
+		require.NoError(t, err)
+		assert.Empty(t, presented.Certificate)
+		assert.True(t, c.clientCerts.shouldRenew(time.Now()))
+
+		closed := false
+		c.closeIdleConnections = func() { closed = true }
+
+		require.NoError(t, c.renewClientCertificate(context.Background()))
+		assert.True(t, closed)
+
+		presented, err = c.clientCerts.getClientCertificate(nil)
+		require.NoError(t, err)
+		require.Len(t, presented.Certificate, 1)
+		assert.Equal(t, []*url.URL{id.URL()}, presented.Leaf.URIs)
+		assert.False(t, c.clientCerts.shouldRenew(time.Now()))
+		assert.True(t, c.clientCerts.shouldRenew(time.Now().Add(31*time.Minute)))
+
+		// the certificate is loaded when the Harvester restarts
+		stored := newClientCertStore(dataDir, id, logrus.New())
+		storedCert, err := stored.getClientCertificate(nil)
+		require.NoError(t, err)
+		assert.Equal(t, presented.Certificate, storedCert.Certificate)
+
+		// but not for another harvester instance
+		otherID, err := harvesterClientID(td, "")
+		require.NoError(t, err)
+		stored = newClientCertStore(dataDir, otherID, logrus.New())
+		storedCert, err = stored.getClientCertificate(nil)
+		require.NoError(t, err)
+		assert.Empty(t, storedCert.Certificate)
+	})
+
+	t.Run("Servers that do not issue client certificates are reported", func(t *testing.T) {
+		c := newClient(t, t.TempDir(), http.StatusNotFound)
+
+		err := c.renewClientCertificate(context.Background())
+		assert.ErrorIs(t, err, ClientCertificateNotSupportedErr)
+	})
+
+	t.Run("Fails when the server refuses to issue the client certificate", func(t *testing.T) {
+		c := newClient(t, t.TempDir(), http.StatusUnauthorized)
+
+		err := c.renewClientCertificate(context.Background())
+		assert.EqualError(t, err, "failed to issue client certificate: not issued")
+		assert.True(t, c.clientCerts.shouldRenew(time.Now()))
+	})
+}
@@ -0,0 +1,50 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/dpop"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+)
+
+// proofKeyID is the ID of the key the JWT tokens issued to the Harvester are bound to.
+const proofKeyID = "harvester-proof-of-possession"
+
+// loadProofKey returns the key the Harvester proves the possession of, generating it the first time.
+func loadProofKey(ctx context.Context, km keymanager.KeyManager) (crypto.Signer, error) {
+	key, err := km.GetKey(ctx, proofKeyID)
+	switch {
+	case errors.Is(err, keymanager.ErrKeyNotFound):
+		key, err = km.GenerateKey(ctx, proofKeyID, cryptoutil.RSA2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate proof of possession key: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get proof of possession key: %w", err)
+	}
+
+	return key.Signer(), nil
+}
+
+// createProofReqEditor returns a request editor that sends a proof of possession of the given key, bound to the
+// method and URL of the request and to the JWT token sent along with it. It must run after the JWT token editor.
+func createProofReqEditor(signer crypto.Signer) harvester.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		accessToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+		proof, err := dpop.NewProof(signer, req.Method, req.URL.String(), accessToken)
+		if err != nil {
+			return fmt.Errorf("failed to create proof of possession: %w", err)
+		}
+		req.Header.Set(dpop.HeaderName, proof)
+
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package galadrielclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/dpop"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofReqEditor(t *testing.T) {
+	keysFilePath := t.TempDir() + "/keys.json"
+	km, err := keymanager.NewDiskKeyManager(nil, keysFilePath)
+	require.NoError(t, err)
+
+	key, err := loadProofKey(context.Background(), km)
+	require.NoError(t, err)
+
+	// the same key is used after the Harvester restarts
+	km, err = keymanager.NewDiskKeyManager(nil, keysFilePath)
+	require.NoError(t, err)
+	storedKey, err := loadProofKey(context.Background(), km)
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), storedKey.Public())
+
+	thumbprint, err := dpop.Thumbprint(key.Public())
+	require.NoError(t, err)
+
+	editor := createProofReqEditor(key)
+	verifier := dpop.NewVerifier(dpop.DefaultMaxAge, &fakeProofStore{proofs: make(map[string]bool)})
+
+	t.Run("Proofs are bound to the request and the JWT token", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPut, "https://localhost:8085/trust-domain/td1.org/bundles?digest=abc", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer access-token")
+		require.NoError(t, editor(context.Background(), req))
+
+		proofThumbprint, err := verifier.Verify(context.Background(), req.Header.Get(dpop.HeaderName), http.MethodPut, "https://localhost:8085/trust-domain/td1.org/bundles", "access-token")
+		require.NoError(t, err)
+		assert.Equal(t, thumbprint, proofThumbprint)
+	})
+
+	t.Run("Onboarding proofs are sent without JWT token", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "https://localhost:8085"+onboardPath, nil)
+		require.NoError(t, err)
+		require.NoError(t, editor(context.Background(), req))
+
+		proofThumbprint, err := verifier.Verify(context.Background(), req.Header.Get(dpop.HeaderName), http.MethodGet, "https://localhost:8085"+onboardPath, "")
+		require.NoError(t, err)
+		assert.Equal(t, thumbprint, proofThumbprint)
+	})
+}
+
+// fakeProofStore is a dpop.ProofStore that records the proofs in memory.
+type fakeProofStore struct {
+	proofs map[string]bool
+}
+
+func (s *fakeProofStore) RecordProof(_ context.Context, id string, _ time.Time) (bool, error) {
+	if s.proofs[id] {
+		return false, nil
+	}
+	s.proofs[id] = true
+	return true, nil
+}
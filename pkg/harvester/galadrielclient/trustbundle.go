@@ -0,0 +1,232 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	serverTrustBundleFile = "server-trust-bundle.pem"
+
+	// serverTrustBundleRefreshInterval is how often the trust bundle is fetched from Galadriel Server.
+	serverTrustBundleRefreshInterval = 5 * time.Minute
+)
+
+// ServerTrustBundleNotSupportedErr is returned when Galadriel Server does not distribute its trust bundle.
+var ServerTrustBundleNotSupportedErr = errors.New("galadriel server does not distribute its trust bundle")
+
+// serverTrustBundle holds the CA certificates the Galadriel Server certificate is verified with: the ones of the
+// configured trust bundle file, and the ones fetched from Galadriel Server, which are persisted in the data dir.
+// Trusting the fetched certificates lets the Harvester follow the rollovers of the Galadriel Server CA.
+type serverTrustBundle struct {
+	mu         sync.RWMutex
+	configured []*x509.Certificate
+	fetched    []*x509.Certificate
+	roots      *x509.CertPool
+	filePath   string
+	logger     logrus.FieldLogger
+}
+
+// newServerTrustBundle creates a serverTrustBundle from the configured trust bundle file and the trust bundle
+// previously fetched from Galadriel Server and persisted in the data dir, if any.
+func newServerTrustBundle(trustBundlePath, dataDir string, logger logrus.FieldLogger) (*serverTrustBundle, error) {
+	configured, err := loadTrustBundle(trustBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trust bundle: %w", err)
+	}
+
+	b := &serverTrustBundle{
+		configured: configured,
+		filePath:   filepath.Join(dataDir, serverTrustBundleFile),
+		logger:     logger,
+	}
+
+	fetched, err := loadTrustBundle(b.filePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		logger.WithError(err).Warn("Ignoring stored server trust bundle")
+	default:
+		b.fetched = fetched
+	}
+	b.roots = newCertPool(b.configured, b.fetched)
+
+	return b, nil
+}
+
+// getRoots returns the CA certificates the Galadriel Server certificate is verified with.
+func (b *serverTrustBundle) getRoots() *x509.CertPool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.roots
+}
+
+// setFetched sets the trust bundle fetched from Galadriel Server and persists it in the data dir.
+// It tells whether the trust bundle changed.
+func (b *serverTrustBundle) setFetched(certs []*x509.Certificate) (bool, error) {
+	b.mu.Lock()
+	changed := !equalCertificates(b.fetched, certs)
+	if changed {
+		b.fetched = certs
+		b.roots = newCertPool(b.configured, b.fetched)
+	}
+	b.mu.Unlock()
+
+	if !changed {
+		return false, nil
+	}
+
+	var bundlePEM []byte
+	for _, cert := range certs {
+		bundlePEM = append(bundlePEM, cryptoutil.EncodeCertificate(cert)...)
+	}
+	if err := diskutil.AtomicWritePrivateFile(b.filePath, bundlePEM); err != nil {
+		return true, fmt.Errorf("failed to save server trust bundle: %w", err)
+	}
+
+	return true, nil
+}
+
+// verifyConnection returns a function that verifies the certificate of the Galadriel Server for the given server name,
+// with the current CA certificates of the trust bundle.
+func (b *serverTrustBundle) verifyConnection(serverName string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("server did not present a certificate")
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range cs.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+			DNSName:       serverName,
+			Roots:         b.getRoots(),
+			Intermediates: intermediates,
+		})
+		return err
+	}
+}
+
+// updateServerTrustBundle fetches the trust bundle of Galadriel Server, which includes the next CA during a rollover.
+func (c *client) updateServerTrustBundle(ctx context.Context) (err error) {
+	ctx, span := c.startSpan(ctx, "GetServerTrustBundle")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	resp, err := c.client.GetServerTrustBundle(ctx, c.trustDomain.String())
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return ServerTrustBundleNotSupportedErr
+	default:
+		return fmt.Errorf("failed to get server trust bundle: %s", string(body))
+	}
+
+	bundleResponse := &harvester.ServerTrustBundle{}
+	if err := json.Unmarshal(body, bundleResponse); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	certs, err := cryptoutil.ParseCertificates([]byte(bundleResponse.TrustBundle))
+	if err != nil {
+		return fmt.Errorf("failed to parse server trust bundle: %w", err)
+	}
+	if len(certs) == 0 {
+		return errors.New("empty server trust bundle in response")
+	}
+
+	changed, err := c.trustBundle.setFetched(certs)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to save server trust bundle to disk")
+	}
+	if changed {
+		c.logger.Infof("Server trust bundle updated with %d CA certificates", len(certs))
+	}
+
+	return nil
+}
+
+func (c *client) startServerTrustBundleRefresh(ctx context.Context) {
+	c.logger.Info("Started server trust bundle refresher")
+
+	ticker := time.NewTicker(serverTrustBundleRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.logger.Debug("Requesting the server trust bundle from Galadriel Server")
+			if err := c.updateServerTrustBundle(ctx); err != nil {
+				c.logger.Errorf("Error getting server trust bundle: %v", err)
+			}
+		case <-ctx.Done():
+			c.logger.Info("Server trust bundle refresher stopped")
+			return
+		}
+	}
+}
+
+func loadTrustBundle(path string) ([]*x509.Certificate, error) {
+	bundlePEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := cryptoutil.ParseCertificates(bundlePEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	return certs, nil
+}
+
+func newCertPool(bundles ...[]*x509.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, bundle := range bundles {
+		for _, cert := range bundle {
+			pool.AddCert(cert)
+		}
+	}
+	return pool
+}
+
+func equalCertificates(a, b []*x509.Certificate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrustBundleClient serves the given trust bundle, like Galadriel Server.
+type fakeTrustBundleClient struct {
+	harvester.ClientInterface
+
+	statusCode  int
+	trustBundle []*x509.Certificate
+}
+
+func (f *fakeTrustBundleClient) GetServerTrustBundle(context.Context, string, ...harvester.RequestEditorFn) (*http.Response, error) {
+	if f.statusCode != http.StatusOK {
+		return &http.Response{StatusCode: f.statusCode, Body: io.NopCloser(strings.NewReader("not found"))}, nil
+	}
+
+	var bundlePEM []byte
+	for _, cert := range f.trustBundle {
+		bundlePEM = append(bundlePEM, cryptoutil.EncodeCertificate(cert)...)
+	}
+	resp, err := json.Marshal(harvester.ServerTrustBundle{TrustBundle: string(bundlePEM)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(resp)))}, nil
+}
+
+func TestUpdateServerTrustBundle(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("td1.org")
+
+	configuredCA, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	currentCA, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	nextCA, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+
+	trustBundlePath := filepath.Join(t.TempDir(), "root-ca.crt")
+	require.NoError(t, os.WriteFile(trustBundlePath, cryptoutil.EncodeCertificate(configuredCA), 0600))
+
+	newClient := func(t *testing.T, dataDir string, fake *fakeTrustBundleClient) *client {
+		trustBundle, err := newServerTrustBundle(trustBundlePath, dataDir, logrus.New())
+		require.NoError(t, err)
+		return &client{
+			client:      fake,
+			trustDomain: td,
+			trustBundle: trustBundle,
+			logger:      logrus.New(),
+			tracer:      telemetry.Tracer(tracerName),
+		}
+	}
+
+	trusts := func(t *testing.T, c *client, cert *x509.Certificate) bool {
+		_, err := cert.Verify(x509.VerifyOptions{Roots: c.trustBundle.getRoots()})
+		return err == nil
+	}
+
+	t.Run("Trusts and persists the fetched trust bundle along with the configured one", func(t *testing.T) {
+		dataDir := t.TempDir()
+		fake := &fakeTrustBundleClient{statusCode: http.StatusOK, trustBundle: []*x509.Certificate{currentCA}}
+		c := newClient(t, dataDir, fake)
+		assert.True(t, trusts(t, c, configuredCA))
+		assert.False(t, trusts(t, c, currentCA))
+
+		require.NoError(t, c.updateServerTrustBundle(context.Background()))
+		assert.True(t, trusts(t, c, configuredCA))
+		assert.True(t, trusts(t, c, currentCA))
+		assert.False(t, trusts(t, c, nextCA))
+
+		// the next CA is trusted once the server distributes it
+		fake.trustBundle = []*x509.Certificate{currentCA, nextCA}
+		require.NoError(t, c.updateServerTrustBundle(context.Background()))
+		assert.True(t, trusts(t, c, nextCA))
+
+		// the fetched trust bundle is loaded when the Harvester restarts
+		restarted := newClient(t, dataDir, fake)
+		assert.True(t, trusts(t, restarted, configuredCA))
+		assert.True(t, trusts(t, restarted, currentCA))
+		assert.True(t, trusts(t, restarted, nextCA))
+
+		// and the CAs removed from it are no longer trusted
+		fake.trustBundle = []*x509.Certificate{nextCA}
+		require.NoError(t, c.updateServerTrustBundle(context.Background()))
+		assert.False(t, trusts(t, c, currentCA))
+		assert.True(t, trusts(t, c, nextCA))
+	})
+
+	t.Run("Servers that do not distribute their trust bundle are reported", func(t *testing.T) {
+		c := newClient(t, t.TempDir(), &fakeTrustBundleClient{statusCode: http.StatusNotFound})
+
+		err := c.updateServerTrustBundle(context.Background())
+		assert.ErrorIs(t, err, ServerTrustBundleNotSupportedErr)
+	})
+
+	t.Run("Empty trust bundles are refused", func(t *testing.T) {
+		c := newClient(t, t.TempDir(), &fakeTrustBundleClient{statusCode: http.StatusOK})
+
+		err := c.updateServerTrustBundle(context.Background())
+		assert.EqualError(t, err, "empty server trust bundle in response")
+		assert.True(t, trusts(t, c, configuredCA))
+	})
+
+	t.Run("Invalid stored trust bundles are ignored", func(t *testing.T) {
+		dataDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dataDir, serverTrustBundleFile), []byte("not a bundle"), 0600))
+
+		c := newClient(t, dataDir, &fakeTrustBundleClient{statusCode: http.StatusOK})
+		assert.True(t, trusts(t, c, configuredCA))
+	})
+}
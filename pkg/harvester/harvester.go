@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/fileutil"
@@ -19,6 +21,13 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
+const (
+	tracingShutdownTimeout = 5 * time.Second
+
+	// keysFile is the file in the data dir storing the keys of the Harvester when no KeyManager is configured.
+	keysFile = "keys.json"
+)
+
 // Harvester represents the Harvester agent.
 // It starts the bundle manager and the endpoints.
 type Harvester struct {
@@ -28,15 +37,20 @@ type Harvester struct {
 // Config conveys the configuration of the Harvester.
 type Config struct {
 	TrustDomain                  spiffeid.TrustDomain
-	HarvesterSocketPath          net.Addr     // UDS socket address the Harvester will listen on
-	SpireSocketPath              net.Addr     // UDS socket address the SPIRE server listens on and Harvester will connect to
-	GaladrielServerAddress       *net.TCPAddr // TCP address the Galadriel Server listens on and Harvester will connect to
+	HarvesterSocketPath          net.Addr                 // UDS socket address the Harvester will listen on
+	SpireSocketPath              net.Addr                 // UDS socket address the SPIRE server listens on and Harvester will connect to
+	GaladrielServerAddress       *net.TCPAddr             // TCP address the Galadriel Server listens on and Harvester will connect to
+	MetricsAddress               *net.TCPAddr             // TCP address the Prometheus metrics endpoint listens on, disabled when nil
+	Tracing                      *telemetry.TracingConfig // OpenTelemetry tracing configuration, disabled when nil
 	JoinToken                    string
+	SVIDOnboarding               *SVIDOnboardingConfig // Onboards with an X509-SVID when there is no join token, disabled when nil
 	BundleUpdatesInterval        time.Duration
 	FederatedBundlesPollInterval time.Duration
 	SpireBundlePollInterval      time.Duration
 	ServerTrustBundlePath        string
+	ServerName                   string // Name the Galadriel Server certificate is verified for, galadriel-server when empty
 	DataDir                      string
+	InstanceID                   string // Identifies this Harvester among the Harvesters of the trust domain
 	Logger                       logrus.FieldLogger
 	ProvidersConfig              *catalog.ProvidersConfig
 }
@@ -49,16 +63,27 @@ func New(cfg *Config) *Harvester {
 
 // Run starts the Harvester and orchestrates the main functionality.
 // It performs the following steps:
+// - Sets up tracing, if configured.
 // - Loads catalogs from the providers configuration.
 // - Creates the data directory if it does not exist.
-// - Creates a client for Galadriel Server.
-// - Onboards the Harvester to Galadriel Server if it is not already onboarded.
+// - Creates the KeyManager holding the key the Harvester tokens are bound to, in the data directory by default.
 // - Creates a SPIRE client using the provided SPIRE address.
+// - Creates a client for Galadriel Server.
+// - Onboards the Harvester to Galadriel Server if it is not already onboarded, with a join token or an X509-SVID.
 // - Creates, configures and run the Harvester endpoints.
 // - Creates and runs the BundleManager responsible for bundles synchronization.
+// - Creates and runs the metrics server, if a metrics address is configured.
 func (h *Harvester) Run(ctx context.Context) error {
 	h.c.Logger.Info("Starting Harvester")
 
+	if h.c.Tracing != nil {
+		shutdownTracing, err := telemetry.SetupTracing(ctx, h.c.Tracing)
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer h.shutdownTracing(shutdownTracing)
+	}
+
 	cat := catalog.New()
 	err := cat.LoadFromProvidersConfig(h.c.ProvidersConfig)
 	if err != nil {
@@ -70,28 +95,53 @@ func (h *Harvester) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
+	keyManager := cat.GetKeyManager()
+	if keyManager == nil {
+		keyManager, err = keymanager.NewDiskKeyManager(nil, filepath.Join(h.c.DataDir, keysFile))
+		if err != nil {
+			return fmt.Errorf("failed to create key manager: %w", err)
+		}
+	}
+
+	spireClient, err := spireclient.NewSpireClient(ctx, h.c.SpireSocketPath)
+	if err != nil {
+		return fmt.Errorf("failed to create SPIRE client: %w", err)
+	}
+
+	var svidFetcher galadrielclient.SVIDFetcher
+	if h.c.SVIDOnboarding != nil {
+		svidFetcher, err = newSVIDFetcher(h.c.SVIDOnboarding, spireClient)
+		if err != nil {
+			return fmt.Errorf("failed to configure SVID onboarding: %w", err)
+		}
+	}
+
 	galadrielClient, err := galadrielclient.NewClient(ctx, &galadrielclient.Config{
 		TrustDomain:            h.c.TrustDomain,
 		GaladrielServerAddress: h.c.GaladrielServerAddress,
 		TrustBundlePath:        h.c.ServerTrustBundlePath,
+		ServerName:             h.c.ServerName,
 		DataDir:                h.c.DataDir,
 		JoinToken:              h.c.JoinToken,
+		SVIDFetcher:            svidFetcher,
+		InstanceID:             h.c.InstanceID,
 		Logger:                 h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Harvester),
+		ProofKeyManager:        keyManager,
+		ConsentSigner:          cat.GetBundleSigner(),
+
+		SpireBundlePollInterval:      h.c.SpireBundlePollInterval,
+		FederatedBundlesPollInterval: h.c.FederatedBundlesPollInterval,
 	})
 	if err != nil {
 		h.c.Logger.Error("Harvester could not connect to Server. Needs to be re-onboarded with new join token")
 		return fmt.Errorf("failed to create Galadriel Server client: %w", err)
 	}
 
-	spireClient, err := spireclient.NewSpireClient(ctx, h.c.SpireSocketPath)
-	if err != nil {
-		return fmt.Errorf("failed to create SPIRE client: %w", err)
-	}
-
 	ep, err := endpoints.New(&endpoints.Config{
-		LocalAddress: h.c.HarvesterSocketPath,
-		Client:       galadrielClient,
-		Logger:       h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Endpoints),
+		LocalAddress:     h.c.HarvesterSocketPath,
+		Client:           galadrielClient,
+		ConsentVerifiers: cat.GetBundleVerifiers(),
+		Logger:           h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create Harvester endpoints: %w", err)
@@ -112,6 +162,17 @@ func (h *Harvester) Run(ctx context.Context) error {
 		bundleManager.Run,
 	}
 
+	if h.c.MetricsAddress != nil {
+		metricsServer, err := telemetry.NewMetricsServer(&telemetry.MetricsServerConfig{
+			Address: h.c.MetricsAddress,
+			Logger:  h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Metrics),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create metrics server: %w", err)
+		}
+		tasks = append(tasks, metricsServer.ListenAndServe)
+	}
+
 	err = util.RunTasks(ctx, tasks...)
 	if errors.Is(err, context.Canceled) {
 		return nil
@@ -119,3 +180,12 @@ func (h *Harvester) Run(ctx context.Context) error {
 
 	return err
 }
+
+func (h *Harvester) shutdownTracing(shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		h.c.Logger.WithError(err).Error("Failed to shut down tracing")
+	}
+}
@@ -5,29 +5,40 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
+	svidv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/svid/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
+	tracerName                   = "github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
 	listFederatedBundlesPageSize = 100
 	defaultSocketPath            = "/tmp/spire-server/private/api.sock"
+	bundleCountKey               = "bundle_count"
 )
 
 // Client is an interface for interacting with a SPIRE Server, providing methods for trust bundle retrieval,
-// setting federation bundles, and deleting federation bundles.
+// setting federation bundles, deleting federation bundles, and minting X509-SVIDs.
 type Client interface {
 	GetBundle(context.Context) (*spiffebundle.Bundle, error)
 	GetFederatedBundles(context.Context) ([]*spiffebundle.Bundle, error)
 	SetFederatedBundles(context.Context, []*spiffebundle.Bundle) ([]*BatchSetFederatedBundleStatus, error)
 	DeleteFederatedBundles(context.Context, []spiffeid.TrustDomain) ([]*BatchDeleteFederatedBundleStatus, error)
+	MintX509SVID(context.Context, spiffeid.ID) (*x509svid.SVID, error)
 }
 
 type spireServerClient struct {
 	bundleClient bundlev1.BundleClient
+	svidClient   svidv1.SVIDClient
+	tracer       trace.Tracer
 }
 
 func NewSpireClient(ctx context.Context, addr net.Addr) (Client, error) {
@@ -44,10 +55,15 @@ func NewSpireClient(ctx context.Context, addr net.Addr) (Client, error) {
 
 	return &spireServerClient{
 		bundleClient: bundlev1.NewBundleClient(clientConn),
+		svidClient:   svidv1.NewSVIDClient(clientConn),
+		tracer:       telemetry.Tracer(tracerName),
 	}, nil
 }
 
-func (c *spireServerClient) GetBundle(ctx context.Context) (*spiffebundle.Bundle, error) {
+func (c *spireServerClient) GetBundle(ctx context.Context) (_ *spiffebundle.Bundle, err error) {
+	ctx, span := c.tracer.Start(ctx, "SpireClient.GetBundle", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	bundle, err := c.bundleClient.GetBundle(ctx, &bundlev1.GetBundleRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bundle: %v", err)
@@ -62,7 +78,10 @@ func (c *spireServerClient) GetBundle(ctx context.Context) (*spiffebundle.Bundle
 }
 
 // GetFederatedBundles lists all the SPIFFE bundles the SPIRE Server set in SPIRE.
-func (c *spireServerClient) GetFederatedBundles(ctx context.Context) ([]*spiffebundle.Bundle, error) {
+func (c *spireServerClient) GetFederatedBundles(ctx context.Context) (_ []*spiffebundle.Bundle, err error) {
+	ctx, span := c.tracer.Start(ctx, "SpireClient.GetFederatedBundles", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	var pageToken string
 	result := make([]*spiffebundle.Bundle, 0)
 
@@ -88,10 +107,17 @@ func (c *spireServerClient) GetFederatedBundles(ctx context.Context) ([]*spiffeb
 		pageToken = res.NextPageToken
 	}
 
+	span.SetAttributes(attribute.Int(bundleCountKey, len(result)))
+
 	return result, nil
 }
 
-func (c *spireServerClient) DeleteFederatedBundles(ctx context.Context, trustDomains []spiffeid.TrustDomain) ([]*BatchDeleteFederatedBundleStatus, error) {
+func (c *spireServerClient) DeleteFederatedBundles(ctx context.Context, trustDomains []spiffeid.TrustDomain) (_ []*BatchDeleteFederatedBundleStatus, err error) {
+	ctx, span := c.tracer.Start(ctx, "SpireClient.DeleteFederatedBundles",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int(bundleCountKey, len(trustDomains))))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	tdsToDel := make([]string, 0, len(trustDomains))
 	for _, td := range trustDomains {
 		tdsToDel = append(tdsToDel, td.String())
@@ -114,7 +140,12 @@ func (c *spireServerClient) DeleteFederatedBundles(ctx context.Context, trustDom
 }
 
 // SetFederatedBundles adds or updates a set of federated SPIFFE bundles on the SPIRE Server
-func (c *spireServerClient) SetFederatedBundles(ctx context.Context, bundles []*spiffebundle.Bundle) ([]*BatchSetFederatedBundleStatus, error) {
+func (c *spireServerClient) SetFederatedBundles(ctx context.Context, bundles []*spiffebundle.Bundle) (_ []*BatchSetFederatedBundleStatus, err error) {
+	ctx, span := c.tracer.Start(ctx, "SpireClient.SetFederatedBundles",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int(bundleCountKey, len(bundles))))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	protoBundles, err := bundlesToProto(bundles)
 	if err != nil {
 		return nil, err
@@ -135,7 +166,9 @@ func (c *spireServerClient) SetFederatedBundles(ctx context.Context, bundles []*
 
 func dialSocket(ctx context.Context, addr net.Addr) (*grpc.ClientConn, error) {
 	target := fmt.Sprintf("%s://%s", addr.Network(), addr.String())
-	clientConn, err := grpc.DialContext(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	clientConn, err := grpc.DialContext(ctx, target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial API socket: %v", err)
 	}
@@ -146,5 +179,6 @@ func dialSocket(ctx context.Context, addr net.Addr) (*grpc.ClientConn, error) {
 func newLocalSpireServerWithClient(bundleClient bundlev1.BundleClient) Client {
 	return &spireServerClient{
 		bundleClient: bundleClient,
+		tracer:       telemetry.Tracer(tracerName),
 	}
 }
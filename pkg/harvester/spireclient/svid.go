@@ -0,0 +1,72 @@
+package spireclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	svidv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/svid/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const spiffeIDKey = "spiffe_id"
+
+// MintX509SVID mints an X509-SVID with the given SPIFFE ID through the SPIRE Server API.
+// The key of the SVID is generated locally, only its CSR is sent to the SPIRE Server.
+func (c *spireServerClient) MintX509SVID(ctx context.Context, id spiffeid.ID) (_ *x509svid.SVID, err error) {
+	ctx, span := c.tracer.Start(ctx, "SpireClient.MintX509SVID",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String(spiffeIDKey, id.String())))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SVID key: %v", err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		URIs: []*url.URL{id.URL()},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SVID CSR: %v", err)
+	}
+
+	resp, err := c.svidClient.MintX509SVID(ctx, &svidv1.MintX509SVIDRequest{Csr: csr})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mint X509-SVID: %v", err)
+	}
+
+	if resp.Svid == nil || len(resp.Svid.CertChain) == 0 {
+		return nil, fmt.Errorf("spire server returned an empty X509-SVID")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(resp.Svid.CertChain))
+	for _, der := range resp.Svid.CertChain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse X509-SVID certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	svidID, err := x509svid.IDFromCert(certs[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get X509-SVID SPIFFE ID: %v", err)
+	}
+	if svidID != id {
+		return nil, fmt.Errorf("spire server minted an X509-SVID for %q instead of %q", svidID, id)
+	}
+
+	return &x509svid.SVID{
+		ID:           id,
+		Certificates: certs,
+		PrivateKey:   key,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package spireclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/jmhodges/clock"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	svidv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/svid/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeSVIDClient signs the CSRs it gets with its CA, using the SPIFFE ID of the CSR unless mintedID is set.
+type fakeSVIDClient struct {
+	svidv1.SVIDClient // Embedded interface, all methods will return not implemented error by default
+	t                 *testing.T
+	ca                *x509.Certificate
+	caKey             crypto.PrivateKey
+	mintedID          spiffeid.ID
+	err               error
+}
+
+func (f *fakeSVIDClient) MintX509SVID(ctx context.Context, in *svidv1.MintX509SVIDRequest, opts ...grpc.CallOption) (*svidv1.MintX509SVIDResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	csr, err := x509.ParseCertificateRequest(in.Csr)
+	require.NoError(f.t, err)
+	require.Len(f.t, csr.URIs, 1)
+
+	id := spiffeid.RequireFromURI(csr.URIs[0])
+	if !f.mintedID.IsZero() {
+		id = f.mintedID
+	}
+
+	template, err := cryptoutil.CreateX509Template(clock.New(), csr.PublicKey, csr.Subject, nil, nil, time.Hour)
+	require.NoError(f.t, err)
+	template.URIs = append(template.URIs, id.URL())
+
+	svid, err := cryptoutil.SignX509(template, f.ca, f.caKey)
+	require.NoError(f.t, err)
+
+	return &svidv1.MintX509SVIDResponse{
+		Svid: &types.X509SVID{
+			Id:        &types.SPIFFEID{TrustDomain: id.TrustDomain().String(), Path: id.Path()},
+			CertChain: [][]byte{svid.Raw},
+		},
+	}, nil
+}
+
+func TestMintX509SVID(t *testing.T) {
+	ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	id := spiffeid.RequireFromString("spiffe://example.org/galadriel-harvester")
+
+	newClient := func(svidClient svidv1.SVIDClient) Client {
+		return &spireServerClient{
+			svidClient: svidClient,
+			tracer:     telemetry.Tracer(tracerName),
+		}
+	}
+
+	t.Run("Successfully mints an X509-SVID", func(t *testing.T) {
+		client := newClient(&fakeSVIDClient{t: t, ca: ca, caKey: caKey})
+
+		svid, err := client.MintX509SVID(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, id, svid.ID)
+		require.Len(t, svid.Certificates, 1)
+		assert.Equal(t, svid.PrivateKey.Public(), svid.Certificates[0].PublicKey)
+	})
+
+	t.Run("Fails when the SPIRE Server API fails", func(t *testing.T) {
+		client := newClient(&fakeSVIDClient{t: t, err: errors.New("permission denied")})
+
+		svid, err := client.MintX509SVID(context.Background(), id)
+		assert.EqualError(t, err, "failed to mint X509-SVID: permission denied")
+		assert.Nil(t, svid)
+	})
+
+	t.Run("Fails when the minted X509-SVID has another SPIFFE ID", func(t *testing.T) {
+		otherID := spiffeid.RequireFromString("spiffe://example.org/other")
+		client := newClient(&fakeSVIDClient{t: t, ca: ca, caKey: caKey, mintedID: otherID})
+
+		svid, err := client.MintX509SVID(context.Background(), id)
+		assert.EqualError(t, err, `spire server minted an X509-SVID for "spiffe://example.org/other" instead of "spiffe://example.org/galadriel-harvester"`)
+		assert.Nil(t, svid)
+	})
+}
@@ -0,0 +1,73 @@
+package harvester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// Sources of the X509-SVID the Harvester presents to Galadriel Server to onboard without a join token.
+const (
+	// SVIDSourceSpireServer mints the X509-SVID through the API of the SPIRE Server the Harvester runs alongside.
+	SVIDSourceSpireServer = "spire_server"
+	// SVIDSourceWorkloadAPI fetches the X509-SVID from the Workload API of a SPIRE Agent, which requires a
+	// registration entry for the Harvester.
+	SVIDSourceWorkloadAPI = "workload_api"
+)
+
+// SVIDOnboardingConfig conveys how the Harvester gets the X509-SVID it onboards with.
+type SVIDOnboardingConfig struct {
+	Source                string      // One of SVIDSourceSpireServer or SVIDSourceWorkloadAPI
+	SPIFFEID              spiffeid.ID // SPIFFE ID of the X509-SVID, required by the spire_server source
+	WorkloadAPISocketPath net.Addr    // UDS socket address of the Workload API, used by the workload_api source
+}
+
+// newSVIDFetcher returns the fetcher of the X509-SVID the Harvester onboards with, according to the given configuration.
+func newSVIDFetcher(cfg *SVIDOnboardingConfig, spireClient spireclient.Client) (galadrielclient.SVIDFetcher, error) {
+	switch cfg.Source {
+	case SVIDSourceSpireServer:
+		if cfg.SPIFFEID.IsZero() {
+			return nil, errors.New("a SPIFFE ID is required to mint the X509-SVID through the SPIRE Server API")
+		}
+		return func(ctx context.Context) (*x509svid.SVID, error) {
+			return spireClient.MintX509SVID(ctx, cfg.SPIFFEID)
+		}, nil
+	case SVIDSourceWorkloadAPI:
+		if cfg.WorkloadAPISocketPath == nil {
+			return nil, errors.New("the Workload API socket path is required to fetch the X509-SVID")
+		}
+		addr := fmt.Sprintf("%s://%s", cfg.WorkloadAPISocketPath.Network(), cfg.WorkloadAPISocketPath.String())
+		return func(ctx context.Context) (*x509svid.SVID, error) {
+			svids, err := workloadapi.FetchX509SVIDs(ctx, workloadapi.WithAddr(addr))
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch X509-SVIDs from the Workload API: %w", err)
+			}
+			return selectSVID(svids, cfg.SPIFFEID)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown X509-SVID source %q", cfg.Source)
+	}
+}
+
+// selectSVID returns the X509-SVID with the given SPIFFE ID, or the default one when the SPIFFE ID is zero.
+func selectSVID(svids []*x509svid.SVID, id spiffeid.ID) (*x509svid.SVID, error) {
+	if len(svids) == 0 {
+		return nil, errors.New("the Workload API returned no X509-SVIDs")
+	}
+	if id.IsZero() {
+		return svids[0], nil
+	}
+	for _, svid := range svids {
+		if svid.ID == id {
+			return svid, nil
+		}
+	}
+	return nil, fmt.Errorf("the Workload API returned no X509-SVID for %q", id)
+}
@@ -0,0 +1,48 @@
+package harvester
+
+import (
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSVIDFetcher(t *testing.T) {
+	_, err := newSVIDFetcher(&SVIDOnboardingConfig{Source: SVIDSourceSpireServer}, nil)
+	assert.EqualError(t, err, "a SPIFFE ID is required to mint the X509-SVID through the SPIRE Server API")
+
+	_, err = newSVIDFetcher(&SVIDOnboardingConfig{Source: SVIDSourceWorkloadAPI}, nil)
+	assert.EqualError(t, err, "the Workload API socket path is required to fetch the X509-SVID")
+
+	_, err = newSVIDFetcher(&SVIDOnboardingConfig{Source: "disk"}, nil)
+	assert.EqualError(t, err, `unknown X509-SVID source "disk"`)
+
+	fetcher, err := newSVIDFetcher(&SVIDOnboardingConfig{
+		Source:   SVIDSourceSpireServer,
+		SPIFFEID: spiffeid.RequireFromString("spiffe://example.org/galadriel-harvester"),
+	}, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, fetcher)
+}
+
+func TestSelectSVID(t *testing.T) {
+	harvesterID := spiffeid.RequireFromString("spiffe://example.org/galadriel-harvester")
+	otherID := spiffeid.RequireFromString("spiffe://example.org/other")
+	svids := []*x509svid.SVID{{ID: otherID}, {ID: harvesterID}}
+
+	svid, err := selectSVID(svids, harvesterID)
+	require.NoError(t, err)
+	assert.Equal(t, harvesterID, svid.ID)
+
+	svid, err = selectSVID(svids, spiffeid.ID{})
+	require.NoError(t, err)
+	assert.Equal(t, otherID, svid.ID)
+
+	_, err = selectSVID(svids, spiffeid.RequireFromString("spiffe://example.org/unknown"))
+	assert.EqualError(t, err, `the Workload API returned no X509-SVID for "spiffe://example.org/unknown"`)
+
+	_, err = selectSVID(nil, harvesterID)
+	assert.EqualError(t, err, "the Workload API returned no X509-SVIDs")
+}
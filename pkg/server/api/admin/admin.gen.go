@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	externalRef0 "github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
@@ -22,15 +23,172 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// BundleDistribution defines model for BundleDistribution.
+type BundleDistribution struct {
+	// ExpectedDigest base64 encoded SHA-256 digest of the bundle
+	ExpectedDigest *externalRef0.BundleDigest `json:"expected_digest,omitempty"`
+
+	// InSync True when the trust domain holds the latest bundle of its peer
+	InSync bool `json:"in_sync"`
+
+	// LagSeconds Time in seconds the latest bundle of the peer has been available without the trust domain holding it
+	LagSeconds          int64                        `json:"lag_seconds"`
+	PeerTrustDomainName externalRef0.TrustDomainName `json:"peer_trust_domain_name"`
+
+	// ReportedAt Time of the last bundle sync that reported the peer bundle
+	ReportedAt *time.Time `json:"reported_at,omitempty"`
+
+	// ReportedDigest base64 encoded SHA-256 digest of the bundle
+	ReportedDigest  *externalRef0.BundleDigest   `json:"reported_digest,omitempty"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+}
+
+// ExternalTrustDomain defines model for ExternalTrustDomain.
+type ExternalTrustDomain struct {
+	BundleEndpointProfile string  `json:"bundle_endpoint_profile"`
+	BundleEndpointUrl     string  `json:"bundle_endpoint_url"`
+	EndpointSpiffeId      *string `json:"endpoint_spiffe_id,omitempty"`
+
+	// LastRefreshAt Time of the last attempt to fetch the bundle, absent if it was never fetched
+	LastRefreshAt *time.Time `json:"last_refresh_at,omitempty"`
+
+	// LastRefreshError Error of the last attempt to fetch the bundle, absent if it succeeded
+	LastRefreshError *string                      `json:"last_refresh_error,omitempty"`
+	TrustDomainName  externalRef0.TrustDomainName `json:"trust_domain_name"`
+}
+
+// FederationGroup defines model for FederationGroup.
+type FederationGroup struct {
+	CreatedAt      time.Time                      `json:"created_at"`
+	DefaultConsent externalRef0.ConsentStatus     `json:"default_consent"`
+	Description    *string                        `json:"description,omitempty"`
+	Id             externalRef0.UUID              `json:"id"`
+	Members        []externalRef0.TrustDomainName `json:"members"`
+	Name           FederationGroupName            `json:"name"`
+	UpdatedAt      time.Time                      `json:"updated_at"`
+}
+
+// FederationGroupName defines model for FederationGroupName.
+type FederationGroupName = string
+
+// FederationStatus defines model for FederationStatus.
+type FederationStatus struct {
+	Relationships     []RelationshipDistribution `json:"relationships"`
+	UnexpectedBundles []UnexpectedBundle         `json:"unexpected_bundles"`
+}
+
+// Harvester defines model for Harvester.
+type Harvester struct {
+	// FederatedBundlesPollInterval Federated bundles poll interval in seconds reported by the Harvester
+	FederatedBundlesPollInterval *int64 `json:"federated_bundles_poll_interval,omitempty"`
+
+	// InstanceId Identifies the Harvester instance among the Harvesters of the trust domain, absent if it was never seen
+	InstanceId *string `json:"instance_id,omitempty"`
+
+	// LastBundleDigest base64 encoded SHA-256 digest of the bundle
+	LastBundleDigest *externalRef0.BundleDigest `json:"last_bundle_digest,omitempty"`
+
+	// LastBundleUploadAt Time of the last bundle upload of the Harvester, absent if it never uploaded a bundle
+	LastBundleUploadAt *time.Time `json:"last_bundle_upload_at,omitempty"`
+
+	// LastBundleUploadStatus Outcome of the last bundle upload, one of 'accepted', 'stale' when a newer bundle was already uploaded by another instance, or 'rejected'
+	LastBundleUploadStatus *string `json:"last_bundle_upload_status,omitempty"`
+
+	// LastSeenAt Time of the last authenticated call of the Harvester, absent if it was never seen
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+
+	// Silent True when the Harvester was never seen, or has been silent longer than the threshold
+	Silent bool `json:"silent"`
+
+	// SourceAddress Remote address of the last call
+	SourceAddress *string `json:"source_address,omitempty"`
+
+	// SpireBundlePollInterval SPIRE bundle poll interval in seconds reported by the Harvester
+	SpireBundlePollInterval *int64 `json:"spire_bundle_poll_interval,omitempty"`
+
+	// TokenExpiresAt Expiry of the JWT used on the last call
+	TokenExpiresAt  *time.Time                   `json:"token_expires_at,omitempty"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+
+	// Version Version reported by the Harvester
+	Version *string `json:"version,omitempty"`
+}
+
+// JoinTokenInfo defines model for JoinTokenInfo.
+type JoinTokenInfo struct {
+	CreatedAt time.Time         `json:"created_at"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Id        externalRef0.UUID `json:"id"`
+	MaxUses   int32             `json:"max_uses"`
+
+	// SourceCidr Network the onboarding requests must come from, absent if any
+	SourceCidr *string `json:"source_cidr,omitempty"`
+
+	// Status One of 'active', 'used' when it allows no more onboardings, or 'expired'
+	Status string `json:"status"`
+
+	// TokenPrefix Non-secret prefix of the token, which identifies it without disclosing its secret
+	TokenPrefix     string                       `json:"token_prefix"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+	UseCount        int32                        `json:"use_count"`
+}
+
 // JoinTokenResponse defines model for JoinTokenResponse.
 type JoinTokenResponse struct {
+	Id *externalRef0.UUID `json:"id,omitempty"`
+
+	// Token Join token of the form <prefix>.<secret>, where only the prefix identifies the token
 	Token externalRef0.JoinToken `json:"token"`
 }
 
+// PutExternalTrustDomainRequest defines model for PutExternalTrustDomainRequest.
+type PutExternalTrustDomainRequest struct {
+	// BootstrapBundle SPIFFE bundle of the trust domain in JSON format, stored as the bundle of the trust domain until one is fetched. Under 'https_spiffe' it authenticates the bundle endpoint, and is required unless the trust domain already has a bundle
+	BootstrapBundle *string `json:"bootstrap_bundle,omitempty"`
+
+	// BundleEndpointProfile SPIFFE bundle endpoint profile, one of 'https_web' or 'https_spiffe'
+	BundleEndpointProfile string `json:"bundle_endpoint_profile"`
+
+	// BundleEndpointUrl HTTPS URL of the SPIFFE bundle endpoint of the trust domain
+	BundleEndpointUrl string `json:"bundle_endpoint_url"`
+
+	// EndpointSpiffeId SPIFFE ID of the bundle endpoint server, required by the 'https_spiffe' profile
+	EndpointSpiffeId *string `json:"endpoint_spiffe_id,omitempty"`
+}
+
+// PutFederationGroupMemberRequest defines model for PutFederationGroupMemberRequest.
+type PutFederationGroupMemberRequest struct {
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+}
+
+// PutFederationGroupRequest defines model for PutFederationGroupRequest.
+type PutFederationGroupRequest struct {
+	DefaultConsent *externalRef0.ConsentStatus `json:"default_consent,omitempty"`
+	Description    *string                     `json:"description,omitempty"`
+	Name           FederationGroupName         `json:"name"`
+}
+
 // PutRelationshipRequest defines model for PutRelationshipRequest.
 type PutRelationshipRequest struct {
-	TrustDomainAName externalRef0.TrustDomainName `json:"trust_domain_a_name"`
-	TrustDomainBName externalRef0.TrustDomainName `json:"trust_domain_b_name"`
+	// Direction Which trust domains of the relationship receive the bundle of their peer. With a_trusts_b, only trust domain A receives the bundle of trust domain B.
+	Direction        *externalRef0.RelationshipDirection `json:"direction,omitempty"`
+	TrustDomainAName externalRef0.TrustDomainName        `json:"trust_domain_a_name"`
+	TrustDomainBName externalRef0.TrustDomainName        `json:"trust_domain_b_name"`
+}
+
+// PutTrustDomainBundleRequest defines model for PutTrustDomainBundleRequest.
+type PutTrustDomainBundleRequest struct {
+	// Digest base64 encoded SHA-256 digest of the bundle
+	Digest externalRef0.BundleDigest `json:"digest"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature *externalRef0.Signature `json:"signature,omitempty"`
+
+	// SigningCertificate X.509 certificate in PEM format
+	SigningCertificate *externalRef0.Certificate `json:"signing_certificate,omitempty"`
+
+	// TrustBundle SPIFFE Trust bundle in JSON format
+	TrustBundle externalRef0.TrustBundle `json:"trust_bundle"`
 }
 
 // PutTrustDomainRequest defines model for PutTrustDomainRequest.
@@ -39,9 +197,54 @@ type PutTrustDomainRequest struct {
 	Name        externalRef0.TrustDomainName `json:"name"`
 }
 
+// RelationshipDistribution defines model for RelationshipDistribution.
+type RelationshipDistribution struct {
+	RelationshipId externalRef0.UUID   `json:"relationship_id"`
+	TrustDomainA   *BundleDistribution `json:"trust_domain_a,omitempty"`
+	TrustDomainB   *BundleDistribution `json:"trust_domain_b,omitempty"`
+}
+
+// TrustDomainBundle defines model for TrustDomainBundle.
+type TrustDomainBundle struct {
+	// AdminManaged Whether the bundle was set by an admin, in which case Harvester uploads are refused
+	AdminManaged bool `json:"admin_managed"`
+
+	// Digest base64 encoded SHA-256 digest of the bundle
+	Digest externalRef0.BundleDigest `json:"digest"`
+
+	// TrustBundle SPIFFE Trust bundle in JSON format
+	TrustBundle     externalRef0.TrustBundle     `json:"trust_bundle"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+	UpdatedAt       time.Time                    `json:"updated_at"`
+
+	// VerificationStatus Result of the verification of the bundle signature, 'verified' or 'skipped'
+	VerificationStatus string `json:"verification_status"`
+}
+
+// UnexpectedBundle defines model for UnexpectedBundle.
+type UnexpectedBundle struct {
+	// Digest base64 encoded SHA-256 digest of the bundle
+	Digest                   externalRef0.BundleDigest    `json:"digest"`
+	FederatedTrustDomainName externalRef0.TrustDomainName `json:"federated_trust_domain_name"`
+	ReportedAt               time.Time                    `json:"reported_at"`
+	TrustDomainName          externalRef0.TrustDomainName `json:"trust_domain_name"`
+}
+
 // Default defines model for Default.
 type Default = externalRef0.ApiError
 
+// ListHarvestersParams defines parameters for ListHarvesters.
+type ListHarvestersParams struct {
+	// SilentThreshold Time in seconds without calls from a Harvester after which it is flagged as silent
+	SilentThreshold *int32 `form:"silentThreshold,omitempty" json:"silentThreshold,omitempty"`
+}
+
+// ListJoinTokensParams defines parameters for ListJoinTokens.
+type ListJoinTokensParams struct {
+	// TrustDomainName Only list the join tokens of this Trust Domain
+	TrustDomainName *externalRef0.TrustDomainName `form:"trustDomainName,omitempty" json:"trustDomainName,omitempty"`
+}
+
 // GetRelationshipsParams defines parameters for GetRelationships.
 type GetRelationshipsParams struct {
 	// ConsentStatus relationship status from a Trust Domain perspective,
@@ -59,8 +262,20 @@ type GetRelationshipsParams struct {
 type GetJoinTokenParams struct {
 	// Ttl Time-to-Live (TTL) in seconds for the join token
 	Ttl int32 `form:"ttl" json:"ttl"`
+
+	// MaxUses Number of Harvester onboardings the join token allows. Single-use when absent
+	MaxUses *int32 `form:"maxUses,omitempty" json:"maxUses,omitempty"`
+
+	// SourceCidr Network in CIDR notation the onboarding requests must come from. Any network when absent
+	SourceCidr *string `form:"sourceCidr,omitempty" json:"sourceCidr,omitempty"`
 }
 
+// PutFederationGroupJSONRequestBody defines body for PutFederationGroup for application/json ContentType.
+type PutFederationGroupJSONRequestBody = PutFederationGroupRequest
+
+// PutFederationGroupMemberJSONRequestBody defines body for PutFederationGroupMember for application/json ContentType.
+type PutFederationGroupMemberJSONRequestBody = PutFederationGroupMemberRequest
+
 // PutRelationshipJSONRequestBody defines body for PutRelationship for application/json ContentType.
 type PutRelationshipJSONRequestBody = PutRelationshipRequest
 
@@ -70,6 +285,12 @@ type PutTrustDomainJSONRequestBody = PutTrustDomainRequest
 // PutTrustDomainByNameJSONRequestBody defines body for PutTrustDomainByName for application/json ContentType.
 type PutTrustDomainByNameJSONRequestBody = externalRef0.TrustDomain
 
+// PutTrustDomainBundleJSONRequestBody defines body for PutTrustDomainBundle for application/json ContentType.
+type PutTrustDomainBundleJSONRequestBody = PutTrustDomainBundleRequest
+
+// PutExternalTrustDomainJSONRequestBody defines body for PutExternalTrustDomain for application/json ContentType.
+type PutExternalTrustDomainJSONRequestBody = PutExternalTrustDomainRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -143,6 +364,43 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListFederationGroups request
+	ListFederationGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutFederationGroup request with any body
+	PutFederationGroupWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutFederationGroup(ctx context.Context, body PutFederationGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFederationGroup request
+	DeleteFederationGroup(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFederationGroup request
+	GetFederationGroup(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutFederationGroupMember request with any body
+	PutFederationGroupMemberWithBody(ctx context.Context, groupName FederationGroupName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutFederationGroupMember(ctx context.Context, groupName FederationGroupName, body PutFederationGroupMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFederationGroupMember request
+	DeleteFederationGroupMember(ctx context.Context, groupName FederationGroupName, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFederationStatus request
+	GetFederationStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListHarvesters request
+	ListHarvesters(ctx context.Context, params *ListHarvestersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListJoinTokens request
+	ListJoinTokens(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeJoinToken request
+	RevokeJoinToken(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetJoinTokenByID request
+	GetJoinTokenByID(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRelationships request
 	GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -173,12 +431,34 @@ type ClientInterface interface {
 
 	PutTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteTrustDomainBundle request
+	DeleteTrustDomainBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTrustDomainBundle request
+	GetTrustDomainBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutTrustDomainBundle request with any body
+	PutTrustDomainBundleWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutTrustDomainBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainBundleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteExternalTrustDomain request
+	DeleteExternalTrustDomain(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetExternalTrustDomain request
+	GetExternalTrustDomain(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutExternalTrustDomain request with any body
+	PutExternalTrustDomainWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutExternalTrustDomain(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutExternalTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetJoinToken request
 	GetJoinToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetRelationshipsRequest(c.Server, params)
+func (c *Client) ListFederationGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListFederationGroupsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -189,8 +469,8 @@ func (c *Client) GetRelationships(ctx context.Context, params *GetRelationshipsP
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutRelationshipWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutRelationshipRequestWithBody(c.Server, contentType, body)
+func (c *Client) PutFederationGroupWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFederationGroupRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -201,8 +481,8 @@ func (c *Client) PutRelationshipWithBody(ctx context.Context, contentType string
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutRelationship(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutRelationshipRequest(c.Server, body)
+func (c *Client) PutFederationGroup(ctx context.Context, body PutFederationGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFederationGroupRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -213,8 +493,8 @@ func (c *Client) PutRelationship(ctx context.Context, body PutRelationshipJSONRe
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetRelationshipByID(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetRelationshipByIDRequest(c.Server, relationshipID)
+func (c *Client) DeleteFederationGroup(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFederationGroupRequest(c.Server, groupName)
 	if err != nil {
 		return nil, err
 	}
@@ -225,8 +505,8 @@ func (c *Client) GetRelationshipByID(ctx context.Context, relationshipID externa
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListTrustDomains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListTrustDomainsRequest(c.Server)
+func (c *Client) GetFederationGroup(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFederationGroupRequest(c.Server, groupName)
 	if err != nil {
 		return nil, err
 	}
@@ -237,8 +517,8 @@ func (c *Client) ListTrustDomains(ctx context.Context, reqEditors ...RequestEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutTrustDomainWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutTrustDomainRequestWithBody(c.Server, contentType, body)
+func (c *Client) PutFederationGroupMemberWithBody(ctx context.Context, groupName FederationGroupName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFederationGroupMemberRequestWithBody(c.Server, groupName, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -249,8 +529,8 @@ func (c *Client) PutTrustDomainWithBody(ctx context.Context, contentType string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutTrustDomain(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutTrustDomainRequest(c.Server, body)
+func (c *Client) PutFederationGroupMember(ctx context.Context, groupName FederationGroupName, body PutFederationGroupMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFederationGroupMemberRequest(c.Server, groupName, body)
 	if err != nil {
 		return nil, err
 	}
@@ -261,8 +541,8 @@ func (c *Client) PutTrustDomain(ctx context.Context, body PutTrustDomainJSONRequ
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteTrustDomainByNameRequest(c.Server, trustDomainName)
+func (c *Client) DeleteFederationGroupMember(ctx context.Context, groupName FederationGroupName, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFederationGroupMemberRequest(c.Server, groupName, trustDomainName)
 	if err != nil {
 		return nil, err
 	}
@@ -273,8 +553,8 @@ func (c *Client) DeleteTrustDomainByName(ctx context.Context, trustDomainName ex
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetTrustDomainByNameRequest(c.Server, trustDomainName)
+func (c *Client) GetFederationStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFederationStatusRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -285,8 +565,8 @@ func (c *Client) GetTrustDomainByName(ctx context.Context, trustDomainName exter
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutTrustDomainByNameWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutTrustDomainByNameRequestWithBody(c.Server, trustDomainName, contentType, body)
+func (c *Client) ListHarvesters(ctx context.Context, params *ListHarvestersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListHarvestersRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -297,8 +577,8 @@ func (c *Client) PutTrustDomainByNameWithBody(ctx context.Context, trustDomainNa
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutTrustDomainByNameRequest(c.Server, trustDomainName, body)
+func (c *Client) ListJoinTokens(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListJoinTokensRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -309,8 +589,8 @@ func (c *Client) PutTrustDomainByName(ctx context.Context, trustDomainName exter
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetJoinToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetJoinTokenRequest(c.Server, trustDomainName, params)
+func (c *Client) RevokeJoinToken(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeJoinTokenRequest(c.Server, joinTokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -321,179 +601,260 @@ func (c *Client) GetJoinToken(ctx context.Context, trustDomainName externalRef0.
 	return c.Client.Do(req)
 }
 
-// NewGetRelationshipsRequest generates requests for GetRelationships
-func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetJoinTokenByID(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJoinTokenByIDRequest(c.Server, joinTokenID)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/relationships")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.ConsentStatus != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.TrustDomainName != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trustDomainName", runtime.ParamLocationQuery, *params.TrustDomainName); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.PageSize != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, *params.PageSize); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.PageNumber != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNumber", runtime.ParamLocationQuery, *params.PageNumber); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PutRelationshipWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutRelationshipRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPutRelationshipRequest calls the generic PutRelationship builder with application/json body
-func NewPutRelationshipRequest(server string, body PutRelationshipJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) PutRelationship(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutRelationshipRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPutRelationshipRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPutRelationshipRequestWithBody generates requests for PutRelationship with any type of body
-func NewPutRelationshipRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetRelationshipByID(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipByIDRequest(c.Server, relationshipID)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/relationships")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ListTrustDomains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTrustDomainsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetRelationshipByIDRequest generates requests for GetRelationshipByID
-func NewGetRelationshipByIDRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
-	var err error
+func (c *Client) PutTrustDomainWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTrustDomainRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam0 string
+func (c *Client) PutTrustDomain(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTrustDomainRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+func (c *Client) DeleteTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTrustDomainByNameRequest(c.Server, trustDomainName)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTrustDomainByNameRequest(c.Server, trustDomainName)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) PutTrustDomainByNameWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTrustDomainByNameRequestWithBody(c.Server, trustDomainName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PutTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTrustDomainByNameRequest(c.Server, trustDomainName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTrustDomainBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTrustDomainBundleRequest(c.Server, trustDomainName)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetTrustDomainBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTrustDomainBundleRequest(c.Server, trustDomainName)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+func (c *Client) PutTrustDomainBundleWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTrustDomainBundleRequestWithBody(c.Server, trustDomainName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewListTrustDomainsRequest generates requests for ListTrustDomains
-func NewListTrustDomainsRequest(server string) (*http.Request, error) {
+func (c *Client) PutTrustDomainBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainBundleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTrustDomainBundleRequest(c.Server, trustDomainName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteExternalTrustDomain(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteExternalTrustDomainRequest(c.Server, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetExternalTrustDomain(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetExternalTrustDomainRequest(c.Server, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutExternalTrustDomainWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutExternalTrustDomainRequestWithBody(c.Server, trustDomainName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutExternalTrustDomain(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutExternalTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutExternalTrustDomainRequest(c.Server, trustDomainName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetJoinToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJoinTokenRequest(c.Server, trustDomainName, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListFederationGroupsRequest generates requests for ListFederationGroups
+func NewListFederationGroupsRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -501,7 +862,7 @@ func NewListTrustDomainsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain")
+	operationPath := fmt.Sprintf("/federation-groups")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -519,19 +880,19 @@ func NewListTrustDomainsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPutTrustDomainRequest calls the generic PutTrustDomain builder with application/json body
-func NewPutTrustDomainRequest(server string, body PutTrustDomainJSONRequestBody) (*http.Request, error) {
+// NewPutFederationGroupRequest calls the generic PutFederationGroup builder with application/json body
+func NewPutFederationGroupRequest(server string, body PutFederationGroupJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPutTrustDomainRequestWithBody(server, "application/json", bodyReader)
+	return NewPutFederationGroupRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPutTrustDomainRequestWithBody generates requests for PutTrustDomain with any type of body
-func NewPutTrustDomainRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPutFederationGroupRequestWithBody generates requests for PutFederationGroup with any type of body
+func NewPutFederationGroupRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -539,7 +900,7 @@ func NewPutTrustDomainRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain")
+	operationPath := fmt.Sprintf("/federation-groups")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -559,13 +920,13 @@ func NewPutTrustDomainRequestWithBody(server string, contentType string, body io
 	return req, nil
 }
 
-// NewDeleteTrustDomainByNameRequest generates requests for DeleteTrustDomainByName
-func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+// NewDeleteFederationGroupRequest generates requests for DeleteFederationGroup
+func NewDeleteFederationGroupRequest(server string, groupName FederationGroupName) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupName", runtime.ParamLocationPath, groupName)
 	if err != nil {
 		return nil, err
 	}
@@ -575,7 +936,7 @@ func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRe
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain/%s", pathParam0)
+	operationPath := fmt.Sprintf("/federation-groups/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -593,13 +954,13 @@ func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRe
 	return req, nil
 }
 
-// NewGetTrustDomainByNameRequest generates requests for GetTrustDomainByName
-func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+// NewGetFederationGroupRequest generates requests for GetFederationGroup
+func NewGetFederationGroupRequest(server string, groupName FederationGroupName) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupName", runtime.ParamLocationPath, groupName)
 	if err != nil {
 		return nil, err
 	}
@@ -609,7 +970,7 @@ func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain/%s", pathParam0)
+	operationPath := fmt.Sprintf("/federation-groups/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -627,24 +988,24 @@ func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.
 	return req, nil
 }
 
-// NewPutTrustDomainByNameRequest calls the generic PutTrustDomainByName builder with application/json body
-func NewPutTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody) (*http.Request, error) {
+// NewPutFederationGroupMemberRequest calls the generic PutFederationGroupMember builder with application/json body
+func NewPutFederationGroupMemberRequest(server string, groupName FederationGroupName, body PutFederationGroupMemberJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPutTrustDomainByNameRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+	return NewPutFederationGroupMemberRequestWithBody(server, groupName, "application/json", bodyReader)
 }
 
-// NewPutTrustDomainByNameRequestWithBody generates requests for PutTrustDomainByName with any type of body
-func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+// NewPutFederationGroupMemberRequestWithBody generates requests for PutFederationGroupMember with any type of body
+func NewPutFederationGroupMemberRequestWithBody(server string, groupName FederationGroupName, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupName", runtime.ParamLocationPath, groupName)
 	if err != nil {
 		return nil, err
 	}
@@ -654,7 +1015,7 @@ func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName exter
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain/%s", pathParam0)
+	operationPath := fmt.Sprintf("/federation-groups/%s/members", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -674,13 +1035,20 @@ func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName exter
 	return req, nil
 }
 
-// NewGetJoinTokenRequest generates requests for GetJoinToken
-func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams) (*http.Request, error) {
+// NewDeleteFederationGroupMemberRequest generates requests for DeleteFederationGroupMember
+func NewDeleteFederationGroupMemberRequest(server string, groupName FederationGroupName, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupName", runtime.ParamLocationPath, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
 	if err != nil {
 		return nil, err
 	}
@@ -690,7 +1058,7 @@ func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDom
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain/%s/join-token", pathParam0)
+	operationPath := fmt.Sprintf("/federation-groups/%s/members/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -700,22 +1068,31 @@ func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDom
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ttl", runtime.ParamLocationQuery, params.Ttl); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+	return req, nil
+}
 
-		queryURL.RawQuery = queryValues.Encode()
+// NewGetFederationStatusRequest generates requests for GetFederationStatus
+func NewGetFederationStatusRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/federation/status")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
 
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
@@ -726,410 +1103,2414 @@ func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDom
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewListHarvestersRequest generates requests for ListHarvesters
+func NewListHarvestersRequest(server string, params *ListHarvestersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	operationPath := fmt.Sprintf("/harvesters")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SilentThreshold != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "silentThreshold", runtime.ParamLocationQuery, *params.SilentThreshold); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListJoinTokensRequest generates requests for ListJoinTokens
+func NewListJoinTokensRequest(server string, params *ListJoinTokensParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/join-tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.TrustDomainName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trustDomainName", runtime.ParamLocationQuery, *params.TrustDomainName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRevokeJoinTokenRequest generates requests for RevokeJoinToken
+func NewRevokeJoinTokenRequest(server string, joinTokenID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, joinTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/join-tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetJoinTokenByIDRequest generates requests for GetJoinTokenByID
+func NewGetJoinTokenByIDRequest(server string, joinTokenID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, joinTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/join-tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRelationshipsRequest generates requests for GetRelationships
+func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/relationships")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ConsentStatus != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TrustDomainName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trustDomainName", runtime.ParamLocationQuery, *params.TrustDomainName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageNumber != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNumber", runtime.ParamLocationQuery, *params.PageNumber); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutRelationshipRequest calls the generic PutRelationship builder with application/json body
+func NewPutRelationshipRequest(server string, body PutRelationshipJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutRelationshipRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutRelationshipRequestWithBody generates requests for PutRelationship with any type of body
+func NewPutRelationshipRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/relationships")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetRelationshipByIDRequest generates requests for GetRelationshipByID
+func NewGetRelationshipByIDRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTrustDomainsRequest generates requests for ListTrustDomains
+func NewListTrustDomainsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTrustDomainRequest calls the generic PutTrustDomain builder with application/json body
+func NewPutTrustDomainRequest(server string, body PutTrustDomainJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTrustDomainRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutTrustDomainRequestWithBody generates requests for PutTrustDomain with any type of body
+func NewPutTrustDomainRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTrustDomainByNameRequest generates requests for DeleteTrustDomainByName
+func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTrustDomainByNameRequest generates requests for GetTrustDomainByName
+func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTrustDomainByNameRequest calls the generic PutTrustDomainByName builder with application/json body
+func NewPutTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTrustDomainByNameRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewPutTrustDomainByNameRequestWithBody generates requests for PutTrustDomainByName with any type of body
+func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTrustDomainBundleRequest generates requests for DeleteTrustDomainBundle
+func NewDeleteTrustDomainBundleRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundle", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTrustDomainBundleRequest generates requests for GetTrustDomainBundle
+func NewGetTrustDomainBundleRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundle", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTrustDomainBundleRequest calls the generic PutTrustDomainBundle builder with application/json body
+func NewPutTrustDomainBundleRequest(server string, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainBundleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTrustDomainBundleRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewPutTrustDomainBundleRequestWithBody generates requests for PutTrustDomainBundle with any type of body
+func NewPutTrustDomainBundleRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundle", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteExternalTrustDomainRequest generates requests for DeleteExternalTrustDomain
+func NewDeleteExternalTrustDomainRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/external", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetExternalTrustDomainRequest generates requests for GetExternalTrustDomain
+func NewGetExternalTrustDomainRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/external", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutExternalTrustDomainRequest calls the generic PutExternalTrustDomain builder with application/json body
+func NewPutExternalTrustDomainRequest(server string, trustDomainName externalRef0.TrustDomainName, body PutExternalTrustDomainJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutExternalTrustDomainRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewPutExternalTrustDomainRequestWithBody generates requests for PutExternalTrustDomain with any type of body
+func NewPutExternalTrustDomainRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/external", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetJoinTokenRequest generates requests for GetJoinToken
+func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/join-token", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ttl", runtime.ParamLocationQuery, params.Ttl); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.MaxUses != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "maxUses", runtime.ParamLocationQuery, *params.MaxUses); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SourceCidr != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sourceCidr", runtime.ParamLocationQuery, *params.SourceCidr); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
 			return err
 		}
-		c.Server = newBaseURL.String()
-		return nil
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListFederationGroups request
+	ListFederationGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListFederationGroupsResponse, error)
+
+	// PutFederationGroup request with any body
+	PutFederationGroupWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFederationGroupResponse, error)
+
+	PutFederationGroupWithResponse(ctx context.Context, body PutFederationGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFederationGroupResponse, error)
+
+	// DeleteFederationGroup request
+	DeleteFederationGroupWithResponse(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*DeleteFederationGroupResponse, error)
+
+	// GetFederationGroup request
+	GetFederationGroupWithResponse(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*GetFederationGroupResponse, error)
+
+	// PutFederationGroupMember request with any body
+	PutFederationGroupMemberWithBodyWithResponse(ctx context.Context, groupName FederationGroupName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFederationGroupMemberResponse, error)
+
+	PutFederationGroupMemberWithResponse(ctx context.Context, groupName FederationGroupName, body PutFederationGroupMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFederationGroupMemberResponse, error)
+
+	// DeleteFederationGroupMember request
+	DeleteFederationGroupMemberWithResponse(ctx context.Context, groupName FederationGroupName, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteFederationGroupMemberResponse, error)
+
+	// GetFederationStatus request
+	GetFederationStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFederationStatusResponse, error)
+
+	// ListHarvesters request
+	ListHarvestersWithResponse(ctx context.Context, params *ListHarvestersParams, reqEditors ...RequestEditorFn) (*ListHarvestersResponse, error)
+
+	// ListJoinTokens request
+	ListJoinTokensWithResponse(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*ListJoinTokensResponse, error)
+
+	// RevokeJoinToken request
+	RevokeJoinTokenWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*RevokeJoinTokenResponse, error)
+
+	// GetJoinTokenByID request
+	GetJoinTokenByIDWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetJoinTokenByIDResponse, error)
+
+	// GetRelationships request
+	GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
+
+	// PutRelationship request with any body
+	PutRelationshipWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error)
+
+	PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error)
+
+	// GetRelationshipByID request
+	GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error)
+
+	// ListTrustDomains request
+	ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error)
+
+	// PutTrustDomain request with any body
+	PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+
+	PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+
+	// DeleteTrustDomainByName request
+	DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error)
+
+	// GetTrustDomainByName request
+	GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error)
+
+	// PutTrustDomainByName request with any body
+	PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+
+	PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+
+	// DeleteTrustDomainBundle request
+	DeleteTrustDomainBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainBundleResponse, error)
+
+	// GetTrustDomainBundle request
+	GetTrustDomainBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainBundleResponse, error)
+
+	// PutTrustDomainBundle request with any body
+	PutTrustDomainBundleWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainBundleResponse, error)
+
+	PutTrustDomainBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainBundleJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainBundleResponse, error)
+
+	// DeleteExternalTrustDomain request
+	DeleteExternalTrustDomainWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteExternalTrustDomainResponse, error)
+
+	// GetExternalTrustDomain request
+	GetExternalTrustDomainWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetExternalTrustDomainResponse, error)
+
+	// PutExternalTrustDomain request with any body
+	PutExternalTrustDomainWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutExternalTrustDomainResponse, error)
+
+	PutExternalTrustDomainWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutExternalTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutExternalTrustDomainResponse, error)
+
+	// GetJoinToken request
+	GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error)
+}
+
+type ListFederationGroupsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]FederationGroup
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListFederationGroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListFederationGroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutFederationGroupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FederationGroup
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutFederationGroupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutFederationGroupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFederationGroupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFederationGroupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFederationGroupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFederationGroupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FederationGroup
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFederationGroupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFederationGroupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutFederationGroupMemberResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FederationGroup
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutFederationGroupMemberResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutFederationGroupMemberResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFederationGroupMemberResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FederationGroup
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFederationGroupMemberResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFederationGroupMemberResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFederationStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FederationStatus
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFederationStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFederationStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListHarvestersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Harvester
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListHarvestersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListHarvestersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListJoinTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]JoinTokenInfo
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListJoinTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListJoinTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeJoinTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeJoinTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeJoinTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJoinTokenByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *JoinTokenInfo
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJoinTokenByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJoinTokenByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRelationshipsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutRelationshipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutRelationshipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutRelationshipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRelationshipByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTrustDomainsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTrustDomainsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTrustDomainsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTrustDomainResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTrustDomainResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTrustDomainResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTrustDomainByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTrustDomainByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTrustDomainByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTrustDomainByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTrustDomainByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTrustDomainByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTrustDomainByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTrustDomainByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTrustDomainByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTrustDomainBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTrustDomainBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTrustDomainBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTrustDomainBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrustDomainBundle
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTrustDomainBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTrustDomainBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTrustDomainBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrustDomainBundle
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTrustDomainBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTrustDomainBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteExternalTrustDomainResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteExternalTrustDomainResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteExternalTrustDomainResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetExternalTrustDomainResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ExternalTrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetExternalTrustDomainResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetExternalTrustDomainResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutExternalTrustDomainResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ExternalTrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutExternalTrustDomainResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutExternalTrustDomainResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJoinTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *JoinTokenResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJoinTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJoinTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListFederationGroupsWithResponse request returning *ListFederationGroupsResponse
+func (c *ClientWithResponses) ListFederationGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListFederationGroupsResponse, error) {
+	rsp, err := c.ListFederationGroups(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListFederationGroupsResponse(rsp)
+}
+
+// PutFederationGroupWithBodyWithResponse request with arbitrary body returning *PutFederationGroupResponse
+func (c *ClientWithResponses) PutFederationGroupWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFederationGroupResponse, error) {
+	rsp, err := c.PutFederationGroupWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFederationGroupResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutFederationGroupWithResponse(ctx context.Context, body PutFederationGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFederationGroupResponse, error) {
+	rsp, err := c.PutFederationGroup(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFederationGroupResponse(rsp)
+}
+
+// DeleteFederationGroupWithResponse request returning *DeleteFederationGroupResponse
+func (c *ClientWithResponses) DeleteFederationGroupWithResponse(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*DeleteFederationGroupResponse, error) {
+	rsp, err := c.DeleteFederationGroup(ctx, groupName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFederationGroupResponse(rsp)
+}
+
+// GetFederationGroupWithResponse request returning *GetFederationGroupResponse
+func (c *ClientWithResponses) GetFederationGroupWithResponse(ctx context.Context, groupName FederationGroupName, reqEditors ...RequestEditorFn) (*GetFederationGroupResponse, error) {
+	rsp, err := c.GetFederationGroup(ctx, groupName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFederationGroupResponse(rsp)
+}
+
+// PutFederationGroupMemberWithBodyWithResponse request with arbitrary body returning *PutFederationGroupMemberResponse
+func (c *ClientWithResponses) PutFederationGroupMemberWithBodyWithResponse(ctx context.Context, groupName FederationGroupName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFederationGroupMemberResponse, error) {
+	rsp, err := c.PutFederationGroupMemberWithBody(ctx, groupName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFederationGroupMemberResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutFederationGroupMemberWithResponse(ctx context.Context, groupName FederationGroupName, body PutFederationGroupMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFederationGroupMemberResponse, error) {
+	rsp, err := c.PutFederationGroupMember(ctx, groupName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFederationGroupMemberResponse(rsp)
+}
+
+// DeleteFederationGroupMemberWithResponse request returning *DeleteFederationGroupMemberResponse
+func (c *ClientWithResponses) DeleteFederationGroupMemberWithResponse(ctx context.Context, groupName FederationGroupName, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteFederationGroupMemberResponse, error) {
+	rsp, err := c.DeleteFederationGroupMember(ctx, groupName, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFederationGroupMemberResponse(rsp)
+}
+
+// GetFederationStatusWithResponse request returning *GetFederationStatusResponse
+func (c *ClientWithResponses) GetFederationStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFederationStatusResponse, error) {
+	rsp, err := c.GetFederationStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFederationStatusResponse(rsp)
+}
+
+// ListHarvestersWithResponse request returning *ListHarvestersResponse
+func (c *ClientWithResponses) ListHarvestersWithResponse(ctx context.Context, params *ListHarvestersParams, reqEditors ...RequestEditorFn) (*ListHarvestersResponse, error) {
+	rsp, err := c.ListHarvesters(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListHarvestersResponse(rsp)
+}
+
+// ListJoinTokensWithResponse request returning *ListJoinTokensResponse
+func (c *ClientWithResponses) ListJoinTokensWithResponse(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*ListJoinTokensResponse, error) {
+	rsp, err := c.ListJoinTokens(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListJoinTokensResponse(rsp)
+}
+
+// RevokeJoinTokenWithResponse request returning *RevokeJoinTokenResponse
+func (c *ClientWithResponses) RevokeJoinTokenWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*RevokeJoinTokenResponse, error) {
+	rsp, err := c.RevokeJoinToken(ctx, joinTokenID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeJoinTokenResponse(rsp)
+}
+
+// GetJoinTokenByIDWithResponse request returning *GetJoinTokenByIDResponse
+func (c *ClientWithResponses) GetJoinTokenByIDWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetJoinTokenByIDResponse, error) {
+	rsp, err := c.GetJoinTokenByID(ctx, joinTokenID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJoinTokenByIDResponse(rsp)
+}
+
+// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
+func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
+	rsp, err := c.GetRelationships(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipsResponse(rsp)
+}
+
+// PutRelationshipWithBodyWithResponse request with arbitrary body returning *PutRelationshipResponse
+func (c *ClientWithResponses) PutRelationshipWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
+	rsp, err := c.PutRelationshipWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutRelationshipResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
+	rsp, err := c.PutRelationship(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutRelationshipResponse(rsp)
+}
+
+// GetRelationshipByIDWithResponse request returning *GetRelationshipByIDResponse
+func (c *ClientWithResponses) GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error) {
+	rsp, err := c.GetRelationshipByID(ctx, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipByIDResponse(rsp)
+}
+
+// ListTrustDomainsWithResponse request returning *ListTrustDomainsResponse
+func (c *ClientWithResponses) ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error) {
+	rsp, err := c.ListTrustDomains(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTrustDomainsResponse(rsp)
+}
+
+// PutTrustDomainWithBodyWithResponse request with arbitrary body returning *PutTrustDomainResponse
+func (c *ClientWithResponses) PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
+	rsp, err := c.PutTrustDomainWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTrustDomainResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
+	rsp, err := c.PutTrustDomain(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTrustDomainResponse(rsp)
+}
+
+// DeleteTrustDomainByNameWithResponse request returning *DeleteTrustDomainByNameResponse
+func (c *ClientWithResponses) DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error) {
+	rsp, err := c.DeleteTrustDomainByName(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTrustDomainByNameResponse(rsp)
+}
+
+// GetTrustDomainByNameWithResponse request returning *GetTrustDomainByNameResponse
+func (c *ClientWithResponses) GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error) {
+	rsp, err := c.GetTrustDomainByName(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTrustDomainByNameResponse(rsp)
+}
+
+// PutTrustDomainByNameWithBodyWithResponse request with arbitrary body returning *PutTrustDomainByNameResponse
+func (c *ClientWithResponses) PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
+	rsp, err := c.PutTrustDomainByNameWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTrustDomainByNameResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
+	rsp, err := c.PutTrustDomainByName(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTrustDomainByNameResponse(rsp)
+}
+
+// DeleteTrustDomainBundleWithResponse request returning *DeleteTrustDomainBundleResponse
+func (c *ClientWithResponses) DeleteTrustDomainBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainBundleResponse, error) {
+	rsp, err := c.DeleteTrustDomainBundle(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTrustDomainBundleResponse(rsp)
+}
+
+// GetTrustDomainBundleWithResponse request returning *GetTrustDomainBundleResponse
+func (c *ClientWithResponses) GetTrustDomainBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainBundleResponse, error) {
+	rsp, err := c.GetTrustDomainBundle(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTrustDomainBundleResponse(rsp)
+}
+
+// PutTrustDomainBundleWithBodyWithResponse request with arbitrary body returning *PutTrustDomainBundleResponse
+func (c *ClientWithResponses) PutTrustDomainBundleWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainBundleResponse, error) {
+	rsp, err := c.PutTrustDomainBundleWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTrustDomainBundleResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutTrustDomainBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainBundleJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainBundleResponse, error) {
+	rsp, err := c.PutTrustDomainBundle(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTrustDomainBundleResponse(rsp)
+}
+
+// DeleteExternalTrustDomainWithResponse request returning *DeleteExternalTrustDomainResponse
+func (c *ClientWithResponses) DeleteExternalTrustDomainWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteExternalTrustDomainResponse, error) {
+	rsp, err := c.DeleteExternalTrustDomain(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteExternalTrustDomainResponse(rsp)
+}
+
+// GetExternalTrustDomainWithResponse request returning *GetExternalTrustDomainResponse
+func (c *ClientWithResponses) GetExternalTrustDomainWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetExternalTrustDomainResponse, error) {
+	rsp, err := c.GetExternalTrustDomain(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetExternalTrustDomainResponse(rsp)
+}
+
+// PutExternalTrustDomainWithBodyWithResponse request with arbitrary body returning *PutExternalTrustDomainResponse
+func (c *ClientWithResponses) PutExternalTrustDomainWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutExternalTrustDomainResponse, error) {
+	rsp, err := c.PutExternalTrustDomainWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutExternalTrustDomainResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutExternalTrustDomainWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutExternalTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutExternalTrustDomainResponse, error) {
+	rsp, err := c.PutExternalTrustDomain(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutExternalTrustDomainResponse(rsp)
+}
+
+// GetJoinTokenWithResponse request returning *GetJoinTokenResponse
+func (c *ClientWithResponses) GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error) {
+	rsp, err := c.GetJoinToken(ctx, trustDomainName, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJoinTokenResponse(rsp)
+}
+
+// ParseListFederationGroupsResponse parses an HTTP response from a ListFederationGroupsWithResponse call
+func ParseListFederationGroupsResponse(rsp *http.Response) (*ListFederationGroupsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListFederationGroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []FederationGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutFederationGroupResponse parses an HTTP response from a PutFederationGroupWithResponse call
+func ParsePutFederationGroupResponse(rsp *http.Response) (*PutFederationGroupResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutFederationGroupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FederationGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFederationGroupResponse parses an HTTP response from a DeleteFederationGroupWithResponse call
+func ParseDeleteFederationGroupResponse(rsp *http.Response) (*DeleteFederationGroupResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFederationGroupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFederationGroupResponse parses an HTTP response from a GetFederationGroupWithResponse call
+func ParseGetFederationGroupResponse(rsp *http.Response) (*GetFederationGroupResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFederationGroupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FederationGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutFederationGroupMemberResponse parses an HTTP response from a PutFederationGroupMemberWithResponse call
+func ParsePutFederationGroupMemberResponse(rsp *http.Response) (*PutFederationGroupMemberResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutFederationGroupMemberResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FederationGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
+
+	return response, nil
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetRelationships request
-	GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
+// ParseDeleteFederationGroupMemberResponse parses an HTTP response from a DeleteFederationGroupMemberWithResponse call
+func ParseDeleteFederationGroupMemberResponse(rsp *http.Response) (*DeleteFederationGroupMemberResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-	// PutRelationship request with any body
-	PutRelationshipWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error)
+	response := &DeleteFederationGroupMemberResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
-	PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FederationGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-	// GetRelationshipByID request
-	GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error)
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-	// ListTrustDomains request
-	ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error)
+	}
 
-	// PutTrustDomain request with any body
-	PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+	return response, nil
+}
 
-	PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+// ParseGetFederationStatusResponse parses an HTTP response from a GetFederationStatusWithResponse call
+func ParseGetFederationStatusResponse(rsp *http.Response) (*GetFederationStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-	// DeleteTrustDomainByName request
-	DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error)
+	response := &GetFederationStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
-	// GetTrustDomainByName request
-	GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FederationStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-	// PutTrustDomainByName request with any body
-	PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-	PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+	}
 
-	// GetJoinToken request
-	GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error)
+	return response, nil
 }
 
-type GetRelationshipsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
-}
+// ParseListHarvestersResponse parses an HTTP response from a ListHarvestersWithResponse call
+func ParseListHarvestersResponse(rsp *http.Response) (*ListHarvestersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetRelationshipsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &ListHarvestersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetRelationshipsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Harvester
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return 0
-}
 
-type PutRelationshipResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PutRelationshipResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseListJoinTokensResponse parses an HTTP response from a ListJoinTokensWithResponse call
+func ParseListJoinTokensResponse(rsp *http.Response) (*ListJoinTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutRelationshipResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &ListJoinTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetRelationshipByIDResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []JoinTokenInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetRelationshipByIDResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetRelationshipByIDResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type ListTrustDomainsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r ListTrustDomainsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseRevokeJoinTokenResponse parses an HTTP response from a RevokeJoinTokenWithResponse call
+func ParseRevokeJoinTokenResponse(rsp *http.Response) (*RevokeJoinTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListTrustDomainsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &RevokeJoinTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type PutTrustDomainResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-// Status returns HTTPResponse.Status
-func (r PutTrustDomainResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutTrustDomainResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-type DeleteTrustDomainByNameResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *externalRef0.ApiError
-}
+// ParseGetJoinTokenByIDResponse parses an HTTP response from a GetJoinTokenByIDWithResponse call
+func ParseGetJoinTokenByIDResponse(rsp *http.Response) (*GetJoinTokenByIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r DeleteTrustDomainByNameResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &GetJoinTokenByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteTrustDomainByNameResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest JoinTokenInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return 0
-}
 
-type GetTrustDomainByNameResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetTrustDomainByNameResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
+func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTrustDomainByNameResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &GetRelationshipsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type PutTrustDomainByNameResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r PutTrustDomainByNameResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutTrustDomainByNameResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type GetJoinTokenResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *JoinTokenResponse
-	JSONDefault  *externalRef0.ApiError
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetJoinTokenResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParsePutRelationshipResponse parses an HTTP response from a PutRelationshipWithResponse call
+func ParsePutRelationshipResponse(rsp *http.Response) (*PutRelationshipResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetJoinTokenResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &PutRelationshipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
-func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
-	rsp, err := c.GetRelationships(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseGetRelationshipsResponse(rsp)
+
+	return response, nil
 }
 
-// PutRelationshipWithBodyWithResponse request with arbitrary body returning *PutRelationshipResponse
-func (c *ClientWithResponses) PutRelationshipWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
-	rsp, err := c.PutRelationshipWithBody(ctx, contentType, body, reqEditors...)
+// ParseGetRelationshipByIDResponse parses an HTTP response from a GetRelationshipByIDWithResponse call
+func ParseGetRelationshipByIDResponse(rsp *http.Response) (*GetRelationshipByIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePutRelationshipResponse(rsp)
-}
 
-func (c *ClientWithResponses) PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
-	rsp, err := c.PutRelationship(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetRelationshipByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePutRelationshipResponse(rsp)
-}
 
-// GetRelationshipByIDWithResponse request returning *GetRelationshipByIDResponse
-func (c *ClientWithResponses) GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error) {
-	rsp, err := c.GetRelationshipByID(ctx, relationshipID, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseGetRelationshipByIDResponse(rsp)
+
+	return response, nil
 }
 
-// ListTrustDomainsWithResponse request returning *ListTrustDomainsResponse
-func (c *ClientWithResponses) ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error) {
-	rsp, err := c.ListTrustDomains(ctx, reqEditors...)
+// ParseListTrustDomainsResponse parses an HTTP response from a ListTrustDomainsWithResponse call
+func ParseListTrustDomainsResponse(rsp *http.Response) (*ListTrustDomainsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListTrustDomainsResponse(rsp)
-}
 
-// PutTrustDomainWithBodyWithResponse request with arbitrary body returning *PutTrustDomainResponse
-func (c *ClientWithResponses) PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
-	rsp, err := c.PutTrustDomainWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ListTrustDomainsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePutTrustDomainResponse(rsp)
-}
 
-func (c *ClientWithResponses) PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
-	rsp, err := c.PutTrustDomain(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.TrustDomain
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParsePutTrustDomainResponse(rsp)
+
+	return response, nil
 }
 
-// DeleteTrustDomainByNameWithResponse request returning *DeleteTrustDomainByNameResponse
-func (c *ClientWithResponses) DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error) {
-	rsp, err := c.DeleteTrustDomainByName(ctx, trustDomainName, reqEditors...)
+// ParsePutTrustDomainResponse parses an HTTP response from a PutTrustDomainWithResponse call
+func ParsePutTrustDomainResponse(rsp *http.Response) (*PutTrustDomainResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteTrustDomainByNameResponse(rsp)
-}
 
-// GetTrustDomainByNameWithResponse request returning *GetTrustDomainByNameResponse
-func (c *ClientWithResponses) GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error) {
-	rsp, err := c.GetTrustDomainByName(ctx, trustDomainName, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PutTrustDomainResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetTrustDomainByNameResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest externalRef0.TrustDomain
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// PutTrustDomainByNameWithBodyWithResponse request with arbitrary body returning *PutTrustDomainByNameResponse
-func (c *ClientWithResponses) PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
-	rsp, err := c.PutTrustDomainByNameWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+// ParseDeleteTrustDomainByNameResponse parses an HTTP response from a DeleteTrustDomainByNameWithResponse call
+func ParseDeleteTrustDomainByNameResponse(rsp *http.Response) (*DeleteTrustDomainByNameResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePutTrustDomainByNameResponse(rsp)
-}
 
-func (c *ClientWithResponses) PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
-	rsp, err := c.PutTrustDomainByName(ctx, trustDomainName, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteTrustDomainByNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParsePutTrustDomainByNameResponse(rsp)
-}
 
-// GetJoinTokenWithResponse request returning *GetJoinTokenResponse
-func (c *ClientWithResponses) GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error) {
-	rsp, err := c.GetJoinToken(ctx, trustDomainName, params, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseGetJoinTokenResponse(rsp)
+	return response, nil
 }
 
-// ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
-func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
+// ParseGetTrustDomainByNameResponse parses an HTTP response from a GetTrustDomainByNameWithResponse call
+func ParseGetTrustDomainByNameResponse(rsp *http.Response) (*GetTrustDomainByNameResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetRelationshipsResponse{
+	response := &GetTrustDomainByNameResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []externalRef0.Relationship
+		var dest externalRef0.TrustDomain
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1147,22 +3528,22 @@ func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsRespons
 	return response, nil
 }
 
-// ParsePutRelationshipResponse parses an HTTP response from a PutRelationshipWithResponse call
-func ParsePutRelationshipResponse(rsp *http.Response) (*PutRelationshipResponse, error) {
+// ParsePutTrustDomainByNameResponse parses an HTTP response from a PutTrustDomainByNameWithResponse call
+func ParsePutTrustDomainByNameResponse(rsp *http.Response) (*PutTrustDomainByNameResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PutRelationshipResponse{
+	response := &PutTrustDomainByNameResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.Relationship
+		var dest externalRef0.TrustDomain
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1180,27 +3561,20 @@ func ParsePutRelationshipResponse(rsp *http.Response) (*PutRelationshipResponse,
 	return response, nil
 }
 
-// ParseGetRelationshipByIDResponse parses an HTTP response from a GetRelationshipByIDWithResponse call
-func ParseGetRelationshipByIDResponse(rsp *http.Response) (*GetRelationshipByIDResponse, error) {
+// ParseDeleteTrustDomainBundleResponse parses an HTTP response from a DeleteTrustDomainBundleWithResponse call
+func ParseDeleteTrustDomainBundleResponse(rsp *http.Response) (*DeleteTrustDomainBundleResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetRelationshipByIDResponse{
+	response := &DeleteTrustDomainBundleResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.Relationship
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest externalRef0.ApiError
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1213,22 +3587,22 @@ func ParseGetRelationshipByIDResponse(rsp *http.Response) (*GetRelationshipByIDR
 	return response, nil
 }
 
-// ParseListTrustDomainsResponse parses an HTTP response from a ListTrustDomainsWithResponse call
-func ParseListTrustDomainsResponse(rsp *http.Response) (*ListTrustDomainsResponse, error) {
+// ParseGetTrustDomainBundleResponse parses an HTTP response from a GetTrustDomainBundleWithResponse call
+func ParseGetTrustDomainBundleResponse(rsp *http.Response) (*GetTrustDomainBundleResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListTrustDomainsResponse{
+	response := &GetTrustDomainBundleResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.TrustDomain
+		var dest TrustDomainBundle
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1246,26 +3620,26 @@ func ParseListTrustDomainsResponse(rsp *http.Response) (*ListTrustDomainsRespons
 	return response, nil
 }
 
-// ParsePutTrustDomainResponse parses an HTTP response from a PutTrustDomainWithResponse call
-func ParsePutTrustDomainResponse(rsp *http.Response) (*PutTrustDomainResponse, error) {
+// ParsePutTrustDomainBundleResponse parses an HTTP response from a PutTrustDomainBundleWithResponse call
+func ParsePutTrustDomainBundleResponse(rsp *http.Response) (*PutTrustDomainBundleResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PutTrustDomainResponse{
+	response := &PutTrustDomainBundleResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest externalRef0.TrustDomain
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TrustDomainBundle
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest externalRef0.ApiError
@@ -1279,15 +3653,15 @@ func ParsePutTrustDomainResponse(rsp *http.Response) (*PutTrustDomainResponse, e
 	return response, nil
 }
 
-// ParseDeleteTrustDomainByNameResponse parses an HTTP response from a DeleteTrustDomainByNameWithResponse call
-func ParseDeleteTrustDomainByNameResponse(rsp *http.Response) (*DeleteTrustDomainByNameResponse, error) {
+// ParseDeleteExternalTrustDomainResponse parses an HTTP response from a DeleteExternalTrustDomainWithResponse call
+func ParseDeleteExternalTrustDomainResponse(rsp *http.Response) (*DeleteExternalTrustDomainResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteTrustDomainByNameResponse{
+	response := &DeleteExternalTrustDomainResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1305,22 +3679,22 @@ func ParseDeleteTrustDomainByNameResponse(rsp *http.Response) (*DeleteTrustDomai
 	return response, nil
 }
 
-// ParseGetTrustDomainByNameResponse parses an HTTP response from a GetTrustDomainByNameWithResponse call
-func ParseGetTrustDomainByNameResponse(rsp *http.Response) (*GetTrustDomainByNameResponse, error) {
+// ParseGetExternalTrustDomainResponse parses an HTTP response from a GetExternalTrustDomainWithResponse call
+func ParseGetExternalTrustDomainResponse(rsp *http.Response) (*GetExternalTrustDomainResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetTrustDomainByNameResponse{
+	response := &GetExternalTrustDomainResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.TrustDomain
+		var dest ExternalTrustDomain
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1338,22 +3712,22 @@ func ParseGetTrustDomainByNameResponse(rsp *http.Response) (*GetTrustDomainByNam
 	return response, nil
 }
 
-// ParsePutTrustDomainByNameResponse parses an HTTP response from a PutTrustDomainByNameWithResponse call
-func ParsePutTrustDomainByNameResponse(rsp *http.Response) (*PutTrustDomainByNameResponse, error) {
+// ParsePutExternalTrustDomainResponse parses an HTTP response from a PutExternalTrustDomainWithResponse call
+func ParsePutExternalTrustDomainResponse(rsp *http.Response) (*PutExternalTrustDomainResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PutTrustDomainByNameResponse{
+	response := &PutExternalTrustDomainResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.TrustDomain
+		var dest ExternalTrustDomain
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1406,6 +3780,39 @@ func ParseGetJoinTokenResponse(rsp *http.Response) (*GetJoinTokenResponse, error
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List all federation groups and their members
+	// (GET /federation-groups)
+	ListFederationGroups(ctx echo.Context) error
+	// Create a federation group, or update the description and default consent of an existing one. The default consent applies to the relationships created for the group from then on
+	// (PUT /federation-groups)
+	PutFederationGroup(ctx echo.Context) error
+	// Delete a federation group. The relationships created for the group are deleted by the reconciler, unless another group still federates their trust domains
+	// (DELETE /federation-groups/{groupName})
+	DeleteFederationGroup(ctx echo.Context, groupName FederationGroupName) error
+	// Get a federation group and its members
+	// (GET /federation-groups/{groupName})
+	GetFederationGroup(ctx echo.Context, groupName FederationGroupName) error
+	// Add a trust domain to a federation group. The reconciler creates its relationships with the other members
+	// (PUT /federation-groups/{groupName}/members)
+	PutFederationGroupMember(ctx echo.Context, groupName FederationGroupName) error
+	// Remove a trust domain from a federation group. The reconciler deletes its relationships with the other members, unless another group still federates them
+	// (DELETE /federation-groups/{groupName}/members/{trustDomainName})
+	DeleteFederationGroupMember(ctx echo.Context, groupName FederationGroupName, trustDomainName externalRef0.TrustDomainName) error
+	// Get the distribution status of the bundles of the approved relationships
+	// (GET /federation/status)
+	GetFederationStatus(ctx echo.Context) error
+	// List the Harvester instances of all trust domains, flagging the ones that have been silent longer than a threshold. Trust domains without any Harvester instance are listed once, flagged as silent
+	// (GET /harvesters)
+	ListHarvesters(ctx echo.Context, params ListHarvestersParams) error
+	// List the join tokens, newest first. The tokens themselves are not returned, only their prefix
+	// (GET /join-tokens)
+	ListJoinTokens(ctx echo.Context, params ListJoinTokensParams) error
+	// Revoke a join token, so that no Harvester can onboard with it anymore
+	// (DELETE /join-tokens/{joinTokenID})
+	RevokeJoinToken(ctx echo.Context, joinTokenID externalRef0.UUID) error
+	// Get the state of a join token
+	// (GET /join-tokens/{joinTokenID})
+	GetJoinTokenByID(ctx echo.Context, joinTokenID externalRef0.UUID) error
 	// Get relationships
 	// (GET /relationships)
 	GetRelationships(ctx echo.Context, params GetRelationshipsParams) error
@@ -1430,7 +3837,25 @@ type ServerInterface interface {
 	// Update a specific trust domain
 	// (PUT /trust-domain/{trustDomainName})
 	PutTrustDomainByName(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
-	// Get a join token for a specific Trust Domain
+	// Delete the admin-managed bundle of a Trust Domain, so that its Harvester can upload it again
+	// (DELETE /trust-domain/{trustDomainName}/bundle)
+	DeleteTrustDomainBundle(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Get the stored bundle of a Trust Domain
+	// (GET /trust-domain/{trustDomainName}/bundle)
+	GetTrustDomainBundle(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Set the bundle of a Trust Domain without a Harvester, e.g. received out of band. The bundle is validated like the bundles uploaded by Harvesters and is admin-managed from then on, so Harvester uploads are refused
+	// (PUT /trust-domain/{trustDomainName}/bundle)
+	PutTrustDomainBundle(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Stop fetching the bundle of an external trust domain. The stored bundle is kept
+	// (DELETE /trust-domain/{trustDomainName}/external)
+	DeleteExternalTrustDomain(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Get the bundle endpoint configuration and refresh status of an external trust domain
+	// (GET /trust-domain/{trustDomainName}/external)
+	GetExternalTrustDomain(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Configure a trust domain as external, i.e. without a Harvester. The server fetches its bundle periodically from its SPIFFE bundle endpoint
+	// (PUT /trust-domain/{trustDomainName}/external)
+	PutExternalTrustDomain(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Get a join token for a specific Trust Domain. The token is only disclosed in this response, the server stores a salted hash of its secret
 	// (GET /trust-domain/{trustDomainName}/join-token)
 	GetJoinToken(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params GetJoinTokenParams) error
 }
@@ -1440,6 +3865,173 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// ListFederationGroups converts echo context to params.
+func (w *ServerInterfaceWrapper) ListFederationGroups(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListFederationGroups(ctx)
+	return err
+}
+
+// PutFederationGroup converts echo context to params.
+func (w *ServerInterfaceWrapper) PutFederationGroup(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PutFederationGroup(ctx)
+	return err
+}
+
+// DeleteFederationGroup converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteFederationGroup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupName" -------------
+	var groupName FederationGroupName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupName", runtime.ParamLocationPath, ctx.Param("groupName"), &groupName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteFederationGroup(ctx, groupName)
+	return err
+}
+
+// GetFederationGroup converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFederationGroup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupName" -------------
+	var groupName FederationGroupName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupName", runtime.ParamLocationPath, ctx.Param("groupName"), &groupName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetFederationGroup(ctx, groupName)
+	return err
+}
+
+// PutFederationGroupMember converts echo context to params.
+func (w *ServerInterfaceWrapper) PutFederationGroupMember(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupName" -------------
+	var groupName FederationGroupName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupName", runtime.ParamLocationPath, ctx.Param("groupName"), &groupName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PutFederationGroupMember(ctx, groupName)
+	return err
+}
+
+// DeleteFederationGroupMember converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteFederationGroupMember(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupName" -------------
+	var groupName FederationGroupName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupName", runtime.ParamLocationPath, ctx.Param("groupName"), &groupName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupName: %s", err))
+	}
+
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteFederationGroupMember(ctx, groupName, trustDomainName)
+	return err
+}
+
+// GetFederationStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFederationStatus(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetFederationStatus(ctx)
+	return err
+}
+
+// ListHarvesters converts echo context to params.
+func (w *ServerInterfaceWrapper) ListHarvesters(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListHarvestersParams
+	// ------------- Optional query parameter "silentThreshold" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "silentThreshold", ctx.QueryParams(), &params.SilentThreshold)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter silentThreshold: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListHarvesters(ctx, params)
+	return err
+}
+
+// ListJoinTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) ListJoinTokens(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListJoinTokensParams
+	// ------------- Optional query parameter "trustDomainName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "trustDomainName", ctx.QueryParams(), &params.TrustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListJoinTokens(ctx, params)
+	return err
+}
+
+// RevokeJoinToken converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeJoinToken(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "joinTokenID" -------------
+	var joinTokenID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, ctx.Param("joinTokenID"), &joinTokenID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter joinTokenID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RevokeJoinToken(ctx, joinTokenID)
+	return err
+}
+
+// GetJoinTokenByID converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJoinTokenByID(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "joinTokenID" -------------
+	var joinTokenID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, ctx.Param("joinTokenID"), &joinTokenID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter joinTokenID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetJoinTokenByID(ctx, joinTokenID)
+	return err
+}
+
 // GetRelationships converts echo context to params.
 func (w *ServerInterfaceWrapper) GetRelationships(ctx echo.Context) error {
 	var err error
@@ -1570,6 +4162,102 @@ func (w *ServerInterfaceWrapper) PutTrustDomainByName(ctx echo.Context) error {
 	return err
 }
 
+// DeleteTrustDomainBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteTrustDomainBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteTrustDomainBundle(ctx, trustDomainName)
+	return err
+}
+
+// GetTrustDomainBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTrustDomainBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetTrustDomainBundle(ctx, trustDomainName)
+	return err
+}
+
+// PutTrustDomainBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) PutTrustDomainBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PutTrustDomainBundle(ctx, trustDomainName)
+	return err
+}
+
+// DeleteExternalTrustDomain converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteExternalTrustDomain(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteExternalTrustDomain(ctx, trustDomainName)
+	return err
+}
+
+// GetExternalTrustDomain converts echo context to params.
+func (w *ServerInterfaceWrapper) GetExternalTrustDomain(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetExternalTrustDomain(ctx, trustDomainName)
+	return err
+}
+
+// PutExternalTrustDomain converts echo context to params.
+func (w *ServerInterfaceWrapper) PutExternalTrustDomain(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PutExternalTrustDomain(ctx, trustDomainName)
+	return err
+}
+
 // GetJoinToken converts echo context to params.
 func (w *ServerInterfaceWrapper) GetJoinToken(ctx echo.Context) error {
 	var err error
@@ -1590,6 +4278,20 @@ func (w *ServerInterfaceWrapper) GetJoinToken(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ttl: %s", err))
 	}
 
+	// ------------- Optional query parameter "maxUses" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxUses", ctx.QueryParams(), &params.MaxUses)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter maxUses: %s", err))
+	}
+
+	// ------------- Optional query parameter "sourceCidr" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sourceCidr", ctx.QueryParams(), &params.SourceCidr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sourceCidr: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.GetJoinToken(ctx, trustDomainName, params)
 	return err
@@ -1623,6 +4325,17 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/federation-groups", wrapper.ListFederationGroups)
+	router.PUT(baseURL+"/federation-groups", wrapper.PutFederationGroup)
+	router.DELETE(baseURL+"/federation-groups/:groupName", wrapper.DeleteFederationGroup)
+	router.GET(baseURL+"/federation-groups/:groupName", wrapper.GetFederationGroup)
+	router.PUT(baseURL+"/federation-groups/:groupName/members", wrapper.PutFederationGroupMember)
+	router.DELETE(baseURL+"/federation-groups/:groupName/members/:trustDomainName", wrapper.DeleteFederationGroupMember)
+	router.GET(baseURL+"/federation/status", wrapper.GetFederationStatus)
+	router.GET(baseURL+"/harvesters", wrapper.ListHarvesters)
+	router.GET(baseURL+"/join-tokens", wrapper.ListJoinTokens)
+	router.DELETE(baseURL+"/join-tokens/:joinTokenID", wrapper.RevokeJoinToken)
+	router.GET(baseURL+"/join-tokens/:joinTokenID", wrapper.GetJoinTokenByID)
 	router.GET(baseURL+"/relationships", wrapper.GetRelationships)
 	router.PUT(baseURL+"/relationships", wrapper.PutRelationship)
 	router.GET(baseURL+"/relationships/:relationshipID", wrapper.GetRelationshipByID)
@@ -1631,6 +4344,12 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.DELETE(baseURL+"/trust-domain/:trustDomainName", wrapper.DeleteTrustDomainByName)
 	router.GET(baseURL+"/trust-domain/:trustDomainName", wrapper.GetTrustDomainByName)
 	router.PUT(baseURL+"/trust-domain/:trustDomainName", wrapper.PutTrustDomainByName)
+	router.DELETE(baseURL+"/trust-domain/:trustDomainName/bundle", wrapper.DeleteTrustDomainBundle)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/bundle", wrapper.GetTrustDomainBundle)
+	router.PUT(baseURL+"/trust-domain/:trustDomainName/bundle", wrapper.PutTrustDomainBundle)
+	router.DELETE(baseURL+"/trust-domain/:trustDomainName/external", wrapper.DeleteExternalTrustDomain)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/external", wrapper.GetExternalTrustDomain)
+	router.PUT(baseURL+"/trust-domain/:trustDomainName/external", wrapper.PutExternalTrustDomain)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/join-token", wrapper.GetJoinToken)
 
 }
@@ -1638,41 +4357,133 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZbXPauBb+Kxrd/bC7Y7CBQBNm9gMJJKVNaBLotk2Tywj72CixLUeSISTj/35HskNs",
-	"MHnbtLt7PyEsS+flec7R0fEdtlkQsRBCKXD7DnMQEQsF6D9dcEnsSzW0WSgh1EMSRT61iaQsNC8FC9Uz",
-	"YU8hIGr0CwcXt/F/zId9zXRWmJ2I9jhnHCdJYmAHhM1ppPbBbawnUOe4jx5UUG9la9XWy+VKCcehaiXx",
-	"jzmLgEuqVHaJL8DAUe6RUt0B9esyHhCJ25iGsrWFDRyQGxrEAW43d3YMHNAw/VezLAPLRQTpq+ABx4mB",
-	"AxCCeHonuCFB5Kv5DpoAiSV1Yx+BtuD+NeNBnpCchl4q8BBCT05xu54Tks0razlcx5SDg9vfU70f5F4s",
-	"32eTS7Cl0mlP+SmUQ0lkrG2FUFnwXWHE2QwcrNwcUj2IIHSUnIs1wQb+wGg4YlcQFs1ruGS76ba2Ks13",
-	"tXeVrWarXpk0XLtSt3daDbfVIi5p5S2NY+oU7Wy0DBwRKYErkP/73arskIp7cbedVJbjrWeMa/XkF/yY",
-	"4qcZa15IDnlv9GO8ffDOKkbp8jJojokHgziYgKZrkeujKaBQzyHmIiohEEgyJK5ohCbgMg5ISMIlDT31",
-	"3Ga+D7ZEcgoqOGJfIgGyinOcLWWsUmFIbyFVIAvlumVs1EYU1OEgYx5WC4Fi5eOkVGYsT8HXyUFMaXQK",
-	"1zEI+VJQeCzk2GEBoeGYjEMSwFMQjdSSrl4xUK8nRnGXyet2WYW7RLFyQaWUiGVOwOtcU4AuH6p6Z5Qq",
-	"geSUSMQh4qDSgyYOhJLKBfr6msRk4Ldw3ka/5Anz0tzOgUhwxkQWvVG36rWKVas0rJG13W5Ybcs6y1vu",
-	"EAkVSTV8eeNrJbZT5ynLP3/ud9cYR8Z2mp2fWl1M4uvbvFr+28TNm1gxea0Vk9daEUfOD2bGCr31yZfj",
-	"Y0GFMlDLXLSRQxthKQuo4XF/f7/X7xYtFxF1XWibZn4nc874lc+IM6aOShEuBf50itjaLokTDcJuHDo+",
-	"rB95qUoozVIT/RKiIfow/DRAmTAjp+vdOb6cyzGJ5ZRxqqL9HLe/351juIkoBzEm8hy3z3Gttb3VrLUa",
-	"W41zbJzjK1iMqaNnOs7ozLbsd7dip2W3vNnJzYfd1onTa3UXw3jgzvT7UTzxqT2+goVec7R/Ne/Nv73/",
-	"yM76t5fWXufkWz8bdzsndvfE6/Ruasdnp3O31+ieiU/X9aNd61Pz+Is7EbecRAcDN2j29s0am39thv3u",
-	"ILgc0Yk5WLjv9mBvNjy0e3bD+haRyawz8Q7fb9uiPu3e1jp//HGOE2OTfdu1dftc70/Stcmo843cXh3U",
-	"v7g7jS/y4CY4db66HWuw+1r7eHd4SW0eXg8/h736AmofWOzudg8OJ7J/dPlh/8+Dj/D+k/w4asbX/q75",
-	"cbQ9qDeaX4X46o0OT06PprdRp2sfHW19Nr/59owtrt43A0/bd2GcYw4uBzEdT2mYWmhpRYU6DEMbxmkh",
-	"omfe6Zk8WfVj6dTOcYI3ETDNAv/AY+TnnNy5WvtX9Pv3TuVMV9C3F+j3334vraCnhM9ASODjNEE8I1Mv",
-	"88uLTsdXJnIWThjh6uYyniyTy5N7ZHnobzsIstJw03lQlrVXbS9oq8OgmpKkarPglTlaY/GvuuIp79LQ",
-	"ZfdNCGJrHFMy4QMqp/FE+Zb7uI2nUkaibZqefqz8ZL6HuQ9SHhP7inDH9IhPHE7BXwtJfHA/hYbAZ8DR",
-	"EQmJB4GKw85xH4kIbOpmnQ91LfKpDdmNM1OnExF7CqhetQoqtU1zPp9XiZ6tMu6Z2VJhHvb3eoNhr1Kv",
-	"WtWpDLRakkoNzZpCHSegodalgj5FEKpRQ8uaARepFbWqVa3VdOBEEJKIKoyrVrWBNUpTne9Mniu59RMP",
-	"tFtVUtQTfUcpAIXLnNBbcBKABC7Uebziwfyu6gYrY4FczgJEsoM/ZTeKgCtnSjoDAyt4cRtfx8AX94HT",
-	"xnahnDSe2WBaKUITY+3mnTsiygXLlSh8ruiS28/LhUf3N/bnSl1e8bW4TVtmfYiXbJotSZILo9gTrFvW",
-	"i/qBup3wlMTCDTBZ5gDCOVmUNQuHsW2DEG7soyVj04BeNizLxC0NMe87m7rDGAcB4YuU74ivEF4ST1Ed",
-	"FwPhIjFwFJeEzEr/A6dHAwi5y5zFmzVSN3RZkuJRJHkMyV+E7/mo/TSU9vSZikgBKpS5GU1AzgFCJOes",
-	"kHQewzIxVjKieZf/2+8mz02Ru4t+96ks2e8i5urKboUpOnxVjn6I3qIaeBXd50Z0WoL95Vj+B5JBhSxZ",
-	"Hs0FSjwBuE70FWd5XdgUzsWs/YOiuaQvmGTRXMCr9mZC82aVwJUGmfMGCHUcJ4+QzN12cgjlQ7UEIPNu",
-	"5Vx+NCJztu0uslP80ZAsVCdZ6V4Sjeu1wevCcb1W+JGR+QTSPycwnwe78Zwo/DdB+vbJYgXN5P+OOJ/1",
-	"LflHpAzzktGwsvzityl7PHztewnFBn8DxdZvGDSAimSVQzoD9OtodPgboiESYLPQEchlXJcdyg1IZhaW",
-	"3oGk/6iWS4QbLcsyCh/WG/X898Lt1pZlPf6Z8oemvvWvwz87AT74Wrs/x+oCfR9YrVRGKf0utLJC3/1T",
-	"/hW7HD6ziT9lQlbFnHge8CplJomoOWtg5dZsy1WSdFChGb/UIAO/8HSdYp1i2U1F9lk661jqGVXfknsp",
-	"++BkTi3Uu48W6pkqxbJtXZfTEqk5h09YHDpIspX2Q/VBQM7ZyUXyvwAAAP//IoID0gwjAAA=",
+	"H4sIAAAAAAAC/+x9fXOqSLfvV6G8p2qf88REfNdd9fwBgooRE981474phBZQaAjdiDqV736rARUUE5PZ",
+	"O/uZuWemaiaBpnu9/Hr16rVWd/5MyZZpWxBAjFLf/0w5ANkWRMD/hQMLyTUw+VG2IAbQ/1GybUOXJaxb",
+	"MLNEFiTPkKwBUyI//ZcDFqnvqf+TOfabCd6iDGPrvONYTur19TWdUgCSHd0m/aS+p/wXFPMoUEcSSKvw",
+	"W9L14XNChKLo5EvJeHQsGzhYJyQvJAOBdMqOPCKkK4D8f2E5poRT31M6xKVCKp0ypY1uumbqe7FaTadM",
+	"HQa/ZWk6ncJbGwRNgQqc1Gs6ZQKEJNXvCWwk0zbIe4aaA8nF+sI1KOBzsG+WPo6HsKNDNRiwDaCKtdT3",
+	"XGSQ8D3h1gEvru4AJfX9j4Du47g/Du2t+RLImNDEulAxAKerAPmqiYt0LiFQKlAAkp4Uqt9kbnPFEqX4",
+	"zSlrQWENUHO/i1Q6wtSCLhRLSlkCCl2pgHI1CwqlLC3n5ZyklPLSAhRKIAfK5XK1Ulkoc7maK9OLbBHI",
+	"1XI2Oy/kUmecHSklT+ZuQOCHtAg2NpAxUJ6VA7dvQS0mmdd0SofPaAvlcyENHBdQngagLw3suAhTimVK",
+	"OqQ0y1CQ/9iQMBFZICsiOR0jygbAObI6tywDSJCMZUjqMwKyBRWUMJ5uAkqHVNgguXvykHRPaRKi5gBA",
+	"SlpLuiHNDUB5OtYsFyeTq0OV0nEqfQ72c0CTAZ79Hp6DHp6hZIL3BDsgH3B++w5p7oPWthyiGglf4Ddk",
+	"yZCOXBJtUFiTMLX//Mj1AZMHLhQJg1usmyAJW4fxPweNvy6Ck4l73uFFYR+BGYdN0mSvkcmwIJYXnIt5",
+	"clekq5R8bEIw9siLVCjC6Py+Jf+wfEPoUDW+NxDqQo0Z8P7TGRQFoZkZ1GosGKuMJ7CMKnQldsrnMyJd",
+	"mTSnNdgZmXKdlZdMh1VXL9pKb1Q9mmW6qM5w7HYGxS7yat0pN+p2G7zXGg13/IPIeA0mO+RrjFcfNUaF",
+	"6UTc8BzzwKqdEcvIIktra2XSoee5wmYG+QHzGLyxxFq9MxjUWG6eb3liv+C1Gb9njquNBkPac6e5Khb4",
+	"0VgI2rXmsGfMoGxmjaeGoSmNodqleXVodFihLuxEtjDhBoIncl1PHDBeZ6DuxKxFnm1ETt50lsGzGRSz",
+	"lqfO6U1tx7QCWqYDxhgNxG7B4wIaBI4ZDZ8mmibv+K7IFHwOWc9r9hvV7AzK+d56vuR7IlMJeFc9YZjt",
+	"iALfWcuQ2dSXzDDoeTjghsWxuGS8B47PiYPutsOJmxmsc0w/aCGKtbySV7bFnZwLeBZ7tNfwfDoeObbX",
+	"lU0jN530DIGvbp9ydVea2NoMKg2D0DAR2WGjtkUNpttl1aVcYVS+xjFPD0+TJ+2pwW/4HdNjVeSwKs8z",
+	"UyH/yAgssxFrMzgaiZ6q8rrI0I1a/6XRF+Z5rsuzTHfIMAWB5TyGvL9nLIFlulxTA73VfJ6t1+TypneP",
+	"8Ax693RLaEj30wout+b93Lybm5emQotTYdMVps0X1qkNR+WqBQx9tbJWvVV9La9t6V6H9SbXbc7g0B7z",
+	"Qqk35HtTs19T8w+VsV7IuQ/yKMcWn6S5OamtPGUzLfKyUcyyc7EyhA3FYhpzpWPqPXMG++ZgKaMbQxM3",
+	"amFRn5YM1tb5UV1vDJeNXu+mlO2Vyu1daVi4b4F2R66ZdLnr1af3rGnrdEWdQWWr9tc9ZegViy3LdoCy",
+	"vBk18HK4YgtafVBodCcZVcOlas942WVuKi6t8N2V5g5dV3ZeJIPQ0NgW8s2exy64+7o3BWOxXHsUlSLI",
+	"KA83mK7gyuN8uRsNBuui1uVqiJ8Ko9ygzNSFal/ubMQZXGnlDKOKLMM0lqraYUVB4B4HzIJgpNkX+QbH",
+	"jFW2n/FGL83MdlnqDvJVTGdWTelGnY5UewbXAzbDqirRc53tyizT7e3EJu8NulPh3puybHfYFJn7Rnes",
+	"0UqTKbW31bySl10530Fts7OewXm/un2asGs5Z9DzfKvYznYGg0ZnPe9nB8q4xXX72fpIz5K5icmsaw+6",
+	"3sNgiodL0Z3mW/QMijWmUasRLA7r7I5hNa1nKc2e96BX1vNcZyc3xcN48z13PT7gTsX5GYxSNJ8KzWNr",
+	"NpQFw485diwycoMdA5ZjeNbH7/aFl5hGYwarUK6xXZ4VOa/B1cJ58bLymK7IshyDxJp1pNET2LpW9GmU",
+	"d9a6nVcIDZG52M63DLlR3UmT3lqGK69JrF+PNlh26tWZo2QZTzj0OoOsJ7IirxLboDS9HityFe9RYsoW",
+	"ZzY6uYP8l7K52bVhZzevFZfzHL0mNoSMOoPtUSc7XXXY9nA0bo+I/cv2hzSPOxxT7OjZvrgtLmXT29Pz",
+	"wLJTvs5wTH0oSDuv6Mzgk9CUbNjbCENoezeNdmjFFM7j2YzX5RlPqFtcrcZM6EZND+SUhasaywi8qtbx",
+	"DLKCwErdOmSaMlM1tsN2tZ4Xa8JwxKqC2OqNl26nw29Wu3W1Ira3THvHlzdPDyLDMPWNSGvWDM49hmEZ",
+	"kelzbIPReaa0AYbe6VUaq0wpb08V2M+sHzaZ2tLGvMivK9XxWMtmXGcs8DWhy21nkHVAc5grcjvPXXWl",
+	"XnfpjUvF4lN79VKDm/mmO+7pD8BcVlsMm2VaXXXNlh6y0yzSm+JCtZA+g20m38utsmDO3wwfx835QK9O",
+	"B1K7xjAMKw86gtTxGIbpcgw/9XqMoDZ6fMHbSfNOT+Eqq5fMDK7rj3ncBTnNpDdFOHENy9MKwtzLG6ua",
+	"UJ/OM3mjz9lGv8zIvYJzM7HHmL/vD+rjltmp9ebyDE5arpPrNVimOWTKqDYqW9ntE3PTL1Qeio2K3Ldy",
+	"xkttgtuSZg0fHp6aCK3xZrGKSLISSrK3ZHlGZ0vCem6NEcr3CgIeeUswN8pcfmvVpQnd4bScMtY01att",
+	"nKYnqLXFS3kGLVmsFfFNdqkXxeJGapuPtYJwM57khQzTW437W/2hLHRlj+tOW/fWk6Ct5Q7T5dtsl+FU",
+	"VWBnkKkB13UKXeguX0zV7TvNYd7UFjdyy1J2g27nxSpgBdw8ctkMqCtThm+7lU39hmZwedPSH6czqBd7",
+	"955ubB+LpfVNXp/mBlXDK/crgxZdyI7amiTc29mCuOsPd70tsB4Y1Cp3GU6sGc37IWeQ9WKYszuuValM",
+	"S7pqrQf5OYJeq6Pz3c7L1uz3p9oKezSWFNd6Wb5MIF1S0Ui3xoMRN9kipTiDL/ymgEtIUAVZNHOlaTO7",
+	"btm1Lq/d23JuS5fV3mplsE89LC4H2rogT7ZbcVJ2B7IyKDMt9nEGXaAvatYoV2xtJq5VUYrZfFX1HrMs",
+	"A8oCO3rc5NzyfScz3D5MlCfTExeZgVlveJxSW6Btc5GZwSfE5rx209oNphYzMrvVujXMttqqPNLXL62b",
+	"dcdgteZEMzai0qGXFbpX7exKvKAa3SW4zz9UZlDIyPWGmWErN4Wc9mDUBKX6pGCotORea7TUaY+jXzyw",
+	"rkkLprpsGc11Zon4G6E63JVku7bVZhB5N4ZTVzZD9WVYrEibF3BfqdZ7Nx2r8EILwsNNS886rXunCld9",
+	"lmZfJtZuBPnslM3ct9eKgGbQnT613Jd5zr5fuTe73aCkDr3mcPC0ZvXOA560C52NJ2fuB+Xx7qGv5LzH",
+	"LN0VKty9Wlgv9A6HZrA5NtmsXLhf6iX1QWWKbn+4kxrmS2ZdGEH5vjh0bmC1PV/ARVvOVVrFBc40LKxD",
+	"ccut8rrkzGA9S0+NF/nBBJOsWzfv54qemVhOw1jVLLGeH3CbimPaVY7V2cwM+o4w3+ESnOPolsQGZtJm",
+	"pGZBBCDuYwm7wcYVuibZFUi27VhroKTSKQVA3f/BBpBs2VI/EjriNxg4UDIiu40PbpmDbdQzgIpt6RA/",
+	"24610A1/83A22mlb1zES2x0aIFtfLMCzriQ2Izu9ZwcsHIC067aFEsbAtDGFLWoBsKxFghNpSpoTmVI6",
+	"2XhTnoQoCNbACRoCJaqVNzeKMarAPpSUFIf6HGXIlWUAFKAkDf4l28wkNaYvAiFpl1kHCnD84F7DsVz7",
+	"o7E2B0jHSMB1WlGCKOOzHMyc98QSn2CnccQELOrKe10OhwIXhPjMOXB8RnQMTPSJWEg4uuQ40pb8fo2i",
+	"T0S+78q1lQ/K8gQgupIKCTgX8pHZdFRpsVGvgEcnZO8YXLAlB8Og37cDoMViOmWTueXA1PfU//1Dut0x",
+	"t0/0bfXu+fbHzX8lIeU4+NG8fgCcDjD8j5Gm29cruRf5Kha/TNC2Cw9hymDOXT/M8PBpEJ867/5Eu3Fu",
+	"EsdOUmBTctYAYfDRKPoikP2x92fbMoxnHWLgrCXj3JDW9x+EthJR5ANq/0E0BnqIAM63vnE90nhVIFOH",
+	"CEtQ3i9GcTIEBUASEwMo3jW1/4qSTAuq8ZdovwBEA6wXlyEEALy43IS293OhyWgPrm1Y0sdirMEn+zcH",
+	"7k4YCZgI2gKFkj4afk0gEh2mZ5zQBxfL1lu0pikL+q+/SbIMbAyUb2nqG8KSAb4FUXqJgsA7xIh9LUiG",
+	"AyRle2RhvqUkaGEtouU0ZTnUNwcs/Rny7SIfRJdXOiwu1gi0ZB/jsmQY7wn6DDHXyRfpBoBJFMUyF0dg",
+	"x4fxGT+kEYK+KMOCKnBI+D34GGvEJbIMJTGfgSzXkcGzpCgOQAla7QHTwoAK38eERMSSyJOtO2APmncM",
+	"Sf9R6PF7ff8qG4KtFYDPYEPoQokA4Mm77Z671nhAuQgolAXPuL1OrT8j87IGDgrdnjixo+DFm1J523lI",
+	"8i5DJCYtKy1LhwMiw3NayCvKl+9eeERA1Myl6bxsO2Chb/yfwV3wCAHZATh4lCYAdwBlQSNgIGhP6XGr",
+	"7vcey3HkF1UpK5dBcZ5TClKJvlu1KuYot+mW7TaNOgXcK3nT6o7Nylwe1Itqs6y3qqt21uzkrSRtHfgT",
+	"4ML6Aqc4jsTrvvmAoyttnt0wyR+dHPlc4uQIDYCsKwkbpg7AnuWsfD1YcG5Jjp+FJFgCCCPKJCuob/UX",
+	"jmVGTaIEt4m24dLicVgZsL4GZF0gEzBcFnRMSYZheYiCFmVaTpQWFBj/QKTJtj+Y/gG4Eli04G2Ayj3+",
+	"9u4B+YxgVJe1KCZ1fEjTKjqSDQsFiVlEBd38KnvgIvAsWy7EVyk2abuQNO1jwjnoJ4Ki6MAx6Mb2Fm+a",
+	"jV5Y9PHBqXU95PHeOL3V+EDOuTn0nyax8CipoOOSvVTCEq0BCvrvgqIBYCISRkAr3abmYEFgirDkYIIO",
+	"bFGyZRhADlL7DkCugSkE8F0qUpySWJpCSOjruzAtHNbs5Oj0RWpQjBwHYNeBd7GKGDpaEJM4posTIlS9",
+	"YNZ/NFBlWRhhR7JDjyDRB6jX+ZMKiVj1gw6pVv+hE+a70xTClkO8WRQJ1yR+6EKsG77bqaN9ROmOGkIF",
+	"ONQ3DWMbhbGub5Qe9/piXe+DK2lKggrpao8eyoUGQOh84L3bSpyziNP9bmguEsZ7S0j79lTY/uhaB0x5",
+	"YP7Nt4sxHq8hIIwNxgdvDgaPfWrYa++FfIGaBBXEFm6fmu+ZjO8h3oXP7yxH/V4pFPKJS2ViSDJRNAIX",
+	"L3s6koWAsyYO+0FrocN0goC97KMUB+++ZzIRYgPyM0Gv7/pafz1q9+jik8iM6Id3PjcfvyBUeR0TnyP/",
+	"JwcUj5oeREB72OeEAS/KclQJ6jufePSZ8r+/ECw8EfdbEo6Gsz4pXt0B8l4410fO9h+dujrSZ52dWC/z",
+	"n49T6eD/JAx0QbqRAYJYzmdF/JloEdJVKGHXeVcM/UPD8Csdqs9yvLzszRkTaXpQxHHpflcD+wjnifRD",
+	"rk86fF/Sn7USV8zyQ4WiA/xdC5nwZPnHW2ry6+b4ezC9iMDojPtLm9SjNHJ0LntLZ2/z9ICufM/T32n6",
+	"6VKMI8p8NoH3v245PuDvx6fyZ9eCk24+Pf7PsXE/hYv5Z7mYf3pbaiu/GFtJW9lLaa0kpSaJ6CKGLqrl",
+	"vSnJRfF/2Kil5vphYkhG6nTXNvaDCzjJ9YimgCgHyEBfg/Ptju74VdV31FjHGiUFVcjoeZ4Oo2pRc8fs",
+	"uznbNkVbsWSvuK9rOKX+OADxYfe/SIk1Dhdza59P7H16il672p5kAGNI+Ewfb6T1CC9JmAp2MwIXn02H",
+	"fUiUpgyJzfk5mUN4ynl/4SpUEpTVj7oXb540OTgibxwykRqlp0leelrclLCa2fa4J6XX72AxXzV2T+PO",
+	"9mnSaz1x2dZ0nB0cfq89LZVJa/s0LtKjhoGfRh16Os56jwM+29nxW3Ew9B4GQ/NponnSpGX4bQb05oFT",
+	"c52BnBW5VbYFW9rc7K3nA3orLpmcuBz+O2lXGXVULm0n/Tb7SRIPQMR4/XOWWnr4mcQOLEcnqJ2lvv/x",
+	"5ywSKpulvs9S2VKlUMyW8oX8LJWepVZg+6wr/htGGTzJtFzeoWpJLqnr7qbFlroKX+K2fbezWPvtbXdu",
+	"6PLzCmz9b8T6yuO9aZPU6e2WdI0hNb7BzxzTlbmuyvCb7ONTz1vwee4JPbzkRJZ+KD6OF3O0cyS70VmY",
+	"Rb6eyVrepAgFrmMuB/o809kuyjVQW/fbMi/n6aktzdfMXG03KzLKadwuy/z737PUa/oSf5XsOX8LdSRx",
+	"sjRgptJu1ciNF9X8GDc2Zk+ZLBi6w36WP4frL3XZgS/9IeRzW5BtWe6C5RrtORbEZas+atyD5gO+HxTd",
+	"F4PN3A8qnVy+OEFoog7a3Z6o7WyGk0WxMMxMDXltbVfNoqn6/P1Iz1L7WiJNhwGHtE8oIg4pSUgH0Tb/",
+	"Tdl/E52a/mOsZGep14sA/FTd15e4cl/iPUfqQ/6b+tcfQX2IdLv7Qf3rf/6VWCKi7RNc8WjQm7uhvTX9",
+	"kH/5SVfomJX4zIbpd7lS4Ub4I4VCZzvhD4JYUkwdPpsSlFSQENAba8DP70e8FJL3RgAH2X/K/z5NrHKQ",
+	"nZElFE2SB7UCiJIc4kYtXASSc99/5UjZpxT8U9JAH64c81PJwZZet+DF+o1ekJQIV/XoJydx1YMHkKa+",
+	"Bc2AEkSbSe7DTszCXZGAjsk1fQwYxMGSzMxHEHte1uaPfBdQcydb5id9KN96xDrOL6RKcVEq3BbL2fJt",
+	"oVjK3c7zC/k2J1dL+UWpJC2kUnQw19WV+FD5UryQzjeRix9/Vl5vDz8Xrvg5m3tNNKlnlWlfEcs6lpv9",
+	"ghOqX1WgcQWi3+IzAvAoA+fYffWL4BbW/qC+JPtsBjSnGjrWXLIZ8/M2hwSL6j8mWM40gWcAjB8leSU5",
+	"SkaVDElxdGCcLfSpxv4V1fdTG5TozzmTrO7k7D6ygXyYeWSXaOgyCNO6ITmMLckaoHJ3dIyk75mM53l3",
+	"kv/WT6GEn6JMW6jxnT5/m7uj7zRs+mRhHRsgiSCGmAKfllvqwQaQ/JT3xzoUy6Syd/RdNusvxzaAkq2T",
+	"eXhH35H0ki1hzcdtZnGItN+qJNTuP1WBL1rLDl8JSup7qq2j08wF8pUWuUshR9MfukfhqqLRk0ETakbP",
+	"NNgnNeoIkQsLDkxEqrAvjXjgJbO/FIJ0jVzTlJxtKAJSgUEdpUYFUvPzoUEY4lhyjCUVkSlxZIAKOPhB",
+	"Dqa7CTI+Tw6lgtkFEGYtZfvTLqm4nIV6jU9o7Ljg9S9q+UPK/TJl1nw/j5LOlOkX0gTLqL/aR6jx1RyO",
+	"S4UBMeIUSJACGx35JQ4WBHfUQANn7XwhAb8Y4TSuhajQ6yQba/+tT4lfTER+hZQF3wbUazphLmf+VPfp",
+	"s9fAzzFAkPiIw47zn58jz5YcyQTYPy3wx4XS54PYqMNZf79AHmt7l/p76kBF6hRa6c/BJFx5fiQD80vg",
+	"E8gsAT6B8q/RLnHLA5UcMvEOkC0o6wbJ0oclFfsi3+AbhPWj/QkCmLoTD5y+Z3kSrXsD4H+M/v/mhqkB",
+	"cAKsgoobjK5bX941B5nIMaArl6Kg2OLvAouvWDPj5Sf/36ycjEIOUMSSJdh6wxLuTVpoBpEP47iBJIWk",
+	"QXGtb+t+LsYzf+L4puXji+HfBvvphAMUCFMB72/QcyKhT1N1vj38ZxppchxkDU6nge+vXTERAuxdPxGu",
+	"dwbMj8yZzDEEFjoFJ3i2HIqcsdnGiKT2h7yJ0zK38EneNk1hYBiIVKz7pAJJ1iikK+Caq8b8VY54SZrl",
+	"+Qd4KB37taOGpKqkZFUgGzBkUYaOcDR9iygNGOcnQFAQnYdWnIOli4IidrJ7f8MR6u+jal+A4HCsr/Uz",
+	"/P1NJFNLBYiIBzoPvx4Uf3osMwFxITsB5A4Ji7fDC0e1vWdpTy+Y2x9EIIeT0H4aHrqjpAX5b3iCAfs1",
+	"0AGgKAmFx8X2ZvHFBc72aBeDl4PI0bGjXg/yL5E0ztlJhLdKy19/fEXk5CCA3x0zST6U6uOKhFNO7Iev",
+	"Gz08rmpBEE5iTVqDiwf8pOPxvjsqXsK6x4YEt4knYx3gWxP/mJsMwuFPoLEH+FGiAbCXlg5v/WMTbyP7",
+	"cObiXWQ/kHIVYy+05eFYWTgJdURFV/QLqD1fzX/X6n0VTuPnzv4DsBoRe9o/ioswtdAdhIOVPFQIWW4R",
+	"MEgdEQERtHB4ygQo6cNhPlKYtD9UtEcR4ZcaBIdtTmGU+XN5kAb3pqPaA2trBQ6yew9Yx2MBR+6SfcEI",
+	"AZ/2A4MM8m+N0ATyoaQIv2kKWQeP4GgLZAnuT9IFDpjuWwvTcsAlrV0OoxwUwm4F7u+klJ/iz5xM5S93",
+	"ZhCWsO9RSnGJXpx6Z/dlXNJr78TneVOv0V73PlXolcQ2ZDZwSDaJnPVMX7DlcqzO9VpVn1THXtgXftEi",
+	"8onB7f1pv2tHPRwP9Ie71GV4hvEjnYaffNFSGEXZb1wJyXS65OTHJ8JbuawYM78sKJd02OeLY3FxrX19",
+	"CuukRtqXATUH2AMAUtizYkbnLV2eWcTMn9FfQ4/kGhP5sdXvBCkJ61+cjP/kJfA3gSFIG+xrE2KQeEfh",
+	"vqG/VQ5VmBd3MRGz/UuDIpFxfkd2/1I2LbbxesvuxZe3X2T2Es6GvYZmL6aW7FepJbBGyk9LMBygfHKM",
+	"+oI6TpH8mah/tJ5zGzo9b8eh/kOj61+YAkcfV9XlzdM/RgF/Yzt4spBcq9IrjOHfSaU/32afaPP1Hwec",
+	"YVAz9QssdyZ6Zcq1BnxfQP2/BvydGiY/taOYOrwNi8sjibl4vOIYP9MxOgmghfcwktCZGld3qIlr7f7f",
+	"Wm8/e/oebhH4+kCa5byBhGT1XrMG/I3U+6v99vjtGV8cs/idIOuHILuErmPOLnrPKLhT7/ZHtxWKvLYW",
+	"1FyCSpCVCTvTEbWWDN0/BUMZ+grEMtnR61MjVQLhdVZxKxitvvUt37snq05nxBUrGwjvF3t/bUu6K/9/",
+	"V7c3QIYtO7jrbJ9LjsCNFGwH4ow5KQGS4rZPR9QK2Pgz25l/kMp+is1JksfXL22n16LJFlzoqhuM45uC",
+	"8MhzpBrmEmA+syP626Lil6yHb1yw+It3Sb8XjbUQdWdFhBI6QC1N6XfgLmk1DA1VcBzMN3JhOWEIbhs4",
+	"uqXopB5qGyxk5GXydYV/aWd2LF14KzNwdZVCDOid3wD0dFKp2S22btvk3pn/Hgza/xMtPNuf6DhP359m",
+	"U7HxJpUHNOUvVJPtby2tlAo0/fZlqedMdA73sx59mMjdwScshLcM31F9HaoGuHVReAd7cK3xBQ5NaTMM",
+	"Lso9cnWZjSxNv3P96mv60i3MOqRqAtejoIUDm33dtcx3FAO3FAw7eZ+h4Ebomq7EE8bHY81Z+s7/N1NJ",
+	"OOj9JVUWhxuFvzo8GAELmQKRmE90BkfKpYgb5ddEhXdFA4Wo0S9o24+YpvDRpvk+mB9blgziyWsS0vaV",
+	"wsf7pROrOgi5fieBgYmfCDYsWTI0C+E75JE6P+dOtzKSrWfWeXLdyb7LU+gxVOw6nMOqHUIl9vQcuEw8",
+	"Q6uj8LBXeGfI4XIBaT9KpJA2mrF7M6cbkhJtjxJoGRwupr20+7o7dna4suH8koRz2iOYmFsuVIJTIRd6",
+	"jqgsmcaIoToPQ/kV4Qj7f/xhX/R9eko6Mli0EPZcN0ExvbWISzSIc5ENXuTELwgLxPyidr8UPzLKWb39",
+	"+WDRW7EOfyjg9G/I+Oxd/KMGKPxrDLoT/SvTKJmOff3Pj9f/NwAlbid8BH8AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,8 +2,12 @@ package admin
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/syncstatus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
@@ -18,9 +22,18 @@ func (r *PutRelationshipRequest) ToEntity() (*entity.Relationship, error) {
 		return nil, fmt.Errorf("malformed trust domain[%q]: %v", r.TrustDomainBName, err)
 	}
 
+	direction := entity.RelationshipBidirectional
+	if r.Direction != nil {
+		direction, err = entity.ParseRelationshipDirection(string(*r.Direction))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &entity.Relationship{
 		TrustDomainAName: tdA,
 		TrustDomainBName: tdB,
+		Direction:        direction,
 	}, nil
 }
 
@@ -40,3 +53,247 @@ func (td *PutTrustDomainRequest) ToEntity() (*entity.TrustDomain, error) {
 		Description: description,
 	}, nil
 }
+
+// HarvesterFromEntity maps the Harvester of the given trust domain to its API representation.
+// The harvester is nil when the trust domain has never been seen by the server.
+func HarvesterFromEntity(td spiffeid.TrustDomain, h *entity.Harvester, silent bool) *Harvester {
+	harvester := &Harvester{
+		TrustDomainName: td.String(),
+		Silent:          silent,
+	}
+	if h == nil {
+		return harvester
+	}
+
+	harvester.InstanceId = &h.InstanceID
+	harvester.LastSeenAt = &h.LastSeenAt
+	harvester.SourceAddress = &h.SourceAddress
+	if h.Version != "" {
+		harvester.Version = &h.Version
+	}
+	if h.SpireBundlePollInterval > 0 {
+		seconds := int64(h.SpireBundlePollInterval / time.Second)
+		harvester.SpireBundlePollInterval = &seconds
+	}
+	if h.FederatedBundlesPollInterval > 0 {
+		seconds := int64(h.FederatedBundlesPollInterval / time.Second)
+		harvester.FederatedBundlesPollInterval = &seconds
+	}
+	if !h.TokenExpiresAt.IsZero() {
+		harvester.TokenExpiresAt = &h.TokenExpiresAt
+	}
+	if !h.LastBundleUploadAt.IsZero() {
+		digest := encoding.EncodeToBase64(h.LastBundleDigest)
+		status := string(h.LastBundleUploadStatus)
+		harvester.LastBundleUploadAt = &h.LastBundleUploadAt
+		harvester.LastBundleDigest = &digest
+		harvester.LastBundleUploadStatus = &status
+	}
+
+	return harvester
+}
+
+// FederationStatusFromSyncStatus maps the distribution status of the federated bundles to its API representation.
+func FederationStatusFromSyncStatus(s *syncstatus.Status) *FederationStatus {
+	status := &FederationStatus{
+		Relationships:     make([]RelationshipDistribution, 0, len(s.Relationships)),
+		UnexpectedBundles: make([]UnexpectedBundle, 0, len(s.UnexpectedBundles)),
+	}
+
+	for _, r := range s.Relationships {
+		status.Relationships = append(status.Relationships, RelationshipDistribution{
+			RelationshipId: r.RelationshipID,
+			TrustDomainA:   bundleDistributionFromSyncStatus(r.TrustDomainA),
+			TrustDomainB:   bundleDistributionFromSyncStatus(r.TrustDomainB),
+		})
+	}
+
+	for _, b := range s.UnexpectedBundles {
+		status.UnexpectedBundles = append(status.UnexpectedBundles, UnexpectedBundle{
+			TrustDomainName:          b.TrustDomain.String(),
+			FederatedTrustDomainName: b.FederatedTrustDomain.String(),
+			Digest:                   encoding.EncodeToBase64(b.Digest),
+			ReportedAt:               b.ReportedAt,
+		})
+	}
+
+	return status
+}
+
+func bundleDistributionFromSyncStatus(d *syncstatus.Distribution) *BundleDistribution {
+	if d == nil {
+		return nil
+	}
+
+	distribution := &BundleDistribution{
+		TrustDomainName:     d.TrustDomain.String(),
+		PeerTrustDomainName: d.PeerTrustDomain.String(),
+		InSync:              d.InSync,
+		LagSeconds:          int64(d.Lag / time.Second),
+		ReportedAt:          d.ReportedAt,
+	}
+	if d.ExpectedDigest != nil {
+		digest := encoding.EncodeToBase64(d.ExpectedDigest)
+		distribution.ExpectedDigest = &digest
+	}
+	if d.ReportedDigest != nil {
+		digest := encoding.EncodeToBase64(d.ReportedDigest)
+		distribution.ReportedDigest = &digest
+	}
+
+	return distribution
+}
+
+// ExternalTrustDomainFromEntity maps the bundle endpoint configuration of the given external trust domain
+// to its API representation.
+func ExternalTrustDomainFromEntity(td spiffeid.TrustDomain, e *entity.ExternalTrustDomain) *ExternalTrustDomain {
+	externalTD := &ExternalTrustDomain{
+		TrustDomainName:       td.String(),
+		BundleEndpointUrl:     e.BundleEndpointURL,
+		BundleEndpointProfile: e.BundleEndpointProfile,
+	}
+	if e.EndpointSPIFFEID != "" {
+		externalTD.EndpointSpiffeId = &e.EndpointSPIFFEID
+	}
+	if !e.LastRefreshAt.IsZero() {
+		externalTD.LastRefreshAt = &e.LastRefreshAt
+	}
+	if e.LastRefreshError != "" {
+		externalTD.LastRefreshError = &e.LastRefreshError
+	}
+
+	return externalTD
+}
+
+// ToEntity maps the bundle set by an admin for the given trust domain to its entity, decoding the base64 encoded
+// digest, signature and signing certificate chain.
+func (b *PutTrustDomainBundleRequest) ToEntity(td spiffeid.TrustDomain) (*entity.Bundle, error) {
+	dig, err := encoding.DecodeFromBase64(b.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode digest: %w", err)
+	}
+
+	var sig []byte
+	if b.Signature != nil {
+		sig, err = encoding.DecodeFromBase64(*b.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode signature: %w", err)
+		}
+	}
+
+	var cert []byte
+	if b.SigningCertificate != nil {
+		cert, err = encoding.DecodeFromBase64(*b.SigningCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode signing certificate: %w", err)
+		}
+	}
+
+	return &entity.Bundle{
+		Data:               []byte(b.TrustBundle),
+		Digest:             dig,
+		Signature:          sig,
+		SigningCertificate: cert,
+		TrustDomainName:    td,
+		AdminManaged:       true,
+	}, nil
+}
+
+// TrustDomainBundleFromEntity maps the stored bundle of the given trust domain to its API representation.
+func TrustDomainBundleFromEntity(td spiffeid.TrustDomain, b *entity.Bundle) *TrustDomainBundle {
+	return &TrustDomainBundle{
+		TrustDomainName:    td.String(),
+		TrustBundle:        string(b.Data),
+		Digest:             encoding.EncodeToBase64(b.Digest),
+		AdminManaged:       b.AdminManaged,
+		VerificationStatus: string(b.VerificationStatus),
+		UpdatedAt:          b.UpdatedAt,
+	}
+}
+
+// ToEntity maps the federation group put by an admin to its entity. The default consent of the group is pending
+// unless set, and can't be denied since members that deny each other have no reason to share a group.
+func (g *PutFederationGroupRequest) ToEntity() (*entity.FederationGroup, error) {
+	if g.Name == "" {
+		return nil, fmt.Errorf("federation group name is required")
+	}
+
+	defaultConsent := entity.ConsentStatusPending
+	if g.DefaultConsent != nil {
+		defaultConsent = entity.ConsentStatus(*g.DefaultConsent)
+	}
+	if defaultConsent != entity.ConsentStatusPending && defaultConsent != entity.ConsentStatusApproved {
+		return nil, fmt.Errorf("invalid default consent %q, must be %q or %q", defaultConsent, entity.ConsentStatusPending, entity.ConsentStatusApproved)
+	}
+
+	description := ""
+	if g.Description != nil {
+		description = *g.Description
+	}
+
+	return &entity.FederationGroup{
+		Name:           g.Name,
+		Description:    description,
+		DefaultConsent: defaultConsent,
+	}, nil
+}
+
+// FederationGroupFromEntity maps the given federation group and its members to its API representation.
+func FederationGroupFromEntity(g *entity.FederationGroup, members []*entity.FederationGroupMember) *FederationGroup {
+	group := &FederationGroup{
+		Id:             g.ID.UUID,
+		Name:           g.Name,
+		DefaultConsent: api.ConsentStatus(g.DefaultConsent),
+		Members:        make([]api.TrustDomainName, 0, len(members)),
+		CreatedAt:      g.CreatedAt,
+		UpdatedAt:      g.UpdatedAt,
+	}
+	if g.Description != "" {
+		group.Description = &g.Description
+	}
+	for _, m := range members {
+		group.Members = append(group.Members, m.TrustDomainName.String())
+	}
+
+	return group
+}
+
+// Join token statuses reported by JoinTokenInfoFromEntity.
+const (
+	JoinTokenStatusActive  = "active"
+	JoinTokenStatusUsed    = "used"
+	JoinTokenStatusExpired = "expired"
+)
+
+// JoinTokenInfoFromEntity maps the given join token of the given trust domain to its API representation, which only
+// discloses the non-secret prefix of the token. The status of the token is evaluated at the given time.
+func JoinTokenInfoFromEntity(td spiffeid.TrustDomain, jt *entity.JoinToken, now time.Time) *JoinTokenInfo {
+	maxUses := jt.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+
+	status := JoinTokenStatusActive
+	switch {
+	case jt.Used:
+		status = JoinTokenStatusUsed
+	case !jt.ExpiresAt.After(now):
+		status = JoinTokenStatusExpired
+	}
+
+	info := &JoinTokenInfo{
+		Id:              jt.ID.UUID,
+		TrustDomainName: td.String(),
+		TokenPrefix:     jt.TokenPrefix,
+		Status:          status,
+		MaxUses:         int32(maxUses),
+		UseCount:        int32(jt.UseCount),
+		ExpiresAt:       jt.ExpiresAt,
+		CreatedAt:       jt.CreatedAt,
+	}
+	if jt.SourceCIDR != "" {
+		info.SourceCidr = &jt.SourceCIDR
+	}
+
+	return info
+}
@@ -2,7 +2,14 @@ package admin
 
 import (
 	"testing"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/syncstatus"
+	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,6 +31,33 @@ func TestRelationshipRequestToEntity(t *testing.T) {
 
 		assert.Equal(t, releationshipRequest.TrustDomainAName, r.TrustDomainAName.String())
 		assert.Equal(t, releationshipRequest.TrustDomainBName, r.TrustDomainBName.String())
+		assert.Equal(t, entity.RelationshipBidirectional, r.Direction)
+	})
+
+	t.Run("Sets the direction of the relationship", func(t *testing.T) {
+		direction := api.ATrustsB
+		releationshipRequest := PutRelationshipRequest{
+			TrustDomainAName: td1,
+			TrustDomainBName: td2,
+			Direction:        &direction,
+		}
+
+		r, err := releationshipRequest.ToEntity()
+		assert.NoError(t, err)
+		assert.Equal(t, entity.RelationshipATrustsB, r.Direction)
+	})
+
+	t.Run("Does not allow unknown directions", func(t *testing.T) {
+		direction := api.RelationshipDirection("sideways")
+		releationshipRequest := PutRelationshipRequest{
+			TrustDomainAName: td1,
+			TrustDomainBName: td2,
+			Direction:        &direction,
+		}
+
+		r, err := releationshipRequest.ToEntity()
+		assert.ErrorContains(t, err, "invalid relationship direction")
+		assert.Nil(t, r)
 	})
 }
 
@@ -57,3 +91,201 @@ func TestTrustDomainPutToEntity(t *testing.T) {
 		assert.Equal(t, *tdPut.Description, trustDomain.Description)
 	})
 }
+
+func TestHarvesterFromEntity(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString(td1)
+
+	t.Run("Trust domain never seen", func(t *testing.T) {
+		harvester := HarvesterFromEntity(td, nil, true)
+		assert.Equal(t, &Harvester{TrustDomainName: td1, Silent: true}, harvester)
+	})
+
+	t.Run("Full fill correctly the harvester model", func(t *testing.T) {
+		now := time.Now()
+		h := &entity.Harvester{
+			InstanceID:                   "spire-server-1",
+			LastSeenAt:                   now,
+			SourceAddress:                "10.0.0.1:4321",
+			Version:                      "0.1.0",
+			SpireBundlePollInterval:      10 * time.Second,
+			FederatedBundlesPollInterval: time.Minute,
+			TokenExpiresAt:               now.Add(time.Hour),
+			LastBundleUploadAt:           now,
+			LastBundleDigest:             []byte("digest"),
+			LastBundleUploadStatus:       entity.BundleUploadStale,
+		}
+
+		harvester := HarvesterFromEntity(td, h, false)
+		assert.Equal(t, td1, harvester.TrustDomainName)
+		assert.Equal(t, "spire-server-1", *harvester.InstanceId)
+		assert.False(t, harvester.Silent)
+		assert.Equal(t, now, *harvester.LastSeenAt)
+		assert.Equal(t, "10.0.0.1:4321", *harvester.SourceAddress)
+		assert.Equal(t, "0.1.0", *harvester.Version)
+		assert.Equal(t, int64(10), *harvester.SpireBundlePollInterval)
+		assert.Equal(t, int64(60), *harvester.FederatedBundlesPollInterval)
+		assert.Equal(t, now.Add(time.Hour), *harvester.TokenExpiresAt)
+		assert.Equal(t, now, *harvester.LastBundleUploadAt)
+		assert.Equal(t, encoding.EncodeToBase64([]byte("digest")), *harvester.LastBundleDigest)
+		assert.Equal(t, "stale", *harvester.LastBundleUploadStatus)
+	})
+
+	t.Run("Harvester that never uploaded a bundle", func(t *testing.T) {
+		harvester := HarvesterFromEntity(td, &entity.Harvester{InstanceID: "default", LastSeenAt: time.Now()}, false)
+		assert.Nil(t, harvester.LastBundleUploadAt)
+		assert.Nil(t, harvester.LastBundleDigest)
+		assert.Nil(t, harvester.LastBundleUploadStatus)
+	})
+}
+
+func TestFederationStatusFromSyncStatus(t *testing.T) {
+	now := time.Now()
+	relationshipID := uuid.New()
+	tdA := spiffeid.RequireTrustDomainFromString(td1)
+	tdB := spiffeid.RequireTrustDomainFromString(td2)
+
+	status := FederationStatusFromSyncStatus(&syncstatus.Status{
+		Relationships: []*syncstatus.RelationshipStatus{
+			{
+				RelationshipID: relationshipID,
+				TrustDomainA: &syncstatus.Distribution{
+					TrustDomain:     tdA,
+					PeerTrustDomain: tdB,
+					ExpectedDigest:  []byte("digest"),
+					ReportedDigest:  []byte("digest"),
+					ReportedAt:      &now,
+					InSync:          true,
+				},
+				TrustDomainB: &syncstatus.Distribution{
+					TrustDomain:     tdB,
+					PeerTrustDomain: tdA,
+					ExpectedDigest:  []byte("digest"),
+					Lag:             90 * time.Second,
+				},
+			},
+		},
+		UnexpectedBundles: []*syncstatus.UnexpectedBundle{
+			{TrustDomain: tdA, FederatedTrustDomain: spiffeid.RequireTrustDomainFromString("other.org"), Digest: []byte("digest"), ReportedAt: now},
+		},
+	})
+
+	digest := "ZGlnZXN0"
+	assert.Equal(t, &FederationStatus{
+		Relationships: []RelationshipDistribution{
+			{
+				RelationshipId: relationshipID,
+				TrustDomainA: &BundleDistribution{
+					TrustDomainName:     td1,
+					PeerTrustDomainName: td2,
+					ExpectedDigest:      &digest,
+					ReportedDigest:      &digest,
+					ReportedAt:          &now,
+					InSync:              true,
+				},
+				TrustDomainB: &BundleDistribution{
+					TrustDomainName:     td2,
+					PeerTrustDomainName: td1,
+					ExpectedDigest:      &digest,
+					LagSeconds:          90,
+				},
+			},
+		},
+		UnexpectedBundles: []UnexpectedBundle{
+			{TrustDomainName: td1, FederatedTrustDomainName: "other.org", Digest: digest, ReportedAt: now},
+		},
+	}, status)
+}
+
+func TestFederationGroupRequestToEntity(t *testing.T) {
+	t.Run("Defaults to a pending consent", func(t *testing.T) {
+		req := PutFederationGroupRequest{Name: "partners"}
+
+		g, err := req.ToEntity()
+		assert.NoError(t, err)
+		assert.Equal(t, "partners", g.Name)
+		assert.Empty(t, g.Description)
+		assert.Equal(t, entity.ConsentStatusPending, g.DefaultConsent)
+	})
+
+	t.Run("Sets the description and default consent", func(t *testing.T) {
+		description := "partner organizations"
+		consent := api.Approved
+		req := PutFederationGroupRequest{Name: "partners", Description: &description, DefaultConsent: &consent}
+
+		g, err := req.ToEntity()
+		assert.NoError(t, err)
+		assert.Equal(t, description, g.Description)
+		assert.Equal(t, entity.ConsentStatusApproved, g.DefaultConsent)
+	})
+
+	t.Run("Does not allow a denied default consent", func(t *testing.T) {
+		consent := api.Denied
+		req := PutFederationGroupRequest{Name: "partners", DefaultConsent: &consent}
+
+		g, err := req.ToEntity()
+		assert.ErrorContains(t, err, "invalid default consent")
+		assert.Nil(t, g)
+	})
+}
+
+func TestFederationGroupFromEntity(t *testing.T) {
+	groupID := uuid.New()
+	now := time.Now()
+	group := &entity.FederationGroup{
+		ID:             uuid.NullUUID{UUID: groupID, Valid: true},
+		Name:           "partners",
+		DefaultConsent: entity.ConsentStatusApproved,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	members := []*entity.FederationGroupMember{
+		{FederationGroupID: groupID, TrustDomainName: spiffeid.RequireTrustDomainFromString(td1)},
+		{FederationGroupID: groupID, TrustDomainName: spiffeid.RequireTrustDomainFromString(td2)},
+	}
+
+	g := FederationGroupFromEntity(group, members)
+	assert.Equal(t, groupID, g.Id)
+	assert.Equal(t, "partners", g.Name)
+	assert.Nil(t, g.Description)
+	assert.Equal(t, api.Approved, g.DefaultConsent)
+	assert.Equal(t, []string{td1, td2}, g.Members)
+	assert.Equal(t, now, g.CreatedAt)
+
+	g = FederationGroupFromEntity(group, nil)
+	assert.NotNil(t, g.Members)
+	assert.Empty(t, g.Members)
+}
+
+func TestJoinTokenInfoFromEntity(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString(td1)
+	now := time.Now()
+	jt := &entity.JoinToken{
+		ID:          uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TokenPrefix: "5a8c3f4e0d2b4c1a",
+		TokenHash:   []byte("hash"),
+		ExpiresAt:   now.Add(time.Hour),
+		CreatedAt:   now,
+	}
+
+	info := JoinTokenInfoFromEntity(td, jt, now)
+	assert.Equal(t, jt.ID.UUID, info.Id)
+	assert.Equal(t, td1, info.TrustDomainName)
+	assert.Equal(t, "5a8c3f4e0d2b4c1a", info.TokenPrefix)
+	assert.Equal(t, JoinTokenStatusActive, info.Status)
+	assert.Equal(t, int32(1), info.MaxUses)
+	assert.Equal(t, int32(0), info.UseCount)
+	assert.Nil(t, info.SourceCidr)
+
+	jt.MaxUses = 5
+	jt.UseCount = 2
+	jt.SourceCIDR = "10.0.0.0/8"
+	info = JoinTokenInfoFromEntity(td, jt, now.Add(2*time.Hour))
+	assert.Equal(t, JoinTokenStatusExpired, info.Status)
+	assert.Equal(t, int32(5), info.MaxUses)
+	assert.Equal(t, int32(2), info.UseCount)
+	assert.Equal(t, "10.0.0.0/8", *info.SourceCidr)
+
+	jt.Used = true
+	info = JoinTokenInfoFromEntity(td, jt, now)
+	assert.Equal(t, JoinTokenStatusUsed, info.Status)
+}
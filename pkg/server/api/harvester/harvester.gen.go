@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	externalRef0 "github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
@@ -26,6 +27,24 @@ const (
 	Harvester_authScopes = "harvester_auth.Scopes"
 )
 
+// BundlePolicyViolation defines model for BundlePolicyViolation.
+type BundlePolicyViolation struct {
+	// Authority Offending authority, e.g. x509_authorities[0] or jwt_authorities[kid]. Not set when the violation concerns the whole bundle
+	Authority *string `json:"authority,omitempty"`
+	Message   string  `json:"message"`
+
+	// Rule Policy rule that the bundle does not comply with: format, bundle_size, trust_domain, sequence_number, authority_count, authority_size, validity, key_algorithm or key_size
+	Rule string `json:"rule"`
+}
+
+// BundleValidationReport Result of the validation of an uploaded bundle against the bundle validation policy
+type BundleValidationReport struct {
+	SequenceNumber *int64                       `json:"sequence_number,omitempty"`
+	TrustDomain    externalRef0.TrustDomainName `json:"trust_domain"`
+	Valid          bool                         `json:"valid"`
+	Violations     []BundlePolicyViolation      `json:"violations"`
+}
+
 // BundlesDigests defines model for BundlesDigests.
 type BundlesDigests map[string]externalRef0.BundleDigest
 
@@ -47,6 +66,25 @@ type BundlesUpdatesItem struct {
 	TrustBundle externalRef0.TrustBundle `json:"trust_bundle"`
 }
 
+// ClientCertificateResponse defines model for ClientCertificateResponse.
+type ClientCertificateResponse struct {
+	// CertificateChain PEM encoded client certificate followed by the intermediate certificates, if any
+	CertificateChain string    `json:"certificate_chain"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// ConsentSignature Consent statement signed by the trust domain
+type ConsentSignature struct {
+	// Signature base64 encoded signature of the bundle
+	Signature externalRef0.Signature `json:"signature"`
+
+	// SigningCertificate base64 encoded DER certificate chain of the signing key, leaf first
+	SigningCertificate string `json:"signing_certificate"`
+
+	// Statement base64 encoded JSON consent statement, as signed
+	Statement string `json:"statement"`
+}
+
 // GetJwtResponse defines model for GetJwtResponse.
 type GetJwtResponse struct {
 	Token externalRef0.JWT `json:"token"`
@@ -55,8 +93,18 @@ type GetJwtResponse struct {
 // GetRelationshipResponse defines model for GetRelationshipResponse.
 type GetRelationshipResponse = []externalRef0.Relationship
 
+// HarvesterInstanceID defines model for HarvesterInstanceID.
+type HarvesterInstanceID = string
+
+// IssueClientCertificateRequest defines model for IssueClientCertificateRequest.
+type IssueClientCertificateRequest struct {
+	// Csr PEM encoded certificate signing request
+	Csr string `json:"csr"`
+}
+
 // OnboardHarvesterResponse defines model for OnboardHarvesterResponse.
 type OnboardHarvesterResponse struct {
+	InstanceID      HarvesterInstanceID          `json:"instanceID"`
 	Token           externalRef0.JWT             `json:"token"`
 	TrustDomainID   externalRef0.UUID            `json:"trustDomainID"`
 	TrustDomainName externalRef0.TrustDomainName `json:"trustDomainName"`
@@ -64,7 +112,9 @@ type OnboardHarvesterResponse struct {
 
 // PatchRelationshipRequest defines model for PatchRelationshipRequest.
 type PatchRelationshipRequest struct {
-	ConsentStatus externalRef0.ConsentStatus `json:"consent_status"`
+	// ConsentSignature Consent statement signed by the trust domain
+	ConsentSignature *ConsentSignature          `json:"consent_signature,omitempty"`
+	ConsentStatus    externalRef0.ConsentStatus `json:"consent_status"`
 }
 
 // PostBundleSyncRequest defines model for PostBundleSyncRequest.
@@ -94,6 +144,31 @@ type PutBundleRequest struct {
 	TrustDomain externalRef0.TrustDomainName `json:"trust_domain"`
 }
 
+// RelationshipConsent defines model for RelationshipConsent.
+type RelationshipConsent struct {
+	ConsentStatus externalRef0.ConsentStatus `json:"consent_status"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature externalRef0.Signature `json:"signature"`
+
+	// SigningCertificate base64 encoded DER certificate chain of the signing key, leaf first
+	SigningCertificate string `json:"signing_certificate"`
+
+	// Statement base64 encoded JSON consent statement, as signed
+	Statement       string                       `json:"statement"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+	UpdatedAt       time.Time                    `json:"updated_at"`
+}
+
+// RelationshipConsents defines model for RelationshipConsents.
+type RelationshipConsents = []RelationshipConsent
+
+// ServerTrustBundle defines model for ServerTrustBundle.
+type ServerTrustBundle struct {
+	// TrustBundle PEM encoded CA certificates of the Galadriel Server
+	TrustBundle string `json:"trust_bundle"`
+}
+
 // Default defines model for Default.
 type Default = externalRef0.ApiError
 
@@ -101,6 +176,15 @@ type Default = externalRef0.ApiError
 type OnboardParams struct {
 	// JoinToken Join token to be used for onboarding
 	JoinToken string `form:"joinToken" json:"joinToken"`
+
+	// InstanceId Identifies the harvester instance among the harvesters of the Trust Domain, one per SPIRE Server. Harvesters that do not provide one are identified as the 'default' instance.
+	InstanceId *HarvesterInstanceID `form:"instanceId,omitempty" json:"instanceId,omitempty"`
+}
+
+// OnboardWithSVIDParams defines parameters for OnboardWithSVID.
+type OnboardWithSVIDParams struct {
+	// InstanceId Identifies the harvester instance among the harvesters of the Trust Domain, one per SPIRE Server. Harvesters that do not provide one are identified as the 'default' instance.
+	InstanceId *HarvesterInstanceID `form:"instanceId,omitempty" json:"instanceId,omitempty"`
 }
 
 // GetRelationshipsParams defines parameters for GetRelationships.
@@ -120,6 +204,9 @@ type BundlePutJSONRequestBody = PutBundleRequest
 // BundleSyncJSONRequestBody defines body for BundleSync for application/json ContentType.
 type BundleSyncJSONRequestBody = PostBundleSyncRequest
 
+// IssueClientCertificateJSONRequestBody defines body for IssueClientCertificate for application/json ContentType.
+type IssueClientCertificateJSONRequestBody = IssueClientCertificateRequest
+
 // PatchRelationshipJSONRequestBody defines body for PatchRelationship for application/json ContentType.
 type PatchRelationshipJSONRequestBody = PatchRelationshipRequest
 
@@ -206,19 +293,36 @@ type ClientInterface interface {
 
 	BundleSync(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body BundleSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// IssueClientCertificate request with any body
+	IssueClientCertificateWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	IssueClientCertificate(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetNewJWTToken request
 	GetNewJWTToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Onboard request
 	Onboard(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// OnboardWithSVID request
+	OnboardWithSVID(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardWithSVIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRelationships request
 	GetRelationships(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetRelationshipByID request
+	GetRelationshipByID(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PatchRelationship request with any body
 	PatchRelationshipWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PatchRelationship(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsents(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetServerTrustBundle request
+	GetServerTrustBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) BundlePutWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -269,6 +373,30 @@ func (c *Client) BundleSync(ctx context.Context, trustDomainName externalRef0.Tr
 	return c.Client.Do(req)
 }
 
+func (c *Client) IssueClientCertificateWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueClientCertificateRequestWithBody(c.Server, trustDomainName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) IssueClientCertificate(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueClientCertificateRequest(c.Server, trustDomainName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetNewJWTToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetNewJWTTokenRequest(c.Server, trustDomainName)
 	if err != nil {
@@ -293,6 +421,18 @@ func (c *Client) Onboard(ctx context.Context, trustDomainName externalRef0.Trust
 	return c.Client.Do(req)
 }
 
+func (c *Client) OnboardWithSVID(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardWithSVIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOnboardWithSVIDRequest(c.Server, trustDomainName, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetRelationships(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetRelationshipsRequest(c.Server, trustDomainName, params)
 	if err != nil {
@@ -305,6 +445,18 @@ func (c *Client) GetRelationships(ctx context.Context, trustDomainName externalR
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetRelationshipByID(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipByIDRequest(c.Server, trustDomainName, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PatchRelationshipWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPatchRelationshipRequestWithBody(c.Server, trustDomainName, relationshipID, contentType, body)
 	if err != nil {
@@ -329,6 +481,30 @@ func (c *Client) PatchRelationship(ctx context.Context, trustDomainName external
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetRelationshipConsents(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipConsentsRequest(c.Server, trustDomainName, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetServerTrustBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetServerTrustBundleRequest(c.Server, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewBundlePutRequest calls the generic BundlePut builder with application/json body
 func NewBundlePutRequest(server string, trustDomainName externalRef0.TrustDomainName, body BundlePutJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -423,6 +599,53 @@ func NewBundleSyncRequestWithBody(server string, trustDomainName externalRef0.Tr
 	return req, nil
 }
 
+// NewIssueClientCertificateRequest calls the generic IssueClientCertificate builder with application/json body
+func NewIssueClientCertificateRequest(server string, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewIssueClientCertificateRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewIssueClientCertificateRequestWithBody generates requests for IssueClientCertificate with any type of body
+func NewIssueClientCertificateRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/client-certificate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewGetNewJWTTokenRequest generates requests for GetNewJWTToken
 func NewGetNewJWTTokenRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
 	var err error
@@ -498,6 +721,78 @@ func NewOnboardRequest(server string, trustDomainName externalRef0.TrustDomainNa
 			}
 		}
 
+		if params.InstanceId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "instanceId", runtime.ParamLocationQuery, *params.InstanceId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOnboardWithSVIDRequest generates requests for OnboardWithSVID
+func NewOnboardWithSVIDRequest(server string, trustDomainName externalRef0.TrustDomainName, params *OnboardWithSVIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/onboard/svid", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.InstanceId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "instanceId", runtime.ParamLocationQuery, *params.InstanceId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
@@ -597,6 +892,47 @@ func NewGetRelationshipsRequest(server string, trustDomainName externalRef0.Trus
 	return req, nil
 }
 
+// NewGetRelationshipByIDRequest generates requests for GetRelationshipByID
+func NewGetRelationshipByIDRequest(server string, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/relationships/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewPatchRelationshipRequest calls the generic PatchRelationship builder with application/json body
 func NewPatchRelationshipRequest(server string, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -651,13 +987,88 @@ func NewPatchRelationshipRequestWithBody(server string, trustDomainName external
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewGetRelationshipConsentsRequest generates requests for GetRelationshipConsents
+func NewGetRelationshipConsentsRequest(server string, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/relationships/%s/consents", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetServerTrustBundleRequest generates requests for GetServerTrustBundle
+func NewGetServerTrustBundleRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/server-trust-bundle", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
 		if err := r(ctx, req); err != nil {
 			return err
 		}
@@ -704,24 +1115,43 @@ type ClientWithResponsesInterface interface {
 
 	BundleSyncWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body BundleSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*BundleSyncResponse, error)
 
+	// IssueClientCertificate request with any body
+	IssueClientCertificateWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error)
+
+	IssueClientCertificateWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error)
+
 	// GetNewJWTToken request
 	GetNewJWTTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetNewJWTTokenResponse, error)
 
 	// Onboard request
 	OnboardWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardParams, reqEditors ...RequestEditorFn) (*OnboardResponse, error)
 
+	// OnboardWithSVID request
+	OnboardWithSVIDWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardWithSVIDParams, reqEditors ...RequestEditorFn) (*OnboardWithSVIDResponse, error)
+
 	// GetRelationships request
 	GetRelationshipsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
 
+	// GetRelationshipByID request
+	GetRelationshipByIDWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error)
+
 	// PatchRelationship request with any body
 	PatchRelationshipWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
 
 	PatchRelationshipWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsentsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error)
+
+	// GetServerTrustBundle request
+	GetServerTrustBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetServerTrustBundleResponse, error)
 }
 
 type BundlePutResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON409      *externalRef0.ApiError
+	JSON422      *BundleValidationReport
 	JSONDefault  *externalRef0.ApiError
 }
 
@@ -764,6 +1194,29 @@ func (r BundleSyncResponse) StatusCode() int {
 	return 0
 }
 
+type IssueClientCertificateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ClientCertificateResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r IssueClientCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r IssueClientCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetNewJWTTokenResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -810,6 +1263,29 @@ func (r OnboardResponse) StatusCode() int {
 	return 0
 }
 
+type OnboardWithSVIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OnboardHarvesterResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r OnboardWithSVIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OnboardWithSVIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetRelationshipsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -833,6 +1309,29 @@ func (r GetRelationshipsResponse) StatusCode() int {
 	return 0
 }
 
+type GetRelationshipByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PatchRelationshipResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -856,6 +1355,52 @@ func (r PatchRelationshipResponse) StatusCode() int {
 	return 0
 }
 
+type GetRelationshipConsentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RelationshipConsents
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipConsentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipConsentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetServerTrustBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ServerTrustBundle
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetServerTrustBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetServerTrustBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // BundlePutWithBodyWithResponse request with arbitrary body returning *BundlePutResponse
 func (c *ClientWithResponses) BundlePutWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BundlePutResponse, error) {
 	rsp, err := c.BundlePutWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
@@ -890,6 +1435,23 @@ func (c *ClientWithResponses) BundleSyncWithResponse(ctx context.Context, trustD
 	return ParseBundleSyncResponse(rsp)
 }
 
+// IssueClientCertificateWithBodyWithResponse request with arbitrary body returning *IssueClientCertificateResponse
+func (c *ClientWithResponses) IssueClientCertificateWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error) {
+	rsp, err := c.IssueClientCertificateWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueClientCertificateResponse(rsp)
+}
+
+func (c *ClientWithResponses) IssueClientCertificateWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error) {
+	rsp, err := c.IssueClientCertificate(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueClientCertificateResponse(rsp)
+}
+
 // GetNewJWTTokenWithResponse request returning *GetNewJWTTokenResponse
 func (c *ClientWithResponses) GetNewJWTTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetNewJWTTokenResponse, error) {
 	rsp, err := c.GetNewJWTToken(ctx, trustDomainName, reqEditors...)
@@ -908,6 +1470,15 @@ func (c *ClientWithResponses) OnboardWithResponse(ctx context.Context, trustDoma
 	return ParseOnboardResponse(rsp)
 }
 
+// OnboardWithSVIDWithResponse request returning *OnboardWithSVIDResponse
+func (c *ClientWithResponses) OnboardWithSVIDWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardWithSVIDParams, reqEditors ...RequestEditorFn) (*OnboardWithSVIDResponse, error) {
+	rsp, err := c.OnboardWithSVID(ctx, trustDomainName, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOnboardWithSVIDResponse(rsp)
+}
+
 // GetRelationshipsWithResponse request returning *GetRelationshipsResponse
 func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
 	rsp, err := c.GetRelationships(ctx, trustDomainName, params, reqEditors...)
@@ -917,6 +1488,15 @@ func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context,
 	return ParseGetRelationshipsResponse(rsp)
 }
 
+// GetRelationshipByIDWithResponse request returning *GetRelationshipByIDResponse
+func (c *ClientWithResponses) GetRelationshipByIDWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error) {
+	rsp, err := c.GetRelationshipByID(ctx, trustDomainName, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipByIDResponse(rsp)
+}
+
 // PatchRelationshipWithBodyWithResponse request with arbitrary body returning *PatchRelationshipResponse
 func (c *ClientWithResponses) PatchRelationshipWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
 	rsp, err := c.PatchRelationshipWithBody(ctx, trustDomainName, relationshipID, contentType, body, reqEditors...)
@@ -934,6 +1514,24 @@ func (c *ClientWithResponses) PatchRelationshipWithResponse(ctx context.Context,
 	return ParsePatchRelationshipResponse(rsp)
 }
 
+// GetRelationshipConsentsWithResponse request returning *GetRelationshipConsentsResponse
+func (c *ClientWithResponses) GetRelationshipConsentsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error) {
+	rsp, err := c.GetRelationshipConsents(ctx, trustDomainName, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipConsentsResponse(rsp)
+}
+
+// GetServerTrustBundleWithResponse request returning *GetServerTrustBundleResponse
+func (c *ClientWithResponses) GetServerTrustBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetServerTrustBundleResponse, error) {
+	rsp, err := c.GetServerTrustBundle(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetServerTrustBundleResponse(rsp)
+}
+
 // ParseBundlePutResponse parses an HTTP response from a BundlePutWithResponse call
 func ParseBundlePutResponse(rsp *http.Response) (*BundlePutResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -948,6 +1546,20 @@ func ParseBundlePutResponse(rsp *http.Response) (*BundlePutResponse, error) {
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest BundleValidationReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest externalRef0.ApiError
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -993,6 +1605,39 @@ func ParseBundleSyncResponse(rsp *http.Response) (*BundleSyncResponse, error) {
 	return response, nil
 }
 
+// ParseIssueClientCertificateResponse parses an HTTP response from a IssueClientCertificateWithResponse call
+func ParseIssueClientCertificateResponse(rsp *http.Response) (*IssueClientCertificateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &IssueClientCertificateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ClientCertificateResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetNewJWTTokenResponse parses an HTTP response from a GetNewJWTTokenWithResponse call
 func ParseGetNewJWTTokenResponse(rsp *http.Response) (*GetNewJWTTokenResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1059,6 +1704,39 @@ func ParseOnboardResponse(rsp *http.Response) (*OnboardResponse, error) {
 	return response, nil
 }
 
+// ParseOnboardWithSVIDResponse parses an HTTP response from a OnboardWithSVIDWithResponse call
+func ParseOnboardWithSVIDResponse(rsp *http.Response) (*OnboardWithSVIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OnboardWithSVIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OnboardHarvesterResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
 func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1092,6 +1770,39 @@ func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsRespons
 	return response, nil
 }
 
+// ParseGetRelationshipByIDResponse parses an HTTP response from a GetRelationshipByIDWithResponse call
+func ParseGetRelationshipByIDResponse(rsp *http.Response) (*GetRelationshipByIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRelationshipByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePatchRelationshipResponse parses an HTTP response from a PatchRelationshipWithResponse call
 func ParsePatchRelationshipResponse(rsp *http.Response) (*PatchRelationshipResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1125,6 +1836,72 @@ func ParsePatchRelationshipResponse(rsp *http.Response) (*PatchRelationshipRespo
 	return response, nil
 }
 
+// ParseGetRelationshipConsentsResponse parses an HTTP response from a GetRelationshipConsentsWithResponse call
+func ParseGetRelationshipConsentsResponse(rsp *http.Response) (*GetRelationshipConsentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRelationshipConsentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RelationshipConsents
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetServerTrustBundleResponse parses an HTTP response from a GetServerTrustBundleWithResponse call
+func ParseGetServerTrustBundleResponse(rsp *http.Response) (*GetServerTrustBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetServerTrustBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ServerTrustBundle
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Upload a new trust bundle to the server
@@ -1133,18 +1910,33 @@ type ServerInterface interface {
 	// Synchronizes federated bundles with Galadriel Server
 	// (POST /trust-domain/{trustDomainName}/bundles/sync)
 	BundleSync(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Issue a short-lived TLS client certificate for the harvester
+	// (POST /trust-domain/{trustDomainName}/client-certificate)
+	IssueClientCertificate(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
 	// Get a renewed JWT token with the same claims as the original one
 	// (GET /trust-domain/{trustDomainName}/jwt)
 	GetNewJWTToken(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
 	// Onboarding a new Trust Domain in the Galadriel Server
 	// (GET /trust-domain/{trustDomainName}/onboard)
 	Onboard(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params OnboardParams) error
+	// Onboarding a new Trust Domain in the Galadriel Server with an X509-SVID
+	// (GET /trust-domain/{trustDomainName}/onboard/svid)
+	OnboardWithSVID(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params OnboardWithSVIDParams) error
 	// List the relationships.
 	// (GET /trust-domain/{trustDomainName}/relationships)
 	GetRelationships(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params GetRelationshipsParams) error
+	// Get a relationship of the trust domain
+	// (GET /trust-domain/{trustDomainName}/relationships/{relationshipID})
+	GetRelationshipByID(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) error
 	// Accept/Denies relationship requests
 	// (PATCH /trust-domain/{trustDomainName}/relationships/{relationshipID})
 	PatchRelationship(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) error
+	// Get the signed consent statements of both trust domains of a relationship
+	// (GET /trust-domain/{trustDomainName}/relationships/{relationshipID}/consents)
+	GetRelationshipConsents(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) error
+	// Get the trust bundle of the Galadriel Server
+	// (GET /trust-domain/{trustDomainName}/server-trust-bundle)
+	GetServerTrustBundle(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -1188,6 +1980,24 @@ func (w *ServerInterfaceWrapper) BundleSync(ctx echo.Context) error {
 	return err
 }
 
+// IssueClientCertificate converts echo context to params.
+func (w *ServerInterfaceWrapper) IssueClientCertificate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.IssueClientCertificate(ctx, trustDomainName)
+	return err
+}
+
 // GetNewJWTToken converts echo context to params.
 func (w *ServerInterfaceWrapper) GetNewJWTToken(ctx echo.Context) error {
 	var err error
@@ -1226,11 +2036,43 @@ func (w *ServerInterfaceWrapper) Onboard(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter joinToken: %s", err))
 	}
 
+	// ------------- Optional query parameter "instanceId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "instanceId", ctx.QueryParams(), &params.InstanceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter instanceId: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.Onboard(ctx, trustDomainName, params)
 	return err
 }
 
+// OnboardWithSVID converts echo context to params.
+func (w *ServerInterfaceWrapper) OnboardWithSVID(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params OnboardWithSVIDParams
+	// ------------- Optional query parameter "instanceId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "instanceId", ctx.QueryParams(), &params.InstanceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter instanceId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OnboardWithSVID(ctx, trustDomainName, params)
+	return err
+}
+
 // GetRelationships converts echo context to params.
 func (w *ServerInterfaceWrapper) GetRelationships(ctx echo.Context) error {
 	var err error
@@ -1272,6 +2114,32 @@ func (w *ServerInterfaceWrapper) GetRelationships(ctx echo.Context) error {
 	return err
 }
 
+// GetRelationshipByID converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRelationshipByID(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// ------------- Path parameter "relationshipID" -------------
+	var relationshipID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, ctx.Param("relationshipID"), &relationshipID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter relationshipID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRelationshipByID(ctx, trustDomainName, relationshipID)
+	return err
+}
+
 // PatchRelationship converts echo context to params.
 func (w *ServerInterfaceWrapper) PatchRelationship(ctx echo.Context) error {
 	var err error
@@ -1298,6 +2166,50 @@ func (w *ServerInterfaceWrapper) PatchRelationship(ctx echo.Context) error {
 	return err
 }
 
+// GetRelationshipConsents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRelationshipConsents(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// ------------- Path parameter "relationshipID" -------------
+	var relationshipID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, ctx.Param("relationshipID"), &relationshipID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter relationshipID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRelationshipConsents(ctx, trustDomainName, relationshipID)
+	return err
+}
+
+// GetServerTrustBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) GetServerTrustBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetServerTrustBundle(ctx, trustDomainName)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1328,119 +2240,148 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 
 	router.PUT(baseURL+"/trust-domain/:trustDomainName/bundles", wrapper.BundlePut)
 	router.POST(baseURL+"/trust-domain/:trustDomainName/bundles/sync", wrapper.BundleSync)
+	router.POST(baseURL+"/trust-domain/:trustDomainName/client-certificate", wrapper.IssueClientCertificate)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/jwt", wrapper.GetNewJWTToken)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/onboard", wrapper.Onboard)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/onboard/svid", wrapper.OnboardWithSVID)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/relationships", wrapper.GetRelationships)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/relationships/:relationshipID", wrapper.GetRelationshipByID)
 	router.PATCH(baseURL+"/trust-domain/:trustDomainName/relationships/:relationshipID", wrapper.PatchRelationship)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/relationships/:relationshipID/consents", wrapper.GetRelationshipConsents)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/server-trust-bundle", wrapper.GetServerTrustBundle)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+R6eZOi2rbnVyHs90d3mJnMihlx4gWzoKAgqHg9XcGwGZRBGUQ9Ud+9AzSzNNOqU6fu",
-	"Pf3ui1f/lAl7r/1ba6958UfHzZJdloK0LDqvf3RyUOyytADtHxzw7Soum59ulpYgbX/au10cuXYZZSm8",
-	"KbK0eVa4IUjs5td/5MDvvHb+F/yNLnx5W8D0LuLzPMs7X79+fep4oHDzaNfQ6bx22hcQPZWgbxCaVde9",
-	"Den37Q0Iz4uanXY8zbMdyMuogezbcQGeOrubRw10DzT/+1me2GXntROlZY/oPHUS+xglVdJ5JQeDp04S",
-	"pZe/UAR56pSnHbgsBQHIO1+fOgkoCjtoKYGjnezi5j0NOcCuysivYgi0HLwte/p2XlHmURpcDhyDNCjD",
-	"zit2c8j1fcNtDvZVlAOv8/qPC+5v5/7+vj5zNsAtG0xMlXox4KIAFO3V3IvUsQvQIyCQNpQ8aDaknzGy",
-	"B3ntcijzoTIEkNOS6DzdMOUjBNnz+jbwEIoC/QEKiB6KuLiL2V4Pt31A9AAG+v3+gKJ8z3EHWB/xURK4",
-	"gz6KOgTW+cTZG9LiArX4/g3+WIHu+P16g/mPTplXRfnFyxI7Sr+gndcOReE4SWF9pI+QoOf3CYDYDsBQ",
-	"m3BdsgcobID0qAHhoCgKKHfg4LbbczCcHOCIC3qE13BxSxPrvHY8ygaY61AAABLYDuWiqI87OIEPgE3Y",
-	"mE0gAxQgvR7Ro7A+haEAoIOe0yd7lE2ghPuJJt557aAk4ff8vj8g7B6C9bE+6RLA7zk4cABC9HsAJQeO",
-	"Y3u4h/g+0sMxj0AJB7hg4LmA7DmNHL6jGIW58+wS/JPifqMilSD5E6H/0SmiILXLKm/gqOOhXO1WKTU6",
-	"CpmczCKZY6dds8pm8iQOlRE6tKSN27OmffIAUHLqKnL/vEflsb4UzstSQc4+MnPHzgpNLUs8aEmw6Ioy",
-	"fSSnRTLb4wm63eRHxBdkDuG5/XwV2ufMkrKCIqY2tRdhdwEQskDzYWZZJF5PMBxdidtyOyR7o/TkDTms",
-	"roF/0tgd/VvnqYUepcEXtxGO33i4honn5h/Di5IKsbxuSILE0gbfPoUUSeKqM8vSe4UVhl2DmIemnMAW",
-	"15jEXqLJAVoLGw1WaERkZ3txJjk4p/EMW5u0IokrSNGKmtUsbq5pIl/Lc/PMTxS6FmnU5Fm6FubinLCW",
-	"ypHn6AkTqHOGdhUGCQ/eUkUcjDhCozO9u7zIFGkbxh52jL2hFpiisLEx4bRiGcFJ9dhNmZO9VGOJVw/O",
-	"kgmddHscbmgXumwuFMEMNX3GDK3FMVwN5d1qUQfmUD7YyXzjcbyjMNsWFV3XMxcTSlc8xuOFeoJWS323",
-	"SuKNtdRjhSGWnCGdFU45KQZPKOfgPJlnS85QmmfHCff+rA5W2yN7puUrAsug47mhaETN0a08JI6em6tl",
-	"GLpnXlNooj2dqevhTBygLq4fnA2fK+xWhFphBXU0E+e4I84Rj2U0a6Hm1lLeSvy88sT5yR3KOxczAw0b",
-	"lK4oVMDggcJcBA2xdT2fCYwg8V7oiMLWTeLYYRnNTQb71UJFFL2oxcstcRwjn60FWjuiWVq4HHtinED2",
-	"Qg090ayDgI8+3jWtmTRNSAxX0837EZ1JDK2xFEwZA5OwnXB0DKEDfgyP7OwgT+y6H8JKpm32CjaIovFi",
-	"lXc5LOtj6p5CV7qiTnc6r2dDtX8mRraTyXlIHrpQ96TlA6piVWu7pTmKWuyn8ZILydDfCYzlKjZfjzEn",
-	"YbqJK8ALlF5NMiuL+yOd9I7LrkBDXpblMZzPa8Vmsak5JMxkQyjTGTwuzgv20MdEF9mE+UihTRHbbQan",
-	"1RIejfJxpRMFVudnyDoSGoaq4bQ/6cm5zIc8Y/EmeuxW+Zat0sqlz4iMGjozPpRnkywOOx87Ivbo1Kth",
-	"cDr3ID4xtt2amh6ORFxnzPFk58qQocfM0A1IWpxXO9PtLytWpSQynmiA4BJW75Pbo5BNBY0AUP+wJ1ay",
-	"MKQDhaFpvuY0Sx5lKyk8uCqt8WNGo7kg4Bma4XcjLtGpEyXrDpbMZrsU4zUWUrytIy6RhaLlGDeCrTQ3",
-	"Y6TflRIzqSeKs2P31US2EIuO6R513JKwVp59ieJ8Fis4jV9CYi1vkY2ezbHUQ+b5CB+c6fOhN8Ak/ZCf",
-	"YGToHVEESXxK2NaJmJ1h102M46wrnkhM5/Qu1J05sE/TWZQcF9iwWDpVGtGY5NRbR1XyHOlOwqkjr5gJ",
-	"jvJ7b4GuahILB8dl6c76dDUWII9ximSzWAoyv8DxMafwGu5vVtFMd/dh4M+PiuSX2jniY7ScU2Jfk5d5",
-	"tFV6dlaNLWGmQi4uE6WMwOTAZb1QXCIZ0OvDGB8LS0omehUqCITrM0XqZFas8D5ujSbMZJlER3tog+A3",
-	"qPWMvMp98pbvse+acbx22oznT0JXG3T+Wr7nvadDfyWVuAlcP944e1/49Tsx48f72ZulXz/K5MdbjWbt",
-	"BfinbPGOztObEG75eoz2UVrJ3nNzn1UuX0hkAN2QgKIUmvIKdM13bzPJHwTPdapI0hA2WJYBi4CuJYYO",
-	"JM1mLB6HFYRaDi02VeeJKzDuhlaZYLsPt5E4qBGG1gqB5pjTOv1nw+c65Q16+hY/WUE1DJbhHFyulRlR",
-	"j+mry2fnhonUlYUNSomfL6TLOrmJq+vUTdB4JcaN/w80hA/MWGUkQTpfY2GtcFqtGHStGsFZQZtYKB0V",
-	"zj2qm8uzdaqgWR04SBsNfyUYrtNrONQVmhKv0VAyUVVpor2b0kdhQ5sXyqbBmeRC2dD1hOMxxdBOKqcc",
-	"16nA0bPLCkVhcQ/3TuTZxS48KzpSi3WLY8oxuuYmMdZEe4kfnFaYUNnLXbhOPTFuMCwVxhTZUyHSmsYE",
-	"G5eiA57l6NVktVyFK5E/8mdaZ4IiZwKepy0Jn9ISQx8Vdp3O58pfiKDcMAT61nFQgXX7R31UlOu0HiGy",
-	"JNojiyr7sjPDHA1zepYkc0E6rCRruGdy1pz3BxmIo+022+pb4eAedvYoSoUhpw3Xqblb8FJPN3ndSmZs",
-	"gE+oRURg1cSdYwy5sp1kyW5r72iRvBuTKOMolJmKXkaLjqcmkZ6s01libNyiG4fKMSB8werFzC7i50Ik",
-	"mhtR17s9VO/1x+eeSYxkMFZdNkH6Wi1YIybZRQgVrFPvFMwOumfWJClnuxx4m+5cLDfmliFCwSBEbQkH",
-	"Ydkb6PH+DHepCvF4bRtWZlW5+d6OGwziicCHes343EioLbBQ+uxU8UgAe5NuiVAlNXU257lhHMhQ49iC",
-	"t6Q5ZvRpQRrMXPWorNNt2IcvcVTcBIHKNJnu1KD9RkeGM4UXOXoRMDO4nu+H8GnT0wx8UCLwdmh3A2se",
-	"7NbpwWBgJgiaexYYzWVoTT8rQ742NEsa1RbDaOZQoUeitggRb0j3xqcB7uFu5eJqMU7Uwzp1Zk2WwRxc",
-	"LEYcXCbHqGoYonpwZqjhLWROm6HCPEIb2ywbqxsbWj0xrNLcKJWFy8g6VVhaZNlGF02BOdNMGOqZN9Tr",
-	"SUQdHEw9u0Pl/TznjTudv3AXlPg6vUXkWNLw22rmKguaX3DMQqFdkVkAhqN5ptXf0563aVFcp4PUZRmN",
-	"ZxSuFjn2ahf7bU1rCsNwdKGw2TeMtcQIIdlidM/ZYYx7DYYbWxzjcuyKg7O91A9uuq2HjffTkZhhrFqg",
-	"v0mWrqV3quuUqRVG4YPGN3jDWmcUjqqnNt3PuERUsXf5b9zkeB6n6tlhyY2DIYfGhzSnrtPxXEWtrcqM",
-	"zfliPG/8HzozEb5UOZpUI3SmnMiNm9RveCYMY/ECzdGCKdnnmszX6Uoa2rtUP0pmuqu74vjqxTyu5hm4",
-	"1ni6loSMY1l6iYhsdJETmm5Zhpb4IBDKdcpIEmNrQkoPXXoQn8zxQMAVVjLnTCApsr7YVKrKH7fnw4BS",
-	"xid6fOb7x9VEoWlaOCpImK1Tp6ZphlboGceIdMTTvSOII1WnxC3cw3eWl87gw+QIs5tdySv8gRosFiEK",
-	"V/lC4llJ407rlMnB0MRI7lxXW83WtU296JHkarzds+nROWoLPZqAZDOQaQalZS04ML0JaqFFNFT8ICui",
-	"dTqmcR3bosDhu+Z0MXSMaGAZ9pilaZpxDVWy1ZqmaY2jeavWaSkQdZ6oz7aj6h5HbffwOj0IU7zUABYm",
-	"yJFMl1Wc1SEhOTUeb1lJsBwYj2fcLp71aVcn8u5ytyj50cwQFnKisrrjrtOlXOWYLjL00KT7BTvvZ+hp",
-	"RXdnBDUhRcqdZVi8Z5fl2A4zczJZDYviUB797Y0kqask9Q3D0xHTkw5OtigKXCekcl5vgBP3OfyUCfYS",
-	"UbkQ8xZhGNTsMR/WUsD6+/46zVyFJcsuuolIhTza42TKElJ3scQlmNa3i9kpmvQlzf1OFi8x65RmQVXl",
-	"hJZWm30SVLN8aOJJ6HddOfPOhqbuM6L0QHfKoTAQPIvmxxV1FLoIXfaPcjS11mlE6qM6ik9Tsnfo4pGF",
-	"GYO47s8oQ0YIdD4ObWm0QwnlPDPP+glkE7qQ+xrNKWw8HJlc3MQLE9upVUZRVi8KsoOBO0Vay2rEa+r+",
-	"lMxmVrgta6S0vSrbb/bLFOkFxTzKFsacW54Kj1yne/5IlL1CCiRXSbCeNUQP8o7V+HC0c7ET0g/07TZm",
-	"VnqpbIzwQLjL00lZ9ivD9Yw+LTPTdVqByGezOUbKx2WVUR6J4oOgnqIMDfoSM58esao/UmHzNFl6q6RW",
-	"fNhIBLHmPNYvTkMfXqergsHq8TA7G1ZGzxNtIGQmKo8Ddx4d9nL3oMZMOFyG8VHxVGRDIfpAPfd4KYi1",
-	"DRjhE2qdSrAriAnMUF0CCycxK3mDlVemnuzq8nwTITWH7GtwYG2fHmzkeHiANwXflQbmuefu2FO4Tou6",
-	"G+eCdzSDvUlS9nEPRtRA0LtqRuwRSZp05QjN5VE+SLczBmH2y+w8T3nUYuDR+OBJxTqtrJVc7R1sN9pW",
-	"3fPZ6AVmPTSN1YGJ1Em5HBPqsXbhkdFfnCczD6unKKJJFDcKiIMfqVyxToeLhEFdYrSJesEkoMlqZp5t",
-	"MdnDB2KeuiPSzLvpYOz4qT92MUom/RIWszJKlRO3xSM7X6cCiljx3p0kYIlWQjJyvAheZrkYb9lMEXCD",
-	"O1J5shtwTMTA6/S7tdI6ve0f70DyqKXKZmkB0nJW2mXVFj4grZKmFLB3uzw7AK8pA0AatT92IPWafb8/",
-	"ICSCUq5L/dp2/4vVVpltQfpnhYu8MD4XLO3GR+WHCEodxO2AoQij3S2wqATJn7Ytbze3nXv7KF32kTdd",
-	"dzvP7VPzugF319QHJzl0RDeaRLJkniVUjaRCSnXSZaWetN0t56w8eAEn+ewtpGgSSUdloyCqYeETbltL",
-	"UR05iVCuZu3igy0SgS4O4ua5vRAQaZMdVYPHlI1CKpx08rWXmR+PjrUuzxQwGgmYZhB+vVOA7OO96WTb",
-	"O8nzL7anFUVNurdqsanL+5kCgQx6T52dXZYgbyq1//sP+/lMP6+Q58F6/fzl9+5/rtcvj579748P/89/",
-	"/scjjZukTmbn3tDOD6AoQf63q8y1PObaBrPE/dke05S4D5tUO/m5svpm+WNN/Yjl8zGPdHlqB0CtEgfk",
-	"nytpIwRQ2r6DMh9qVRsqM6jYRjvIAX6WA6go7byM0qB57mZxDNyyndrkoKjiEipA+dK5GV09HFw1EGbR",
-	"+VrKXyd6GPL0XTTFHZwclFWevtzNy5DbcdnjM0s3vDfifXXtyvyl0V3r4r4U7z7uh52VO4f4eZx2R+vh",
-	"ZWVv7ZXZKXV/DXJDHvzkYOVtGvYR6oXGzyD8Jfv7JYhPnerbMOnnh0bf4e0btYdcVlcmf+0K/oc0AO9n",
-	"if+sk3tvGN4R/QDv0WXdRdu/aN45sEvgfbHL+wCMIRj6jKDPOGIg1CuOvCLI6jbyNZrzXEYJ+DBTRx/E",
-	"rMj7S1HjbZhpf7n6i7/odD6R+eXz01+JXB+oOP8aLpxf5cL5VS4u3uHv1IwP6h81SfKNPt5BeHSpj0T0",
-	"XR367rU8MqjZrSP64Rcd7y7rBx9z2GJvtcTtld/tlQF80rmVp8/UUsEH8Xm1UE+rpS6vOFS2Fqjx/je7",
-	"2nhL+bRakMhcjMvVXEWsBVpPDR5Vz/xJMcx6YpjJahnW9lKO2zUGcpxwAaYaLqpwW1RO5dBJ9INjICdl",
-	"Q2PKxvztUU5569I+8TubSoLAQ+2aK3NQlELybKI+Gjf8sW6S4i92VYZZHjVeZt15/ccf6w447qIcFF/s",
-	"ct15XXfQHkWQaA8n8HXnad3ZgtOXyGvf0J6xchG3fy4GPbcXHLSjzPQ0j+9xp1ml+od2/a5y4sj9sgWn",
-	"do8ibGu+toajbCWdNwhLa5Z0/c3RmstpAc0f0elKr30e51bFZI8pDDIhpwvfKc65vRNVPyF5AUazekmm",
-	"EqcmGyNyYPXk91nAHmZjl3dxxNrZzoF2gvGQcgss5M4o/dtv687Xp+/xR6Gf+fODuc25tkFb9nkrYgt/",
-	"gC9K8Zjo3tKnEZX5Vf5ybraJ3Dzdz8yUx04AlbPKZzhx7JSSspGFuTgCw0k5MshqHzPwyKBUDCeXRbEM",
-	"jLGmK+F5R3OuohAmbMXuITtth2QStPz9/rTu5MDPQRF+CaP0wiHSAi2a9CB1wZdL3tq+6bdvbs2tfVx6",
-	"6Lrz9bsKeF8sfNOols7Lhc6LmyV//lkXQT04o3WOd4Rx36ZIv0c8k320/0yQPezZwX33GXMHPdzv9Wzf",
-	"7t0eVlWtf7k5Cv9Q6yHPA/vZ//0P6uvz+2/iJ36j2NcHxV6T2gC3yqPyNGvc9CVgh2+VX2tj3/Xr9xvh",
-	"D7vaj/+i1M/eviu03dbLX0JFR4zKsHIaB5zHnddOWJa74hWGg/ZxcwfwENQxKMup7W7t3IMDO7a9PAJx",
-	"59NHheLbK2gG8gPIoffStf3SsNgB95KJRVlb4cSRC6759BUNvbPdEEDYC3KH6BWG67p+sdu3L1kewNet",
-	"BTyWWF6d8c/YC/ISlkmLqozK9tb/BM8zNNmBtPmFt+cdQF5cGEFfkBcUbUhlO5Dau6hRoRfkBe+0ShC2",
-	"twO32vp80Vb4jw/l6Vf44kDbpbuqFXmTjLXMS17n9Tp5n1ZlSzS3E1CCvGg86MdSsfXHF9JQK6jmQjuv",
-	"LZTO05vsPtbHtxG3zCvw9JOfjX5OXX+/kAJFyWTe6V/2feqnuuPBd6qXBU1h7ADomiN84qxNLm4+pMUQ",
-	"5EFwq1wXFIVfxdD7PVxU+P2r20dg3wnDb5/n3hpre1sfzfQfvzcSK6oksfNT57Vj7uLM9iAbSkENlbfB",
-	"tczaPKJotbPxCnbQKMD1xq+lXef35sSf1Da4OKVuq3JZ8V2da4rZ/7FK97Dp8EDzrqV4k+vZcQz5wGsE",
-	"Cbzr3RVQGdoldCcmt8pzkJbxCdqmWV28/Kyi/i2MXXsVDzgTshxEQQrdaRn01iH4/2ISDcQwz9LoDIoH",
-	"oq2jMoQ+eu9/zj42dctPAB5YhQhKFdTywjCurcf/BpbxN2nRh+HEA+35r/OjIighG8pBCmrgQfLCgNpW",
-	"8UVbWj9qJwByYztKCsgu2kdZHgVRasdQloIbBWo2Xy77p5Qnu3TibxToXiZSCVUFKCAb2mRReoVVZtC1",
-	"NDqDFss7f01R1Tz4lJ/YqQcFoISismj5o1tRQ29qea+11/HAv726Pn1EJN/JqInrBfCaAhO6yvmS57cg",
-	"9xXIT99QNuJ9k8b38X1Mrv9Og/nukOaB6ehtb7+A7BSyLzf7UAqN1oC0bPGkwTe1KaAshRwQ2rH/1oG4",
-	"vdyXX7fCdxObvF/ANV25057vqO2NXb3p5E9ZVX7Tyix+5Jz1u4V/ou+3VKHL9AHy8yyB7HtmdiBvCpIy",
-	"OvxXWMQj5XbvuoI/S/7TGOahA+DeGsyPDt69jax+9sz3GdcvH3cd0v2VA69b/vb493AY/m8VCMdR8TaX",
-	"vDGMlxs7vLeYv26N8B+3f0rc17aksEs3/Gygn4aP//0iksS9OdT8no8HsO4F88uoLg38v63K+d5A+GFU",
-	"+uwwf6ne/pcgv/+a5N/J6ppkbFfCHEgjUNwpCnS9wuJHFtge14TLi0nct9vizLXjMCvKl6K2gwDkL1EG",
-	"27sIPuCdBsWV6ke9nbxJ4IoHeG1L4bbFAI5uaKdBk5ymHlS8110Xwb1r9X1F9dlCbk5qUpTLERdfcp+1",
-	"Xem9JQI/pHSD+U6cDihr0ORFN6cU32jfi/br71//XwAAAP//GWDrGVY9AAA=",
+	"H4sIAAAAAAAC/+x8+Y/iStLgv2KxI327gip8cpQ0+uQLY4MNNjeP3paP9AF2Gnxg4Kn+95XNUUBR3dU1",
+	"782++Wb6lzZ2ZmREZFwZEVm/l8wwWIcQwCQuvfxeikC8DmEMih8csPXUT/JHM4QJgMWjvl77nqknXgir",
+	"yziE+bvYdEGg509/i4Bdein9r+ob3Orxa1yl1x4fRWFUen19rZQsEJuRt87hlF5KxQeE7ovIGwr5qNPc",
+	"HPRleo6EZXn5TN3vR+EaRImXo2zrfgwqpfXVqxx1C+T/22EU6EnppeTBpEaWKqVA33lBGpReqGazUgo8",
+	"ePyFoWillOzX4DgUOCAqvVZKAYhj3SkggZ0erP38O40YQE8Tz059BBQUnIdV3taLk8iDznHBLoBO4pZe",
+	"8KtFTt9zaiOwSb0IWKWX3454v6377TI+NJbATHKcmBRaPuA8B8TF1tyy1NBjUCMRAHNIFjJo0084VUOs",
+	"YjgS2kjiAsQoQJQqV0TZKEnVrLoOLLTRAPUmBsgahpqEietWjdBtQNYADur1erPRsC3DbOJ11MYoYDbr",
+	"GGaQeOkdZWdM+6HvmfuxF/r6Ecdf2kg9Tdww8pL9e1J7tg2g5UEHuQyqIODZeUZ2FNr8fn7pgfg39BsS",
+	"RsgyS27erjzr2zOihAkSgwTJXAAL7mzPqCJmCE0Qwbh4nbmhf8W6d9Reycq7b1Hqg/cEHDmD5B+RxNWT",
+	"q71BrBDECAwTJFcpf49kXuK+IEfxqpwGfY+9A6ggSZTGyXcrDHQPVpAYbFIATfAdpoEBosobc76bYQqT",
+	"6xfH+Vvd96yCeSuw/677Tv7RDXKOrcBxUOlnclsQ+Bm5HeeLFdzVwDqMkp+Kwy3LNBCn/kWOtxdg+Rsd",
+	"IunaD/Vc7k9c1B3dg/ENY6/mrAv+l+5F7o6Dj83I2XA8NBvXO/Iz+zjMx3LFUEUPQD69QPFKjoww9IEO",
+	"i09n2Sww9RIQxD9b4LEWvl7w1qNI37/b0BsSzijdrP/xHsdH4xR/vLefwfgIpPR6ZaV+v0HrO1Z6KTUa",
+	"BEE18DpaRylQs+skQHUD4JhOmiZVAw28idYaTdLAMAw0zKZB6GbNwAmqSaAmqJE5TTcw8dJLyWroADeN",
+	"BgCAArrRMDHMJgyCJJpAJ3VcJ9EmBtBajaw18HoDxwDAmjWjTtUaOomR5juYROmlhFGkXbPrdpPUayhe",
+	"x+uUSQK7ZhDAAChZrwGMahqGbhEWattojcAtEiMNYIKmZQKqZpReP2b3aG3pCfgH2X2GIiYg+AnTfy/F",
+	"ngP1JI1ydJRuW0rXc9jo7FqhFAw8iWP75VEaDqSe78odrD0Tl2Zt1q9TW4BRfVOW6ocNJnW1aeswTWT0",
+	"YKMDs2vMMTibCVs1cCZlQaJ3VD8OBhsiwFbLaIfaLYlDeW4znrv6IZyJYdwg+3pjI1TNCUCpGIva4WxG",
+	"EVkPJ7C5sEpWbarWgXurzeFZBuy9yq7pv5cqBeoedL6bOXPsPKbJiXjK/zG8ICoIy2tDsSWy9JAv3iKy",
+	"KHLpgWXpjcy22uUhOXZHUlCdcbkT3Ig01cSy1lKtyjQqsIONMBANglN5hs1GtCwKc0RW44xVZ9xYVQU+",
+	"k8ajA9+T6UygsRHP0llrLIzJ2VTe8RzdYxxlzNCmzKDu1poqqIGTO6RzoNfHD6Esrlzfwne+1VadkdBa",
+	"6nhrP2eZlgE134TMXp8qvsgrW2PKuAZc7dpL2kSOk2O5NXJVbcC0Z5OdO29L6/kkc0ZtaasH46XF8YbM",
+	"rAqs6CwbmHgrMYWd350oe2Q+1dbzwF/OppovM+SUG4oHmZP38pAn5YNz6I3DKTeU83e7Hnd5lznz1Y49",
+	"0NIJg9mQ9sdDWSUzji74IXL0eDSfuq554FWZJovVmSxrD4QmZhLa1ljykcyuBKRglpN5A2FMGMIYtVhG",
+	"nU2UaDaVViI/Ti1hvDfb0trER46KNxNTaKVgyAOZOTIaYbNsPGgxLZG3XENorczA9w2WUc2guZlPFFTW",
+	"4kw47hLHMdJhNsEyQxglM0LyLcEPEH2iuJYwyhyH9+73mlZHNE2KDJfR+fcOHYoMrbKNamPYHJG64XZ2",
+	"LrIldu6OHWylnp7V3aocqsuNjDc9rzuZR2UOD+u4smlgc01W+muN18K2Uj+QHd0IpciltmWkvFejZiNl",
+	"ldlqRXONxmTT96ecS7n2usXMTFnnsy5uBEw5MFvVCUbPe+Es9OsdjbJ203KLRqwwjPxqNM5kncX7ozY5",
+	"Cpak3B9Uu/Fhwm7ruGCiSzfqyPRIwNfL5n4+rXY6UTfVyBjPogMy25Eqjiluv96rSZHEuzwz40fYrpxG",
+	"KzaFqUkfUAkbakx3mxxGVLxd2/gO1Tv7WlYF+0MN4YPhqpw1+tsd6Wchs9vrkdxm6C7TNh2KFsbpemTW",
+	"pymrNETK76mA5AJWq1OrXSvst1QSIPXthpxLrTbtyAxN8xmnzqROOBfdranQKt9lVJpzHJ6hGX7d4QKt",
+	"sW9ImoEHg8Ea4rzKIrK1MoQpOpHVCOc61RmMRj5aL4vBKMh6srFmN2lPmqEz2qdrjd2KqqrJwRYbnM3i",
+	"MafyU0TIpBW61MIxDi10HHWI5oE+bGtNXNS20b6Ktq0dhqKB3WitskAID1XTDIa7QVnYU7jGaWWkPDCq",
+	"Nk2HXrCb4O14aqTQo3HRyFaGIkcRWu65fUOaMz0C4zfWBJtnFO42d9PEHNTptNtCLMaIg+Vk2pL4CUF0",
+	"OZlXCXs59waauXEde7yTRTtRDx7vY8m4IdRVaRp5K7mmh2l31hooiElIZCKhVappspYrTNEQaNm2S3Rb",
+	"04ZE1lKs1SJNm4mhEc58mbeJWafH9KaBt9PbOnD+jhSWkVe4d9by4vtOgfJLqfT6c9dVOJ1fOxhYlwPQ",
+	"r4QSV47rxxMHl4GvH/iMH89nr4a+3vPkExHhEfEPwrLLIeTEhGu6HmP7KFhjb6m5DbSnzxTaRK5AIB5E",
+	"+rx8OoLcnB1/4DwXUBbFdnXIsgyYOHQmMrQjqjoz44mqjDam7RkLlXFgthhzSSuMs9q4K09oZihDq3GL",
+	"5pj9Av6j7nMB+SHdP/tPtqUMhyzDGYSUyQMy69Ink8+OhyM0S2d4MxH58UQ8jpNyv7qAZoD5c8HP7b+j",
+	"orwz8hVGbImHky/MZE7N5CGdKUPnIGO5LxR3MmfulOXx3QLKWJg5Blp4w684wwU8uUNNphvCyRuKI0yR",
+	"c29vQnrXWtKjI+TRkBtRE3lJZz2Ox+Whulc4ebeALY4eHEfIMktYhLWnDiZ+pFnW0EzICjz6HKOpZuDj",
+	"ubcX+eZ+jrdSfbp2F9AS/ByHqcyMBHYfC7SqMs7SbNAOz3L0vDefzt25wO/4A60xThwxDs/TM5Ho0yJD",
+	"72R2Acdj+Rc8KNd2gbYyDKzFmvWd1omTBcw6qCQKemfWSOqSMcANFTdqM1HiHNhOxVl7w0TsaFxvhsD3",
+	"Vqtwpa1aW3O71jsebLU5tb2Ao/WEF2vaiNdmwYB1iF5j4pF42jPHOEPNdSOYsqvM2s0o3vQpjDHkxggK",
+	"VkgLhqUEnhYs4CAYLs247LvyziHt1qzmM2uPH7c8YbQUNK1cw7RavXuojciOBLqKyQZoXc1asw4TrD20",
+	"4SygtXcGW80aZRQlhesIWMvyWEiWoxVDuq0hKajTquMmtabmbw7VciNFLV5duekoTc1oo/s5DsKeJNpa",
+	"xthcp5XNwESus33ZokDV6pUTtJE0+sbyMB4Ot5SrcmzMz8QxPqzTLbE5MJWdvIArt149+lFh6TgKk0e6",
+	"/SFt5zLSHsi8wNEThxlUs/GmXd0va+qQaCZoddXWy85s7KwXcDtkqozj5PvcYlSToVXtILf5bKjOxE42",
+	"Yxh11JbpjqBOXNRq07XuvklYhJmahBJ3A2W7gMYgjzKYrYn7qEFIVBdThkNB2RoDbGhNJE4dYK2xh+W6",
+	"meRa1x2qWW84S0ZLOZ0RErqAMksLLJvL4qjFHGjGdbXQamtZz2tsDVw5mG35sp5xpk7jj9Q5CbGA1xgZ",
+	"M7H9Npo58YLmJxwzkWlTYCaA4WieKeR3v+F1WhAWsAlNllF5RuYygWNPerFZZbQqMwxHxzIbvuGYiUzL",
+	"pQoczUO47RJWjsOVLnYJyTeF5kGfalsTrrJ2bv001GeYWdai3zhLZ+IF6gIymczIvJPbBqudaYzMNbK+",
+	"TtdDLhAU/ML/pRnsDl2oHAyWWho4us1tSL7qAnbHCjZbKUx3NJ50x7n9wwYjlE8UjqYUDxvIe2ppBtkZ",
+	"nx7DzPgWzdGtkagfMipawLnY1tdQ24kjuM7KQvdkxSwu45lqpvJ0JrZCjmXpKSqw3pFPGFyxDC3yjtNK",
+	"FpARRUZXW5Bum3TT34+6zRYhs+JozDiiLGmTZaoo/G512DYbcndPdw98fTfvyTRNt3Yy6oYLaGQ0zdAy",
+	"PeAYgfZ4urYDvqdoDWFVrRHrmQUH1W1vV2WX64SX+W2jOZm4WDWNJiLPiiq3X0AmAu0RTnGHLF2puqYu",
+	"s0mNoubd1YaFO2OnTjSvB4JlU6IZjJZUZ8vUetgMi722bDth7C1glyY0fIUBgy+P+pO2MfSas6HeZWma",
+	"ZsyhIupKRtO0ytH8LNNo0RE0nswOuqFoFtdYbaoLuG31iUQFuBugOwpOUz/MXFI0MsJfsWJrZlQJf8Ct",
+	"/UGdNjUyKk/Xk4TvDIatiRQorGaYCziV0gjXBIZuj+h6zI7rIbaf0+UB2ehRQsMchLi/YadJV3fDUa83",
+	"b8fxNtnZqytONk6c1JYMT3tMTdwa4SSOCY0Uk3G2BIZf54h92NKnqMK5uDVxXSdjd1E7Ex3W3tQXMDRl",
+	"lkrK2NKjZGqnd4M+S4rlyZQQq7S2mgz2Xq8uquYHUbzILCDNgjSNSBWmy03gpIOoPSIC1y6bUmgdhqqy",
+	"CcnEAuU+h1VBy5rRfDdt7FpllE7qO8nrzxbQo7RO5vn7PlXblglvhg+bflYfNIYSSmLjrquLnTVGyofB",
+	"6KDtQdijY6mu0pzM+u3OiPNzfzHC10oaNhqzmueE2yFhxDCTFI9Xlc0+GAxm7irJ0ES30nCz3EwhWnPi",
+	"sRdOhmNuuo8tagE3/I5MarHoiKYc4LVZG9tKa1bl3c7axPdo3dFWK5+Za4m8HLpb0pzu9/K0ng5Na1in",
+	"Jaa/gCnwbDYc45S0m6Zhw6IwoulkfYyhQV1kxv0dntY7SnW0702teZDJdnUYtISMs1g73rft6gLOYwbP",
+	"uu3wMJyF9DhQm61whEldxxx7241U3io+47anrr+TLQVdNlCtqRxqvOj46hJ0iF5jAcWq2RKCKtMok7jb",
+	"81nRas6tBFqSqUnjpYdmHLrJwJbVbbq5lPz2trqM+bLYHB1q5prduwsYZ2U/alm7kbMZUQ19twGdRrOl",
+	"lZWQ3KCi2CtLHhZJnagJVwMGZTbT8DCGPDZjqp3u1hLjBUxncyndGPi6s0rLh8Ow5oyy9mg43zKe0kum",
+	"XVLZZWa1M6xPDr2BhWd9DFXFBtdxyK3tKVy8gO1JwGAm2Vl6Nafn0FQ6GB10IdhUt+QYmh1qFJVhs2vY",
+	"0O6aeEOi7KQqhIkH5T23Ijw9WsAWhs78jdkLwBRLW0HHsLzqNIwEf8WGcosYcrtGFKybHOMx1QX88Ky0",
+	"gNcVozUIHpUVWN8DMLmK1bVTqexXK2JvEL6b7ikxfVeP4OVL4cgslr05ANih74dZnlzfFwl1DyYgCoDl",
+	"5R+vBsYVxMuz8ftH5IDd2otA/F1PbvLq+SnwKfGCn1ca3tNxA/ThQSdnF0wG12e/X6g5nKYjcaInICie",
+	"PAe+8aE4kyGXVPkt2//QA+cPS30cr91sV8Gcc6XkBDAv6FQQH+g2YntRcXJ8t0MXMn+6ojToKYh5z50K",
+	"oscnDv10M9/W+uIR9rSziZ6kBbsBzIsyv+X16ijcFhhYAHrFw/pYLSx9e4dVpSSARMqSL6pWEq7AT+s8",
+	"0mT4jvzjxEeECSDRwKnS4nrra8Q+VfS5npzDC/SdeJxHoeh96adSauvRFsQJiEQYJzo0gcjd1rzjXMGe",
+	"YhBtQfSE3Za1a2SltNaTBES5jPzf3/SnA/00R5+az9+fvpX/9kjGxDhOwQPLtklPOZ1fMWxx9BNTdqUU",
+	"Zz2ITkvdEILViAb5U/sTRw93LN/fG5aBveQagun1PEkcHURM8cRYhBplsmJNXK2nY1ZqPoO9dLAmotfz",
+	"xJ28lFFlOCN63CoTvcwzglYyHxSDt7pAOprQ9PP3+qSFistwpwx5XF7KlMyJe1t9Hth+Z5dp0kAGnU4L",
+	"V4ekna1lINlErd9b1fbS+LtuqXGcUea121lmd1wg0Wbtgw1dLJ6+fyv/92Lx/Ojd/75/+X/+++Hm96AR",
+	"6pF1kbkvap13I6o/UoZH0v1aOWnfZ9T2lL87Vml/vt5oJHJ3k4rS7q9Wgh9ai3tc3i9TuWbNI1Ht6w5Q",
+	"LrXtW8UZugA51r1z11HYGiQJkXjlrRED2GEEcksfJbkSJSFihr4PzGOBPTrW5mOQPP+0Qp6jMMgbCwoE",
+	"Th1HOFr5EJv4Bp0IJGkEn2/6edDrdp7Hayame2tVv2Rvjj7n+6c9+7vw47XyBuTiuT4D4Tj4fc/QDayH",
+	"Ox6eM8qDPTS/RncOHnyylnxuAHjo7z+F4ZdMwpdQrJTSt/r55+vkH9D2Bu0hlemJyK9twb9JzeMfbKC5",
+	"25dLjeSupeUGvUebdRND/aKNiICeAOt0ynmLCXAUx55Q7IlAh2jjhUBfUHR+7Yyvj0HXjYPYAzdqeREw",
+	"zx11n40Fucuk10rJs3429caTnTtA9O8ni/OLZusdmC+vD7/iTe+gGH8MFcZXqTC+SsXRvvyZsnWnQEXj",
+	"15VE36DwaFMfsehDGfpwW36mkuzb9n3Fe3/F8Vb+c5y/B3+zeX+IRH8hJ/QeiXcRVuWXcg03OH1SEOMv",
+	"Hc5Pk9/3Y1ZKj+32dcBcMryLF9D90n30PHE9071JT8VnAYquYCMRMIG3BdddssdhXoSsAYiekYmXuIj+",
+	"vQAVfzcqSAj9/Q1khD6Die/hXI9i8pj9nKS5x/5tgVKlZJx/6A8TNoMiFXEdNvxizuYuOPk4fcDSN8nN",
+	"MwcF3detyAM+ckTlk0L6g3jjJj/5Q029iO8PGvp1oTafEvrcLtcSp7rXuLmlDZREJpr+YT5R9vOpJs05",
+	"TJpNsOHlNztfWlNpP59Q6Fjwk/lYQfM2uP6Qx5QDv5eHo6w3HAXzqZvpU8kvxgzRXY9zcGVoYjK3wiQo",
+	"uUagbY0hupeXNC4vR39/ZDjutu6W3kFfbLV4pBhzFiUPHi3UgwaU3xelu97+Renlt98XV4nhRellUcJq",
+	"DZLCagRJLEqVRSnvbves4gttDecmatYPcbNm1pytupOYmmrxNW4/SBV7W4xfp4bvmd9XYF/MkVurjM9m",
+	"7bxudViiLJ3XvI/PHK2anOrQ/A7rz7XM5gluHvc2uMygPao/sY34EOlrQbEDim9VsTCbUlDklGA59Iyq",
+	"srfrLGC3g67JmwQ6W+vGljacbrthxrjLHTD6739flF4rH9HXwN7TZztjnTP1IT3TDysBn9hNYpIIu0Cz",
+	"pjaNKsxX6Yu4wdIzI7gZjCCP7wEmhanNcELXSER5KbXGQge0e0lnSKUbn6l2hg0FJ6hpHE+dYVfVZPew",
+	"pjlTlslRdeab23C/alOBU9D3rbIoRcCOQOx+dz14pBAtEL3r0C++1Isv156geJ1Y2KL0+qEA3mZn3iSq",
+	"gPN8hPNshsHPr/aQjQdrFJHfDWDC1huUXSOfqDpWfyKpGv5kELb5hJvNGmHXarqt164XS1PPul2KuMvO",
+	"oU9N/cn+9nvj9enyTH7iGcNf//bQaMXATPPrIYPcXR2tpXvOoBU69qF/u51YvZtVXADzoB2e75bpZuHw",
+	"j1FDSfASN80tfxr5pZeSmyTr+KVadYrX+R5U2yDzQZL0dXOlR1bVOVvg0ruLZffGGbmkAIvbZvEamEd7",
+	"7oVFFsn3THBKN5ywode66QIEf0ZvMHqpVrMse9aLr89h5FRPU+NqV2R5ZcA/4c/os5sEBVaJl/jg5/g8",
+	"Ib01gPkTUay3BVF8JAR7Rp8xLAcVrgHU114uQs/oM1EqhMAtdqdaSOvTUVqrv9/lA1+rRwNaDF2nBctz",
+	"T1gQL1qll/M9rTQpgEZ6ABIQxbkFvU/HFfb4CBqB51xj6aVApVQ58+59QvLNDyZRCiqfvDr4/mT/7QgK",
+	"xAkTWvs/7I7iu7TMg7uKxwF58tEAyCk6fEdZ4fKvLlPiKPrAuaWmCeI4vz542Yd8h0m0+U+5dTl8C8+8",
+	"OA/0fVA5niqSMHq7O3UKLW72PNPjqztWe0SHYeKCCLloOnJOPSM6tBBXjxEdcT0nH3M22sj5YloYvY84",
+	"b5fzYiTQoe6cF0N0K/COvMLxP4xXH1xM+zHnHt3P+/E1s9fKW+D+GKGL6FTPl3CvzXGhj/eG+LdvuU7E",
+	"aRDo0b70UhoVm4PoCATZKfI2LoJb7PElWNWdXMVPOs2cTMS3fMVP2pNqvIdmYVTC+EOrkmdz/23NysOs",
+	"+wO5OuWii0uMvo/YwMoZeVHF+Hg79IZNZhpFACb+HlnBMIufP2uK/hTCjss8oqwVRsBzIHIjZcg5Rf5P",
+	"UYkcRTcKoXcA8QPWFor76DD3df049s483WV7zlpy5ww853S/+Adl6jfr8i6QYOlnJDdLXhynd7VuU48i",
+	"73QcPx2oRO5sZ3NeAZgUI60H9ruSn7fuu1sQL0EM4IfQiZEkLDJDI01EBrRSmHtTh4VzjIGVm5vrJQpQ",
+	"b6sU9ARpkuo+MuwOcum9NR6PGwT+XQ3Jj9slXl9f7yn4M5X/44a0B/r/Uazz56t9wTNER2I3jJIn39sC",
+	"Kxe1x41t0a18Xqn/kVrkWgg/ZQPytoqX30sOeOAZBZAoIJMmw+Gpov8vINR/kjDd9V39pSRIAAmiIxGA",
+	"IG96lCZDpOjAeDPGsR4AxPR1L4hzU5i/CiPP8aDuIyEEV1KUTz5u9qeEJzx2yFwJ0C1PxCQ3snlsvQxz",
+	"M12gdbK4YeQd7s2tBx87j9xoOyBBvCQu6KMLViNnsbyV2lPbzl9eXCv3GEk3PDo7qFzpT3w+ZnMKJDcp",
+	"iPZvWObsPXPjY/zepVDe7ZeV+0H77IwfnZaCEDq3H+NHR6I88w6QNYhyj67xp418fssnnGJFKywOJ3kT",
+	"pGeBYpIeAcQ7I2KdBfa/Tnr0Xxdcnj/gxfm7aJWuif/lxqs/1Z582Fv2wLJoRRNRXJwrj4L/UEiuwpg8",
+	"JLvenzzccXXffrRTz183UhcL1LvI5+lEd3s6hh8WIk5m56yyv2J0qvHW+7nlyReeUmjzaTAWOWQdgRjA",
+	"5K0l+k3C9fgjr/sH26tjGFyg48XIFkQnMb/6CzBv6v6DpEORl8gXuMmGVIq1g3xYkLeRFSPWHoQ3wfNb",
+	"lO0VgKMiwxJC8PyRNc0LaznO/3pW9T827T827Z9m044xlw7fbM5Xrdx14Tv+UYSu3Qz8iXpeQ0WOHQeI",
+	"HYUBot+StwZRvAZm4m3/fyjwIw0wbzpcPgv+XUPqQ3vFnVvtHi28PncAf3bNS8vwl5c79Tz/yoKnKX/6",
+	"IejhZY+/1Gmo68XJu9aR+PlKD2815te1sfr79U+Re/2sejL7f0kHyj1qxnmM1i1jvozVsRHxT5Xl24tH",
+	"f8Xj/JWlPvH/7gLfR/JcVFxN9704vrtY8B9h/EVh/BNqLx9d9ngYNL333l+q8/4P16D8uLVOqhyAedR/",
+	"10JYcDf+M91B1bxqtHx4OqXzC8oxAnTTReLijGDfq3wSFgeI44nzBPAs+sdatgctsAbQOlbXPu72+6FL",
+	"uvSE/scS/OXc0mVv/nLu6dzanRfy7vuwi6OzESYPGnr1+636B1TwdMX3OOqtPfahup0Pmzna912yl79n",
+	"/O4oV6SDroceO9vTdVFS9KDpp8XpMJ8MwS7JQR+JzP/+wPaYHlpHoROBOK4gcXhc6+oMe2TQ9fzTzcW3",
+	"BggkzrzEdEFh6L3k+ZE+v28x/neu1Lznxl9SfW56Xn7Qqn3SkLNMXhX5cz0pVs+/HLf5tiPRD03dd8M4",
+	"eY4z3XFA9OyFVX3tVbdE3rV6hv3uz5WfGXJyncci+Q2+YGe6OnRAXGQb40vjwpGPFwG6bUl4rfxgpaK0",
+	"eV3Bvyl5nOCdEyivlc/hfONQDZBkAMCbVeI32Lcm6PXb6/8bAIwtCL99YAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
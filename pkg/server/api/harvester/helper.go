@@ -3,8 +3,10 @@ package harvester
 import (
 	"fmt"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
@@ -46,3 +48,88 @@ func (b PutBundleRequest) ToEntity() (*entity.Bundle, error) {
 		SigningCertificate: cert,
 	}, nil
 }
+
+func (c ConsentSignature) ToEntity() (*entity.RelationshipConsent, error) {
+	statement, err := encoding.DecodeFromBase64(c.Statement)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode consent statement: %w", err)
+	}
+
+	sig, err := encoding.DecodeFromBase64(c.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode signature: %w", err)
+	}
+
+	cert, err := encoding.DecodeFromBase64(c.SigningCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode signing certificate: %w", err)
+	}
+
+	return &entity.RelationshipConsent{
+		Statement:          statement,
+		Signature:          sig,
+		SigningCertificate: cert,
+	}, nil
+}
+
+func (c RelationshipConsent) ToEntity() (*entity.RelationshipConsent, error) {
+	td, err := spiffeid.TrustDomainFromString(c.TrustDomainName)
+	if err != nil {
+		return nil, fmt.Errorf("malformed trust domain[%v]: %w", c.TrustDomainName, err)
+	}
+
+	consent, err := ConsentSignature{
+		Statement:          c.Statement,
+		Signature:          c.Signature,
+		SigningCertificate: c.SigningCertificate,
+	}.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+
+	consent.TrustDomainName = td
+	consent.ConsentStatus = entity.ConsentStatus(c.ConsentStatus)
+	consent.UpdatedAt = c.UpdatedAt
+
+	return consent, nil
+}
+
+// RelationshipConsentFromEntity maps a relationship consent entity to its API representation.
+func RelationshipConsentFromEntity(c *entity.RelationshipConsent) RelationshipConsent {
+	return RelationshipConsent{
+		TrustDomainName:    c.TrustDomainName.String(),
+		ConsentStatus:      api.ConsentStatus(c.ConsentStatus),
+		Statement:          encoding.EncodeToBase64(c.Statement),
+		Signature:          encoding.EncodeToBase64(c.Signature),
+		SigningCertificate: encoding.EncodeToBase64(c.SigningCertificate),
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
+
+// BundleValidationReportFromPolicyReport converts a bundle policy validation report to its API representation.
+func BundleValidationReportFromPolicyReport(r *bundlepolicy.Report) *BundleValidationReport {
+	report := &BundleValidationReport{
+		TrustDomain: r.TrustDomain.String(),
+		Valid:       r.Valid(),
+		Violations:  make([]BundlePolicyViolation, 0, len(r.Violations)),
+	}
+
+	if r.SequenceNumber != nil {
+		seq := int64(*r.SequenceNumber)
+		report.SequenceNumber = &seq
+	}
+
+	for _, v := range r.Violations {
+		violation := BundlePolicyViolation{
+			Rule:    v.Rule,
+			Message: v.Message,
+		}
+		if v.Authority != "" {
+			authority := v.Authority
+			violation.Authority = &authority
+		}
+		report.Violations = append(report.Violations, violation)
+	}
+
+	return report
+}
@@ -2,9 +2,13 @@ package harvester
 
 import (
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +47,58 @@ func TestBundlePutToEntity(t *testing.T) {
 		assert.Equal(t, []byte("test-certificate"), bundle.SigningCertificate)
 	})
 }
+
+func TestRelationshipConsentToEntity(t *testing.T) {
+	t.Run("Does not allow malformed signatures", func(t *testing.T) {
+		sig := ConsentSignature{
+			Statement:          encoding.EncodeToBase64([]byte("statement")),
+			Signature:          "not base64!",
+			SigningCertificate: encoding.EncodeToBase64([]byte("certificate")),
+		}
+
+		consent, err := sig.ToEntity()
+		assert.ErrorContains(t, err, "cannot decode signature")
+		assert.Nil(t, consent)
+	})
+
+	t.Run("Maps the consent entity back and forth", func(t *testing.T) {
+		ent := &entity.RelationshipConsent{
+			TrustDomainName:    spiffeid.RequireTrustDomainFromString("test.com"),
+			ConsentStatus:      entity.ConsentStatusApproved,
+			Statement:          []byte("test-statement"),
+			Signature:          []byte("test-signature"),
+			SigningCertificate: []byte("test-certificate"),
+			UpdatedAt:          time.Now().UTC().Truncate(time.Second),
+		}
+
+		consent, err := RelationshipConsentFromEntity(ent).ToEntity()
+		assert.NoError(t, err)
+		assert.Equal(t, ent, consent)
+	})
+}
+
+func TestBundleValidationReportFromPolicyReport(t *testing.T) {
+	seq := uint64(3)
+	report := &bundlepolicy.Report{
+		TrustDomain:    spiffeid.RequireTrustDomainFromString("test.com"),
+		SequenceNumber: &seq,
+		Violations: []*bundlepolicy.Violation{
+			{Rule: bundlepolicy.RuleSequenceNumber, Message: "sequence number 3 is lower than the sequence number 5 of the current bundle"},
+			{Rule: bundlepolicy.RuleValidity, Authority: "x509_authorities[0]", Message: "authority expired"},
+		},
+	}
+
+	authority := "x509_authorities[0]"
+	expectedSeq := int64(3)
+	expected := &BundleValidationReport{
+		TrustDomain:    "test.com",
+		SequenceNumber: &expectedSeq,
+		Valid:          false,
+		Violations: []BundlePolicyViolation{
+			{Rule: bundlepolicy.RuleSequenceNumber, Message: "sequence number 3 is lower than the sequence number 5 of the current bundle"},
+			{Rule: bundlepolicy.RuleValidity, Authority: &authority, Message: "authority expired"},
+		},
+	}
+
+	assert.Equal(t, expected, BundleValidationReportFromPolicyReport(report))
+}
@@ -0,0 +1,375 @@
+// Package bundleendpoint implements a SPIFFE bundle endpoint server that serves the bundles stored
+// in the Galadriel Server to the consumers that don't run a Harvester, like SPIRE Servers using
+// native federation or service mesh gateways.
+package bundleendpoint
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// Profile is the SPIFFE bundle endpoint profile, which defines how the endpoint authenticates to its clients.
+type Profile string
+
+const (
+	// ProfileHTTPSWeb authenticates the endpoint with a Web PKI certificate. The callers are not authenticated.
+	ProfileHTTPSWeb Profile = "https_web"
+
+	// ProfileHTTPSSPIFFE authenticates the endpoint with an X509-SVID issued by the X509CA of the server.
+	// The callers are authenticated by their X509-SVIDs.
+	ProfileHTTPSSPIFFE Profile = "https_spiffe"
+)
+
+const (
+	svidTTL           = 1 * time.Hour
+	readHeaderTimeout = 10 * time.Second
+	contentType       = "application/json"
+)
+
+// Config conveys the configuration of the bundle endpoint server.
+type Config struct {
+	// Address is the TCP address the bundle endpoint listens on.
+	Address *net.TCPAddr
+
+	Profile Profile
+
+	// CertFilePath and KeyFilePath are the PEM files of the Web PKI certificate and key served under https_web.
+	CertFilePath string
+	KeyFilePath  string
+
+	// SPIFFEID is the SPIFFE ID of the X509-SVID served under https_spiffe.
+	SPIFFEID spiffeid.ID
+}
+
+// Server serves the bundle of each trust domain on the path named after the trust domain, in SPIFFE bundle format.
+//
+// The bundle of a trust domain is served to the trust domains it has a relationship with that the peer approved.
+// Under https_spiffe, the caller is authenticated by its X509-SVID, verified against the stored bundle of its trust
+// domain, and only gets the bundles of the trust domains federated with its own. Under https_web, the caller is not
+// authenticated, and the bundle of a trust domain is served as long as any peer approved a relationship with it.
+type Server struct {
+	c         *Config
+	datastore db.Datastore
+	x509CA    x509ca.X509CA
+	logger    logrus.FieldLogger
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+
+	hooks struct {
+		// test hook used to signal that the listener is ready
+		listening chan net.Addr
+	}
+}
+
+// New creates a new bundle endpoint Server.
+func New(c *Config, datastore db.Datastore, x509CA x509ca.X509CA, logger logrus.FieldLogger) (*Server, error) {
+	if c.Address == nil {
+		return nil, errors.New("bundle endpoint address is required")
+	}
+
+	s := &Server{
+		c:         c,
+		datastore: datastore,
+		x509CA:    x509CA,
+		logger:    logger,
+	}
+
+	switch c.Profile {
+	case ProfileHTTPSWeb:
+		if c.CertFilePath == "" || c.KeyFilePath == "" {
+			return nil, fmt.Errorf("certificate and key files are required by the %s profile", ProfileHTTPSWeb)
+		}
+		cert, err := tls.LoadX509KeyPair(c.CertFilePath, c.KeyFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bundle endpoint certificate: %w", err)
+		}
+		s.cert = &cert
+	case ProfileHTTPSSPIFFE:
+		if c.SPIFFEID.IsZero() {
+			return nil, fmt.Errorf("a SPIFFE ID is required by the %s profile", ProfileHTTPSSPIFFE)
+		}
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile %q", c.Profile)
+	}
+
+	return s, nil
+}
+
+// ListenAndServe serves the bundle endpoint until the context is canceled.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return s.getCertificate(), nil
+		},
+	}
+
+	if s.c.Profile == ProfileHTTPSSPIFFE {
+		if err := s.rotateSVID(ctx); err != nil {
+			return err
+		}
+		go s.startSVIDRotation(ctx)
+
+		// the client X509-SVID is verified by the handler against the stored bundle of its trust domain
+		tlsConfig.ClientAuth = tls.RequireAnyClientCert
+	}
+
+	l, err := tls.Listen(s.c.Address.Network(), s.c.Address.String(), tlsConfig)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", s.c.Address, err)
+	}
+	defer l.Close()
+
+	server := &http.Server{
+		Handler:           http.HandlerFunc(s.serveBundle),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	log := s.logger.WithFields(logrus.Fields{
+		telemetry.Network: l.Addr().Network(),
+		telemetry.Address: l.Addr().String()})
+
+	errChan := make(chan error)
+	go func() {
+		s.triggerListeningHook(l.Addr())
+		log.WithField("profile", s.c.Profile).Info("Started bundle endpoint listener")
+		errChan <- server.Serve(l)
+	}()
+
+	select {
+	case err = <-errChan:
+		log.WithError(err).Error("Bundle endpoint listener stopped prematurely")
+		return err
+	case <-ctx.Done():
+		log.Info("Stopping bundle endpoint listener")
+		if err := server.Close(); err != nil {
+			log.WithError(err).Error("Error closing bundle endpoint listener")
+		}
+		<-errChan
+		log.Info("Bundle endpoint listener stopped")
+		return nil
+	}
+}
+
+func (s *Server) serveBundle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+
+	td, err := spiffeid.TrustDomainFromString(strings.TrimPrefix(r.URL.Path, "/"))
+	if err != nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	log := s.logger.WithField(telemetry.TrustDomain, td)
+
+	var caller *entity.TrustDomain
+	if s.c.Profile == ProfileHTTPSSPIFFE {
+		caller, err = s.authenticateCaller(ctx, r.TLS.PeerCertificates)
+		if err != nil {
+			log.WithError(err).Debug("Failed to authenticate bundle endpoint caller")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	bundle, status, err := s.findServedBundle(ctx, td, caller)
+	if err != nil {
+		if status == http.StatusInternalServerError {
+			log.WithError(err).Error("Failed to look up bundle")
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if _, err := w.Write(bundle.Data); err != nil {
+		log.WithError(err).Debug("Failed to write bundle")
+	}
+}
+
+// findServedBundle returns the bundle of the trust domain if the caller is allowed to get it,
+// or the HTTP status the request has to be answered with.
+func (s *Server) findServedBundle(ctx context.Context, td spiffeid.TrustDomain, caller *entity.TrustDomain) (*entity.Bundle, int, error) {
+	trustDomain, err := s.datastore.FindTrustDomainByName(ctx, td)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if trustDomain == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("trust domain %q not found", td)
+	}
+
+	allowed, err := s.isAllowed(ctx, trustDomain, caller)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !allowed {
+		return nil, http.StatusForbidden, fmt.Errorf("bundle of trust domain %q not allowed", td)
+	}
+
+	bundle, err := s.datastore.FindBundleByTrustDomainID(ctx, trustDomain.ID.UUID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if bundle == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("bundle of trust domain %q not found", td)
+	}
+
+	return bundle, http.StatusOK, nil
+}
+
+// isAllowed tells whether the bundle of the trust domain can be served to the caller, nil if it is not
+// authenticated, following the relationships the trust domain has with its peers. The bundle is only served to
+// the peers that trust the trust domain, according to the direction of the relationship.
+func (s *Server) isAllowed(ctx context.Context, trustDomain, caller *entity.TrustDomain) (bool, error) {
+	if caller != nil && caller.ID.UUID == trustDomain.ID.UUID {
+		return true, nil
+	}
+
+	relationships, err := s.datastore.FindRelationshipsByTrustDomainID(ctx, trustDomain.ID.UUID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, r := range relationships {
+		peerID, peerConsent := r.TrustDomainBID, r.TrustDomainBConsent
+		if r.TrustDomainBID == trustDomain.ID.UUID {
+			peerID, peerConsent = r.TrustDomainAID, r.TrustDomainAConsent
+		}
+
+		if peerConsent != entity.ConsentStatusApproved || !r.Trusts(peerID) {
+			continue
+		}
+		if caller == nil || caller.ID.UUID == peerID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// authenticateCaller verifies the X509-SVID of the caller against the stored bundle of its trust domain,
+// and returns the trust domain.
+func (s *Server) authenticateCaller(ctx context.Context, certs []*x509.Certificate) (*entity.TrustDomain, error) {
+	if len(certs) == 0 {
+		return nil, errors.New("no client certificate")
+	}
+
+	id, err := x509svid.IDFromCert(certs[0])
+	if err != nil {
+		return nil, fmt.Errorf("client certificate is not an X509-SVID: %w", err)
+	}
+
+	trustDomain, err := s.datastore.FindTrustDomainByName(ctx, id.TrustDomain())
+	if err != nil {
+		return nil, err
+	}
+	if trustDomain == nil {
+		return nil, fmt.Errorf("trust domain %q not found", id.TrustDomain())
+	}
+
+	bundle, err := s.datastore.FindBundleByTrustDomainID(ctx, trustDomain.ID.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if bundle == nil {
+		return nil, fmt.Errorf("bundle of trust domain %q not found", id.TrustDomain())
+	}
+
+	spiffeBundle, err := spiffebundle.Parse(id.TrustDomain(), bundle.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bundle of trust domain %q: %w", id.TrustDomain(), err)
+	}
+
+	if _, _, err := x509svid.Verify(certs, spiffeBundle); err != nil {
+		return nil, fmt.Errorf("failed to verify X509-SVID %q: %w", id, err)
+	}
+
+	return trustDomain, nil
+}
+
+func (s *Server) startSVIDRotation(ctx context.Context) {
+	ticker := time.NewTicker(svidTTL / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.rotateSVID(ctx); err != nil {
+				s.logger.WithError(err).Error("Failed to rotate bundle endpoint X509-SVID")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// rotateSVID issues a new X509-SVID for the bundle endpoint.
+func (s *Server) rotateSVID(ctx context.Context) error {
+	privateKey, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	if err != nil {
+		return fmt.Errorf("failed to create private key: %w", err)
+	}
+
+	id := s.c.SPIFFEID.URL()
+	params := &x509ca.X509CertificateParams{
+		Subject:   pkix.Name{CommonName: s.c.SPIFFEID.TrustDomain().String()},
+		URIs:      []*url.URL{id},
+		TTL:       svidTTL,
+		PublicKey: privateKey.Public(),
+	}
+	if err := params.SetCertificateRequest(privateKey); err != nil {
+		return err
+	}
+	chain, err := s.x509CA.IssueX509Certificate(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to issue bundle endpoint X509-SVID: %w", err)
+	}
+
+	cert := &tls.Certificate{PrivateKey: privateKey, Leaf: chain[0]}
+	for _, c := range chain {
+		cert.Certificate = append(cert.Certificate, c.Raw)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cert = cert
+
+	return nil
+}
+
+func (s *Server) getCertificate() *tls.Certificate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cert
+}
+
+func (s *Server) triggerListeningHook(addr net.Addr) {
+	if s.hooks.listening != nil {
+		s.hooks.listening <- addr
+	}
+}
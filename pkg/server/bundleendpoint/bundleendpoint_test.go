@@ -0,0 +1,365 @@
+package bundleendpoint
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	clk = clock.New()
+
+	td1 = spiffeid.RequireTrustDomainFromString("td1.org")
+	td2 = spiffeid.RequireTrustDomainFromString("td2.org")
+	td3 = spiffeid.RequireTrustDomainFromString("td3.org")
+
+	endpointID = spiffeid.RequireFromString("spiffe://galadriel.org/bundle-endpoint")
+)
+
+type testSetup struct {
+	x509CA    *disk.X509CA
+	rootCA    *x509.Certificate
+	tempDir   string
+	datastore *fakedatastore.FakeDatabase
+	bundles   map[spiffeid.TrustDomain][]byte
+
+	// CA of td1, that issues the X509-SVIDs of the callers of td1
+	td1CA    *x509.Certificate
+	td1CAKey crypto.PrivateKey
+}
+
+func TestHTTPSSPIFFE(t *testing.T) {
+	setup := newTestSetup(t)
+	addr := setup.startServer(t, &Config{
+		Profile:  ProfileHTTPSSPIFFE,
+		SPIFFEID: endpointID,
+	})
+
+	callerSVID := setup.newSVID(t, setup.td1CA, setup.td1CAKey, "spiffe://td1.org/gateway")
+	client := setup.newSPIFFEClient(callerSVID)
+
+	testCases := []struct {
+		name   string
+		td     string
+		status int
+	}{
+		{name: "bundle of a federated trust domain", td: td2.String(), status: http.StatusOK},
+		{name: "bundle of the caller trust domain", td: td1.String(), status: http.StatusOK},
+		{name: "bundle of a trust domain not federated with the caller", td: td3.String(), status: http.StatusForbidden},
+		{name: "unknown trust domain", td: "unknown.org", status: http.StatusNotFound},
+		{name: "invalid trust domain", td: "Invalid TD", status: http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := get(t, client, fmt.Sprintf("https://%s/%s", addr, url.PathEscape(tc.td)))
+			require.Equal(t, tc.status, status)
+			if tc.status == http.StatusOK {
+				assert.Equal(t, setup.bundles[spiffeid.RequireTrustDomainFromString(tc.td)], body)
+			}
+		})
+	}
+
+	t.Run("caller with an untrusted X509-SVID", func(t *testing.T) {
+		untrustedCA, untrustedKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+		svid := setup.newSVID(t, untrustedCA, untrustedKey, "spiffe://td1.org/gateway")
+
+		status, _ := get(t, setup.newSPIFFEClient(svid), fmt.Sprintf("https://%s/%s", addr, td2))
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("caller of an unknown trust domain", func(t *testing.T) {
+		svid := setup.newSVID(t, setup.td1CA, setup.td1CAKey, "spiffe://unknown.org/gateway")
+
+		status, _ := get(t, setup.newSPIFFEClient(svid), fmt.Sprintf("https://%s/%s", addr, td2))
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+}
+
+func TestHTTPSWeb(t *testing.T) {
+	setup := newTestSetup(t)
+	certFile, keyFile := setup.writeWebCertificate(t)
+	addr := setup.startServer(t, &Config{
+		Profile:      ProfileHTTPSWeb,
+		CertFilePath: certFile,
+		KeyFilePath:  keyFile,
+	})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(setup.rootCA)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	// td1 approved the relationship with td2, so the bundle of td2 is published
+	status, body := get(t, client, fmt.Sprintf("https://localhost:%d/%s", addr.Port, td2))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, setup.bundles[td2], body)
+
+	// td2 did not approve the relationship with td1, and td3 has no relationships
+	status, _ = get(t, client, fmt.Sprintf("https://localhost:%d/%s", addr.Port, td1))
+	require.Equal(t, http.StatusForbidden, status)
+	status, _ = get(t, client, fmt.Sprintf("https://localhost:%d/%s", addr.Port, td3))
+	require.Equal(t, http.StatusForbidden, status)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("https://localhost:%d/%s", addr.Port, td2), nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestOneWayRelationship(t *testing.T) {
+	setup := newTestSetup(t)
+	trustDomain1, err := setup.datastore.FindTrustDomainByName(context.Background(), td1)
+	require.NoError(t, err)
+	trustDomain3, err := setup.datastore.FindTrustDomainByName(context.Background(), td3)
+	require.NoError(t, err)
+
+	// td3 trusts td1, but td1 does not trust td3
+	setup.datastore.WithRelationships(&entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      trustDomain1.ID.UUID,
+		TrustDomainBID:      trustDomain3.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusApproved,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+		Direction:           entity.RelationshipBTrustsA,
+	})
+
+	certFile, keyFile := setup.writeWebCertificate(t)
+	addr := setup.startServer(t, &Config{
+		Profile:      ProfileHTTPSWeb,
+		CertFilePath: certFile,
+		KeyFilePath:  keyFile,
+	})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(setup.rootCA)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	status, body := get(t, client, fmt.Sprintf("https://localhost:%d/%s", addr.Port, td1))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, setup.bundles[td1], body)
+
+	status, _ = get(t, client, fmt.Sprintf("https://localhost:%d/%s", addr.Port, td3))
+	require.Equal(t, http.StatusForbidden, status)
+}
+
+func TestNew(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	logger, _ := test.NewNullLogger()
+
+	testCases := []struct {
+		name   string
+		config *Config
+		err    string
+	}{
+		{
+			name:   "missing address",
+			config: &Config{Profile: ProfileHTTPSSPIFFE, SPIFFEID: endpointID},
+			err:    "bundle endpoint address is required",
+		},
+		{
+			name:   "unknown profile",
+			config: &Config{Address: addr, Profile: "https"},
+			err:    `unknown bundle endpoint profile "https"`,
+		},
+		{
+			name:   "https_web without certificate",
+			config: &Config{Address: addr, Profile: ProfileHTTPSWeb},
+			err:    "certificate and key files are required by the https_web profile",
+		},
+		{
+			name:   "https_web with missing certificate",
+			config: &Config{Address: addr, Profile: ProfileHTTPSWeb, CertFilePath: "/not-found.crt", KeyFilePath: "/not-found.key"},
+			err:    "failed to load bundle endpoint certificate",
+		},
+		{
+			name:   "https_spiffe without SPIFFE ID",
+			config: &Config{Address: addr, Profile: ProfileHTTPSSPIFFE},
+			err:    "a SPIFFE ID is required by the https_spiffe profile",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.config, fakedatastore.NewFakeDB(), nil, logger)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func newTestSetup(t *testing.T) *testSetup {
+	tempDir := certtest.CreateTestCACertificates(t, clk)
+	t.Cleanup(func() { os.RemoveAll(tempDir) })
+
+	x509CA, err := disk.New()
+	require.NoError(t, err)
+	err = x509CA.Configure(&disk.Config{
+		CertFilePath: filepath.Join(tempDir, "root-ca.crt"),
+		KeyFilePath:  filepath.Join(tempDir, "root-ca.key"),
+	})
+	require.NoError(t, err)
+
+	rootCA, err := cryptoutil.LoadCertificate(filepath.Join(tempDir, "root-ca.crt"))
+	require.NoError(t, err)
+
+	setup := &testSetup{
+		x509CA:    x509CA,
+		rootCA:    rootCA,
+		tempDir:   tempDir,
+		datastore: fakedatastore.NewFakeDB(),
+		bundles:   make(map[spiffeid.TrustDomain][]byte),
+	}
+	setup.td1CA, setup.td1CAKey = certtest.CreateTestSelfSignedCACertificate(t, clk)
+
+	trustDomains := make(map[spiffeid.TrustDomain]*entity.TrustDomain)
+	var bundles []*entity.Bundle
+	for _, td := range []spiffeid.TrustDomain{td1, td2, td3} {
+		trustDomains[td] = &entity.TrustDomain{
+			ID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			Name: td,
+		}
+
+		ca := setup.td1CA
+		if td != td1 {
+			ca, _ = certtest.CreateTestSelfSignedCACertificate(t, clk)
+		}
+		data, err := spiffebundle.FromX509Authorities(td, []*x509.Certificate{ca}).Marshal()
+		require.NoError(t, err)
+		setup.bundles[td] = data
+
+		bundles = append(bundles, &entity.Bundle{
+			ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			Data:          data,
+			TrustDomainID: trustDomains[td].ID.UUID,
+		})
+	}
+
+	setup.datastore.WithTrustDomains(trustDomains[td1], trustDomains[td2], trustDomains[td3])
+	setup.datastore.WithBundles(bundles...)
+	setup.datastore.WithRelationships(&entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      trustDomains[td1].ID.UUID,
+		TrustDomainBID:      trustDomains[td2].ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusApproved,
+		TrustDomainBConsent: entity.ConsentStatusPending,
+	})
+
+	return setup
+}
+
+func (s *testSetup) startServer(t *testing.T, c *Config) *net.TCPAddr {
+	logger, _ := test.NewNullLogger()
+	c.Address = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+
+	server, err := New(c, s.datastore, s.x509CA, logger)
+	require.NoError(t, err)
+	server.hooks.listening = make(chan net.Addr, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.ListenAndServe(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	select {
+	case addr := <-server.hooks.listening:
+		return addr.(*net.TCPAddr)
+	case err := <-errCh:
+		t.Fatalf("bundle endpoint failed to start: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the bundle endpoint to start")
+	}
+	return nil
+}
+
+func (s *testSetup) newSVID(t *testing.T, ca *x509.Certificate, caKey crypto.PrivateKey, id string) *x509svid.SVID {
+	key, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	require.NoError(t, err)
+
+	template, err := cryptoutil.CreateX509Template(clk, key.Public(), pkix.Name{CommonName: "svid"}, []*url.URL{spiffeid.RequireFromString(id).URL()}, nil, time.Hour)
+	require.NoError(t, err)
+	cert, err := cryptoutil.SignX509(template, ca, caKey)
+	require.NoError(t, err)
+
+	return &x509svid.SVID{
+		ID:           spiffeid.RequireFromString(id),
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}
+}
+
+// newSPIFFEClient returns a client that authenticates with the X509-SVID, and authenticates the
+// bundle endpoint by its X509-SVID, verified with the root CA of the Galadriel Server.
+func (s *testSetup) newSPIFFEClient(svid *x509svid.SVID) *http.Client {
+	endpointBundle := x509bundle.FromX509Authorities(endpointID.TrustDomain(), []*x509.Certificate{s.rootCA})
+	tlsConfig := tlsconfig.MTLSClientConfig(svid, endpointBundle, tlsconfig.AuthorizeID(endpointID))
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+}
+
+func (s *testSetup) writeWebCertificate(t *testing.T) (string, string) {
+	key, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	require.NoError(t, err)
+
+	chain, err := s.x509CA.IssueX509Certificate(context.Background(), &x509ca.X509CertificateParams{
+		Subject:   pkix.Name{CommonName: "localhost"},
+		DNSNames:  []string{"localhost"},
+		TTL:       time.Hour,
+		PublicKey: key.Public(),
+	})
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	certFile := filepath.Join(s.tempDir, "web.crt")
+	keyFile := filepath.Join(s.tempDir, "web.key")
+	require.NoError(t, os.WriteFile(certFile, cryptoutil.EncodeCertificate(chain[0]), 0600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	return certFile, keyFile
+}
+
+func get(t *testing.T, client *http.Client, u string) (int, []byte) {
+	resp, err := client.Get(u)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, body
+}
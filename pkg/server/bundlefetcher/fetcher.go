@@ -0,0 +1,277 @@
+// Package bundlefetcher keeps the bundles of the external trust domains, which don't run a Harvester, up to date
+// by fetching them periodically from their SPIFFE bundle endpoints.
+package bundlefetcher
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleendpoint"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/ha"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/federation"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+const (
+	// DefaultRefreshInterval is how often the bundle of an external trust domain is fetched
+	// when the stored bundle has no refresh hint.
+	DefaultRefreshInterval = 5 * time.Minute
+
+	// DefaultPollInterval is how often the fetcher looks for the external trust domains whose bundle is due
+	// for a refresh. Failed refreshes are retried on the next poll.
+	DefaultPollInterval = 30 * time.Second
+
+	// fetcherJob is the job that fetches the external bundles, run by a single server replica at a time.
+	fetcherJob = "external-bundle-fetcher"
+
+	fetchTimeout = 30 * time.Second
+)
+
+// Config conveys the configuration of the bundle Fetcher.
+type Config struct {
+	Datastore    db.Datastore
+	BundlePolicy *bundlepolicy.Policy
+	Notifier     notification.Notifier
+	Coordinator  ha.Coordinator
+	Logger       logrus.FieldLogger
+
+	// PollInterval is how often the fetcher looks for bundles due for a refresh. DefaultPollInterval when zero.
+	PollInterval time.Duration
+
+	// WebPKIRoots authenticate the bundle endpoints of the https_web profile. The system roots are used when nil.
+	WebPKIRoots *x509.CertPool
+}
+
+// Fetcher fetches the bundles of the external trust domains from their SPIFFE bundle endpoints, validates them
+// against the bundle policy, and stores them as the bundles of the trust domains, like the uploads of a Harvester.
+type Fetcher struct {
+	c            *Config
+	pollInterval time.Duration
+	clk          clock.Clock
+}
+
+// New creates a new bundle Fetcher.
+func New(c *Config) *Fetcher {
+	pollInterval := c.PollInterval
+	if pollInterval == 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &Fetcher{
+		c:            c,
+		pollInterval: pollInterval,
+		clk:          clock.New(),
+	}
+}
+
+// Run refreshes the bundles of the external trust domains until the context is canceled.
+// In high availability, only the replica elected leader of the fetcher job refreshes them.
+func (f *Fetcher) Run(ctx context.Context) error {
+	f.c.Logger.Info("Starting external bundle fetcher")
+
+	err := f.c.Coordinator.RunAsLeader(ctx, fetcherJob, f.run)
+	if ctx.Err() != nil {
+		f.c.Logger.Info("External bundle fetcher stopped")
+		return nil
+	}
+	return err
+}
+
+func (f *Fetcher) run(ctx context.Context) error {
+	ticker := time.NewTicker(f.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		f.refreshDue(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// refreshDue refreshes the bundles of the external trust domains that are due for a refresh.
+func (f *Fetcher) refreshDue(ctx context.Context) {
+	externalTDs, err := f.c.Datastore.ListExternalTrustDomains(ctx)
+	if err != nil {
+		f.c.Logger.WithError(err).Error("Failed to list external trust domains")
+		return
+	}
+
+	for _, externalTD := range externalTDs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		td, err := f.c.Datastore.FindTrustDomainByID(ctx, externalTD.TrustDomainID)
+		if err != nil || td == nil {
+			f.c.Logger.WithError(err).Errorf("Failed to look up external trust domain ID=%q", externalTD.TrustDomainID)
+			continue
+		}
+
+		due, err := f.isDue(ctx, td, externalTD)
+		if err != nil {
+			f.c.Logger.WithError(err).WithField(telemetry.TrustDomain, td.Name.String()).Error("Failed to check external bundle refresh")
+			continue
+		}
+		if due {
+			_ = f.refreshExternalTrustDomain(ctx, td, externalTD)
+		}
+	}
+}
+
+// isDue tells whether the bundle of the external trust domain is due for a refresh: it was never refreshed,
+// the last refresh failed, or the refresh hint of the stored bundle elapsed since the last refresh.
+func (f *Fetcher) isDue(ctx context.Context, td *entity.TrustDomain, externalTD *entity.ExternalTrustDomain) (bool, error) {
+	if externalTD.LastRefreshAt.IsZero() || externalTD.LastRefreshError != "" {
+		return true, nil
+	}
+
+	refreshInterval := DefaultRefreshInterval
+	stored, err := f.c.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		return false, err
+	}
+	if stored != nil {
+		if bundle, err := spiffebundle.Parse(td.Name, stored.Data); err == nil {
+			if hint, ok := bundle.RefreshHint(); ok && hint > 0 {
+				refreshInterval = hint
+			}
+		}
+	}
+
+	return !f.clk.Now().Before(externalTD.LastRefreshAt.Add(refreshInterval)), nil
+}
+
+// refreshExternalTrustDomain fetches the bundle of the external trust domain, stores it when it is valid,
+// and records the outcome of the refresh.
+func (f *Fetcher) refreshExternalTrustDomain(ctx context.Context, td *entity.TrustDomain, externalTD *entity.ExternalTrustDomain) error {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	log := f.c.Logger.WithField(telemetry.TrustDomain, td.Name.String())
+
+	changed, refreshErr := f.refresh(ctx, td, externalTD)
+	switch {
+	case refreshErr != nil:
+		log.WithError(refreshErr).Warn("Failed to refresh external bundle")
+		externalTD.LastRefreshError = refreshErr.Error()
+	case changed:
+		log.Info("Stored new external bundle")
+		externalTD.LastRefreshError = ""
+	default:
+		log.Debug("External bundle is up to date")
+		externalTD.LastRefreshError = ""
+	}
+	externalTD.LastRefreshAt = f.clk.Now()
+
+	if _, err := f.c.Datastore.UpdateExternalTrustDomainRefresh(ctx, externalTD); err != nil {
+		log.WithError(err).Error("Failed to record external bundle refresh")
+	}
+
+	return refreshErr
+}
+
+func (f *Fetcher) refresh(ctx context.Context, td *entity.TrustDomain, externalTD *entity.ExternalTrustDomain) (bool, error) {
+	stored, err := f.c.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		return false, fmt.Errorf("failed looking up bundle: %w", err)
+	}
+
+	var previousData []byte
+	if stored != nil {
+		previousData = stored.Data
+	}
+
+	option, err := f.fetchOption(td.Name, externalTD, previousData)
+	if err != nil {
+		return false, err
+	}
+
+	fetched, err := federation.FetchBundle(ctx, td.Name, externalTD.BundleEndpointURL, option)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch bundle: %w", err)
+	}
+
+	if stored != nil {
+		if previous, err := spiffebundle.Parse(td.Name, previousData); err == nil && previous.Equal(fetched) {
+			return false, nil
+		}
+	}
+
+	data, err := fetched.Marshal()
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	report := f.c.BundlePolicy.Validate(td.Name, data, previousData, f.clk.Now())
+	if !report.Valid() {
+		return false, fmt.Errorf("bundle does not comply with the bundle policy: %s", report.String())
+	}
+
+	bundle := &entity.Bundle{
+		Data:          data,
+		Digest:        cryptoutil.CalculateDigest(data),
+		TrustDomainID: td.ID.UUID,
+		// the bundle endpoint authenticated the bundle, which is not signed. It is not admin-managed: the harvester
+		// uploads are refused because the trust domain is external, and the admin can still reconfigure it
+		VerificationStatus: entity.BundleVerificationSkipped,
+	}
+	if stored != nil {
+		bundle.ID = stored.ID
+	}
+
+	if _, err := f.c.Datastore.CreateOrUpdateBundle(ctx, bundle); err != nil {
+		return false, fmt.Errorf("failed to store bundle: %w", err)
+	}
+
+	f.c.Notifier.Notify(notification.NewBundleUpdatedEvent(td, bundle))
+
+	return true, nil
+}
+
+// fetchOption returns the authentication of the bundle endpoint of the external trust domain. Under https_spiffe,
+// the endpoint is authenticated with the stored bundle of the trust domain, or with the bootstrap bundle until a
+// bundle is stored.
+func (f *Fetcher) fetchOption(td spiffeid.TrustDomain, externalTD *entity.ExternalTrustDomain, storedData []byte) (federation.FetchOption, error) {
+	switch bundleendpoint.Profile(externalTD.BundleEndpointProfile) {
+	case bundleendpoint.ProfileHTTPSWeb:
+		return federation.WithWebPKIRoots(f.c.WebPKIRoots), nil
+	case bundleendpoint.ProfileHTTPSSPIFFE:
+		endpointID, err := spiffeid.FromString(externalTD.EndpointSPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle endpoint SPIFFE ID: %w", err)
+		}
+
+		trustData := storedData
+		if trustData == nil {
+			trustData = externalTD.BootstrapBundle
+		}
+		if trustData == nil {
+			return nil, errors.New("no bundle to authenticate the bundle endpoint with")
+		}
+
+		trustBundle, err := spiffebundle.Parse(td, trustData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the bundle that authenticates the bundle endpoint: %w", err)
+		}
+
+		return federation.WithSPIFFEAuth(trustBundle, endpointID), nil
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile %q", externalTD.BundleEndpointProfile)
+	}
+}
@@ -0,0 +1,325 @@
+package bundlefetcher
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleendpoint"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/ha"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakenotifier"
+	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/federation"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	externalTD = spiffeid.RequireTrustDomainFromString("external.org")
+	endpointID = spiffeid.RequireFromString("spiffe://external.org/spire/server")
+)
+
+type testSetup struct {
+	fetcher    *Fetcher
+	datastore  *fakedatastore.FakeDatabase
+	notifier   *fakenotifier.Notifier
+	clk        clock.FakeClock
+	td         *entity.TrustDomain
+	externalTD *entity.ExternalTrustDomain
+
+	// CA of the external trust domain, that issues the X509-SVID of its bundle endpoint
+	ca    *x509.Certificate
+	caKey crypto.PrivateKey
+	// bundle served by the bundle endpoint of the external trust domain
+	bundle *spiffebundle.Bundle
+}
+
+func TestRefreshHTTPSWeb(t *testing.T) {
+	setup := newTestSetup(t, nil)
+	server := httptest.NewTLSServer(setup.bundleHandler(t))
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	setup.fetcher.c.WebPKIRoots = roots
+	setup.configure(bundleendpoint.ProfileHTTPSWeb, server.URL, nil)
+
+	setup.fetcher.refreshDue(context.Background())
+
+	setup.requireStoredBundle(t, setup.bundle)
+	setup.requireRefresh(t, "")
+	require.Len(t, setup.notifier.Events(), 1)
+	assert.Equal(t, notification.EventBundleUpdated, setup.notifier.Events()[0].Type)
+
+	// Refreshing an unchanged bundle does not notify it again
+	setup.clk.Add(DefaultRefreshInterval)
+	setup.fetcher.refreshDue(context.Background())
+	setup.requireRefresh(t, "")
+	require.Len(t, setup.notifier.Events(), 1)
+}
+
+func TestRefreshHTTPSSPIFFE(t *testing.T) {
+	setup := newTestSetup(t, nil)
+	server := setup.newSPIFFEBundleEndpoint(t)
+	defer server.Close()
+
+	t.Run("bundle endpoint not authenticated by the bootstrap bundle", func(t *testing.T) {
+		otherCA, _ := certtest.CreateTestSelfSignedCACertificate(t, setup.clk)
+		setup.configure(bundleendpoint.ProfileHTTPSSPIFFE, server.URL, spiffebundle.FromX509Authorities(externalTD, []*x509.Certificate{otherCA}))
+
+		setup.fetcher.refreshDue(context.Background())
+
+		setup.requireStoredBundle(t, nil)
+		setup.requireRefresh(t, "failed to fetch bundle")
+	})
+
+	t.Run("bundle endpoint authenticated by the bootstrap bundle", func(t *testing.T) {
+		setup.configure(bundleendpoint.ProfileHTTPSSPIFFE, server.URL, setup.bundle)
+
+		setup.fetcher.refreshDue(context.Background())
+
+		setup.requireStoredBundle(t, setup.bundle)
+		setup.requireRefresh(t, "")
+	})
+
+	t.Run("bundle endpoint authenticated by the stored bundle", func(t *testing.T) {
+		// the bundle endpoint rotates its CA, announcing the new one in the bundle
+		newCA, newCAKey := certtest.CreateTestSelfSignedCACertificate(t, setup.clk)
+		setup.bundle.AddX509Authority(newCA)
+		setup.clk.Add(DefaultRefreshInterval)
+		setup.fetcher.refreshDue(context.Background())
+		setup.requireStoredBundle(t, setup.bundle)
+
+		setup.ca, setup.caKey = newCA, newCAKey
+		rotated := setup.newSPIFFEBundleEndpoint(t)
+		defer rotated.Close()
+		setup.configure(bundleendpoint.ProfileHTTPSSPIFFE, rotated.URL, nil)
+		setup.bundle.SetRefreshHint(time.Minute)
+
+		setup.fetcher.refreshDue(context.Background())
+
+		setup.requireStoredBundle(t, setup.bundle)
+		setup.requireRefresh(t, "")
+		assert.Len(t, setup.notifier.Events(), 3)
+	})
+}
+
+func TestRefreshInvalidBundle(t *testing.T) {
+	setup := newTestSetup(t, &bundlepolicy.Config{MaxX509Authorities: 1})
+	otherCA, _ := certtest.CreateTestSelfSignedCACertificate(t, setup.clk)
+	setup.bundle.AddX509Authority(otherCA)
+
+	server := httptest.NewTLSServer(setup.bundleHandler(t))
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	setup.fetcher.c.WebPKIRoots = roots
+	setup.configure(bundleendpoint.ProfileHTTPSWeb, server.URL, nil)
+
+	setup.fetcher.refreshDue(context.Background())
+
+	setup.requireStoredBundle(t, nil)
+	setup.requireRefresh(t, "bundle does not comply with the bundle policy: bundle has 2 X.509 authorities, the maximum is 1")
+	assert.Empty(t, setup.notifier.Events())
+}
+
+func TestIsDue(t *testing.T) {
+	setup := newTestSetup(t, nil)
+	ctx := context.Background()
+	setup.configure(bundleendpoint.ProfileHTTPSWeb, "https://localhost", nil)
+
+	due, err := setup.fetcher.isDue(ctx, setup.td, setup.externalTD)
+	require.NoError(t, err)
+	assert.True(t, due, "never refreshed")
+
+	setup.externalTD.LastRefreshAt = setup.clk.Now()
+	setup.externalTD.LastRefreshError = "connection refused"
+	due, err = setup.fetcher.isDue(ctx, setup.td, setup.externalTD)
+	require.NoError(t, err)
+	assert.True(t, due, "last refresh failed")
+
+	setup.externalTD.LastRefreshError = ""
+	due, err = setup.fetcher.isDue(ctx, setup.td, setup.externalTD)
+	require.NoError(t, err)
+	assert.False(t, due, "refreshed recently")
+
+	setup.clk.Add(DefaultRefreshInterval)
+	due, err = setup.fetcher.isDue(ctx, setup.td, setup.externalTD)
+	require.NoError(t, err)
+	assert.True(t, due, "default refresh interval elapsed")
+
+	// The refresh hint of the stored bundle overrides the default refresh interval
+	setup.bundle.SetRefreshHint(time.Minute)
+	data, err := setup.bundle.Marshal()
+	require.NoError(t, err)
+	setup.datastore.WithBundles(&entity.Bundle{
+		ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Data:          data,
+		TrustDomainID: setup.td.ID.UUID,
+	})
+
+	setup.externalTD.LastRefreshAt = setup.clk.Now()
+	setup.clk.Add(30 * time.Second)
+	due, err = setup.fetcher.isDue(ctx, setup.td, setup.externalTD)
+	require.NoError(t, err)
+	assert.False(t, due, "refresh hint not elapsed")
+
+	setup.clk.Add(30 * time.Second)
+	due, err = setup.fetcher.isDue(ctx, setup.td, setup.externalTD)
+	require.NoError(t, err)
+	assert.True(t, due, "refresh hint elapsed")
+}
+
+func TestRun(t *testing.T) {
+	setup := newTestSetup(t, nil)
+	server := setup.newSPIFFEBundleEndpoint(t)
+	defer server.Close()
+	setup.configure(bundleendpoint.ProfileHTTPSSPIFFE, server.URL, setup.bundle)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- setup.fetcher.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		stored, err := setup.datastore.FindBundleByTrustDomainID(ctx, setup.td.ID.UUID)
+		return err == nil && stored != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func newTestSetup(t *testing.T, policyConfig *bundlepolicy.Config) *testSetup {
+	logger, _ := test.NewNullLogger()
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	policy, err := bundlepolicy.New(policyConfig)
+	require.NoError(t, err)
+
+	td := &entity.TrustDomain{
+		ID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Name: externalTD,
+	}
+	datastore := fakedatastore.NewFakeDB()
+	datastore.WithTrustDomains(td)
+
+	notifier := fakenotifier.New()
+	fetcher := New(&Config{
+		Datastore:    datastore,
+		BundlePolicy: policy,
+		Notifier:     notifier,
+		Coordinator:  ha.NewLocalCoordinator(),
+		Logger:       logger,
+		PollInterval: 10 * time.Millisecond,
+	})
+	fetcher.clk = clk
+
+	// the CA is valid from the real time, the fake clock must not precede it
+	ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+
+	return &testSetup{
+		fetcher:   fetcher,
+		datastore: datastore,
+		notifier:  notifier,
+		clk:       clk,
+		td:        td,
+		ca:        ca,
+		caKey:     caKey,
+		bundle:    spiffebundle.FromX509Authorities(externalTD, []*x509.Certificate{ca}),
+	}
+}
+
+// configure configures the trust domain as external, with the given bundle endpoint and bootstrap bundle.
+func (s *testSetup) configure(profile bundleendpoint.Profile, endpointURL string, bootstrap *spiffebundle.Bundle) {
+	s.externalTD = &entity.ExternalTrustDomain{
+		ID:                    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainID:         s.td.ID.UUID,
+		BundleEndpointURL:     endpointURL,
+		BundleEndpointProfile: string(profile),
+	}
+	if profile == bundleendpoint.ProfileHTTPSSPIFFE {
+		s.externalTD.EndpointSPIFFEID = endpointID.String()
+	}
+	if bootstrap != nil {
+		s.externalTD.BootstrapBundle, _ = bootstrap.Marshal()
+	}
+	s.datastore.WithExternalTrustDomains(s.externalTD)
+}
+
+// bundleHandler serves the bundle of the test setup, as it is updated by the tests.
+func (s *testSetup) bundleHandler(t *testing.T) http.Handler {
+	handler, err := federation.NewHandler(externalTD, s.bundle)
+	require.NoError(t, err)
+	return handler
+}
+
+// newSPIFFEBundleEndpoint starts a bundle endpoint of the https_spiffe profile, authenticated with an X509-SVID
+// issued by the current CA of the test setup.
+func (s *testSetup) newSPIFFEBundleEndpoint(t *testing.T) *httptest.Server {
+	key, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	require.NoError(t, err)
+
+	template, err := cryptoutil.CreateX509Template(clock.New(), key.Public(), pkix.Name{CommonName: "bundle-endpoint"}, []*url.URL{endpointID.URL()}, nil, time.Hour)
+	require.NoError(t, err)
+	cert, err := cryptoutil.SignX509(template, s.ca, s.caKey)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(s.bundleHandler(t))
+	// httptest serves its own certificate unless one is set
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+	server.StartTLS()
+	return server
+}
+
+func (s *testSetup) requireStoredBundle(t *testing.T, expected *spiffebundle.Bundle) {
+	stored, err := s.datastore.FindBundleByTrustDomainID(context.Background(), s.td.ID.UUID)
+	require.NoError(t, err)
+	if expected == nil {
+		require.Nil(t, stored)
+		return
+	}
+
+	require.NotNil(t, stored)
+	storedBundle, err := spiffebundle.Parse(externalTD, stored.Data)
+	require.NoError(t, err)
+	assert.True(t, expected.Equal(storedBundle))
+	assert.Equal(t, cryptoutil.CalculateDigest(stored.Data), stored.Digest)
+	assert.Equal(t, entity.BundleVerificationSkipped, stored.VerificationStatus)
+}
+
+func (s *testSetup) requireRefresh(t *testing.T, expectedErr string) {
+	stored, err := s.datastore.FindExternalTrustDomainByTrustDomainID(context.Background(), s.td.ID.UUID)
+	require.NoError(t, err)
+	assert.Equal(t, s.clk.Now(), stored.LastRefreshAt)
+	if expectedErr == "" {
+		assert.Empty(t, stored.LastRefreshError)
+	} else {
+		assert.Contains(t, stored.LastRefreshError, expectedErr)
+	}
+
+	// the next refresh starts from the stored state
+	s.externalTD = stored
+	s.datastore.WithExternalTrustDomains(stored)
+}
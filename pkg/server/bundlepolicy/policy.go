@@ -0,0 +1,293 @@
+package bundlepolicy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+const (
+	// DefaultMinRSAKeySize is the default minimum size in bits of the RSA keys of the authorities.
+	DefaultMinRSAKeySize = 2048
+	// DefaultMinECKeySize is the default minimum size in bits of the curves of the ECDSA keys of the authorities.
+	DefaultMinECKeySize = 256
+	// DefaultMaxX509Authorities is the default maximum number of X.509 authorities in a bundle.
+	DefaultMaxX509Authorities = 16
+	// DefaultMaxJWTAuthorities is the default maximum number of JWT authorities in a bundle.
+	DefaultMaxJWTAuthorities = 16
+	// DefaultMaxAuthoritySize is the default maximum size in bytes of an authority, DER encoded.
+	DefaultMaxAuthoritySize = 8 * 1024
+	// DefaultMaxBundleSize is the default maximum size in bytes of a bundle.
+	DefaultMaxBundleSize = 256 * 1024
+)
+
+// Key algorithms of the authorities.
+const (
+	KeyAlgorithmRSA     = "rsa"
+	KeyAlgorithmECDSA   = "ecdsa"
+	KeyAlgorithmEd25519 = "ed25519"
+)
+
+// Rules reported by the violations.
+const (
+	RuleFormat         = "format"
+	RuleBundleSize     = "bundle_size"
+	RuleTrustDomain    = "trust_domain"
+	RuleSequenceNumber = "sequence_number"
+	RuleAuthorityCount = "authority_count"
+	RuleAuthoritySize  = "authority_size"
+	RuleValidity       = "validity"
+	RuleKeyAlgorithm   = "key_algorithm"
+	RuleKeySize        = "key_size"
+)
+
+// DefaultAllowedKeyAlgorithms are the key algorithms allowed by default.
+var DefaultAllowedKeyAlgorithms = []string{KeyAlgorithmRSA, KeyAlgorithmECDSA}
+
+// Config conveys the limits enforced by the policy. Zero values are set to their defaults.
+type Config struct {
+	MinRSAKeySize        int
+	MinECKeySize         int
+	AllowedKeyAlgorithms []string
+	MaxX509Authorities   int
+	MaxJWTAuthorities    int
+	MaxAuthoritySize     int
+	MaxBundleSize        int
+}
+
+// Policy validates the content of the bundles uploaded by the harvesters.
+type Policy struct {
+	c                 Config
+	allowedAlgorithms map[string]bool
+}
+
+// Violation is a policy rule that a bundle does not comply with.
+type Violation struct {
+	Rule string
+	// Authority identifies the offending authority, e.g. "x509_authorities[0]" or "jwt_authorities[kid]".
+	// It is empty when the violation concerns the whole bundle.
+	Authority string
+	Message   string
+}
+
+// Report is the result of the validation of a bundle.
+type Report struct {
+	TrustDomain    spiffeid.TrustDomain
+	SequenceNumber *uint64
+	Violations     []*Violation
+}
+
+// Valid returns true when the bundle complies with the policy.
+func (r *Report) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// Violation returns the first violation of the rule, nil if the bundle complies with it.
+func (r *Report) Violation(rule string) *Violation {
+	for _, v := range r.Violations {
+		if v.Rule == rule {
+			return v
+		}
+	}
+	return nil
+}
+
+// String returns the violations of the report in a single line, prefixed by the offending authority if any.
+func (r *Report) String() string {
+	messages := make([]string, len(r.Violations))
+	for i, v := range r.Violations {
+		messages[i] = v.Message
+		if v.Authority != "" {
+			messages[i] = v.Authority + ": " + v.Message
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (r *Report) addViolation(rule, authority, format string, args ...any) {
+	r.Violations = append(r.Violations, &Violation{
+		Rule:      rule,
+		Authority: authority,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// New creates a new Policy. A nil config creates a policy with the default limits.
+func New(c *Config) (*Policy, error) {
+	config := Config{}
+	if c != nil {
+		config = *c
+	}
+
+	if config.MinRSAKeySize <= 0 {
+		config.MinRSAKeySize = DefaultMinRSAKeySize
+	}
+	if config.MinECKeySize <= 0 {
+		config.MinECKeySize = DefaultMinECKeySize
+	}
+	if len(config.AllowedKeyAlgorithms) == 0 {
+		config.AllowedKeyAlgorithms = DefaultAllowedKeyAlgorithms
+	}
+	if config.MaxX509Authorities <= 0 {
+		config.MaxX509Authorities = DefaultMaxX509Authorities
+	}
+	if config.MaxJWTAuthorities <= 0 {
+		config.MaxJWTAuthorities = DefaultMaxJWTAuthorities
+	}
+	if config.MaxAuthoritySize <= 0 {
+		config.MaxAuthoritySize = DefaultMaxAuthoritySize
+	}
+	if config.MaxBundleSize <= 0 {
+		config.MaxBundleSize = DefaultMaxBundleSize
+	}
+
+	allowedAlgorithms := make(map[string]bool, len(config.AllowedKeyAlgorithms))
+	for _, alg := range config.AllowedKeyAlgorithms {
+		switch alg {
+		case KeyAlgorithmRSA, KeyAlgorithmECDSA, KeyAlgorithmEd25519:
+			allowedAlgorithms[alg] = true
+		default:
+			return nil, fmt.Errorf("unknown key algorithm %q", alg)
+		}
+	}
+
+	return &Policy{
+		c:                 config,
+		allowedAlgorithms: allowedAlgorithms,
+	}, nil
+}
+
+// Validate parses the bundle data as a SPIFFE bundle of the trust domain, and checks it against the policy.
+// The previous bundle data is the one currently stored for the trust domain, nil if there is none, and
+// is used to check that the sequence number does not go backwards.
+func (p *Policy) Validate(td spiffeid.TrustDomain, data, previous []byte, now time.Time) *Report {
+	report := &Report{TrustDomain: td}
+
+	if len(data) > p.c.MaxBundleSize {
+		report.addViolation(RuleBundleSize, "", "bundle size %d bytes exceeds the maximum of %d bytes", len(data), p.c.MaxBundleSize)
+		return report
+	}
+
+	bundle, err := spiffebundle.Parse(td, data)
+	if err != nil {
+		report.addViolation(RuleFormat, "", "bundle is not a valid SPIFFE bundle: %v", err)
+		return report
+	}
+
+	if seq, ok := bundle.SequenceNumber(); ok {
+		report.SequenceNumber = &seq
+	}
+	p.validateSequenceNumber(report, td, previous)
+
+	x509Authorities := bundle.X509Authorities()
+	if len(x509Authorities) > p.c.MaxX509Authorities {
+		report.addViolation(RuleAuthorityCount, "", "bundle has %d X.509 authorities, the maximum is %d", len(x509Authorities), p.c.MaxX509Authorities)
+	}
+	for i, cert := range x509Authorities {
+		p.validateX509Authority(report, fmt.Sprintf("x509_authorities[%d]", i), cert, now)
+	}
+
+	jwtAuthorities := bundle.JWTAuthorities()
+	if len(jwtAuthorities) > p.c.MaxJWTAuthorities {
+		report.addViolation(RuleAuthorityCount, "", "bundle has %d JWT authorities, the maximum is %d", len(jwtAuthorities), p.c.MaxJWTAuthorities)
+	}
+	keyIDs := make([]string, 0, len(jwtAuthorities))
+	for keyID := range jwtAuthorities {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	for _, keyID := range keyIDs {
+		p.validateJWTAuthority(report, fmt.Sprintf("jwt_authorities[%s]", keyID), jwtAuthorities[keyID])
+	}
+
+	return report
+}
+
+func (p *Policy) validateSequenceNumber(report *Report, td spiffeid.TrustDomain, previous []byte) {
+	if previous == nil {
+		return
+	}
+
+	// a stored bundle that cannot be parsed has no sequence number to compare with
+	previousBundle, err := spiffebundle.Parse(td, previous)
+	if err != nil {
+		return
+	}
+	previousSeq, ok := previousBundle.SequenceNumber()
+	if !ok {
+		return
+	}
+
+	switch {
+	case report.SequenceNumber == nil:
+		report.addViolation(RuleSequenceNumber, "", "bundle has no sequence number, the current bundle has sequence number %d", previousSeq)
+	case *report.SequenceNumber < previousSeq:
+		report.addViolation(RuleSequenceNumber, "", "sequence number %d is lower than the sequence number %d of the current bundle", *report.SequenceNumber, previousSeq)
+	}
+}
+
+func (p *Policy) validateX509Authority(report *Report, authority string, cert *x509.Certificate, now time.Time) {
+	if len(cert.Raw) > p.c.MaxAuthoritySize {
+		report.addViolation(RuleAuthoritySize, authority, "authority size %d bytes exceeds the maximum of %d bytes", len(cert.Raw), p.c.MaxAuthoritySize)
+	}
+
+	if now.Before(cert.NotBefore) {
+		report.addViolation(RuleValidity, authority, "authority is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.After(cert.NotAfter) {
+		report.addViolation(RuleValidity, authority, "authority expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	for _, uri := range cert.URIs {
+		id, err := spiffeid.FromURI(uri)
+		if err != nil {
+			continue
+		}
+		if id.TrustDomain() != report.TrustDomain {
+			report.addViolation(RuleTrustDomain, authority, "authority SPIFFE ID %q does not belong to trust domain %q", id, report.TrustDomain)
+		}
+	}
+
+	p.validateKey(report, authority, cert.PublicKey)
+}
+
+func (p *Policy) validateJWTAuthority(report *Report, authority string, key crypto.PublicKey) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err == nil && len(der) > p.c.MaxAuthoritySize {
+		report.addViolation(RuleAuthoritySize, authority, "authority size %d bytes exceeds the maximum of %d bytes", len(der), p.c.MaxAuthoritySize)
+	}
+
+	p.validateKey(report, authority, key)
+}
+
+func (p *Policy) validateKey(report *Report, authority string, key crypto.PublicKey) {
+	var alg string
+	var size, minSize int
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		alg, size, minSize = KeyAlgorithmRSA, k.N.BitLen(), p.c.MinRSAKeySize
+	case *ecdsa.PublicKey:
+		alg, size, minSize = KeyAlgorithmECDSA, k.Curve.Params().BitSize, p.c.MinECKeySize
+	case ed25519.PublicKey:
+		alg = KeyAlgorithmEd25519
+	default:
+		report.addViolation(RuleKeyAlgorithm, authority, "unsupported key type %T", key)
+		return
+	}
+
+	if !p.allowedAlgorithms[alg] {
+		report.addViolation(RuleKeyAlgorithm, authority, "key algorithm %q is not allowed", alg)
+	}
+	if size < minSize {
+		report.addViolation(RuleKeySize, authority, "%s key size %d bits is lower than the minimum of %d bits", alg, size, minSize)
+	}
+}
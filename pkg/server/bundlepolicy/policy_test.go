@@ -0,0 +1,279 @@
+package bundlepolicy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	td  = spiffeid.RequireTrustDomainFromString("td1.org")
+	now = time.Now().Truncate(time.Second)
+)
+
+func TestNew(t *testing.T) {
+	p, err := New(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		MinRSAKeySize:        DefaultMinRSAKeySize,
+		MinECKeySize:         DefaultMinECKeySize,
+		AllowedKeyAlgorithms: DefaultAllowedKeyAlgorithms,
+		MaxX509Authorities:   DefaultMaxX509Authorities,
+		MaxJWTAuthorities:    DefaultMaxJWTAuthorities,
+		MaxAuthoritySize:     DefaultMaxAuthoritySize,
+		MaxBundleSize:        DefaultMaxBundleSize,
+	}, p.c)
+
+	_, err = New(&Config{AllowedKeyAlgorithms: []string{"dsa"}})
+	require.EqualError(t, err, `unknown key algorithm "dsa"`)
+}
+
+func TestValidateValidBundle(t *testing.T) {
+	p, err := New(nil)
+	require.NoError(t, err)
+
+	ecKey := generateECKey(t, elliptic.P256())
+	bundle := spiffebundle.New(td)
+	bundle.AddX509Authority(createAuthority(t, ecKey, now.Add(-time.Hour), now.Add(time.Hour), td))
+	require.NoError(t, bundle.AddJWTAuthority("kid", ecKey.Public()))
+	bundle.SetSequenceNumber(2)
+
+	previous := newJWTBundle(t, ecKey, 2)
+
+	report := p.Validate(td, marshal(t, bundle), marshal(t, previous), now)
+	assert.True(t, report.Valid())
+	assert.Empty(t, report.Violations)
+	assert.Equal(t, td, report.TrustDomain)
+	require.NotNil(t, report.SequenceNumber)
+	assert.Equal(t, uint64(2), *report.SequenceNumber)
+}
+
+func TestValidateViolations(t *testing.T) {
+	ecKey := generateECKey(t, elliptic.P256())
+
+	testCases := []struct {
+		name     string
+		config   *Config
+		bundle   func(t *testing.T) []byte
+		previous func(t *testing.T) []byte
+		expected []*Violation
+	}{
+		{
+			name: "not a SPIFFE bundle",
+			bundle: func(t *testing.T) []byte {
+				return []byte("not a bundle")
+			},
+			expected: []*Violation{{Rule: RuleFormat, Message: "bundle is not a valid SPIFFE bundle: spiffebundle: unable to parse JWKS: invalid character 'o' in literal null (expecting 'u')"}},
+		},
+		{
+			name:   "bundle too big",
+			config: &Config{MaxBundleSize: 10},
+			bundle: func(t *testing.T) []byte {
+				return []byte("01234567890")
+			},
+			expected: []*Violation{{Rule: RuleBundleSize, Message: "bundle size 11 bytes exceeds the maximum of 10 bytes"}},
+		},
+		{
+			name: "sequence number goes backwards",
+			bundle: func(t *testing.T) []byte {
+				return marshal(t, newJWTBundle(t, ecKey, 1))
+			},
+			previous: func(t *testing.T) []byte {
+				return marshal(t, newJWTBundle(t, ecKey, 5))
+			},
+			expected: []*Violation{{Rule: RuleSequenceNumber, Message: "sequence number 1 is lower than the sequence number 5 of the current bundle"}},
+		},
+		{
+			name: "sequence number removed",
+			bundle: func(t *testing.T) []byte {
+				b := newJWTBundle(t, ecKey, 0)
+				b.ClearSequenceNumber()
+				return marshal(t, b)
+			},
+			previous: func(t *testing.T) []byte {
+				return marshal(t, newJWTBundle(t, ecKey, 5))
+			},
+			expected: []*Violation{{Rule: RuleSequenceNumber, Message: "bundle has no sequence number, the current bundle has sequence number 5"}},
+		},
+		{
+			name: "expired and not yet valid authorities",
+			bundle: func(t *testing.T) []byte {
+				b := spiffebundle.New(td)
+				b.AddX509Authority(createAuthority(t, ecKey, now.Add(-2*time.Hour), now.Add(-time.Hour), td))
+				b.AddX509Authority(createAuthority(t, ecKey, now.Add(time.Hour), now.Add(2*time.Hour), td))
+				return marshal(t, b)
+			},
+			expected: []*Violation{
+				{Rule: RuleValidity, Authority: "x509_authorities[0]", Message: "authority expired at " + now.Add(-time.Hour).UTC().Format(time.RFC3339)},
+				{Rule: RuleValidity, Authority: "x509_authorities[1]", Message: "authority is not valid before " + now.Add(time.Hour).UTC().Format(time.RFC3339)},
+			},
+		},
+		{
+			name: "authority of another trust domain",
+			bundle: func(t *testing.T) []byte {
+				b := spiffebundle.New(td)
+				b.AddX509Authority(createAuthority(t, ecKey, now.Add(-time.Hour), now.Add(time.Hour), spiffeid.RequireTrustDomainFromString("other.org")))
+				return marshal(t, b)
+			},
+			expected: []*Violation{{Rule: RuleTrustDomain, Authority: "x509_authorities[0]", Message: `authority SPIFFE ID "spiffe://other.org" does not belong to trust domain "td1.org"`}},
+		},
+		{
+			name:   "small keys",
+			config: &Config{MinECKeySize: 384},
+			bundle: func(t *testing.T) []byte {
+				rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+				require.NoError(t, err)
+
+				b := spiffebundle.New(td)
+				b.AddX509Authority(createAuthority(t, rsaKey, now.Add(-time.Hour), now.Add(time.Hour), td))
+				require.NoError(t, b.AddJWTAuthority("kid", ecKey.Public()))
+				return marshal(t, b)
+			},
+			expected: []*Violation{
+				{Rule: RuleKeySize, Authority: "x509_authorities[0]", Message: "rsa key size 1024 bits is lower than the minimum of 2048 bits"},
+				{Rule: RuleKeySize, Authority: "jwt_authorities[kid]", Message: "ecdsa key size 256 bits is lower than the minimum of 384 bits"},
+			},
+		},
+		{
+			name:   "key algorithm not allowed",
+			config: &Config{AllowedKeyAlgorithms: []string{KeyAlgorithmRSA}},
+			bundle: func(t *testing.T) []byte {
+				edKey, _, err := ed25519.GenerateKey(rand.Reader)
+				require.NoError(t, err)
+
+				b := spiffebundle.New(td)
+				require.NoError(t, b.AddJWTAuthority("ec", ecKey.Public()))
+				require.NoError(t, b.AddJWTAuthority("ed", edKey))
+				return marshal(t, b)
+			},
+			expected: []*Violation{
+				{Rule: RuleKeyAlgorithm, Authority: "jwt_authorities[ec]", Message: `key algorithm "ecdsa" is not allowed`},
+				{Rule: RuleKeyAlgorithm, Authority: "jwt_authorities[ed]", Message: `key algorithm "ed25519" is not allowed`},
+			},
+		},
+		{
+			name:   "too many authorities",
+			config: &Config{MaxX509Authorities: 1, MaxJWTAuthorities: 1},
+			bundle: func(t *testing.T) []byte {
+				b := spiffebundle.New(td)
+				b.AddX509Authority(createAuthority(t, ecKey, now.Add(-time.Hour), now.Add(time.Hour), td))
+				b.AddX509Authority(createAuthority(t, ecKey, now.Add(-time.Hour), now.Add(2*time.Hour), td))
+				require.NoError(t, b.AddJWTAuthority("kid1", ecKey.Public()))
+				require.NoError(t, b.AddJWTAuthority("kid2", ecKey.Public()))
+				return marshal(t, b)
+			},
+			expected: []*Violation{
+				{Rule: RuleAuthorityCount, Message: "bundle has 2 X.509 authorities, the maximum is 1"},
+				{Rule: RuleAuthorityCount, Message: "bundle has 2 JWT authorities, the maximum is 1"},
+			},
+		},
+		{
+			name:   "authority too big",
+			config: &Config{MaxAuthoritySize: 90},
+			bundle: func(t *testing.T) []byte {
+				b := spiffebundle.New(td)
+				require.NoError(t, b.AddJWTAuthority("kid", ecKey.Public()))
+				return marshal(t, b)
+			},
+			expected: []*Violation{{Rule: RuleAuthoritySize, Authority: "jwt_authorities[kid]", Message: "authority size 91 bytes exceeds the maximum of 90 bytes"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.config)
+			require.NoError(t, err)
+
+			var previous []byte
+			if tc.previous != nil {
+				previous = tc.previous(t)
+			}
+
+			report := p.Validate(td, tc.bundle(t), previous, now)
+			assert.False(t, report.Valid())
+			assert.Equal(t, tc.expected, report.Violations)
+		})
+	}
+}
+
+func generateECKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newJWTBundle(t *testing.T, key crypto.Signer, seq uint64) *spiffebundle.Bundle {
+	b := spiffebundle.New(td)
+	require.NoError(t, b.AddJWTAuthority("kid", key.Public()))
+	b.SetSequenceNumber(seq)
+	return b
+}
+
+func TestReportString(t *testing.T) {
+	report := &Report{
+		Violations: []*Violation{
+			{Rule: RuleSequenceNumber, Message: "sequence number is missing"},
+			{Rule: RuleKeySize, Authority: "x509_authorities[0]", Message: "RSA key size 1024 is lower than 2048"},
+		},
+	}
+
+	assert.Equal(t, "sequence number is missing; x509_authorities[0]: RSA key size 1024 is lower than 2048", report.String())
+	assert.Empty(t, (&Report{}).String())
+}
+
+func TestReportViolation(t *testing.T) {
+	report := &Report{
+		Violations: []*Violation{
+			{Rule: RuleKeySize, Authority: "x509_authorities[0]", Message: "RSA key size 1024 is lower than 2048"},
+			{Rule: RuleSequenceNumber, Message: "sequence number is missing"},
+		},
+	}
+
+	assert.Equal(t, report.Violations[1], report.Violation(RuleSequenceNumber))
+	assert.Nil(t, report.Violation(RuleValidity))
+	assert.Nil(t, (&Report{}).Violation(RuleSequenceNumber))
+}
+
+func createAuthority(t *testing.T, key crypto.Signer, notBefore, notAfter time.Time, td spiffeid.TrustDomain) *x509.Certificate {
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		URIs:                  []*url.URL{td.ID().URL()},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func marshal(t *testing.T, bundle *spiffebundle.Bundle) []byte {
+	data, err := bundle.Marshal()
+	require.NoError(t, err)
+	return data
+}
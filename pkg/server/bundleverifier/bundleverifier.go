@@ -0,0 +1,97 @@
+package bundleverifier
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// ErrVerificationFailed is returned when none of the verifiers of the trust domain could verify the bundle signature.
+var ErrVerificationFailed = errors.New("no verifier could verify the bundle signature")
+
+// Provider is a bundle signature verifier along with the trust domains it applies to.
+type Provider struct {
+	// Name identifies the provider, and is recorded along with the bundles it verified.
+	Name     string
+	Verifier integrity.Verifier
+
+	// TrustDomains whose bundles are verified by the provider. A provider without trust domains is global, and
+	// applies to every trust domain that doesn't have providers of its own.
+	TrustDomains []spiffeid.TrustDomain
+}
+
+// Result is the outcome of a successful bundle verification.
+type Result struct {
+	Status entity.BundleVerificationStatus
+
+	// VerifiedBy is the name of the provider that verified the bundle signature, empty when the verification was skipped.
+	VerifiedBy string
+}
+
+// Set verifies the signatures of the bundles uploaded by the harvesters using the providers configured for
+// the trust domain of each bundle.
+type Set struct {
+	global        []*Provider
+	byTrustDomain map[spiffeid.TrustDomain][]*Provider
+}
+
+// NewSet creates a new Set with the given providers. A Set without providers skips all the verifications.
+func NewSet(providers []*Provider) *Set {
+	s := &Set{
+		byTrustDomain: make(map[spiffeid.TrustDomain][]*Provider),
+	}
+	for _, p := range providers {
+		if len(p.TrustDomains) == 0 {
+			s.global = append(s.global, p)
+			continue
+		}
+		for _, td := range p.TrustDomains {
+			s.byTrustDomain[td] = append(s.byTrustDomain[td], p)
+		}
+	}
+
+	return s
+}
+
+// ProvidersFor returns the providers that verify the bundles of the given trust domain: the ones configured for
+// the trust domain if there is any, otherwise the global ones.
+func (s *Set) ProvidersFor(td spiffeid.TrustDomain) []*Provider {
+	if providers, ok := s.byTrustDomain[td]; ok {
+		return providers
+	}
+	return s.global
+}
+
+// Verify verifies the bundle signature, which is accepted as soon as one of the providers of the trust domain
+// of the bundle verifies it. The verification is skipped when there are no providers for the trust domain.
+func (s *Set) Verify(td spiffeid.TrustDomain, bundle *entity.Bundle) (*Result, error) {
+	providers := s.ProvidersFor(td)
+	if len(providers) == 0 {
+		return &Result{Status: entity.BundleVerificationSkipped}, nil
+	}
+
+	var certChain []*x509.Certificate
+	if len(bundle.SigningCertificate) > 0 {
+		var err error
+		certChain, err = x509.ParseCertificates(bundle.SigningCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing certificate chain: %w", err)
+		}
+	}
+
+	errs := make([]string, 0, len(providers))
+	for _, p := range providers {
+		err := p.Verifier.Verify(bundle.Data, bundle.Signature, certChain)
+		if err == nil {
+			return &Result{Status: entity.BundleVerificationVerified, VerifiedBy: p.Name}, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", p.Name, err))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrVerificationFailed, strings.Join(errs, "; "))
+}
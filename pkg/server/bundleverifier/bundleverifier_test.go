@@ -0,0 +1,94 @@
+package bundleverifier
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	td1 = spiffeid.RequireTrustDomainFromString("td1.org")
+	td2 = spiffeid.RequireTrustDomainFromString("td2.org")
+)
+
+type fakeVerifier struct {
+	err error
+}
+
+func (v fakeVerifier) Verify(payload, signature []byte, certChain []*x509.Certificate) error {
+	return v.err
+}
+
+func TestVerify(t *testing.T) {
+	bundle := &entity.Bundle{Data: []byte("bundle"), Signature: []byte("signature")}
+
+	accepting := &Provider{Name: "accepting", Verifier: fakeVerifier{}}
+	rejecting := &Provider{Name: "rejecting", Verifier: fakeVerifier{err: errors.New("invalid signature")}}
+	td1Rejecting := &Provider{Name: "td1", Verifier: fakeVerifier{err: errors.New("untrusted chain")}, TrustDomains: []spiffeid.TrustDomain{td1}}
+
+	testCases := []struct {
+		name      string
+		providers []*Provider
+		td        spiffeid.TrustDomain
+		expected  *Result
+		err       string
+	}{
+		{
+			name:     "no providers",
+			td:       td1,
+			expected: &Result{Status: entity.BundleVerificationSkipped},
+		},
+		{
+			name:      "verified by the second global provider",
+			providers: []*Provider{rejecting, accepting},
+			td:        td1,
+			expected:  &Result{Status: entity.BundleVerificationVerified, VerifiedBy: "accepting"},
+		},
+		{
+			name:      "trust domain providers take precedence over the global ones",
+			providers: []*Provider{accepting, td1Rejecting},
+			td:        td1,
+			err:       "no verifier could verify the bundle signature: td1: untrusted chain",
+		},
+		{
+			name:      "global providers apply to trust domains without providers",
+			providers: []*Provider{accepting, td1Rejecting},
+			td:        td2,
+			expected:  &Result{Status: entity.BundleVerificationVerified, VerifiedBy: "accepting"},
+		},
+		{
+			name:      "all providers fail",
+			providers: []*Provider{rejecting},
+			td:        td2,
+			err:       "no verifier could verify the bundle signature: rejecting: invalid signature",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NewSet(tc.providers).Verify(tc.td, bundle)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				assert.ErrorIs(t, err, ErrVerificationFailed)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestVerifyInvalidSigningCertificate(t *testing.T) {
+	set := NewSet([]*Provider{{Name: "accepting", Verifier: fakeVerifier{}}})
+
+	_, err := set.Verify(td1, &entity.Bundle{Data: []byte("bundle"), SigningCertificate: []byte("not-a-certificate")})
+	require.ErrorContains(t, err, "failed to parse signing certificate chain")
+}
@@ -1,16 +1,25 @@
 package catalog
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/memory"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/vault"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleverifier"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/postgres"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/sqlite"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
 // Catalog is a collection of provider interfaces.
@@ -18,6 +27,7 @@ type Catalog interface {
 	GetDatastore() db.Datastore
 	GetX509CA() x509ca.X509CA
 	GetKeyManager() keymanager.KeyManager
+	GetBundleVerifiers() *bundleverifier.Set
 }
 
 // ProvidersRepository is the implementation of the Catalog interface.
@@ -25,6 +35,8 @@ type ProvidersRepository struct {
 	datastore  db.Datastore
 	x509ca     x509ca.X509CA
 	keyManager keymanager.KeyManager
+
+	bundleVerifiers *bundleverifier.Set
 }
 
 // ProvidersConfig holds the HCL configuration for the providers.
@@ -32,6 +44,9 @@ type ProvidersConfig struct {
 	Datastore  *providerConfig `hcl:"Datastore,block"`
 	X509CA     *providerConfig `hcl:"X509CA,block"`
 	KeyManager *providerConfig `hcl:"KeyManager,block"`
+
+	// BundleVerifiers are optional. The signatures of the uploaded bundles are not verified when none is configured.
+	BundleVerifiers []*providerConfig `hcl:"BundleVerifier,block"`
 }
 
 // providerConfig holds the HCL configuration options for a single provider.
@@ -44,10 +59,28 @@ type datastoreConfig struct {
 	ConnectionString string `hcl:"connection_string"`
 }
 
+type datastoreKeyManagerConfig struct {
+	// KeyEncryptionKeyFile is the path to the file holding the base64 encoded AES-256 key that encrypts the keys
+	// stored in the datastore.
+	KeyEncryptionKeyFile string `hcl:"key_encryption_key_file"`
+}
+
 type diskKeyManagerConfig struct {
 	KeysFilePath string `hcl:"keys_file_path"`
 }
 
+// bundleVerifierConfig holds the options common to all the bundle verifier providers.
+type bundleVerifierConfig struct {
+	// TrustDomains restricts the verifier to the bundles of these trust domains. It applies to all the trust domains
+	// without verifiers of their own when empty.
+	TrustDomains []string `hcl:"trust_domains,optional"`
+	Options      hcl.Body `hcl:",remain"`
+}
+
+type diskBundleVerifierConfig struct {
+	TrustBundlePath string `hcl:"trust_bundle_path"`
+}
+
 // New creates a new ProvidersRepository.
 // It is the responsibility of the caller to load the catalog with providers using LoadFromProvidersConfig.
 func New() *ProvidersRepository {
@@ -66,7 +99,7 @@ func ProvidersConfigsFromHCLBody(body hcl.Body) (*ProvidersConfig, error) {
 }
 
 // LoadFromProvidersConfig loads the catalog from HCL configuration.
-func (c *ProvidersRepository) LoadFromProvidersConfig(config *ProvidersConfig) error {
+func (c *ProvidersRepository) LoadFromProvidersConfig(config *ProvidersConfig) (err error) {
 	if config == nil {
 		return fmt.Errorf("configuration is required")
 	}
@@ -80,21 +113,64 @@ func (c *ProvidersRepository) LoadFromProvidersConfig(config *ProvidersConfig) e
 		return fmt.Errorf("datastore configuration is required")
 	}
 
-	var err error
 	c.x509ca, err = loadX509CA(config.X509CA)
 	if err != nil {
 		return fmt.Errorf("error loading X509CA: %w", err)
 	}
+	defer func() {
+		// the providers loaded so far are released when the catalog fails to load
+		if err != nil {
+			c.Close()
+		}
+	}()
+
+	c.datastore, err = loadDatastore(config.Datastore)
+	if err != nil {
+		return fmt.Errorf("error loading datastore: %w", err)
+	}
 
-	c.keyManager, err = loadKeyManager(config.KeyManager)
+	c.keyManager, err = loadKeyManager(config.KeyManager, c.datastore)
 	if err != nil {
 		return fmt.Errorf("error loading KeyManager: %w", err)
 	}
-	c.datastore, err = loadDatastore(config.Datastore)
+
+	providers := make([]*bundleverifier.Provider, 0, len(config.BundleVerifiers))
+	for _, vc := range config.BundleVerifiers {
+		provider, err := loadBundleVerifier(vc)
+		if err != nil {
+			return fmt.Errorf("error loading BundleVerifier: %w", err)
+		}
+		providers = append(providers, provider)
+	}
+	c.bundleVerifiers = bundleverifier.NewSet(providers)
+
+	return nil
+}
+
+// HighAvailabilityConnString returns the connection string of the datastore, checking that the providers can be
+// shared by several Galadriel Server replicas: the datastore must be "postgres" and the KeyManager "datastore".
+func (c *ProvidersConfig) HighAvailabilityConnString() (string, error) {
+	if c.Datastore == nil || c.Datastore.Name != "postgres" {
+		return "", fmt.Errorf("high availability requires the postgres datastore")
+	}
+	if c.KeyManager == nil || c.KeyManager.Name != "datastore" {
+		return "", fmt.Errorf("high availability requires the datastore KeyManager")
+	}
+
+	dsConfig, err := decodeDatastoreConfig(c.Datastore)
 	if err != nil {
-		return fmt.Errorf("error loading datastore: %w", err)
+		return "", fmt.Errorf("error decoding datastore config: %w", err)
 	}
 
+	return dsConfig.ConnectionString, nil
+}
+
+// Close releases the providers that hold resources, such as the Vault X509CA that renews its Vault token in the
+// background.
+func (c *ProvidersRepository) Close() error {
+	if closer, ok := c.x509ca.(io.Closer); ok {
+		return closer.Close()
+	}
 	return nil
 }
 
@@ -110,6 +186,10 @@ func (c *ProvidersRepository) GetKeyManager() keymanager.KeyManager {
 	return c.keyManager
 }
 
+func (c *ProvidersRepository) GetBundleVerifiers() *bundleverifier.Set {
+	return c.bundleVerifiers
+}
+
 func loadX509CA(c *providerConfig) (x509ca.X509CA, error) {
 	switch c.Name {
 	case "disk":
@@ -118,16 +198,43 @@ func loadX509CA(c *providerConfig) (x509ca.X509CA, error) {
 			return nil, fmt.Errorf("error creating disk X509CA: %w", err)
 		}
 		return x509CA, nil
+	case "memory":
+		x509CA, err := makeMemoryX509CA(c)
+		if err != nil {
+			return nil, fmt.Errorf("error creating memory X509CA: %w", err)
+		}
+		return x509CA, nil
+	case "vault":
+		x509CA, err := makeVaultX509CA(c)
+		if err != nil {
+			return nil, fmt.Errorf("error creating vault X509CA: %w", err)
+		}
+		return x509CA, nil
 	}
 
 	return nil, fmt.Errorf("unknown X509CA provider: %s", c.Name)
 }
 
-func loadKeyManager(c *providerConfig) (keymanager.KeyManager, error) {
+func loadKeyManager(c *providerConfig, datastore db.Datastore) (keymanager.KeyManager, error) {
 	switch c.Name {
 	case "memory":
 		km := keymanager.NewMemoryKeyManager(nil)
 		return km, nil
+	case "datastore":
+		kmConfig, err := decodeDatastoreKeyManagerConfig(c)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding datastore KeyManager config: %w", err)
+		}
+		kek, err := loadKeyEncryptionKey(kmConfig.KeyEncryptionKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		store, err := keymanager.NewEncryptedStore(&datastoreKeyStore{datastore: datastore}, kek)
+		if err != nil {
+			return nil, fmt.Errorf("error creating datastore KeyManager: %w", err)
+		}
+		km := keymanager.NewSharedKeyManager(nil, store)
+		return km, nil
 	case "disk":
 		kmConfig, err := decodeDiskKeyManagerConfig(c)
 		if err != nil {
@@ -154,20 +261,60 @@ func loadDatastore(config *providerConfig) (db.Datastore, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error creating postgres datastore: %w", err)
 		}
-		return ds, nil
+		return db.WithTracing(ds, db.Postgres), nil
 	case "sqlite3":
 		ds, err := sqlite.NewDatastore(c.ConnectionString)
 		if err != nil {
 			return nil, fmt.Errorf("error creating sqlite datastore: %w", err)
 		}
 
-		return ds, nil
+		return db.WithTracing(ds, db.SQLite), nil
 
 	}
 
 	return nil, fmt.Errorf("unknown datastore provider: %s", config.Name)
 }
 
+func loadBundleVerifier(config *providerConfig) (*bundleverifier.Provider, error) {
+	var c bundleVerifierConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &c); err != nil {
+		return nil, fmt.Errorf("error decoding bundle verifier config: %w", err)
+	}
+
+	trustDomains := make([]spiffeid.TrustDomain, 0, len(c.TrustDomains))
+	for _, name := range c.TrustDomains {
+		td, err := spiffeid.TrustDomainFromString(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trust domain %q: %w", name, err)
+		}
+		trustDomains = append(trustDomains, td)
+	}
+
+	var verifier integrity.Verifier
+	switch config.Name {
+	case "disk":
+		var diskConfig diskBundleVerifierConfig
+		if err := gohcl.DecodeBody(c.Options, nil, &diskConfig); err != nil {
+			return nil, fmt.Errorf("error decoding disk bundle verifier config: %w", err)
+		}
+		diskVerifier, err := integrity.NewDiskVerifier(integrity.NewDiskVerifierConfig(diskConfig.TrustBundlePath))
+		if err != nil {
+			return nil, fmt.Errorf("error creating disk bundle verifier: %w", err)
+		}
+		verifier = diskVerifier
+	case "noop":
+		verifier = integrity.NewNoOpVerifier()
+	default:
+		return nil, fmt.Errorf("unknown bundle verifier provider: %s", config.Name)
+	}
+
+	return &bundleverifier.Provider{
+		Name:         config.Name,
+		Verifier:     verifier,
+		TrustDomains: trustDomains,
+	}, nil
+}
+
 func decodeDatastoreConfig(config *providerConfig) (*datastoreConfig, error) {
 	var dsConfig datastoreConfig
 	if err := gohcl.DecodeBody(config.Options, nil, &dsConfig); err != nil {
@@ -176,6 +323,28 @@ func decodeDatastoreConfig(config *providerConfig) (*datastoreConfig, error) {
 	return &dsConfig, nil
 }
 
+func decodeDatastoreKeyManagerConfig(config *providerConfig) (*datastoreKeyManagerConfig, error) {
+	var kmConfig datastoreKeyManagerConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &kmConfig); err != nil {
+		return nil, err
+	}
+	return &kmConfig, nil
+}
+
+// loadKeyEncryptionKey reads the base64 encoded key-encryption key from the file, e.g. generated with
+// `openssl rand -base64 32`.
+func loadKeyEncryptionKey(path string) ([]byte, error) {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key-encryption key file: %w", err)
+	}
+	kek, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding key-encryption key: %w", err)
+	}
+	return kek, nil
+}
+
 func decodeDiskKeyManagerConfig(config *providerConfig) (*diskKeyManagerConfig, error) {
 	var dsConfig diskKeyManagerConfig
 	if err := gohcl.DecodeBody(config.Options, nil, &dsConfig); err != nil {
@@ -199,3 +368,35 @@ func makeDiskX509CA(config *providerConfig) (*disk.X509CA, error) {
 	}
 	return ca, nil
 }
+
+func makeMemoryX509CA(config *providerConfig) (*memory.X509CA, error) {
+	var memoryX509CAConfig memory.Config
+	if err := gohcl.DecodeBody(config.Options, nil, &memoryX509CAConfig); err != nil {
+		return nil, err
+	}
+
+	ca, err := memory.New()
+	if err != nil {
+		return nil, err
+	}
+	if err := ca.Configure(&memoryX509CAConfig); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+func makeVaultX509CA(config *providerConfig) (*vault.X509CA, error) {
+	var vaultX509CAConfig vault.Config
+	if err := gohcl.DecodeBody(config.Options, nil, &vaultX509CAConfig); err != nil {
+		return nil, err
+	}
+
+	ca, err := vault.New()
+	if err != nil {
+		return nil, err
+	}
+	if err := ca.Configure(&vaultX509CAConfig); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
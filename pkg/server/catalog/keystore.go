@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+)
+
+// datastoreKeyStore is a keymanager.Store that stores the keys in the datastore,
+// sharing them with all the Galadriel Server replicas that use the same datastore.
+type datastoreKeyStore struct {
+	datastore db.Datastore
+}
+
+func (s *datastoreKeyStore) PutKey(ctx context.Context, id string, keyPEM []byte) error {
+	_, err := s.datastore.CreateOrUpdateSigningKey(ctx, &entity.SigningKey{
+		ID:         id,
+		PrivateKey: keyPEM,
+	})
+	return err
+}
+
+func (s *datastoreKeyStore) GetKeys(ctx context.Context) (map[string][]byte, error) {
+	signingKeys, err := s.datastore.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string][]byte, len(signingKeys))
+	for _, k := range signingKeys {
+		keys[k.ID] = k.PrivateKey
+	}
+
+	return keys, nil
+}
+
+func (s *datastoreKeyStore) DeleteKey(ctx context.Context, id string) error {
+	return s.datastore.DeleteSigningKey(ctx, id)
+}
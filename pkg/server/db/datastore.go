@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
@@ -29,15 +30,26 @@ type Datastore interface {
 	DeleteBundle(ctx context.Context, bundleID uuid.UUID) error
 	FindBundleByID(ctx context.Context, bundleID uuid.UUID) (*entity.Bundle, error)
 	CreateOrUpdateBundle(ctx context.Context, req *entity.Bundle) (*entity.Bundle, error)
+	// CreateOrUpdateBundleIfUnchanged stores the bundle unless the stored bundle changed since it was read: a bundle
+	// without ID is created if the trust domain has no bundle yet, and a bundle with ID is updated if the stored one
+	// still has the previous digest. It returns nil when the stored bundle changed.
+	CreateOrUpdateBundleIfUnchanged(ctx context.Context, req *entity.Bundle, previousDigest []byte) (*entity.Bundle, error)
 	FindBundleByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.Bundle, error)
 
 	// Token
 	ListJoinTokens(ctx context.Context) ([]*entity.JoinToken, error)
 	DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error
-	FindJoinToken(ctx context.Context, token string) (*entity.JoinToken, error)
+	// FindJoinTokenByPrefix looks up a join token by the non-secret prefix of the token.
+	FindJoinTokenByPrefix(ctx context.Context, prefix string) (*entity.JoinToken, error)
+	// FindLegacyJoinTokenByHash looks up a join token generated before the tokens had a prefix, by the unsalted hash
+	// of the whole token.
+	FindLegacyJoinTokenByHash(ctx context.Context, hash []byte) (*entity.JoinToken, error)
 	CreateJoinToken(ctx context.Context, req *entity.JoinToken) (*entity.JoinToken, error)
 	FindJoinTokensByID(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error)
 	UpdateJoinToken(ctx context.Context, joinTokenID uuid.UUID, used bool) (*entity.JoinToken, error)
+	// RecordJoinTokenUse counts an onboarding done with the join token, marking it used once it reaches its max uses.
+	// It returns nil if the token does not exist or is already used.
+	RecordJoinTokenUse(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error)
 	FindJoinTokensByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.JoinToken, error)
 
 	// Relationships
@@ -46,4 +58,51 @@ type Datastore interface {
 	CreateOrUpdateRelationship(ctx context.Context, req *entity.Relationship) (*entity.Relationship, error)
 	FindRelationshipsByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Relationship, error)
 	ListRelationships(ctx context.Context, criteria *criteria.ListRelationshipsCriteria) ([]*entity.Relationship, error)
+
+	// Relationship consents
+	CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error)
+	FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error)
+	DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error
+
+	// Webhook dead letters
+	CreateWebhookDeadLetter(ctx context.Context, req *entity.WebhookDeadLetter) (*entity.WebhookDeadLetter, error)
+	ListWebhookDeadLetters(ctx context.Context) ([]*entity.WebhookDeadLetter, error)
+
+	// Bundle sync states
+	CreateOrUpdateBundleSyncState(ctx context.Context, req *entity.BundleSyncState) (*entity.BundleSyncState, error)
+	FindBundleSyncStatesByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleSyncState, error)
+	ListBundleSyncStates(ctx context.Context) ([]*entity.BundleSyncState, error)
+	DeleteStaleBundleSyncStates(ctx context.Context, trustDomainID uuid.UUID, instanceID string, reportedBefore time.Time) error
+
+	// Harvesters
+	CreateOrUpdateHarvester(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error)
+	UpdateHarvesterBundleUpload(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error)
+	FindHarvestersByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Harvester, error)
+	ListHarvesters(ctx context.Context) ([]*entity.Harvester, error)
+
+	// SigningKeys
+	CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error)
+	ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error)
+	DeleteSigningKey(ctx context.Context, id string) error
+
+	// External trust domains
+	CreateOrUpdateExternalTrustDomain(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error)
+	UpdateExternalTrustDomainRefresh(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error)
+	FindExternalTrustDomainByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.ExternalTrustDomain, error)
+	ListExternalTrustDomains(ctx context.Context) ([]*entity.ExternalTrustDomain, error)
+	DeleteExternalTrustDomain(ctx context.Context, trustDomainID uuid.UUID) error
+
+	// Federation groups
+	CreateOrUpdateFederationGroup(ctx context.Context, req *entity.FederationGroup) (*entity.FederationGroup, error)
+	FindFederationGroupByName(ctx context.Context, name string) (*entity.FederationGroup, error)
+	ListFederationGroups(ctx context.Context) ([]*entity.FederationGroup, error)
+	DeleteFederationGroup(ctx context.Context, groupID uuid.UUID) error
+	CreateFederationGroupMember(ctx context.Context, req *entity.FederationGroupMember) (*entity.FederationGroupMember, error)
+	FindFederationGroupMembersByGroupID(ctx context.Context, groupID uuid.UUID) ([]*entity.FederationGroupMember, error)
+	DeleteFederationGroupMember(ctx context.Context, groupID, trustDomainID uuid.UUID) error
+
+	// DPoP proofs
+	// RecordDPoPProof records the ID of an accepted proof of possession until it expires, forgetting the expired ones.
+	// It returns false if the ID was already recorded, so that the replicas sharing the datastore accept a proof once.
+	RecordDPoPProof(ctx context.Context, id string, expiresAt time.Time) (bool, error)
 }
@@ -12,9 +12,10 @@ import (
 )
 
 const createBundle = `-- name: CreateBundle :one
-INSERT INTO bundles(data, digest, signature, signing_certificate, trust_domain_id)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+INSERT INTO bundles(data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 `
 
 type CreateBundleParams struct {
@@ -23,6 +24,9 @@ type CreateBundleParams struct {
 	Signature          []byte
 	SigningCertificate []byte
 	TrustDomainID      pgtype.UUID
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
 }
 
 func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bundle, error) {
@@ -32,6 +36,9 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		arg.Signature,
 		arg.SigningCertificate,
 		arg.TrustDomainID,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
 	)
 	var i Bundle
 	err := row.Scan(
@@ -43,6 +50,56 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
+	)
+	return i, err
+}
+
+const createBundleIfNotExists = `-- name: CreateBundleIfNotExists :one
+INSERT INTO bundles(data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (trust_domain_id) DO NOTHING
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
+`
+
+type CreateBundleIfNotExistsParams struct {
+	Data               []byte
+	Digest             []byte
+	Signature          []byte
+	SigningCertificate []byte
+	TrustDomainID      pgtype.UUID
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
+}
+
+func (q *Queries) CreateBundleIfNotExists(ctx context.Context, arg CreateBundleIfNotExistsParams) (Bundle, error) {
+	row := q.queryRow(ctx, q.createBundleIfNotExistsStmt, createBundleIfNotExists,
+		arg.Data,
+		arg.Digest,
+		arg.Signature,
+		arg.SigningCertificate,
+		arg.TrustDomainID,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
+	)
+	var i Bundle
+	err := row.Scan(
+		&i.ID,
+		&i.TrustDomainID,
+		&i.Data,
+		&i.Digest,
+		&i.Signature,
+		&i.SigningCertificate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
@@ -59,7 +116,7 @@ func (q *Queries) DeleteBundle(ctx context.Context, id pgtype.UUID) error {
 }
 
 const findBundleByID = `-- name: FindBundleByID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 FROM bundles
 WHERE id = $1
 `
@@ -76,12 +133,15 @@ func (q *Queries) FindBundleByID(ctx context.Context, id pgtype.UUID) (Bundle, e
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
 
 const findBundleByTrustDomainID = `-- name: FindBundleByTrustDomainID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 FROM bundles
 WHERE trust_domain_id = $1
 `
@@ -98,12 +158,15 @@ func (q *Queries) FindBundleByTrustDomainID(ctx context.Context, trustDomainID p
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
 
 const listBundles = `-- name: ListBundles :many
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 FROM bundles
 ORDER BY created_at DESC
 `
@@ -126,6 +189,9 @@ func (q *Queries) ListBundles(ctx context.Context) ([]Bundle, error) {
 			&i.SigningCertificate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VerificationStatus,
+			&i.VerifiedBy,
+			&i.AdminManaged,
 		); err != nil {
 			return nil, err
 		}
@@ -146,9 +212,12 @@ SET data                = $2,
     digest              = $3,
     signature           = $4,
     signing_certificate = $5,
+    verification_status = $6,
+    verified_by         = $7,
+    admin_managed       = $8,
     updated_at          = now()
 WHERE id = $1
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 `
 
 type UpdateBundleParams struct {
@@ -157,6 +226,9 @@ type UpdateBundleParams struct {
 	Digest             []byte
 	Signature          []byte
 	SigningCertificate []byte
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
 }
 
 func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bundle, error) {
@@ -166,6 +238,65 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		arg.Digest,
 		arg.Signature,
 		arg.SigningCertificate,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
+	)
+	var i Bundle
+	err := row.Scan(
+		&i.ID,
+		&i.TrustDomainID,
+		&i.Data,
+		&i.Digest,
+		&i.Signature,
+		&i.SigningCertificate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
+	)
+	return i, err
+}
+
+const updateBundleIfDigest = `-- name: UpdateBundleIfDigest :one
+UPDATE bundles
+SET data                = $2,
+    digest              = $3,
+    signature           = $4,
+    signing_certificate = $5,
+    verification_status = $6,
+    verified_by         = $7,
+    admin_managed       = $8,
+    updated_at          = now()
+WHERE id = $1
+  AND digest = $9
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
+`
+
+type UpdateBundleIfDigestParams struct {
+	ID                 pgtype.UUID
+	Data               []byte
+	Digest             []byte
+	Signature          []byte
+	SigningCertificate []byte
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
+	PreviousDigest     []byte
+}
+
+func (q *Queries) UpdateBundleIfDigest(ctx context.Context, arg UpdateBundleIfDigestParams) (Bundle, error) {
+	row := q.queryRow(ctx, q.updateBundleIfDigestStmt, updateBundleIfDigest,
+		arg.ID,
+		arg.Data,
+		arg.Digest,
+		arg.Signature,
+		arg.SigningCertificate,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
+		arg.PreviousDigest,
 	)
 	var i Bundle
 	err := row.Scan(
@@ -177,6 +308,9 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
@@ -99,7 +99,7 @@ func (d *Datastore) ListTrustDomains(ctx context.Context, criteria *criteria.Lis
 	var domains []TrustDomain
 	for rows.Next() {
 		var d TrustDomain
-		if err := rows.Scan(&d.ID, &d.Name, &d.Description, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		if err := rows.Scan(&d.ID, &d.Name, &d.Description, &d.CreatedAt, &d.UpdatedAt, &d.HarvesterSpiffeID, &d.OnboardingBundle); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		domains = append(domains, d)
@@ -167,6 +167,56 @@ func (d *Datastore) CreateOrUpdateBundle(ctx context.Context, req *entity.Bundle
 	return response, nil
 }
 
+func (d *Datastore) CreateOrUpdateBundleIfUnchanged(ctx context.Context, req *entity.Bundle, previousDigest []byte) (*entity.Bundle, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle Bundle
+	if req.ID.Valid {
+		pgID, err := uuidToPgType(req.ID.UUID)
+		if err != nil {
+			return nil, err
+		}
+		bundle, err = d.querier.UpdateBundleIfDigest(ctx, UpdateBundleIfDigestParams{
+			ID:                 pgID,
+			Data:               req.Data,
+			Digest:             req.Digest,
+			Signature:          req.Signature,
+			SigningCertificate: req.SigningCertificate,
+			VerificationStatus: string(req.VerificationStatus),
+			VerifiedBy:         req.VerifiedBy,
+			AdminManaged:       req.AdminManaged,
+			PreviousDigest:     previousDigest,
+		})
+	} else {
+		bundle, err = d.querier.CreateBundleIfNotExists(ctx, CreateBundleIfNotExistsParams{
+			Data:               req.Data,
+			Digest:             req.Digest,
+			Signature:          req.Signature,
+			SigningCertificate: req.SigningCertificate,
+			VerificationStatus: string(req.VerificationStatus),
+			VerifiedBy:         req.VerifiedBy,
+			AdminManaged:       req.AdminManaged,
+			TrustDomainID:      pgTrustDomainID,
+		})
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed storing bundle: %w", err)
+	}
+
+	response, err := bundle.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model bundle to entity: %w", err)
+	}
+
+	return response, nil
+}
+
 func (d *Datastore) FindBundleByID(ctx context.Context, bundleID uuid.UUID) (*entity.Bundle, error) {
 	pgID, err := uuidToPgType(bundleID)
 	if err != nil {
@@ -249,9 +299,13 @@ func (d *Datastore) CreateJoinToken(ctx context.Context, req *entity.JoinToken)
 	}
 
 	params := CreateJoinTokenParams{
-		Token:         req.Token,
+		TokenPrefix:   req.TokenPrefix,
+		TokenSalt:     req.TokenSalt,
+		TokenHash:     req.TokenHash,
 		ExpiresAt:     req.ExpiresAt,
 		TrustDomainID: pgID,
+		MaxUses:       int32(maxUsesOrDefault(req.MaxUses)),
+		SourceCidr:    req.SourceCIDR,
 	}
 	joinToken, err := d.querier.CreateJoinToken(ctx, params)
 	if err != nil {
@@ -333,6 +387,23 @@ func (d *Datastore) UpdateJoinToken(ctx context.Context, joinTokenID uuid.UUID,
 	return jt.ToEntity(), nil
 }
 
+func (d *Datastore) RecordJoinTokenUse(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	pgID, err := uuidToPgType(joinTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	jt, err := d.querier.RecordJoinTokenUse(ctx, pgID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed recording use of join token with ID=%q, %w", joinTokenID, err)
+	}
+
+	return jt.ToEntity(), nil
+}
+
 func (d *Datastore) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error {
 	pgID, err := uuidToPgType(joinTokenID)
 	if err != nil {
@@ -346,8 +417,8 @@ func (d *Datastore) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID)
 	return nil
 }
 
-func (d *Datastore) FindJoinToken(ctx context.Context, token string) (*entity.JoinToken, error) {
-	joinToken, err := d.querier.FindJoinToken(ctx, token)
+func (d *Datastore) FindJoinTokenByPrefix(ctx context.Context, prefix string) (*entity.JoinToken, error) {
+	joinToken, err := d.querier.FindJoinTokenByPrefix(ctx, prefix)
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return nil, nil
@@ -358,6 +429,18 @@ func (d *Datastore) FindJoinToken(ctx context.Context, token string) (*entity.Jo
 	return joinToken.ToEntity(), nil
 }
 
+func (d *Datastore) FindLegacyJoinTokenByHash(ctx context.Context, hash []byte) (*entity.JoinToken, error) {
+	joinToken, err := d.querier.FindLegacyJoinTokenByHash(ctx, hash)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up legacy join token: %w", err)
+	}
+
+	return joinToken.ToEntity(), nil
+}
+
 func (d *Datastore) CreateOrUpdateRelationship(ctx context.Context, req *entity.Relationship) (*entity.Relationship, error) {
 	var relationship *Relationship
 	var err error
@@ -444,7 +527,7 @@ func (d *Datastore) ListRelationships(ctx context.Context, criteria *criteria.Li
 	var relationships []Relationship
 	for rows.Next() {
 		var m Relationship
-		if err := rows.Scan(&m.ID, &m.TrustDomainAID, &m.TrustDomainBID, &m.TrustDomainAConsent, &m.TrustDomainBConsent, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		if err := rows.Scan(&m.ID, &m.TrustDomainAID, &m.TrustDomainBID, &m.TrustDomainAConsent, &m.TrustDomainBConsent, &m.CreatedAt, &m.UpdatedAt, &m.Direction, &m.FederationGroupID); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		relationships = append(relationships, m)
@@ -470,9 +553,278 @@ func (d *Datastore) DeleteRelationship(ctx context.Context, relationshipID uuid.
 	return nil
 }
 
+func (d *Datastore) CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error) {
+	pgRelationshipID, err := uuidToPgType(req.RelationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := UpsertRelationshipConsentParams{
+		RelationshipID:     pgRelationshipID,
+		TrustDomainID:      pgTrustDomainID,
+		ConsentStatus:      ConsentStatus(req.ConsentStatus),
+		Statement:          req.Statement,
+		Signature:          req.Signature,
+		SigningCertificate: req.SigningCertificate,
+	}
+	consent, err := d.querier.UpsertRelationshipConsent(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing relationship consent: %w", err)
+	}
+
+	return consent.ToEntity(), nil
+}
+
+func (d *Datastore) FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	pgID, err := uuidToPgType(relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := d.querier.FindRelationshipConsentsByRelationshipID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up relationship consents for relationship ID=%q: %w", relationshipID, err)
+	}
+
+	result := make([]*entity.RelationshipConsent, len(consents))
+	for i, c := range consents {
+		result[i] = c.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error {
+	pgRelationshipID, err := uuidToPgType(relationshipID)
+	if err != nil {
+		return err
+	}
+
+	pgTrustDomainID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	params := DeleteRelationshipConsentParams{
+		RelationshipID: pgRelationshipID,
+		TrustDomainID:  pgTrustDomainID,
+	}
+	if err = d.querier.DeleteRelationshipConsent(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting relationship consent for relationship ID=%q: %w", relationshipID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) CreateWebhookDeadLetter(ctx context.Context, req *entity.WebhookDeadLetter) (*entity.WebhookDeadLetter, error) {
+	pgEventID, err := uuidToPgType(req.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := CreateWebhookDeadLetterParams{
+		Webhook:   req.Webhook,
+		Url:       req.URL,
+		EventID:   pgEventID,
+		EventType: req.EventType,
+		Payload:   req.Payload,
+		Attempts:  int32(req.Attempts),
+		LastError: req.LastError,
+	}
+	deadLetter, err := d.querier.CreateWebhookDeadLetter(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating webhook dead letter: %w", err)
+	}
+
+	return deadLetter.ToEntity(), nil
+}
+
+func (d *Datastore) ListWebhookDeadLetters(ctx context.Context) ([]*entity.WebhookDeadLetter, error) {
+	deadLetters, err := d.querier.ListWebhookDeadLetters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up webhook dead letters: %w", err)
+	}
+
+	result := make([]*entity.WebhookDeadLetter, len(deadLetters))
+	for i, dl := range deadLetters {
+		result[i] = dl.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) CreateOrUpdateBundleSyncState(ctx context.Context, req *entity.BundleSyncState) (*entity.BundleSyncState, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := UpsertBundleSyncStateParams{
+		TrustDomainID:        pgTrustDomainID,
+		HarvesterInstanceID:  req.HarvesterInstanceID,
+		FederatedTrustDomain: req.FederatedTrustDomain.String(),
+		Digest:               req.Digest,
+		DigestUpdatedAt:      req.DigestUpdatedAt,
+		ReportedAt:           req.ReportedAt,
+	}
+	state, err := d.querier.UpsertBundleSyncState(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing bundle sync state: %w", err)
+	}
+
+	ent, err := state.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model bundle sync state to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) FindBundleSyncStatesByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleSyncState, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := d.querier.FindBundleSyncStatesByTrustDomainID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up bundle sync states for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return bundleSyncStatesToEntity(states)
+}
+
+func (d *Datastore) ListBundleSyncStates(ctx context.Context) ([]*entity.BundleSyncState, error) {
+	states, err := d.querier.ListBundleSyncStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up bundle sync states: %w", err)
+	}
+
+	return bundleSyncStatesToEntity(states)
+}
+
+func (d *Datastore) DeleteStaleBundleSyncStates(ctx context.Context, trustDomainID uuid.UUID, instanceID string, reportedBefore time.Time) error {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	params := DeleteStaleBundleSyncStatesParams{
+		TrustDomainID:       pgID,
+		HarvesterInstanceID: instanceID,
+		ReportedAt:          reportedBefore,
+	}
+	if err = d.querier.DeleteStaleBundleSyncStates(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting stale bundle sync states for trust domain ID=%q and harvester instance %q: %w", trustDomainID, instanceID, err)
+	}
+
+	return nil
+}
+
+func bundleSyncStatesToEntity(states []BundleSyncState) ([]*entity.BundleSyncState, error) {
+	result := make([]*entity.BundleSyncState, len(states))
+	for i, s := range states {
+		ent, err := s.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting model bundle sync state to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) CreateOrUpdateHarvester(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := UpsertHarvesterParams{
+		TrustDomainID:                pgTrustDomainID,
+		InstanceID:                   req.InstanceID,
+		LastSeenAt:                   req.LastSeenAt,
+		SourceAddress:                req.SourceAddress,
+		Version:                      req.Version,
+		SpireBundlePollInterval:      int64(req.SpireBundlePollInterval.Seconds()),
+		FederatedBundlesPollInterval: int64(req.FederatedBundlesPollInterval.Seconds()),
+		TokenExpiresAt:               req.TokenExpiresAt,
+	}
+	harvester, err := d.querier.UpsertHarvester(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing harvester: %w", err)
+	}
+
+	return harvester.ToEntity(), nil
+}
+
+func (d *Datastore) UpdateHarvesterBundleUpload(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := UpdateHarvesterBundleUploadParams{
+		LastBundleUploadAt: sql.NullTime{
+			Time:  req.LastBundleUploadAt,
+			Valid: !req.LastBundleUploadAt.IsZero(),
+		},
+		LastBundleDigest:       req.LastBundleDigest,
+		LastBundleUploadStatus: string(req.LastBundleUploadStatus),
+		TrustDomainID:          pgTrustDomainID,
+		InstanceID:             req.InstanceID,
+	}
+
+	harvester, err := d.querier.UpdateHarvesterBundleUpload(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed updating bundle upload of harvester instance %q of trust domain ID=%q: %w", req.InstanceID, req.TrustDomainID, err)
+	}
+
+	return harvester.ToEntity(), nil
+}
+
+func (d *Datastore) FindHarvestersByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Harvester, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	harvesters, err := d.querier.FindHarvestersByTrustDomainID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up harvesters for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return harvestersToEntity(harvesters), nil
+}
+
+func (d *Datastore) ListHarvesters(ctx context.Context) ([]*entity.Harvester, error) {
+	harvesters, err := d.querier.ListHarvesters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up harvesters: %w", err)
+	}
+
+	return harvestersToEntity(harvesters), nil
+}
+
+func harvestersToEntity(harvesters []Harvester) []*entity.Harvester {
+	result := make([]*entity.Harvester, len(harvesters))
+	for i, h := range harvesters {
+		result[i] = h.ToEntity()
+	}
+
+	return result
+}
+
 func (d *Datastore) createTrustDomain(ctx context.Context, req *entity.TrustDomain) (*TrustDomain, error) {
 	params := CreateTrustDomainParams{
-		Name: req.Name.String(),
+		Name:              req.Name.String(),
+		HarvesterSpiffeID: harvesterSPIFFEIDToString(req.HarvesterSPIFFEID),
+		OnboardingBundle:  onboardingBundleOrEmpty(req.OnboardingBundle),
 	}
 	if req.Description != "" {
 		params.Description = sql.NullString{
@@ -495,7 +847,9 @@ func (d *Datastore) updateTrustDomain(ctx context.Context, req *entity.TrustDoma
 	}
 
 	params := UpdateTrustDomainParams{
-		ID: pgID,
+		ID:                pgID,
+		HarvesterSpiffeID: harvesterSPIFFEIDToString(req.HarvesterSPIFFEID),
+		OnboardingBundle:  onboardingBundleOrEmpty(req.OnboardingBundle),
 	}
 
 	if req.Description != "" {
@@ -522,6 +876,9 @@ func (d *Datastore) createBundle(ctx context.Context, req *entity.Bundle) (*Bund
 		Digest:             req.Digest,
 		Signature:          req.Signature,
 		SigningCertificate: req.SigningCertificate,
+		VerificationStatus: string(req.VerificationStatus),
+		VerifiedBy:         req.VerifiedBy,
+		AdminManaged:       req.AdminManaged,
 		TrustDomainID:      pgTrustDomainID,
 	}
 
@@ -544,6 +901,9 @@ func (d *Datastore) updateBundle(ctx context.Context, req *entity.Bundle) (*Bund
 		Digest:             req.Digest,
 		Signature:          req.Signature,
 		SigningCertificate: req.SigningCertificate,
+		VerificationStatus: string(req.VerificationStatus),
+		VerifiedBy:         req.VerifiedBy,
+		AdminManaged:       req.AdminManaged,
 	}
 
 	bundle, err := d.querier.UpdateBundle(ctx, params)
@@ -578,11 +938,18 @@ func (d *Datastore) createRelationship(ctx context.Context, req *entity.Relation
 		req.UpdatedAt = time.Now()
 	}
 
+	direction := req.Direction
+	if direction == "" {
+		direction = entity.RelationshipBidirectional
+	}
+
 	params := CreateRelationshipParams{
 		TrustDomainAID:      pgTrustDomainAID,
 		TrustDomainBID:      pgTrustDomainBID,
 		TrustDomainAConsent: ConsentStatus(req.TrustDomainAConsent),
 		TrustDomainBConsent: ConsentStatus(req.TrustDomainBConsent),
+		Direction:           string(direction),
+		FederationGroupID:   req.FederationGroupID,
 		CreatedAt:           req.CreatedAt,
 		UpdatedAt:           req.UpdatedAt,
 	}
@@ -605,6 +972,7 @@ func (d *Datastore) updateRelationship(ctx context.Context, req *entity.Relation
 		ID:                  pgID,
 		TrustDomainAConsent: ConsentStatus(req.TrustDomainAConsent),
 		TrustDomainBConsent: ConsentStatus(req.TrustDomainBConsent),
+		FederationGroupID:   req.FederationGroupID,
 	}
 
 	relationship, err := d.querier.UpdateRelationship(ctx, params)
@@ -641,3 +1009,279 @@ func trustDomainToEntity(models []TrustDomain) ([]*entity.TrustDomain, error) {
 
 	return result, nil
 }
+
+func (d *Datastore) CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error) {
+	params := UpsertSigningKeyParams{
+		ID:         req.ID,
+		PrivateKey: req.PrivateKey,
+	}
+	signingKey, err := d.querier.UpsertSigningKey(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing signing key %q: %w", req.ID, err)
+	}
+
+	return signingKey.ToEntity(), nil
+}
+
+func (d *Datastore) DeleteSigningKey(ctx context.Context, id string) error {
+	if err := d.querier.DeleteSigningKey(ctx, id); err != nil {
+		return fmt.Errorf("failed deleting signing key with ID=%q: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	signingKeys, err := d.querier.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up signing keys: %w", err)
+	}
+
+	result := make([]*entity.SigningKey, len(signingKeys))
+	for i, k := range signingKeys {
+		result[i] = k.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) CreateOrUpdateExternalTrustDomain(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := UpsertExternalTrustDomainParams{
+		TrustDomainID:         pgTrustDomainID,
+		BundleEndpointUrl:     req.BundleEndpointURL,
+		BundleEndpointProfile: req.BundleEndpointProfile,
+		EndpointSpiffeID:      req.EndpointSPIFFEID,
+		BootstrapBundle:       req.BootstrapBundle,
+	}
+	externalTD, err := d.querier.UpsertExternalTrustDomain(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing external trust domain: %w", err)
+	}
+
+	return externalTD.ToEntity(), nil
+}
+
+func (d *Datastore) UpdateExternalTrustDomainRefresh(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := UpdateExternalTrustDomainRefreshParams{
+		LastRefreshAt: sql.NullTime{
+			Time:  req.LastRefreshAt,
+			Valid: !req.LastRefreshAt.IsZero(),
+		},
+		LastRefreshError: req.LastRefreshError,
+		TrustDomainID:    pgTrustDomainID,
+	}
+	externalTD, err := d.querier.UpdateExternalTrustDomainRefresh(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed updating refresh of external trust domain ID=%q: %w", req.TrustDomainID, err)
+	}
+
+	return externalTD.ToEntity(), nil
+}
+
+func (d *Datastore) FindExternalTrustDomainByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.ExternalTrustDomain, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	externalTD, err := d.querier.FindExternalTrustDomainByTrustDomainID(ctx, pgID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up external trust domain for ID=%q: %w", trustDomainID, err)
+	}
+
+	return externalTD.ToEntity(), nil
+}
+
+func (d *Datastore) ListExternalTrustDomains(ctx context.Context) ([]*entity.ExternalTrustDomain, error) {
+	externalTDs, err := d.querier.ListExternalTrustDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up external trust domains: %w", err)
+	}
+
+	result := make([]*entity.ExternalTrustDomain, len(externalTDs))
+	for i, e := range externalTDs {
+		result[i] = e.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteExternalTrustDomain(ctx context.Context, trustDomainID uuid.UUID) error {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	if err := d.querier.DeleteExternalTrustDomain(ctx, pgID); err != nil {
+		return fmt.Errorf("failed deleting external trust domain for ID=%q: %w", trustDomainID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) CreateOrUpdateFederationGroup(ctx context.Context, req *entity.FederationGroup) (*entity.FederationGroup, error) {
+	defaultConsent := req.DefaultConsent
+	if defaultConsent == "" {
+		defaultConsent = entity.ConsentStatusPending
+	}
+
+	var group FederationGroup
+	if req.ID.Valid {
+		pgID, err := uuidToPgType(req.ID.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		group, err = d.querier.UpdateFederationGroup(ctx, UpdateFederationGroupParams{
+			ID:             pgID,
+			Description:    req.Description,
+			DefaultConsent: ConsentStatus(defaultConsent),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed updating federation group: %w", err)
+		}
+	} else {
+		var err error
+		group, err = d.querier.CreateFederationGroup(ctx, CreateFederationGroupParams{
+			Name:           req.Name,
+			Description:    req.Description,
+			DefaultConsent: ConsentStatus(defaultConsent),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed creating federation group: %w", err)
+		}
+	}
+
+	return group.ToEntity(), nil
+}
+
+func (d *Datastore) FindFederationGroupByName(ctx context.Context, name string) (*entity.FederationGroup, error) {
+	group, err := d.querier.FindFederationGroupByName(ctx, name)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up federation group %q: %w", name, err)
+	}
+
+	return group.ToEntity(), nil
+}
+
+func (d *Datastore) ListFederationGroups(ctx context.Context) ([]*entity.FederationGroup, error) {
+	groups, err := d.querier.ListFederationGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up federation groups: %w", err)
+	}
+
+	result := make([]*entity.FederationGroup, len(groups))
+	for i, g := range groups {
+		result[i] = g.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteFederationGroup(ctx context.Context, groupID uuid.UUID) error {
+	pgID, err := uuidToPgType(groupID)
+	if err != nil {
+		return err
+	}
+
+	if err := d.querier.DeleteFederationGroup(ctx, pgID); err != nil {
+		return fmt.Errorf("failed deleting federation group ID=%q: %w", groupID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) CreateFederationGroupMember(ctx context.Context, req *entity.FederationGroupMember) (*entity.FederationGroupMember, error) {
+	pgGroupID, err := uuidToPgType(req.FederationGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := d.querier.CreateFederationGroupMember(ctx, CreateFederationGroupMemberParams{
+		FederationGroupID: pgGroupID,
+		TrustDomainID:     pgTrustDomainID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed storing federation group member: %w", err)
+	}
+
+	return member.ToEntity(), nil
+}
+
+func (d *Datastore) FindFederationGroupMembersByGroupID(ctx context.Context, groupID uuid.UUID) ([]*entity.FederationGroupMember, error) {
+	pgID, err := uuidToPgType(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := d.querier.FindFederationGroupMembersByGroupID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up members of federation group ID=%q: %w", groupID, err)
+	}
+
+	result := make([]*entity.FederationGroupMember, len(members))
+	for i, m := range members {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteFederationGroupMember(ctx context.Context, groupID, trustDomainID uuid.UUID) error {
+	pgGroupID, err := uuidToPgType(groupID)
+	if err != nil {
+		return err
+	}
+
+	pgTrustDomainID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	params := DeleteFederationGroupMemberParams{
+		FederationGroupID: pgGroupID,
+		TrustDomainID:     pgTrustDomainID,
+	}
+	if err := d.querier.DeleteFederationGroupMember(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting member ID=%q of federation group ID=%q: %w", trustDomainID, groupID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) RecordDPoPProof(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	if err := d.querier.DeleteExpiredDPoPProofs(ctx, time.Now()); err != nil {
+		return false, fmt.Errorf("failed deleting expired DPoP proofs: %w", err)
+	}
+
+	recorded, err := d.querier.InsertDPoPProof(ctx, InsertDPoPProofParams{
+		ID:        id,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed recording DPoP proof with ID=%q: %w", id, err)
+	}
+
+	return recorded == 1, nil
+}
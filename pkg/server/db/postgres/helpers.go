@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"time"
+
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
@@ -30,6 +32,18 @@ func (td TrustDomain) ToEntity() (*entity.TrustDomain, error) {
 		result.Description = td.Description.String
 	}
 
+	if td.HarvesterSpiffeID != "" {
+		harvesterID, err := spiffeid.FromString(td.HarvesterSpiffeID)
+		if err != nil {
+			return nil, errors.Errorf("cannot convert model to entity: %v", err)
+		}
+		result.HarvesterSPIFFEID = harvesterID
+	}
+
+	if len(td.OnboardingBundle) > 0 {
+		result.OnboardingBundle = td.OnboardingBundle
+	}
+
 	return result, nil
 }
 
@@ -45,6 +59,8 @@ func (r Relationship) ToEntity() (*entity.Relationship, error) {
 		TrustDomainBID:      r.TrustDomainBID.Bytes,
 		TrustDomainAConsent: entity.ConsentStatus(r.TrustDomainAConsent),
 		TrustDomainBConsent: entity.ConsentStatus(r.TrustDomainBConsent),
+		Direction:           entity.RelationshipDirection(r.Direction),
+		FederationGroupID:   r.FederationGroupID,
 		CreatedAt:           r.CreatedAt,
 		UpdatedAt:           r.UpdatedAt,
 	}, nil
@@ -63,6 +79,9 @@ func (b Bundle) ToEntity() (*entity.Bundle, error) {
 		Signature:          b.Signature,
 		SigningCertificate: b.SigningCertificate,
 		TrustDomainID:      b.TrustDomainID.Bytes,
+		VerificationStatus: entity.BundleVerificationStatus(b.VerificationStatus),
+		VerifiedBy:         b.VerifiedBy,
+		AdminManaged:       b.AdminManaged,
 		CreatedAt:          b.CreatedAt,
 		UpdatedAt:          b.UpdatedAt,
 	}, nil
@@ -76,15 +95,58 @@ func (jt JoinToken) ToEntity() *entity.JoinToken {
 
 	return &entity.JoinToken{
 		ID:            id,
-		Token:         jt.Token,
+		TokenPrefix:   jt.TokenPrefix,
+		TokenSalt:     jt.TokenSalt,
+		TokenHash:     jt.TokenHash,
 		ExpiresAt:     jt.ExpiresAt,
 		Used:          jt.Used,
 		TrustDomainID: jt.TrustDomainID.Bytes,
+		MaxUses:       int(jt.MaxUses),
+		UseCount:      int(jt.UseCount),
+		SourceCIDR:    jt.SourceCidr,
 		CreatedAt:     jt.CreatedAt,
 		UpdatedAt:     jt.UpdatedAt,
 	}
 }
 
+func (c RelationshipConsent) ToEntity() *entity.RelationshipConsent {
+	id := uuid.NullUUID{
+		UUID:  c.ID.Bytes,
+		Valid: true,
+	}
+
+	return &entity.RelationshipConsent{
+		ID:                 id,
+		RelationshipID:     c.RelationshipID.Bytes,
+		TrustDomainID:      c.TrustDomainID.Bytes,
+		ConsentStatus:      entity.ConsentStatus(c.ConsentStatus),
+		Statement:          c.Statement,
+		Signature:          c.Signature,
+		SigningCertificate: c.SigningCertificate,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
+
+func (dl WebhookDeadLetter) ToEntity() *entity.WebhookDeadLetter {
+	id := uuid.NullUUID{
+		UUID:  dl.ID.Bytes,
+		Valid: true,
+	}
+
+	return &entity.WebhookDeadLetter{
+		ID:        id,
+		Webhook:   dl.Webhook,
+		URL:       dl.Url,
+		EventID:   dl.EventID.Bytes,
+		EventType: dl.EventType,
+		Payload:   dl.Payload,
+		Attempts:  int(dl.Attempts),
+		LastError: dl.LastError,
+		CreatedAt: dl.CreatedAt,
+	}
+}
+
 func uuidToPgType(id uuid.UUID) (pgtype.UUID, error) {
 	pgID := pgtype.UUID{}
 	err := pgID.Set(id)
@@ -93,3 +155,116 @@ func uuidToPgType(id uuid.UUID) (pgtype.UUID, error) {
 	}
 	return pgID, err
 }
+
+func (h Harvester) ToEntity() *entity.Harvester {
+	id := uuid.NullUUID{
+		UUID:  h.ID.Bytes,
+		Valid: true,
+	}
+
+	return &entity.Harvester{
+		ID:                           id,
+		TrustDomainID:                h.TrustDomainID.Bytes,
+		InstanceID:                   h.InstanceID,
+		LastSeenAt:                   h.LastSeenAt,
+		SourceAddress:                h.SourceAddress,
+		Version:                      h.Version,
+		SpireBundlePollInterval:      time.Duration(h.SpireBundlePollInterval) * time.Second,
+		FederatedBundlesPollInterval: time.Duration(h.FederatedBundlesPollInterval) * time.Second,
+		TokenExpiresAt:               h.TokenExpiresAt,
+		LastBundleUploadAt:           h.LastBundleUploadAt.Time,
+		LastBundleDigest:             h.LastBundleDigest,
+		LastBundleUploadStatus:       entity.BundleUploadStatus(h.LastBundleUploadStatus),
+		CreatedAt:                    h.CreatedAt,
+		UpdatedAt:                    h.UpdatedAt,
+	}
+}
+
+func (s BundleSyncState) ToEntity() (*entity.BundleSyncState, error) {
+	federatedTD, err := spiffeid.TrustDomainFromString(s.FederatedTrustDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.BundleSyncState{
+		ID: uuid.NullUUID{
+			UUID:  s.ID.Bytes,
+			Valid: true,
+		},
+		TrustDomainID:        s.TrustDomainID.Bytes,
+		HarvesterInstanceID:  s.HarvesterInstanceID,
+		FederatedTrustDomain: federatedTD,
+		Digest:               s.Digest,
+		DigestUpdatedAt:      s.DigestUpdatedAt,
+		ReportedAt:           s.ReportedAt,
+		CreatedAt:            s.CreatedAt,
+		UpdatedAt:            s.UpdatedAt,
+	}, nil
+}
+
+func (k SigningKey) ToEntity() *entity.SigningKey {
+	return &entity.SigningKey{
+		ID:         k.ID,
+		PrivateKey: k.PrivateKey,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+}
+
+func (e ExternalTrustDomain) ToEntity() *entity.ExternalTrustDomain {
+	return &entity.ExternalTrustDomain{
+		ID:                    uuid.NullUUID{UUID: e.ID.Bytes, Valid: true},
+		TrustDomainID:         e.TrustDomainID.Bytes,
+		BundleEndpointURL:     e.BundleEndpointUrl,
+		BundleEndpointProfile: e.BundleEndpointProfile,
+		EndpointSPIFFEID:      e.EndpointSpiffeID,
+		BootstrapBundle:       e.BootstrapBundle,
+		LastRefreshAt:         e.LastRefreshAt.Time,
+		LastRefreshError:      e.LastRefreshError,
+		CreatedAt:             e.CreatedAt,
+		UpdatedAt:             e.UpdatedAt,
+	}
+}
+
+func (g FederationGroup) ToEntity() *entity.FederationGroup {
+	return &entity.FederationGroup{
+		ID:             uuid.NullUUID{UUID: g.ID.Bytes, Valid: true},
+		Name:           g.Name,
+		Description:    g.Description,
+		DefaultConsent: entity.ConsentStatus(g.DefaultConsent),
+		CreatedAt:      g.CreatedAt,
+		UpdatedAt:      g.UpdatedAt,
+	}
+}
+
+func (m FederationGroupMember) ToEntity() *entity.FederationGroupMember {
+	return &entity.FederationGroupMember{
+		ID:                uuid.NullUUID{UUID: m.ID.Bytes, Valid: true},
+		FederationGroupID: m.FederationGroupID.Bytes,
+		TrustDomainID:     m.TrustDomainID.Bytes,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+// maxUsesOrDefault makes join tokens single-use unless they allow more uses.
+func maxUsesOrDefault(maxUses int) int {
+	if maxUses < 1 {
+		return 1
+	}
+	return maxUses
+}
+
+func harvesterSPIFFEIDToString(id spiffeid.ID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.String()
+}
+
+// onboardingBundleOrEmpty avoids storing NULL in the not-null onboarding bundle column.
+func onboardingBundleOrEmpty(bundle []byte) []byte {
+	if bundle == nil {
+		return []byte{}
+	}
+	return bundle
+}
@@ -0,0 +1,2 @@
+ALTER TABLE bundles
+    DROP COLUMN admin_managed;
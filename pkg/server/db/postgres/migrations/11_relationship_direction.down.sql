@@ -0,0 +1,2 @@
+ALTER TABLE relationships
+    DROP COLUMN direction;
@@ -0,0 +1,6 @@
+ALTER TABLE relationships
+    DROP COLUMN federation_group_id;
+
+DROP TABLE IF EXISTS federation_group_members;
+
+DROP TABLE IF EXISTS federation_groups;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS federation_groups
+(
+    id              UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    name            TEXT                     NOT NULL UNIQUE,
+    description     TEXT                     NOT NULL DEFAULT '',
+    default_consent consent_status           NOT NULL DEFAULT 'pending',
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS federation_group_members
+(
+    id                  UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    federation_group_id UUID                     NOT NULL,
+    trust_domain_id     UUID                     NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (federation_group_id, trust_domain_id)
+);
+
+ALTER TABLE "federation_group_members"
+    ADD FOREIGN KEY ("federation_group_id") REFERENCES "federation_groups" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "federation_group_members"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
+
+-- the group whose members the relationship federates, NULL for the relationships created by an admin.
+-- It is not a foreign key, so that the reconciler removes the relationships of deleted groups.
+ALTER TABLE relationships
+    ADD COLUMN federation_group_id UUID;
@@ -0,0 +1,9 @@
+-- The plaintext of the legacy tokens can't be restored, they are left with their ID as token.
+DROP INDEX join_tokens_legacy_hash_idx;
+
+ALTER TABLE join_tokens
+    DROP COLUMN token_hash;
+ALTER TABLE join_tokens
+    DROP COLUMN token_salt;
+ALTER TABLE join_tokens
+    RENAME COLUMN token_prefix TO token;
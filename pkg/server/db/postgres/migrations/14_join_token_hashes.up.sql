@@ -0,0 +1,19 @@
+-- Join tokens are stored as a non-secret prefix and the salted hash of their secret. The tokens stored in plaintext
+-- have no prefix: they keep their ID as prefix, and the outstanding ones are kept as legacy tokens, stored with the
+-- unsalted hash of the whole token, by which they are looked up and accepted until they expire.
+ALTER TABLE join_tokens
+    ADD COLUMN token_salt BYTEA NOT NULL DEFAULT '';
+ALTER TABLE join_tokens
+    ADD COLUMN token_hash BYTEA NOT NULL DEFAULT '';
+
+UPDATE join_tokens
+SET token_hash = sha256(convert_to(token, 'UTF8'))
+WHERE NOT used;
+
+UPDATE join_tokens
+SET token = id::text;
+
+ALTER TABLE join_tokens
+    RENAME COLUMN token TO token_prefix;
+
+CREATE INDEX join_tokens_legacy_hash_idx ON join_tokens (token_hash) WHERE token_salt = '';
@@ -0,0 +1,4 @@
+ALTER TABLE trust_domains
+    DROP COLUMN onboarding_bundle;
+ALTER TABLE trust_domains
+    DROP COLUMN harvester_spiffe_id;
@@ -0,0 +1,4 @@
+ALTER TABLE trust_domains
+    ADD COLUMN harvester_spiffe_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE trust_domains
+    ADD COLUMN onboarding_bundle BYTEA NOT NULL DEFAULT '';
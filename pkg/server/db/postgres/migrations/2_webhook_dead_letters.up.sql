@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS webhook_dead_letters
+(
+    id         UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    webhook    TEXT                     NOT NULL,
+    url        TEXT                     NOT NULL,
+    event_id   UUID                     NOT NULL,
+    event_type TEXT                     NOT NULL,
+    payload    BYTEA                    NOT NULL,
+    attempts   INTEGER                  NOT NULL,
+    last_error TEXT                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
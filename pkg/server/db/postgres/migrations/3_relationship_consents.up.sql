@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS relationship_consents
+(
+    id                  UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    relationship_id     UUID                     NOT NULL,
+    trust_domain_id     UUID                     NOT NULL,
+    consent_status      consent_status           NOT NULL,
+    statement           BYTEA                    NOT NULL,
+    signature           BYTEA                    NOT NULL,
+    signing_certificate BYTEA                    NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (relationship_id, trust_domain_id)
+);
+
+ALTER TABLE "relationship_consents"
+    ADD FOREIGN KEY ("relationship_id") REFERENCES "relationships" ("id") ON DELETE CASCADE;
+ALTER TABLE "relationship_consents"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
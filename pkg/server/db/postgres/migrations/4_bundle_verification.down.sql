@@ -0,0 +1,5 @@
+ALTER TABLE bundles
+    DROP COLUMN verified_by;
+
+ALTER TABLE bundles
+    DROP COLUMN verification_status;
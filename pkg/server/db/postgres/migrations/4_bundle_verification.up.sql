@@ -0,0 +1,5 @@
+ALTER TABLE bundles
+    ADD COLUMN verification_status TEXT NOT NULL DEFAULT 'skipped';
+
+ALTER TABLE bundles
+    ADD COLUMN verified_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS harvesters
+(
+    id                              UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    trust_domain_id                 UUID                     NOT NULL UNIQUE,
+    last_seen_at                    TIMESTAMP WITH TIME ZONE NOT NULL,
+    source_address                  TEXT                     NOT NULL,
+    version                         TEXT                     NOT NULL,
+    spire_bundle_poll_interval      BIGINT                   NOT NULL,
+    federated_bundles_poll_interval BIGINT                   NOT NULL,
+    token_expires_at                TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at                      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at                      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE "harvesters"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS bundle_sync_states
+(
+    id                     UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    trust_domain_id        UUID                     NOT NULL,
+    federated_trust_domain TEXT                     NOT NULL,
+    digest                 BYTEA                    NOT NULL,
+    digest_updated_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    reported_at            TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at             TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at             TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (trust_domain_id, federated_trust_domain)
+);
+
+ALTER TABLE "bundle_sync_states"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
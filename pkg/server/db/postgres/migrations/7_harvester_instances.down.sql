@@ -0,0 +1,41 @@
+-- keeps the most recently reported state of each federated bundle of a trust domain
+DELETE
+FROM bundle_sync_states s
+    USING bundle_sync_states newer
+WHERE s.trust_domain_id = newer.trust_domain_id
+  AND s.federated_trust_domain = newer.federated_trust_domain
+  AND (s.reported_at, s.id) < (newer.reported_at, newer.id);
+
+ALTER TABLE bundle_sync_states
+    DROP CONSTRAINT IF EXISTS bundle_sync_states_instance_federated_trust_domain_key;
+
+ALTER TABLE bundle_sync_states
+    DROP COLUMN IF EXISTS harvester_instance_id;
+
+ALTER TABLE bundle_sync_states
+    ADD CONSTRAINT bundle_sync_states_trust_domain_id_federated_trust_domain_key UNIQUE (trust_domain_id, federated_trust_domain);
+
+ALTER TABLE harvesters
+    DROP COLUMN IF EXISTS last_bundle_upload_status;
+
+ALTER TABLE harvesters
+    DROP COLUMN IF EXISTS last_bundle_digest;
+
+ALTER TABLE harvesters
+    DROP COLUMN IF EXISTS last_bundle_upload_at;
+
+-- keeps the most recently seen instance of each trust domain
+DELETE
+FROM harvesters h
+    USING harvesters newer
+WHERE h.trust_domain_id = newer.trust_domain_id
+  AND (h.last_seen_at, h.id) < (newer.last_seen_at, newer.id);
+
+ALTER TABLE harvesters
+    DROP CONSTRAINT IF EXISTS harvesters_trust_domain_id_instance_id_key;
+
+ALTER TABLE harvesters
+    DROP COLUMN IF EXISTS instance_id;
+
+ALTER TABLE harvesters
+    ADD CONSTRAINT harvesters_trust_domain_id_key UNIQUE (trust_domain_id);
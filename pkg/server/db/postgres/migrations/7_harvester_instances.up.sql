@@ -0,0 +1,32 @@
+ALTER TABLE harvesters
+    ADD COLUMN instance_id TEXT NOT NULL DEFAULT 'default';
+
+ALTER TABLE harvesters
+    DROP CONSTRAINT IF EXISTS harvesters_trust_domain_id_key;
+
+ALTER TABLE harvesters
+    ADD CONSTRAINT harvesters_trust_domain_id_instance_id_key UNIQUE (trust_domain_id, instance_id);
+
+ALTER TABLE harvesters
+    ALTER COLUMN instance_id DROP DEFAULT;
+
+ALTER TABLE harvesters
+    ADD COLUMN last_bundle_upload_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE harvesters
+    ADD COLUMN last_bundle_digest BYTEA;
+
+ALTER TABLE harvesters
+    ADD COLUMN last_bundle_upload_status TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE bundle_sync_states
+    ADD COLUMN harvester_instance_id TEXT NOT NULL DEFAULT 'default';
+
+ALTER TABLE bundle_sync_states
+    DROP CONSTRAINT IF EXISTS bundle_sync_states_trust_domain_id_federated_trust_domain_key;
+
+ALTER TABLE bundle_sync_states
+    ADD CONSTRAINT bundle_sync_states_instance_federated_trust_domain_key UNIQUE (trust_domain_id, harvester_instance_id, federated_trust_domain);
+
+ALTER TABLE bundle_sync_states
+    ALTER COLUMN harvester_instance_id DROP DEFAULT;
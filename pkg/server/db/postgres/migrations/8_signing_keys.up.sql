@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS signing_keys
+(
+    id          TEXT PRIMARY KEY,
+    private_key BYTEA                    NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
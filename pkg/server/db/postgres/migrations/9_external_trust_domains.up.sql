@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS external_trust_domains
+(
+    id                      UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    trust_domain_id         UUID                     NOT NULL UNIQUE,
+    bundle_endpoint_url     TEXT                     NOT NULL,
+    bundle_endpoint_profile TEXT                     NOT NULL,
+    endpoint_spiffe_id      TEXT                     NOT NULL DEFAULT '',
+    bootstrap_bundle        BYTEA,
+    last_refresh_at         TIMESTAMP WITH TIME ZONE,
+    last_refresh_error      TEXT                     NOT NULL DEFAULT '',
+    created_at              TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at              TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE "external_trust_domains"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
 )
 
@@ -65,16 +66,87 @@ type Bundle struct {
 	SigningCertificate []byte
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
+}
+
+type BundleSyncState struct {
+	ID                   pgtype.UUID
+	TrustDomainID        pgtype.UUID
+	FederatedTrustDomain string
+	Digest               []byte
+	DigestUpdatedAt      time.Time
+	ReportedAt           time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	HarvesterInstanceID  string
+}
+
+type DpopProof struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+type ExternalTrustDomain struct {
+	ID                    pgtype.UUID
+	TrustDomainID         pgtype.UUID
+	BundleEndpointUrl     string
+	BundleEndpointProfile string
+	EndpointSpiffeID      string
+	BootstrapBundle       []byte
+	LastRefreshAt         sql.NullTime
+	LastRefreshError      string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+type FederationGroup struct {
+	ID             pgtype.UUID
+	Name           string
+	Description    string
+	DefaultConsent ConsentStatus
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type FederationGroupMember struct {
+	ID                pgtype.UUID
+	FederationGroupID pgtype.UUID
+	TrustDomainID     pgtype.UUID
+	CreatedAt         time.Time
+}
+
+type Harvester struct {
+	ID                           pgtype.UUID
+	TrustDomainID                pgtype.UUID
+	LastSeenAt                   time.Time
+	SourceAddress                string
+	Version                      string
+	SpireBundlePollInterval      int64
+	FederatedBundlesPollInterval int64
+	TokenExpiresAt               time.Time
+	CreatedAt                    time.Time
+	UpdatedAt                    time.Time
+	InstanceID                   string
+	LastBundleUploadAt           sql.NullTime
+	LastBundleDigest             []byte
+	LastBundleUploadStatus       string
 }
 
 type JoinToken struct {
 	ID            pgtype.UUID
 	TrustDomainID pgtype.UUID
-	Token         string
+	TokenPrefix   string
 	Used          bool
 	ExpiresAt     time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	MaxUses       int32
+	UseCount      int32
+	SourceCidr    string
+	TokenSalt     []byte
+	TokenHash     []byte
 }
 
 type Relationship struct {
@@ -85,12 +157,47 @@ type Relationship struct {
 	TrustDomainBConsent ConsentStatus
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+	Direction           string
+	FederationGroupID   uuid.NullUUID
+}
+
+type RelationshipConsent struct {
+	ID                 pgtype.UUID
+	RelationshipID     pgtype.UUID
+	TrustDomainID      pgtype.UUID
+	ConsentStatus      ConsentStatus
+	Statement          []byte
+	Signature          []byte
+	SigningCertificate []byte
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type SigningKey struct {
+	ID         string
+	PrivateKey []byte
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type TrustDomain struct {
-	ID          pgtype.UUID
-	Name        string
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                pgtype.UUID
+	Name              string
+	Description       sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	HarvesterSpiffeID string
+	OnboardingBundle  []byte
+}
+
+type WebhookDeadLetter struct {
+	ID        pgtype.UUID
+	Webhook   string
+	Url       string
+	EventID   pgtype.UUID
+	EventType string
+	Payload   []byte
+	Attempts  int32
+	LastError string
+	CreatedAt time.Time
 }
@@ -0,0 +1,30 @@
+-- name: UpsertBundleSyncState :one
+INSERT INTO bundle_sync_states(trust_domain_id, harvester_instance_id, federated_trust_domain, digest, digest_updated_at,
+                               reported_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (trust_domain_id, harvester_instance_id, federated_trust_domain) DO UPDATE SET digest            = excluded.digest,
+                                                                                           digest_updated_at = CASE
+                                                                                                                   WHEN bundle_sync_states.digest = excluded.digest
+                                                                                                                       THEN bundle_sync_states.digest_updated_at
+                                                                                                                   ELSE excluded.digest_updated_at END,
+                                                                                           reported_at       = excluded.reported_at,
+                                                                                           updated_at        = now()
+RETURNING *;
+
+-- name: FindBundleSyncStatesByTrustDomainID :many
+SELECT *
+FROM bundle_sync_states
+WHERE trust_domain_id = $1
+ORDER BY federated_trust_domain, harvester_instance_id;
+
+-- name: ListBundleSyncStates :many
+SELECT *
+FROM bundle_sync_states
+ORDER BY created_at;
+
+-- name: DeleteStaleBundleSyncStates :exec
+DELETE
+FROM bundle_sync_states
+WHERE trust_domain_id = $1
+  AND harvester_instance_id = $2
+  AND reported_at < $3;
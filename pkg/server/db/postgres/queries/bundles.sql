@@ -1,6 +1,14 @@
 -- name: CreateBundle :one
-INSERT INTO bundles(data, digest, signature, signing_certificate, trust_domain_id)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO bundles(data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: CreateBundleIfNotExists :one
+INSERT INTO bundles(data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (trust_domain_id) DO NOTHING
 RETURNING *;
 
 -- name: UpdateBundle :one
@@ -9,8 +17,25 @@ SET data                = $2,
     digest              = $3,
     signature           = $4,
     signing_certificate = $5,
+    verification_status = $6,
+    verified_by         = $7,
+    admin_managed       = $8,
+    updated_at          = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateBundleIfDigest :one
+UPDATE bundles
+SET data                = $2,
+    digest              = $3,
+    signature           = $4,
+    signing_certificate = $5,
+    verification_status = $6,
+    verified_by         = $7,
+    admin_managed       = $8,
     updated_at          = now()
 WHERE id = $1
+  AND digest = sqlc.arg(previous_digest)
 RETURNING *;
 
 -- name: DeleteBundle :exec
@@ -0,0 +1,44 @@
+-- name: CreateFederationGroup :one
+INSERT INTO federation_groups(name, description, default_consent)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateFederationGroup :one
+UPDATE federation_groups
+SET description     = $2,
+    default_consent = $3,
+    updated_at      = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: FindFederationGroupByName :one
+SELECT *
+FROM federation_groups
+WHERE name = $1;
+
+-- name: ListFederationGroups :many
+SELECT *
+FROM federation_groups
+ORDER BY created_at, name;
+
+-- name: DeleteFederationGroup :exec
+DELETE
+FROM federation_groups
+WHERE id = $1;
+
+-- name: CreateFederationGroupMember :one
+INSERT INTO federation_group_members(federation_group_id, trust_domain_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: FindFederationGroupMembersByGroupID :many
+SELECT *
+FROM federation_group_members
+WHERE federation_group_id = $1
+ORDER BY created_at;
+
+-- name: DeleteFederationGroupMember :exec
+DELETE
+FROM federation_group_members
+WHERE federation_group_id = $1
+  AND trust_domain_id = $2;
@@ -0,0 +1,33 @@
+-- name: UpsertHarvester :one
+INSERT INTO harvesters(trust_domain_id, instance_id, last_seen_at, source_address, version,
+                       spire_bundle_poll_interval, federated_bundles_poll_interval, token_expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (trust_domain_id, instance_id) DO UPDATE SET last_seen_at                    = excluded.last_seen_at,
+                                                         source_address                  = excluded.source_address,
+                                                         version                         = excluded.version,
+                                                         spire_bundle_poll_interval      = excluded.spire_bundle_poll_interval,
+                                                         federated_bundles_poll_interval = excluded.federated_bundles_poll_interval,
+                                                         token_expires_at                = excluded.token_expires_at,
+                                                         updated_at                      = now()
+RETURNING *;
+
+-- name: UpdateHarvesterBundleUpload :one
+UPDATE harvesters
+SET last_bundle_upload_at     = $1,
+    last_bundle_digest        = $2,
+    last_bundle_upload_status = $3,
+    updated_at                = now()
+WHERE trust_domain_id = $4
+  AND instance_id = $5
+RETURNING *;
+
+-- name: FindHarvestersByTrustDomainID :many
+SELECT *
+FROM harvesters
+WHERE trust_domain_id = $1
+ORDER BY instance_id;
+
+-- name: ListHarvesters :many
+SELECT *
+FROM harvesters
+ORDER BY created_at;
@@ -1,6 +1,6 @@
 -- name: CreateJoinToken :one
-INSERT INTO join_tokens(token, expires_at, trust_domain_id)
-VALUES ($1, $2, $3)
+INSERT INTO join_tokens(token_prefix, token_salt, token_hash, expires_at, trust_domain_id, max_uses, source_cidr)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: UpdateJoinToken :one
@@ -20,10 +20,16 @@ SELECT *
 FROM join_tokens
 WHERE id = $1;
 
--- name: FindJoinToken :one
+-- name: FindJoinTokenByPrefix :one
 SELECT *
 FROM join_tokens
-WHERE token = $1;
+WHERE token_prefix = $1;
+
+-- name: FindLegacyJoinTokenByHash :one
+SELECT *
+FROM join_tokens
+WHERE token_salt = ''
+  AND token_hash = $1;
 
 -- name: FindJoinTokensByTrustDomainID :many
 SELECT *
@@ -34,3 +40,13 @@ WHERE trust_domain_id = $1;
 SELECT *
 FROM join_tokens
 ORDER BY created_at DESC;
+
+-- name: RecordJoinTokenUse :one
+UPDATE join_tokens
+SET use_count  = use_count + 1,
+    used       = use_count + 1 >= max_uses,
+    updated_at = now()
+WHERE id = $1
+  AND NOT used
+  AND use_count < max_uses
+RETURNING *;
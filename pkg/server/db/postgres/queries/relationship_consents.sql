@@ -0,0 +1,22 @@
+-- name: UpsertRelationshipConsent :one
+INSERT INTO relationship_consents(relationship_id, trust_domain_id, consent_status, statement, signature,
+                                  signing_certificate)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (relationship_id, trust_domain_id) DO UPDATE SET consent_status      = excluded.consent_status,
+                                                             statement           = excluded.statement,
+                                                             signature           = excluded.signature,
+                                                             signing_certificate = excluded.signing_certificate,
+                                                             updated_at          = now()
+RETURNING *;
+
+-- name: FindRelationshipConsentsByRelationshipID :many
+SELECT *
+FROM relationship_consents
+WHERE relationship_id = $1
+ORDER BY created_at;
+
+-- name: DeleteRelationshipConsent :exec
+DELETE
+FROM relationship_consents
+WHERE relationship_id = $1
+  AND trust_domain_id = $2;
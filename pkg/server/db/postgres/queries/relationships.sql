@@ -1,12 +1,14 @@
 -- name: CreateRelationship :one
-INSERT INTO relationships(trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO relationships(trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, direction,
+                          federation_group_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
 -- name: UpdateRelationship :one
 UPDATE relationships
 SET trust_domain_a_consent = $2,
     trust_domain_b_consent = $3,
+    federation_group_id    = $4,
     updated_at             = now()
 WHERE id = $1
 RETURNING *;
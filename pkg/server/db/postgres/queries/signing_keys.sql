@@ -0,0 +1,16 @@
+-- name: UpsertSigningKey :one
+INSERT INTO signing_keys(id, private_key)
+VALUES ($1, $2)
+ON CONFLICT (id) DO UPDATE SET private_key = excluded.private_key,
+                               updated_at  = now()
+RETURNING *;
+
+-- name: DeleteSigningKey :exec
+DELETE
+FROM signing_keys
+WHERE id = $1;
+
+-- name: ListSigningKeys :many
+SELECT *
+FROM signing_keys
+ORDER BY created_at;
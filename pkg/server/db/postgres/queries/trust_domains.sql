@@ -1,12 +1,14 @@
 -- name: CreateTrustDomain :one
-INSERT INTO trust_domains(name, description)
-VALUES ($1, $2)
+INSERT INTO trust_domains(name, description, harvester_spiffe_id, onboarding_bundle)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = $2,
-    updated_at  = now()
+SET description         = $2,
+    harvester_spiffe_id = $3,
+    onboarding_bundle   = $4,
+    updated_at          = now()
 WHERE id = $1
 RETURNING *;
 
@@ -0,0 +1,9 @@
+-- name: CreateWebhookDeadLetter :one
+INSERT INTO webhook_dead_letters(webhook, url, event_id, event_type, payload, attempts, last_error)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListWebhookDeadLetters :many
+SELECT *
+FROM webhook_dead_letters
+ORDER BY created_at DESC;
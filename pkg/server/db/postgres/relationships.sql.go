@@ -9,13 +9,15 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
 )
 
 const createRelationship = `-- name: CreateRelationship :one
-INSERT INTO relationships(trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+INSERT INTO relationships(trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, direction,
+                          federation_group_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 `
 
 type CreateRelationshipParams struct {
@@ -23,6 +25,8 @@ type CreateRelationshipParams struct {
 	TrustDomainBID      pgtype.UUID
 	TrustDomainAConsent ConsentStatus
 	TrustDomainBConsent ConsentStatus
+	Direction           string
+	FederationGroupID   uuid.NullUUID
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
@@ -33,6 +37,8 @@ func (q *Queries) CreateRelationship(ctx context.Context, arg CreateRelationship
 		arg.TrustDomainBID,
 		arg.TrustDomainAConsent,
 		arg.TrustDomainBConsent,
+		arg.Direction,
+		arg.FederationGroupID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -45,6 +51,8 @@ func (q *Queries) CreateRelationship(ctx context.Context, arg CreateRelationship
 		&i.TrustDomainBConsent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Direction,
+		&i.FederationGroupID,
 	)
 	return i, err
 }
@@ -61,7 +69,7 @@ func (q *Queries) DeleteRelationship(ctx context.Context, id pgtype.UUID) error
 }
 
 const findRelationshipByID = `-- name: FindRelationshipByID :one
-SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 FROM relationships
 WHERE id = $1
 `
@@ -77,12 +85,14 @@ func (q *Queries) FindRelationshipByID(ctx context.Context, id pgtype.UUID) (Rel
 		&i.TrustDomainBConsent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Direction,
+		&i.FederationGroupID,
 	)
 	return i, err
 }
 
 const findRelationshipsByTrustDomainID = `-- name: FindRelationshipsByTrustDomainID :many
-SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 FROM relationships
 WHERE trust_domain_a_id = $1
    OR trust_domain_b_id = $1
@@ -105,6 +115,8 @@ func (q *Queries) FindRelationshipsByTrustDomainID(ctx context.Context, trustDom
 			&i.TrustDomainBConsent,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Direction,
+			&i.FederationGroupID,
 		); err != nil {
 			return nil, err
 		}
@@ -123,19 +135,26 @@ const updateRelationship = `-- name: UpdateRelationship :one
 UPDATE relationships
 SET trust_domain_a_consent = $2,
     trust_domain_b_consent = $3,
+    federation_group_id    = $4,
     updated_at             = now()
 WHERE id = $1
-RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 `
 
 type UpdateRelationshipParams struct {
 	ID                  pgtype.UUID
 	TrustDomainAConsent ConsentStatus
 	TrustDomainBConsent ConsentStatus
+	FederationGroupID   uuid.NullUUID
 }
 
 func (q *Queries) UpdateRelationship(ctx context.Context, arg UpdateRelationshipParams) (Relationship, error) {
-	row := q.queryRow(ctx, q.updateRelationshipStmt, updateRelationship, arg.ID, arg.TrustDomainAConsent, arg.TrustDomainBConsent)
+	row := q.queryRow(ctx, q.updateRelationshipStmt, updateRelationship,
+		arg.ID,
+		arg.TrustDomainAConsent,
+		arg.TrustDomainBConsent,
+		arg.FederationGroupID,
+	)
 	var i Relationship
 	err := row.Scan(
 		&i.ID,
@@ -145,6 +164,8 @@ func (q *Queries) UpdateRelationship(ctx context.Context, arg UpdateRelationship
 		&i.TrustDomainBConsent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Direction,
+		&i.FederationGroupID,
 	)
 	return i, err
 }
@@ -16,7 +16,7 @@ var fs embed.FS
 // This is used to ensure that the app is compatible with the database schema.
 // When a new migration is created, this version should be updated in order to force
 // the migrations to run when starting up the app.
-const currentDBVersion = 1
+const currentDBVersion = 16
 
 const scheme = "postgresql"
 
@@ -13,18 +13,25 @@ import (
 )
 
 const createTrustDomain = `-- name: CreateTrustDomain :one
-INSERT INTO trust_domains(name, description)
-VALUES ($1, $2)
-RETURNING id, name, description, created_at, updated_at
+INSERT INTO trust_domains(name, description, harvester_spiffe_id, onboarding_bundle)
+VALUES ($1, $2, $3, $4)
+RETURNING id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 `
 
 type CreateTrustDomainParams struct {
-	Name        string
-	Description sql.NullString
+	Name              string
+	Description       sql.NullString
+	HarvesterSpiffeID string
+	OnboardingBundle  []byte
 }
 
 func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainParams) (TrustDomain, error) {
-	row := q.queryRow(ctx, q.createTrustDomainStmt, createTrustDomain, arg.Name, arg.Description)
+	row := q.queryRow(ctx, q.createTrustDomainStmt, createTrustDomain,
+		arg.Name,
+		arg.Description,
+		arg.HarvesterSpiffeID,
+		arg.OnboardingBundle,
+	)
 	var i TrustDomain
 	err := row.Scan(
 		&i.ID,
@@ -32,6 +39,8 @@ func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
@@ -48,7 +57,7 @@ func (q *Queries) DeleteTrustDomain(ctx context.Context, id pgtype.UUID) error {
 }
 
 const findTrustDomainByID = `-- name: FindTrustDomainByID :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 FROM trust_domains
 WHERE id = $1
 `
@@ -62,12 +71,14 @@ func (q *Queries) FindTrustDomainByID(ctx context.Context, id pgtype.UUID) (Trus
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
 
 const findTrustDomainByName = `-- name: FindTrustDomainByName :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 FROM trust_domains
 WHERE name = $1
 `
@@ -81,25 +92,36 @@ func (q *Queries) FindTrustDomainByName(ctx context.Context, name string) (Trust
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
 
 const updateTrustDomain = `-- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = $2,
-    updated_at  = now()
+SET description         = $2,
+    harvester_spiffe_id = $3,
+    onboarding_bundle   = $4,
+    updated_at          = now()
 WHERE id = $1
-RETURNING id, name, description, created_at, updated_at
+RETURNING id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 `
 
 type UpdateTrustDomainParams struct {
-	ID          pgtype.UUID
-	Description sql.NullString
+	ID                pgtype.UUID
+	Description       sql.NullString
+	HarvesterSpiffeID string
+	OnboardingBundle  []byte
 }
 
 func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainParams) (TrustDomain, error) {
-	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain, arg.ID, arg.Description)
+	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain,
+		arg.ID,
+		arg.Description,
+		arg.HarvesterSpiffeID,
+		arg.OnboardingBundle,
+	)
 	var i TrustDomain
 	err := row.Scan(
 		&i.ID,
@@ -107,6 +129,8 @@ func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
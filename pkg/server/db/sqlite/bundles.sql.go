@@ -10,9 +10,10 @@ import (
 )
 
 const createBundle = `-- name: CreateBundle :one
-INSERT INTO bundles(id, data, digest, signature, signing_certificate, trust_domain_id)
-VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+INSERT INTO bundles(id, data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 `
 
 type CreateBundleParams struct {
@@ -22,6 +23,9 @@ type CreateBundleParams struct {
 	Signature          []byte
 	SigningCertificate []byte
 	TrustDomainID      string
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
 }
 
 func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bundle, error) {
@@ -32,6 +36,9 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		arg.Signature,
 		arg.SigningCertificate,
 		arg.TrustDomainID,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
 	)
 	var i Bundle
 	err := row.Scan(
@@ -43,6 +50,58 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
+	)
+	return i, err
+}
+
+const createBundleIfNotExists = `-- name: CreateBundleIfNotExists :one
+INSERT INTO bundles(id, data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (trust_domain_id) DO NOTHING
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
+`
+
+type CreateBundleIfNotExistsParams struct {
+	ID                 string
+	Data               []byte
+	Digest             []byte
+	Signature          []byte
+	SigningCertificate []byte
+	TrustDomainID      string
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
+}
+
+func (q *Queries) CreateBundleIfNotExists(ctx context.Context, arg CreateBundleIfNotExistsParams) (Bundle, error) {
+	row := q.queryRow(ctx, q.createBundleIfNotExistsStmt, createBundleIfNotExists,
+		arg.ID,
+		arg.Data,
+		arg.Digest,
+		arg.Signature,
+		arg.SigningCertificate,
+		arg.TrustDomainID,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
+	)
+	var i Bundle
+	err := row.Scan(
+		&i.ID,
+		&i.TrustDomainID,
+		&i.Data,
+		&i.Digest,
+		&i.Signature,
+		&i.SigningCertificate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
@@ -59,7 +118,7 @@ func (q *Queries) DeleteBundle(ctx context.Context, id string) error {
 }
 
 const findBundleByID = `-- name: FindBundleByID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 FROM bundles
 WHERE id = ?
 `
@@ -76,12 +135,15 @@ func (q *Queries) FindBundleByID(ctx context.Context, id string) (Bundle, error)
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
 
 const findBundleByTrustDomainID = `-- name: FindBundleByTrustDomainID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 FROM bundles
 WHERE trust_domain_id = ?
 LIMIT 1
@@ -99,12 +161,15 @@ func (q *Queries) FindBundleByTrustDomainID(ctx context.Context, trustDomainID s
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
 
 const listBundles = `-- name: ListBundles :many
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 FROM bundles
 ORDER BY created_at DESC
 `
@@ -127,6 +192,9 @@ func (q *Queries) ListBundles(ctx context.Context) ([]Bundle, error) {
 			&i.SigningCertificate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VerificationStatus,
+			&i.VerifiedBy,
+			&i.AdminManaged,
 		); err != nil {
 			return nil, err
 		}
@@ -147,9 +215,12 @@ SET data                = ?,
     digest              = ?,
     signature           = ?,
     signing_certificate = ?,
+    verification_status = ?,
+    verified_by         = ?,
+    admin_managed       = ?,
     updated_at          = datetime('now')
 WHERE id = ?
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
 `
 
 type UpdateBundleParams struct {
@@ -157,6 +228,9 @@ type UpdateBundleParams struct {
 	Digest             []byte
 	Signature          []byte
 	SigningCertificate []byte
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
 	ID                 string
 }
 
@@ -166,7 +240,66 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		arg.Digest,
 		arg.Signature,
 		arg.SigningCertificate,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
+		arg.ID,
+	)
+	var i Bundle
+	err := row.Scan(
+		&i.ID,
+		&i.TrustDomainID,
+		&i.Data,
+		&i.Digest,
+		&i.Signature,
+		&i.SigningCertificate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
+	)
+	return i, err
+}
+
+const updateBundleIfDigest = `-- name: UpdateBundleIfDigest :one
+UPDATE bundles
+SET data                = ?,
+    digest              = ?,
+    signature           = ?,
+    signing_certificate = ?,
+    verification_status = ?,
+    verified_by         = ?,
+    admin_managed       = ?,
+    updated_at          = datetime('now')
+WHERE id = ?
+  AND digest = ?
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate, created_at, updated_at, verification_status, verified_by, admin_managed
+`
+
+type UpdateBundleIfDigestParams struct {
+	Data               []byte
+	Digest             []byte
+	Signature          []byte
+	SigningCertificate []byte
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
+	ID                 string
+	PreviousDigest     []byte
+}
+
+func (q *Queries) UpdateBundleIfDigest(ctx context.Context, arg UpdateBundleIfDigestParams) (Bundle, error) {
+	row := q.queryRow(ctx, q.updateBundleIfDigestStmt, updateBundleIfDigest,
+		arg.Data,
+		arg.Digest,
+		arg.Signature,
+		arg.SigningCertificate,
+		arg.VerificationStatus,
+		arg.VerifiedBy,
+		arg.AdminManaged,
 		arg.ID,
+		arg.PreviousDigest,
 	)
 	var i Bundle
 	err := row.Scan(
@@ -178,6 +311,9 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		&i.SigningCertificate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerificationStatus,
+		&i.VerifiedBy,
+		&i.AdminManaged,
 	)
 	return i, err
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
 	"github.com/google/uuid"
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
@@ -27,7 +26,7 @@ type Datastore struct {
 // parsing the connString.
 // The connString should be a file path to the SQLite database file.
 func NewDatastore(connString string) (*Datastore, error) {
-	openDB, err := sql.Open("sqlite3", connString)
+	openDB, err := sql.Open(driverName, connString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
 	}
@@ -97,7 +96,7 @@ func (d *Datastore) ListTrustDomains(ctx context.Context, criteria *criteria.Lis
 	var domains []TrustDomain
 	for rows.Next() {
 		var t TrustDomain
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.CreatedAt, &t.UpdatedAt, &t.HarvesterSpiffeID, &t.OnboardingBundle); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		domains = append(domains, t)
@@ -160,6 +159,49 @@ func (d *Datastore) CreateOrUpdateBundle(ctx context.Context, req *entity.Bundle
 	return response, nil
 }
 
+func (d *Datastore) CreateOrUpdateBundleIfUnchanged(ctx context.Context, req *entity.Bundle, previousDigest []byte) (*entity.Bundle, error) {
+	var bundle Bundle
+	var err error
+	if req.ID.Valid {
+		bundle, err = d.querier.UpdateBundleIfDigest(ctx, UpdateBundleIfDigestParams{
+			ID:                 req.ID.UUID.String(),
+			Data:               req.Data,
+			Digest:             req.Digest,
+			Signature:          req.Signature,
+			SigningCertificate: req.SigningCertificate,
+			VerificationStatus: string(req.VerificationStatus),
+			VerifiedBy:         req.VerifiedBy,
+			AdminManaged:       req.AdminManaged,
+			PreviousDigest:     previousDigest,
+		})
+	} else {
+		bundle, err = d.querier.CreateBundleIfNotExists(ctx, CreateBundleIfNotExistsParams{
+			ID:                 uuid.New().String(),
+			Data:               req.Data,
+			Digest:             req.Digest,
+			Signature:          req.Signature,
+			SigningCertificate: req.SigningCertificate,
+			VerificationStatus: string(req.VerificationStatus),
+			VerifiedBy:         req.VerifiedBy,
+			AdminManaged:       req.AdminManaged,
+			TrustDomainID:      req.TrustDomainID.String(),
+		})
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed storing bundle: %w", err)
+	}
+
+	response, err := bundle.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model bundle to entity: %w", err)
+	}
+
+	return response, nil
+}
+
 func (d *Datastore) FindBundleByID(ctx context.Context, bundleID uuid.UUID) (*entity.Bundle, error) {
 	bundle, err := d.querier.FindBundleByID(ctx, bundleID.String())
 	switch {
@@ -224,9 +266,13 @@ func (d *Datastore) CreateJoinToken(ctx context.Context, req *entity.JoinToken)
 	id := uuid.New()
 	params := CreateJoinTokenParams{
 		ID:            id.String(),
-		Token:         req.Token,
+		TokenPrefix:   req.TokenPrefix,
+		TokenSalt:     req.TokenSalt,
+		TokenHash:     req.TokenHash,
 		ExpiresAt:     req.ExpiresAt,
 		TrustDomainID: req.TrustDomainID.String(),
+		MaxUses:       int64(maxUsesOrDefault(req.MaxUses)),
+		SourceCidr:    req.SourceCIDR,
 	}
 	joinToken, err := d.querier.CreateJoinToken(ctx, params)
 	if err != nil {
@@ -316,6 +362,23 @@ func (d *Datastore) UpdateJoinToken(ctx context.Context, joinTokenID uuid.UUID,
 	return ent, nil
 }
 
+func (d *Datastore) RecordJoinTokenUse(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	jt, err := d.querier.RecordJoinTokenUse(ctx, joinTokenID.String())
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed recording use of join token with ID=%q, %w", joinTokenID, err)
+	}
+
+	ent, err := jt.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model join token to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
 func (d *Datastore) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error {
 	if err := d.querier.DeleteJoinToken(ctx, joinTokenID.String()); err != nil {
 		return fmt.Errorf("failed deleting join token with ID=%q, %w", joinTokenID, err)
@@ -324,8 +387,8 @@ func (d *Datastore) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID)
 	return nil
 }
 
-func (d *Datastore) FindJoinToken(ctx context.Context, token string) (*entity.JoinToken, error) {
-	joinToken, err := d.querier.FindJoinToken(ctx, token)
+func (d *Datastore) FindJoinTokenByPrefix(ctx context.Context, prefix string) (*entity.JoinToken, error) {
+	joinToken, err := d.querier.FindJoinTokenByPrefix(ctx, prefix)
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return nil, nil
@@ -341,6 +404,23 @@ func (d *Datastore) FindJoinToken(ctx context.Context, token string) (*entity.Jo
 	return ent, nil
 }
 
+func (d *Datastore) FindLegacyJoinTokenByHash(ctx context.Context, hash []byte) (*entity.JoinToken, error) {
+	joinToken, err := d.querier.FindLegacyJoinTokenByHash(ctx, hash)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up legacy join token: %w", err)
+	}
+
+	ent, err := joinToken.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model join token to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
 func (d *Datastore) CreateOrUpdateRelationship(ctx context.Context, req *entity.Relationship) (*entity.Relationship, error) {
 	var relationship *Relationship
 	var err error
@@ -417,7 +497,7 @@ func (d *Datastore) ListRelationships(ctx context.Context, criteria *criteria.Li
 	var relationships []Relationship
 	for rows.Next() {
 		var m Relationship
-		if err := rows.Scan(&m.ID, &m.TrustDomainAID, &m.TrustDomainBID, &m.TrustDomainAConsent, &m.TrustDomainBConsent, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		if err := rows.Scan(&m.ID, &m.TrustDomainAID, &m.TrustDomainBID, &m.TrustDomainAConsent, &m.TrustDomainBConsent, &m.CreatedAt, &m.UpdatedAt, &m.Direction, &m.FederationGroupID); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		relationships = append(relationships, m)
@@ -438,11 +518,256 @@ func (d *Datastore) DeleteRelationship(ctx context.Context, relationshipID uuid.
 	return nil
 }
 
+func (d *Datastore) CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error) {
+	params := UpsertRelationshipConsentParams{
+		ID:                 uuid.New().String(),
+		RelationshipID:     req.RelationshipID.String(),
+		TrustDomainID:      req.TrustDomainID.String(),
+		ConsentStatus:      string(req.ConsentStatus),
+		Statement:          req.Statement,
+		Signature:          req.Signature,
+		SigningCertificate: req.SigningCertificate,
+	}
+	consent, err := d.querier.UpsertRelationshipConsent(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing relationship consent: %w", err)
+	}
+
+	ent, err := consent.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting relationship consent model to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	consents, err := d.querier.FindRelationshipConsentsByRelationshipID(ctx, relationshipID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up relationship consents for relationship ID=%q: %w", relationshipID, err)
+	}
+
+	result := make([]*entity.RelationshipConsent, len(consents))
+	for i, c := range consents {
+		ent, err := c.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting relationship consent model to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error {
+	params := DeleteRelationshipConsentParams{
+		RelationshipID: relationshipID.String(),
+		TrustDomainID:  trustDomainID.String(),
+	}
+	if err := d.querier.DeleteRelationshipConsent(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting relationship consent for relationship ID=%q: %w", relationshipID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) CreateWebhookDeadLetter(ctx context.Context, req *entity.WebhookDeadLetter) (*entity.WebhookDeadLetter, error) {
+	params := CreateWebhookDeadLetterParams{
+		ID:        uuid.New().String(),
+		Webhook:   req.Webhook,
+		Url:       req.URL,
+		EventID:   req.EventID.String(),
+		EventType: req.EventType,
+		Payload:   req.Payload,
+		Attempts:  int64(req.Attempts),
+		LastError: req.LastError,
+	}
+	deadLetter, err := d.querier.CreateWebhookDeadLetter(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating webhook dead letter: %w", err)
+	}
+
+	ent, err := deadLetter.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model webhook dead letter to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) ListWebhookDeadLetters(ctx context.Context) ([]*entity.WebhookDeadLetter, error) {
+	deadLetters, err := d.querier.ListWebhookDeadLetters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up webhook dead letters: %w", err)
+	}
+
+	result := make([]*entity.WebhookDeadLetter, len(deadLetters))
+	for i, dl := range deadLetters {
+		ent, err := dl.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting model webhook dead letter to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) CreateOrUpdateBundleSyncState(ctx context.Context, req *entity.BundleSyncState) (*entity.BundleSyncState, error) {
+	params := UpsertBundleSyncStateParams{
+		ID:                   uuid.New().String(),
+		TrustDomainID:        req.TrustDomainID.String(),
+		HarvesterInstanceID:  req.HarvesterInstanceID,
+		FederatedTrustDomain: req.FederatedTrustDomain.String(),
+		Digest:               req.Digest,
+		DigestUpdatedAt:      req.DigestUpdatedAt,
+		ReportedAt:           req.ReportedAt,
+	}
+	state, err := d.querier.UpsertBundleSyncState(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing bundle sync state: %w", err)
+	}
+
+	ent, err := state.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model bundle sync state to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) FindBundleSyncStatesByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleSyncState, error) {
+	states, err := d.querier.FindBundleSyncStatesByTrustDomainID(ctx, trustDomainID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up bundle sync states for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return bundleSyncStatesToEntity(states)
+}
+
+func (d *Datastore) ListBundleSyncStates(ctx context.Context) ([]*entity.BundleSyncState, error) {
+	states, err := d.querier.ListBundleSyncStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up bundle sync states: %w", err)
+	}
+
+	return bundleSyncStatesToEntity(states)
+}
+
+func (d *Datastore) DeleteStaleBundleSyncStates(ctx context.Context, trustDomainID uuid.UUID, instanceID string, reportedBefore time.Time) error {
+	params := DeleteStaleBundleSyncStatesParams{
+		TrustDomainID:       trustDomainID.String(),
+		HarvesterInstanceID: instanceID,
+		ReportedAt:          reportedBefore,
+	}
+	if err := d.querier.DeleteStaleBundleSyncStates(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting stale bundle sync states for trust domain ID=%q and harvester instance %q: %w", trustDomainID, instanceID, err)
+	}
+
+	return nil
+}
+
+func bundleSyncStatesToEntity(states []BundleSyncState) ([]*entity.BundleSyncState, error) {
+	result := make([]*entity.BundleSyncState, len(states))
+	for i, s := range states {
+		ent, err := s.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting model bundle sync state to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) CreateOrUpdateHarvester(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	params := UpsertHarvesterParams{
+		ID:                           uuid.New().String(),
+		TrustDomainID:                req.TrustDomainID.String(),
+		InstanceID:                   req.InstanceID,
+		LastSeenAt:                   req.LastSeenAt,
+		SourceAddress:                req.SourceAddress,
+		Version:                      req.Version,
+		SpireBundlePollInterval:      int64(req.SpireBundlePollInterval.Seconds()),
+		FederatedBundlesPollInterval: int64(req.FederatedBundlesPollInterval.Seconds()),
+		TokenExpiresAt:               req.TokenExpiresAt,
+	}
+	harvester, err := d.querier.UpsertHarvester(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing harvester: %w", err)
+	}
+
+	ent, err := harvester.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model harvester to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) UpdateHarvesterBundleUpload(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	params := UpdateHarvesterBundleUploadParams{
+		LastBundleUploadAt: sql.NullTime{
+			Time:  req.LastBundleUploadAt,
+			Valid: !req.LastBundleUploadAt.IsZero(),
+		},
+		LastBundleDigest:       req.LastBundleDigest,
+		LastBundleUploadStatus: string(req.LastBundleUploadStatus),
+		TrustDomainID:          req.TrustDomainID.String(),
+		InstanceID:             req.InstanceID,
+	}
+
+	harvester, err := d.querier.UpdateHarvesterBundleUpload(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed updating bundle upload of harvester instance %q of trust domain ID=%q: %w", req.InstanceID, req.TrustDomainID, err)
+	}
+
+	ent, err := harvester.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model harvester to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) FindHarvestersByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Harvester, error) {
+	harvesters, err := d.querier.FindHarvestersByTrustDomainID(ctx, trustDomainID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up harvesters for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return harvestersToEntity(harvesters)
+}
+
+func (d *Datastore) ListHarvesters(ctx context.Context) ([]*entity.Harvester, error) {
+	harvesters, err := d.querier.ListHarvesters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up harvesters: %w", err)
+	}
+
+	return harvestersToEntity(harvesters)
+}
+
+func harvestersToEntity(harvesters []Harvester) ([]*entity.Harvester, error) {
+	result := make([]*entity.Harvester, len(harvesters))
+	for i, h := range harvesters {
+		ent, err := h.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting model harvester to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
 func (d *Datastore) createTrustDomain(ctx context.Context, req *entity.TrustDomain) (*TrustDomain, error) {
 	id := uuid.New()
 	params := CreateTrustDomainParams{
-		ID:   id.String(),
-		Name: req.Name.String(),
+		ID:                id.String(),
+		Name:              req.Name.String(),
+		HarvesterSpiffeID: harvesterSPIFFEIDToString(req.HarvesterSPIFFEID),
+		OnboardingBundle:  onboardingBundleOrEmpty(req.OnboardingBundle),
 	}
 	if req.Description != "" {
 		params.Description = sql.NullString{
@@ -465,7 +790,9 @@ func (d *Datastore) createTrustDomain(ctx context.Context, req *entity.TrustDoma
 
 func (d *Datastore) updateTrustDomain(ctx context.Context, req *entity.TrustDomain) (*TrustDomain, error) {
 	params := UpdateTrustDomainParams{
-		ID: req.ID.UUID.String(),
+		ID:                req.ID.UUID.String(),
+		HarvesterSpiffeID: harvesterSPIFFEIDToString(req.HarvesterSPIFFEID),
+		OnboardingBundle:  onboardingBundleOrEmpty(req.OnboardingBundle),
 	}
 
 	if req.Description != "" {
@@ -497,12 +824,19 @@ func (d *Datastore) createRelationship(ctx context.Context, req *entity.Relation
 	if req.UpdatedAt.IsZero() {
 		req.UpdatedAt = time.Now()
 	}
+	direction := req.Direction
+	if direction == "" {
+		direction = entity.RelationshipBidirectional
+	}
+
 	params := CreateRelationshipParams{
 		ID:                  id.String(),
 		TrustDomainAID:      req.TrustDomainAID.String(),
 		TrustDomainBID:      req.TrustDomainBID.String(),
 		TrustDomainAConsent: string(req.TrustDomainAConsent),
 		TrustDomainBConsent: string(req.TrustDomainBConsent),
+		Direction:           string(direction),
+		FederationGroupID:   uuidToNullString(req.FederationGroupID),
 		CreatedAt:           req.CreatedAt,
 		UpdatedAt:           req.UpdatedAt,
 	}
@@ -520,6 +854,7 @@ func (d *Datastore) updateRelationship(ctx context.Context, req *entity.Relation
 		ID:                  req.ID.UUID.String(),
 		TrustDomainAConsent: string(req.TrustDomainAConsent),
 		TrustDomainBConsent: string(req.TrustDomainBConsent),
+		FederationGroupID:   uuidToNullString(req.FederationGroupID),
 	}
 
 	relationship, err := d.querier.UpdateRelationship(ctx, params)
@@ -538,6 +873,9 @@ func (d *Datastore) createBundle(ctx context.Context, req *entity.Bundle) (*Bund
 		Digest:             req.Digest,
 		Signature:          req.Signature,
 		SigningCertificate: req.SigningCertificate,
+		VerificationStatus: string(req.VerificationStatus),
+		VerifiedBy:         req.VerifiedBy,
+		AdminManaged:       req.AdminManaged,
 		TrustDomainID:      req.TrustDomainID.String(),
 	}
 
@@ -556,6 +894,9 @@ func (d *Datastore) updateBundle(ctx context.Context, req *entity.Bundle) (*Bund
 		Digest:             req.Digest,
 		Signature:          req.Signature,
 		SigningCertificate: req.SigningCertificate,
+		VerificationStatus: string(req.VerificationStatus),
+		VerifiedBy:         req.VerifiedBy,
+		AdminManaged:       req.AdminManaged,
 	}
 
 	bundle, err := d.querier.UpdateBundle(ctx, params)
@@ -593,3 +934,268 @@ func trustDomainToEntity(models []TrustDomain) ([]*entity.TrustDomain, error) {
 
 	return result, nil
 }
+
+func (d *Datastore) CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error) {
+	params := UpsertSigningKeyParams{
+		ID:         req.ID,
+		PrivateKey: req.PrivateKey,
+	}
+	signingKey, err := d.querier.UpsertSigningKey(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing signing key %q: %w", req.ID, err)
+	}
+
+	return signingKey.ToEntity(), nil
+}
+
+func (d *Datastore) DeleteSigningKey(ctx context.Context, id string) error {
+	if err := d.querier.DeleteSigningKey(ctx, id); err != nil {
+		return fmt.Errorf("failed deleting signing key with ID=%q: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	signingKeys, err := d.querier.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up signing keys: %w", err)
+	}
+
+	result := make([]*entity.SigningKey, len(signingKeys))
+	for i, k := range signingKeys {
+		result[i] = k.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) CreateOrUpdateExternalTrustDomain(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	params := UpsertExternalTrustDomainParams{
+		ID:                    uuid.New().String(),
+		TrustDomainID:         req.TrustDomainID.String(),
+		BundleEndpointUrl:     req.BundleEndpointURL,
+		BundleEndpointProfile: req.BundleEndpointProfile,
+		EndpointSpiffeID:      req.EndpointSPIFFEID,
+		BootstrapBundle:       req.BootstrapBundle,
+	}
+	externalTD, err := d.querier.UpsertExternalTrustDomain(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing external trust domain: %w", err)
+	}
+
+	ent, err := externalTD.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model external trust domain to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) UpdateExternalTrustDomainRefresh(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	params := UpdateExternalTrustDomainRefreshParams{
+		LastRefreshAt: sql.NullTime{
+			Time:  req.LastRefreshAt,
+			Valid: !req.LastRefreshAt.IsZero(),
+		},
+		LastRefreshError: req.LastRefreshError,
+		TrustDomainID:    req.TrustDomainID.String(),
+	}
+	externalTD, err := d.querier.UpdateExternalTrustDomainRefresh(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed updating refresh of external trust domain ID=%q: %w", req.TrustDomainID, err)
+	}
+
+	ent, err := externalTD.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model external trust domain to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) FindExternalTrustDomainByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.ExternalTrustDomain, error) {
+	externalTD, err := d.querier.FindExternalTrustDomainByTrustDomainID(ctx, trustDomainID.String())
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up external trust domain for ID=%q: %w", trustDomainID, err)
+	}
+
+	ent, err := externalTD.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model external trust domain to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) ListExternalTrustDomains(ctx context.Context) ([]*entity.ExternalTrustDomain, error) {
+	externalTDs, err := d.querier.ListExternalTrustDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up external trust domains: %w", err)
+	}
+
+	result := make([]*entity.ExternalTrustDomain, len(externalTDs))
+	for i, e := range externalTDs {
+		ent, err := e.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting model external trust domain to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteExternalTrustDomain(ctx context.Context, trustDomainID uuid.UUID) error {
+	if err := d.querier.DeleteExternalTrustDomain(ctx, trustDomainID.String()); err != nil {
+		return fmt.Errorf("failed deleting external trust domain for ID=%q: %w", trustDomainID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) CreateOrUpdateFederationGroup(ctx context.Context, req *entity.FederationGroup) (*entity.FederationGroup, error) {
+	defaultConsent := req.DefaultConsent
+	if defaultConsent == "" {
+		defaultConsent = entity.ConsentStatusPending
+	}
+
+	var group FederationGroup
+	var err error
+	if req.ID.Valid {
+		group, err = d.querier.UpdateFederationGroup(ctx, UpdateFederationGroupParams{
+			ID:             req.ID.UUID.String(),
+			Description:    req.Description,
+			DefaultConsent: string(defaultConsent),
+		})
+	} else {
+		group, err = d.querier.CreateFederationGroup(ctx, CreateFederationGroupParams{
+			ID:             uuid.New().String(),
+			Name:           req.Name,
+			Description:    req.Description,
+			DefaultConsent: string(defaultConsent),
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed storing federation group: %w", err)
+	}
+
+	ent, err := group.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model federation group to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) FindFederationGroupByName(ctx context.Context, name string) (*entity.FederationGroup, error) {
+	group, err := d.querier.FindFederationGroupByName(ctx, name)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up federation group %q: %w", name, err)
+	}
+
+	ent, err := group.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model federation group to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) ListFederationGroups(ctx context.Context) ([]*entity.FederationGroup, error) {
+	groups, err := d.querier.ListFederationGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up federation groups: %w", err)
+	}
+
+	result := make([]*entity.FederationGroup, len(groups))
+	for i, g := range groups {
+		ent, err := g.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting model federation group to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteFederationGroup(ctx context.Context, groupID uuid.UUID) error {
+	if err := d.querier.DeleteFederationGroup(ctx, groupID.String()); err != nil {
+		return fmt.Errorf("failed deleting federation group ID=%q: %w", groupID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) CreateFederationGroupMember(ctx context.Context, req *entity.FederationGroupMember) (*entity.FederationGroupMember, error) {
+	params := CreateFederationGroupMemberParams{
+		ID:                uuid.New().String(),
+		FederationGroupID: req.FederationGroupID.String(),
+		TrustDomainID:     req.TrustDomainID.String(),
+	}
+	member, err := d.querier.CreateFederationGroupMember(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing federation group member: %w", err)
+	}
+
+	ent, err := member.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting model federation group member to entity: %w", err)
+	}
+
+	return ent, nil
+}
+
+func (d *Datastore) FindFederationGroupMembersByGroupID(ctx context.Context, groupID uuid.UUID) ([]*entity.FederationGroupMember, error) {
+	members, err := d.querier.FindFederationGroupMembersByGroupID(ctx, groupID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up members of federation group ID=%q: %w", groupID, err)
+	}
+
+	result := make([]*entity.FederationGroupMember, len(members))
+	for i, m := range members {
+		ent, err := m.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting model federation group member to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteFederationGroupMember(ctx context.Context, groupID, trustDomainID uuid.UUID) error {
+	params := DeleteFederationGroupMemberParams{
+		FederationGroupID: groupID.String(),
+		TrustDomainID:     trustDomainID.String(),
+	}
+	if err := d.querier.DeleteFederationGroupMember(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting member ID=%q of federation group ID=%q: %w", trustDomainID, groupID, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) RecordDPoPProof(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	// sqlite compares the timestamps as text, which requires them to be in the same time zone
+	if err := d.querier.DeleteExpiredDPoPProofs(ctx, time.Now().UTC()); err != nil {
+		return false, fmt.Errorf("failed deleting expired DPoP proofs: %w", err)
+	}
+
+	recorded, err := d.querier.InsertDPoPProof(ctx, InsertDPoPProofParams{
+		ID:        id,
+		ExpiresAt: expiresAt.UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed recording DPoP proof with ID=%q: %w", id, err)
+	}
+
+	return recorded == 1, nil
+}
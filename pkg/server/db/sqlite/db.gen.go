@@ -27,6 +27,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createBundleStmt, err = db.PrepareContext(ctx, createBundle); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateBundle: %w", err)
 	}
+	if q.createBundleIfNotExistsStmt, err = db.PrepareContext(ctx, createBundleIfNotExists); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBundleIfNotExists: %w", err)
+	}
+	if q.createFederationGroupStmt, err = db.PrepareContext(ctx, createFederationGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFederationGroup: %w", err)
+	}
+	if q.createFederationGroupMemberStmt, err = db.PrepareContext(ctx, createFederationGroupMember); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFederationGroupMember: %w", err)
+	}
 	if q.createJoinTokenStmt, err = db.PrepareContext(ctx, createJoinToken); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateJoinToken: %w", err)
 	}
@@ -36,15 +45,39 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createTrustDomainStmt, err = db.PrepareContext(ctx, createTrustDomain); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTrustDomain: %w", err)
 	}
+	if q.createWebhookDeadLetterStmt, err = db.PrepareContext(ctx, createWebhookDeadLetter); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDeadLetter: %w", err)
+	}
 	if q.deleteBundleStmt, err = db.PrepareContext(ctx, deleteBundle); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteBundle: %w", err)
 	}
+	if q.deleteExpiredDPoPProofsStmt, err = db.PrepareContext(ctx, deleteExpiredDPoPProofs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredDPoPProofs: %w", err)
+	}
+	if q.deleteExternalTrustDomainStmt, err = db.PrepareContext(ctx, deleteExternalTrustDomain); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExternalTrustDomain: %w", err)
+	}
+	if q.deleteFederationGroupStmt, err = db.PrepareContext(ctx, deleteFederationGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFederationGroup: %w", err)
+	}
+	if q.deleteFederationGroupMemberStmt, err = db.PrepareContext(ctx, deleteFederationGroupMember); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFederationGroupMember: %w", err)
+	}
 	if q.deleteJoinTokenStmt, err = db.PrepareContext(ctx, deleteJoinToken); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteJoinToken: %w", err)
 	}
 	if q.deleteRelationshipStmt, err = db.PrepareContext(ctx, deleteRelationship); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteRelationship: %w", err)
 	}
+	if q.deleteRelationshipConsentStmt, err = db.PrepareContext(ctx, deleteRelationshipConsent); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRelationshipConsent: %w", err)
+	}
+	if q.deleteSigningKeyStmt, err = db.PrepareContext(ctx, deleteSigningKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSigningKey: %w", err)
+	}
+	if q.deleteStaleBundleSyncStatesStmt, err = db.PrepareContext(ctx, deleteStaleBundleSyncStates); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStaleBundleSyncStates: %w", err)
+	}
 	if q.deleteTrustDomainStmt, err = db.PrepareContext(ctx, deleteTrustDomain); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteTrustDomain: %w", err)
 	}
@@ -54,18 +87,39 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.findBundleByTrustDomainIDStmt, err = db.PrepareContext(ctx, findBundleByTrustDomainID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindBundleByTrustDomainID: %w", err)
 	}
-	if q.findJoinTokenStmt, err = db.PrepareContext(ctx, findJoinToken); err != nil {
-		return nil, fmt.Errorf("error preparing query FindJoinToken: %w", err)
+	if q.findBundleSyncStatesByTrustDomainIDStmt, err = db.PrepareContext(ctx, findBundleSyncStatesByTrustDomainID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindBundleSyncStatesByTrustDomainID: %w", err)
+	}
+	if q.findExternalTrustDomainByTrustDomainIDStmt, err = db.PrepareContext(ctx, findExternalTrustDomainByTrustDomainID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindExternalTrustDomainByTrustDomainID: %w", err)
+	}
+	if q.findFederationGroupByNameStmt, err = db.PrepareContext(ctx, findFederationGroupByName); err != nil {
+		return nil, fmt.Errorf("error preparing query FindFederationGroupByName: %w", err)
+	}
+	if q.findFederationGroupMembersByGroupIDStmt, err = db.PrepareContext(ctx, findFederationGroupMembersByGroupID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindFederationGroupMembersByGroupID: %w", err)
+	}
+	if q.findHarvestersByTrustDomainIDStmt, err = db.PrepareContext(ctx, findHarvestersByTrustDomainID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindHarvestersByTrustDomainID: %w", err)
 	}
 	if q.findJoinTokenByIDStmt, err = db.PrepareContext(ctx, findJoinTokenByID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindJoinTokenByID: %w", err)
 	}
+	if q.findJoinTokenByPrefixStmt, err = db.PrepareContext(ctx, findJoinTokenByPrefix); err != nil {
+		return nil, fmt.Errorf("error preparing query FindJoinTokenByPrefix: %w", err)
+	}
 	if q.findJoinTokensByTrustDomainIDStmt, err = db.PrepareContext(ctx, findJoinTokensByTrustDomainID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindJoinTokensByTrustDomainID: %w", err)
 	}
+	if q.findLegacyJoinTokenByHashStmt, err = db.PrepareContext(ctx, findLegacyJoinTokenByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query FindLegacyJoinTokenByHash: %w", err)
+	}
 	if q.findRelationshipByIDStmt, err = db.PrepareContext(ctx, findRelationshipByID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindRelationshipByID: %w", err)
 	}
+	if q.findRelationshipConsentsByRelationshipIDStmt, err = db.PrepareContext(ctx, findRelationshipConsentsByRelationshipID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindRelationshipConsentsByRelationshipID: %w", err)
+	}
 	if q.findRelationshipsByTrustDomainIDStmt, err = db.PrepareContext(ctx, findRelationshipsByTrustDomainID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindRelationshipsByTrustDomainID: %w", err)
 	}
@@ -75,15 +129,51 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.findTrustDomainByNameStmt, err = db.PrepareContext(ctx, findTrustDomainByName); err != nil {
 		return nil, fmt.Errorf("error preparing query FindTrustDomainByName: %w", err)
 	}
+	if q.insertDPoPProofStmt, err = db.PrepareContext(ctx, insertDPoPProof); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertDPoPProof: %w", err)
+	}
+	if q.listBundleSyncStatesStmt, err = db.PrepareContext(ctx, listBundleSyncStates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBundleSyncStates: %w", err)
+	}
 	if q.listBundlesStmt, err = db.PrepareContext(ctx, listBundles); err != nil {
 		return nil, fmt.Errorf("error preparing query ListBundles: %w", err)
 	}
+	if q.listExternalTrustDomainsStmt, err = db.PrepareContext(ctx, listExternalTrustDomains); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExternalTrustDomains: %w", err)
+	}
+	if q.listFederationGroupsStmt, err = db.PrepareContext(ctx, listFederationGroups); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFederationGroups: %w", err)
+	}
+	if q.listHarvestersStmt, err = db.PrepareContext(ctx, listHarvesters); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHarvesters: %w", err)
+	}
 	if q.listJoinTokensStmt, err = db.PrepareContext(ctx, listJoinTokens); err != nil {
 		return nil, fmt.Errorf("error preparing query ListJoinTokens: %w", err)
 	}
+	if q.listSigningKeysStmt, err = db.PrepareContext(ctx, listSigningKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSigningKeys: %w", err)
+	}
+	if q.listWebhookDeadLettersStmt, err = db.PrepareContext(ctx, listWebhookDeadLetters); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookDeadLetters: %w", err)
+	}
+	if q.recordJoinTokenUseStmt, err = db.PrepareContext(ctx, recordJoinTokenUse); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordJoinTokenUse: %w", err)
+	}
 	if q.updateBundleStmt, err = db.PrepareContext(ctx, updateBundle); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateBundle: %w", err)
 	}
+	if q.updateBundleIfDigestStmt, err = db.PrepareContext(ctx, updateBundleIfDigest); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateBundleIfDigest: %w", err)
+	}
+	if q.updateExternalTrustDomainRefreshStmt, err = db.PrepareContext(ctx, updateExternalTrustDomainRefresh); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateExternalTrustDomainRefresh: %w", err)
+	}
+	if q.updateFederationGroupStmt, err = db.PrepareContext(ctx, updateFederationGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFederationGroup: %w", err)
+	}
+	if q.updateHarvesterBundleUploadStmt, err = db.PrepareContext(ctx, updateHarvesterBundleUpload); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateHarvesterBundleUpload: %w", err)
+	}
 	if q.updateJoinTokenStmt, err = db.PrepareContext(ctx, updateJoinToken); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateJoinToken: %w", err)
 	}
@@ -93,6 +183,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateTrustDomainStmt, err = db.PrepareContext(ctx, updateTrustDomain); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateTrustDomain: %w", err)
 	}
+	if q.upsertBundleSyncStateStmt, err = db.PrepareContext(ctx, upsertBundleSyncState); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertBundleSyncState: %w", err)
+	}
+	if q.upsertExternalTrustDomainStmt, err = db.PrepareContext(ctx, upsertExternalTrustDomain); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertExternalTrustDomain: %w", err)
+	}
+	if q.upsertHarvesterStmt, err = db.PrepareContext(ctx, upsertHarvester); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertHarvester: %w", err)
+	}
+	if q.upsertRelationshipConsentStmt, err = db.PrepareContext(ctx, upsertRelationshipConsent); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertRelationshipConsent: %w", err)
+	}
+	if q.upsertSigningKeyStmt, err = db.PrepareContext(ctx, upsertSigningKey); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertSigningKey: %w", err)
+	}
 	return &q, nil
 }
 
@@ -103,6 +208,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createBundleStmt: %w", cerr)
 		}
 	}
+	if q.createBundleIfNotExistsStmt != nil {
+		if cerr := q.createBundleIfNotExistsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBundleIfNotExistsStmt: %w", cerr)
+		}
+	}
+	if q.createFederationGroupStmt != nil {
+		if cerr := q.createFederationGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFederationGroupStmt: %w", cerr)
+		}
+	}
+	if q.createFederationGroupMemberStmt != nil {
+		if cerr := q.createFederationGroupMemberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFederationGroupMemberStmt: %w", cerr)
+		}
+	}
 	if q.createJoinTokenStmt != nil {
 		if cerr := q.createJoinTokenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createJoinTokenStmt: %w", cerr)
@@ -118,11 +238,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createTrustDomainStmt: %w", cerr)
 		}
 	}
+	if q.createWebhookDeadLetterStmt != nil {
+		if cerr := q.createWebhookDeadLetterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeadLetterStmt: %w", cerr)
+		}
+	}
 	if q.deleteBundleStmt != nil {
 		if cerr := q.deleteBundleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteBundleStmt: %w", cerr)
 		}
 	}
+	if q.deleteExpiredDPoPProofsStmt != nil {
+		if cerr := q.deleteExpiredDPoPProofsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredDPoPProofsStmt: %w", cerr)
+		}
+	}
+	if q.deleteExternalTrustDomainStmt != nil {
+		if cerr := q.deleteExternalTrustDomainStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExternalTrustDomainStmt: %w", cerr)
+		}
+	}
+	if q.deleteFederationGroupStmt != nil {
+		if cerr := q.deleteFederationGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFederationGroupStmt: %w", cerr)
+		}
+	}
+	if q.deleteFederationGroupMemberStmt != nil {
+		if cerr := q.deleteFederationGroupMemberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFederationGroupMemberStmt: %w", cerr)
+		}
+	}
 	if q.deleteJoinTokenStmt != nil {
 		if cerr := q.deleteJoinTokenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteJoinTokenStmt: %w", cerr)
@@ -133,6 +278,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteRelationshipStmt: %w", cerr)
 		}
 	}
+	if q.deleteRelationshipConsentStmt != nil {
+		if cerr := q.deleteRelationshipConsentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRelationshipConsentStmt: %w", cerr)
+		}
+	}
+	if q.deleteSigningKeyStmt != nil {
+		if cerr := q.deleteSigningKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSigningKeyStmt: %w", cerr)
+		}
+	}
+	if q.deleteStaleBundleSyncStatesStmt != nil {
+		if cerr := q.deleteStaleBundleSyncStatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStaleBundleSyncStatesStmt: %w", cerr)
+		}
+	}
 	if q.deleteTrustDomainStmt != nil {
 		if cerr := q.deleteTrustDomainStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteTrustDomainStmt: %w", cerr)
@@ -148,9 +308,29 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findBundleByTrustDomainIDStmt: %w", cerr)
 		}
 	}
-	if q.findJoinTokenStmt != nil {
-		if cerr := q.findJoinTokenStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing findJoinTokenStmt: %w", cerr)
+	if q.findBundleSyncStatesByTrustDomainIDStmt != nil {
+		if cerr := q.findBundleSyncStatesByTrustDomainIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findBundleSyncStatesByTrustDomainIDStmt: %w", cerr)
+		}
+	}
+	if q.findExternalTrustDomainByTrustDomainIDStmt != nil {
+		if cerr := q.findExternalTrustDomainByTrustDomainIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findExternalTrustDomainByTrustDomainIDStmt: %w", cerr)
+		}
+	}
+	if q.findFederationGroupByNameStmt != nil {
+		if cerr := q.findFederationGroupByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findFederationGroupByNameStmt: %w", cerr)
+		}
+	}
+	if q.findFederationGroupMembersByGroupIDStmt != nil {
+		if cerr := q.findFederationGroupMembersByGroupIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findFederationGroupMembersByGroupIDStmt: %w", cerr)
+		}
+	}
+	if q.findHarvestersByTrustDomainIDStmt != nil {
+		if cerr := q.findHarvestersByTrustDomainIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findHarvestersByTrustDomainIDStmt: %w", cerr)
 		}
 	}
 	if q.findJoinTokenByIDStmt != nil {
@@ -158,16 +338,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findJoinTokenByIDStmt: %w", cerr)
 		}
 	}
+	if q.findJoinTokenByPrefixStmt != nil {
+		if cerr := q.findJoinTokenByPrefixStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findJoinTokenByPrefixStmt: %w", cerr)
+		}
+	}
 	if q.findJoinTokensByTrustDomainIDStmt != nil {
 		if cerr := q.findJoinTokensByTrustDomainIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findJoinTokensByTrustDomainIDStmt: %w", cerr)
 		}
 	}
+	if q.findLegacyJoinTokenByHashStmt != nil {
+		if cerr := q.findLegacyJoinTokenByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findLegacyJoinTokenByHashStmt: %w", cerr)
+		}
+	}
 	if q.findRelationshipByIDStmt != nil {
 		if cerr := q.findRelationshipByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findRelationshipByIDStmt: %w", cerr)
 		}
 	}
+	if q.findRelationshipConsentsByRelationshipIDStmt != nil {
+		if cerr := q.findRelationshipConsentsByRelationshipIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findRelationshipConsentsByRelationshipIDStmt: %w", cerr)
+		}
+	}
 	if q.findRelationshipsByTrustDomainIDStmt != nil {
 		if cerr := q.findRelationshipsByTrustDomainIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findRelationshipsByTrustDomainIDStmt: %w", cerr)
@@ -183,21 +378,81 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findTrustDomainByNameStmt: %w", cerr)
 		}
 	}
+	if q.insertDPoPProofStmt != nil {
+		if cerr := q.insertDPoPProofStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertDPoPProofStmt: %w", cerr)
+		}
+	}
+	if q.listBundleSyncStatesStmt != nil {
+		if cerr := q.listBundleSyncStatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBundleSyncStatesStmt: %w", cerr)
+		}
+	}
 	if q.listBundlesStmt != nil {
 		if cerr := q.listBundlesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listBundlesStmt: %w", cerr)
 		}
 	}
+	if q.listExternalTrustDomainsStmt != nil {
+		if cerr := q.listExternalTrustDomainsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExternalTrustDomainsStmt: %w", cerr)
+		}
+	}
+	if q.listFederationGroupsStmt != nil {
+		if cerr := q.listFederationGroupsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFederationGroupsStmt: %w", cerr)
+		}
+	}
+	if q.listHarvestersStmt != nil {
+		if cerr := q.listHarvestersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHarvestersStmt: %w", cerr)
+		}
+	}
 	if q.listJoinTokensStmt != nil {
 		if cerr := q.listJoinTokensStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listJoinTokensStmt: %w", cerr)
 		}
 	}
+	if q.listSigningKeysStmt != nil {
+		if cerr := q.listSigningKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSigningKeysStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookDeadLettersStmt != nil {
+		if cerr := q.listWebhookDeadLettersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookDeadLettersStmt: %w", cerr)
+		}
+	}
+	if q.recordJoinTokenUseStmt != nil {
+		if cerr := q.recordJoinTokenUseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordJoinTokenUseStmt: %w", cerr)
+		}
+	}
 	if q.updateBundleStmt != nil {
 		if cerr := q.updateBundleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateBundleStmt: %w", cerr)
 		}
 	}
+	if q.updateBundleIfDigestStmt != nil {
+		if cerr := q.updateBundleIfDigestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateBundleIfDigestStmt: %w", cerr)
+		}
+	}
+	if q.updateExternalTrustDomainRefreshStmt != nil {
+		if cerr := q.updateExternalTrustDomainRefreshStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateExternalTrustDomainRefreshStmt: %w", cerr)
+		}
+	}
+	if q.updateFederationGroupStmt != nil {
+		if cerr := q.updateFederationGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFederationGroupStmt: %w", cerr)
+		}
+	}
+	if q.updateHarvesterBundleUploadStmt != nil {
+		if cerr := q.updateHarvesterBundleUploadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateHarvesterBundleUploadStmt: %w", cerr)
+		}
+	}
 	if q.updateJoinTokenStmt != nil {
 		if cerr := q.updateJoinTokenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateJoinTokenStmt: %w", cerr)
@@ -213,6 +468,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateTrustDomainStmt: %w", cerr)
 		}
 	}
+	if q.upsertBundleSyncStateStmt != nil {
+		if cerr := q.upsertBundleSyncStateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertBundleSyncStateStmt: %w", cerr)
+		}
+	}
+	if q.upsertExternalTrustDomainStmt != nil {
+		if cerr := q.upsertExternalTrustDomainStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertExternalTrustDomainStmt: %w", cerr)
+		}
+	}
+	if q.upsertHarvesterStmt != nil {
+		if cerr := q.upsertHarvesterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertHarvesterStmt: %w", cerr)
+		}
+	}
+	if q.upsertRelationshipConsentStmt != nil {
+		if cerr := q.upsertRelationshipConsentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertRelationshipConsentStmt: %w", cerr)
+		}
+	}
+	if q.upsertSigningKeyStmt != nil {
+		if cerr := q.upsertSigningKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertSigningKeyStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -250,59 +530,129 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                   DBTX
-	tx                                   *sql.Tx
-	createBundleStmt                     *sql.Stmt
-	createJoinTokenStmt                  *sql.Stmt
-	createRelationshipStmt               *sql.Stmt
-	createTrustDomainStmt                *sql.Stmt
-	deleteBundleStmt                     *sql.Stmt
-	deleteJoinTokenStmt                  *sql.Stmt
-	deleteRelationshipStmt               *sql.Stmt
-	deleteTrustDomainStmt                *sql.Stmt
-	findBundleByIDStmt                   *sql.Stmt
-	findBundleByTrustDomainIDStmt        *sql.Stmt
-	findJoinTokenStmt                    *sql.Stmt
-	findJoinTokenByIDStmt                *sql.Stmt
-	findJoinTokensByTrustDomainIDStmt    *sql.Stmt
-	findRelationshipByIDStmt             *sql.Stmt
-	findRelationshipsByTrustDomainIDStmt *sql.Stmt
-	findTrustDomainByIDStmt              *sql.Stmt
-	findTrustDomainByNameStmt            *sql.Stmt
-	listBundlesStmt                      *sql.Stmt
-	listJoinTokensStmt                   *sql.Stmt
-	updateBundleStmt                     *sql.Stmt
-	updateJoinTokenStmt                  *sql.Stmt
-	updateRelationshipStmt               *sql.Stmt
-	updateTrustDomainStmt                *sql.Stmt
+	db                                           DBTX
+	tx                                           *sql.Tx
+	createBundleStmt                             *sql.Stmt
+	createBundleIfNotExistsStmt                  *sql.Stmt
+	createFederationGroupStmt                    *sql.Stmt
+	createFederationGroupMemberStmt              *sql.Stmt
+	createJoinTokenStmt                          *sql.Stmt
+	createRelationshipStmt                       *sql.Stmt
+	createTrustDomainStmt                        *sql.Stmt
+	createWebhookDeadLetterStmt                  *sql.Stmt
+	deleteBundleStmt                             *sql.Stmt
+	deleteExpiredDPoPProofsStmt                  *sql.Stmt
+	deleteExternalTrustDomainStmt                *sql.Stmt
+	deleteFederationGroupStmt                    *sql.Stmt
+	deleteFederationGroupMemberStmt              *sql.Stmt
+	deleteJoinTokenStmt                          *sql.Stmt
+	deleteRelationshipStmt                       *sql.Stmt
+	deleteRelationshipConsentStmt                *sql.Stmt
+	deleteSigningKeyStmt                         *sql.Stmt
+	deleteStaleBundleSyncStatesStmt              *sql.Stmt
+	deleteTrustDomainStmt                        *sql.Stmt
+	findBundleByIDStmt                           *sql.Stmt
+	findBundleByTrustDomainIDStmt                *sql.Stmt
+	findBundleSyncStatesByTrustDomainIDStmt      *sql.Stmt
+	findExternalTrustDomainByTrustDomainIDStmt   *sql.Stmt
+	findFederationGroupByNameStmt                *sql.Stmt
+	findFederationGroupMembersByGroupIDStmt      *sql.Stmt
+	findHarvestersByTrustDomainIDStmt            *sql.Stmt
+	findJoinTokenByIDStmt                        *sql.Stmt
+	findJoinTokenByPrefixStmt                    *sql.Stmt
+	findJoinTokensByTrustDomainIDStmt            *sql.Stmt
+	findLegacyJoinTokenByHashStmt                *sql.Stmt
+	findRelationshipByIDStmt                     *sql.Stmt
+	findRelationshipConsentsByRelationshipIDStmt *sql.Stmt
+	findRelationshipsByTrustDomainIDStmt         *sql.Stmt
+	findTrustDomainByIDStmt                      *sql.Stmt
+	findTrustDomainByNameStmt                    *sql.Stmt
+	insertDPoPProofStmt                          *sql.Stmt
+	listBundleSyncStatesStmt                     *sql.Stmt
+	listBundlesStmt                              *sql.Stmt
+	listExternalTrustDomainsStmt                 *sql.Stmt
+	listFederationGroupsStmt                     *sql.Stmt
+	listHarvestersStmt                           *sql.Stmt
+	listJoinTokensStmt                           *sql.Stmt
+	listSigningKeysStmt                          *sql.Stmt
+	listWebhookDeadLettersStmt                   *sql.Stmt
+	recordJoinTokenUseStmt                       *sql.Stmt
+	updateBundleStmt                             *sql.Stmt
+	updateBundleIfDigestStmt                     *sql.Stmt
+	updateExternalTrustDomainRefreshStmt         *sql.Stmt
+	updateFederationGroupStmt                    *sql.Stmt
+	updateHarvesterBundleUploadStmt              *sql.Stmt
+	updateJoinTokenStmt                          *sql.Stmt
+	updateRelationshipStmt                       *sql.Stmt
+	updateTrustDomainStmt                        *sql.Stmt
+	upsertBundleSyncStateStmt                    *sql.Stmt
+	upsertExternalTrustDomainStmt                *sql.Stmt
+	upsertHarvesterStmt                          *sql.Stmt
+	upsertRelationshipConsentStmt                *sql.Stmt
+	upsertSigningKeyStmt                         *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                   tx,
-		tx:                                   tx,
-		createBundleStmt:                     q.createBundleStmt,
-		createJoinTokenStmt:                  q.createJoinTokenStmt,
-		createRelationshipStmt:               q.createRelationshipStmt,
-		createTrustDomainStmt:                q.createTrustDomainStmt,
-		deleteBundleStmt:                     q.deleteBundleStmt,
-		deleteJoinTokenStmt:                  q.deleteJoinTokenStmt,
-		deleteRelationshipStmt:               q.deleteRelationshipStmt,
-		deleteTrustDomainStmt:                q.deleteTrustDomainStmt,
-		findBundleByIDStmt:                   q.findBundleByIDStmt,
-		findBundleByTrustDomainIDStmt:        q.findBundleByTrustDomainIDStmt,
-		findJoinTokenStmt:                    q.findJoinTokenStmt,
-		findJoinTokenByIDStmt:                q.findJoinTokenByIDStmt,
-		findJoinTokensByTrustDomainIDStmt:    q.findJoinTokensByTrustDomainIDStmt,
-		findRelationshipByIDStmt:             q.findRelationshipByIDStmt,
-		findRelationshipsByTrustDomainIDStmt: q.findRelationshipsByTrustDomainIDStmt,
-		findTrustDomainByIDStmt:              q.findTrustDomainByIDStmt,
-		findTrustDomainByNameStmt:            q.findTrustDomainByNameStmt,
-		listBundlesStmt:                      q.listBundlesStmt,
-		listJoinTokensStmt:                   q.listJoinTokensStmt,
-		updateBundleStmt:                     q.updateBundleStmt,
-		updateJoinTokenStmt:                  q.updateJoinTokenStmt,
-		updateRelationshipStmt:               q.updateRelationshipStmt,
-		updateTrustDomainStmt:                q.updateTrustDomainStmt,
+		db:                                           tx,
+		tx:                                           tx,
+		createBundleStmt:                             q.createBundleStmt,
+		createBundleIfNotExistsStmt:                  q.createBundleIfNotExistsStmt,
+		createFederationGroupStmt:                    q.createFederationGroupStmt,
+		createFederationGroupMemberStmt:              q.createFederationGroupMemberStmt,
+		createJoinTokenStmt:                          q.createJoinTokenStmt,
+		createRelationshipStmt:                       q.createRelationshipStmt,
+		createTrustDomainStmt:                        q.createTrustDomainStmt,
+		createWebhookDeadLetterStmt:                  q.createWebhookDeadLetterStmt,
+		deleteBundleStmt:                             q.deleteBundleStmt,
+		deleteExpiredDPoPProofsStmt:                  q.deleteExpiredDPoPProofsStmt,
+		deleteExternalTrustDomainStmt:                q.deleteExternalTrustDomainStmt,
+		deleteFederationGroupStmt:                    q.deleteFederationGroupStmt,
+		deleteFederationGroupMemberStmt:              q.deleteFederationGroupMemberStmt,
+		deleteJoinTokenStmt:                          q.deleteJoinTokenStmt,
+		deleteRelationshipStmt:                       q.deleteRelationshipStmt,
+		deleteRelationshipConsentStmt:                q.deleteRelationshipConsentStmt,
+		deleteSigningKeyStmt:                         q.deleteSigningKeyStmt,
+		deleteStaleBundleSyncStatesStmt:              q.deleteStaleBundleSyncStatesStmt,
+		deleteTrustDomainStmt:                        q.deleteTrustDomainStmt,
+		findBundleByIDStmt:                           q.findBundleByIDStmt,
+		findBundleByTrustDomainIDStmt:                q.findBundleByTrustDomainIDStmt,
+		findBundleSyncStatesByTrustDomainIDStmt:      q.findBundleSyncStatesByTrustDomainIDStmt,
+		findExternalTrustDomainByTrustDomainIDStmt:   q.findExternalTrustDomainByTrustDomainIDStmt,
+		findFederationGroupByNameStmt:                q.findFederationGroupByNameStmt,
+		findFederationGroupMembersByGroupIDStmt:      q.findFederationGroupMembersByGroupIDStmt,
+		findHarvestersByTrustDomainIDStmt:            q.findHarvestersByTrustDomainIDStmt,
+		findJoinTokenByIDStmt:                        q.findJoinTokenByIDStmt,
+		findJoinTokenByPrefixStmt:                    q.findJoinTokenByPrefixStmt,
+		findJoinTokensByTrustDomainIDStmt:            q.findJoinTokensByTrustDomainIDStmt,
+		findLegacyJoinTokenByHashStmt:                q.findLegacyJoinTokenByHashStmt,
+		findRelationshipByIDStmt:                     q.findRelationshipByIDStmt,
+		findRelationshipConsentsByRelationshipIDStmt: q.findRelationshipConsentsByRelationshipIDStmt,
+		findRelationshipsByTrustDomainIDStmt:         q.findRelationshipsByTrustDomainIDStmt,
+		findTrustDomainByIDStmt:                      q.findTrustDomainByIDStmt,
+		findTrustDomainByNameStmt:                    q.findTrustDomainByNameStmt,
+		insertDPoPProofStmt:                          q.insertDPoPProofStmt,
+		listBundleSyncStatesStmt:                     q.listBundleSyncStatesStmt,
+		listBundlesStmt:                              q.listBundlesStmt,
+		listExternalTrustDomainsStmt:                 q.listExternalTrustDomainsStmt,
+		listFederationGroupsStmt:                     q.listFederationGroupsStmt,
+		listHarvestersStmt:                           q.listHarvestersStmt,
+		listJoinTokensStmt:                           q.listJoinTokensStmt,
+		listSigningKeysStmt:                          q.listSigningKeysStmt,
+		listWebhookDeadLettersStmt:                   q.listWebhookDeadLettersStmt,
+		recordJoinTokenUseStmt:                       q.recordJoinTokenUseStmt,
+		updateBundleStmt:                             q.updateBundleStmt,
+		updateBundleIfDigestStmt:                     q.updateBundleIfDigestStmt,
+		updateExternalTrustDomainRefreshStmt:         q.updateExternalTrustDomainRefreshStmt,
+		updateFederationGroupStmt:                    q.updateFederationGroupStmt,
+		updateHarvesterBundleUploadStmt:              q.updateHarvesterBundleUploadStmt,
+		updateJoinTokenStmt:                          q.updateJoinTokenStmt,
+		updateRelationshipStmt:                       q.updateRelationshipStmt,
+		updateTrustDomainStmt:                        q.updateTrustDomainStmt,
+		upsertBundleSyncStateStmt:                    q.upsertBundleSyncStateStmt,
+		upsertExternalTrustDomainStmt:                q.upsertExternalTrustDomainStmt,
+		upsertHarvesterStmt:                          q.upsertHarvesterStmt,
+		upsertRelationshipConsentStmt:                q.upsertRelationshipConsentStmt,
+		upsertSigningKeyStmt:                         q.upsertSigningKeyStmt,
 	}
 }
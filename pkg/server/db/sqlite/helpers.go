@@ -1,7 +1,9 @@
 package sqlite
 
 import (
+	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/google/uuid"
@@ -34,6 +36,18 @@ func (td TrustDomain) ToEntity() (*entity.TrustDomain, error) {
 		result.Description = td.Description.String
 	}
 
+	if td.HarvesterSpiffeID != "" {
+		harvesterID, err := spiffeid.FromString(td.HarvesterSpiffeID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+		}
+		result.HarvesterSPIFFEID = harvesterID
+	}
+
+	if len(td.OnboardingBundle) > 0 {
+		result.OnboardingBundle = td.OnboardingBundle
+	}
+
 	return result, nil
 }
 
@@ -56,12 +70,23 @@ func (r Relationship) ToEntity() (*entity.Relationship, error) {
 		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
 	}
 
+	var groupID uuid.NullUUID
+	if r.FederationGroupID.Valid {
+		id, err := uuid.Parse(r.FederationGroupID.String)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+		}
+		groupID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
 	return &entity.Relationship{
 		ID:                  nullID,
 		TrustDomainAID:      tdAID,
 		TrustDomainBID:      tdBID,
 		TrustDomainAConsent: entity.ConsentStatus(r.TrustDomainAConsent),
 		TrustDomainBConsent: entity.ConsentStatus(r.TrustDomainBConsent),
+		Direction:           entity.RelationshipDirection(r.Direction),
+		FederationGroupID:   groupID,
 		CreatedAt:           r.CreatedAt,
 		UpdatedAt:           r.UpdatedAt,
 	}, nil
@@ -89,6 +114,9 @@ func (b Bundle) ToEntity() (*entity.Bundle, error) {
 		Signature:          b.Signature,
 		SigningCertificate: b.SigningCertificate,
 		TrustDomainID:      tdID,
+		VerificationStatus: entity.BundleVerificationStatus(b.VerificationStatus),
+		VerifiedBy:         b.VerifiedBy,
+		AdminManaged:       b.AdminManaged,
 		CreatedAt:          b.CreatedAt,
 		UpdatedAt:          b.UpdatedAt,
 	}, nil
@@ -111,11 +139,235 @@ func (jt JoinToken) ToEntity() (*entity.JoinToken, error) {
 
 	return &entity.JoinToken{
 		ID:            nullID,
-		Token:         jt.Token,
+		TokenPrefix:   jt.TokenPrefix,
+		TokenSalt:     jt.TokenSalt,
+		TokenHash:     jt.TokenHash,
 		ExpiresAt:     jt.ExpiresAt,
 		Used:          jt.Used,
 		TrustDomainID: tdID,
+		MaxUses:       int(jt.MaxUses),
+		UseCount:      int(jt.UseCount),
+		SourceCIDR:    jt.SourceCidr,
 		CreatedAt:     jt.CreatedAt,
 		UpdatedAt:     jt.UpdatedAt,
 	}, nil
 }
+
+func (c RelationshipConsent) ToEntity() (*entity.RelationshipConsent, error) {
+	id, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	relationshipID, err := uuid.Parse(c.RelationshipID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	trustDomainID, err := uuid.Parse(c.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.RelationshipConsent{
+		ID:                 uuid.NullUUID{UUID: id, Valid: true},
+		RelationshipID:     relationshipID,
+		TrustDomainID:      trustDomainID,
+		ConsentStatus:      entity.ConsentStatus(c.ConsentStatus),
+		Statement:          c.Statement,
+		Signature:          c.Signature,
+		SigningCertificate: c.SigningCertificate,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}, nil
+}
+
+func (dl WebhookDeadLetter) ToEntity() (*entity.WebhookDeadLetter, error) {
+	id, err := uuid.Parse(dl.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+	nullID := uuid.NullUUID{
+		UUID:  id,
+		Valid: true,
+	}
+
+	eventID, err := uuid.Parse(dl.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.WebhookDeadLetter{
+		ID:        nullID,
+		Webhook:   dl.Webhook,
+		URL:       dl.Url,
+		EventID:   eventID,
+		EventType: dl.EventType,
+		Payload:   dl.Payload,
+		Attempts:  int(dl.Attempts),
+		LastError: dl.LastError,
+		CreatedAt: dl.CreatedAt,
+	}, nil
+}
+
+func (h Harvester) ToEntity() (*entity.Harvester, error) {
+	id, err := uuid.Parse(h.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	tdID, err := uuid.Parse(h.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.Harvester{
+		ID:                           uuid.NullUUID{UUID: id, Valid: true},
+		TrustDomainID:                tdID,
+		InstanceID:                   h.InstanceID,
+		LastSeenAt:                   h.LastSeenAt,
+		SourceAddress:                h.SourceAddress,
+		Version:                      h.Version,
+		SpireBundlePollInterval:      time.Duration(h.SpireBundlePollInterval) * time.Second,
+		FederatedBundlesPollInterval: time.Duration(h.FederatedBundlesPollInterval) * time.Second,
+		TokenExpiresAt:               h.TokenExpiresAt,
+		LastBundleUploadAt:           h.LastBundleUploadAt.Time,
+		LastBundleDigest:             h.LastBundleDigest,
+		LastBundleUploadStatus:       entity.BundleUploadStatus(h.LastBundleUploadStatus),
+		CreatedAt:                    h.CreatedAt,
+		UpdatedAt:                    h.UpdatedAt,
+	}, nil
+}
+
+func (s BundleSyncState) ToEntity() (*entity.BundleSyncState, error) {
+	id, err := uuid.Parse(s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	tdID, err := uuid.Parse(s.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	federatedTD, err := spiffeid.TrustDomainFromString(s.FederatedTrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.BundleSyncState{
+		ID:                   uuid.NullUUID{UUID: id, Valid: true},
+		TrustDomainID:        tdID,
+		HarvesterInstanceID:  s.HarvesterInstanceID,
+		FederatedTrustDomain: federatedTD,
+		Digest:               s.Digest,
+		DigestUpdatedAt:      s.DigestUpdatedAt,
+		ReportedAt:           s.ReportedAt,
+		CreatedAt:            s.CreatedAt,
+		UpdatedAt:            s.UpdatedAt,
+	}, nil
+}
+
+func (k SigningKey) ToEntity() *entity.SigningKey {
+	return &entity.SigningKey{
+		ID:         k.ID,
+		PrivateKey: k.PrivateKey,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+}
+
+func (e ExternalTrustDomain) ToEntity() (*entity.ExternalTrustDomain, error) {
+	id, err := uuid.Parse(e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	tdID, err := uuid.Parse(e.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.ExternalTrustDomain{
+		ID:                    uuid.NullUUID{UUID: id, Valid: true},
+		TrustDomainID:         tdID,
+		BundleEndpointURL:     e.BundleEndpointUrl,
+		BundleEndpointProfile: e.BundleEndpointProfile,
+		EndpointSPIFFEID:      e.EndpointSpiffeID,
+		BootstrapBundle:       e.BootstrapBundle,
+		LastRefreshAt:         e.LastRefreshAt.Time,
+		LastRefreshError:      e.LastRefreshError,
+		CreatedAt:             e.CreatedAt,
+		UpdatedAt:             e.UpdatedAt,
+	}, nil
+}
+
+func (g FederationGroup) ToEntity() (*entity.FederationGroup, error) {
+	id, err := uuid.Parse(g.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.FederationGroup{
+		ID:             uuid.NullUUID{UUID: id, Valid: true},
+		Name:           g.Name,
+		Description:    g.Description,
+		DefaultConsent: entity.ConsentStatus(g.DefaultConsent),
+		CreatedAt:      g.CreatedAt,
+		UpdatedAt:      g.UpdatedAt,
+	}, nil
+}
+
+func (m FederationGroupMember) ToEntity() (*entity.FederationGroupMember, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	groupID, err := uuid.Parse(m.FederationGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	tdID, err := uuid.Parse(m.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.FederationGroupMember{
+		ID:                uuid.NullUUID{UUID: id, Valid: true},
+		FederationGroupID: groupID,
+		TrustDomainID:     tdID,
+		CreatedAt:         m.CreatedAt,
+	}, nil
+}
+
+func uuidToNullString(id uuid.NullUUID) sql.NullString {
+	if !id.Valid {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: id.UUID.String(), Valid: true}
+}
+
+// maxUsesOrDefault makes join tokens single-use unless they allow more uses.
+func maxUsesOrDefault(maxUses int) int {
+	if maxUses < 1 {
+		return 1
+	}
+	return maxUses
+}
+
+func harvesterSPIFFEIDToString(id spiffeid.ID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.String()
+}
+
+// onboardingBundleOrEmpty avoids storing NULL in the not-null onboarding bundle column.
+func onboardingBundleOrEmpty(bundle []byte) []byte {
+	if bundle == nil {
+		return []byte{}
+	}
+	return bundle
+}
@@ -11,34 +11,47 @@ import (
 )
 
 const createJoinToken = `-- name: CreateJoinToken :one
-INSERT INTO join_tokens(id, token, expires_at, trust_domain_id)
-VALUES (?, ?, ?, ?)
-RETURNING id, trust_domain_id, token, used, expires_at, created_at, updated_at
+INSERT INTO join_tokens(id, token_prefix, token_salt, token_hash, expires_at, trust_domain_id, max_uses, source_cidr)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
 `
 
 type CreateJoinTokenParams struct {
 	ID            string
-	Token         string
+	TokenPrefix   string
+	TokenSalt     []byte
+	TokenHash     []byte
 	ExpiresAt     time.Time
 	TrustDomainID string
+	MaxUses       int64
+	SourceCidr    string
 }
 
 func (q *Queries) CreateJoinToken(ctx context.Context, arg CreateJoinTokenParams) (JoinToken, error) {
 	row := q.queryRow(ctx, q.createJoinTokenStmt, createJoinToken,
 		arg.ID,
-		arg.Token,
+		arg.TokenPrefix,
+		arg.TokenSalt,
+		arg.TokenHash,
 		arg.ExpiresAt,
 		arg.TrustDomainID,
+		arg.MaxUses,
+		arg.SourceCidr,
 	)
 	var i JoinToken
 	err := row.Scan(
 		&i.ID,
 		&i.TrustDomainID,
-		&i.Token,
+		&i.TokenPrefix,
 		&i.Used,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.SourceCidr,
+		&i.TokenSalt,
+		&i.TokenHash,
 	)
 	return i, err
 }
@@ -54,50 +67,87 @@ func (q *Queries) DeleteJoinToken(ctx context.Context, id string) error {
 	return err
 }
 
-const findJoinToken = `-- name: FindJoinToken :one
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+const findJoinTokenByID = `-- name: FindJoinTokenByID :one
+SELECT id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
 FROM join_tokens
-WHERE token = ?
+WHERE id = ?
 `
 
-func (q *Queries) FindJoinToken(ctx context.Context, token string) (JoinToken, error) {
-	row := q.queryRow(ctx, q.findJoinTokenStmt, findJoinToken, token)
+func (q *Queries) FindJoinTokenByID(ctx context.Context, id string) (JoinToken, error) {
+	row := q.queryRow(ctx, q.findJoinTokenByIDStmt, findJoinTokenByID, id)
 	var i JoinToken
 	err := row.Scan(
 		&i.ID,
 		&i.TrustDomainID,
-		&i.Token,
+		&i.TokenPrefix,
 		&i.Used,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.SourceCidr,
+		&i.TokenSalt,
+		&i.TokenHash,
 	)
 	return i, err
 }
 
-const findJoinTokenByID = `-- name: FindJoinTokenByID :one
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+const findJoinTokenByPrefix = `-- name: FindJoinTokenByPrefix :one
+SELECT id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
 FROM join_tokens
-WHERE id = ?
+WHERE token_prefix = ?
 `
 
-func (q *Queries) FindJoinTokenByID(ctx context.Context, id string) (JoinToken, error) {
-	row := q.queryRow(ctx, q.findJoinTokenByIDStmt, findJoinTokenByID, id)
+func (q *Queries) FindJoinTokenByPrefix(ctx context.Context, tokenPrefix string) (JoinToken, error) {
+	row := q.queryRow(ctx, q.findJoinTokenByPrefixStmt, findJoinTokenByPrefix, tokenPrefix)
+	var i JoinToken
+	err := row.Scan(
+		&i.ID,
+		&i.TrustDomainID,
+		&i.TokenPrefix,
+		&i.Used,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.SourceCidr,
+		&i.TokenSalt,
+		&i.TokenHash,
+	)
+	return i, err
+}
+
+const findLegacyJoinTokenByHash = `-- name: FindLegacyJoinTokenByHash :one
+SELECT id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
+FROM join_tokens
+WHERE token_salt = x''
+  AND token_hash = ?
+`
+
+func (q *Queries) FindLegacyJoinTokenByHash(ctx context.Context, tokenHash []byte) (JoinToken, error) {
+	row := q.queryRow(ctx, q.findLegacyJoinTokenByHashStmt, findLegacyJoinTokenByHash, tokenHash)
 	var i JoinToken
 	err := row.Scan(
 		&i.ID,
 		&i.TrustDomainID,
-		&i.Token,
+		&i.TokenPrefix,
 		&i.Used,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.SourceCidr,
+		&i.TokenSalt,
+		&i.TokenHash,
 	)
 	return i, err
 }
 
 const findJoinTokensByTrustDomainID = `-- name: FindJoinTokensByTrustDomainID :many
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+SELECT id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
 FROM join_tokens
 WHERE trust_domain_id = ?
 ORDER BY created_at DESC
@@ -115,11 +165,16 @@ func (q *Queries) FindJoinTokensByTrustDomainID(ctx context.Context, trustDomain
 		if err := rows.Scan(
 			&i.ID,
 			&i.TrustDomainID,
-			&i.Token,
+			&i.TokenPrefix,
 			&i.Used,
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxUses,
+			&i.UseCount,
+			&i.SourceCidr,
+			&i.TokenSalt,
+			&i.TokenHash,
 		); err != nil {
 			return nil, err
 		}
@@ -135,7 +190,7 @@ func (q *Queries) FindJoinTokensByTrustDomainID(ctx context.Context, trustDomain
 }
 
 const listJoinTokens = `-- name: ListJoinTokens :many
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+SELECT id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
 FROM join_tokens
 ORDER BY created_at DESC
 `
@@ -152,11 +207,16 @@ func (q *Queries) ListJoinTokens(ctx context.Context) ([]JoinToken, error) {
 		if err := rows.Scan(
 			&i.ID,
 			&i.TrustDomainID,
-			&i.Token,
+			&i.TokenPrefix,
 			&i.Used,
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxUses,
+			&i.UseCount,
+			&i.SourceCidr,
+			&i.TokenSalt,
+			&i.TokenHash,
 		); err != nil {
 			return nil, err
 		}
@@ -171,12 +231,43 @@ func (q *Queries) ListJoinTokens(ctx context.Context) ([]JoinToken, error) {
 	return items, nil
 }
 
+const recordJoinTokenUse = `-- name: RecordJoinTokenUse :one
+UPDATE join_tokens
+SET use_count  = use_count + 1,
+    used       = use_count + 1 >= max_uses,
+    updated_at = datetime('now')
+WHERE id = ?
+  AND NOT used
+  AND use_count < max_uses
+RETURNING id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
+`
+
+func (q *Queries) RecordJoinTokenUse(ctx context.Context, id string) (JoinToken, error) {
+	row := q.queryRow(ctx, q.recordJoinTokenUseStmt, recordJoinTokenUse, id)
+	var i JoinToken
+	err := row.Scan(
+		&i.ID,
+		&i.TrustDomainID,
+		&i.TokenPrefix,
+		&i.Used,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.SourceCidr,
+		&i.TokenSalt,
+		&i.TokenHash,
+	)
+	return i, err
+}
+
 const updateJoinToken = `-- name: UpdateJoinToken :one
 UPDATE join_tokens
 SET used       = ?,
     updated_at = datetime('now')
 WHERE id = ?
-RETURNING id, trust_domain_id, token, used, expires_at, created_at, updated_at
+RETURNING id, trust_domain_id, token_prefix, used, expires_at, created_at, updated_at, max_uses, use_count, source_cidr, token_salt, token_hash
 `
 
 type UpdateJoinTokenParams struct {
@@ -190,11 +281,16 @@ func (q *Queries) UpdateJoinToken(ctx context.Context, arg UpdateJoinTokenParams
 	err := row.Scan(
 		&i.ID,
 		&i.TrustDomainID,
-		&i.Token,
+		&i.TokenPrefix,
 		&i.Used,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.SourceCidr,
+		&i.TokenSalt,
+		&i.TokenHash,
 	)
 	return i, err
 }
@@ -0,0 +1,2 @@
+ALTER TABLE bundles
+    ADD COLUMN admin_managed BOOLEAN NOT NULL DEFAULT false;
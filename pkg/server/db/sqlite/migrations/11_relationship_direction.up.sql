@@ -0,0 +1,2 @@
+ALTER TABLE relationships
+    ADD COLUMN direction TEXT NOT NULL DEFAULT 'bidirectional';
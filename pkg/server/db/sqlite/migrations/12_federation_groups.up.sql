@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS federation_groups
+(
+    id              TEXT PRIMARY KEY,
+    name            TEXT      NOT NULL UNIQUE,
+    description     TEXT      NOT NULL DEFAULT '',
+    default_consent TEXT      NOT NULL DEFAULT 'pending',
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS federation_group_members
+(
+    id                  TEXT PRIMARY KEY,
+    federation_group_id TEXT      NOT NULL,
+    trust_domain_id     TEXT      NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (federation_group_id, trust_domain_id),
+    FOREIGN KEY (federation_group_id)
+        REFERENCES federation_groups (id) ON DELETE CASCADE,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
+
+-- the group whose members the relationship federates, NULL for the relationships created by an admin.
+-- It is not a foreign key, so that the reconciler removes the relationships of deleted groups.
+ALTER TABLE relationships
+    ADD COLUMN federation_group_id TEXT;
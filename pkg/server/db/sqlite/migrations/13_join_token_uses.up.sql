@@ -0,0 +1,10 @@
+ALTER TABLE join_tokens
+    ADD COLUMN max_uses INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE join_tokens
+    ADD COLUMN use_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE join_tokens
+    ADD COLUMN source_cidr TEXT NOT NULL DEFAULT '';
+
+UPDATE join_tokens
+SET use_count = 1
+WHERE used;
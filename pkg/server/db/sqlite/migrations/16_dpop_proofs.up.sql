@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS dpop_proofs
+(
+    id         TEXT PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX dpop_proofs_expires_at_idx ON dpop_proofs (expires_at);
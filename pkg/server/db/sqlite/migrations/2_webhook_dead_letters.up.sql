@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS webhook_dead_letters
+(
+    id         TEXT PRIMARY KEY,
+    webhook    TEXT      NOT NULL,
+    url        TEXT      NOT NULL,
+    event_id   TEXT      NOT NULL,
+    event_type TEXT      NOT NULL,
+    payload    BLOB      NOT NULL,
+    attempts   INTEGER   NOT NULL,
+    last_error TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS relationship_consents
+(
+    id                  TEXT PRIMARY KEY,
+    relationship_id     TEXT      NOT NULL,
+    trust_domain_id     TEXT      NOT NULL,
+    consent_status      TEXT      NOT NULL,
+    statement           BLOB      NOT NULL,
+    signature           BLOB      NOT NULL,
+    signing_certificate BLOB      NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (relationship_id, trust_domain_id),
+    FOREIGN KEY (relationship_id)
+        REFERENCES relationships (id) ON DELETE CASCADE,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
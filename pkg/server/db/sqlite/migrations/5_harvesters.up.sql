@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS harvesters
+(
+    id                              TEXT PRIMARY KEY,
+    trust_domain_id                 TEXT      NOT NULL UNIQUE,
+    last_seen_at                    TIMESTAMP NOT NULL,
+    source_address                  TEXT      NOT NULL,
+    version                         TEXT      NOT NULL,
+    spire_bundle_poll_interval      INTEGER   NOT NULL,
+    federated_bundles_poll_interval INTEGER   NOT NULL,
+    token_expires_at                TIMESTAMP NOT NULL,
+    created_at                      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
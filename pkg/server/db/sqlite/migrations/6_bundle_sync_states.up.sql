@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS bundle_sync_states
+(
+    id                     TEXT PRIMARY KEY,
+    trust_domain_id        TEXT      NOT NULL,
+    federated_trust_domain TEXT      NOT NULL,
+    digest                 BLOB      NOT NULL,
+    digest_updated_at      TIMESTAMP NOT NULL,
+    reported_at            TIMESTAMP NOT NULL,
+    created_at             TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (trust_domain_id, federated_trust_domain),
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
@@ -0,0 +1,70 @@
+CREATE TABLE IF NOT EXISTS harvesters_single
+(
+    id                              TEXT PRIMARY KEY,
+    trust_domain_id                 TEXT      NOT NULL UNIQUE,
+    last_seen_at                    TIMESTAMP NOT NULL,
+    source_address                  TEXT      NOT NULL,
+    version                         TEXT      NOT NULL,
+    spire_bundle_poll_interval      INTEGER   NOT NULL,
+    federated_bundles_poll_interval INTEGER   NOT NULL,
+    token_expires_at                TIMESTAMP NOT NULL,
+    created_at                      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
+
+-- keeps the most recently seen instance of each trust domain
+INSERT INTO harvesters_single(id, trust_domain_id, last_seen_at, source_address, version, spire_bundle_poll_interval,
+                              federated_bundles_poll_interval, token_expires_at, created_at, updated_at)
+SELECT id,
+       trust_domain_id,
+       MAX(last_seen_at),
+       source_address,
+       version,
+       spire_bundle_poll_interval,
+       federated_bundles_poll_interval,
+       token_expires_at,
+       created_at,
+       updated_at
+FROM harvesters
+GROUP BY trust_domain_id;
+
+DROP TABLE harvesters;
+
+ALTER TABLE harvesters_single
+    RENAME TO harvesters;
+
+CREATE TABLE IF NOT EXISTS bundle_sync_single_states
+(
+    id                     TEXT PRIMARY KEY,
+    trust_domain_id        TEXT      NOT NULL,
+    federated_trust_domain TEXT      NOT NULL,
+    digest                 BLOB      NOT NULL,
+    digest_updated_at      TIMESTAMP NOT NULL,
+    reported_at            TIMESTAMP NOT NULL,
+    created_at             TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (trust_domain_id, federated_trust_domain),
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
+
+-- keeps the most recently reported state of each federated bundle of a trust domain
+INSERT INTO bundle_sync_single_states(id, trust_domain_id, federated_trust_domain, digest, digest_updated_at,
+                                      reported_at, created_at, updated_at)
+SELECT id,
+       trust_domain_id,
+       federated_trust_domain,
+       digest,
+       digest_updated_at,
+       MAX(reported_at),
+       created_at,
+       updated_at
+FROM bundle_sync_states
+GROUP BY trust_domain_id, federated_trust_domain;
+
+DROP TABLE bundle_sync_states;
+
+ALTER TABLE bundle_sync_single_states
+    RENAME TO bundle_sync_states;
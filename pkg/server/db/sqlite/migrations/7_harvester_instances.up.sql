@@ -0,0 +1,75 @@
+CREATE TABLE IF NOT EXISTS harvester_instances
+(
+    id                              TEXT PRIMARY KEY,
+    trust_domain_id                 TEXT      NOT NULL,
+    instance_id                     TEXT      NOT NULL,
+    last_seen_at                    TIMESTAMP NOT NULL,
+    source_address                  TEXT      NOT NULL,
+    version                         TEXT      NOT NULL,
+    spire_bundle_poll_interval      INTEGER   NOT NULL,
+    federated_bundles_poll_interval INTEGER   NOT NULL,
+    token_expires_at                TIMESTAMP NOT NULL,
+    last_bundle_upload_at           TIMESTAMP,
+    last_bundle_digest              BLOB,
+    last_bundle_upload_status       TEXT      NOT NULL DEFAULT '',
+    created_at                      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (trust_domain_id, instance_id),
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
+
+INSERT INTO harvester_instances(id, trust_domain_id, instance_id, last_seen_at, source_address, version,
+                                spire_bundle_poll_interval, federated_bundles_poll_interval, token_expires_at,
+                                created_at, updated_at)
+SELECT id,
+       trust_domain_id,
+       'default',
+       last_seen_at,
+       source_address,
+       version,
+       spire_bundle_poll_interval,
+       federated_bundles_poll_interval,
+       token_expires_at,
+       created_at,
+       updated_at
+FROM harvesters;
+
+DROP TABLE harvesters;
+
+ALTER TABLE harvester_instances
+    RENAME TO harvesters;
+
+CREATE TABLE IF NOT EXISTS bundle_sync_instance_states
+(
+    id                     TEXT PRIMARY KEY,
+    trust_domain_id        TEXT      NOT NULL,
+    harvester_instance_id  TEXT      NOT NULL,
+    federated_trust_domain TEXT      NOT NULL,
+    digest                 BLOB      NOT NULL,
+    digest_updated_at      TIMESTAMP NOT NULL,
+    reported_at            TIMESTAMP NOT NULL,
+    created_at             TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (trust_domain_id, harvester_instance_id, federated_trust_domain),
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
+
+INSERT INTO bundle_sync_instance_states(id, trust_domain_id, harvester_instance_id, federated_trust_domain, digest,
+                                        digest_updated_at, reported_at, created_at, updated_at)
+SELECT id,
+       trust_domain_id,
+       'default',
+       federated_trust_domain,
+       digest,
+       digest_updated_at,
+       reported_at,
+       created_at,
+       updated_at
+FROM bundle_sync_states;
+
+DROP TABLE bundle_sync_states;
+
+ALTER TABLE bundle_sync_instance_states
+    RENAME TO bundle_sync_states;
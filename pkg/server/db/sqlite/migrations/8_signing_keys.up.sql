@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS signing_keys
+(
+    id          TEXT PRIMARY KEY,
+    private_key BLOB      NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS external_trust_domains
+(
+    id                      TEXT PRIMARY KEY,
+    trust_domain_id         TEXT      NOT NULL UNIQUE,
+    bundle_endpoint_url     TEXT      NOT NULL,
+    bundle_endpoint_profile TEXT      NOT NULL,
+    endpoint_spiffe_id      TEXT      NOT NULL DEFAULT '',
+    bootstrap_bundle        BLOB,
+    last_refresh_at         TIMESTAMP,
+    last_refresh_error      TEXT      NOT NULL DEFAULT '',
+    created_at              TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id) ON DELETE CASCADE
+);
@@ -18,16 +18,87 @@ type Bundle struct {
 	SigningCertificate []byte
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
+	VerificationStatus string
+	VerifiedBy         string
+	AdminManaged       bool
+}
+
+type BundleSyncState struct {
+	ID                   string
+	TrustDomainID        string
+	HarvesterInstanceID  string
+	FederatedTrustDomain string
+	Digest               []byte
+	DigestUpdatedAt      time.Time
+	ReportedAt           time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type DpopProof struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+type ExternalTrustDomain struct {
+	ID                    string
+	TrustDomainID         string
+	BundleEndpointUrl     string
+	BundleEndpointProfile string
+	EndpointSpiffeID      string
+	BootstrapBundle       []byte
+	LastRefreshAt         sql.NullTime
+	LastRefreshError      string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+type FederationGroup struct {
+	ID             string
+	Name           string
+	Description    string
+	DefaultConsent string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type FederationGroupMember struct {
+	ID                string
+	FederationGroupID string
+	TrustDomainID     string
+	CreatedAt         time.Time
+}
+
+type Harvester struct {
+	ID                           string
+	TrustDomainID                string
+	InstanceID                   string
+	LastSeenAt                   time.Time
+	SourceAddress                string
+	Version                      string
+	SpireBundlePollInterval      int64
+	FederatedBundlesPollInterval int64
+	TokenExpiresAt               time.Time
+	LastBundleUploadAt           sql.NullTime
+	LastBundleDigest             []byte
+	LastBundleUploadStatus       string
+	CreatedAt                    time.Time
+	UpdatedAt                    time.Time
 }
 
 type JoinToken struct {
 	ID            string
 	TrustDomainID string
-	Token         string
+	TokenPrefix   string
 	Used          bool
 	ExpiresAt     time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	MaxUses       int64
+	UseCount      int64
+	SourceCidr    string
+	TokenSalt     []byte
+	TokenHash     []byte
 }
 
 type Relationship struct {
@@ -38,12 +109,47 @@ type Relationship struct {
 	TrustDomainBConsent string
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+	Direction           string
+	FederationGroupID   sql.NullString
+}
+
+type RelationshipConsent struct {
+	ID                 string
+	RelationshipID     string
+	TrustDomainID      string
+	ConsentStatus      string
+	Statement          []byte
+	Signature          []byte
+	SigningCertificate []byte
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type SigningKey struct {
+	ID         string
+	PrivateKey []byte
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type TrustDomain struct {
-	ID          string
-	Name        string
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                string
+	Name              string
+	Description       sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	HarvesterSpiffeID string
+	OnboardingBundle  []byte
+}
+
+type WebhookDeadLetter struct {
+	ID        string
+	Webhook   string
+	Url       string
+	EventID   string
+	EventType string
+	Payload   []byte
+	Attempts  int64
+	LastError string
+	CreatedAt time.Time
 }
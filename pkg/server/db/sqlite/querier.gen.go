@@ -6,32 +6,68 @@ package sqlite
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
 	CreateBundle(ctx context.Context, arg CreateBundleParams) (Bundle, error)
+	CreateBundleIfNotExists(ctx context.Context, arg CreateBundleIfNotExistsParams) (Bundle, error)
+	CreateFederationGroup(ctx context.Context, arg CreateFederationGroupParams) (FederationGroup, error)
+	CreateFederationGroupMember(ctx context.Context, arg CreateFederationGroupMemberParams) (FederationGroupMember, error)
 	CreateJoinToken(ctx context.Context, arg CreateJoinTokenParams) (JoinToken, error)
 	CreateRelationship(ctx context.Context, arg CreateRelationshipParams) (Relationship, error)
 	CreateTrustDomain(ctx context.Context, arg CreateTrustDomainParams) (TrustDomain, error)
+	CreateWebhookDeadLetter(ctx context.Context, arg CreateWebhookDeadLetterParams) (WebhookDeadLetter, error)
 	DeleteBundle(ctx context.Context, id string) error
+	DeleteExpiredDPoPProofs(ctx context.Context, expiresAt time.Time) error
+	DeleteExternalTrustDomain(ctx context.Context, trustDomainID string) error
+	DeleteFederationGroup(ctx context.Context, id string) error
+	DeleteFederationGroupMember(ctx context.Context, arg DeleteFederationGroupMemberParams) error
 	DeleteJoinToken(ctx context.Context, id string) error
 	DeleteRelationship(ctx context.Context, id string) error
+	DeleteRelationshipConsent(ctx context.Context, arg DeleteRelationshipConsentParams) error
+	DeleteSigningKey(ctx context.Context, id string) error
+	DeleteStaleBundleSyncStates(ctx context.Context, arg DeleteStaleBundleSyncStatesParams) error
 	DeleteTrustDomain(ctx context.Context, id string) error
 	FindBundleByID(ctx context.Context, id string) (Bundle, error)
 	FindBundleByTrustDomainID(ctx context.Context, trustDomainID string) (Bundle, error)
-	FindJoinToken(ctx context.Context, token string) (JoinToken, error)
+	FindBundleSyncStatesByTrustDomainID(ctx context.Context, trustDomainID string) ([]BundleSyncState, error)
+	FindExternalTrustDomainByTrustDomainID(ctx context.Context, trustDomainID string) (ExternalTrustDomain, error)
+	FindFederationGroupByName(ctx context.Context, name string) (FederationGroup, error)
+	FindFederationGroupMembersByGroupID(ctx context.Context, federationGroupID string) ([]FederationGroupMember, error)
+	FindHarvestersByTrustDomainID(ctx context.Context, trustDomainID string) ([]Harvester, error)
 	FindJoinTokenByID(ctx context.Context, id string) (JoinToken, error)
+	FindJoinTokenByPrefix(ctx context.Context, tokenPrefix string) (JoinToken, error)
 	FindJoinTokensByTrustDomainID(ctx context.Context, trustDomainID string) ([]JoinToken, error)
+	FindLegacyJoinTokenByHash(ctx context.Context, tokenHash []byte) (JoinToken, error)
 	FindRelationshipByID(ctx context.Context, id string) (Relationship, error)
+	FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID string) ([]RelationshipConsent, error)
 	FindRelationshipsByTrustDomainID(ctx context.Context, arg FindRelationshipsByTrustDomainIDParams) ([]Relationship, error)
 	FindTrustDomainByID(ctx context.Context, id string) (TrustDomain, error)
 	FindTrustDomainByName(ctx context.Context, name string) (TrustDomain, error)
+	InsertDPoPProof(ctx context.Context, arg InsertDPoPProofParams) (int64, error)
+	ListBundleSyncStates(ctx context.Context) ([]BundleSyncState, error)
 	ListBundles(ctx context.Context) ([]Bundle, error)
+	ListExternalTrustDomains(ctx context.Context) ([]ExternalTrustDomain, error)
+	ListFederationGroups(ctx context.Context) ([]FederationGroup, error)
+	ListHarvesters(ctx context.Context) ([]Harvester, error)
 	ListJoinTokens(ctx context.Context) ([]JoinToken, error)
+	ListSigningKeys(ctx context.Context) ([]SigningKey, error)
+	ListWebhookDeadLetters(ctx context.Context) ([]WebhookDeadLetter, error)
+	RecordJoinTokenUse(ctx context.Context, id string) (JoinToken, error)
 	UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bundle, error)
+	UpdateBundleIfDigest(ctx context.Context, arg UpdateBundleIfDigestParams) (Bundle, error)
+	UpdateExternalTrustDomainRefresh(ctx context.Context, arg UpdateExternalTrustDomainRefreshParams) (ExternalTrustDomain, error)
+	UpdateFederationGroup(ctx context.Context, arg UpdateFederationGroupParams) (FederationGroup, error)
+	UpdateHarvesterBundleUpload(ctx context.Context, arg UpdateHarvesterBundleUploadParams) (Harvester, error)
 	UpdateJoinToken(ctx context.Context, arg UpdateJoinTokenParams) (JoinToken, error)
 	UpdateRelationship(ctx context.Context, arg UpdateRelationshipParams) (Relationship, error)
 	UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainParams) (TrustDomain, error)
+	UpsertBundleSyncState(ctx context.Context, arg UpsertBundleSyncStateParams) (BundleSyncState, error)
+	UpsertExternalTrustDomain(ctx context.Context, arg UpsertExternalTrustDomainParams) (ExternalTrustDomain, error)
+	UpsertHarvester(ctx context.Context, arg UpsertHarvesterParams) (Harvester, error)
+	UpsertRelationshipConsent(ctx context.Context, arg UpsertRelationshipConsentParams) (RelationshipConsent, error)
+	UpsertSigningKey(ctx context.Context, arg UpsertSigningKeyParams) (SigningKey, error)
 }
 
 var _ Querier = (*Queries)(nil)
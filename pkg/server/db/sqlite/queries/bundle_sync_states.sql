@@ -0,0 +1,30 @@
+-- name: UpsertBundleSyncState :one
+INSERT INTO bundle_sync_states(id, trust_domain_id, harvester_instance_id, federated_trust_domain, digest,
+                               digest_updated_at, reported_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (trust_domain_id, harvester_instance_id, federated_trust_domain) DO UPDATE SET digest            = excluded.digest,
+                                                                                           digest_updated_at = CASE
+                                                                                                                   WHEN bundle_sync_states.digest = excluded.digest
+                                                                                                                       THEN bundle_sync_states.digest_updated_at
+                                                                                                                   ELSE excluded.digest_updated_at END,
+                                                                                           reported_at       = excluded.reported_at,
+                                                                                           updated_at        = datetime('now')
+RETURNING *;
+
+-- name: FindBundleSyncStatesByTrustDomainID :many
+SELECT *
+FROM bundle_sync_states
+WHERE trust_domain_id = ?
+ORDER BY federated_trust_domain, harvester_instance_id;
+
+-- name: ListBundleSyncStates :many
+SELECT *
+FROM bundle_sync_states
+ORDER BY created_at;
+
+-- name: DeleteStaleBundleSyncStates :exec
+DELETE
+FROM bundle_sync_states
+WHERE trust_domain_id = ?
+  AND harvester_instance_id = ?
+  AND reported_at < ?;
@@ -1,6 +1,14 @@
 -- name: CreateBundle :one
-INSERT INTO bundles(id, data, digest, signature, signing_certificate, trust_domain_id)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO bundles(id, data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: CreateBundleIfNotExists :one
+INSERT INTO bundles(id, data, digest, signature, signing_certificate, trust_domain_id, verification_status, verified_by,
+                    admin_managed)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (trust_domain_id) DO NOTHING
 RETURNING *;
 
 -- name: UpdateBundle :one
@@ -9,8 +17,25 @@ SET data                = ?,
     digest              = ?,
     signature           = ?,
     signing_certificate = ?,
+    verification_status = ?,
+    verified_by         = ?,
+    admin_managed       = ?,
+    updated_at          = datetime('now')
+WHERE id = ?
+RETURNING *;
+
+-- name: UpdateBundleIfDigest :one
+UPDATE bundles
+SET data                = ?,
+    digest              = ?,
+    signature           = ?,
+    signing_certificate = ?,
+    verification_status = ?,
+    verified_by         = ?,
+    admin_managed       = ?,
     updated_at          = datetime('now')
 WHERE id = ?
+  AND digest = sqlc.arg(previous_digest)
 RETURNING *;
 
 -- name: DeleteBundle :exec
@@ -0,0 +1,9 @@
+-- name: InsertDPoPProof :execrows
+INSERT INTO dpop_proofs(id, expires_at)
+VALUES (?, ?)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: DeleteExpiredDPoPProofs :exec
+DELETE
+FROM dpop_proofs
+WHERE expires_at < ?;
@@ -0,0 +1,35 @@
+-- name: UpsertExternalTrustDomain :one
+INSERT INTO external_trust_domains(id, trust_domain_id, bundle_endpoint_url, bundle_endpoint_profile,
+                                   endpoint_spiffe_id, bootstrap_bundle)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (trust_domain_id) DO UPDATE SET bundle_endpoint_url     = excluded.bundle_endpoint_url,
+                                            bundle_endpoint_profile = excluded.bundle_endpoint_profile,
+                                            endpoint_spiffe_id      = excluded.endpoint_spiffe_id,
+                                            bootstrap_bundle        = excluded.bootstrap_bundle,
+                                            last_refresh_at         = NULL,
+                                            last_refresh_error      = '',
+                                            updated_at              = datetime('now')
+RETURNING *;
+
+-- name: UpdateExternalTrustDomainRefresh :one
+UPDATE external_trust_domains
+SET last_refresh_at    = ?,
+    last_refresh_error = ?,
+    updated_at         = datetime('now')
+WHERE trust_domain_id = ?
+RETURNING *;
+
+-- name: FindExternalTrustDomainByTrustDomainID :one
+SELECT *
+FROM external_trust_domains
+WHERE trust_domain_id = ?;
+
+-- name: ListExternalTrustDomains :many
+SELECT *
+FROM external_trust_domains
+ORDER BY created_at;
+
+-- name: DeleteExternalTrustDomain :exec
+DELETE
+FROM external_trust_domains
+WHERE trust_domain_id = ?;
@@ -0,0 +1,44 @@
+-- name: CreateFederationGroup :one
+INSERT INTO federation_groups(id, name, description, default_consent)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateFederationGroup :one
+UPDATE federation_groups
+SET description     = ?,
+    default_consent = ?,
+    updated_at      = datetime('now')
+WHERE id = ?
+RETURNING *;
+
+-- name: FindFederationGroupByName :one
+SELECT *
+FROM federation_groups
+WHERE name = ?;
+
+-- name: ListFederationGroups :many
+SELECT *
+FROM federation_groups
+ORDER BY created_at, name;
+
+-- name: DeleteFederationGroup :exec
+DELETE
+FROM federation_groups
+WHERE id = ?;
+
+-- name: CreateFederationGroupMember :one
+INSERT INTO federation_group_members(id, federation_group_id, trust_domain_id)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: FindFederationGroupMembersByGroupID :many
+SELECT *
+FROM federation_group_members
+WHERE federation_group_id = ?
+ORDER BY created_at;
+
+-- name: DeleteFederationGroupMember :exec
+DELETE
+FROM federation_group_members
+WHERE federation_group_id = ?
+  AND trust_domain_id = ?;
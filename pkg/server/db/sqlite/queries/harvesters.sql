@@ -0,0 +1,33 @@
+-- name: UpsertHarvester :one
+INSERT INTO harvesters(id, trust_domain_id, instance_id, last_seen_at, source_address, version,
+                       spire_bundle_poll_interval, federated_bundles_poll_interval, token_expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (trust_domain_id, instance_id) DO UPDATE SET last_seen_at                    = excluded.last_seen_at,
+                                                         source_address                  = excluded.source_address,
+                                                         version                         = excluded.version,
+                                                         spire_bundle_poll_interval      = excluded.spire_bundle_poll_interval,
+                                                         federated_bundles_poll_interval = excluded.federated_bundles_poll_interval,
+                                                         token_expires_at                = excluded.token_expires_at,
+                                                         updated_at                      = datetime('now')
+RETURNING *;
+
+-- name: UpdateHarvesterBundleUpload :one
+UPDATE harvesters
+SET last_bundle_upload_at     = ?,
+    last_bundle_digest        = ?,
+    last_bundle_upload_status = ?,
+    updated_at                = datetime('now')
+WHERE trust_domain_id = ?
+  AND instance_id = ?
+RETURNING *;
+
+-- name: FindHarvestersByTrustDomainID :many
+SELECT *
+FROM harvesters
+WHERE trust_domain_id = ?
+ORDER BY instance_id;
+
+-- name: ListHarvesters :many
+SELECT *
+FROM harvesters
+ORDER BY created_at;
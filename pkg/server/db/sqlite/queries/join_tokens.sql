@@ -1,6 +1,6 @@
 -- name: CreateJoinToken :one
-INSERT INTO join_tokens(id, token, expires_at, trust_domain_id)
-VALUES (?, ?, ?, ?)
+INSERT INTO join_tokens(id, token_prefix, token_salt, token_hash, expires_at, trust_domain_id, max_uses, source_cidr)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateJoinToken :one
@@ -20,10 +20,16 @@ SELECT *
 FROM join_tokens
 WHERE id = ?;
 
--- name: FindJoinToken :one
+-- name: FindJoinTokenByPrefix :one
 SELECT *
 FROM join_tokens
-WHERE token = ?;
+WHERE token_prefix = ?;
+
+-- name: FindLegacyJoinTokenByHash :one
+SELECT *
+FROM join_tokens
+WHERE token_salt = x''
+  AND token_hash = ?;
 
 -- name: FindJoinTokensByTrustDomainID :many
 SELECT *
@@ -35,3 +41,13 @@ ORDER BY created_at DESC;
 SELECT *
 FROM join_tokens
 ORDER BY created_at DESC;
+
+-- name: RecordJoinTokenUse :one
+UPDATE join_tokens
+SET use_count  = use_count + 1,
+    used       = use_count + 1 >= max_uses,
+    updated_at = datetime('now')
+WHERE id = ?
+  AND NOT used
+  AND use_count < max_uses
+RETURNING *;
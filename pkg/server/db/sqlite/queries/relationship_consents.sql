@@ -0,0 +1,22 @@
+-- name: UpsertRelationshipConsent :one
+INSERT INTO relationship_consents(id, relationship_id, trust_domain_id, consent_status, statement, signature,
+                                  signing_certificate)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (relationship_id, trust_domain_id) DO UPDATE SET consent_status      = excluded.consent_status,
+                                                             statement           = excluded.statement,
+                                                             signature           = excluded.signature,
+                                                             signing_certificate = excluded.signing_certificate,
+                                                             updated_at          = datetime('now')
+RETURNING *;
+
+-- name: FindRelationshipConsentsByRelationshipID :many
+SELECT *
+FROM relationship_consents
+WHERE relationship_id = ?
+ORDER BY created_at;
+
+-- name: DeleteRelationshipConsent :exec
+DELETE
+FROM relationship_consents
+WHERE relationship_id = ?
+  AND trust_domain_id = ?;
@@ -1,12 +1,14 @@
 -- name: CreateRelationship :one
-INSERT INTO relationships(id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO relationships(id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, direction,
+                          federation_group_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateRelationship :one
 UPDATE relationships
 SET trust_domain_a_consent = ?,
     trust_domain_b_consent = ?,
+    federation_group_id    = ?,
     updated_at             = CURRENT_TIMESTAMP
 WHERE id = ?
 RETURNING *;
@@ -0,0 +1,16 @@
+-- name: UpsertSigningKey :one
+INSERT INTO signing_keys(id, private_key)
+VALUES (?, ?)
+ON CONFLICT (id) DO UPDATE SET private_key = excluded.private_key,
+                               updated_at  = datetime('now')
+RETURNING *;
+
+-- name: DeleteSigningKey :exec
+DELETE
+FROM signing_keys
+WHERE id = ?;
+
+-- name: ListSigningKeys :many
+SELECT *
+FROM signing_keys
+ORDER BY created_at;
@@ -1,12 +1,14 @@
 -- name: CreateTrustDomain :one
-INSERT INTO trust_domains(id, name, description)
-VALUES (?, ?, ?)
+INSERT INTO trust_domains(id, name, description, harvester_spiffe_id, onboarding_bundle)
+VALUES (?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = ?,
-    updated_at  = datetime('now')
+SET description         = ?,
+    harvester_spiffe_id = ?,
+    onboarding_bundle   = ?,
+    updated_at          = datetime('now')
 WHERE id = ?
 RETURNING *;
 
@@ -0,0 +1,9 @@
+-- name: CreateWebhookDeadLetter :one
+INSERT INTO webhook_dead_letters(id, webhook, url, event_id, event_type, payload, attempts, last_error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: ListWebhookDeadLetters :many
+SELECT *
+FROM webhook_dead_letters
+ORDER BY created_at DESC;
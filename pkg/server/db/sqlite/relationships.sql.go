@@ -7,13 +7,15 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
 const createRelationship = `-- name: CreateRelationship :one
-INSERT INTO relationships(id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+INSERT INTO relationships(id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, direction,
+                          federation_group_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 `
 
 type CreateRelationshipParams struct {
@@ -22,6 +24,8 @@ type CreateRelationshipParams struct {
 	TrustDomainBID      string
 	TrustDomainAConsent string
 	TrustDomainBConsent string
+	Direction           string
+	FederationGroupID   sql.NullString
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
@@ -33,6 +37,8 @@ func (q *Queries) CreateRelationship(ctx context.Context, arg CreateRelationship
 		arg.TrustDomainBID,
 		arg.TrustDomainAConsent,
 		arg.TrustDomainBConsent,
+		arg.Direction,
+		arg.FederationGroupID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -45,6 +51,8 @@ func (q *Queries) CreateRelationship(ctx context.Context, arg CreateRelationship
 		&i.TrustDomainBConsent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Direction,
+		&i.FederationGroupID,
 	)
 	return i, err
 }
@@ -61,7 +69,7 @@ func (q *Queries) DeleteRelationship(ctx context.Context, id string) error {
 }
 
 const findRelationshipByID = `-- name: FindRelationshipByID :one
-SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 FROM relationships
 WHERE id = ?
 `
@@ -77,12 +85,14 @@ func (q *Queries) FindRelationshipByID(ctx context.Context, id string) (Relation
 		&i.TrustDomainBConsent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Direction,
+		&i.FederationGroupID,
 	)
 	return i, err
 }
 
 const findRelationshipsByTrustDomainID = `-- name: FindRelationshipsByTrustDomainID :many
-SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+SELECT id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 FROM relationships
 WHERE trust_domain_a_id = ?
    OR trust_domain_b_id = ?
@@ -110,6 +120,8 @@ func (q *Queries) FindRelationshipsByTrustDomainID(ctx context.Context, arg Find
 			&i.TrustDomainBConsent,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Direction,
+			&i.FederationGroupID,
 		); err != nil {
 			return nil, err
 		}
@@ -128,19 +140,26 @@ const updateRelationship = `-- name: UpdateRelationship :one
 UPDATE relationships
 SET trust_domain_a_consent = ?,
     trust_domain_b_consent = ?,
+    federation_group_id    = ?,
     updated_at             = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at
+RETURNING id, trust_domain_a_id, trust_domain_b_id, trust_domain_a_consent, trust_domain_b_consent, created_at, updated_at, direction, federation_group_id
 `
 
 type UpdateRelationshipParams struct {
 	TrustDomainAConsent string
 	TrustDomainBConsent string
+	FederationGroupID   sql.NullString
 	ID                  string
 }
 
 func (q *Queries) UpdateRelationship(ctx context.Context, arg UpdateRelationshipParams) (Relationship, error) {
-	row := q.queryRow(ctx, q.updateRelationshipStmt, updateRelationship, arg.TrustDomainAConsent, arg.TrustDomainBConsent, arg.ID)
+	row := q.queryRow(ctx, q.updateRelationshipStmt, updateRelationship,
+		arg.TrustDomainAConsent,
+		arg.TrustDomainBConsent,
+		arg.FederationGroupID,
+		arg.ID,
+	)
 	var i Relationship
 	err := row.Scan(
 		&i.ID,
@@ -150,6 +169,8 @@ func (q *Queries) UpdateRelationship(ctx context.Context, arg UpdateRelationship
 		&i.TrustDomainBConsent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Direction,
+		&i.FederationGroupID,
 	)
 	return i, err
 }
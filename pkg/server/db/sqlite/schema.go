@@ -1,12 +1,14 @@
 package sqlite
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"embed"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/sqlite"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/mattn/go-sqlite3"
 )
 
 //go:embed migrations/*.sql
@@ -16,10 +18,25 @@ var fs embed.FS
 // This is used to ensure that the app is compatible with the database schema.
 // When a new migration is created, this version should be updated in order to force
 // the migrations to run when starting up the app.
-const currentDBVersion = 1
+const currentDBVersion = 16
 
 const scheme = "sqlite3"
 
+// driverName is the SQLite driver extended with the functions used by the migrations.
+const driverName = "galadriel_sqlite3"
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			// sha256 hashes the join tokens stored in plaintext before migration 14, as postgres' sha256 does
+			return conn.RegisterFunc("sha256", func(data []byte) []byte {
+				sum := sha256.Sum256(data)
+				return sum[:]
+			}, true)
+		},
+	})
+}
+
 func validateAndMigrateSchema(db *sql.DB) error {
 
 	sourceInstance, err := iofs.New(fs, "migrations")
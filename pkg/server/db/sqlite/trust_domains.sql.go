@@ -11,19 +11,27 @@ import (
 )
 
 const createTrustDomain = `-- name: CreateTrustDomain :one
-INSERT INTO trust_domains(id, name, description)
-VALUES (?, ?, ?)
-RETURNING id, name, description, created_at, updated_at
+INSERT INTO trust_domains(id, name, description, harvester_spiffe_id, onboarding_bundle)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 `
 
 type CreateTrustDomainParams struct {
-	ID          string
-	Name        string
-	Description sql.NullString
+	ID                string
+	Name              string
+	Description       sql.NullString
+	HarvesterSpiffeID string
+	OnboardingBundle  []byte
 }
 
 func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainParams) (TrustDomain, error) {
-	row := q.queryRow(ctx, q.createTrustDomainStmt, createTrustDomain, arg.ID, arg.Name, arg.Description)
+	row := q.queryRow(ctx, q.createTrustDomainStmt, createTrustDomain,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.HarvesterSpiffeID,
+		arg.OnboardingBundle,
+	)
 	var i TrustDomain
 	err := row.Scan(
 		&i.ID,
@@ -31,6 +39,8 @@ func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
@@ -47,7 +57,7 @@ func (q *Queries) DeleteTrustDomain(ctx context.Context, id string) error {
 }
 
 const findTrustDomainByID = `-- name: FindTrustDomainByID :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 FROM trust_domains
 WHERE id = ?
 `
@@ -61,12 +71,14 @@ func (q *Queries) FindTrustDomainByID(ctx context.Context, id string) (TrustDoma
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
 
 const findTrustDomainByName = `-- name: FindTrustDomainByName :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 FROM trust_domains
 WHERE name = ?
 `
@@ -80,25 +92,36 @@ func (q *Queries) FindTrustDomainByName(ctx context.Context, name string) (Trust
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
 
 const updateTrustDomain = `-- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = ?,
-    updated_at  = datetime('now')
+SET description         = ?,
+    harvester_spiffe_id = ?,
+    onboarding_bundle   = ?,
+    updated_at          = datetime('now')
 WHERE id = ?
-RETURNING id, name, description, created_at, updated_at
+RETURNING id, name, description, created_at, updated_at, harvester_spiffe_id, onboarding_bundle
 `
 
 type UpdateTrustDomainParams struct {
-	Description sql.NullString
-	ID          string
+	Description       sql.NullString
+	HarvesterSpiffeID string
+	OnboardingBundle  []byte
+	ID                string
 }
 
 func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainParams) (TrustDomain, error) {
-	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain, arg.Description, arg.ID)
+	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain,
+		arg.Description,
+		arg.HarvesterSpiffeID,
+		arg.OnboardingBundle,
+		arg.ID,
+	)
 	var i TrustDomain
 	err := row.Scan(
 		&i.ID,
@@ -106,6 +129,8 @@ func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HarvesterSpiffeID,
+		&i.OnboardingBundle,
 	)
 	return i, err
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/jointoken"
 	"github.com/google/uuid"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
@@ -126,6 +127,41 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		postgresExpectedErr := "duplicate key value violates unique constraint"
 		assertErrorString(t, err, sqliteExpectedErr, postgresExpectedErr)
 	})
+	t.Run("Test TrustDomain SVID Onboarding Settings", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		harvesterID := spiffeid.RequireFromPath(spiffeTD1, "/galadriel-harvester")
+		bundle := []byte(`{"keys":[]}`)
+
+		// Create trust domain with a pinned harvester SPIFFE ID and an onboarding bundle
+		td, err := ds.CreateOrUpdateTrustDomain(ctx, &entity.TrustDomain{
+			Name:              spiffeTD1,
+			HarvesterSPIFFEID: harvesterID,
+			OnboardingBundle:  bundle,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, harvesterID, td.HarvesterSPIFFEID)
+		assert.Equal(t, bundle, td.OnboardingBundle)
+		assert.True(t, td.SVIDOnboardingEnabled())
+
+		list, err := ds.ListTrustDomains(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.TrustDomain{td}, list)
+
+		// Clear the settings
+		td.HarvesterSPIFFEID = spiffeid.ID{}
+		td.OnboardingBundle = nil
+		_, err = ds.CreateOrUpdateTrustDomain(ctx, td)
+		require.NoError(t, err)
+
+		stored, err := ds.FindTrustDomainByName(ctx, spiffeTD1)
+		require.NoError(t, err)
+		assert.True(t, stored.HarvesterSPIFFEID.IsZero())
+		assert.Empty(t, stored.OnboardingBundle)
+		assert.False(t, stored.SVIDOnboardingEnabled())
+	})
 	t.Run("Test CRUD Relationships", func(t *testing.T) {
 		t.Parallel()
 		ds := newDS()
@@ -162,11 +198,13 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.Equal(t, req1.TrustDomainBID, relationship1.TrustDomainBID)
 		assert.Equal(t, entity.ConsentStatusPending, relationship1.TrustDomainAConsent)
 		assert.Equal(t, entity.ConsentStatusPending, relationship1.TrustDomainBConsent)
+		assert.Equal(t, entity.RelationshipBidirectional, relationship1.Direction)
 
-		// Create relationship TrustDomain2 -- TrustDomain3
+		// Create relationship TrustDomain2 -> TrustDomain3
 		req2 := &entity.Relationship{
 			TrustDomainAID: td2.ID.UUID,
 			TrustDomainBID: td3.ID.UUID,
+			Direction:      entity.RelationshipATrustsB,
 		}
 
 		relationship2, err := ds.CreateOrUpdateRelationship(ctx, req2)
@@ -178,6 +216,7 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.Equal(t, req2.TrustDomainBID, relationship2.TrustDomainBID)
 		assert.Equal(t, entity.ConsentStatusPending, relationship2.TrustDomainAConsent)
 		assert.Equal(t, entity.ConsentStatusPending, relationship2.TrustDomainBConsent)
+		assert.Equal(t, entity.RelationshipATrustsB, relationship2.Direction)
 
 		// Find relationship by ID
 		stored, err := ds.FindRelationshipByID(ctx, relationship1.ID.UUID)
@@ -299,6 +338,7 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 			Signature:          []byte{4, 2},
 			SigningCertificate: []byte{50, 60},
 			TrustDomainID:      td1.ID.UUID,
+			VerificationStatus: entity.BundleVerificationSkipped,
 		}
 
 		b1, err := ds.CreateOrUpdateBundle(ctx, req1)
@@ -309,6 +349,9 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.Equal(t, req1.Signature, b1.Signature)
 		assert.Equal(t, req1.SigningCertificate, b1.SigningCertificate)
 		assert.Equal(t, req1.TrustDomainID, b1.TrustDomainID)
+		assert.Equal(t, req1.VerificationStatus, b1.VerificationStatus)
+		assert.Empty(t, b1.VerifiedBy)
+		assert.False(t, b1.AdminManaged)
 
 		// Look up bundle stored in DB and compare
 		stored, err := ds.FindBundleByID(ctx, b1.ID.UUID)
@@ -352,6 +395,9 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		b1.Digest = []byte("test-digest-3")
 		b1.Signature = []byte{'f', 'g', 'h'}
 		b1.SigningCertificate = []byte{'f', 'g', 'h'}
+		b1.VerificationStatus = entity.BundleVerificationVerified
+		b1.VerifiedBy = "disk"
+		b1.AdminManaged = true
 
 		updated, err := ds.CreateOrUpdateBundle(ctx, b1)
 		assert.NoError(t, err)
@@ -361,6 +407,9 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.Equal(t, b1.Signature, updated.Signature)
 		assert.Equal(t, b1.SigningCertificate, updated.SigningCertificate)
 		assert.Equal(t, b1.TrustDomainID, updated.TrustDomainID)
+		assert.Equal(t, entity.BundleVerificationVerified, updated.VerificationStatus)
+		assert.Equal(t, "disk", updated.VerifiedBy)
+		assert.True(t, updated.AdminManaged)
 
 		// Look up bundle stored in DB and compare
 		stored, err = ds.FindBundleByID(ctx, b1.ID.UUID)
@@ -392,6 +441,55 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.NoError(t, err)
 		require.Nil(t, stored)
 	})
+	t.Run("Test Create Or Update Bundle If Unchanged", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		td1, err := ds.CreateOrUpdateTrustDomain(ctx, &entity.TrustDomain{Name: spiffeTD1})
+		require.NoError(t, err)
+
+		// Create the bundle of the trust domain
+		b1, err := ds.CreateOrUpdateBundleIfUnchanged(ctx, &entity.Bundle{
+			Data:          []byte{1, 2, 3},
+			Digest:        []byte("test-digest-1"),
+			TrustDomainID: td1.ID.UUID,
+		}, nil)
+		require.NoError(t, err)
+		require.NotNil(t, b1)
+		assert.True(t, b1.ID.Valid)
+
+		// Another bundle is not created when the trust domain already has one
+		b2, err := ds.CreateOrUpdateBundleIfUnchanged(ctx, &entity.Bundle{
+			Data:          []byte{4, 5, 6},
+			Digest:        []byte("test-digest-2"),
+			TrustDomainID: td1.ID.UUID,
+		}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, b2)
+
+		// The bundle is not updated when the stored bundle has another digest
+		b1.Data = []byte{7, 8, 9}
+		b1.Digest = []byte("test-digest-3")
+		updated, err := ds.CreateOrUpdateBundleIfUnchanged(ctx, b1, []byte("test-digest-2"))
+		require.NoError(t, err)
+		assert.Nil(t, updated)
+
+		stored, err := ds.FindBundleByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, stored.Data)
+
+		// The bundle is updated when the stored bundle still has the previous digest
+		updated, err = ds.CreateOrUpdateBundleIfUnchanged(ctx, b1, []byte("test-digest-1"))
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.Equal(t, b1.ID, updated.ID)
+
+		stored, err = ds.FindBundleByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{7, 8, 9}, stored.Data)
+		assert.Equal(t, []byte("test-digest-3"), stored.Digest)
+	})
 	t.Run("Test Bundle Unique TrustDomain Constraint", func(t *testing.T) {
 		t.Parallel()
 		ds := newDS()
@@ -457,16 +555,14 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		expiry := time.Now().In(loc).Add(1 * time.Hour)
 
 		// Create first join_token -> trustDomain_1
-		req1 := &entity.JoinToken{
-			Token:         uuid.NewString(),
-			ExpiresAt:     expiry,
-			TrustDomainID: td1.ID.UUID,
-		}
+		req1 := newJoinTokenRequest(t, td1.ID.UUID, expiry)
 
 		token1, err := ds.CreateJoinToken(ctx, req1)
 		assert.NoError(t, err)
 		assert.NotNil(t, token1)
-		assert.Equal(t, req1.Token, token1.Token)
+		assert.Equal(t, req1.TokenPrefix, token1.TokenPrefix)
+		assert.Equal(t, req1.TokenSalt, token1.TokenSalt)
+		assert.Equal(t, req1.TokenHash, token1.TokenHash)
 		assertEqualDate(t, req1.ExpiresAt, token1.ExpiresAt.In(loc))
 		require.False(t, token1.Used)
 		assert.Equal(t, req1.TrustDomainID, token1.TrustDomainID)
@@ -477,16 +573,14 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.Equal(t, token1, stored)
 
 		// Create second join_token -> trustDomain_2
-		req2 := &entity.JoinToken{
-			Token:         uuid.NewString(),
-			ExpiresAt:     expiry,
-			TrustDomainID: td2.ID.UUID,
-		}
+		req2 := newJoinTokenRequest(t, td2.ID.UUID, expiry)
 
 		token2, err := ds.CreateJoinToken(ctx, req2)
 		assert.NoError(t, err)
 		assert.NotNil(t, token1)
-		assert.Equal(t, req2.Token, token2.Token)
+		assert.Equal(t, req2.TokenPrefix, token2.TokenPrefix)
+		assert.Equal(t, req2.TokenSalt, token2.TokenSalt)
+		assert.Equal(t, req2.TokenHash, token2.TokenHash)
 		assert.Equal(t, req1.TrustDomainID, token1.TrustDomainID)
 		require.False(t, token2.Used)
 
@@ -499,11 +593,7 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.Equal(t, token2, stored)
 
 		// Create second join_token -> trustDomain_2
-		req3 := &entity.JoinToken{
-			Token:         uuid.NewString(),
-			ExpiresAt:     expiry,
-			TrustDomainID: td2.ID.UUID,
-		}
+		req3 := newJoinTokenRequest(t, td2.ID.UUID, expiry)
 
 		token3, err := ds.CreateJoinToken(ctx, req3)
 		assert.NoError(t, err)
@@ -521,16 +611,16 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		require.Contains(t, tokens, token2)
 		require.Contains(t, tokens, token3)
 
-		// Look up join token by token string
-		stored, err = ds.FindJoinToken(ctx, token1.Token)
+		// Look up join token by the prefix of the token
+		stored, err = ds.FindJoinTokenByPrefix(ctx, token1.TokenPrefix)
 		assert.NoError(t, err)
 		assert.Equal(t, token1, stored)
 
-		stored, err = ds.FindJoinToken(ctx, token2.Token)
+		stored, err = ds.FindJoinTokenByPrefix(ctx, token2.TokenPrefix)
 		assert.NoError(t, err)
 		assert.Equal(t, token2, stored)
 
-		stored, err = ds.FindJoinToken(ctx, token3.Token)
+		stored, err = ds.FindJoinTokenByPrefix(ctx, token3.TokenPrefix)
 		assert.NoError(t, err)
 		assert.Equal(t, token3, stored)
 
@@ -553,6 +643,75 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.Equal(t, true, stored.Used)
 		assert.Equal(t, updated.UpdatedAt, stored.UpdatedAt)
 
+		// Tokens are single-use by default
+		assert.Equal(t, 1, token2.MaxUses)
+		assert.Equal(t, 0, token2.UseCount)
+		assert.Empty(t, token2.SourceCIDR)
+
+		recorded, err := ds.RecordJoinTokenUse(ctx, token2.ID.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, recorded)
+		assert.Equal(t, 1, recorded.UseCount)
+		assert.True(t, recorded.Used)
+
+		recorded, err = ds.RecordJoinTokenUse(ctx, token2.ID.UUID)
+		require.NoError(t, err)
+		require.Nil(t, recorded)
+
+		// Multi-use token bound to a network
+		multiUseReq := newJoinTokenRequest(t, td1.ID.UUID, expiry)
+		multiUseReq.MaxUses = 2
+		multiUseReq.SourceCIDR = "10.0.0.0/8"
+		multiUse, err := ds.CreateJoinToken(ctx, multiUseReq)
+		require.NoError(t, err)
+		assert.Equal(t, 2, multiUse.MaxUses)
+		assert.Equal(t, "10.0.0.0/8", multiUse.SourceCIDR)
+
+		recorded, err = ds.RecordJoinTokenUse(ctx, multiUse.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, recorded.UseCount)
+		assert.False(t, recorded.Used)
+
+		recorded, err = ds.RecordJoinTokenUse(ctx, multiUse.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, recorded.UseCount)
+		assert.True(t, recorded.Used)
+
+		recorded, err = ds.RecordJoinTokenUse(ctx, multiUse.ID.UUID)
+		require.NoError(t, err)
+		require.Nil(t, recorded)
+
+		err = ds.DeleteJoinToken(ctx, multiUse.ID.UUID)
+		require.NoError(t, err)
+
+		// Legacy tokens have no salt and are looked up by the hash of the whole token
+		legacyToken := uuid.NewString()
+		legacy, err := ds.CreateJoinToken(ctx, &entity.JoinToken{
+			TokenPrefix:   uuid.NewString(),
+			TokenSalt:     []byte{},
+			TokenHash:     jointoken.LegacyHash(legacyToken),
+			ExpiresAt:     expiry,
+			TrustDomainID: td1.ID.UUID,
+		})
+		require.NoError(t, err)
+
+		stored, err = ds.FindLegacyJoinTokenByHash(ctx, jointoken.LegacyHash(legacyToken))
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, legacy.ID, stored.ID)
+
+		stored, err = ds.FindLegacyJoinTokenByHash(ctx, jointoken.LegacyHash(uuid.NewString()))
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		// Salted tokens are not legacy tokens
+		stored, err = ds.FindLegacyJoinTokenByHash(ctx, token2.TokenHash)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		err = ds.DeleteJoinToken(ctx, legacy.ID.UUID)
+		require.NoError(t, err)
+
 		// Delete join tokens
 		err = ds.DeleteJoinToken(ctx, token1.ID.UUID)
 		assert.NoError(t, err)
@@ -576,6 +735,609 @@ func runTests(t *testing.T, ctx context.Context, newDS func() db.Datastore) {
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(tokens))
 	})
+
+	t.Run("Test CRUD RelationshipConsents", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+
+		relationship, err := ds.CreateOrUpdateRelationship(ctx, &entity.Relationship{
+			TrustDomainAID: td1.ID.UUID,
+			TrustDomainBID: td2.ID.UUID,
+		})
+		require.NoError(t, err)
+
+		req1 := &entity.RelationshipConsent{
+			RelationshipID:     relationship.ID.UUID,
+			TrustDomainID:      td1.ID.UUID,
+			ConsentStatus:      entity.ConsentStatusApproved,
+			Statement:          []byte(`{"consent_status":"approved"}`),
+			Signature:          []byte("signature-1"),
+			SigningCertificate: []byte("certificate-1"),
+		}
+		consent1, err := ds.CreateOrUpdateRelationshipConsent(ctx, req1)
+		require.NoError(t, err)
+		require.True(t, consent1.ID.Valid)
+		assert.Equal(t, req1.RelationshipID, consent1.RelationshipID)
+		assert.Equal(t, req1.TrustDomainID, consent1.TrustDomainID)
+		assert.Equal(t, req1.ConsentStatus, consent1.ConsentStatus)
+		assert.Equal(t, req1.Statement, consent1.Statement)
+		assert.Equal(t, req1.Signature, consent1.Signature)
+		assert.Equal(t, req1.SigningCertificate, consent1.SigningCertificate)
+
+		req2 := &entity.RelationshipConsent{
+			RelationshipID:     relationship.ID.UUID,
+			TrustDomainID:      td2.ID.UUID,
+			ConsentStatus:      entity.ConsentStatusApproved,
+			Statement:          []byte(`{"consent_status":"approved"}`),
+			Signature:          []byte("signature-2"),
+			SigningCertificate: []byte("certificate-2"),
+		}
+		_, err = ds.CreateOrUpdateRelationshipConsent(ctx, req2)
+		require.NoError(t, err)
+
+		// Update the consent of the first trust domain
+		req1.ConsentStatus = entity.ConsentStatusDenied
+		req1.Statement = []byte(`{"consent_status":"denied"}`)
+		req1.Signature = []byte("signature-3")
+		updated, err := ds.CreateOrUpdateRelationshipConsent(ctx, req1)
+		require.NoError(t, err)
+		assert.Equal(t, consent1.ID, updated.ID)
+		assert.Equal(t, entity.ConsentStatusDenied, updated.ConsentStatus)
+		assert.Equal(t, req1.Statement, updated.Statement)
+		assert.Equal(t, req1.Signature, updated.Signature)
+
+		consents, err := ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, consents, 2)
+
+		// Delete the consent of the second trust domain
+		err = ds.DeleteRelationshipConsent(ctx, relationship.ID.UUID, td2.ID.UUID)
+		require.NoError(t, err)
+
+		consents, err = ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, consents, 1)
+		assert.Equal(t, td1.ID.UUID, consents[0].TrustDomainID)
+
+		// Consents are deleted along with the relationship
+		err = ds.DeleteRelationship(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+
+		consents, err = ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, consents)
+	})
+
+	t.Run("Test CRUD WebhookDeadLetters", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		req1 := &entity.WebhookDeadLetter{
+			Webhook:   "ops",
+			URL:       "https://hooks.example.org/galadriel",
+			EventID:   uuid.New(),
+			EventType: "bundle.updated",
+			Payload:   []byte(`{"type":"bundle.updated"}`),
+			Attempts:  5,
+			LastError: "unexpected status code 500",
+		}
+		dl1, err := ds.CreateWebhookDeadLetter(ctx, req1)
+		require.NoError(t, err)
+		require.True(t, dl1.ID.Valid)
+		assert.Equal(t, req1.Webhook, dl1.Webhook)
+		assert.Equal(t, req1.URL, dl1.URL)
+		assert.Equal(t, req1.EventID, dl1.EventID)
+		assert.Equal(t, req1.EventType, dl1.EventType)
+		assert.Equal(t, req1.Payload, dl1.Payload)
+		assert.Equal(t, req1.Attempts, dl1.Attempts)
+		assert.Equal(t, req1.LastError, dl1.LastError)
+		assert.NotNil(t, dl1.CreatedAt)
+
+		req2 := &entity.WebhookDeadLetter{
+			Webhook:   "audit",
+			URL:       "https://audit.example.org/hook",
+			EventID:   uuid.New(),
+			EventType: "relationship.created",
+			Payload:   []byte(`{"type":"relationship.created"}`),
+			Attempts:  3,
+			LastError: "connection refused",
+		}
+		dl2, err := ds.CreateWebhookDeadLetter(ctx, req2)
+		require.NoError(t, err)
+
+		deadLetters, err := ds.ListWebhookDeadLetters(ctx)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 2)
+
+		ids := []uuid.UUID{deadLetters[0].ID.UUID, deadLetters[1].ID.UUID}
+		assert.Contains(t, ids, dl1.ID.UUID)
+		assert.Contains(t, ids, dl2.ID.UUID)
+	})
+
+	t.Run("Test CRUD BundleSyncStates", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+
+		reported := time.Now().UTC().Truncate(time.Second)
+		req1 := &entity.BundleSyncState{
+			TrustDomainID:        td1.ID.UUID,
+			HarvesterInstanceID:  "spire-1",
+			FederatedTrustDomain: spiffeTD2,
+			Digest:               []byte("digest-1"),
+			DigestUpdatedAt:      reported,
+			ReportedAt:           reported,
+		}
+		state1, err := ds.CreateOrUpdateBundleSyncState(ctx, req1)
+		require.NoError(t, err)
+		require.True(t, state1.ID.Valid)
+		assert.Equal(t, req1.TrustDomainID, state1.TrustDomainID)
+		assert.Equal(t, req1.HarvesterInstanceID, state1.HarvesterInstanceID)
+		assert.Equal(t, req1.FederatedTrustDomain, state1.FederatedTrustDomain)
+		assert.Equal(t, req1.Digest, state1.Digest)
+		assertEqualDate(t, reported, state1.DigestUpdatedAt.UTC())
+
+		req2 := &entity.BundleSyncState{
+			TrustDomainID:        td1.ID.UUID,
+			HarvesterInstanceID:  "spire-1",
+			FederatedTrustDomain: spiffeid.RequireTrustDomainFromString("unknown.org"),
+			Digest:               []byte("digest-2"),
+			DigestUpdatedAt:      reported,
+			ReportedAt:           reported,
+		}
+		_, err = ds.CreateOrUpdateBundleSyncState(ctx, req2)
+		require.NoError(t, err)
+
+		req3 := &entity.BundleSyncState{
+			TrustDomainID:        td2.ID.UUID,
+			HarvesterInstanceID:  "spire-1",
+			FederatedTrustDomain: spiffeTD1,
+			Digest:               []byte("digest-3"),
+			DigestUpdatedAt:      reported,
+			ReportedAt:           reported,
+		}
+		_, err = ds.CreateOrUpdateBundleSyncState(ctx, req3)
+		require.NoError(t, err)
+
+		// Another harvester instance of the same trust domain has its own state of the same bundle
+		req4 := &entity.BundleSyncState{
+			TrustDomainID:        td1.ID.UUID,
+			HarvesterInstanceID:  "spire-2",
+			FederatedTrustDomain: spiffeTD2,
+			Digest:               []byte("digest-5"),
+			DigestUpdatedAt:      reported,
+			ReportedAt:           reported,
+		}
+		state4, err := ds.CreateOrUpdateBundleSyncState(ctx, req4)
+		require.NoError(t, err)
+		assert.NotEqual(t, state1.ID, state4.ID)
+
+		// Reporting the same digest again keeps the time the digest was first reported
+		later := reported.Add(time.Minute)
+		req1.DigestUpdatedAt = later
+		req1.ReportedAt = later
+		updated, err := ds.CreateOrUpdateBundleSyncState(ctx, req1)
+		require.NoError(t, err)
+		assert.Equal(t, state1.ID, updated.ID)
+		assertEqualDate(t, reported, updated.DigestUpdatedAt.UTC())
+		assertEqualDate(t, later, updated.ReportedAt.UTC())
+
+		// Reporting a new digest updates the time the digest was first reported
+		req1.Digest = []byte("digest-4")
+		updated, err = ds.CreateOrUpdateBundleSyncState(ctx, req1)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("digest-4"), updated.Digest)
+		assertEqualDate(t, later, updated.DigestUpdatedAt.UTC())
+
+		states, err := ds.FindBundleSyncStatesByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, states, 3)
+
+		states, err = ds.ListBundleSyncStates(ctx)
+		require.NoError(t, err)
+		require.Len(t, states, 4)
+
+		// States not reported on the last sync of the instance are deleted, the ones of other instances are kept
+		err = ds.DeleteStaleBundleSyncStates(ctx, td1.ID.UUID, "spire-1", later)
+		require.NoError(t, err)
+
+		states, err = ds.FindBundleSyncStatesByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, states, 2)
+		assert.Equal(t, spiffeTD2, states[0].FederatedTrustDomain)
+		assert.Equal(t, "spire-1", states[0].HarvesterInstanceID)
+		assert.Equal(t, []byte("digest-4"), states[0].Digest)
+		assert.Equal(t, spiffeTD2, states[1].FederatedTrustDomain)
+		assert.Equal(t, "spire-2", states[1].HarvesterInstanceID)
+		assert.Equal(t, []byte("digest-5"), states[1].Digest)
+
+		// States are deleted along with the trust domain
+		err = ds.DeleteTrustDomain(ctx, td2.ID.UUID)
+		require.NoError(t, err)
+
+		states, err = ds.FindBundleSyncStatesByTrustDomainID(ctx, td2.ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, states)
+	})
+
+	t.Run("Test CRUD Harvesters", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+
+		// Harvesters not found
+		stored, err := ds.FindHarvestersByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		require.Empty(t, stored)
+
+		now := time.Now().UTC()
+		req1 := &entity.Harvester{
+			TrustDomainID:                td1.ID.UUID,
+			InstanceID:                   "spire-server-1",
+			LastSeenAt:                   now,
+			SourceAddress:                "10.0.0.1:4321",
+			Version:                      "0.1.0",
+			SpireBundlePollInterval:      10 * time.Second,
+			FederatedBundlesPollInterval: 30 * time.Second,
+			TokenExpiresAt:               now.Add(time.Hour),
+		}
+		harvester1, err := ds.CreateOrUpdateHarvester(ctx, req1)
+		require.NoError(t, err)
+		require.True(t, harvester1.ID.Valid)
+		assert.Equal(t, req1.TrustDomainID, harvester1.TrustDomainID)
+		assert.Equal(t, req1.InstanceID, harvester1.InstanceID)
+		assert.Equal(t, req1.SourceAddress, harvester1.SourceAddress)
+		assert.Equal(t, req1.Version, harvester1.Version)
+		assert.Equal(t, req1.SpireBundlePollInterval, harvester1.SpireBundlePollInterval)
+		assert.Equal(t, req1.FederatedBundlesPollInterval, harvester1.FederatedBundlesPollInterval)
+		assertEqualDate(t, req1.LastSeenAt, harvester1.LastSeenAt.UTC())
+		assertEqualDate(t, req1.TokenExpiresAt, harvester1.TokenExpiresAt.UTC())
+		assert.True(t, harvester1.LastBundleUploadAt.IsZero())
+		assert.Empty(t, harvester1.LastBundleUploadStatus)
+
+		// Second instance of the first trust domain
+		req2 := &entity.Harvester{
+			TrustDomainID:  td1.ID.UUID,
+			InstanceID:     "spire-server-2",
+			LastSeenAt:     now,
+			SourceAddress:  "10.0.0.2:4321",
+			TokenExpiresAt: now.Add(time.Hour),
+		}
+		harvester2, err := ds.CreateOrUpdateHarvester(ctx, req2)
+		require.NoError(t, err)
+		assert.NotEqual(t, harvester1.ID, harvester2.ID)
+
+		req3 := &entity.Harvester{
+			TrustDomainID:  td2.ID.UUID,
+			InstanceID:     "spire-server-1",
+			LastSeenAt:     now,
+			SourceAddress:  "10.0.0.3:4321",
+			TokenExpiresAt: now.Add(time.Hour),
+		}
+		_, err = ds.CreateOrUpdateHarvester(ctx, req3)
+		require.NoError(t, err)
+
+		// Record a bundle upload of the first instance
+		uploaded, err := ds.UpdateHarvesterBundleUpload(ctx, &entity.Harvester{
+			TrustDomainID:          td1.ID.UUID,
+			InstanceID:             "spire-server-1",
+			LastBundleUploadAt:     now,
+			LastBundleDigest:       []byte("digest"),
+			LastBundleUploadStatus: entity.BundleUploadAccepted,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, harvester1.ID, uploaded.ID)
+		assert.Equal(t, []byte("digest"), uploaded.LastBundleDigest)
+		assert.Equal(t, entity.BundleUploadAccepted, uploaded.LastBundleUploadStatus)
+		assertEqualDate(t, now, uploaded.LastBundleUploadAt.UTC())
+
+		// Recording the upload of an unknown instance fails
+		_, err = ds.UpdateHarvesterBundleUpload(ctx, &entity.Harvester{TrustDomainID: td1.ID.UUID, InstanceID: "unknown"})
+		require.Error(t, err)
+
+		// Update the first instance, keeping its bundle upload
+		req1.LastSeenAt = now.Add(time.Minute)
+		req1.SourceAddress = "10.0.0.4:4321"
+		req1.Version = "0.2.0"
+		updated, err := ds.CreateOrUpdateHarvester(ctx, req1)
+		require.NoError(t, err)
+		assert.Equal(t, harvester1.ID, updated.ID)
+
+		stored, err = ds.FindHarvestersByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		assert.Equal(t, "spire-server-1", stored[0].InstanceID)
+		assert.Equal(t, "10.0.0.4:4321", stored[0].SourceAddress)
+		assert.Equal(t, "0.2.0", stored[0].Version)
+		assertEqualDate(t, req1.LastSeenAt, stored[0].LastSeenAt.UTC())
+		assert.Equal(t, entity.BundleUploadAccepted, stored[0].LastBundleUploadStatus)
+		assert.Equal(t, "spire-server-2", stored[1].InstanceID)
+
+		harvesters, err := ds.ListHarvesters(ctx)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 3)
+
+		// Harvesters are deleted along with the trust domain
+		err = ds.DeleteTrustDomain(ctx, td2.ID.UUID)
+		require.NoError(t, err)
+
+		harvesters, err = ds.ListHarvesters(ctx)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 2)
+		for _, h := range harvesters {
+			assert.Equal(t, td1.ID.UUID, h.TrustDomainID)
+		}
+	})
+
+	t.Run("Test CRUD SigningKeys", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		signingKeys, err := ds.ListSigningKeys(ctx)
+		require.NoError(t, err)
+		require.Empty(t, signingKeys)
+
+		req1 := &entity.SigningKey{
+			ID:         "jwt-signing-key",
+			PrivateKey: []byte("private key 1"),
+		}
+		key1, err := ds.CreateOrUpdateSigningKey(ctx, req1)
+		require.NoError(t, err)
+		assert.Equal(t, req1.ID, key1.ID)
+		assert.Equal(t, req1.PrivateKey, key1.PrivateKey)
+		assert.NotNil(t, key1.CreatedAt)
+
+		req2 := &entity.SigningKey{
+			ID:         "other-key",
+			PrivateKey: []byte("private key 2"),
+		}
+		_, err = ds.CreateOrUpdateSigningKey(ctx, req2)
+		require.NoError(t, err)
+
+		// Overwrite the first key
+		req1.PrivateKey = []byte("private key 3")
+		key1, err = ds.CreateOrUpdateSigningKey(ctx, req1)
+		require.NoError(t, err)
+		assert.Equal(t, req1.PrivateKey, key1.PrivateKey)
+
+		signingKeys, err = ds.ListSigningKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, signingKeys, 2)
+
+		stored := map[string][]byte{}
+		for _, k := range signingKeys {
+			stored[k.ID] = k.PrivateKey
+		}
+		assert.Equal(t, []byte("private key 3"), stored[req1.ID])
+		assert.Equal(t, []byte("private key 2"), stored[req2.ID])
+
+		err = ds.DeleteSigningKey(ctx, req1.ID)
+		require.NoError(t, err)
+
+		// Deleting a key that does not exist is not an error
+		err = ds.DeleteSigningKey(ctx, req1.ID)
+		require.NoError(t, err)
+
+		signingKeys, err = ds.ListSigningKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, signingKeys, 1)
+		assert.Equal(t, req2.ID, signingKeys[0].ID)
+	})
+
+	t.Run("Test CRUD ExternalTrustDomains", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("external1.org")})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("external2.org")})
+
+		externalTD, err := ds.FindExternalTrustDomainByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		require.Nil(t, externalTD)
+
+		req1 := &entity.ExternalTrustDomain{
+			TrustDomainID:         td1.ID.UUID,
+			BundleEndpointURL:     "https://external1.org/bundle",
+			BundleEndpointProfile: "https_spiffe",
+			EndpointSPIFFEID:      "spiffe://external1.org/spire/server",
+			BootstrapBundle:       []byte("bootstrap bundle"),
+		}
+		externalTD1, err := ds.CreateOrUpdateExternalTrustDomain(ctx, req1)
+		require.NoError(t, err)
+		assert.True(t, externalTD1.ID.Valid)
+		assert.Equal(t, req1.BundleEndpointURL, externalTD1.BundleEndpointURL)
+		assert.Equal(t, req1.BundleEndpointProfile, externalTD1.BundleEndpointProfile)
+		assert.Equal(t, req1.EndpointSPIFFEID, externalTD1.EndpointSPIFFEID)
+		assert.Equal(t, req1.BootstrapBundle, externalTD1.BootstrapBundle)
+		assert.True(t, externalTD1.LastRefreshAt.IsZero())
+
+		req2 := &entity.ExternalTrustDomain{
+			TrustDomainID:         td2.ID.UUID,
+			BundleEndpointURL:     "https://external2.org/bundle",
+			BundleEndpointProfile: "https_web",
+		}
+		_, err = ds.CreateOrUpdateExternalTrustDomain(ctx, req2)
+		require.NoError(t, err)
+
+		// Record a failed refresh
+		refreshedAt := time.Now().UTC().Truncate(time.Second)
+		refreshed, err := ds.UpdateExternalTrustDomainRefresh(ctx, &entity.ExternalTrustDomain{
+			TrustDomainID:    td1.ID.UUID,
+			LastRefreshAt:    refreshedAt,
+			LastRefreshError: "connection refused",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, refreshedAt, refreshed.LastRefreshAt.UTC())
+		assert.Equal(t, "connection refused", refreshed.LastRefreshError)
+
+		// Reconfiguring the trust domain resets its refresh state
+		req1.BundleEndpointURL = "https://external1.org/new-bundle"
+		externalTD1, err = ds.CreateOrUpdateExternalTrustDomain(ctx, req1)
+		require.NoError(t, err)
+		assert.Equal(t, req1.BundleEndpointURL, externalTD1.BundleEndpointURL)
+		assert.True(t, externalTD1.LastRefreshAt.IsZero())
+		assert.Empty(t, externalTD1.LastRefreshError)
+
+		externalTDs, err := ds.ListExternalTrustDomains(ctx)
+		require.NoError(t, err)
+		require.Len(t, externalTDs, 2)
+
+		err = ds.DeleteExternalTrustDomain(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		externalTD, err = ds.FindExternalTrustDomainByTrustDomainID(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		require.Nil(t, externalTD)
+
+		// Deleting the trust domain deletes its external configuration
+		err = ds.DeleteTrustDomain(ctx, td2.ID.UUID)
+		require.NoError(t, err)
+		externalTDs, err = ds.ListExternalTrustDomains(ctx)
+		require.NoError(t, err)
+		require.Empty(t, externalTDs)
+	})
+
+	t.Run("Test CRUD FederationGroups", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("member1.org")})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("member2.org")})
+
+		group, err := ds.FindFederationGroupByName(ctx, "mesh")
+		require.NoError(t, err)
+		require.Nil(t, group)
+
+		group1, err := ds.CreateOrUpdateFederationGroup(ctx, &entity.FederationGroup{Name: "mesh", Description: "all of them"})
+		require.NoError(t, err)
+		assert.True(t, group1.ID.Valid)
+		assert.Equal(t, "mesh", group1.Name)
+		assert.Equal(t, "all of them", group1.Description)
+		assert.Equal(t, entity.ConsentStatusPending, group1.DefaultConsent)
+
+		_, err = ds.CreateOrUpdateFederationGroup(ctx, &entity.FederationGroup{Name: "mesh"})
+		require.Error(t, err)
+
+		group1.DefaultConsent = entity.ConsentStatusApproved
+		group1.Description = "updated"
+		updated, err := ds.CreateOrUpdateFederationGroup(ctx, group1)
+		require.NoError(t, err)
+		assert.Equal(t, group1.ID, updated.ID)
+		assert.Equal(t, entity.ConsentStatusApproved, updated.DefaultConsent)
+		assert.Equal(t, "updated", updated.Description)
+
+		group2, err := ds.CreateOrUpdateFederationGroup(ctx, &entity.FederationGroup{Name: "other"})
+		require.NoError(t, err)
+
+		groups, err := ds.ListFederationGroups(ctx)
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+
+		stored, err := ds.FindFederationGroupByName(ctx, "mesh")
+		require.NoError(t, err)
+		assert.Equal(t, updated.ID, stored.ID)
+
+		// Members
+		member1, err := ds.CreateFederationGroupMember(ctx, &entity.FederationGroupMember{FederationGroupID: group1.ID.UUID, TrustDomainID: td1.ID.UUID})
+		require.NoError(t, err)
+		assert.True(t, member1.ID.Valid)
+		assert.Equal(t, group1.ID.UUID, member1.FederationGroupID)
+		assert.Equal(t, td1.ID.UUID, member1.TrustDomainID)
+
+		_, err = ds.CreateFederationGroupMember(ctx, &entity.FederationGroupMember{FederationGroupID: group1.ID.UUID, TrustDomainID: td1.ID.UUID})
+		require.Error(t, err)
+
+		_, err = ds.CreateFederationGroupMember(ctx, &entity.FederationGroupMember{FederationGroupID: group1.ID.UUID, TrustDomainID: td2.ID.UUID})
+		require.NoError(t, err)
+		_, err = ds.CreateFederationGroupMember(ctx, &entity.FederationGroupMember{FederationGroupID: group2.ID.UUID, TrustDomainID: td2.ID.UUID})
+		require.NoError(t, err)
+
+		members, err := ds.FindFederationGroupMembersByGroupID(ctx, group1.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+
+		err = ds.DeleteFederationGroupMember(ctx, group1.ID.UUID, td1.ID.UUID)
+		require.NoError(t, err)
+		members, err = ds.FindFederationGroupMembersByGroupID(ctx, group1.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, td2.ID.UUID, members[0].TrustDomainID)
+
+		// Relationships record the group that manages them
+		relationship, err := ds.CreateOrUpdateRelationship(ctx, &entity.Relationship{
+			TrustDomainAID:    td1.ID.UUID,
+			TrustDomainBID:    td2.ID.UUID,
+			FederationGroupID: group1.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, group1.ID, relationship.FederationGroupID)
+
+		relationship.FederationGroupID = group2.ID
+		relationship, err = ds.CreateOrUpdateRelationship(ctx, relationship)
+		require.NoError(t, err)
+		assert.Equal(t, group2.ID, relationship.FederationGroupID)
+
+		rels, err := ds.ListRelationships(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, rels, 1)
+		assert.Equal(t, group2.ID, rels[0].FederationGroupID)
+
+		// Deleting a group deletes its members, but not its relationships
+		err = ds.DeleteFederationGroup(ctx, group2.ID.UUID)
+		require.NoError(t, err)
+		members, err = ds.FindFederationGroupMembersByGroupID(ctx, group2.ID.UUID)
+		require.NoError(t, err)
+		require.Empty(t, members)
+		stored, err = ds.FindFederationGroupByName(ctx, "other")
+		require.NoError(t, err)
+		require.Nil(t, stored)
+		rel, err := ds.FindRelationshipByID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, rel)
+	})
+
+	t.Run("Test DPoP Proofs", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS()
+		defer closeDatastore(t, ds)
+
+		expiresAt := time.Now().Add(time.Minute)
+
+		recorded, err := ds.RecordDPoPProof(ctx, "proof-1", expiresAt)
+		require.NoError(t, err)
+		assert.True(t, recorded)
+
+		// a proof is recorded once
+		recorded, err = ds.RecordDPoPProof(ctx, "proof-1", expiresAt)
+		require.NoError(t, err)
+		assert.False(t, recorded)
+
+		recorded, err = ds.RecordDPoPProof(ctx, "proof-2", expiresAt)
+		require.NoError(t, err)
+		assert.True(t, recorded)
+
+		// expired proofs are forgotten
+		recorded, err = ds.RecordDPoPProof(ctx, "proof-3", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.True(t, recorded)
+
+		recorded, err = ds.RecordDPoPProof(ctx, "proof-3", expiresAt)
+		require.NoError(t, err)
+		assert.True(t, recorded)
+	})
 }
 
 func createTrustDomain(ctx context.Context, t *testing.T, ds db.Datastore, req *entity.TrustDomain) *entity.TrustDomain {
@@ -606,6 +1368,19 @@ func assertErrorString(t *testing.T, err error, s1, s2 string) {
 	}
 }
 
+func newJoinTokenRequest(t *testing.T, trustDomainID uuid.UUID, expiry time.Time) *entity.JoinToken {
+	token, err := jointoken.Generate()
+	require.NoError(t, err)
+
+	return &entity.JoinToken{
+		TokenPrefix:   token.Prefix,
+		TokenSalt:     token.Salt,
+		TokenHash:     token.Hash,
+		ExpiresAt:     expiry,
+		TrustDomainID: trustDomainID,
+	}
+}
+
 func assertEqualDate(t *testing.T, time1 time.Time, time2 time.Time) {
 	y1, td1, d1 := time1.Date()
 	y2, td2, d2 := time2.Date()
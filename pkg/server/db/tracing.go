@@ -0,0 +1,528 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
+	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/HewlettPackard/galadriel/pkg/server/db"
+
+// tracingDatastore is a Datastore that records an OpenTelemetry span for each call to the wrapped Datastore.
+type tracingDatastore struct {
+	datastore Datastore
+	engine    Engine
+	tracer    trace.Tracer
+}
+
+// WithTracing wraps the given Datastore so that every operation is traced.
+// The spans are named after the Datastore method, and carry the database engine as the db.system attribute.
+func WithTracing(ds Datastore, engine Engine) Datastore {
+	return &tracingDatastore{
+		datastore: ds,
+		engine:    engine,
+		tracer:    telemetry.Tracer(tracerName),
+	}
+}
+
+func (d *tracingDatastore) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return d.tracer.Start(ctx, "Datastore."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(string(d.engine)),
+			semconv.DBOperation(operation),
+		))
+}
+
+func (d *tracingDatastore) DeleteTrustDomain(ctx context.Context, trustDomainID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteTrustDomain")
+	defer span.End()
+
+	err := d.datastore.DeleteTrustDomain(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) FindTrustDomainByID(ctx context.Context, trustDomainID uuid.UUID) (*entity.TrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "FindTrustDomainByID")
+	defer span.End()
+
+	res, err := d.datastore.FindTrustDomainByID(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateOrUpdateTrustDomain(ctx context.Context, req *entity.TrustDomain) (*entity.TrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateTrustDomain")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateTrustDomain(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindTrustDomainByName(ctx context.Context, trustDomain spiffeid.TrustDomain) (*entity.TrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "FindTrustDomainByName")
+	defer span.End()
+
+	res, err := d.datastore.FindTrustDomainByName(ctx, trustDomain)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListTrustDomains(ctx context.Context, criteria *criteria.ListTrustDomainCriteria) ([]*entity.TrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "ListTrustDomains")
+	defer span.End()
+
+	res, err := d.datastore.ListTrustDomains(ctx, criteria)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListBundles(ctx context.Context) ([]*entity.Bundle, error) {
+	ctx, span := d.startSpan(ctx, "ListBundles")
+	defer span.End()
+
+	res, err := d.datastore.ListBundles(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteBundle(ctx context.Context, bundleID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteBundle")
+	defer span.End()
+
+	err := d.datastore.DeleteBundle(ctx, bundleID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) FindBundleByID(ctx context.Context, bundleID uuid.UUID) (*entity.Bundle, error) {
+	ctx, span := d.startSpan(ctx, "FindBundleByID")
+	defer span.End()
+
+	res, err := d.datastore.FindBundleByID(ctx, bundleID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateOrUpdateBundle(ctx context.Context, req *entity.Bundle) (*entity.Bundle, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateBundle")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateBundle(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateOrUpdateBundleIfUnchanged(ctx context.Context, req *entity.Bundle, previousDigest []byte) (*entity.Bundle, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateBundleIfUnchanged")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateBundleIfUnchanged(ctx, req, previousDigest)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindBundleByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.Bundle, error) {
+	ctx, span := d.startSpan(ctx, "FindBundleByTrustDomainID")
+	defer span.End()
+
+	res, err := d.datastore.FindBundleByTrustDomainID(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListJoinTokens(ctx context.Context) ([]*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "ListJoinTokens")
+	defer span.End()
+
+	res, err := d.datastore.ListJoinTokens(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteJoinToken")
+	defer span.End()
+
+	err := d.datastore.DeleteJoinToken(ctx, joinTokenID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) FindJoinTokenByPrefix(ctx context.Context, prefix string) (*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "FindJoinTokenByPrefix")
+	defer span.End()
+
+	res, err := d.datastore.FindJoinTokenByPrefix(ctx, prefix)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindLegacyJoinTokenByHash(ctx context.Context, hash []byte) (*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "FindLegacyJoinTokenByHash")
+	defer span.End()
+
+	res, err := d.datastore.FindLegacyJoinTokenByHash(ctx, hash)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateJoinToken(ctx context.Context, req *entity.JoinToken) (*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "CreateJoinToken")
+	defer span.End()
+
+	res, err := d.datastore.CreateJoinToken(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindJoinTokensByID(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "FindJoinTokensByID")
+	defer span.End()
+
+	res, err := d.datastore.FindJoinTokensByID(ctx, joinTokenID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) UpdateJoinToken(ctx context.Context, joinTokenID uuid.UUID, used bool) (*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "UpdateJoinToken")
+	defer span.End()
+
+	res, err := d.datastore.UpdateJoinToken(ctx, joinTokenID, used)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) RecordJoinTokenUse(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "RecordJoinTokenUse")
+	defer span.End()
+
+	res, err := d.datastore.RecordJoinTokenUse(ctx, joinTokenID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindJoinTokensByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.JoinToken, error) {
+	ctx, span := d.startSpan(ctx, "FindJoinTokensByTrustDomainID")
+	defer span.End()
+
+	res, err := d.datastore.FindJoinTokensByTrustDomainID(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteRelationship(ctx context.Context, relationshipID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteRelationship")
+	defer span.End()
+
+	err := d.datastore.DeleteRelationship(ctx, relationshipID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) FindRelationshipByID(ctx context.Context, relationshipID uuid.UUID) (*entity.Relationship, error) {
+	ctx, span := d.startSpan(ctx, "FindRelationshipByID")
+	defer span.End()
+
+	res, err := d.datastore.FindRelationshipByID(ctx, relationshipID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateOrUpdateRelationship(ctx context.Context, req *entity.Relationship) (*entity.Relationship, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateRelationship")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateRelationship(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindRelationshipsByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Relationship, error) {
+	ctx, span := d.startSpan(ctx, "FindRelationshipsByTrustDomainID")
+	defer span.End()
+
+	res, err := d.datastore.FindRelationshipsByTrustDomainID(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListRelationships(ctx context.Context, criteria *criteria.ListRelationshipsCriteria) ([]*entity.Relationship, error) {
+	ctx, span := d.startSpan(ctx, "ListRelationships")
+	defer span.End()
+
+	res, err := d.datastore.ListRelationships(ctx, criteria)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateRelationshipConsent")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateRelationshipConsent(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	ctx, span := d.startSpan(ctx, "FindRelationshipConsentsByRelationshipID")
+	defer span.End()
+
+	res, err := d.datastore.FindRelationshipConsentsByRelationshipID(ctx, relationshipID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteRelationshipConsent")
+	defer span.End()
+
+	err := d.datastore.DeleteRelationshipConsent(ctx, relationshipID, trustDomainID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) CreateWebhookDeadLetter(ctx context.Context, req *entity.WebhookDeadLetter) (*entity.WebhookDeadLetter, error) {
+	ctx, span := d.startSpan(ctx, "CreateWebhookDeadLetter")
+	defer span.End()
+
+	res, err := d.datastore.CreateWebhookDeadLetter(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListWebhookDeadLetters(ctx context.Context) ([]*entity.WebhookDeadLetter, error) {
+	ctx, span := d.startSpan(ctx, "ListWebhookDeadLetters")
+	defer span.End()
+
+	res, err := d.datastore.ListWebhookDeadLetters(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateOrUpdateBundleSyncState(ctx context.Context, req *entity.BundleSyncState) (*entity.BundleSyncState, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateBundleSyncState")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateBundleSyncState(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindBundleSyncStatesByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleSyncState, error) {
+	ctx, span := d.startSpan(ctx, "FindBundleSyncStatesByTrustDomainID")
+	defer span.End()
+
+	res, err := d.datastore.FindBundleSyncStatesByTrustDomainID(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListBundleSyncStates(ctx context.Context) ([]*entity.BundleSyncState, error) {
+	ctx, span := d.startSpan(ctx, "ListBundleSyncStates")
+	defer span.End()
+
+	res, err := d.datastore.ListBundleSyncStates(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteStaleBundleSyncStates(ctx context.Context, trustDomainID uuid.UUID, instanceID string, reportedBefore time.Time) error {
+	ctx, span := d.startSpan(ctx, "DeleteStaleBundleSyncStates")
+	defer span.End()
+
+	err := d.datastore.DeleteStaleBundleSyncStates(ctx, trustDomainID, instanceID, reportedBefore)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) CreateOrUpdateHarvester(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateHarvester")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateHarvester(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) UpdateHarvesterBundleUpload(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	ctx, span := d.startSpan(ctx, "UpdateHarvesterBundleUpload")
+	defer span.End()
+
+	res, err := d.datastore.UpdateHarvesterBundleUpload(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindHarvestersByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Harvester, error) {
+	ctx, span := d.startSpan(ctx, "FindHarvestersByTrustDomainID")
+	defer span.End()
+
+	res, err := d.datastore.FindHarvestersByTrustDomainID(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListHarvesters(ctx context.Context) ([]*entity.Harvester, error) {
+	ctx, span := d.startSpan(ctx, "ListHarvesters")
+	defer span.End()
+
+	res, err := d.datastore.ListHarvesters(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateSigningKey")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateSigningKey(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	ctx, span := d.startSpan(ctx, "ListSigningKeys")
+	defer span.End()
+
+	res, err := d.datastore.ListSigningKeys(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteSigningKey(ctx context.Context, id string) error {
+	ctx, span := d.startSpan(ctx, "DeleteSigningKey")
+	defer span.End()
+
+	err := d.datastore.DeleteSigningKey(ctx, id)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) CreateOrUpdateExternalTrustDomain(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateExternalTrustDomain")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateExternalTrustDomain(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) UpdateExternalTrustDomainRefresh(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "UpdateExternalTrustDomainRefresh")
+	defer span.End()
+
+	res, err := d.datastore.UpdateExternalTrustDomainRefresh(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindExternalTrustDomainByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.ExternalTrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "FindExternalTrustDomainByTrustDomainID")
+	defer span.End()
+
+	res, err := d.datastore.FindExternalTrustDomainByTrustDomainID(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListExternalTrustDomains(ctx context.Context) ([]*entity.ExternalTrustDomain, error) {
+	ctx, span := d.startSpan(ctx, "ListExternalTrustDomains")
+	defer span.End()
+
+	res, err := d.datastore.ListExternalTrustDomains(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteExternalTrustDomain(ctx context.Context, trustDomainID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteExternalTrustDomain")
+	defer span.End()
+
+	err := d.datastore.DeleteExternalTrustDomain(ctx, trustDomainID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) CreateOrUpdateFederationGroup(ctx context.Context, req *entity.FederationGroup) (*entity.FederationGroup, error) {
+	ctx, span := d.startSpan(ctx, "CreateOrUpdateFederationGroup")
+	defer span.End()
+
+	res, err := d.datastore.CreateOrUpdateFederationGroup(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindFederationGroupByName(ctx context.Context, name string) (*entity.FederationGroup, error) {
+	ctx, span := d.startSpan(ctx, "FindFederationGroupByName")
+	defer span.End()
+
+	res, err := d.datastore.FindFederationGroupByName(ctx, name)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) ListFederationGroups(ctx context.Context) ([]*entity.FederationGroup, error) {
+	ctx, span := d.startSpan(ctx, "ListFederationGroups")
+	defer span.End()
+
+	res, err := d.datastore.ListFederationGroups(ctx)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteFederationGroup(ctx context.Context, groupID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteFederationGroup")
+	defer span.End()
+
+	err := d.datastore.DeleteFederationGroup(ctx, groupID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) CreateFederationGroupMember(ctx context.Context, req *entity.FederationGroupMember) (*entity.FederationGroupMember, error) {
+	ctx, span := d.startSpan(ctx, "CreateFederationGroupMember")
+	defer span.End()
+
+	res, err := d.datastore.CreateFederationGroupMember(ctx, req)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) FindFederationGroupMembersByGroupID(ctx context.Context, groupID uuid.UUID) ([]*entity.FederationGroupMember, error) {
+	ctx, span := d.startSpan(ctx, "FindFederationGroupMembersByGroupID")
+	defer span.End()
+
+	res, err := d.datastore.FindFederationGroupMembersByGroupID(ctx, groupID)
+	telemetry.RecordError(span, err)
+	return res, err
+}
+
+func (d *tracingDatastore) DeleteFederationGroupMember(ctx context.Context, groupID, trustDomainID uuid.UUID) error {
+	ctx, span := d.startSpan(ctx, "DeleteFederationGroupMember")
+	defer span.End()
+
+	err := d.datastore.DeleteFederationGroupMember(ctx, groupID, trustDomainID)
+	telemetry.RecordError(span, err)
+	return err
+}
+
+func (d *tracingDatastore) RecordDPoPProof(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	ctx, span := d.startSpan(ctx, "RecordDPoPProof")
+	defer span.End()
+
+	res, err := d.datastore.RecordDPoPProof(ctx, id, expiresAt)
+	telemetry.RecordError(span, err)
+	return res, err
+}
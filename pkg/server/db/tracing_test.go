@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	ctx := context.Background()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	fakeDB := fakedatastore.NewFakeDB()
+	ds := WithTracing(fakeDB, SQLite).(*tracingDatastore)
+	ds.tracer = tp.Tracer(tracerName)
+
+	_, err := ds.FindTrustDomainByID(ctx, uuid.New())
+	require.NoError(t, err)
+
+	fakeDB.SetNextError(errors.New("datastore error"))
+	err = ds.DeleteBundle(ctx, uuid.New())
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "Datastore.FindTrustDomainByID", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.system", "sqlite3"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.operation", "FindTrustDomainByID"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "Datastore.DeleteBundle", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "datastore error", spans[1].Status().Description)
+}
@@ -1,42 +1,62 @@
 package endpoints
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleendpoint"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleverifier"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
+	"github.com/HewlettPackard/galadriel/pkg/server/jointoken"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
+	"github.com/HewlettPackard/galadriel/pkg/server/syncstatus"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
-// DefaultTokenTTL is the default TTL for tokens in seconds.
 const (
-	DefaultTokenTTL   = 600
+	// DefaultTokenTTL is the default TTL for tokens in seconds.
+	DefaultTokenTTL = 600
+	// DefaultHarvesterSilentThreshold is the default time in seconds without calls after which a Harvester is flagged as silent.
+	DefaultHarvesterSilentThreshold = 600
+
 	defaultPageSize   = 10
 	defaultPageNumber = 0
 )
 
 type AdminAPIHandlers struct {
-	Logger    logrus.FieldLogger
-	Datastore db.Datastore
+	Logger          logrus.FieldLogger
+	Datastore       db.Datastore
+	Notifier        notification.Notifier
+	BundleVerifiers *bundleverifier.Set
+	BundlePolicy    *bundlepolicy.Policy
 }
 
 // NewAdminAPIHandlers creates a new NewAdminAPIHandlers
-func NewAdminAPIHandlers(l logrus.FieldLogger, ds db.Datastore) *AdminAPIHandlers {
+func NewAdminAPIHandlers(l logrus.FieldLogger, ds db.Datastore, n notification.Notifier, bv *bundleverifier.Set, bp *bundlepolicy.Policy) *AdminAPIHandlers {
 	return &AdminAPIHandlers{
-		Logger:    l,
-		Datastore: ds,
+		Logger:          l,
+		Datastore:       ds,
+		Notifier:        n,
+		BundleVerifiers: bv,
+		BundlePolicy:    bp,
 	}
 }
 
@@ -94,7 +114,7 @@ func (h *AdminAPIHandlers) PutRelationship(echoCtx echo.Context) error {
 	}
 	eRelationship, err := reqBody.ToEntity()
 	if err != nil {
-		return err
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
 	dbTd1, err := h.lookupTrustDomain(ctx, eRelationship.TrustDomainAName.String())
@@ -119,6 +139,10 @@ func (h *AdminAPIHandlers) PutRelationship(echoCtx echo.Context) error {
 
 	h.Logger.Printf("Created relationship between trust domains %s and %s", dbTd1.Name.String(), dbTd2.Name.String())
 
+	rel.TrustDomainAName = dbTd1.Name
+	rel.TrustDomainBName = dbTd2.Name
+	h.Notifier.Notify(notification.NewRelationshipEvent(notification.EventRelationshipCreated, rel))
+
 	response := api.RelationshipFromEntity(rel)
 	err = chttp.WriteResponse(echoCtx, http.StatusCreated, response)
 	if err != nil {
@@ -187,6 +211,7 @@ func (h *AdminAPIHandlers) PutTrustDomain(echoCtx echo.Context) error {
 	}
 
 	h.Logger.Printf("Created trustDomain: %s", dbTD.Name.String())
+	h.Notifier.Notify(notification.NewTrustDomainEvent(notification.EventTrustDomainCreated, m))
 
 	response := api.TrustDomainFromEntity(m)
 	err = chttp.WriteResponse(echoCtx, http.StatusCreated, response)
@@ -242,6 +267,8 @@ func (h *AdminAPIHandlers) DeleteTrustDomainByName(echoCtx echo.Context, trustDo
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
 
+	h.Notifier.Notify(notification.NewTrustDomainEvent(notification.EventTrustDomainDeleted, trustDomain))
+
 	err = chttp.WriteResponse(echoCtx, http.StatusOK, fmt.Sprintf("Trust domain %q deleted", trustDomainName))
 	if err != nil {
 		err = fmt.Errorf("trust domain entity - %v", err.Error())
@@ -314,6 +341,7 @@ func (h *AdminAPIHandlers) PutTrustDomainByName(echoCtx echo.Context, trustDomai
 	}
 
 	h.Logger.Printf("Trust Bundle %v updated", td.Name)
+	h.Notifier.Notify(notification.NewTrustDomainEvent(notification.EventTrustDomainUpdated, td))
 
 	response := api.TrustDomainFromEntity(td)
 	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
@@ -345,23 +373,64 @@ func (h *AdminAPIHandlers) GetJoinToken(echoCtx echo.Context, trustDomainName ap
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
-	token := uuid.New()
+	externalTD, err := h.Datastore.FindExternalTrustDomainByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		err = fmt.Errorf("failed looking up external trust domain: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	// no harvester onboards an external trust domain, its bundle is fetched from its bundle endpoint
+	if externalTD != nil {
+		err = fmt.Errorf("trust domain %q is external, its bundle is fetched from its bundle endpoint", trustDomainName)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+
+	maxUses := 1
+	if params.MaxUses != nil {
+		if *params.MaxUses < 1 {
+			err := fmt.Errorf("max uses must be greater than 0")
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+		maxUses = int(*params.MaxUses)
+	}
+
+	sourceCIDR := ""
+	if params.SourceCidr != nil && *params.SourceCidr != "" {
+		_, network, err := net.ParseCIDR(*params.SourceCidr)
+		if err != nil {
+			err = fmt.Errorf("malformed source CIDR: %v", err)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+		sourceCIDR = network.String()
+	}
+
+	token, err := jointoken.Generate()
+	if err != nil {
+		err = fmt.Errorf("failed generating join token: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
 
+	// only the salted hash of the secret is stored, the token is disclosed once in the response
 	ttl := time.Duration(params.Ttl) * time.Second
 	joinToken := &entity.JoinToken{
-		Token:         token.String(),
+		TokenPrefix:   token.Prefix,
+		TokenSalt:     token.Salt,
+		TokenHash:     token.Hash,
 		TrustDomainID: td.ID.UUID,
+		MaxUses:       maxUses,
+		SourceCIDR:    sourceCIDR,
 		ExpiresAt:     time.Now().Add(ttl),
 	}
 
-	_, err = h.Datastore.CreateJoinToken(ctx, joinToken)
+	created, err := h.Datastore.CreateJoinToken(ctx, joinToken)
 	if err != nil {
 		err = fmt.Errorf("failed creating join token: %v", err)
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
 
 	response := admin.JoinTokenResponse{
-		Token: token,
+		Token: token.String(),
+		Id:    &created.ID.UUID,
 	}
 	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
 	if err != nil {
@@ -374,6 +443,861 @@ func (h *AdminAPIHandlers) GetJoinToken(echoCtx echo.Context, trustDomainName ap
 	return nil
 }
 
+// ListJoinTokens lists the join tokens, optionally of a single trust domain, without disclosing them
+// - (GET /join-tokens)
+func (h *AdminAPIHandlers) ListJoinTokens(echoCtx echo.Context, params admin.ListJoinTokensParams) error {
+	ctx := echoCtx.Request().Context()
+
+	var tokens []*entity.JoinToken
+	var err error
+	if params.TrustDomainName != nil {
+		td, lookupErr := h.lookupTrustDomain(ctx, *params.TrustDomainName)
+		if lookupErr != nil {
+			return lookupErr
+		}
+		tokens, err = h.Datastore.FindJoinTokensByTrustDomainID(ctx, td.ID.UUID)
+	} else {
+		tokens, err = h.Datastore.ListJoinTokens(ctx)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed listing join tokens: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	now := time.Now()
+	trustDomainNames := make(map[uuid.UUID]spiffeid.TrustDomain)
+	response := make([]*admin.JoinTokenInfo, 0, len(tokens))
+	for _, jt := range tokens {
+		tdName, ok := trustDomainNames[jt.TrustDomainID]
+		if !ok {
+			tdName, err = h.joinTokenTrustDomainName(ctx, jt)
+			if err != nil {
+				return err
+			}
+			trustDomainNames[jt.TrustDomainID] = tdName
+		}
+		response = append(response, admin.JoinTokenInfoFromEntity(tdName, jt, now))
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("join tokens entities - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetJoinTokenByID gets the state of a join token, without disclosing it - (GET /join-tokens/{joinTokenID})
+func (h *AdminAPIHandlers) GetJoinTokenByID(echoCtx echo.Context, joinTokenID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	jt, err := h.lookupJoinToken(ctx, joinTokenID)
+	if err != nil {
+		return err
+	}
+
+	tdName, err := h.joinTokenTrustDomainName(ctx, jt)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.JoinTokenInfoFromEntity(tdName, jt, time.Now()))
+	if err != nil {
+		err = fmt.Errorf("join token entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// RevokeJoinToken deletes a join token, so that no Harvester can onboard with it anymore
+// - (DELETE /join-tokens/{joinTokenID})
+func (h *AdminAPIHandlers) RevokeJoinToken(echoCtx echo.Context, joinTokenID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	jt, err := h.lookupJoinToken(ctx, joinTokenID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Datastore.DeleteJoinToken(ctx, jt.ID.UUID); err != nil {
+		err = fmt.Errorf("failed revoking join token: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.Infof("Revoked join token %s", jt.ID.UUID)
+
+	response := fmt.Sprintf("Join token %q revoked", jt.ID.UUID)
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("join token revocation: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ListHarvesters lists the Harvesters of all trust domains, flagging the silent ones - (GET /harvesters)
+func (h *AdminAPIHandlers) ListHarvesters(echoCtx echo.Context, params admin.ListHarvestersParams) error {
+	ctx := echoCtx.Request().Context()
+
+	silentThreshold := time.Duration(DefaultHarvesterSilentThreshold) * time.Second
+	if params.SilentThreshold != nil {
+		if *params.SilentThreshold <= 0 {
+			err := fmt.Errorf("silent threshold must be greater than 0")
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+		silentThreshold = time.Duration(*params.SilentThreshold) * time.Second
+	}
+
+	trustDomains, err := h.Datastore.ListTrustDomains(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("failed listing trust domains: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	harvesters, err := h.Datastore.ListHarvesters(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed listing harvesters: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	byTrustDomainID := make(map[uuid.UUID][]*entity.Harvester, len(harvesters))
+	for _, harvester := range harvesters {
+		byTrustDomainID[harvester.TrustDomainID] = append(byTrustDomainID[harvester.TrustDomainID], harvester)
+	}
+
+	now := time.Now()
+	response := make([]*admin.Harvester, 0, len(harvesters)+len(trustDomains))
+	for _, td := range trustDomains {
+		instances := byTrustDomainID[td.ID.UUID]
+		if len(instances) == 0 {
+			// the trust domain harvester was never seen
+			response = append(response, admin.HarvesterFromEntity(td.Name, nil, true))
+			continue
+		}
+
+		for _, harvester := range instances {
+			silent := now.Sub(harvester.LastSeenAt) > silentThreshold
+			response = append(response, admin.HarvesterFromEntity(td.Name, harvester, silent))
+		}
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("failed to write harvesters response: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetFederationStatus gets the distribution status of the bundles of the approved relationships - (GET /federation/status)
+func (h *AdminAPIHandlers) GetFederationStatus(echoCtx echo.Context) error {
+	ctx := echoCtx.Request().Context()
+
+	trustDomains, err := h.Datastore.ListTrustDomains(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("failed listing trust domains: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	relationships, err := h.Datastore.ListRelationships(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("failed listing relationships: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	bundles, err := h.Datastore.ListBundles(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed listing bundles: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	states, err := h.Datastore.ListBundleSyncStates(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed listing bundle sync states: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	status := syncstatus.Compute(trustDomains, relationships, bundles, states, time.Now())
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.FederationStatusFromSyncStatus(status))
+	if err != nil {
+		err = fmt.Errorf("failed to write federation status response: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetTrustDomainBundle gets the stored bundle of a trust domain - (GET /trust-domain/{trustDomainName}/bundle)
+func (h *AdminAPIHandlers) GetTrustDomainBundle(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := h.lookupBundle(ctx, td)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.TrustDomainBundleFromEntity(td.Name, bundle))
+	if err != nil {
+		err = fmt.Errorf("failed to write bundle response: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// PutTrustDomainBundle sets the bundle of a trust domain without a Harvester. The bundle is validated like the
+// uploads of the Harvesters, and is admin-managed from then on - (PUT /trust-domain/{trustDomainName}/bundle)
+func (h *AdminAPIHandlers) PutTrustDomainBundle(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	req := &admin.PutTrustDomainBundleJSONRequestBody{}
+	if err := chttp.ParseRequestBodyToStruct(echoCtx, req); err != nil {
+		err := fmt.Errorf("failed to read bundle put body: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	if err := validateBundleDigest(req.TrustBundle, req.Digest); err != nil {
+		err := fmt.Errorf("invalid bundle request: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := req.ToEntity(td.Name)
+	if err != nil {
+		err := fmt.Errorf("failed to parse request bundle: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+	bundle.TrustDomainID = td.ID.UUID
+
+	externalTD, err := h.Datastore.FindExternalTrustDomainByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "error looking up external trust domain"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if externalTD != nil {
+		err := fmt.Errorf("the bundle of trust domain %q is fetched from its bundle endpoint", td.Name.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+
+	storedBundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "failed looking up bundle in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	var previousData []byte
+	if storedBundle != nil {
+		previousData = storedBundle.Data
+	}
+
+	report := h.BundlePolicy.Validate(td.Name, bundle.Data, previousData, time.Now())
+	if v := report.Violation(bundlepolicy.RuleSequenceNumber); v != nil {
+		err := fmt.Errorf("stale bundle: %s", v.Message)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+	if !report.Valid() {
+		err := fmt.Errorf("bundle does not comply with the bundle policy: %s", report.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnprocessableEntity)
+	}
+
+	result, err := h.BundleVerifiers.Verify(td.Name, bundle)
+	if err != nil {
+		err := fmt.Errorf("failed to verify bundle signature: %w", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+	bundle.VerificationStatus = result.Status
+	bundle.VerifiedBy = result.VerifiedBy
+
+	bundleChanged := true
+	if storedBundle != nil {
+		bundle.ID = storedBundle.ID
+		bundleChanged = !bytes.Equal(storedBundle.Digest, bundle.Digest)
+	}
+
+	stored, err := h.Datastore.CreateOrUpdateBundle(ctx, bundle)
+	if err != nil {
+		msg := "failed to store bundle in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Info("Stored admin-managed bundle")
+
+	if bundleChanged {
+		h.Notifier.Notify(notification.NewBundleUpdatedEvent(td, bundle))
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.TrustDomainBundleFromEntity(td.Name, stored))
+	if err != nil {
+		err = fmt.Errorf("failed to write bundle response: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DeleteTrustDomainBundle deletes the admin-managed bundle of a trust domain, so that its Harvester can upload
+// it again - (DELETE /trust-domain/{trustDomainName}/bundle)
+func (h *AdminAPIHandlers) DeleteTrustDomainBundle(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := h.lookupBundle(ctx, td)
+	if err != nil {
+		return err
+	}
+
+	if !bundle.AdminManaged {
+		err := fmt.Errorf("the bundle of trust domain %q is not admin-managed", td.Name.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+
+	if err := h.Datastore.DeleteBundle(ctx, bundle.ID.UUID); err != nil {
+		err = fmt.Errorf("failed deleting bundle: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Info("Deleted admin-managed bundle")
+
+	response := fmt.Sprintf("Bundle of trust domain %q deleted", td.Name.String())
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("bundle deletion: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetExternalTrustDomain gets the bundle endpoint configuration of an external trust domain - (GET /trust-domain/{trustDomainName}/external)
+func (h *AdminAPIHandlers) GetExternalTrustDomain(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	externalTD, err := h.lookupExternalTrustDomain(ctx, td)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.ExternalTrustDomainFromEntity(td.Name, externalTD))
+	if err != nil {
+		err = fmt.Errorf("failed to write external trust domain response: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// PutExternalTrustDomain configures a trust domain as external, fed from its SPIFFE bundle endpoint instead of
+// a Harvester - (PUT /trust-domain/{trustDomainName}/external)
+func (h *AdminAPIHandlers) PutExternalTrustDomain(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	reqBody := &admin.PutExternalTrustDomainJSONRequestBody{}
+	err := chttp.ParseRequestBodyToStruct(echoCtx, reqBody)
+	if err != nil {
+		err := fmt.Errorf("failed to read external trust domain put body: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	externalTD, err := externalTrustDomainFromRequest(td.Name, reqBody)
+	if err != nil {
+		err := fmt.Errorf("invalid external trust domain: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+	externalTD.TrustDomainID = td.ID.UUID
+
+	storedBundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "failed looking up bundle in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if storedBundle != nil && storedBundle.AdminManaged {
+		err := fmt.Errorf("the bundle of trust domain %q is admin-managed", td.Name.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+
+	if externalTD.BootstrapBundle == nil && storedBundle == nil && externalTD.BundleEndpointProfile == string(bundleendpoint.ProfileHTTPSSPIFFE) {
+		err := fmt.Errorf("a bootstrap bundle is required to authenticate the bundle endpoint of the %q profile", bundleendpoint.ProfileHTTPSSPIFFE)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	if externalTD.BootstrapBundle != nil && storedBundle == nil {
+		// the bootstrap bundle is the bundle of the trust domain until the first one is fetched
+		report := h.BundlePolicy.Validate(td.Name, externalTD.BootstrapBundle, nil, time.Now())
+		if !report.Valid() {
+			err := fmt.Errorf("bootstrap bundle does not comply with the bundle policy: %s", report.String())
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+
+		bundle := &entity.Bundle{
+			Data:               externalTD.BootstrapBundle,
+			Digest:             cryptoutil.CalculateDigest(externalTD.BootstrapBundle),
+			TrustDomainID:      td.ID.UUID,
+			VerificationStatus: entity.BundleVerificationSkipped,
+		}
+		if _, err := h.Datastore.CreateOrUpdateBundle(ctx, bundle); err != nil {
+			msg := "failed to store bootstrap bundle in DB"
+			err := fmt.Errorf("%s: %w", msg, err)
+			return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+		}
+		h.Notifier.Notify(notification.NewBundleUpdatedEvent(td, bundle))
+	}
+
+	externalTD, err = h.Datastore.CreateOrUpdateExternalTrustDomain(ctx, externalTD)
+	if err != nil {
+		err = fmt.Errorf("failed creating/updating external trust domain: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Infof("Configured external trust domain with bundle endpoint %q", externalTD.BundleEndpointURL)
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.ExternalTrustDomainFromEntity(td.Name, externalTD))
+	if err != nil {
+		err = fmt.Errorf("failed to write external trust domain response: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DeleteExternalTrustDomain stops fetching the bundle of an external trust domain - (DELETE /trust-domain/{trustDomainName}/external)
+func (h *AdminAPIHandlers) DeleteExternalTrustDomain(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.lookupExternalTrustDomain(ctx, td); err != nil {
+		return err
+	}
+
+	if err := h.Datastore.DeleteExternalTrustDomain(ctx, td.ID.UUID); err != nil {
+		err = fmt.Errorf("failed deleting external trust domain: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Info("Deleted external trust domain")
+
+	response := fmt.Sprintf("External trust domain %q deleted", td.Name.String())
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("external trust domain deletion: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ListFederationGroups lists all federation groups and their members - (GET /federation-groups)
+func (h *AdminAPIHandlers) ListFederationGroups(echoCtx echo.Context) error {
+	ctx := echoCtx.Request().Context()
+
+	groups, err := h.Datastore.ListFederationGroups(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed listing federation groups: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	response := make([]*admin.FederationGroup, 0, len(groups))
+	for _, group := range groups {
+		g, err := h.federationGroupResponse(ctx, group)
+		if err != nil {
+			return err
+		}
+		response = append(response, g)
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("federation groups entities - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// PutFederationGroup creates a federation group, or updates the description and default consent of an
+// existing one - (PUT /federation-groups)
+func (h *AdminAPIHandlers) PutFederationGroup(echoCtx echo.Context) error {
+	ctx := echoCtx.Request().Context()
+
+	reqBody := &admin.PutFederationGroupJSONRequestBody{}
+	err := chttp.ParseRequestBodyToStruct(echoCtx, reqBody)
+	if err != nil {
+		err := fmt.Errorf("failed to read federation group put body: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	group, err := reqBody.ToEntity()
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	stored, err := h.Datastore.FindFederationGroupByName(ctx, group.Name)
+	if err != nil {
+		msg := "error looking up federation group"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if stored != nil {
+		group.ID = stored.ID
+	}
+
+	group, err = h.Datastore.CreateOrUpdateFederationGroup(ctx, group)
+	if err != nil {
+		err = fmt.Errorf("failed creating/updating federation group: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.FederationGroup, group.Name).Infof("Stored federation group with default consent %q", group.DefaultConsent)
+
+	response, err := h.federationGroupResponse(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("federation group entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetFederationGroup gets a federation group and its members - (GET /federation-groups/{groupName})
+func (h *AdminAPIHandlers) GetFederationGroup(echoCtx echo.Context, groupName admin.FederationGroupName) error {
+	ctx := echoCtx.Request().Context()
+
+	group, err := h.lookupFederationGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.federationGroupResponse(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("federation group entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DeleteFederationGroup deletes a federation group. The reconciler deletes the relationships of the group
+// - (DELETE /federation-groups/{groupName})
+func (h *AdminAPIHandlers) DeleteFederationGroup(echoCtx echo.Context, groupName admin.FederationGroupName) error {
+	ctx := echoCtx.Request().Context()
+
+	group, err := h.lookupFederationGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Datastore.DeleteFederationGroup(ctx, group.ID.UUID); err != nil {
+		err = fmt.Errorf("failed deleting federation group: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.FederationGroup, group.Name).Info("Deleted federation group")
+
+	response := fmt.Sprintf("Federation group %q deleted", group.Name)
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("federation group deletion: %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// PutFederationGroupMember adds a trust domain to a federation group - (PUT /federation-groups/{groupName}/members)
+func (h *AdminAPIHandlers) PutFederationGroupMember(echoCtx echo.Context, groupName admin.FederationGroupName) error {
+	ctx := echoCtx.Request().Context()
+
+	reqBody := &admin.PutFederationGroupMemberJSONRequestBody{}
+	err := chttp.ParseRequestBodyToStruct(echoCtx, reqBody)
+	if err != nil {
+		err := fmt.Errorf("failed to read federation group member put body: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	group, err := h.lookupFederationGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	td, err := h.lookupTrustDomain(ctx, reqBody.TrustDomainName)
+	if err != nil {
+		return err
+	}
+
+	members, err := h.Datastore.FindFederationGroupMembersByGroupID(ctx, group.ID.UUID)
+	if err != nil {
+		err = fmt.Errorf("failed listing federation group members: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+	for _, m := range members {
+		if m.TrustDomainID == td.ID.UUID {
+			err := fmt.Errorf("trust domain %q is already a member of federation group %q", td.Name.String(), group.Name)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+		}
+	}
+
+	member := &entity.FederationGroupMember{
+		FederationGroupID: group.ID.UUID,
+		TrustDomainID:     td.ID.UUID,
+	}
+	if _, err := h.Datastore.CreateFederationGroupMember(ctx, member); err != nil {
+		err = fmt.Errorf("failed adding federation group member: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.FederationGroup, group.Name).Infof("Added trust domain %s to federation group", td.Name.String())
+
+	response, err := h.federationGroupResponse(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("federation group entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DeleteFederationGroupMember removes a trust domain from a federation group
+// - (DELETE /federation-groups/{groupName}/members/{trustDomainName})
+func (h *AdminAPIHandlers) DeleteFederationGroupMember(echoCtx echo.Context, groupName admin.FederationGroupName, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	group, err := h.lookupFederationGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	members, err := h.Datastore.FindFederationGroupMembersByGroupID(ctx, group.ID.UUID)
+	if err != nil {
+		err = fmt.Errorf("failed listing federation group members: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+	isMember := false
+	for _, m := range members {
+		if m.TrustDomainID == td.ID.UUID {
+			isMember = true
+		}
+	}
+	if !isMember {
+		err := fmt.Errorf("trust domain %q is not a member of federation group %q", td.Name.String(), group.Name)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	if err := h.Datastore.DeleteFederationGroupMember(ctx, group.ID.UUID, td.ID.UUID); err != nil {
+		err = fmt.Errorf("failed removing federation group member: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.FederationGroup, group.Name).Infof("Removed trust domain %s from federation group", td.Name.String())
+
+	response, err := h.federationGroupResponse(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("federation group entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (h *AdminAPIHandlers) lookupFederationGroup(ctx context.Context, groupName admin.FederationGroupName) (*entity.FederationGroup, error) {
+	group, err := h.Datastore.FindFederationGroupByName(ctx, groupName)
+	if err != nil {
+		msg := "error looking up federation group"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if group == nil {
+		err := fmt.Errorf("federation group does not exist: %q", groupName)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	return group, nil
+}
+
+// federationGroupResponse maps the federation group to its API representation, with the names of its members.
+func (h *AdminAPIHandlers) federationGroupResponse(ctx context.Context, group *entity.FederationGroup) (*admin.FederationGroup, error) {
+	members, err := h.Datastore.FindFederationGroupMembersByGroupID(ctx, group.ID.UUID)
+	if err != nil {
+		err = fmt.Errorf("failed listing federation group members: %v", err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, m := range members {
+		td, err := h.Datastore.FindTrustDomainByID(ctx, m.TrustDomainID)
+		if err != nil || td == nil {
+			err = fmt.Errorf("failed looking up federation group member %q: %v", m.TrustDomainID, err)
+			return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+		}
+		m.TrustDomainName = td.Name
+	}
+
+	return admin.FederationGroupFromEntity(group, members), nil
+}
+
+func (h *AdminAPIHandlers) lookupJoinToken(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	jt, err := h.Datastore.FindJoinTokensByID(ctx, joinTokenID)
+	if err != nil {
+		msg := "error looking up join token"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if jt == nil {
+		err := fmt.Errorf("join token does not exist: %q", joinTokenID)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	return jt, nil
+}
+
+// joinTokenTrustDomainName returns the name of the trust domain of the join token.
+func (h *AdminAPIHandlers) joinTokenTrustDomainName(ctx context.Context, jt *entity.JoinToken) (spiffeid.TrustDomain, error) {
+	td, err := h.Datastore.FindTrustDomainByID(ctx, jt.TrustDomainID)
+	if err != nil || td == nil {
+		err = fmt.Errorf("failed looking up trust domain of join token %q: %v", jt.ID.UUID, err)
+		return spiffeid.TrustDomain{}, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return td.Name, nil
+}
+
+func (h *AdminAPIHandlers) lookupBundle(ctx context.Context, td *entity.TrustDomain) (*entity.Bundle, error) {
+	bundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "error looking up bundle"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if bundle == nil {
+		err := fmt.Errorf("trust domain has no bundle: %q", td.Name.String())
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	return bundle, nil
+}
+
+func (h *AdminAPIHandlers) lookupExternalTrustDomain(ctx context.Context, td *entity.TrustDomain) (*entity.ExternalTrustDomain, error) {
+	externalTD, err := h.Datastore.FindExternalTrustDomainByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "error looking up external trust domain"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if externalTD == nil {
+		err := fmt.Errorf("trust domain is not external: %q", td.Name.String())
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	return externalTD, nil
+}
+
+// externalTrustDomainFromRequest validates the bundle endpoint configuration of the request.
+func externalTrustDomainFromRequest(td spiffeid.TrustDomain, req *admin.PutExternalTrustDomainRequest) (*entity.ExternalTrustDomain, error) {
+	endpointURL, err := url.Parse(req.BundleEndpointUrl)
+	if err != nil {
+		return nil, fmt.Errorf("malformed bundle endpoint URL: %v", err)
+	}
+	if endpointURL.Scheme != "https" || endpointURL.Host == "" {
+		return nil, fmt.Errorf("bundle endpoint URL must be an absolute https URL: %q", req.BundleEndpointUrl)
+	}
+
+	externalTD := &entity.ExternalTrustDomain{
+		BundleEndpointURL:     req.BundleEndpointUrl,
+		BundleEndpointProfile: req.BundleEndpointProfile,
+	}
+
+	switch bundleendpoint.Profile(req.BundleEndpointProfile) {
+	case bundleendpoint.ProfileHTTPSWeb:
+	case bundleendpoint.ProfileHTTPSSPIFFE:
+		if req.EndpointSpiffeId == nil || *req.EndpointSpiffeId == "" {
+			return nil, fmt.Errorf("endpoint SPIFFE ID is required by the %q profile", bundleendpoint.ProfileHTTPSSPIFFE)
+		}
+		endpointID, err := spiffeid.FromString(*req.EndpointSpiffeId)
+		if err != nil {
+			return nil, fmt.Errorf("malformed endpoint SPIFFE ID: %v", err)
+		}
+		if !endpointID.MemberOf(td) {
+			return nil, fmt.Errorf("endpoint SPIFFE ID %q is not a member of trust domain %q", endpointID, td)
+		}
+		externalTD.EndpointSPIFFEID = endpointID.String()
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile %q", req.BundleEndpointProfile)
+	}
+
+	if req.BootstrapBundle != nil && *req.BootstrapBundle != "" {
+		data := []byte(*req.BootstrapBundle)
+		if _, err := spiffebundle.Parse(td, data); err != nil {
+			return nil, fmt.Errorf("malformed bootstrap bundle: %v", err)
+		}
+		externalTD.BootstrapBundle = data
+	}
+
+	return externalTD, nil
+}
+
 func (h *AdminAPIHandlers) findTrustDomainByName(ctx context.Context, trustDomain string) (*entity.TrustDomain, error) {
 	tdName, err := spiffeid.TrustDomainFromString(trustDomain)
 	if err != nil {
@@ -2,6 +2,7 @@ package endpoints
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,11 +10,19 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleverifier"
+	"github.com/HewlettPackard/galadriel/pkg/server/jointoken"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakenotifier"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
@@ -63,6 +72,7 @@ type ManagementTestSetup struct {
 	Handler      *AdminAPIHandlers
 	Recorder     *httptest.ResponseRecorder
 	FakeDatabase *fakedatastore.FakeDatabase
+	FakeNotifier *fakenotifier.Notifier
 
 	// Helpers
 	bodyReader io.Reader
@@ -84,13 +94,17 @@ func NewManagementTestSetup(t *testing.T, method, url string, body interface{})
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	fakeDB := fakedatastore.NewFakeDB()
+	fakeNotifier := fakenotifier.New()
 	logger := logrus.New()
+	bundlePolicy, err := bundlepolicy.New(nil)
+	require.NoError(t, err)
 
 	return &ManagementTestSetup{
 		EchoCtx:      e.NewContext(req, rec),
 		Recorder:     rec,
-		Handler:      NewAdminAPIHandlers(logger, fakeDB),
+		Handler:      NewAdminAPIHandlers(logger, fakeDB, fakeNotifier, bundleverifier.NewSet(nil), bundlePolicy),
 		FakeDatabase: fakeDB,
+		FakeNotifier: fakeNotifier,
 		// Helpers
 		url:        url,
 		method:     method,
@@ -219,6 +233,51 @@ func TestUDSPutRelationships(t *testing.T) {
 		assert.NotNil(t, apiRelation)
 		assert.Equal(t, tdUUID1.UUID, apiRelation.TrustDomainAId)
 		assert.Equal(t, tdUUID2.UUID, apiRelation.TrustDomainBId)
+
+		assertNotified(t, setup.FakeNotifier, notification.EventRelationshipCreated, td1, td2)
+	})
+
+	t.Run("Successfully create a one-way relationship", func(t *testing.T) {
+		direction := api.ATrustsB
+		reqBody := &admin.PutRelationshipJSONRequestBody{
+			TrustDomainAName: td1,
+			TrustDomainBName: td2,
+			Direction:        &direction,
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, relationshipsPath, reqBody)
+		setup.FakeDatabase.WithTrustDomains(
+			&entity.TrustDomain{ID: tdUUID1, Name: NewTrustDomain(t, td1)},
+			&entity.TrustDomain{ID: tdUUID2, Name: NewTrustDomain(t, td2)},
+		)
+
+		err := setup.Handler.PutRelationship(setup.EchoCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, setup.Recorder.Code)
+
+		apiRelation := api.Relationship{}
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &apiRelation)
+		assert.NoError(t, err)
+		require.NotNil(t, apiRelation.Direction)
+		assert.Equal(t, api.ATrustsB, *apiRelation.Direction)
+	})
+
+	t.Run("Should not allow unknown relationship directions", func(t *testing.T) {
+		direction := api.RelationshipDirection("sideways")
+		reqBody := &admin.PutRelationshipJSONRequestBody{
+			TrustDomainAName: td1,
+			TrustDomainBName: td2,
+			Direction:        &direction,
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, relationshipsPath, reqBody)
+
+		err := setup.Handler.PutRelationship(setup.EchoCtx)
+		assert.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHttpErr.Code)
+		assert.Contains(t, echoHttpErr.Message, "invalid relationship direction")
 	})
 
 	t.Run("Should not allow relationships request between inexistent trust domains", func(t *testing.T) {
@@ -329,6 +388,7 @@ func TestUDSPutTrustDomain(t *testing.T) {
 		assert.Equal(t, td1, apiTrustDomain.Name)
 		assert.Equal(t, description, *apiTrustDomain.Description)
 
+		assertNotified(t, setup.FakeNotifier, notification.EventTrustDomainCreated, td1)
 	})
 
 	t.Run("Should not allow creating trust domain with the same name of one already created", func(t *testing.T) {
@@ -414,6 +474,8 @@ func TestUDSDeleteTrustDomain(t *testing.T) {
 		got := bytes.NewBuffer(setup.Recorder.Body.Bytes()).String()
 
 		assert.Equal(t, expectedOutput, strings.ReplaceAll(got, "\\", ""))
+
+		assertNotified(t, setup.FakeNotifier, notification.EventTrustDomainDeleted, td1)
 	})
 
 	t.Run("Error when deleting a trust domain that does not exists", func(t *testing.T) {
@@ -502,6 +564,64 @@ func TestUDSPutTrustDomainByName(t *testing.T) {
 		assert.Equal(t, td1, apiTrustDomain.Name)
 		assert.Equal(t, tdUUID1.UUID, apiTrustDomain.Id)
 		assert.Equal(t, description, *apiTrustDomain.Description)
+
+		assertNotified(t, setup.FakeNotifier, notification.EventTrustDomainUpdated, td1)
+	})
+
+	t.Run("Successfully sets the SVID onboarding settings of a trust domain", func(t *testing.T) {
+		fakeTrustDomains := entity.TrustDomain{ID: tdUUID1, Name: NewTrustDomain(t, td1)}
+
+		completePath := fmt.Sprintf(trustDomainPath, tdUUID1.UUID)
+
+		harvesterSpiffeID := fmt.Sprintf("spiffe://%s/galadriel-harvester", td1)
+		onboardingBundle := `{"keys":[]}`
+		reqBody := &admin.PutTrustDomainByNameJSONRequestBody{
+			Id:                tdUUID1.UUID,
+			Name:              td1,
+			HarvesterSpiffeId: &harvesterSpiffeID,
+			OnboardingBundle:  &onboardingBundle,
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, completePath, reqBody)
+		setup.FakeDatabase.WithTrustDomains(&fakeTrustDomains)
+
+		err := setup.Handler.PutTrustDomainByName(setup.EchoCtx, td1)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		apiTrustDomain := api.TrustDomain{}
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &apiTrustDomain)
+		assert.NoError(t, err)
+		assert.Equal(t, harvesterSpiffeID, *apiTrustDomain.HarvesterSpiffeId)
+		assert.Equal(t, onboardingBundle, *apiTrustDomain.OnboardingBundle)
+
+		stored, err := setup.FakeDatabase.FindTrustDomainByName(context.Background(), fakeTrustDomains.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, harvesterSpiffeID, stored.HarvesterSPIFFEID.String())
+		assert.Equal(t, []byte(onboardingBundle), stored.OnboardingBundle)
+	})
+
+	t.Run("Raise a bad request when the harvester SPIFFE ID is not a member of the trust domain", func(t *testing.T) {
+		fakeTrustDomains := entity.TrustDomain{ID: tdUUID1, Name: NewTrustDomain(t, td1)}
+
+		completePath := fmt.Sprintf(trustDomainPath, tdUUID1.UUID)
+
+		harvesterSpiffeID := "spiffe://other.test/galadriel-harvester"
+		reqBody := &admin.PutTrustDomainByNameJSONRequestBody{
+			Id:                tdUUID1.UUID,
+			Name:              td1,
+			HarvesterSpiffeId: &harvesterSpiffeID,
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, completePath, reqBody)
+		setup.FakeDatabase.WithTrustDomains(&fakeTrustDomains)
+
+		err := setup.Handler.PutTrustDomainByName(setup.EchoCtx, td1)
+		assert.Error(t, err)
+
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHTTPErr.Code)
+		assert.Contains(t, echoHTTPErr.Message, "is not a member of trust domain")
 	})
 
 	t.Run("Raise a not found when trying to updated a trust domain that does not exists", func(t *testing.T) {
@@ -556,6 +676,78 @@ func TestUDSGetJoinToken(t *testing.T) {
 		assert.NotEmpty(t, jtResp)
 	})
 
+	t.Run("Successfully generates a multi-use join token bound to a network", func(t *testing.T) {
+		td1ID := NewNullableID()
+		completePath := fmt.Sprintf(trustDomainPath, td1)
+
+		setup := NewManagementTestSetup(t, http.MethodGet, completePath, nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: td1ID, Name: NewTrustDomain(t, td1)})
+
+		maxUses := int32(3)
+		sourceCIDR := "10.1.2.3/16"
+		params := admin.GetJoinTokenParams{
+			Ttl:        600,
+			MaxUses:    &maxUses,
+			SourceCidr: &sourceCIDR,
+		}
+		err := setup.Handler.GetJoinToken(setup.EchoCtx, td1, params)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		jtResp := admin.JoinTokenResponse{}
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &jtResp))
+		require.NotNil(t, jtResp.Id)
+
+		stored, err := setup.FakeDatabase.FindJoinTokensByID(context.Background(), *jtResp.Id)
+		require.NoError(t, err)
+		prefix, secret, err := jointoken.Parse(jtResp.Token)
+		require.NoError(t, err)
+		assert.Equal(t, prefix, stored.TokenPrefix)
+		assert.True(t, jointoken.Verify(secret, stored.TokenSalt, stored.TokenHash))
+		assert.Empty(t, stored.Token)
+		assert.Equal(t, 3, stored.MaxUses)
+		assert.Equal(t, "10.1.0.0/16", stored.SourceCIDR)
+	})
+
+	t.Run("Raise a bad request when the source CIDR is malformed", func(t *testing.T) {
+		completePath := fmt.Sprintf(trustDomainPath, td1)
+
+		setup := NewManagementTestSetup(t, http.MethodGet, completePath, nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)})
+
+		sourceCIDR := "10.0.0.0"
+		params := admin.GetJoinTokenParams{
+			Ttl:        600,
+			SourceCidr: &sourceCIDR,
+		}
+		err := setup.Handler.GetJoinToken(setup.EchoCtx, td1, params)
+		require.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHttpErr.Code)
+		assert.Contains(t, echoHttpErr.Message, "malformed source CIDR")
+	})
+
+	t.Run("Raise a conflict when trying to generate a join token for an external trust domain", func(t *testing.T) {
+		td1ID := NewNullableID()
+		completePath := fmt.Sprintf(trustDomainPath, td1)
+
+		setup := NewManagementTestSetup(t, http.MethodGet, completePath, nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: td1ID, Name: NewTrustDomain(t, td1)})
+		SetupExternalTrustDomain(t, setup.FakeDatabase, td1ID.UUID)
+
+		err := setup.Handler.GetJoinToken(setup.EchoCtx, td1, admin.GetJoinTokenParams{Ttl: 600})
+		require.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusConflict, echoHttpErr.Code)
+		assert.Equal(t, fmt.Sprintf("trust domain %q is external, its bundle is fetched from its bundle endpoint", td1), echoHttpErr.Message)
+
+		tokens, err := setup.FakeDatabase.FindJoinTokensByTrustDomainID(context.Background(), td1ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, tokens)
+	})
+
 	t.Run("Raise a bad request when trying to generates a join token for the trust domain that does not exists", func(t *testing.T) {
 		completePath := fmt.Sprintf(trustDomainPath, td1)
 
@@ -576,6 +768,146 @@ func TestUDSGetJoinToken(t *testing.T) {
 	})
 }
 
+func TestUDSListJoinTokens(t *testing.T) {
+	joinTokensPath := "/join-tokens"
+	now := time.Now()
+	tdA := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+	tdB := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td2)}
+	jtA := NewJoinToken(t, tdA.ID.UUID)
+	jtA.ID = NewNullableID()
+	jtA.ExpiresAt = now.Add(time.Hour)
+	jtB := NewJoinToken(t, tdB.ID.UUID)
+	jtB.ID = NewNullableID()
+	jtB.MaxUses = 5
+	jtB.UseCount = 2
+	jtB.ExpiresAt = now.Add(time.Hour)
+
+	t.Run("Successfully lists the join tokens of all trust domains without disclosing them", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, joinTokensPath, nil)
+		setup.FakeDatabase.WithTrustDomains(tdA, tdB)
+		setup.FakeDatabase.WithTokens(jtA, jtB)
+
+		err := setup.Handler.ListJoinTokens(setup.EchoCtx, admin.ListJoinTokensParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		// neither the tokens nor their hash are disclosed
+		for _, jt := range []*entity.JoinToken{jtA, jtB} {
+			_, secret, err := jointoken.Parse(jt.Token)
+			require.NoError(t, err)
+			assert.NotContains(t, setup.Recorder.Body.String(), secret)
+			assert.NotContains(t, setup.Recorder.Body.String(), "hash")
+		}
+
+		var tokens []*admin.JoinTokenInfo
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &tokens))
+		require.Len(t, tokens, 2)
+		for _, info := range tokens {
+			switch info.Id {
+			case jtA.ID.UUID:
+				assert.Equal(t, td1, info.TrustDomainName)
+				assert.Equal(t, jtA.TokenPrefix, info.TokenPrefix)
+				assert.Equal(t, admin.JoinTokenStatusActive, info.Status)
+			case jtB.ID.UUID:
+				assert.Equal(t, td2, info.TrustDomainName)
+				assert.Equal(t, int32(5), info.MaxUses)
+				assert.Equal(t, int32(2), info.UseCount)
+			default:
+				t.Fatalf("unexpected join token %q", info.Id)
+			}
+		}
+	})
+
+	t.Run("Successfully lists the join tokens of a trust domain", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, joinTokensPath, nil)
+		setup.FakeDatabase.WithTrustDomains(tdA, tdB)
+		setup.FakeDatabase.WithTokens(jtA, jtB)
+
+		tdName := td2
+		err := setup.Handler.ListJoinTokens(setup.EchoCtx, admin.ListJoinTokensParams{TrustDomainName: &tdName})
+		require.NoError(t, err)
+
+		var tokens []*admin.JoinTokenInfo
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &tokens))
+		require.Len(t, tokens, 1)
+		assert.Equal(t, jtB.ID.UUID, tokens[0].Id)
+	})
+
+	t.Run("Raise a not found when filtering by a trust domain that does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, joinTokensPath, nil)
+
+		tdName := td3
+		err := setup.Handler.ListJoinTokens(setup.EchoCtx, admin.ListJoinTokensParams{TrustDomainName: &tdName})
+		require.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusNotFound, echoHttpErr.Code)
+	})
+}
+
+func TestUDSGetJoinTokenByID(t *testing.T) {
+	joinTokenPath := "/join-tokens/%v"
+	td := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+	jt := &entity.JoinToken{ID: NewNullableID(), TrustDomainID: td.ID.UUID, SourceCIDR: "10.0.0.0/8", ExpiresAt: time.Now().Add(-time.Minute)}
+
+	t.Run("Successfully gets the state of a join token", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(joinTokenPath, jt.ID.UUID), nil)
+		setup.FakeDatabase.WithTrustDomains(td)
+		setup.FakeDatabase.WithTokens(jt)
+
+		err := setup.Handler.GetJoinTokenByID(setup.EchoCtx, jt.ID.UUID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		info := admin.JoinTokenInfo{}
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &info))
+		assert.Equal(t, td1, info.TrustDomainName)
+		assert.Equal(t, admin.JoinTokenStatusExpired, info.Status)
+		assert.Equal(t, "10.0.0.0/8", *info.SourceCidr)
+	})
+
+	t.Run("Raise a not found when the join token does not exist", func(t *testing.T) {
+		id := uuid.New()
+		setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(joinTokenPath, id), nil)
+
+		err := setup.Handler.GetJoinTokenByID(setup.EchoCtx, id)
+		require.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusNotFound, echoHttpErr.Code)
+		assert.Equal(t, fmt.Sprintf("join token does not exist: %q", id), echoHttpErr.Message)
+	})
+}
+
+func TestUDSRevokeJoinToken(t *testing.T) {
+	joinTokenPath := "/join-tokens/%v"
+
+	t.Run("Successfully revokes a join token", func(t *testing.T) {
+		jt := &entity.JoinToken{ID: NewNullableID(), TrustDomainID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+		setup := NewManagementTestSetup(t, http.MethodDelete, fmt.Sprintf(joinTokenPath, jt.ID.UUID), nil)
+		setup.FakeDatabase.WithTokens(jt)
+
+		err := setup.Handler.RevokeJoinToken(setup.EchoCtx, jt.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		stored, err := setup.FakeDatabase.FindJoinTokensByID(context.Background(), jt.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("Raise a not found when the join token does not exist", func(t *testing.T) {
+		id := uuid.New()
+		setup := NewManagementTestSetup(t, http.MethodDelete, fmt.Sprintf(joinTokenPath, id), nil)
+
+		err := setup.Handler.RevokeJoinToken(setup.EchoCtx, id)
+		require.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusNotFound, echoHttpErr.Code)
+	})
+}
+
 func NewNullableID() uuid.NullUUID {
 	return uuid.NullUUID{
 		Valid: true,
@@ -607,3 +939,801 @@ func equalRelationships(r1 *api.Relationship, r2 *api.Relationship) bool {
 	return r1.Id == r2.Id && r1.TrustDomainAId == r2.TrustDomainAId && r1.TrustDomainBId == r2.TrustDomainBId &&
 		r1.TrustDomainAConsent == r2.TrustDomainAConsent && r1.TrustDomainBConsent == r2.TrustDomainBConsent
 }
+
+// assertNotified asserts that a single event of the given type was notified for the given trust domains
+func assertNotified(t *testing.T, n *fakenotifier.Notifier, eventType notification.EventType, trustDomains ...string) {
+	events := n.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, eventType, events[0].Type)
+	assert.Equal(t, trustDomains, events[0].TrustDomains)
+}
+
+func TestUDSListHarvesters(t *testing.T) {
+	harvestersPath := "/harvesters"
+
+	now := time.Now()
+	harvesters := []*entity.Harvester{
+		{TrustDomainID: tdUUID1.UUID, LastSeenAt: now.Add(-time.Minute), SourceAddress: "10.0.0.1:4321", Version: "0.1.0", TokenExpiresAt: now.Add(time.Hour)},
+		{TrustDomainID: tdUUID2.UUID, LastSeenAt: now.Add(-time.Hour), SourceAddress: "10.0.0.2:4321", Version: "0.1.0", TokenExpiresAt: now},
+	}
+
+	listHarvesters := func(t *testing.T, threshold *int32) map[string]*admin.Harvester {
+		setup := NewManagementTestSetup(t, http.MethodGet, harvestersPath, nil)
+		setup.FakeDatabase.WithTrustDomains(trustDomains...)
+		setup.FakeDatabase.WithHarvesters(harvesters...)
+
+		err := setup.Handler.ListHarvesters(setup.EchoCtx, admin.ListHarvestersParams{SilentThreshold: threshold})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response []*admin.Harvester
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		byName := make(map[string]*admin.Harvester, len(response))
+		for _, h := range response {
+			byName[h.TrustDomainName] = h
+		}
+		return byName
+	}
+
+	t.Run("Flags trust domains silent longer than the default threshold", func(t *testing.T) {
+		response := listHarvesters(t, nil)
+		require.Len(t, response, 3)
+
+		assert.False(t, response[td1].Silent)
+		assert.Equal(t, "10.0.0.1:4321", *response[td1].SourceAddress)
+		assert.Equal(t, "0.1.0", *response[td1].Version)
+
+		assert.True(t, response[td2].Silent)
+		assert.NotNil(t, response[td2].LastSeenAt)
+
+		// td3 harvester was never seen
+		assert.True(t, response[td3].Silent)
+		assert.Nil(t, response[td3].LastSeenAt)
+	})
+
+	t.Run("Flags trust domains silent longer than the given threshold", func(t *testing.T) {
+		threshold := int32(2 * time.Hour / time.Second)
+		response := listHarvesters(t, &threshold)
+		require.Len(t, response, 3)
+
+		assert.False(t, response[td1].Silent)
+		assert.False(t, response[td2].Silent)
+		assert.True(t, response[td3].Silent)
+	})
+
+	t.Run("Lists every harvester instance of a trust domain", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, harvestersPath, nil)
+		setup.FakeDatabase.WithTrustDomains(entTD1)
+		setup.FakeDatabase.WithHarvesters(
+			&entity.Harvester{TrustDomainID: tdUUID1.UUID, InstanceID: "spire-server-1", LastSeenAt: now.Add(-time.Minute)},
+			&entity.Harvester{TrustDomainID: tdUUID1.UUID, InstanceID: "spire-server-2", LastSeenAt: now.Add(-time.Hour)},
+		)
+
+		err := setup.Handler.ListHarvesters(setup.EchoCtx, admin.ListHarvestersParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response []*admin.Harvester
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		require.Len(t, response, 2)
+
+		byInstance := make(map[string]*admin.Harvester, len(response))
+		for _, h := range response {
+			assert.Equal(t, td1, h.TrustDomainName)
+			byInstance[*h.InstanceId] = h
+		}
+		assert.False(t, byInstance["spire-server-1"].Silent)
+		assert.True(t, byInstance["spire-server-2"].Silent)
+	})
+
+	t.Run("Fails with an invalid threshold", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, harvestersPath, nil)
+
+		threshold := int32(0)
+		err := setup.Handler.ListHarvesters(setup.EchoCtx, admin.ListHarvestersParams{SilentThreshold: &threshold})
+		require.Error(t, err)
+
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHTTPErr.Code)
+		assert.Equal(t, "silent threshold must be greater than 0", echoHTTPErr.Message)
+	})
+}
+
+func TestUDSGetFederationStatus(t *testing.T) {
+	federationStatusPath := "/federation/status"
+
+	now := time.Now()
+	approvedRel := &entity.Relationship{ID: r1ID, TrustDomainAID: tdUUID1.UUID, TrustDomainBID: tdUUID2.UUID, TrustDomainAName: spiffeTD1, TrustDomainBName: spiffeTD2, TrustDomainAConsent: entity.ConsentStatus(api.Approved), TrustDomainBConsent: entity.ConsentStatus(api.Approved)}
+	bundle1 := &entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte("bundle-1"), Digest: []byte("digest-1"), UpdatedAt: now.Add(-time.Minute)}
+	bundle2 := &entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID2.UUID, Data: []byte("bundle-2"), Digest: []byte("digest-2"), UpdatedAt: now.Add(-time.Minute)}
+	states := []*entity.BundleSyncState{
+		// td1 holds td2's latest bundle
+		{TrustDomainID: tdUUID1.UUID, FederatedTrustDomain: spiffeTD2, Digest: []byte("digest-2"), ReportedAt: now},
+		// td2 holds an outdated td1 bundle
+		{TrustDomainID: tdUUID2.UUID, FederatedTrustDomain: spiffeTD1, Digest: []byte("old-digest-1"), ReportedAt: now},
+		// td2 holds td3's bundle without an approved relationship
+		{TrustDomainID: tdUUID2.UUID, FederatedTrustDomain: spiffeTD3, Digest: []byte("digest-3"), ReportedAt: now},
+	}
+
+	setup := NewManagementTestSetup(t, http.MethodGet, federationStatusPath, nil)
+	setup.FakeDatabase.WithTrustDomains(trustDomains...)
+	setup.FakeDatabase.WithRelationships(approvedRel, rel5)
+	setup.FakeDatabase.WithBundles(bundle1, bundle2)
+	setup.FakeDatabase.WithBundleSyncStates(states...)
+
+	err := setup.Handler.GetFederationStatus(setup.EchoCtx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	var response admin.FederationStatus
+	err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.Len(t, response.Relationships, 1)
+	rel := response.Relationships[0]
+	assert.Equal(t, r1ID.UUID, rel.RelationshipId)
+
+	assert.Equal(t, td1, rel.TrustDomainA.TrustDomainName)
+	assert.Equal(t, td2, rel.TrustDomainA.PeerTrustDomainName)
+	assert.True(t, rel.TrustDomainA.InSync)
+	assert.Zero(t, rel.TrustDomainA.LagSeconds)
+
+	assert.Equal(t, td2, rel.TrustDomainB.TrustDomainName)
+	assert.Equal(t, td1, rel.TrustDomainB.PeerTrustDomainName)
+	assert.False(t, rel.TrustDomainB.InSync)
+	assert.GreaterOrEqual(t, rel.TrustDomainB.LagSeconds, int64(60))
+
+	require.Len(t, response.UnexpectedBundles, 1)
+	assert.Equal(t, td2, response.UnexpectedBundles[0].TrustDomainName)
+	assert.Equal(t, td3, response.UnexpectedBundles[0].FederatedTrustDomainName)
+}
+
+func TestUDSPutExternalTrustDomain(t *testing.T) {
+	externalPath := "/trust-domain/%v/external"
+	endpointID := "spiffe://" + td1 + "/spire/server"
+	otherEndpointID := "spiffe://" + td2 + "/spire/server"
+	malformedBundle := "not a bundle"
+
+	t.Run("Successfully configure an https_spiffe external trust domain and store its bootstrap bundle", func(t *testing.T) {
+		bootstrap := newTestSPIFFEBundle(t, 1)
+		reqBody := &admin.PutExternalTrustDomainRequest{
+			BundleEndpointUrl:     "https://spire.td1.org:8443",
+			BundleEndpointProfile: "https_spiffe",
+			EndpointSpiffeId:      &endpointID,
+			BootstrapBundle:       &bootstrap,
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(externalPath, td1), reqBody)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+		err := setup.Handler.PutExternalTrustDomain(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.ExternalTrustDomain
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, td1, response.TrustDomainName)
+		assert.Equal(t, "https://spire.td1.org:8443", response.BundleEndpointUrl)
+		assert.Equal(t, "https_spiffe", response.BundleEndpointProfile)
+		assert.Equal(t, &endpointID, response.EndpointSpiffeId)
+		assert.Nil(t, response.LastRefreshAt)
+
+		stored, err := setup.FakeDatabase.FindExternalTrustDomainByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, []byte(bootstrap), stored.BootstrapBundle)
+
+		bundle, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, bundle)
+		assert.Equal(t, []byte(bootstrap), bundle.Data)
+		assert.Equal(t, entity.BundleVerificationSkipped, bundle.VerificationStatus)
+		assertNotified(t, setup.FakeNotifier, notification.EventBundleUpdated, td1)
+	})
+
+	t.Run("Successfully configure an https_web external trust domain without bootstrap bundle", func(t *testing.T) {
+		reqBody := &admin.PutExternalTrustDomainRequest{
+			BundleEndpointUrl:     "https://bundle.td1.org",
+			BundleEndpointProfile: "https_web",
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(externalPath, td1), reqBody)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+		err := setup.Handler.PutExternalTrustDomain(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		bundle, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, bundle)
+	})
+
+	t.Run("Keep the stored bundle when the trust domain already has one", func(t *testing.T) {
+		storedBundle := &entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte(newTestSPIFFEBundle(t, 5))}
+		bootstrap := newTestSPIFFEBundle(t, 1)
+		reqBody := &admin.PutExternalTrustDomainRequest{
+			BundleEndpointUrl:     "https://spire.td1.org:8443",
+			BundleEndpointProfile: "https_spiffe",
+			EndpointSpiffeId:      &endpointID,
+			BootstrapBundle:       &bootstrap,
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(externalPath, td1), reqBody)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithBundles(storedBundle)
+
+		err := setup.Handler.PutExternalTrustDomain(setup.EchoCtx, td1)
+		require.NoError(t, err)
+
+		bundle, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, storedBundle.Data, bundle.Data)
+	})
+
+	t.Run("Fail with a bootstrap bundle that does not comply with the bundle policy", func(t *testing.T) {
+		bootstrap := newTestSPIFFEBundle(t, 1)
+		reqBody := &admin.PutExternalTrustDomainRequest{
+			BundleEndpointUrl:     "https://spire.td1.org:8443",
+			BundleEndpointProfile: "https_spiffe",
+			EndpointSpiffeId:      &endpointID,
+			BootstrapBundle:       &bootstrap,
+		}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(externalPath, td1), reqBody)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		policy, err := bundlepolicy.New(&bundlepolicy.Config{AllowedKeyAlgorithms: []string{bundlepolicy.KeyAlgorithmEd25519}})
+		require.NoError(t, err)
+		setup.Handler.BundlePolicy = policy
+
+		err = setup.Handler.PutExternalTrustDomain(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Regexp(t, `^bootstrap bundle does not comply with the bundle policy: x509_authorities\[0\]: key algorithm "[a-z]+" is not allowed$`, err.(*echo.HTTPError).Message)
+
+		externalTD, err := setup.FakeDatabase.FindExternalTrustDomainByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, externalTD)
+	})
+
+	badRequests := []struct {
+		name   string
+		req    *admin.PutExternalTrustDomainRequest
+		errMsg string
+	}{
+		{
+			name:   "Non-https URL",
+			req:    &admin.PutExternalTrustDomainRequest{BundleEndpointUrl: "http://bundle.td1.org", BundleEndpointProfile: "https_web"},
+			errMsg: "invalid external trust domain: bundle endpoint URL must be an absolute https URL: \"http://bundle.td1.org\"",
+		},
+		{
+			name:   "Unknown profile",
+			req:    &admin.PutExternalTrustDomainRequest{BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_other"},
+			errMsg: "invalid external trust domain: unknown bundle endpoint profile \"https_other\"",
+		},
+		{
+			name:   "https_spiffe without endpoint SPIFFE ID",
+			req:    &admin.PutExternalTrustDomainRequest{BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_spiffe"},
+			errMsg: "invalid external trust domain: endpoint SPIFFE ID is required by the \"https_spiffe\" profile",
+		},
+		{
+			name:   "Endpoint SPIFFE ID of another trust domain",
+			req:    &admin.PutExternalTrustDomainRequest{BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_spiffe", EndpointSpiffeId: &otherEndpointID},
+			errMsg: fmt.Sprintf("invalid external trust domain: endpoint SPIFFE ID \"spiffe://%s/spire/server\" is not a member of trust domain \"%s\"", td2, td1),
+		},
+		{
+			name:   "https_spiffe without bootstrap bundle",
+			req:    &admin.PutExternalTrustDomainRequest{BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_spiffe", EndpointSpiffeId: &endpointID},
+			errMsg: "a bootstrap bundle is required to authenticate the bundle endpoint of the \"https_spiffe\" profile",
+		},
+		{
+			name:   "Malformed bootstrap bundle",
+			req:    &admin.PutExternalTrustDomainRequest{BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_web", BootstrapBundle: &malformedBundle},
+			errMsg: "invalid external trust domain: malformed bootstrap bundle",
+		},
+	}
+	for _, tt := range badRequests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(externalPath, td1), tt.req)
+			setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+			err := setup.Handler.PutExternalTrustDomain(setup.EchoCtx, td1)
+			require.Error(t, err)
+
+			echoHTTPErr := err.(*echo.HTTPError)
+			assert.Equal(t, http.StatusBadRequest, echoHTTPErr.Code)
+			assert.Contains(t, echoHTTPErr.Message, tt.errMsg)
+
+			externalTD, err := setup.FakeDatabase.FindExternalTrustDomainByTrustDomainID(context.Background(), tdUUID1.UUID)
+			require.NoError(t, err)
+			assert.Nil(t, externalTD)
+		})
+	}
+
+	t.Run("Raise a not found when the trust domain does not exist", func(t *testing.T) {
+		reqBody := &admin.PutExternalTrustDomainRequest{BundleEndpointUrl: "https://bundle.td1.org", BundleEndpointProfile: "https_web"}
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(externalPath, td1), reqBody)
+
+		err := setup.Handler.PutExternalTrustDomain(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestUDSGetExternalTrustDomain(t *testing.T) {
+	externalPath := "/trust-domain/%v/external"
+	refreshedAt := time.Now().Add(-time.Minute).Round(time.Second)
+
+	t.Run("Successfully retrieve the refresh status of an external trust domain", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(externalPath, td1), nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithExternalTrustDomains(&entity.ExternalTrustDomain{
+			TrustDomainID:         tdUUID1.UUID,
+			BundleEndpointURL:     "https://bundle.td1.org",
+			BundleEndpointProfile: "https_web",
+			LastRefreshAt:         refreshedAt,
+			LastRefreshError:      "failed to fetch bundle",
+		})
+
+		err := setup.Handler.GetExternalTrustDomain(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.ExternalTrustDomain
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "https://bundle.td1.org", response.BundleEndpointUrl)
+		assert.Nil(t, response.EndpointSpiffeId)
+		require.NotNil(t, response.LastRefreshAt)
+		assert.True(t, refreshedAt.Equal(*response.LastRefreshAt))
+		assert.Equal(t, "failed to fetch bundle", *response.LastRefreshError)
+	})
+
+	t.Run("Raise a not found when the trust domain is not external", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(externalPath, td1), nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+		err := setup.Handler.GetExternalTrustDomain(setup.EchoCtx, td1)
+		require.Error(t, err)
+
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusNotFound, echoHTTPErr.Code)
+		assert.Equal(t, fmt.Sprintf("trust domain is not external: %q", td1), echoHTTPErr.Message)
+	})
+}
+
+func TestUDSDeleteExternalTrustDomain(t *testing.T) {
+	externalPath := "/trust-domain/%v/external"
+	bundle := &entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte("bundle-1")}
+
+	setup := NewManagementTestSetup(t, http.MethodDelete, fmt.Sprintf(externalPath, td1), nil)
+	setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+	setup.FakeDatabase.WithBundles(bundle)
+	setup.FakeDatabase.WithExternalTrustDomains(&entity.ExternalTrustDomain{
+		TrustDomainID:         tdUUID1.UUID,
+		BundleEndpointURL:     "https://bundle.td1.org",
+		BundleEndpointProfile: "https_web",
+	})
+
+	err := setup.Handler.DeleteExternalTrustDomain(setup.EchoCtx, td1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	externalTD, err := setup.FakeDatabase.FindExternalTrustDomainByTrustDomainID(context.Background(), tdUUID1.UUID)
+	require.NoError(t, err)
+	assert.Nil(t, externalTD)
+
+	// the bundle of the trust domain is kept
+	stored, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored)
+
+	setup.Refresh()
+	err = setup.Handler.DeleteExternalTrustDomain(setup.EchoCtx, td1)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
+
+func TestUDSPutTrustDomainBundle(t *testing.T) {
+	bundlePath := "/trust-domain/%v/bundle"
+
+	newRequest := func(bundle string) *admin.PutTrustDomainBundleRequest {
+		return &admin.PutTrustDomainBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+		}
+	}
+
+	t.Run("Successfully set an admin-managed bundle", func(t *testing.T) {
+		bundle := newTestSPIFFEBundle(t, 1)
+
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(bundlePath, td1), newRequest(bundle))
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+		err := setup.Handler.PutTrustDomainBundle(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.TrustDomainBundle
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, td1, response.TrustDomainName)
+		assert.Equal(t, bundle, response.TrustBundle)
+		assert.True(t, response.AdminManaged)
+		assert.Equal(t, string(entity.BundleVerificationSkipped), response.VerificationStatus)
+
+		stored, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.True(t, stored.AdminManaged)
+		assert.Equal(t, []byte(bundle), stored.Data)
+		assertNotified(t, setup.FakeNotifier, notification.EventBundleUpdated, td1)
+	})
+
+	t.Run("Successfully replace a bundle uploaded by a Harvester with a signed bundle", func(t *testing.T) {
+		signer, verifier := newBundleSignerAndVerifier(t)
+		bundle := newTestSPIFFEBundle(t, 2)
+		signature, chain, err := signer.Sign([]byte(bundle))
+		require.NoError(t, err)
+		req := newRequest(bundle)
+		sig := encoding.EncodeToBase64(signature)
+		cert := encoding.EncodeToBase64(chain[0].Raw)
+		req.Signature = &sig
+		req.SigningCertificate = &cert
+
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(bundlePath, td1), req)
+		setup.Handler.BundleVerifiers = bundleverifier.NewSet([]*bundleverifier.Provider{{Name: "disk", Verifier: verifier}})
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithBundles(&entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte(newTestSPIFFEBundle(t, 1))})
+
+		err = setup.Handler.PutTrustDomainBundle(setup.EchoCtx, td1)
+		require.NoError(t, err)
+
+		stored, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		assert.True(t, stored.AdminManaged)
+		assert.Equal(t, []byte(bundle), stored.Data)
+		assert.Equal(t, entity.BundleVerificationVerified, stored.VerificationStatus)
+		assert.Equal(t, "disk", stored.VerifiedBy)
+	})
+
+	t.Run("Fail with a digest that does not match the bundle", func(t *testing.T) {
+		req := newRequest(newTestSPIFFEBundle(t, 1))
+		req.Digest = encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte("other")))
+
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(bundlePath, td1), req)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+		err := setup.Handler.PutTrustDomainBundle(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "invalid bundle request: failed validating bundle digest")
+	})
+
+	t.Run("Fail with a bundle that does not comply with the bundle policy", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(bundlePath, td1), newRequest("not a bundle"))
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+		err := setup.Handler.PutTrustDomainBundle(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "bundle does not comply with the bundle policy")
+
+		stored, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("Fail with a stale bundle", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(bundlePath, td1), newRequest(newTestSPIFFEBundle(t, 1)))
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithBundles(&entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte(newTestSPIFFEBundle(t, 3))})
+
+		err := setup.Handler.PutTrustDomainBundle(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "stale bundle")
+	})
+
+	t.Run("Fail for an external trust domain", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(bundlePath, td1), newRequest(newTestSPIFFEBundle(t, 1)))
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithExternalTrustDomains(&entity.ExternalTrustDomain{TrustDomainID: tdUUID1.UUID, BundleEndpointURL: "https://bundle.td1.org", BundleEndpointProfile: "https_web"})
+
+		err := setup.Handler.PutTrustDomainBundle(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+		assert.Equal(t, fmt.Sprintf("the bundle of trust domain %q is fetched from its bundle endpoint", td1), err.(*echo.HTTPError).Message)
+	})
+}
+
+func TestUDSGetTrustDomainBundle(t *testing.T) {
+	bundlePath := "/trust-domain/%v/bundle"
+
+	t.Run("Successfully retrieve the bundle of a trust domain", func(t *testing.T) {
+		bundle := &entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte("bundle-1"), Digest: []byte("digest-1"), VerificationStatus: entity.BundleVerificationSkipped}
+
+		setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(bundlePath, td1), nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithBundles(bundle)
+
+		err := setup.Handler.GetTrustDomainBundle(setup.EchoCtx, td1)
+		require.NoError(t, err)
+
+		var response admin.TrustDomainBundle
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "bundle-1", response.TrustBundle)
+		assert.Equal(t, encoding.EncodeToBase64([]byte("digest-1")), response.Digest)
+		assert.False(t, response.AdminManaged)
+	})
+
+	t.Run("Raise a not found when the trust domain has no bundle", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(bundlePath, td1), nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+
+		err := setup.Handler.GetTrustDomainBundle(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+		assert.Equal(t, fmt.Sprintf("trust domain has no bundle: %q", td1), err.(*echo.HTTPError).Message)
+	})
+}
+
+func TestUDSDeleteTrustDomainBundle(t *testing.T) {
+	bundlePath := "/trust-domain/%v/bundle"
+
+	t.Run("Successfully delete an admin-managed bundle", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodDelete, fmt.Sprintf(bundlePath, td1), nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithBundles(&entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte("bundle-1"), AdminManaged: true})
+
+		err := setup.Handler.DeleteTrustDomainBundle(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		stored, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("Fail to delete a bundle uploaded by a Harvester", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodDelete, fmt.Sprintf(bundlePath, td1), nil)
+		setup.FakeDatabase.WithTrustDomains(&entity.TrustDomain{ID: tdUUID1, Name: spiffeTD1})
+		setup.FakeDatabase.WithBundles(&entity.Bundle{ID: NewNullableID(), TrustDomainID: tdUUID1.UUID, Data: []byte("bundle-1")})
+
+		err := setup.Handler.DeleteTrustDomainBundle(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+
+		stored, err := setup.FakeDatabase.FindBundleByTrustDomainID(context.Background(), tdUUID1.UUID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+	})
+}
+
+func TestUDSPutFederationGroup(t *testing.T) {
+	groupsPath := "/federation-groups"
+
+	t.Run("Successfully create a federation group", func(t *testing.T) {
+		description := "partners"
+		req := &admin.PutFederationGroupRequest{Name: "mesh", Description: &description}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, groupsPath, req)
+
+		err := setup.Handler.PutFederationGroup(setup.EchoCtx)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.FederationGroup
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "mesh", response.Name)
+		assert.Equal(t, &description, response.Description)
+		assert.Equal(t, api.Pending, response.DefaultConsent)
+		assert.Empty(t, response.Members)
+
+		stored, err := setup.FakeDatabase.FindFederationGroupByName(context.Background(), "mesh")
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, response.Id, stored.ID.UUID)
+	})
+
+	t.Run("Successfully update the default consent of a federation group", func(t *testing.T) {
+		consent := api.Approved
+		req := &admin.PutFederationGroupRequest{Name: "mesh", DefaultConsent: &consent}
+		group := &entity.FederationGroup{ID: NewNullableID(), Name: "mesh", DefaultConsent: entity.ConsentStatusPending}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, groupsPath, req)
+		setup.FakeDatabase.WithFederationGroups(group)
+
+		err := setup.Handler.PutFederationGroup(setup.EchoCtx)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.FederationGroup
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, group.ID.UUID, response.Id)
+		assert.Equal(t, api.Approved, response.DefaultConsent)
+
+		groups, err := setup.FakeDatabase.ListFederationGroups(context.Background())
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, entity.ConsentStatusApproved, groups[0].DefaultConsent)
+	})
+
+	t.Run("Fail with a denied default consent", func(t *testing.T) {
+		consent := api.Denied
+		req := &admin.PutFederationGroupRequest{Name: "mesh", DefaultConsent: &consent}
+
+		setup := NewManagementTestSetup(t, http.MethodPut, groupsPath, req)
+
+		err := setup.Handler.PutFederationGroup(setup.EchoCtx)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestUDSGetFederationGroup(t *testing.T) {
+	group := &entity.FederationGroup{ID: NewNullableID(), Name: "mesh", DefaultConsent: entity.ConsentStatusApproved}
+
+	t.Run("Successfully get a federation group and its members", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, "/federation-groups/mesh", nil)
+		setup.FakeDatabase.WithTrustDomains(trustDomains...)
+		setup.FakeDatabase.WithFederationGroups(group)
+		setup.FakeDatabase.WithFederationGroupMembers(
+			&entity.FederationGroupMember{FederationGroupID: group.ID.UUID, TrustDomainID: tdUUID1.UUID, CreatedAt: time.Now().Add(-time.Minute)},
+			&entity.FederationGroupMember{FederationGroupID: group.ID.UUID, TrustDomainID: tdUUID2.UUID, CreatedAt: time.Now()},
+		)
+
+		err := setup.Handler.GetFederationGroup(setup.EchoCtx, "mesh")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.FederationGroup
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "mesh", response.Name)
+		assert.Equal(t, api.Approved, response.DefaultConsent)
+		assert.Equal(t, []string{td1, td2}, response.Members)
+	})
+
+	t.Run("Fail with an unknown federation group", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, "/federation-groups/unknown", nil)
+
+		err := setup.Handler.GetFederationGroup(setup.EchoCtx, "unknown")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+		assert.Equal(t, `federation group does not exist: "unknown"`, err.(*echo.HTTPError).Message)
+	})
+}
+
+func TestUDSListFederationGroups(t *testing.T) {
+	now := time.Now()
+	group1 := &entity.FederationGroup{ID: NewNullableID(), Name: "mesh", CreatedAt: now.Add(-time.Hour)}
+	group2 := &entity.FederationGroup{ID: NewNullableID(), Name: "other", CreatedAt: now}
+
+	setup := NewManagementTestSetup(t, http.MethodGet, "/federation-groups", nil)
+	setup.FakeDatabase.WithTrustDomains(trustDomains...)
+	setup.FakeDatabase.WithFederationGroups(group1, group2)
+	setup.FakeDatabase.WithFederationGroupMembers(&entity.FederationGroupMember{FederationGroupID: group2.ID.UUID, TrustDomainID: tdUUID3.UUID})
+
+	err := setup.Handler.ListFederationGroups(setup.EchoCtx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	var response []*admin.FederationGroup
+	err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response, 2)
+	assert.Equal(t, "mesh", response[0].Name)
+	assert.Empty(t, response[0].Members)
+	assert.Equal(t, "other", response[1].Name)
+	assert.Equal(t, []string{td3}, response[1].Members)
+}
+
+func TestUDSDeleteFederationGroup(t *testing.T) {
+	group := &entity.FederationGroup{ID: NewNullableID(), Name: "mesh"}
+
+	setup := NewManagementTestSetup(t, http.MethodDelete, "/federation-groups/mesh", nil)
+	setup.FakeDatabase.WithFederationGroups(group)
+	setup.FakeDatabase.WithFederationGroupMembers(&entity.FederationGroupMember{FederationGroupID: group.ID.UUID, TrustDomainID: tdUUID1.UUID})
+
+	err := setup.Handler.DeleteFederationGroup(setup.EchoCtx, "mesh")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	stored, err := setup.FakeDatabase.FindFederationGroupByName(context.Background(), "mesh")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	members, err := setup.FakeDatabase.FindFederationGroupMembersByGroupID(context.Background(), group.ID.UUID)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	setup.Refresh()
+	err = setup.Handler.DeleteFederationGroup(setup.EchoCtx, "mesh")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
+
+func TestUDSPutFederationGroupMember(t *testing.T) {
+	membersPath := "/federation-groups/mesh/members"
+	group := &entity.FederationGroup{ID: NewNullableID(), Name: "mesh"}
+
+	t.Run("Successfully add a member", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPut, membersPath, &admin.PutFederationGroupMemberRequest{TrustDomainName: td1})
+		setup.FakeDatabase.WithTrustDomains(trustDomains...)
+		setup.FakeDatabase.WithFederationGroups(group)
+
+		err := setup.Handler.PutFederationGroupMember(setup.EchoCtx, "mesh")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.FederationGroup
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, []string{td1}, response.Members)
+	})
+
+	t.Run("Fail to add a member twice", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPut, membersPath, &admin.PutFederationGroupMemberRequest{TrustDomainName: td1})
+		setup.FakeDatabase.WithTrustDomains(trustDomains...)
+		setup.FakeDatabase.WithFederationGroups(group)
+		setup.FakeDatabase.WithFederationGroupMembers(&entity.FederationGroupMember{FederationGroupID: group.ID.UUID, TrustDomainID: tdUUID1.UUID})
+
+		err := setup.Handler.PutFederationGroupMember(setup.EchoCtx, "mesh")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("Fail with an unknown trust domain", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPut, membersPath, &admin.PutFederationGroupMemberRequest{TrustDomainName: "unknown.org"})
+		setup.FakeDatabase.WithFederationGroups(group)
+
+		err := setup.Handler.PutFederationGroupMember(setup.EchoCtx, "mesh")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("Fail with an unknown federation group", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPut, membersPath, &admin.PutFederationGroupMemberRequest{TrustDomainName: td1})
+		setup.FakeDatabase.WithTrustDomains(trustDomains...)
+
+		err := setup.Handler.PutFederationGroupMember(setup.EchoCtx, "mesh")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestUDSDeleteFederationGroupMember(t *testing.T) {
+	memberPath := "/federation-groups/mesh/members/%v"
+	group := &entity.FederationGroup{ID: NewNullableID(), Name: "mesh"}
+
+	setup := NewManagementTestSetup(t, http.MethodDelete, fmt.Sprintf(memberPath, td1), nil)
+	setup.FakeDatabase.WithTrustDomains(trustDomains...)
+	setup.FakeDatabase.WithFederationGroups(group)
+	setup.FakeDatabase.WithFederationGroupMembers(
+		&entity.FederationGroupMember{FederationGroupID: group.ID.UUID, TrustDomainID: tdUUID1.UUID},
+		&entity.FederationGroupMember{FederationGroupID: group.ID.UUID, TrustDomainID: tdUUID2.UUID},
+	)
+
+	err := setup.Handler.DeleteFederationGroupMember(setup.EchoCtx, "mesh", td1)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	var response admin.FederationGroup
+	err = json.Unmarshal(setup.Recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, []string{td2}, response.Members)
+
+	setup.Refresh()
+	err = setup.Handler.DeleteFederationGroupMember(setup.EchoCtx, "mesh", td1)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	assert.Equal(t, fmt.Sprintf("trust domain %q is not a member of federation group %q", td1, "mesh"), err.(*echo.HTTPError).Message)
+}
@@ -1,66 +1,310 @@
 package endpoints
 
 import (
+	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/dpop"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	gojwt "github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 )
 
 type AuthenticationMiddleware struct {
-	datastore    db.Datastore
-	jwtValidator jwt.Validator
-	logger       logrus.FieldLogger
+	datastore     db.Datastore
+	jwtValidator  jwt.Validator
+	x509CA        x509ca.X509CA
+	proofVerifier *dpop.Verifier
+	logger        logrus.FieldLogger
 }
 
-func NewAuthenticationMiddleware(l logrus.FieldLogger, ds db.Datastore, jwtValidator jwt.Validator) *AuthenticationMiddleware {
+func NewAuthenticationMiddleware(l logrus.FieldLogger, ds db.Datastore, jwtValidator jwt.Validator, x509CA x509ca.X509CA) *AuthenticationMiddleware {
 	return &AuthenticationMiddleware{
-		logger:       l,
-		datastore:    ds,
-		jwtValidator: jwtValidator,
+		logger:        l,
+		datastore:     ds,
+		jwtValidator:  jwtValidator,
+		x509CA:        x509CA,
+		proofVerifier: newProofVerifier(ds),
 	}
 }
 
 // Authenticate is the middleware method that is responsible for authenticating the calling Harvester using the JWT token
-// passed in the Authorization header.
+// passed in the Authorization header. Tokens bound to a key must be sent along with a proof of possession of the key.
 func (m *AuthenticationMiddleware) Authenticate(bearerToken string, echoCtx echo.Context) (bool, error) {
 	ctx := echoCtx.Request().Context()
 
+	td, claims, err := m.validateToken(ctx, bearerToken, echoCtx.Request())
+	if err != nil {
+		return false, err
+	}
+
+	// set the authenticated trust domain ID in the echo context
+	echoCtx.Set(authTrustDomainKey, td)
+	// set the authenticated claims in the echo context
+	echoCtx.Set(authClaimsKey, claims)
+	// set the authenticated harvester instance in the echo context
+	instanceID := harvesterInstanceID(claims)
+	echoCtx.Set(authInstanceIDKey, instanceID)
+
+	m.recordHarvester(ctx, td, instanceID, claims.ExpiresAt, echoCtx.Request())
+
+	return true, nil
+}
+
+// AuthenticateClientCertificate authenticates the calling Harvester using the TLS client certificate issued to it by
+// the Galadriel Server CA. It returns false, and no error, when the Harvester didn't present such certificate, so
+// that it can be authenticated with its JWT token instead. When the request also carries a JWT token, it must be
+// valid and issued to the same harvester instance as the certificate.
+func (m *AuthenticationMiddleware) AuthenticateClientCertificate(echoCtx echo.Context) (bool, error) {
+	req := echoCtx.Request()
+	ctx := req.Context()
+
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return false, nil
+	}
+
+	id, ok, err := m.verifyClientCertificate(ctx, req.TLS.PeerCertificates)
+	if err != nil {
+		msg := "failed to verify client certificate"
+		return false, chttp.LogAndRespondWithError(m.logger, err, msg, http.StatusInternalServerError)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	instanceID, ok := harvesterClientInstanceID(id)
+	if !ok {
+		return false, nil
+	}
+
+	td, err := m.datastore.FindTrustDomainByName(ctx, id.TrustDomain())
+	if err != nil {
+		return false, chttp.LogAndRespondWithError(m.logger, err, "invalid client certificate: trust domain not found", http.StatusUnauthorized)
+	}
+
+	if td == nil {
+		msg := fmt.Sprintf("trust domain not found: %q", id.TrustDomain())
+		return false, chttp.LogAndRespondWithError(m.logger, nil, msg, http.StatusUnauthorized)
+	}
+
+	var claims *jwt.Claims
+	if bearerToken, ok := bearerTokenFromRequest(req); ok {
+		tokenTD, tokenClaims, err := m.validateToken(ctx, bearerToken, req)
+		if err != nil {
+			return false, err
+		}
+		if tokenTD.Name != td.Name || harvesterInstanceID(tokenClaims) != instanceID {
+			msg := "JWT token and client certificate identify different harvesters"
+			return false, chttp.LogAndRespondWithError(m.logger, nil, msg, http.StatusUnauthorized)
+		}
+
+		claims = tokenClaims
+		echoCtx.Set(authClaimsKey, claims)
+	}
+
+	echoCtx.Set(authTrustDomainKey, td)
+	echoCtx.Set(authInstanceIDKey, instanceID)
+
+	var expiresAt *gojwt.NumericDate
+	if claims != nil {
+		expiresAt = claims.ExpiresAt
+	}
+	m.recordHarvester(ctx, td, instanceID, expiresAt, req)
+
+	return true, nil
+}
+
+// validateToken validates the JWT token, and the proof of possession of the key it is bound to if any, and looks up
+// the trust domain it was issued to.
+func (m *AuthenticationMiddleware) validateToken(ctx context.Context, bearerToken string, req *http.Request) (*entity.TrustDomain, *jwt.Claims, error) {
 	claims, err := m.jwtValidator.ValidateToken(ctx, bearerToken)
 	if err != nil {
 		msg := "invalid JWT authentication token"
-		return false, chttp.LogAndRespondWithError(m.logger, err, msg, http.StatusUnauthorized)
+		return nil, nil, chttp.LogAndRespondWithError(m.logger, err, msg, http.StatusUnauthorized)
 	}
 
 	subject := claims.Subject
 	if subject == "" {
-		return false, chttp.LogAndRespondWithError(m.logger, err, "invalid token: missing subject", http.StatusUnauthorized)
+		return nil, nil, chttp.LogAndRespondWithError(m.logger, err, "invalid token: missing subject", http.StatusUnauthorized)
 	}
 
 	tdName, err := spiffeid.TrustDomainFromString(subject)
 	if err != nil {
-		return false, chttp.LogAndRespondWithError(m.logger, err, "invalid token: invalid trust domain name", http.StatusUnauthorized)
+		return nil, nil, chttp.LogAndRespondWithError(m.logger, err, "invalid token: invalid trust domain name", http.StatusUnauthorized)
 	}
 
 	td, err := m.datastore.FindTrustDomainByName(ctx, tdName)
 	if err != nil {
-		return false, chttp.LogAndRespondWithError(m.logger, err, "invalid token: trust domain not found", http.StatusUnauthorized)
+		return nil, nil, chttp.LogAndRespondWithError(m.logger, err, "invalid token: trust domain not found", http.StatusUnauthorized)
 	}
 
 	if td == nil {
 		msg := fmt.Sprintf("trust domain not found: %q", tdName)
-		return false, chttp.LogAndRespondWithError(m.logger, nil, msg, http.StatusUnauthorized)
+		return nil, nil, chttp.LogAndRespondWithError(m.logger, nil, msg, http.StatusUnauthorized)
 	}
 
-	// set the authenticated trust domain ID in the echo context
-	echoCtx.Set(authTrustDomainKey, td)
-	// set the authenticated claims in the echo context
-	echoCtx.Set(authClaimsKey, claims)
+	if keyThumbprint := claims.KeyThumbprint(); keyThumbprint != "" {
+		proofThumbprint, err := m.proofVerifier.Verify(ctx, req.Header.Get(dpop.HeaderName), req.Method, requestURL(req), bearerToken)
+		if err != nil {
+			return nil, nil, respondProofError(m.logger, err, "invalid token: invalid proof of possession", http.StatusUnauthorized)
+		}
+		if proofThumbprint != keyThumbprint {
+			return nil, nil, chttp.LogAndRespondWithError(m.logger, nil, "invalid token: proof signed with another key", http.StatusUnauthorized)
+		}
+	}
 
-	return true, nil
+	return td, claims, nil
+}
+
+// verifyClientCertificate verifies the client certificate chain against the Galadriel Server CA authorities and
+// returns the SPIFFE ID of the leaf certificate. Certificates that were not issued by the Galadriel Server CA, such
+// as the X509-SVIDs presented on onboarding, are not verified.
+func (m *AuthenticationMiddleware) verifyClientCertificate(ctx context.Context, certs []*x509.Certificate) (spiffeid.ID, bool, error) {
+	authorities, err := m.x509CA.GetX509Authorities(ctx)
+	if err != nil {
+		return spiffeid.ID{}, false, err
+	}
+
+	roots := x509.NewCertPool()
+	for _, authority := range authorities {
+		roots.AddCert(authority)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		m.logger.WithError(err).Debug("Client certificate not issued by the Galadriel Server CA")
+		return spiffeid.ID{}, false, nil
+	}
+
+	id, err := x509svid.IDFromCert(certs[0])
+	if err != nil {
+		return spiffeid.ID{}, false, nil
+	}
+
+	return id, true, nil
+}
+
+// recordHarvester stores the metadata of the authenticated Harvester instance of the trust domain.
+// Failing to store it doesn't fail the request, as it is only used for reporting.
+func (m *AuthenticationMiddleware) recordHarvester(ctx context.Context, td *entity.TrustDomain, instanceID string, expiresAt *gojwt.NumericDate, req *http.Request) {
+	harvester := &entity.Harvester{
+		TrustDomainID:                td.ID.UUID,
+		InstanceID:                   instanceID,
+		LastSeenAt:                   time.Now(),
+		SourceAddress:                req.RemoteAddr,
+		Version:                      req.Header.Get(constants.HarvesterVersionHeader),
+		SpireBundlePollInterval:      m.parseInterval(req, constants.HarvesterSpireBundlePollIntervalHeader),
+		FederatedBundlesPollInterval: m.parseInterval(req, constants.HarvesterFederatedBundlesPollIntervalHeader),
+	}
+	if expiresAt != nil {
+		harvester.TokenExpiresAt = expiresAt.Time
+	}
+
+	if _, err := m.datastore.CreateOrUpdateHarvester(ctx, harvester); err != nil {
+		m.logger.WithError(err).WithFields(logrus.Fields{
+			telemetry.TrustDomain:       td.Name,
+			telemetry.HarvesterInstance: instanceID,
+		}).Warn("Failed to record harvester metadata")
+	}
+}
+
+func (m *AuthenticationMiddleware) parseInterval(req *http.Request, header string) time.Duration {
+	value := req.Header.Get(header)
+	if value == "" {
+		return 0
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		m.logger.WithError(err).Debugf("Ignoring invalid %s header", header)
+		return 0
+	}
+
+	return interval
+}
+
+// harvesterInstanceID returns the harvester instance the token was issued to. Tokens issued to harvesters that
+// did not provide an instance ID on onboarding belong to the default instance.
+func harvesterInstanceID(claims *jwt.Claims) string {
+	if claims.InstanceID == "" {
+		return constants.DefaultHarvesterInstanceID
+	}
+	return claims.InstanceID
+}
+
+// harvesterClientID returns the SPIFFE ID carried by the TLS client certificates issued to the harvester instance.
+func harvesterClientID(td spiffeid.TrustDomain, instanceID string) (spiffeid.ID, error) {
+	return spiffeid.FromPath(td, constants.HarvesterClientIDPathPrefix+instanceID)
+}
+
+// harvesterClientInstanceID returns the harvester instance of a SPIFFE ID returned by harvesterClientID.
+func harvesterClientInstanceID(id spiffeid.ID) (string, bool) {
+	instanceID, ok := strings.CutPrefix(id.Path(), constants.HarvesterClientIDPathPrefix)
+	if !ok || !instanceIDRegexp.MatchString(instanceID) {
+		return "", false
+	}
+	return instanceID, true
+}
+
+// datastoreProofStore records the proofs of possession accepted by the verifiers in the datastore, which is shared by
+// the replicas of a highly available server, so that a proof cannot be replayed to another replica.
+type datastoreProofStore struct {
+	datastore db.Datastore
+}
+
+func (s datastoreProofStore) RecordProof(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	return s.datastore.RecordDPoPProof(ctx, id, expiresAt)
+}
+
+// newProofVerifier creates a verifier of the proofs of possession that records the accepted proofs in the datastore.
+func newProofVerifier(ds db.Datastore) *dpop.Verifier {
+	return dpop.NewVerifier(dpop.DefaultMaxAge, datastoreProofStore{datastore: ds})
+}
+
+// respondProofError responds to a request with a proof of possession that was not accepted. Proofs that could not
+// be recorded are not known to be invalid, and are answered with an internal error.
+func respondProofError(l logrus.FieldLogger, err error, msg string, status int) error {
+	if errors.Is(err, dpop.ErrRecordProof) {
+		return chttp.LogAndRespondWithError(l, err, "failed to verify proof of possession", http.StatusInternalServerError)
+	}
+	return chttp.LogAndRespondWithError(l, err, msg, status)
+}
+
+// requestURL returns the URL of the request as sent by the harvester, which proofs of possession are bound to.
+func requestURL(req *http.Request) string {
+	scheme := "https"
+	if req.TLS == nil {
+		scheme = "http"
+	}
+	return scheme + "://" + req.Host + req.URL.Path
+}
+
+func bearerTokenFromRequest(req *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(req.Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	return token, true
 }
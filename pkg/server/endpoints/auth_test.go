@@ -2,16 +2,29 @@ package endpoints
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/dpop"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/test/certtest"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
@@ -26,6 +39,49 @@ type AuthNTestSetup struct {
 	Recorder     *httptest.ResponseRecorder
 	FakeDatabase *fakedatastore.FakeDatabase
 	JWTIssuer    jwt.Issuer
+	X509CA       *disk.X509CA
+}
+
+var (
+	testX509CAOnce sync.Once
+	testX509CA     *disk.X509CA
+)
+
+// newTestX509CA returns a disk X509CA shared by the tests of the package, as creating its certificates is costly.
+func newTestX509CA(t *testing.T) *disk.X509CA {
+	testX509CAOnce.Do(func() {
+		certsFolder := certtest.CreateTestCACertificates(t, clock.New())
+
+		ca, err := disk.New()
+		require.NoError(t, err)
+		err = ca.Configure(&disk.Config{
+			CertFilePath: certsFolder + "/root-ca.crt",
+			KeyFilePath:  certsFolder + "/root-ca.key",
+		})
+		require.NoError(t, err)
+		testX509CA = ca
+	})
+
+	return testX509CA
+}
+
+// issueTestClientCertificate issues a client certificate to the harvester instance of the trust domain.
+func issueTestClientCertificate(t *testing.T, ca x509ca.X509CA, td spiffeid.TrustDomain, instanceID string) []*x509.Certificate {
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+
+	id, err := harvesterClientID(td, instanceID)
+	require.NoError(t, err)
+
+	chain, err := ca.IssueX509Certificate(context.Background(), &x509ca.X509CertificateParams{
+		PublicKey: key.Public(),
+		Subject:   pkix.Name{CommonName: td.String()},
+		URIs:      []*url.URL{id.URL()},
+		TTL:       time.Hour,
+	})
+	require.NoError(t, err)
+
+	return chain
 }
 
 func SetupMiddleware(t *testing.T) *AuthNTestSetup {
@@ -48,7 +104,8 @@ func SetupMiddleware(t *testing.T) *AuthNTestSetup {
 	})
 	require.NoError(t, err)
 
-	authnMiddleware := NewAuthenticationMiddleware(logger, fakeDB, jwtValidator)
+	x509CA := newTestX509CA(t)
+	authnMiddleware := NewAuthenticationMiddleware(logger, fakeDB, jwtValidator, x509CA)
 
 	e := echo.New()
 	e.Use(middleware.KeyAuth(authnMiddleware.Authenticate))
@@ -63,6 +120,7 @@ func SetupMiddleware(t *testing.T) *AuthNTestSetup {
 		Middleware:   authnMiddleware,
 		EchoCtx:      e.NewContext(req, rec),
 		JWTIssuer:    jwtIssuer,
+		X509CA:       x509CA,
 	}
 }
 
@@ -70,7 +128,10 @@ func TestAuthenticate(t *testing.T) {
 	t.Run("Authorized tokens must be able to pass authn verification", func(t *testing.T) {
 		authnSetup := SetupMiddleware(t)
 
-		td := entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("spiffe://test.com")}
+		td := entity.TrustDomain{
+			ID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			Name: spiffeid.RequireTrustDomainFromString("spiffe://test.com"),
+		}
 		authnSetup.FakeDatabase.WithTrustDomains(&td)
 
 		token, err := authnSetup.JWTIssuer.IssueJWT(context.Background(), &jwt.JWTParams{
@@ -81,9 +142,61 @@ func TestAuthenticate(t *testing.T) {
 		})
 		require.NoError(t, err)
 
+		req := authnSetup.EchoCtx.Request()
+		req.RemoteAddr = "10.0.0.1:4321"
+		req.Header.Set(constants.HarvesterVersionHeader, "0.1.0")
+		req.Header.Set(constants.HarvesterSpireBundlePollIntervalHeader, "10s")
+		req.Header.Set(constants.HarvesterFederatedBundlesPollIntervalHeader, "invalid")
+
 		authorized, err := authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
 		assert.NoError(t, err)
 		assert.True(t, authorized)
+
+		assert.Equal(t, constants.DefaultHarvesterInstanceID, authnSetup.EchoCtx.Get(authInstanceIDKey))
+
+		harvesters, err := authnSetup.FakeDatabase.FindHarvestersByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 1)
+		harvester := harvesters[0]
+		assert.Equal(t, constants.DefaultHarvesterInstanceID, harvester.InstanceID)
+		assert.Equal(t, "10.0.0.1:4321", harvester.SourceAddress)
+		assert.Equal(t, "0.1.0", harvester.Version)
+		assert.Equal(t, 10*time.Second, harvester.SpireBundlePollInterval)
+		assert.Zero(t, harvester.FederatedBundlesPollInterval)
+		assert.WithinDuration(t, time.Now(), harvester.LastSeenAt, time.Minute)
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), harvester.TokenExpiresAt, time.Minute)
+	})
+
+	t.Run("Tokens issued to harvester instances record each instance", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+
+		td := entity.TrustDomain{
+			ID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			Name: spiffeid.RequireTrustDomainFromString("spiffe://test.com"),
+		}
+		authnSetup.FakeDatabase.WithTrustDomains(&td)
+
+		for _, instanceID := range []string{"spire-server-1", "spire-server-2"} {
+			token, err := authnSetup.JWTIssuer.IssueJWT(context.Background(), &jwt.JWTParams{
+				Issuer:     "test",
+				Subject:    td.Name,
+				Audience:   []string{"test"},
+				TTL:        5 * time.Minute,
+				InstanceID: instanceID,
+			})
+			require.NoError(t, err)
+
+			authorized, err := authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
+			require.NoError(t, err)
+			assert.True(t, authorized)
+			assert.Equal(t, instanceID, authnSetup.EchoCtx.Get(authInstanceIDKey))
+		}
+
+		harvesters, err := authnSetup.FakeDatabase.FindHarvestersByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 2)
+		assert.Equal(t, "spire-server-1", harvesters[0].InstanceID)
+		assert.Equal(t, "spire-server-2", harvesters[1].InstanceID)
 	})
 
 	t.Run("Non authorized tokens must raise unauthorized responses", func(t *testing.T) {
@@ -105,3 +218,248 @@ func TestAuthenticate(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, echoHTTPErr.Code)
 	})
 }
+
+func TestAuthenticateProofOfPossession(t *testing.T) {
+	td := &entity.TrustDomain{
+		ID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Name: spiffeid.RequireTrustDomainFromString("test.com"),
+	}
+
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	thumbprint, err := dpop.Thumbprint(key.Public())
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (*AuthNTestSetup, string) {
+		authnSetup := SetupMiddleware(t)
+		authnSetup.FakeDatabase.WithTrustDomains(td)
+
+		token, err := authnSetup.JWTIssuer.IssueJWT(context.Background(), &jwt.JWTParams{
+			Issuer:        "test",
+			Subject:       td.Name,
+			Audience:      []string{"test"},
+			TTL:           5 * time.Minute,
+			KeyThumbprint: thumbprint,
+		})
+		require.NoError(t, err)
+
+		return authnSetup, token
+	}
+
+	t.Run("Tokens bound to a key are accepted with a proof of possession of the key", func(t *testing.T) {
+		authnSetup, token := setup(t)
+
+		proof, err := dpop.NewProof(key, http.MethodGet, "http://example.com/", token)
+		require.NoError(t, err)
+		authnSetup.EchoCtx.Request().Header.Set(dpop.HeaderName, proof)
+
+		authorized, err := authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.True(t, authorized)
+
+		// the proof cannot be replayed
+		authorized, err = authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
+		require.Error(t, err)
+		assert.False(t, authorized)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("A proof of possession cannot be replayed to another replica sharing the datastore", func(t *testing.T) {
+		authnSetup, token := setup(t)
+		otherReplica := NewAuthenticationMiddleware(logrus.New(), authnSetup.FakeDatabase, authnSetup.Middleware.jwtValidator, authnSetup.X509CA)
+
+		proof, err := dpop.NewProof(key, http.MethodGet, "http://example.com/", token)
+		require.NoError(t, err)
+		authnSetup.EchoCtx.Request().Header.Set(dpop.HeaderName, proof)
+
+		authorized, err := authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.True(t, authorized)
+
+		authorized, err = otherReplica.Authenticate(token, authnSetup.EchoCtx)
+		require.Error(t, err)
+		assert.False(t, authorized)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		assert.Equal(t, "invalid token: invalid proof of possession", err.(*echo.HTTPError).Message)
+	})
+
+	t.Run("Proofs of possession that cannot be recorded are answered with an internal error", func(t *testing.T) {
+		authnSetup, token := setup(t)
+
+		proof, err := dpop.NewProof(key, http.MethodGet, "http://example.com/", token)
+		require.NoError(t, err)
+		authnSetup.EchoCtx.Request().Header.Set(dpop.HeaderName, proof)
+
+		// the trust domain is found, the proof is not recorded
+		authnSetup.FakeDatabase.AppendNextError(nil)
+		authnSetup.FakeDatabase.AppendNextError(errors.New("datastore is down"))
+
+		authorized, err := authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
+		require.Error(t, err)
+		assert.False(t, authorized)
+		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+		assert.Equal(t, "failed to verify proof of possession", err.(*echo.HTTPError).Message)
+	})
+
+	t.Run("Tokens bound to a key are refused without a proof of possession", func(t *testing.T) {
+		authnSetup, token := setup(t)
+
+		authorized, err := authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
+		require.Error(t, err)
+		assert.False(t, authorized)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		assert.Equal(t, "invalid token: invalid proof of possession", err.(*echo.HTTPError).Message)
+	})
+
+	t.Run("Tokens bound to a key are refused with a proof signed with another key", func(t *testing.T) {
+		authnSetup, token := setup(t)
+
+		otherKey, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		proof, err := dpop.NewProof(otherKey, http.MethodGet, "http://example.com/", token)
+		require.NoError(t, err)
+		authnSetup.EchoCtx.Request().Header.Set(dpop.HeaderName, proof)
+
+		authorized, err := authnSetup.Middleware.Authenticate(token, authnSetup.EchoCtx)
+		require.Error(t, err)
+		assert.False(t, authorized)
+		assert.Equal(t, "invalid token: proof signed with another key", err.(*echo.HTTPError).Message)
+	})
+}
+
+func TestAuthenticateClientCertificate(t *testing.T) {
+	td := &entity.TrustDomain{
+		ID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Name: spiffeid.RequireTrustDomainFromString("test.com"),
+	}
+
+	issueToken := func(t *testing.T, setup *AuthNTestSetup, instanceID string) string {
+		token, err := setup.JWTIssuer.IssueJWT(context.Background(), &jwt.JWTParams{
+			Issuer:     "test",
+			Subject:    td.Name,
+			Audience:   []string{"test"},
+			TTL:        5 * time.Minute,
+			InstanceID: instanceID,
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("Client certificates issued by the server CA authenticate the harvester instance", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+		authnSetup.FakeDatabase.WithTrustDomains(td)
+
+		req := authnSetup.EchoCtx.Request()
+		req.TLS = &tls.ConnectionState{PeerCertificates: issueTestClientCertificate(t, authnSetup.X509CA, td.Name, "spire-server-1")}
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.True(t, authenticated)
+
+		assert.Equal(t, td, authnSetup.EchoCtx.Get(authTrustDomainKey))
+		assert.Equal(t, "spire-server-1", authnSetup.EchoCtx.Get(authInstanceIDKey))
+		assert.Nil(t, authnSetup.EchoCtx.Get(authClaimsKey))
+
+		harvesters, err := authnSetup.FakeDatabase.FindHarvestersByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 1)
+		assert.Equal(t, "spire-server-1", harvesters[0].InstanceID)
+	})
+
+	t.Run("Client certificates can be presented along with the JWT token of the same harvester instance", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+		authnSetup.FakeDatabase.WithTrustDomains(td)
+
+		req := authnSetup.EchoCtx.Request()
+		req.TLS = &tls.ConnectionState{PeerCertificates: issueTestClientCertificate(t, authnSetup.X509CA, td.Name, constants.DefaultHarvesterInstanceID)}
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+issueToken(t, authnSetup, ""))
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.True(t, authenticated)
+		assert.Equal(t, constants.DefaultHarvesterInstanceID, authnSetup.EchoCtx.Get(authInstanceIDKey))
+		assert.NotNil(t, authnSetup.EchoCtx.Get(authClaimsKey))
+	})
+
+	t.Run("JWT tokens of another harvester instance are rejected", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+		authnSetup.FakeDatabase.WithTrustDomains(td)
+
+		req := authnSetup.EchoCtx.Request()
+		req.TLS = &tls.ConnectionState{PeerCertificates: issueTestClientCertificate(t, authnSetup.X509CA, td.Name, "spire-server-1")}
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+issueToken(t, authnSetup, "spire-server-2"))
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.Error(t, err)
+		assert.False(t, authenticated)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "JWT token and client certificate identify different harvesters")
+	})
+
+	t.Run("Client certificates of unknown trust domains are rejected", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+
+		req := authnSetup.EchoCtx.Request()
+		req.TLS = &tls.ConnectionState{PeerCertificates: issueTestClientCertificate(t, authnSetup.X509CA, td.Name, "spire-server-1")}
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.Error(t, err)
+		assert.False(t, authenticated)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("Requests without client certificates issued by the server CA are not authenticated", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+		authnSetup.FakeDatabase.WithTrustDomains(td)
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.False(t, authenticated)
+
+		ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+		svid, _ := certtest.CreateTestX509SVID(t, clock.New(), ca, caKey, spiffeid.RequireFromString("spiffe://test.com/galadriel/harvester/spire-server-1"))
+		authnSetup.EchoCtx.Request().TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{svid}}
+
+		authenticated, err = authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.False(t, authenticated)
+		assert.Nil(t, authnSetup.EchoCtx.Get(authTrustDomainKey))
+	})
+
+	t.Run("Certificates issued by the server CA to other identities are not authenticated", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+		authnSetup.FakeDatabase.WithTrustDomains(td)
+
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		serverCert, err := authnSetup.X509CA.IssueX509Certificate(context.Background(), &x509ca.X509CertificateParams{
+			PublicKey: key.Public(),
+			Subject:   pkix.Name{CommonName: constants.GaladrielServerName},
+			DNSNames:  []string{constants.GaladrielServerName},
+			TTL:       time.Hour,
+		})
+		require.NoError(t, err)
+		authnSetup.EchoCtx.Request().TLS = &tls.ConnectionState{PeerCertificates: serverCert}
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.False(t, authenticated)
+	})
+}
+
+func TestHarvesterClientID(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("test.com")
+
+	id, err := harvesterClientID(td, "spire-server-1")
+	require.NoError(t, err)
+	assert.Equal(t, "spiffe://test.com/galadriel/harvester/spire-server-1", id.String())
+
+	instanceID, ok := harvesterClientInstanceID(id)
+	assert.True(t, ok)
+	assert.Equal(t, "spire-server-1", instanceID)
+
+	for _, other := range []string{"spiffe://test.com/galadriel/harvester", "spiffe://test.com/galadriel/harvester/a/b", "spiffe://test.com/workload"} {
+		_, ok := harvesterClientInstanceID(spiffeid.RequireFromString(other))
+		assert.False(t, ok, other)
+	}
+}
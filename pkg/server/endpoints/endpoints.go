@@ -2,22 +2,22 @@ package endpoints
 
 import (
 	"context"
-	"crypto/rsa"
 	"crypto/tls"
-	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleverifier"
 	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/metrics"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
-	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
@@ -28,11 +28,10 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
-const (
-	serverCertificateTTL = 1 * time.Hour
-)
+const clientCertificateTTL = 1 * time.Hour
 
 // Server manages the UDS and TCP endpoints lifecycle
 type Server interface {
@@ -45,12 +44,17 @@ type Endpoints struct {
 	tcpAddress *net.TCPAddr
 	localAddr  net.Addr
 	datastore  db.Datastore
+	notifier   notification.Notifier
 	logger     logrus.FieldLogger
 
+	bundleVerifiers *bundleverifier.Set
+	bundlePolicy    *bundlepolicy.Policy
+
 	x509CA       x509ca.X509CA
 	jwtIssuer    jwt.Issuer
 	jwtValidator jwt.Validator
 	certsStore   *certificateSource
+	tls          *TLSConfig
 
 	hooks struct {
 		// test hook used to signal that TCP listener is ready
@@ -65,27 +69,29 @@ type Config struct {
 	JWTIssuer    jwt.Issuer
 	JWTValidator jwt.Validator
 	Catalog      catalog.Catalog
+	Notifier     notification.Notifier
+	BundlePolicy *bundlepolicy.Policy
+	TLS          *TLSConfig // TLS certificate of the TCP endpoint, issued by the X509CA with the defaults when nil
 	Logger       logrus.FieldLogger
 }
 
-type certificateSource struct {
-	mu   sync.RWMutex
-	cert *tls.Certificate
-}
-
 func New(c *Config) (*Endpoints, error) {
 	if err := util.PrepareLocalAddr(c.LocalAddress); err != nil {
 		return nil, err
 	}
 
 	return &Endpoints{
-		tcpAddress:   c.TCPAddress,
-		localAddr:    c.LocalAddress,
-		datastore:    c.Catalog.GetDatastore(),
-		logger:       c.Logger,
-		x509CA:       c.Catalog.GetX509CA(),
-		jwtIssuer:    c.JWTIssuer,
-		jwtValidator: c.JWTValidator,
+		tcpAddress:      c.TCPAddress,
+		localAddr:       c.LocalAddress,
+		datastore:       c.Catalog.GetDatastore(),
+		notifier:        c.Notifier,
+		logger:          c.Logger,
+		bundleVerifiers: c.Catalog.GetBundleVerifiers(),
+		bundlePolicy:    c.BundlePolicy,
+		x509CA:          c.Catalog.GetX509CA(),
+		jwtIssuer:       c.JWTIssuer,
+		jwtValidator:    c.JWTValidator,
+		tls:             c.TLS.withDefaults(),
 	}, nil
 }
 
@@ -112,16 +118,20 @@ func (e *Endpoints) startTCPListener(ctx context.Context) error {
 	e.addTCPHandlers(server)
 	e.addTCPMiddlewares(server)
 
-	cert, err := e.getTLSCertificate(ctx)
+	certsStore, err := e.loadTLSCertificate(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start TCP listener: %w", err)
 	}
-	e.certsStore = &certificateSource{cert: cert}
+	e.certsStore = certsStore
 
 	tlsConfig := &tls.Config{
 		GetCertificate: func(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
 			return e.certsStore.getTLSCertificate(), nil
 		},
+		// harvesters onboarding with an X509-SVID present it as client certificate, which is verified by the
+		// onboarding handler against the bundle of their trust domain. Client certificates issued by the server
+		// CA are verified by the authentication middleware.
+		ClientAuth: tls.RequestClientCert,
 	}
 
 	httpServer := http.Server{
@@ -174,6 +184,7 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 	}
 	defer l.Close()
 
+	server.Use(otelecho.Middleware(constants.GaladrielServerName))
 	e.addUDSHandlers(server)
 
 	log := e.logger.WithFields(logrus.Fields{
@@ -203,16 +214,16 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 }
 
 func (e *Endpoints) addUDSHandlers(server *echo.Echo) {
-	adminapi.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.datastore))
+	adminapi.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.datastore, e.notifier, e.bundleVerifiers, e.bundlePolicy))
 }
 
 func (e *Endpoints) addTCPHandlers(server *echo.Echo) {
-	harvesterapi.RegisterHandlers(server, NewHarvesterAPIHandlers(e.logger, e.datastore, e.jwtIssuer, e.jwtValidator))
+	harvesterapi.RegisterHandlers(server, NewHarvesterAPIHandlers(e.logger, e.datastore, e.notifier, e.bundleVerifiers, e.bundlePolicy, e.jwtIssuer, e.jwtValidator, e.x509CA))
 }
 
 func (e *Endpoints) addTCPMiddlewares(server *echo.Echo) {
 	logger := e.logger.WithField(telemetry.SubsystemName, telemetry.Endpoints)
-	authNMiddleware := NewAuthenticationMiddleware(logger, e.datastore, e.jwtValidator)
+	authNMiddleware := NewAuthenticationMiddleware(logger, e.datastore, e.jwtValidator, e.x509CA)
 
 	skipOnboard := func(c echo.Context) bool {
 		return strings.Contains(c.Request().URL.Path, "/onboard")
@@ -223,75 +234,19 @@ func (e *Endpoints) addTCPMiddlewares(server *echo.Echo) {
 			if skipOnboard(c) {
 				return next(c)
 			}
-			return middleware.KeyAuth(authNMiddleware.Authenticate)(next)(c)
-		}
-	}
-
-	server.Use(myMiddleware, middleware.Recover(), middleware.CORS())
-}
-
-func (t *certificateSource) setTLSCertificate(cert *tls.Certificate) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	t.cert = cert
-}
-
-func (t *certificateSource) getTLSCertificate() *tls.Certificate {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
-	return t.cert
-}
-
-func (e *Endpoints) startTLSCertificateRotation(ctx context.Context, errChan chan error) {
-	e.logger.Info("Started TLS certificate rotator")
-
-	// Start a ticker that rotates the certificate every default interval
-	certRotationInterval := serverCertificateTTL / 2
-	ticker := time.NewTicker(certRotationInterval)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ticker.C:
-			e.logger.Debug("Rotating Server TLS certificate")
-			cert, err := e.getTLSCertificate(ctx)
+			// harvesters presenting a client certificate issued by the server CA don't need a JWT token
+			authenticated, err := authNMiddleware.AuthenticateClientCertificate(c)
 			if err != nil {
-				errChan <- fmt.Errorf("failed to rotate Server TLS certificate: %w", err)
+				return err
+			}
+			if authenticated {
+				return next(c)
 			}
-			e.certsStore.setTLSCertificate(cert)
-		case <-ctx.Done():
-			e.logger.Info("Stopped Server TLS certificate rotator")
-			return
+			return middleware.KeyAuth(authNMiddleware.Authenticate)(next)(c)
 		}
 	}
-}
-
-func (e *Endpoints) getTLSCertificate(ctx context.Context) (*tls.Certificate, error) {
-	privateKey, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create private key: %w", err)
-	}
 
-	params := &x509ca.X509CertificateParams{
-		Subject: pkix.Name{
-			CommonName: constants.GaladrielServerName,
-		},
-		TTL:       serverCertificateTTL,
-		PublicKey: privateKey.Public(),
-		DNSNames:  []string{constants.GaladrielServerName},
-	}
-	cert, err := e.x509CA.IssueX509Certificate(ctx, params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to issue TLS certificate: %w", err)
-	}
-
-	certPEM := cryptoutil.EncodeCertificate(cert[0])
-	keyPEM := cryptoutil.EncodeRSAPrivateKey(privateKey.(*rsa.PrivateKey))
-
-	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
-	if err != nil {
-		return nil, err
-	}
-	return &certificate, nil
+	server.Use(otelecho.Middleware(constants.GaladrielServerName), metrics.HarvesterAPIMiddleware, myMiddleware, middleware.Recover(), middleware.CORS())
 }
 
 func (e *Endpoints) triggerListeningHook() {
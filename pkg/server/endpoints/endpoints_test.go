@@ -10,6 +10,7 @@ import (
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleverifier"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	"github.com/HewlettPackard/galadriel/test/certtest"
 	"github.com/jmhodges/clock"
@@ -36,6 +37,10 @@ func (c fakeCatalog) GetDatastore() db.Datastore {
 	return c.ds
 }
 
+func (c fakeCatalog) GetBundleVerifiers() *bundleverifier.Set {
+	return bundleverifier.NewSet(nil)
+}
+
 func TestListenAndServe(t *testing.T) {
 	config := newEndpointTestConfig(t)
 
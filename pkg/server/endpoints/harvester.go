@@ -3,48 +3,81 @@ package endpoints
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"regexp"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/consent"
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/dpop"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleverifier"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
-	gojwt "github.com/golang-jwt/jwt/v4"
+	"github.com/HewlettPackard/galadriel/pkg/server/jointoken"
+	"github.com/HewlettPackard/galadriel/pkg/server/metrics"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 )
 
 const (
 	authTrustDomainKey = "trust_domain"
 	authClaimsKey      = "auth_claims"
+	authInstanceIDKey  = "auth_instance_id"
+
+	// maxBundleStoreAttempts is how many times an uploaded bundle is checked and stored again when the stored
+	// bundle was changed by another harvester instance in the meantime.
+	maxBundleStoreAttempts = 3
 )
 
+// instanceIDRegexp matches the valid harvester instance IDs.
+var instanceIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
 type HarvesterAPIHandlers struct {
-	Logger       logrus.FieldLogger
-	Datastore    db.Datastore
-	jwtIssuer    jwt.Issuer
-	jwtValidator jwt.Validator
+	Logger          logrus.FieldLogger
+	Datastore       db.Datastore
+	Notifier        notification.Notifier
+	BundleVerifiers *bundleverifier.Set
+	BundlePolicy    *bundlepolicy.Policy
+	jwtIssuer       jwt.Issuer
+	jwtValidator    jwt.Validator
+	x509CA          x509ca.X509CA
+	proofVerifier   *dpop.Verifier
 }
 
 // NewHarvesterAPIHandlers creates a new HarvesterAPIHandlers
-func NewHarvesterAPIHandlers(l logrus.FieldLogger, ds db.Datastore, jwtIssuer jwt.Issuer, jwtValidator jwt.Validator) *HarvesterAPIHandlers {
+func NewHarvesterAPIHandlers(l logrus.FieldLogger, ds db.Datastore, n notification.Notifier, bv *bundleverifier.Set, bp *bundlepolicy.Policy, jwtIssuer jwt.Issuer, jwtValidator jwt.Validator, x509CA x509ca.X509CA) *HarvesterAPIHandlers {
 	return &HarvesterAPIHandlers{
-		Logger:       l,
-		Datastore:    ds,
-		jwtIssuer:    jwtIssuer,
-		jwtValidator: jwtValidator,
+		Logger:          l,
+		Datastore:       ds,
+		Notifier:        n,
+		BundleVerifiers: bv,
+		BundlePolicy:    bp,
+		jwtIssuer:       jwtIssuer,
+		jwtValidator:    jwtValidator,
+		x509CA:          x509CA,
+		proofVerifier:   newProofVerifier(ds),
 	}
 }
 
@@ -87,7 +120,29 @@ func (h *HarvesterAPIHandlers) GetRelationships(echoCtx echo.Context, trustDomai
 	return chttp.WriteResponse(echoCtx, http.StatusOK, apiRelationships)
 }
 
+// GetRelationshipByID gets a relationship of the authenticated trust domain - (GET /trust-domain/{trustDomainName}/relationships/{relationshipID})
+func (h *HarvesterAPIHandlers) GetRelationshipByID(echoCtx echo.Context, trustDomainName api.TrustDomainName, relationshipID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	authTD, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	relationship, err := h.findAuthorizedRelationship(ctx, authTD, relationshipID)
+	if err != nil {
+		return err
+	}
+
+	resp := api.RelationshipFromEntity(relationship)
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+}
+
 // PatchRelationship approves/denies relationships requests - (PATCH /trust-domain/{trustDomainName}/relationships/{relationshipID})
+// When the request carries a consent signature, the signed statement is verified and stored, so that it can be
+// fetched by the other trust domain of the relationship. Otherwise, any previously stored consent of the
+// authenticated trust domain is removed, as it no longer reflects its consent status.
 func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDomainName api.TrustDomainName, relationshipID api.UUID) error {
 	ctx := echoCtx.Request().Context()
 
@@ -102,22 +157,9 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
-	// get the relationships for the trust domain
-	relationship, err := h.Datastore.FindRelationshipByID(ctx, relationshipID)
+	relationship, err := h.findAuthorizedRelationship(ctx, authTD, relationshipID)
 	if err != nil {
-		msg := "error looking up relationships"
-		err := fmt.Errorf("%s: %w", msg, err)
-		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
-	}
-
-	if relationship == nil {
-		err := fmt.Errorf("relationship not found")
-		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
-	}
-
-	if relationship.TrustDomainAID != authTD.ID.UUID && relationship.TrustDomainBID != authTD.ID.UUID {
-		err := fmt.Errorf("relationship doesn't belong to the authenticated trust domain")
-		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+		return err
 	}
 
 	var patchRequest harvester.PatchRelationshipRequest
@@ -136,6 +178,15 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
+	var signedConsent *entity.RelationshipConsent
+	if patchRequest.ConsentSignature != nil {
+		signedConsent, err = verifyConsentSignature(patchRequest.ConsentSignature, relationship, authTD, entity.ConsentStatus(consentStatus))
+		if err != nil {
+			err := fmt.Errorf("invalid consent signature: %w", err)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+	}
+
 	// update the relationship consent status for the authenticated trust domain
 	if relationship.TrustDomainAID == authTD.ID.UUID {
 		relationship.TrustDomainAConsent = entity.ConsentStatus(consentStatus)
@@ -150,6 +201,17 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
+	if signedConsent != nil {
+		_, err = h.Datastore.CreateOrUpdateRelationshipConsent(ctx, signedConsent)
+	} else {
+		err = h.Datastore.DeleteRelationshipConsent(ctx, relationship.ID.UUID, authTD.ID.UUID)
+	}
+	if err != nil {
+		msg := "error storing relationship consent"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
 	r, err := db.PopulateTrustDomainNames(ctx, h.Datastore, updatedRel)
 	if err != nil {
 		msg := "failed populating relationships entities"
@@ -157,6 +219,8 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
+	h.Notifier.Notify(notification.NewRelationshipEvent(notification.EventRelationshipConsentUpdated, r[0]))
+
 	resp := api.RelationshipFromEntity(r[0])
 
 	if err = chttp.WriteResponse(echoCtx, http.StatusOK, resp); err != nil {
@@ -166,44 +230,109 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 	return nil
 }
 
+// GetRelationshipConsents gets the signed consents of both trust domains of a relationship - (GET /trust-domain/{trustDomainName}/relationships/{relationshipID}/consents)
+// Only the trust domains of the relationship are allowed to get its consents.
+func (h *HarvesterAPIHandlers) GetRelationshipConsents(echoCtx echo.Context, trustDomainName api.TrustDomainName, relationshipID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	authTD, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	relationship, err := h.findAuthorizedRelationship(ctx, authTD, relationshipID)
+	if err != nil {
+		return err
+	}
+
+	consents, err := h.Datastore.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+	if err != nil {
+		msg := "error looking up relationship consents"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	resp := make(harvester.RelationshipConsents, 0, len(consents))
+	for _, c := range consents {
+		c.TrustDomainName = relationship.TrustDomainAName
+		if c.TrustDomainID == relationship.TrustDomainBID {
+			c.TrustDomainName = relationship.TrustDomainBName
+		}
+		resp = append(resp, harvester.RelationshipConsentFromEntity(c))
+	}
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+}
+
 // Onboard introduces a harvester to Galadriel Server providing its join token, and gets back a JWT token - (GET /trust-domain/onboard)
 func (h *HarvesterAPIHandlers) Onboard(echoCtx echo.Context, trustDomainName api.TrustDomainName, params harvester.OnboardParams) error {
 	ctx := echoCtx.Request().Context()
 
 	if trustDomainName == "" {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInvalidRequest)
 		err := errors.New("trust domain name is required")
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 	tdName, err := spiffeid.TrustDomainFromString(trustDomainName)
 	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInvalidRequest)
 		err := fmt.Errorf("invalid trust domain name: %q", trustDomainName)
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
 	if params.JoinToken == "" {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInvalidRequest)
 		err := errors.New("join token is required")
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
-	token, err := h.Datastore.FindJoinToken(ctx, params.JoinToken)
+	instanceID := constants.DefaultHarvesterInstanceID
+	if params.InstanceId != nil {
+		if !instanceIDRegexp.MatchString(*params.InstanceId) {
+			metrics.IncOnboardFailure(metrics.OnboardFailureInvalidRequest)
+			err := fmt.Errorf("invalid harvester instance ID: %q", *params.InstanceId)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+		instanceID = *params.InstanceId
+	}
+
+	keyThumbprint, err := h.onboardingKeyThumbprint(echoCtx)
 	if err != nil {
+		return err
+	}
+
+	// an unknown token and a wrong secret are answered alike
+	var token *entity.JoinToken
+	prefix, secret, err := jointoken.Parse(params.JoinToken)
+	if err == nil {
+		token, err = h.Datastore.FindJoinTokenByPrefix(ctx, prefix)
+	} else {
+		// a token without prefix may be a legacy token, verified with the unsalted hash of the whole token
+		secret = params.JoinToken
+		token, err = h.Datastore.FindLegacyJoinTokenByHash(ctx, jointoken.LegacyHash(params.JoinToken))
+	}
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInternalError)
 		msg := "error looking up token"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
-	if token == nil {
+	if token == nil || !jointoken.Verify(secret, token.TokenSalt, token.TokenHash) {
+		metrics.IncOnboardFailure(metrics.OnboardFailureTokenNotFound)
 		err := errors.New("token not found")
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
 	if token.ExpiresAt.Before(time.Now()) {
+		metrics.IncOnboardFailure(metrics.OnboardFailureTokenExpired)
 		msg := "token expired"
 		err := fmt.Errorf("%s: trust domain ID: %s", msg, token.TrustDomainID)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusUnauthorized)
 	}
 
 	if token.Used {
+		metrics.IncOnboardFailure(metrics.OnboardFailureTokenUsed)
 		msg := "token already used"
 		err := fmt.Errorf("%s: trust domain name: %s", msg, trustDomainName)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
@@ -211,55 +340,246 @@ func (h *HarvesterAPIHandlers) Onboard(echoCtx echo.Context, trustDomainName api
 
 	trustDomain, err := h.Datastore.FindTrustDomainByID(ctx, token.TrustDomainID)
 	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInternalError)
 		msg := "error looking up trust domain"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
 	if trustDomain == nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureTrustDomainNotFound)
 		msg := "trust domain not found"
 		err := fmt.Errorf("%s: trust domain ID: %s", msg, token.TrustDomainID)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
 	}
 
 	if trustDomain.Name != tdName {
+		metrics.IncOnboardFailure(metrics.OnboardFailureTrustDomainMismatch)
 		msg := "trust domain name does not match the one associated to the token"
 		err := fmt.Errorf("%s: trust domain ID: %s", msg, token.TrustDomainID)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
 	}
 
-	// mark token as used
-	if _, err := h.Datastore.UpdateJoinToken(ctx, token.ID.UUID, true); err != nil {
+	if err := h.refuseExternalTrustDomain(ctx, trustDomain); err != nil {
+		return err
+	}
+
+	if !sourceAllowed(token.SourceCIDR, echoCtx.Request().RemoteAddr) {
+		metrics.IncOnboardFailure(metrics.OnboardFailureSourceNotAllowed)
+		msg := "token not allowed from this source address"
+		err := fmt.Errorf("%s: %s is not in %s", msg, echoCtx.Request().RemoteAddr, token.SourceCIDR)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusUnauthorized)
+	}
+
+	// count the use of the token, which fails if concurrent onboardings used it up in the meantime
+	recorded, err := h.Datastore.RecordJoinTokenUse(ctx, token.ID.UUID)
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInternalError)
 		msg := "failed to update token"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
+	if recorded == nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureTokenUsed)
+		msg := "token already used"
+		err := fmt.Errorf("%s: trust domain name: %s", msg, trustDomainName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	return h.respondOnboarded(echoCtx, trustDomain, instanceID, keyThumbprint)
+}
+
+// OnboardWithSVID introduces a harvester to Galadriel Server presenting an X509-SVID as TLS client certificate,
+// and gets back a JWT token - (GET /trust-domain/{trustDomainName}/onboard/svid)
+func (h *HarvesterAPIHandlers) OnboardWithSVID(echoCtx echo.Context, trustDomainName api.TrustDomainName, params harvester.OnboardWithSVIDParams) error {
+	ctx := echoCtx.Request().Context()
+
+	tdName, err := spiffeid.TrustDomainFromString(trustDomainName)
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInvalidRequest)
+		err := fmt.Errorf("invalid trust domain name: %q", trustDomainName)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	instanceID := constants.DefaultHarvesterInstanceID
+	if params.InstanceId != nil {
+		if !instanceIDRegexp.MatchString(*params.InstanceId) {
+			metrics.IncOnboardFailure(metrics.OnboardFailureInvalidRequest)
+			err := fmt.Errorf("invalid harvester instance ID: %q", *params.InstanceId)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+		instanceID = *params.InstanceId
+	}
+
+	keyThumbprint, err := h.onboardingKeyThumbprint(echoCtx)
+	if err != nil {
+		return err
+	}
+
+	trustDomain, err := h.Datastore.FindTrustDomainByName(ctx, tdName)
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInternalError)
+		msg := "error looking up trust domain"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
 
+	if trustDomain == nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureTrustDomainNotFound)
+		msg := "trust domain not found"
+		err := fmt.Errorf("%s: %s", msg, tdName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	if err := h.refuseExternalTrustDomain(ctx, trustDomain); err != nil {
+		return err
+	}
+
+	if !trustDomain.SVIDOnboardingEnabled() {
+		metrics.IncOnboardFailure(metrics.OnboardFailureSVIDOnboardingDisabled)
+		msg := "SVID onboarding is not enabled for the trust domain"
+		err := fmt.Errorf("%s: %s", msg, tdName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusForbidden)
+	}
+
+	tlsState := echoCtx.Request().TLS
+	if tlsState == nil || len(tlsState.PeerCertificates) == 0 {
+		metrics.IncOnboardFailure(metrics.OnboardFailureSVIDMissing)
+		err := errors.New("an X509-SVID client certificate is required")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+	}
+
+	bundle, err := h.onboardingBundle(ctx, trustDomain)
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInternalError)
+		msg := "error loading the onboarding bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if bundle == nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureSVIDInvalid)
+		msg := "no bundle to verify the X509-SVID"
+		err := fmt.Errorf("%s: trust domain %s has neither an onboarding bundle nor a stored bundle", msg, tdName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusUnauthorized)
+	}
+
+	svidID, _, err := x509svid.Verify(tlsState.PeerCertificates, bundle)
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureSVIDInvalid)
+		msg := "invalid X509-SVID"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusUnauthorized)
+	}
+
+	if !svidIDAllowed(trustDomain, svidID) {
+		metrics.IncOnboardFailure(metrics.OnboardFailureSVIDIDMismatch)
+		msg := "X509-SVID SPIFFE ID is not allowed to onboard the trust domain"
+		err := fmt.Errorf("%s: %s", msg, svidID)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusUnauthorized)
+	}
+
+	return h.respondOnboarded(echoCtx, trustDomain, instanceID, keyThumbprint)
+}
+
+// refuseExternalTrustDomain refuses the onboarding of a harvester to an external trust domain, whose bundle is
+// fetched from its bundle endpoint instead of uploaded by a harvester.
+func (h *HarvesterAPIHandlers) refuseExternalTrustDomain(ctx context.Context, td *entity.TrustDomain) error {
+	externalTD, err := h.Datastore.FindExternalTrustDomainByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInternalError)
+		msg := "error looking up external trust domain"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if externalTD != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureExternalTrustDomain)
+		err := fmt.Errorf("trust domain %q is external, its bundle is fetched from its bundle endpoint", td.Name.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+
+	return nil
+}
+
+// onboardingKeyThumbprint returns the JWK thumbprint of the key that the harvester proves the possession of when
+// onboarding, which its JWT token is bound to. It returns an empty thumbprint when the harvester sends no proof.
+func (h *HarvesterAPIHandlers) onboardingKeyThumbprint(echoCtx echo.Context) (string, error) {
+	req := echoCtx.Request()
+	proof := req.Header.Get(dpop.HeaderName)
+	if proof == "" {
+		return "", nil
+	}
+
+	keyThumbprint, err := h.proofVerifier.Verify(req.Context(), proof, req.Method, requestURL(req), "")
+	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInvalidProof)
+		err := fmt.Errorf("invalid proof of possession: %w", err)
+		return "", respondProofError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	return keyThumbprint, nil
+}
+
+// respondOnboarded issues the JWT access token of an onboarded harvester, bound to the key with the given
+// thumbprint if any, and writes the onboarding response.
+func (h *HarvesterAPIHandlers) respondOnboarded(echoCtx echo.Context, trustDomain *entity.TrustDomain, instanceID, keyThumbprint string) error {
 	jwtParams := &jwt.JWTParams{
-		Issuer:   constants.GaladrielServerName,
-		Subject:  trustDomain.Name,
-		Audience: []string{constants.GaladrielServerName},
-		TTL:      24 * 5 * time.Hour,
+		Issuer:        constants.GaladrielServerName,
+		Subject:       trustDomain.Name,
+		Audience:      []string{constants.GaladrielServerName},
+		TTL:           24 * 5 * time.Hour,
+		InstanceID:    instanceID,
+		KeyThumbprint: keyThumbprint,
 	}
 
-	jwtToken, err := h.jwtIssuer.IssueJWT(ctx, jwtParams)
+	jwtToken, err := h.jwtIssuer.IssueJWT(echoCtx.Request().Context(), jwtParams)
 	if err != nil {
+		metrics.IncOnboardFailure(metrics.OnboardFailureInternalError)
 		msg := "error generating JWT token"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
-	h.Logger.WithField(telemetry.TrustDomain, tdName.String()).Debug("Harvester onboarded successfully")
+	h.Logger.WithFields(logrus.Fields{
+		telemetry.TrustDomain:       trustDomain.Name.String(),
+		telemetry.HarvesterInstance: instanceID,
+	}).Debug("Harvester onboarded successfully")
 
 	resp := &harvester.OnboardHarvesterResponse{
 		Token:           jwtToken,
 		TrustDomainID:   trustDomain.ID.UUID,
 		TrustDomainName: trustDomain.Name.String(),
+		InstanceID:      instanceID,
 	}
 
 	return chttp.WriteResponse(echoCtx, http.StatusOK, resp)
 }
 
+// onboardingBundle returns the bundle that the X509-SVID of a harvester of the trust domain is verified against:
+// its onboarding bundle if set, otherwise its stored bundle. It returns nil if there is neither.
+func (h *HarvesterAPIHandlers) onboardingBundle(ctx context.Context, td *entity.TrustDomain) (*spiffebundle.Bundle, error) {
+	data := td.OnboardingBundle
+	if len(data) == 0 {
+		stored, err := h.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+		if err != nil {
+			return nil, err
+		}
+		if stored == nil {
+			return nil, nil
+		}
+		data = stored.Data
+	}
+
+	return spiffebundle.Parse(td.Name, data)
+}
+
+// svidIDAllowed tells if a harvester presenting an X509-SVID with the given SPIFFE ID can onboard the trust domain.
+// It must be the pinned harvester SPIFFE ID, no SPIFFE ID is allowed when there is none.
+func svidIDAllowed(td *entity.TrustDomain, id spiffeid.ID) bool {
+	return !td.HarvesterSPIFFEID.IsZero() && id == td.HarvesterSPIFFEID
+}
+
 // GetNewJWTToken renews a JWT access token - (GET /trust-domain/jwt)
 func (h *HarvesterAPIHandlers) GetNewJWTToken(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
 	ctx := echoCtx.Request().Context()
@@ -274,7 +594,7 @@ func (h *HarvesterAPIHandlers) GetNewJWTToken(echoCtx echo.Context, trustDomainN
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
-	claims, ok := echoCtx.Get(authClaimsKey).(*gojwt.RegisteredClaims)
+	claims, ok := echoCtx.Get(authClaimsKey).(*jwt.Claims)
 	if !ok {
 		msg := "failed to parse JWT access token claims"
 		err := fmt.Errorf("%s", msg)
@@ -295,12 +615,29 @@ func (h *HarvesterAPIHandlers) GetNewJWTToken(echoCtx echo.Context, trustDomainN
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
 	}
 
+	// the new JWT token is bound to the same key as the received token. Harvesters holding a token that is not bound
+	// to a key get a bound token by proving the possession of their key.
+	keyThumbprint := claims.KeyThumbprint()
+	if keyThumbprint == "" {
+		req := echoCtx.Request()
+		if proof := req.Header.Get(dpop.HeaderName); proof != "" {
+			accessToken, _ := bearerTokenFromRequest(req)
+			keyThumbprint, err = h.proofVerifier.Verify(ctx, proof, req.Method, requestURL(req), accessToken)
+			if err != nil {
+				err := fmt.Errorf("invalid proof of possession: %w", err)
+				return respondProofError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+			}
+		}
+	}
+
 	// params for the new JWT token
 	params := jwt.JWTParams{
 		Issuer: constants.GaladrielServerName,
-		// the new JWT token has the same subject as the received token
-		Subject:  subject,
-		Audience: []string{constants.GaladrielServerName},
+		// the new JWT token has the same subject and instance as the received token
+		Subject:       subject,
+		Audience:      []string{constants.GaladrielServerName},
+		InstanceID:    claims.InstanceID,
+		KeyThumbprint: keyThumbprint,
 	}
 
 	newToken, err := h.jwtIssuer.IssueJWT(ctx, &params)
@@ -317,6 +654,113 @@ func (h *HarvesterAPIHandlers) GetNewJWTToken(echoCtx echo.Context, trustDomainN
 	return chttp.WriteResponse(echoCtx, http.StatusOK, jwtResp)
 }
 
+// IssueClientCertificate issues a TLS client certificate to the authenticated harvester - (POST /trust-domain/{trustDomainName}/client-certificate)
+// The certificate is bound to the public key of the certificate signing request and carries the SPIFFE ID of the
+// harvester instance, so that the harvester can authenticate with mutual TLS.
+func (h *HarvesterAPIHandlers) IssueClientCertificate(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	authTD, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName)
+	if err != nil {
+		return err
+	}
+	instanceID := getAuthenticatedInstanceID(echoCtx)
+
+	var req harvester.IssueClientCertificateRequest
+	if err := chttp.ParseRequestBodyToStruct(echoCtx, &req); err != nil {
+		msg := "failed to read client certificate request body"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	csr, err := parseCertificateRequest(req.Csr)
+	if err != nil {
+		err := fmt.Errorf("invalid certificate signing request: %w", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	id, err := harvesterClientID(authTD.Name, instanceID)
+	if err != nil {
+		err := fmt.Errorf("invalid harvester instance: %w", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	params := &x509ca.X509CertificateParams{
+		PublicKey: csr.PublicKey,
+		Subject: pkix.Name{
+			CommonName: authTD.Name.String(),
+		},
+		URIs:               []*url.URL{id.URL()},
+		TTL:                clientCertificateTTL,
+		CertificateRequest: csr.Raw,
+	}
+	chain, err := h.x509CA.IssueX509Certificate(ctx, params)
+	if err != nil {
+		msg := "failed to issue client certificate"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	var chainPEM []byte
+	for _, cert := range chain {
+		chainPEM = append(chainPEM, cryptoutil.EncodeCertificate(cert)...)
+	}
+
+	resp := harvester.ClientCertificateResponse{
+		CertificateChain: string(chainPEM),
+		ExpiresAt:        chain[0].NotAfter,
+	}
+
+	h.Logger.WithFields(logrus.Fields{
+		telemetry.TrustDomain:       authTD.Name,
+		telemetry.HarvesterInstance: instanceID,
+	}).Debug("Issued client certificate")
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+}
+
+// GetServerTrustBundle returns the CA certificates that the Galadriel Server TLS certificates chain up to - (GET /trust-domain/{trustDomainName}/server-trust-bundle)
+func (h *HarvesterAPIHandlers) GetServerTrustBundle(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	if _, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName); err != nil {
+		return err
+	}
+
+	authorities, err := h.x509CA.GetX509Authorities(ctx)
+	if err != nil {
+		msg := "failed to get server trust bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	var bundlePEM []byte
+	for _, cert := range authorities {
+		bundlePEM = append(bundlePEM, cryptoutil.EncodeCertificate(cert)...)
+	}
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, harvester.ServerTrustBundle{TrustBundle: string(bundlePEM)})
+}
+
+// parseCertificateRequest parses a PEM encoded certificate signing request and checks its signature.
+func parseCertificateRequest(csrPEM string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("no PEM encoded certificate request found")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, err
+	}
+
+	return csr, nil
+}
+
 // BundleSync synchronizes the status of trust bundles between server and harvester - (POST /trust-domain/{trustDomainName}/bundles/sync)
 func (h *HarvesterAPIHandlers) BundleSync(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
 	ctx := echoCtx.Request().Context()
@@ -334,6 +778,8 @@ func (h *HarvesterAPIHandlers) BundleSync(echoCtx echo.Context, trustDomainName
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
 	}
 
+	h.storeBundleSyncState(ctx, authTD, getAuthenticatedInstanceID(echoCtx), req.State)
+
 	// Look up relationships the authenticated trust domain has with other trust domains
 	relationships, err := h.Datastore.FindRelationshipsByTrustDomainID(ctx, authTD.ID.UUID)
 	if err != nil {
@@ -391,26 +837,101 @@ func (h *HarvesterAPIHandlers) BundlePut(echoCtx echo.Context, trustDomainName a
 	}
 	// ensure that the bundle's trust domain ID matches the authenticated trust domain ID
 	bundle.TrustDomainID = authTD.ID.UUID
+	instanceID := getAuthenticatedInstanceID(echoCtx)
 
-	storedBundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, authTD.ID.UUID)
+	// the bundle of an external trust domain is fetched from its bundle endpoint, a harvester onboarded before
+	// the trust domain became external must not overwrite it
+	externalTD, err := h.Datastore.FindExternalTrustDomainByTrustDomainID(ctx, authTD.ID.UUID)
 	if err != nil {
-		msg := "failed looking up bundle in DB"
+		msg := "failed looking up external trust domain in DB"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
-	// the bundle already exists in the datastore, so we need to update it
-	if storedBundle != nil {
-		bundle.ID = storedBundle.ID
+	if externalTD != nil {
+		h.recordBundleUpload(ctx, authTD, instanceID, bundle.Digest, entity.BundleUploadRejected)
+		err := fmt.Errorf("trust domain %q is external, its bundle is fetched from its bundle endpoint", authTD.Name.String())
+		return chttp.LogAndRespondWithError(h.Logger.WithField(telemetry.HarvesterInstance, instanceID), err, err.Error(), http.StatusConflict)
 	}
 
-	if _, err := h.Datastore.CreateOrUpdateBundle(ctx, bundle); err != nil {
-		msg := "failed to store bundle in DB"
-		err := fmt.Errorf("%s: %w", msg, err)
-		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	// the bundle is checked against the stored bundle and only stored if the stored bundle did not change in the
+	// meantime. When another harvester instance stored its bundle first, the bundle is checked again against that
+	// one, so that a bundle with a lower sequence number never overwrites a bundle with a higher one.
+	var storedBundle *entity.Bundle
+	for attempt := 1; ; attempt++ {
+		storedBundle, err = h.Datastore.FindBundleByTrustDomainID(ctx, authTD.ID.UUID)
+		if err != nil {
+			msg := "failed looking up bundle in DB"
+			err := fmt.Errorf("%s: %w", msg, err)
+			return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+		}
+
+		if storedBundle != nil && storedBundle.AdminManaged {
+			h.recordBundleUpload(ctx, authTD, instanceID, bundle.Digest, entity.BundleUploadRejected)
+			err := fmt.Errorf("the bundle of trust domain %q is admin-managed", authTD.Name.String())
+			return chttp.LogAndRespondWithError(h.Logger.WithField(telemetry.HarvesterInstance, instanceID), err, err.Error(), http.StatusConflict)
+		}
+
+		var previousData, previousDigest []byte
+		bundle.ID = uuid.NullUUID{}
+		if storedBundle != nil {
+			previousData = storedBundle.Data
+			previousDigest = storedBundle.Digest
+			bundle.ID = storedBundle.ID
+		}
+
+		report := h.BundlePolicy.Validate(authTD.Name, bundle.Data, previousData, time.Now())
+
+		// several harvester instances of the trust domain upload the bundle of their own SPIRE Server, the last writer
+		// wins unless the stored bundle has a higher sequence number, i.e. the uploading SPIRE Server is lagging behind
+		if v := report.Violation(bundlepolicy.RuleSequenceNumber); v != nil {
+			h.recordBundleUpload(ctx, authTD, instanceID, bundle.Digest, entity.BundleUploadStale)
+			err := fmt.Errorf("stale bundle: %s", v.Message)
+			return chttp.LogAndRespondWithError(h.Logger.WithField(telemetry.HarvesterInstance, instanceID), err, err.Error(), http.StatusConflict)
+		}
+		if !report.Valid() {
+			h.recordBundleUpload(ctx, authTD, instanceID, bundle.Digest, entity.BundleUploadRejected)
+			h.Logger.WithField(telemetry.TrustDomain, authTD.Name.String()).Warnf("Rejected bundle that does not comply with the bundle policy: %d violations", len(report.Violations))
+			return chttp.WriteResponse(echoCtx, http.StatusUnprocessableEntity, harvester.BundleValidationReportFromPolicyReport(report))
+		}
+
+		result, err := h.BundleVerifiers.Verify(authTD.Name, bundle)
+		if err != nil {
+			h.recordBundleUpload(ctx, authTD, instanceID, bundle.Digest, entity.BundleUploadRejected)
+			err := fmt.Errorf("failed to verify bundle signature: %w", err)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+		bundle.VerificationStatus = result.Status
+		bundle.VerifiedBy = result.VerifiedBy
+
+		stored, err := h.Datastore.CreateOrUpdateBundleIfUnchanged(ctx, bundle, previousDigest)
+		if err != nil {
+			msg := "failed to store bundle in DB"
+			err := fmt.Errorf("%s: %w", msg, err)
+			return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+		}
+		if stored != nil {
+			break
+		}
+
+		if attempt == maxBundleStoreAttempts {
+			err := fmt.Errorf("the bundle of trust domain %q kept changing while it was stored", authTD.Name.String())
+			return chttp.LogAndRespondWithError(h.Logger.WithField(telemetry.HarvesterInstance, instanceID), err, err.Error(), http.StatusConflict)
+		}
 	}
 
-	h.Logger.WithField(telemetry.TrustDomain, authTD.Name.String()).Info("Stored new bundle")
+	// only notify the update when the content of the bundle changed
+	bundleChanged := storedBundle == nil || !bytes.Equal(storedBundle.Digest, bundle.Digest)
+
+	h.recordBundleUpload(ctx, authTD, instanceID, bundle.Digest, entity.BundleUploadAccepted)
+	h.Logger.WithFields(logrus.Fields{
+		telemetry.TrustDomain:       authTD.Name.String(),
+		telemetry.HarvesterInstance: instanceID,
+	}).Info("Stored new bundle")
+
+	if bundleChanged {
+		h.Notifier.Notify(notification.NewBundleUpdatedEvent(authTD, bundle))
+	}
 
 	if err = chttp.RespondWithoutBody(echoCtx, http.StatusOK); err != nil {
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
@@ -419,6 +940,25 @@ func (h *HarvesterAPIHandlers) BundlePut(echoCtx echo.Context, trustDomainName a
 	return nil
 }
 
+// recordBundleUpload stores the outcome of the bundle upload of the harvester instance.
+// Failing to store it doesn't fail the request, as it is only used for reporting.
+func (h *HarvesterAPIHandlers) recordBundleUpload(ctx context.Context, authTD *entity.TrustDomain, instanceID string, digest []byte, status entity.BundleUploadStatus) {
+	upload := &entity.Harvester{
+		TrustDomainID:          authTD.ID.UUID,
+		InstanceID:             instanceID,
+		LastBundleUploadAt:     time.Now(),
+		LastBundleDigest:       digest,
+		LastBundleUploadStatus: status,
+	}
+
+	if _, err := h.Datastore.UpdateHarvesterBundleUpload(ctx, upload); err != nil {
+		h.Logger.WithError(err).WithFields(logrus.Fields{
+			telemetry.TrustDomain:       authTD.Name,
+			telemetry.HarvesterInstance: instanceID,
+		}).Warn("Failed to record harvester bundle upload")
+	}
+}
+
 func (h *HarvesterAPIHandlers) getBundleSyncResult(ctx context.Context, authTD *entity.TrustDomain, relationships []*entity.Relationship, req harvester.PostBundleSyncRequest) (*harvester.PostBundleSyncResponse, error) {
 	resp := &harvester.PostBundleSyncResponse{
 		State:   make(map[string]api.BundleDigest, len(relationships)),
@@ -426,6 +966,11 @@ func (h *HarvesterAPIHandlers) getBundleSyncResult(ctx context.Context, authTD *
 	}
 
 	for _, relationship := range relationships {
+		if !relationship.Trusts(authTD.ID.UUID) {
+			// one-way relationship in which the peer trusts this trust domain, but not the other way around
+			continue
+		}
+
 		relatedTrustDomainID := relationship.TrustDomainAID
 		if relationship.TrustDomainAID == authTD.ID.UUID {
 			relatedTrustDomainID = relationship.TrustDomainBID
@@ -470,6 +1015,57 @@ func (h *HarvesterAPIHandlers) getBundleSyncResult(ctx context.Context, authTD *
 	return resp, nil
 }
 
+// storeBundleSyncState persists the digests of the federated bundles that the harvester instance reported holding,
+// replacing the ones it reported on previous syncs. The states reported by the other instances of the trust domain are
+// left untouched. Failing to store them doesn't fail the sync, as they are only used for reporting.
+func (h *HarvesterAPIHandlers) storeBundleSyncState(ctx context.Context, authTD *entity.TrustDomain, instanceID string, state harvester.BundlesDigests) {
+	logger := h.Logger.WithFields(logrus.Fields{
+		telemetry.TrustDomain:       authTD.Name,
+		telemetry.HarvesterInstance: instanceID,
+	})
+	reportedAt := time.Now().UTC()
+
+	for tdName, digest := range state {
+		federatedTD, err := spiffeid.TrustDomainFromString(tdName)
+		if err != nil {
+			logger.WithError(err).Debugf("Ignoring bundle sync state of invalid trust domain %q", tdName)
+			continue
+		}
+
+		decodedDigest, err := encoding.DecodeFromBase64(digest)
+		if err != nil {
+			logger.WithError(err).Debugf("Ignoring invalid bundle digest of trust domain %q", tdName)
+			continue
+		}
+
+		_, err = h.Datastore.CreateOrUpdateBundleSyncState(ctx, &entity.BundleSyncState{
+			TrustDomainID:        authTD.ID.UUID,
+			HarvesterInstanceID:  instanceID,
+			FederatedTrustDomain: federatedTD,
+			Digest:               decodedDigest,
+			DigestUpdatedAt:      reportedAt,
+			ReportedAt:           reportedAt,
+		})
+		if err != nil {
+			logger.WithError(err).Warn("Failed to store bundle sync state")
+			return
+		}
+	}
+
+	if err := h.Datastore.DeleteStaleBundleSyncStates(ctx, authTD.ID.UUID, instanceID, reportedAt); err != nil {
+		logger.WithError(err).Warn("Failed to delete stale bundle sync states")
+	}
+}
+
+// getAuthenticatedInstanceID returns the authenticated harvester instance, the default one if there is none.
+func getAuthenticatedInstanceID(echoCtx echo.Context) string {
+	instanceID, ok := echoCtx.Get(authInstanceIDKey).(string)
+	if !ok || instanceID == "" {
+		return constants.DefaultHarvesterInstanceID
+	}
+	return instanceID
+}
+
 func (h *HarvesterAPIHandlers) getAuthenticateTrustDomain(echoCtx echo.Context, trustDomainName string) (*entity.TrustDomain, error) {
 	authTD, ok := echoCtx.Get(authTrustDomainKey).(*entity.TrustDomain)
 	if !ok {
@@ -485,24 +1081,91 @@ func (h *HarvesterAPIHandlers) getAuthenticateTrustDomain(echoCtx echo.Context,
 	return authTD, nil
 }
 
+// findAuthorizedRelationship looks up the relationship, with its trust domain names populated,
+// and checks that the authenticated trust domain is one of its trust domains.
+func (h *HarvesterAPIHandlers) findAuthorizedRelationship(ctx context.Context, authTD *entity.TrustDomain, relationshipID uuid.UUID) (*entity.Relationship, error) {
+	relationship, err := h.Datastore.FindRelationshipByID(ctx, relationshipID)
+	if err != nil {
+		msg := "error looking up relationships"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if relationship == nil {
+		err := fmt.Errorf("relationship not found")
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	if relationship.TrustDomainAID != authTD.ID.UUID && relationship.TrustDomainBID != authTD.ID.UUID {
+		err := fmt.Errorf("relationship doesn't belong to the authenticated trust domain")
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+	}
+
+	if _, err := db.PopulateTrustDomainNames(ctx, h.Datastore, relationship); err != nil {
+		msg := "failed populating relationships entities"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	return relationship, nil
+}
+
+// verifyConsentSignature checks that the signed statement is the consent of the authenticated trust domain to the
+// relationship with the given status, and that it was signed with the key of the leaf signing certificate.
+func verifyConsentSignature(sig *harvester.ConsentSignature, relationship *entity.Relationship, authTD *entity.TrustDomain, status entity.ConsentStatus) (*entity.RelationshipConsent, error) {
+	signedConsent, err := sig.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := consent.ParseStatement(signedConsent.Statement)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := statement.Validate(relationship, authTD.Name, status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	chain, err := x509.ParseCertificates(signedConsent.SigningCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing certificate chain: %w", err)
+	}
+
+	if err := consent.VerifySignature(signedConsent.Statement, signedConsent.Signature, chain); err != nil {
+		return nil, err
+	}
+
+	signedConsent.RelationshipID = relationship.ID.UUID
+	signedConsent.TrustDomainID = authTD.ID.UUID
+	signedConsent.ConsentStatus = status
+
+	return signedConsent, nil
+}
+
 func validateBundleRequest(req *harvester.BundlePutJSONRequestBody) error {
 	if req.TrustDomain == "" {
 		return errors.New("bundle trust domain is required")
 	}
 
-	if req.TrustBundle == "" {
+	return validateBundleDigest(req.TrustBundle, req.Digest)
+}
+
+// validateBundleDigest checks that the base64 encoded digest matches the trust bundle.
+func validateBundleDigest(trustBundle, digest string) error {
+	if trustBundle == "" {
 		return errors.New("trust bundle is required")
 	}
 
-	if req.Digest == "" {
+	if digest == "" {
 		return errors.New("bundle digest is required")
 	}
 
-	decodedDigest, err := encoding.DecodeFromBase64(req.Digest)
+	decodedDigest, err := encoding.DecodeFromBase64(digest)
 	if err != nil {
 		return fmt.Errorf("failed decoding bundle digest: %w", err)
 	}
-	if err := cryptoutil.ValidateBundleDigest([]byte(req.TrustBundle), decodedDigest); err != nil {
+	if err := cryptoutil.ValidateBundleDigest([]byte(trustBundle), decodedDigest); err != nil {
 		return fmt.Errorf("failed validating bundle digest: %w", err)
 	}
 
@@ -530,3 +1193,24 @@ func harvesterGetRelationshipsToQueryParams(params harvester.GetRelationshipsPar
 		consentStatus: params.ConsentStatus,
 	}
 }
+
+// sourceAllowed tells whether the remote address of an onboarding request is in the network the join token
+// is bound to. Tokens that aren't bound to a network are allowed from any address.
+func sourceAllowed(cidr, remoteAddr string) bool {
+	if cidr == "" {
+		return true
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+
+	return ip != nil && network.Contains(ip)
+}
@@ -2,28 +2,49 @@ package endpoints
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/consent"
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/dpop"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleverifier"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/jointoken"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
+	"github.com/HewlettPackard/galadriel/test/certtest"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakejwtissuer"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakenotifier"
 	"github.com/HewlettPackard/galadriel/test/jwttest"
 	gojwt "github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,7 +52,10 @@ import (
 
 const (
 	jwtPath           = "/jwt"
+	clientCertPath    = "/client-certificate"
+	trustBundlePath   = "/server-trust-bundle"
 	onboardPath       = "/onboard"
+	onboardSVIDPath   = "/onboard/svid"
 	relationshipsPath = "/relationships"
 )
 
@@ -45,6 +69,8 @@ var (
 	deniedAcceptedRelAB   = &entity.Relationship{TrustDomainAID: tdA.ID.UUID, TrustDomainBID: tdB.ID.UUID, TrustDomainAConsent: entity.ConsentStatusDenied, TrustDomainBConsent: entity.ConsentStatusApproved, ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
 	acceptedDeniedRelAC   = &entity.Relationship{TrustDomainAID: tdA.ID.UUID, TrustDomainBID: tdC.ID.UUID, TrustDomainAConsent: entity.ConsentStatusApproved, TrustDomainBConsent: entity.ConsentStatusDenied, ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
 	acceptedAcceptedRelBC = &entity.Relationship{TrustDomainAID: tdB.ID.UUID, TrustDomainBID: tdC.ID.UUID, TrustDomainAConsent: entity.ConsentStatusApproved, TrustDomainBConsent: entity.ConsentStatusApproved, ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	aTrustsBRelAB         = &entity.Relationship{TrustDomainAID: tdA.ID.UUID, TrustDomainBID: tdB.ID.UUID, TrustDomainAConsent: entity.ConsentStatusApproved, TrustDomainBConsent: entity.ConsentStatusApproved, Direction: entity.RelationshipATrustsB, ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	aTrustsBRelCA         = &entity.Relationship{TrustDomainAID: tdC.ID.UUID, TrustDomainBID: tdA.ID.UUID, TrustDomainAConsent: entity.ConsentStatusApproved, TrustDomainBConsent: entity.ConsentStatusApproved, Direction: entity.RelationshipATrustsB, ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
 
 	bundleA = &entity.Bundle{Data: []byte("bundle-A"), Digest: cryptoutil.CalculateDigest([]byte("bundle-A")), Signature: []byte("signature-A"), TrustDomainName: tdA.Name, TrustDomainID: tdA.ID.UUID, ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
 	bundleB = &entity.Bundle{Data: []byte("bundle-B"), Digest: cryptoutil.CalculateDigest([]byte("bundle-B")), Signature: []byte("signature-B"), TrustDomainName: tdB.Name, TrustDomainID: tdB.ID.UUID, ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
@@ -55,7 +81,9 @@ type HarvesterTestSetup struct {
 	EchoCtx   echo.Context
 	Handler   *HarvesterAPIHandlers
 	Datastore *fakedatastore.FakeDatabase
+	Notifier  *fakenotifier.Notifier
 	JWTIssuer *fakejwtissuer.JWTIssuer
+	X509CA    *disk.X509CA
 	Recorder  *httptest.ResponseRecorder
 }
 
@@ -72,18 +100,26 @@ func NewHarvesterTestSetup(t *testing.T, method, url string, body interface{}) *
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	fakeDB := fakedatastore.NewFakeDB()
+	fakeNotifier := fakenotifier.New()
 	logger := logrus.New()
 
 	jwtAudience := []string{"test"}
 	jwtIssuer := fakejwtissuer.New(t, "test", td1, jwtAudience)
 	jwtValidator := jwttest.NewJWTValidator(jwtIssuer.Signer, jwtAudience)
 
+	bundlePolicy, err := bundlepolicy.New(nil)
+	require.NoError(t, err)
+
+	x509CA := newTestX509CA(t)
+
 	return &HarvesterTestSetup{
 		EchoCtx:   e.NewContext(req, rec),
 		Recorder:  rec,
-		Handler:   NewHarvesterAPIHandlers(logger, fakeDB, jwtIssuer, jwtValidator),
+		Handler:   NewHarvesterAPIHandlers(logger, fakeDB, fakeNotifier, bundleverifier.NewSet(nil), bundlePolicy, jwtIssuer, jwtValidator, x509CA),
 		JWTIssuer: jwtIssuer,
+		X509CA:    x509CA,
 		Datastore: fakeDB,
+		Notifier:  fakeNotifier,
 	}
 }
 
@@ -101,6 +137,18 @@ func SetupTrustDomain(t *testing.T, ds db.Datastore) *entity.TrustDomain {
 	return trustDomain
 }
 
+// SetupExternalTrustDomain configures the trust domain as external, fed from its SPIFFE bundle endpoint.
+func SetupExternalTrustDomain(t *testing.T, ds db.Datastore, td uuid.UUID) *entity.ExternalTrustDomain {
+	externalTD, err := ds.CreateOrUpdateExternalTrustDomain(context.Background(), &entity.ExternalTrustDomain{
+		TrustDomainID:         td,
+		BundleEndpointURL:     "https://td1.org/bundle",
+		BundleEndpointProfile: "https_web",
+	})
+	require.NoError(t, err)
+
+	return externalTD
+}
+
 func SetupBundle(t *testing.T, ds db.Datastore, td uuid.UUID) *entity.Bundle {
 	bundle := &entity.Bundle{
 		TrustDomainID: td,
@@ -115,13 +163,28 @@ func SetupBundle(t *testing.T, ds db.Datastore, td uuid.UUID) *entity.Bundle {
 }
 
 func SetupJoinToken(t *testing.T, ds db.Datastore, td uuid.UUID) *entity.JoinToken {
-	jt := &entity.JoinToken{
-		Token:         "test-join-token",
+	return createJoinToken(t, ds, NewJoinToken(t, td))
+}
+
+// NewJoinToken returns a join token of the given trust domain to be created, holding both the token and its hash.
+func NewJoinToken(t *testing.T, td uuid.UUID) *entity.JoinToken {
+	token, err := jointoken.Generate()
+	require.NoError(t, err)
+
+	return &entity.JoinToken{
+		Token:         token.String(),
+		TokenPrefix:   token.Prefix,
+		TokenSalt:     token.Salt,
+		TokenHash:     token.Hash,
 		TrustDomainID: td,
 	}
+}
 
+// createJoinToken creates the join token, returning it along with the token, which is not stored.
+func createJoinToken(t *testing.T, ds db.Datastore, jt *entity.JoinToken) *entity.JoinToken {
 	joinToken, err := ds.CreateJoinToken(context.Background(), jt)
 	require.NoError(t, err)
+	joinToken.Token = jt.Token
 
 	return joinToken
 }
@@ -308,6 +371,264 @@ func testPatchRelationship(t *testing.T, f func(setup *HarvesterTestSetup, trust
 	assert.Equal(t, expected.TrustDomainAName, resp.TrustDomainAName)
 	assert.Equal(t, expected.TrustDomainBName, resp.TrustDomainBName)
 	assert.Equal(t, expected.TrustDomainAConsent, resp.TrustDomainAConsent)
+
+	names := map[uuid.UUID]string{tdA.ID.UUID: tdA.Name.String(), tdB.ID.UUID: tdB.Name.String(), tdC.ID.UUID: tdC.Name.String()}
+	assertNotified(t, setup.Notifier, notification.EventRelationshipConsentUpdated, names[relationship.TrustDomainAID], names[relationship.TrustDomainBID])
+}
+
+func TestTCPPatchRelationshipWithConsentSignature(t *testing.T) {
+	t.Run("Successfully patch relationship storing the signed consent", func(t *testing.T) {
+		relationship := newPendingRelationship(tdA, tdB)
+		sig := signConsent(t, relationship, tdB, entity.ConsentStatusApproved, time.Now())
+
+		setup := patchRelationshipWithConsent(t, tdB, relationship, api.Approved, sig)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		consents, err := setup.Datastore.FindRelationshipConsentsByRelationshipID(context.Background(), relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, consents, 1)
+		assert.Equal(t, tdB.ID.UUID, consents[0].TrustDomainID)
+		assert.Equal(t, entity.ConsentStatusApproved, consents[0].ConsentStatus)
+		assert.Equal(t, sig.Statement, encoding.EncodeToBase64(consents[0].Statement))
+		assert.Equal(t, sig.Signature, encoding.EncodeToBase64(consents[0].Signature))
+		assert.Equal(t, sig.SigningCertificate, encoding.EncodeToBase64(consents[0].SigningCertificate))
+	})
+
+	t.Run("Successfully patch relationship without signature removing the stored consent", func(t *testing.T) {
+		relationship := newPendingRelationship(tdA, tdB)
+
+		setup := NewHarvesterTestSetup(t, http.MethodPatch, relationshipsPath+"/"+relationship.ID.UUID.String(), &harvester.PatchRelationshipRequest{ConsentStatus: api.Denied})
+		setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+		setup.Datastore.WithRelationships(relationship)
+		setup.EchoCtx.Set(authTrustDomainKey, tdA)
+
+		_, err := setup.Datastore.CreateOrUpdateRelationshipConsent(context.Background(), &entity.RelationshipConsent{
+			RelationshipID: relationship.ID.UUID,
+			TrustDomainID:  tdA.ID.UUID,
+			ConsentStatus:  entity.ConsentStatusApproved,
+		})
+		require.NoError(t, err)
+
+		err = setup.Handler.PatchRelationship(setup.EchoCtx, tdA.Name.String(), relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		consents, err := setup.Datastore.FindRelationshipConsentsByRelationshipID(context.Background(), relationship.ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, consents)
+	})
+
+	testCases := []struct {
+		name   string
+		modify func(relationship *entity.Relationship) *harvester.ConsentSignature
+		err    string
+	}{
+		{
+			name: "statement of another trust domain",
+			modify: func(relationship *entity.Relationship) *harvester.ConsentSignature {
+				return signConsent(t, relationship, tdA, entity.ConsentStatusApproved, time.Now())
+			},
+			err: `invalid consent signature: statement trust domain "td-a.org" does not match trust domain "td-b.org"`,
+		},
+		{
+			name: "statement with another consent status",
+			modify: func(relationship *entity.Relationship) *harvester.ConsentSignature {
+				return signConsent(t, relationship, tdB, entity.ConsentStatusDenied, time.Now())
+			},
+			err: `invalid consent signature: statement consent status "denied" does not match consent status "approved"`,
+		},
+		{
+			name: "stale statement",
+			modify: func(relationship *entity.Relationship) *harvester.ConsentSignature {
+				return signConsent(t, relationship, tdB, entity.ConsentStatusApproved, time.Now().Add(-time.Hour))
+			},
+			err: "is not within 5m0s of the current time",
+		},
+		{
+			name: "tampered statement",
+			modify: func(relationship *entity.Relationship) *harvester.ConsentSignature {
+				sig := signConsent(t, relationship, tdB, entity.ConsentStatusApproved, time.Now())
+				other := signConsent(t, relationship, tdB, entity.ConsentStatusApproved, time.Now().Add(time.Second))
+				sig.Statement = other.Statement
+				return sig
+			},
+			err: "invalid consent signature: failed to verify consent signature",
+		},
+		{
+			name: "malformed signing certificate",
+			modify: func(relationship *entity.Relationship) *harvester.ConsentSignature {
+				sig := signConsent(t, relationship, tdB, entity.ConsentStatusApproved, time.Now())
+				sig.SigningCertificate = encoding.EncodeToBase64([]byte("not a certificate"))
+				return sig
+			},
+			err: "invalid consent signature: failed to parse signing certificate chain",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run("Fails with "+tc.name, func(t *testing.T) {
+			relationship := newPendingRelationship(tdA, tdB)
+			sig := tc.modify(relationship)
+
+			setup := NewHarvesterTestSetup(t, http.MethodPatch, relationshipsPath+"/"+relationship.ID.UUID.String(), &harvester.PatchRelationshipRequest{ConsentStatus: api.Approved, ConsentSignature: sig})
+			setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+			setup.Datastore.WithRelationships(relationship)
+			setup.EchoCtx.Set(authTrustDomainKey, tdB)
+
+			err := setup.Handler.PatchRelationship(setup.EchoCtx, tdB.Name.String(), relationship.ID.UUID)
+			require.Error(t, err)
+			assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+			assert.Contains(t, err.(*echo.HTTPError).Message, tc.err)
+
+			rel, err := setup.Datastore.FindRelationshipByID(context.Background(), relationship.ID.UUID)
+			require.NoError(t, err)
+			assert.Equal(t, entity.ConsentStatusPending, rel.TrustDomainBConsent)
+			assert.Empty(t, setup.Notifier.Events())
+		})
+	}
+}
+
+func TestTCPGetRelationshipByID(t *testing.T) {
+	t.Run("Successfully get a relationship", func(t *testing.T) {
+		relationship := newPendingRelationship(tdA, tdB)
+
+		setup := NewHarvesterTestSetup(t, http.MethodGet, relationshipsPath+"/"+relationship.ID.UUID.String(), nil)
+		setup.Datastore.WithTrustDomains(tdA, tdB)
+		setup.Datastore.WithRelationships(relationship)
+		setup.EchoCtx.Set(authTrustDomainKey, tdB)
+
+		err := setup.Handler.GetRelationshipByID(setup.EchoCtx, tdB.Name.String(), relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp api.Relationship
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+		assert.Equal(t, relationship.ID.UUID, resp.Id)
+		assert.Equal(t, tdA.Name.String(), *resp.TrustDomainAName)
+		assert.Equal(t, tdB.Name.String(), *resp.TrustDomainBName)
+	})
+
+	t.Run("Fails if the relationship does not exist", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodGet, relationshipsPath+"/"+uuid.NewString(), nil)
+		setup.EchoCtx.Set(authTrustDomainKey, tdB)
+
+		err := setup.Handler.GetRelationshipByID(setup.EchoCtx, tdB.Name.String(), uuid.New())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "relationship not found")
+	})
+}
+
+func TestTCPGetRelationshipConsents(t *testing.T) {
+	relationship := newPendingRelationship(tdA, tdB)
+	relationship.TrustDomainAConsent = entity.ConsentStatusApproved
+	relationship.TrustDomainBConsent = entity.ConsentStatusApproved
+
+	setupFunc := func(t *testing.T, authTD *entity.TrustDomain) *HarvesterTestSetup {
+		setup := NewHarvesterTestSetup(t, http.MethodGet, relationshipsPath+"/"+relationship.ID.UUID.String()+"/consents", nil)
+		setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+		setup.Datastore.WithRelationships(relationship)
+		setup.EchoCtx.Set(authTrustDomainKey, authTD)
+
+		for _, td := range []*entity.TrustDomain{tdA, tdB} {
+			_, err := setup.Datastore.CreateOrUpdateRelationshipConsent(context.Background(), &entity.RelationshipConsent{
+				RelationshipID:     relationship.ID.UUID,
+				TrustDomainID:      td.ID.UUID,
+				ConsentStatus:      entity.ConsentStatusApproved,
+				Statement:          []byte("statement-" + td.Name.String()),
+				Signature:          []byte("signature-" + td.Name.String()),
+				SigningCertificate: []byte("certificate-" + td.Name.String()),
+			})
+			require.NoError(t, err)
+		}
+
+		return setup
+	}
+
+	t.Run("Successfully get the consents of both trust domains", func(t *testing.T) {
+		setup := setupFunc(t, tdA)
+
+		err := setup.Handler.GetRelationshipConsents(setup.EchoCtx, tdA.Name.String(), relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.RelationshipConsents
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+		require.Len(t, resp, 2)
+
+		byTrustDomain := make(map[string]harvester.RelationshipConsent)
+		for _, c := range resp {
+			byTrustDomain[c.TrustDomainName] = c
+		}
+		for _, td := range []*entity.TrustDomain{tdA, tdB} {
+			c, ok := byTrustDomain[td.Name.String()]
+			require.True(t, ok)
+			assert.Equal(t, api.Approved, c.ConsentStatus)
+			assert.Equal(t, encoding.EncodeToBase64([]byte("statement-"+td.Name.String())), c.Statement)
+			assert.Equal(t, encoding.EncodeToBase64([]byte("signature-"+td.Name.String())), c.Signature)
+			assert.Equal(t, encoding.EncodeToBase64([]byte("certificate-"+td.Name.String())), c.SigningCertificate)
+		}
+	})
+
+	t.Run("Fails if the trust domain is not part of the relationship", func(t *testing.T) {
+		setup := setupFunc(t, tdC)
+
+		err := setup.Handler.GetRelationshipConsents(setup.EchoCtx, tdC.Name.String(), relationship.ID.UUID)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "relationship doesn't belong to the authenticated trust domain")
+	})
+}
+
+func patchRelationshipWithConsent(t *testing.T, authTD *entity.TrustDomain, relationship *entity.Relationship, status api.ConsentStatus, sig *harvester.ConsentSignature) *HarvesterTestSetup {
+	requestBody := &harvester.PatchRelationshipRequest{
+		ConsentStatus:    status,
+		ConsentSignature: sig,
+	}
+
+	setup := NewHarvesterTestSetup(t, http.MethodPatch, relationshipsPath+"/"+relationship.ID.UUID.String(), requestBody)
+	setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+	setup.Datastore.WithRelationships(relationship)
+	setup.EchoCtx.Set(authTrustDomainKey, authTD)
+
+	err := setup.Handler.PatchRelationship(setup.EchoCtx, authTD.Name.String(), relationship.ID.UUID)
+	require.NoError(t, err)
+
+	return setup
+}
+
+// signConsent signs the consent statement of the trust domain td with a self-signed certificate.
+func signConsent(t *testing.T, relationship *entity.Relationship, td *entity.TrustDomain, status entity.ConsentStatus, now time.Time) *harvester.ConsentSignature {
+	cert, key := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	signer, ok := key.(crypto.Signer)
+	require.True(t, ok)
+
+	rel := *relationship
+	rel.TrustDomainAName = tdA.Name
+	rel.TrustDomainBName = tdB.Name
+
+	statement, err := consent.NewStatement(&rel, td.Name, status, now).Marshal()
+	require.NoError(t, err)
+
+	signature, err := signer.Sign(rand.Reader, cryptoutil.CalculateDigest(statement), crypto.SHA256)
+	require.NoError(t, err)
+
+	return &harvester.ConsentSignature{
+		Statement:          encoding.EncodeToBase64(statement),
+		Signature:          encoding.EncodeToBase64(signature),
+		SigningCertificate: encoding.EncodeToBase64(cert.Raw),
+	}
+}
+
+func newPendingRelationship(a, b *entity.TrustDomain) *entity.Relationship {
+	return &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      a.ID.UUID,
+		TrustDomainBID:      b.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusPending,
+		TrustDomainBConsent: entity.ConsentStatusPending,
+	}
 }
 
 func TestTCPOnboard(t *testing.T) {
@@ -342,6 +663,94 @@ func TestTCPOnboard(t *testing.T) {
 		jwtToken := strings.ReplaceAll(result.Token, "\"", "")
 		jwtToken = strings.ReplaceAll(jwtToken, "\n", "")
 		assert.Equal(t, harvesterTestSetup.JWTIssuer.Token, jwtToken)
+
+		assert.Equal(t, constants.DefaultHarvesterInstanceID, result.InstanceID)
+		assert.Equal(t, constants.DefaultHarvesterInstanceID, harvesterTestSetup.JWTIssuer.Params.InstanceID)
+	})
+	t.Run("Successfully onboard a harvester instance", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+
+		instanceID := "spire-server-1"
+		params := harvester.OnboardParams{
+			JoinToken:  token.Token,
+			InstanceId: &instanceID,
+		}
+
+		err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, harvesterTestSetup.Recorder.Code)
+
+		var result harvester.OnboardHarvesterResponse
+		err = json.Unmarshal(harvesterTestSetup.Recorder.Body.Bytes(), &result)
+		require.NoError(t, err)
+		assert.Equal(t, instanceID, result.InstanceID)
+		assert.Equal(t, instanceID, harvesterTestSetup.JWTIssuer.Params.InstanceID)
+	})
+	t.Run("Onboarding with a proof of possession binds the token to the key", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		proof, err := dpop.NewProof(key, http.MethodGet, "http://example.com"+onboardPath, "")
+		require.NoError(t, err)
+		echoCtx.Request().Header.Set(dpop.HeaderName, proof)
+
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), harvester.OnboardParams{JoinToken: token.Token})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, harvesterTestSetup.Recorder.Code)
+
+		thumbprint, err := dpop.Thumbprint(key.Public())
+		require.NoError(t, err)
+		assert.Equal(t, thumbprint, harvesterTestSetup.JWTIssuer.Params.KeyThumbprint)
+	})
+	t.Run("onboard with an invalid proof of possession fails without using the token", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		proof, err := dpop.NewProof(key, http.MethodGet, "http://other.com"+onboardPath, "")
+		require.NoError(t, err)
+		echoCtx.Request().Header.Set(dpop.HeaderName, proof)
+
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), harvester.OnboardParams{JoinToken: token.Token})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "invalid proof of possession")
+
+		stored, err := harvesterTestSetup.Handler.Datastore.FindJoinTokensByID(context.Background(), token.ID.UUID)
+		require.NoError(t, err)
+		assert.False(t, stored.Used)
+	})
+	t.Run("onboard with invalid instance ID fails", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+
+		instanceID := "spire server/1"
+		params := harvester.OnboardParams{
+			JoinToken:  token.Token,
+			InstanceId: &instanceID,
+		}
+		err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+		require.Error(t, err)
+
+		httpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Equal(t, `invalid harvester instance ID: "spire server/1"`, httpErr.Message)
 	})
 	t.Run("onboard without join token fails", func(t *testing.T) {
 		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
@@ -376,6 +785,27 @@ func TestTCPOnboard(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
 		assert.Contains(t, httpErr.Message, "token not found")
 	})
+	t.Run("onboard with the prefix of a join token and a wrong secret", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+
+		params := harvester.OnboardParams{
+			JoinToken: token.TokenPrefix + ".wrong-secret",
+		}
+		err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+		require.Error(t, err)
+
+		httpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Contains(t, httpErr.Message, "token not found")
+
+		stored, err := harvesterTestSetup.Handler.Datastore.FindJoinTokensByID(context.Background(), token.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, stored.UseCount)
+	})
 	t.Run("onboard with join token that was used", func(t *testing.T) {
 		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
 		echoCtx := harvesterTestSetup.EchoCtx
@@ -397,57 +827,431 @@ func TestTCPOnboard(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
 		assert.Contains(t, httpErr.Message, "token already used")
 	})
-}
-
-func TestTCPGetNewJWTToken(t *testing.T) {
-	t.Run("Successfully get a new JWT token", func(t *testing.T) {
-		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwtPath, nil)
+	t.Run("onboard several times with a multi-use join token", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
 		echoCtx := harvesterTestSetup.EchoCtx
 
 		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		jt := NewJoinToken(t, td.ID.UUID)
+		jt.MaxUses = 2
+		token := createJoinToken(t, harvesterTestSetup.Handler.Datastore, jt)
 
-		var claims gojwt.RegisteredClaims
-		_, err := gojwt.ParseWithClaims(harvesterTestSetup.JWTIssuer.Token, &claims, func(*gojwt.Token) (interface{}, error) {
-			return harvesterTestSetup.JWTIssuer.Signer.Public(), nil
+		params := harvester.OnboardParams{JoinToken: token.Token}
+		for i := 0; i < 2; i++ {
+			err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+			require.NoError(t, err)
+		}
+
+		stored, err := harvesterTestSetup.Handler.Datastore.FindJoinTokensByID(context.Background(), token.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored.UseCount)
+		assert.True(t, stored.Used)
+
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+		require.Error(t, err)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "token already used")
+	})
+	t.Run("Successfully onboard with a legacy join token kept by the hashing migration", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		legacyToken := uuid.NewString()
+		token := createJoinToken(t, harvesterTestSetup.Handler.Datastore, &entity.JoinToken{
+			Token:         legacyToken,
+			TokenPrefix:   uuid.NewString(),
+			TokenHash:     jointoken.LegacyHash(legacyToken),
+			TrustDomainID: td.ID.UUID,
 		})
-		assert.NoError(t, err)
-		echoCtx.Set(authClaimsKey, &claims)
 
-		err = harvesterTestSetup.Handler.GetNewJWTToken(echoCtx, td.Name.String())
-		assert.NoError(t, err)
+		err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), harvester.OnboardParams{JoinToken: legacyToken})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, harvesterTestSetup.Recorder.Code)
 
-		recorder := harvesterTestSetup.Recorder
-		assert.Equal(t, http.StatusOK, recorder.Code)
-		assert.NotEmpty(t, recorder.Body)
+		stored, err := harvesterTestSetup.Handler.Datastore.FindJoinTokensByID(context.Background(), token.ID.UUID)
+		require.NoError(t, err)
+		assert.True(t, stored.Used)
 
-		jwtToken := strings.ReplaceAll(recorder.Body.String(), "\"", "")
-		jwtToken = strings.ReplaceAll(jwtToken, "\n", "")
-		expected := fmt.Sprintf("{token:%s}", harvesterTestSetup.JWTIssuer.Token)
-		assert.Equal(t, expected, jwtToken)
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), harvester.OnboardParams{JoinToken: legacyToken})
+		require.Error(t, err)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "token already used")
+
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), harvester.OnboardParams{JoinToken: uuid.NewString()})
+		require.Error(t, err)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "token not found")
 	})
-	t.Run("Fails if no JWT token was sent", func(t *testing.T) {
-		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwtPath, nil)
+	t.Run("onboard with a join token of an external trust domain fails without using the token", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+		SetupExternalTrustDomain(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+
+		err := harvesterTestSetup.Handler.Onboard(harvesterTestSetup.EchoCtx, td1, harvester.OnboardParams{JoinToken: token.Token})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+		assert.Equal(t, fmt.Sprintf("trust domain %q is external, its bundle is fetched from its bundle endpoint", td1), err.(*echo.HTTPError).Message)
+
+		stored, err := harvesterTestSetup.Handler.Datastore.FindJoinTokensByID(context.Background(), token.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, stored.UseCount)
+		assert.False(t, stored.Used)
+	})
+
+	t.Run("onboard with a join token bound to a network", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
 		echoCtx := harvesterTestSetup.EchoCtx
 
-		err := harvesterTestSetup.Handler.GetNewJWTToken(echoCtx, "td1")
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		jt := NewJoinToken(t, td.ID.UUID)
+		jt.SourceCIDR = "10.0.0.0/8"
+		token := createJoinToken(t, harvesterTestSetup.Handler.Datastore, jt)
+
+		params := harvester.OnboardParams{JoinToken: token.Token}
+
+		echoCtx.Request().RemoteAddr = "192.0.2.1:4321"
+		err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
 		require.Error(t, err)
+		httpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+		assert.Equal(t, "token not allowed from this source address", httpErr.Message)
 
-		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
-		assert.Contains(t, err.(*echo.HTTPError).Message, "failed to parse JWT access token claims")
+		// the refused attempt does not count as a use
+		echoCtx.Request().RemoteAddr = "10.1.2.3:4321"
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+		require.NoError(t, err)
 	})
 }
 
-func TestTCPBundleSync(t *testing.T) {
-	testCases := []struct {
-		name          string
-		trustDomain   string
-		relationships []*entity.Relationship
-		bundleState   harvester.PostBundleSyncRequest
-		expected      harvester.PostBundleSyncResponse
-	}{
-		{
-			name:          "Successfully sync no new bundles",
-			trustDomain:   tdA.Name.String(),
+func TestTCPOnboardWithSVID(t *testing.T) {
+	clk := clock.New()
+	ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	otherCA, otherCAKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+
+	tdName := spiffeid.RequireTrustDomainFromString(td1)
+	harvesterID := spiffeid.RequireFromPath(tdName, "/galadriel-harvester")
+	svid, _ := certtest.CreateTestX509SVID(t, clk, ca, caKey, harvesterID)
+
+	onboardingBundle := spiffebundle.New(tdName)
+	onboardingBundle.AddX509Authority(ca)
+	onboardingBundleBytes, err := onboardingBundle.Marshal()
+	require.NoError(t, err)
+
+	// setupSVIDOnboarding creates the trust domain with the given SVID onboarding settings,
+	// and presents the given certificates as TLS client certificates of the request
+	setupSVIDOnboarding := func(t *testing.T, pinnedID spiffeid.ID, bundle []byte, certs ...*x509.Certificate) (*HarvesterTestSetup, *entity.TrustDomain) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardSVIDPath, nil)
+		harvesterTestSetup.EchoCtx.Request().TLS = &tls.ConnectionState{PeerCertificates: certs}
+
+		td, err := harvesterTestSetup.Handler.Datastore.CreateOrUpdateTrustDomain(context.Background(), &entity.TrustDomain{
+			Name:              tdName,
+			HarvesterSPIFFEID: pinnedID,
+			OnboardingBundle:  bundle,
+		})
+		require.NoError(t, err)
+
+		return harvesterTestSetup, td
+	}
+
+	assertOnboardError := func(t *testing.T, err error, code int, msg string) {
+		require.Error(t, err)
+		httpErr := err.(*echo.HTTPError)
+		assert.Equal(t, code, httpErr.Code)
+		assert.Equal(t, msg, httpErr.Message)
+	}
+
+	t.Run("Successfully onboard with an SVID verified against the onboarding bundle", func(t *testing.T) {
+		harvesterTestSetup, td := setupSVIDOnboarding(t, harvesterID, onboardingBundleBytes, svid)
+
+		instanceID := "spire-server-1"
+		params := harvester.OnboardWithSVIDParams{InstanceId: &instanceID}
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, params)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, harvesterTestSetup.Recorder.Code)
+
+		var result harvester.OnboardHarvesterResponse
+		err = json.Unmarshal(harvesterTestSetup.Recorder.Body.Bytes(), &result)
+		require.NoError(t, err)
+		assert.Equal(t, td.ID.UUID, result.TrustDomainID)
+		assert.Equal(t, td1, result.TrustDomainName)
+		assert.Equal(t, instanceID, result.InstanceID)
+		assert.Equal(t, harvesterTestSetup.JWTIssuer.Token, result.Token)
+		assert.Equal(t, tdName, harvesterTestSetup.JWTIssuer.Params.Subject)
+	})
+	t.Run("Successfully onboard with an SVID verified against the stored bundle", func(t *testing.T) {
+		harvesterTestSetup, td := setupSVIDOnboarding(t, harvesterID, nil, svid)
+		_, err := harvesterTestSetup.Handler.Datastore.CreateOrUpdateBundle(context.Background(), &entity.Bundle{
+			TrustDomainID: td.ID.UUID,
+			Data:          onboardingBundleBytes,
+		})
+		require.NoError(t, err)
+
+		err = harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, harvesterTestSetup.Recorder.Code)
+		assert.Equal(t, constants.DefaultHarvesterInstanceID, harvesterTestSetup.JWTIssuer.Params.InstanceID)
+	})
+	t.Run("onboard with SVID fails with any SVID of the trust domain when no SPIFFE ID is pinned", func(t *testing.T) {
+		otherSVID, _ := certtest.CreateTestX509SVID(t, clk, ca, caKey, spiffeid.RequireFromPath(tdName, "/other"))
+		harvesterTestSetup, _ := setupSVIDOnboarding(t, spiffeid.ID{}, onboardingBundleBytes, otherSVID)
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusForbidden, "SVID onboarding is not enabled for the trust domain")
+	})
+	t.Run("onboard with SVID fails if the trust domain does not exist", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardSVIDPath, nil)
+		harvesterTestSetup.EchoCtx.Request().TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{svid}}
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusBadRequest, "trust domain not found")
+	})
+	t.Run("onboard with SVID fails if the trust domain is external", func(t *testing.T) {
+		harvesterTestSetup, td := setupSVIDOnboarding(t, harvesterID, onboardingBundleBytes, svid)
+		SetupExternalTrustDomain(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusConflict, fmt.Sprintf("trust domain %q is external, its bundle is fetched from its bundle endpoint", td1))
+	})
+	t.Run("onboard with SVID fails if it is not enabled for the trust domain", func(t *testing.T) {
+		harvesterTestSetup, _ := setupSVIDOnboarding(t, spiffeid.ID{}, nil, svid)
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusForbidden, "SVID onboarding is not enabled for the trust domain")
+	})
+	t.Run("onboard with SVID fails without client certificate", func(t *testing.T) {
+		harvesterTestSetup, _ := setupSVIDOnboarding(t, harvesterID, onboardingBundleBytes)
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusUnauthorized, "an X509-SVID client certificate is required")
+	})
+	t.Run("onboard with SVID fails if there is no bundle to verify it", func(t *testing.T) {
+		harvesterTestSetup, _ := setupSVIDOnboarding(t, harvesterID, nil, svid)
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusUnauthorized, "no bundle to verify the X509-SVID")
+	})
+	t.Run("onboard with SVID fails if it is not signed by the bundle", func(t *testing.T) {
+		untrustedSVID, _ := certtest.CreateTestX509SVID(t, clk, otherCA, otherCAKey, harvesterID)
+		harvesterTestSetup, _ := setupSVIDOnboarding(t, harvesterID, onboardingBundleBytes, untrustedSVID)
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusUnauthorized, "invalid X509-SVID")
+	})
+	t.Run("onboard with SVID fails if its SPIFFE ID is not the pinned one", func(t *testing.T) {
+		otherSVID, _ := certtest.CreateTestX509SVID(t, clk, ca, caKey, spiffeid.RequireFromPath(tdName, "/other"))
+		harvesterTestSetup, _ := setupSVIDOnboarding(t, harvesterID, onboardingBundleBytes, otherSVID)
+
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, harvester.OnboardWithSVIDParams{})
+		assertOnboardError(t, err, http.StatusUnauthorized, "X509-SVID SPIFFE ID is not allowed to onboard the trust domain")
+	})
+	t.Run("onboard with SVID fails with an invalid instance ID", func(t *testing.T) {
+		harvesterTestSetup, _ := setupSVIDOnboarding(t, harvesterID, onboardingBundleBytes, svid)
+
+		instanceID := "spire server/1"
+		params := harvester.OnboardWithSVIDParams{InstanceId: &instanceID}
+		err := harvesterTestSetup.Handler.OnboardWithSVID(harvesterTestSetup.EchoCtx, td1, params)
+		assertOnboardError(t, err, http.StatusBadRequest, `invalid harvester instance ID: "spire server/1"`)
+	})
+}
+
+func TestSourceAllowed(t *testing.T) {
+	assert.True(t, sourceAllowed("", "192.0.2.1:1234"))
+	assert.True(t, sourceAllowed("192.0.2.0/24", "192.0.2.1:1234"))
+	assert.True(t, sourceAllowed("2001:db8::/32", "[2001:db8::1]:1234"))
+	assert.False(t, sourceAllowed("10.0.0.0/8", "192.0.2.1:1234"))
+	assert.False(t, sourceAllowed("10.0.0.0/8", "not-an-address"))
+	assert.False(t, sourceAllowed("malformed", "10.0.0.1:1234"))
+}
+
+func TestTCPGetNewJWTToken(t *testing.T) {
+	t.Run("Successfully get a new JWT token", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwtPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+
+		var claims jwt.Claims
+		_, err := gojwt.ParseWithClaims(harvesterTestSetup.JWTIssuer.Token, &claims, func(*gojwt.Token) (interface{}, error) {
+			return harvesterTestSetup.JWTIssuer.Signer.Public(), nil
+		})
+		assert.NoError(t, err)
+		claims.InstanceID = "spire-server-1"
+		echoCtx.Set(authClaimsKey, &claims)
+
+		err = harvesterTestSetup.Handler.GetNewJWTToken(echoCtx, td.Name.String())
+		assert.NoError(t, err)
+
+		recorder := harvesterTestSetup.Recorder
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotEmpty(t, recorder.Body)
+
+		jwtToken := strings.ReplaceAll(recorder.Body.String(), "\"", "")
+		jwtToken = strings.ReplaceAll(jwtToken, "\n", "")
+		expected := fmt.Sprintf("{token:%s}", harvesterTestSetup.JWTIssuer.Token)
+		assert.Equal(t, expected, jwtToken)
+
+		// the new token is issued to the same harvester instance
+		require.NotNil(t, harvesterTestSetup.JWTIssuer.Params)
+		assert.Equal(t, "spire-server-1", harvesterTestSetup.JWTIssuer.Params.InstanceID)
+	})
+	t.Run("The new JWT token is bound to the same key", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwtPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		claims := &jwt.Claims{Confirmation: &jwt.Confirmation{JWKThumbprint: "thumbprint"}}
+		claims.Subject = td.Name.String()
+		echoCtx.Set(authClaimsKey, claims)
+
+		err := harvesterTestSetup.Handler.GetNewJWTToken(echoCtx, td.Name.String())
+		require.NoError(t, err)
+		assert.Equal(t, "thumbprint", harvesterTestSetup.JWTIssuer.Params.KeyThumbprint)
+	})
+	t.Run("Tokens not bound to a key are renewed bound to the key of the proof of possession", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwtPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		claims := &jwt.Claims{}
+		claims.Subject = td.Name.String()
+		echoCtx.Set(authClaimsKey, claims)
+
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		proof, err := dpop.NewProof(key, http.MethodGet, "http://example.com"+jwtPath, "access-token")
+		require.NoError(t, err)
+		echoCtx.Request().Header.Set(echo.HeaderAuthorization, "Bearer access-token")
+		echoCtx.Request().Header.Set(dpop.HeaderName, proof)
+
+		err = harvesterTestSetup.Handler.GetNewJWTToken(echoCtx, td.Name.String())
+		require.NoError(t, err)
+
+		thumbprint, err := dpop.Thumbprint(key.Public())
+		require.NoError(t, err)
+		assert.Equal(t, thumbprint, harvesterTestSetup.JWTIssuer.Params.KeyThumbprint)
+	})
+	t.Run("Fails if no JWT token was sent", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwtPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		err := harvesterTestSetup.Handler.GetNewJWTToken(echoCtx, "td1")
+		require.Error(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "failed to parse JWT access token claims")
+	})
+}
+
+func TestTCPIssueClientCertificate(t *testing.T) {
+	newCSR := func(t *testing.T) (string, crypto.Signer) {
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), key
+	}
+
+	t.Run("Issues a client certificate to the authenticated harvester instance", func(t *testing.T) {
+		csr, key := newCSR(t)
+		setup := NewHarvesterTestSetup(t, http.MethodPost, clientCertPath, &harvester.IssueClientCertificateRequest{Csr: csr})
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		setup.EchoCtx.Set(authInstanceIDKey, "spire-server-1")
+
+		err := setup.Handler.IssueClientCertificate(setup.EchoCtx, td.Name.String())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.ClientCertificateResponse
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &resp)
+		require.NoError(t, err)
+
+		chain, err := cryptoutil.ParseCertificates([]byte(resp.CertificateChain))
+		require.NoError(t, err)
+		require.Len(t, chain, 1)
+
+		leaf := chain[0]
+		assert.Equal(t, key.Public(), leaf.PublicKey)
+		require.Len(t, leaf.URIs, 1)
+		assert.Equal(t, "spiffe://"+td1+"/galadriel/harvester/spire-server-1", leaf.URIs[0].String())
+		assert.Equal(t, leaf.NotAfter, resp.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(clientCertificateTTL), leaf.NotAfter, time.Minute)
+
+		// the certificate authenticates the harvester instance
+		authorities, err := setup.X509CA.GetX509Authorities(context.Background())
+		require.NoError(t, err)
+		roots := x509.NewCertPool()
+		roots.AddCert(authorities[0])
+		_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+		require.NoError(t, err)
+	})
+
+	t.Run("Fails if the trust domain is not the authenticated one", func(t *testing.T) {
+		csr, _ := newCSR(t)
+		setup := NewHarvesterTestSetup(t, http.MethodPost, clientCertPath, &harvester.IssueClientCertificateRequest{Csr: csr})
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.IssueClientCertificate(setup.EchoCtx, tdA.Name.String())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("Fails if the certificate signing request is invalid", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, clientCertPath, &harvester.IssueClientCertificateRequest{Csr: "invalid"})
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.IssueClientCertificate(setup.EchoCtx, td.Name.String())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "invalid certificate signing request")
+	})
+}
+
+func TestTCPGetServerTrustBundle(t *testing.T) {
+	t.Run("Returns the server CA certificates", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodGet, trustBundlePath, nil)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.GetServerTrustBundle(setup.EchoCtx, td.Name.String())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.ServerTrustBundle
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &resp)
+		require.NoError(t, err)
+
+		bundle, err := cryptoutil.ParseCertificates([]byte(resp.TrustBundle))
+		require.NoError(t, err)
+		authorities, err := setup.X509CA.GetX509Authorities(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, authorities, bundle)
+	})
+
+	t.Run("Fails if the trust domain is not the authenticated one", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodGet, trustBundlePath, nil)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.GetServerTrustBundle(setup.EchoCtx, tdA.Name.String())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestTCPBundleSync(t *testing.T) {
+	testCases := []struct {
+		name          string
+		trustDomain   string
+		relationships []*entity.Relationship
+		bundleState   harvester.PostBundleSyncRequest
+		expected      harvester.PostBundleSyncResponse
+	}{
+		{
+			name:          "Successfully sync no new bundles",
+			trustDomain:   tdA.Name.String(),
 			relationships: []*entity.Relationship{acceptedPendingRelAB, acceptedDeniedRelAC, acceptedAcceptedRelBC},
 			bundleState: harvester.PostBundleSyncRequest{
 				State: map[string]api.BundleDigest{
@@ -552,6 +1356,26 @@ func TestTCPBundleSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "Successfully sync the bundle of the trusted trust domain only in one-way relationships",
+			trustDomain:   tdA.Name.String(),
+			relationships: []*entity.Relationship{aTrustsBRelAB, aTrustsBRelCA},
+			bundleState: harvester.PostBundleSyncRequest{
+				State: map[string]api.BundleDigest{},
+			},
+			expected: harvester.PostBundleSyncResponse{
+				State: harvester.BundlesDigests{
+					tdB.Name.String(): encoding.EncodeToBase64(bundleB.Digest),
+				},
+				Updates: harvester.BundlesUpdates{
+					tdB.Name.String(): harvester.BundlesUpdatesItem{
+						TrustBundle: string(bundleB.Data),
+						Digest:      encoding.EncodeToBase64(bundleB.Digest),
+						Signature:   encoding.EncodeToBase64(bundleB.Signature),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -581,6 +1405,61 @@ func TestTCPBundleSync(t *testing.T) {
 	}
 }
 
+func TestTCPBundleSyncStoresState(t *testing.T) {
+	stale := &entity.BundleSyncState{
+		TrustDomainID:        tdA.ID.UUID,
+		HarvesterInstanceID:  "spire-1",
+		FederatedTrustDomain: spiffeid.RequireTrustDomainFromString("stale.org"),
+		Digest:               []byte("stale"),
+		ReportedAt:           time.Now().Add(-time.Hour),
+	}
+	// reported by another harvester instance of the trust domain, which is not affected by the sync of spire-1
+	otherInstance := &entity.BundleSyncState{
+		TrustDomainID:        tdA.ID.UUID,
+		HarvesterInstanceID:  "spire-2",
+		FederatedTrustDomain: tdB.Name,
+		Digest:               []byte("old"),
+		ReportedAt:           time.Now().Add(-time.Hour),
+	}
+
+	req := harvester.PostBundleSyncRequest{
+		State: map[string]api.BundleDigest{
+			tdB.Name.String():   encoding.EncodeToBase64(bundleB.Digest),
+			"other.org":         encoding.EncodeToBase64([]byte("other")),
+			"Invalid TD":        encoding.EncodeToBase64([]byte("invalid")),
+			tdC.Name.String():   "not base64!",
+			"another-other.org": encoding.EncodeToBase64([]byte("another")),
+		},
+	}
+
+	setup := NewHarvesterTestSetup(t, http.MethodPost, "/trust-domain/:trustDomainName/bundles/sync", &req)
+	setup.EchoCtx.Set(authTrustDomainKey, tdA)
+	setup.EchoCtx.Set(authInstanceIDKey, "spire-1")
+
+	setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+	setup.Datastore.WithRelationships(acceptedPendingRelAB)
+	setup.Datastore.WithBundles(bundleA, bundleB, bundleC)
+	setup.Datastore.WithBundleSyncStates(stale, otherInstance)
+
+	err := setup.Handler.BundleSync(setup.EchoCtx, tdA.Name.String())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	states, err := setup.Datastore.FindBundleSyncStatesByTrustDomainID(context.Background(), tdA.ID.UUID)
+	require.NoError(t, err)
+
+	stored := make(map[string][]byte, len(states))
+	for _, s := range states {
+		stored[s.HarvesterInstanceID+"/"+s.FederatedTrustDomain.String()] = s.Digest
+	}
+	assert.Equal(t, map[string][]byte{
+		"spire-1/" + tdB.Name.String(): bundleB.Digest,
+		"spire-1/other.org":            []byte("other"),
+		"spire-1/another-other.org":    []byte("another"),
+		"spire-2/" + tdB.Name.String(): []byte("old"),
+	}, stored)
+}
+
 func TestBundlePut(t *testing.T) {
 	t.Run("Successfully post new bundle for a trust domain", func(t *testing.T) {
 		setupFunc := func(setup *HarvesterTestSetup) *entity.TrustDomain {
@@ -601,6 +1480,401 @@ func TestBundlePut(t *testing.T) {
 		testBundlePut(t, setupFunc, http.StatusOK, "")
 	})
 
+	t.Run("Successfully post unchanged bundle without notifying an update", func(t *testing.T) {
+		bundle := newTestSPIFFEBundle(t, 1)
+		digest := encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle)))
+		bundlePut := harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      digest,
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		setup.Datastore.WithBundles(&entity.Bundle{
+			ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			TrustDomainID: td.ID.UUID,
+			Data:          []byte(bundle),
+			Digest:        cryptoutil.CalculateDigest([]byte(bundle)),
+		})
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+		assert.Empty(t, setup.Notifier.Events())
+	})
+
+	t.Run("Successfully post bundle with a higher sequence number uploaded by another instance", func(t *testing.T) {
+		bundle := newTestSPIFFEBundle(t, 3)
+		bundlePut := harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		setup.EchoCtx.Set(authInstanceIDKey, "spire-server-1")
+		setup.Datastore.WithHarvesters(
+			&entity.Harvester{TrustDomainID: td.ID.UUID, InstanceID: "spire-server-1"},
+			&entity.Harvester{TrustDomainID: td.ID.UUID, InstanceID: "spire-server-2", LastBundleUploadStatus: entity.BundleUploadAccepted},
+		)
+		storedData := []byte(newTestSPIFFEBundle(t, 2))
+		setup.Datastore.WithBundles(&entity.Bundle{
+			ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			TrustDomainID: td.ID.UUID,
+			Data:          storedData,
+			Digest:        cryptoutil.CalculateDigest(storedData),
+		})
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+		assert.Len(t, setup.Notifier.Events(), 1)
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(bundle), storedBundle.Data)
+
+		harvesters, err := setup.Datastore.FindHarvestersByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 2)
+		assert.Equal(t, entity.BundleUploadAccepted, harvesters[0].LastBundleUploadStatus)
+		assert.Equal(t, cryptoutil.CalculateDigest([]byte(bundle)), harvesters[0].LastBundleDigest)
+		assert.False(t, harvesters[0].LastBundleUploadAt.IsZero())
+	})
+
+	t.Run("Fail post bundle that is not a SPIFFE bundle", func(t *testing.T) {
+		bundle := "not a bundle"
+		bundlePut := &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, setup.Recorder.Code)
+
+		var report harvester.BundleValidationReport
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &report))
+		assert.Equal(t, td1, report.TrustDomain)
+		assert.False(t, report.Valid)
+		require.Len(t, report.Violations, 1)
+		assert.Equal(t, bundlepolicy.RuleFormat, report.Violations[0].Rule)
+		assert.Contains(t, report.Violations[0].Message, "bundle is not a valid SPIFFE bundle")
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, storedBundle)
+		assert.Empty(t, setup.Notifier.Events())
+	})
+
+	t.Run("Fail post stale bundle with a sequence number lower than the stored one", func(t *testing.T) {
+		bundle := newTestSPIFFEBundle(t, 1)
+		bundlePut := &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		setup.EchoCtx.Set(authInstanceIDKey, "spire-server-2")
+		setup.Datastore.WithHarvesters(&entity.Harvester{TrustDomainID: td.ID.UUID, InstanceID: "spire-server-2"})
+		storedData := []byte(newTestSPIFFEBundle(t, 2))
+		setup.Datastore.WithBundles(&entity.Bundle{
+			ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			TrustDomainID: td.ID.UUID,
+			Data:          storedData,
+			Digest:        cryptoutil.CalculateDigest(storedData),
+		})
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.Error(t, err)
+
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusConflict, echoHTTPErr.Code)
+		assert.Equal(t, "stale bundle: sequence number 1 is lower than the sequence number 2 of the current bundle", echoHTTPErr.Message)
+
+		harvesters, err := setup.Datastore.FindHarvestersByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 1)
+		assert.Equal(t, entity.BundleUploadStale, harvesters[0].LastBundleUploadStatus)
+		assert.Equal(t, cryptoutil.CalculateDigest([]byte(bundle)), harvesters[0].LastBundleDigest)
+		assert.Empty(t, setup.Notifier.Events())
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, storedData, storedBundle.Data)
+	})
+
+	t.Run("Fail post stale bundle without sequence number when the stored one has one", func(t *testing.T) {
+		bundle := newTestSPIFFEBundleWithoutSequenceNumber(t)
+		bundlePut := &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		setup.EchoCtx.Set(authInstanceIDKey, "spire-server-2")
+		setup.Datastore.WithHarvesters(&entity.Harvester{TrustDomainID: td.ID.UUID, InstanceID: "spire-server-2"})
+		storedData := []byte(newTestSPIFFEBundle(t, 2))
+		setup.Datastore.WithBundles(&entity.Bundle{
+			ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			TrustDomainID: td.ID.UUID,
+			Data:          storedData,
+			Digest:        cryptoutil.CalculateDigest(storedData),
+		})
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.Error(t, err)
+
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusConflict, echoHTTPErr.Code)
+		assert.Equal(t, "stale bundle: bundle has no sequence number, the current bundle has sequence number 2", echoHTTPErr.Message)
+
+		harvesters, err := setup.Datastore.FindHarvestersByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, harvesters, 1)
+		assert.Equal(t, entity.BundleUploadStale, harvesters[0].LastBundleUploadStatus)
+		assert.Equal(t, cryptoutil.CalculateDigest([]byte(bundle)), harvesters[0].LastBundleDigest)
+		assert.Empty(t, setup.Notifier.Events())
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, storedData, storedBundle.Data)
+	})
+
+	t.Run("Fail post stale bundle when a bundle with a higher sequence number is stored while it is checked", func(t *testing.T) {
+		bundle := newTestSPIFFEBundle(t, 2)
+		bundlePut := &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+		newerBundle := newTestSPIFFEBundle(t, 3)
+		newerBundlePut := &harvester.PutBundleRequest{
+			TrustBundle: newerBundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(newerBundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		setup.EchoCtx.Set(authInstanceIDKey, "spire-server-1")
+		storedData := []byte(newTestSPIFFEBundle(t, 1))
+		setup.Datastore.WithBundles(&entity.Bundle{
+			ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			TrustDomainID: td.ID.UUID,
+			Data:          storedData,
+			Digest:        cryptoutil.CalculateDigest(storedData),
+		})
+
+		// another instance uploads a bundle with a higher sequence number after the first upload was checked
+		// against the stored bundle and before it is stored
+		newerSetup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", newerBundlePut)
+		newerSetup.EchoCtx.Set(authTrustDomainKey, td)
+		newerSetup.EchoCtx.Set(authInstanceIDKey, "spire-server-2")
+		newerSetup.Handler.Datastore = setup.Datastore
+		setup.Handler.Datastore = &racingDatastore{
+			FakeDatabase: setup.Datastore,
+			beforeStore: func() {
+				require.NoError(t, newerSetup.Handler.BundlePut(newerSetup.EchoCtx, td1))
+				assert.Equal(t, http.StatusOK, newerSetup.Recorder.Code)
+			},
+		}
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+		assert.Equal(t, "stale bundle: sequence number 2 is lower than the sequence number 3 of the current bundle", err.(*echo.HTTPError).Message)
+		assert.Empty(t, setup.Notifier.Events())
+
+		storedBundle, err := setup.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(newerBundle), storedBundle.Data)
+	})
+
+	t.Run("Concurrent bundle uploads never overwrite the bundle with the higher sequence number", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			setups := make([]*HarvesterTestSetup, 0, 2)
+			bundles := make([]string, 0, 2)
+			for _, seq := range []uint64{2, 3} {
+				bundle := newTestSPIFFEBundle(t, seq)
+				bundles = append(bundles, bundle)
+				setups = append(setups, NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &harvester.PutBundleRequest{
+					TrustBundle: bundle,
+					Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+					TrustDomain: td1,
+				}))
+			}
+
+			ds := setups[0].Datastore
+			td := SetupTrustDomain(t, ds)
+			storedData := []byte(newTestSPIFFEBundle(t, 1))
+			ds.WithBundles(&entity.Bundle{
+				ID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				TrustDomainID: td.ID.UUID,
+				Data:          storedData,
+				Digest:        cryptoutil.CalculateDigest(storedData),
+			})
+
+			errs := make([]error, len(setups))
+			var wg sync.WaitGroup
+			for j, setup := range setups {
+				setup.Handler.Datastore = ds
+				setup.EchoCtx.Set(authTrustDomainKey, td)
+				setup.EchoCtx.Set(authInstanceIDKey, fmt.Sprintf("spire-server-%d", j))
+
+				wg.Add(1)
+				go func(j int, setup *HarvesterTestSetup) {
+					defer wg.Done()
+					errs[j] = setup.Handler.BundlePut(setup.EchoCtx, td1)
+				}(j, setup)
+			}
+			wg.Wait()
+
+			// the bundle with the lower sequence number is either stored first or rejected as stale
+			if errs[0] != nil {
+				assert.Equal(t, http.StatusConflict, errs[0].(*echo.HTTPError).Code)
+			}
+			require.NoError(t, errs[1])
+
+			storedBundle, err := ds.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+			require.NoError(t, err)
+			assert.Equal(t, []byte(bundles[1]), storedBundle.Data)
+		}
+	})
+
+	t.Run("Fail post bundle when the bundle of the trust domain is admin-managed", func(t *testing.T) {
+		bundle := newTestSPIFFEBundle(t, 2)
+		bundlePut := &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		storedData := []byte(newTestSPIFFEBundle(t, 1))
+		_, err := setup.Handler.Datastore.CreateOrUpdateBundle(context.Background(), &entity.Bundle{TrustDomainID: td.ID.UUID, Data: storedData, AdminManaged: true})
+		require.NoError(t, err)
+
+		err = setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+		assert.Equal(t, fmt.Sprintf("the bundle of trust domain %q is admin-managed", td1), err.(*echo.HTTPError).Message)
+		assert.Empty(t, setup.Notifier.Events())
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, storedData, storedBundle.Data)
+	})
+
+	t.Run("Fail post bundle when the trust domain is external", func(t *testing.T) {
+		bundle := newTestSPIFFEBundle(t, 2)
+		bundlePut := &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		// the bundle fetched from the bundle endpoint is not admin-managed
+		storedData := []byte(newTestSPIFFEBundle(t, 1))
+		_, err := setup.Handler.Datastore.CreateOrUpdateBundle(context.Background(), &entity.Bundle{TrustDomainID: td.ID.UUID, Data: storedData})
+		require.NoError(t, err)
+		SetupExternalTrustDomain(t, setup.Handler.Datastore, td.ID.UUID)
+
+		err = setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+		assert.Equal(t, fmt.Sprintf("trust domain %q is external, its bundle is fetched from its bundle endpoint", td1), err.(*echo.HTTPError).Message)
+		assert.Empty(t, setup.Notifier.Events())
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, storedData, storedBundle.Data)
+	})
+
+	t.Run("Successfully post bundle verified by the server", func(t *testing.T) {
+		signer, verifier := newBundleSignerAndVerifier(t)
+		bundlePut := newSignedBundleRequest(t, signer, newTestSPIFFEBundle(t, 1))
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		setup.Handler.BundleVerifiers = bundleverifier.NewSet([]*bundleverifier.Provider{{Name: "disk", Verifier: verifier}})
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.BundleVerificationVerified, storedBundle.VerificationStatus)
+		assert.Equal(t, "disk", storedBundle.VerifiedBy)
+	})
+
+	t.Run("Fail post bundle with invalid signature", func(t *testing.T) {
+		signer, verifier := newBundleSignerAndVerifier(t)
+		bundlePut := newSignedBundleRequest(t, signer, newTestSPIFFEBundle(t, 1))
+		invalidSignature := encoding.EncodeToBase64([]byte("invalid-signature"))
+		bundlePut.Signature = &invalidSignature
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		setup.Handler.BundleVerifiers = bundleverifier.NewSet([]*bundleverifier.Provider{{Name: "disk", Verifier: verifier}})
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "failed to verify bundle signature: no verifier could verify the bundle signature")
+
+		storedBundle, err := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, storedBundle)
+		assert.Empty(t, setup.Notifier.Events())
+	})
+
+	t.Run("Fail post unsigned bundle when verifiers are configured", func(t *testing.T) {
+		_, verifier := newBundleSignerAndVerifier(t)
+		bundle := newTestSPIFFEBundle(t, 1)
+		bundlePut := &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td1,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", bundlePut)
+		setup.Handler.BundleVerifiers = bundleverifier.NewSet([]*bundleverifier.Provider{{Name: "disk", Verifier: verifier}})
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "signing certificate is missing")
+	})
+
 	t.Run("Fail post bundle no authenticated trust domain", func(t *testing.T) {
 		sig := "test-signature"
 		cert := "test-certificate"
@@ -634,7 +1908,7 @@ func TestBundlePut(t *testing.T) {
 }
 
 func testBundlePut(t *testing.T, setupFunc func(*HarvesterTestSetup) *entity.TrustDomain, expectedStatusCode int, expectedResponseBody string) {
-	bundle := "a new bundle"
+	bundle := newTestSPIFFEBundle(t, 1)
 	digest := encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle)))
 	sig := encoding.EncodeToBase64([]byte("test-signature"))
 	cert := encoding.EncodeToBase64([]byte("test-signing-certificate"))
@@ -665,6 +1939,61 @@ func testBundlePut(t *testing.T, setupFunc func(*HarvesterTestSetup) *entity.Tru
 	assert.Equal(t, sig, encoding.EncodeToBase64(storedBundle.Signature))
 	assert.Equal(t, cert, encoding.EncodeToBase64(storedBundle.SigningCertificate))
 	assert.Equal(t, td.ID.UUID, storedBundle.TrustDomainID)
+	assert.Equal(t, entity.BundleVerificationSkipped, storedBundle.VerificationStatus)
+	assert.Empty(t, storedBundle.VerifiedBy)
+
+	assertNotified(t, setup.Notifier, notification.EventBundleUpdated, td1)
+}
+
+// racingDatastore runs beforeStore once before the first bundle is stored, to store another bundle in between the
+// lookup and the write of the bundle.
+type racingDatastore struct {
+	*fakedatastore.FakeDatabase
+	beforeStore func()
+	once        sync.Once
+}
+
+func (d *racingDatastore) CreateOrUpdateBundleIfUnchanged(ctx context.Context, req *entity.Bundle, previousDigest []byte) (*entity.Bundle, error) {
+	d.once.Do(d.beforeStore)
+	return d.FakeDatabase.CreateOrUpdateBundleIfUnchanged(ctx, req, previousDigest)
+}
+
+// newTestSPIFFEBundle returns a valid SPIFFE bundle of td1 with the given sequence number.
+func newTestSPIFFEBundle(t *testing.T, seq uint64) string {
+	cert, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+
+	bundle := spiffebundle.FromX509Authorities(spiffeid.RequireTrustDomainFromString(td1), []*x509.Certificate{cert})
+	bundle.SetSequenceNumber(seq)
+
+	data, err := bundle.Marshal()
+	require.NoError(t, err)
+	return string(data)
+}
+
+func newBundleSignerAndVerifier(t *testing.T) (integrity.Signer, integrity.Verifier) {
+	tempDir := certtest.CreateTestCACertificates(t, clock.New())
+
+	signer, err := integrity.NewDiskSigner(integrity.NewDiskSignerConfig(tempDir+"/root-ca.key", tempDir+"/root-ca.crt"))
+	require.NoError(t, err)
+	verifier, err := integrity.NewDiskVerifier(integrity.NewDiskVerifierConfig(tempDir + "/root-ca.crt"))
+	require.NoError(t, err)
+
+	return signer, verifier
+}
+
+func newSignedBundleRequest(t *testing.T, signer integrity.Signer, bundle string) *harvester.PutBundleRequest {
+	signature, chain, err := signer.Sign([]byte(bundle))
+	require.NoError(t, err)
+
+	sig := encoding.EncodeToBase64(signature)
+	cert := encoding.EncodeToBase64(chain[0].Raw)
+	return &harvester.PutBundleRequest{
+		Signature:          &sig,
+		SigningCertificate: &cert,
+		TrustBundle:        bundle,
+		Digest:             encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+		TrustDomain:        td1,
+	}
 }
 
 func testInvalidBundleRequest(t *testing.T, fieldName string, fieldValue interface{}, expectedStatusCode int, expectedErrorMessage string) {
@@ -692,3 +2021,14 @@ func testInvalidBundleRequest(t *testing.T, fieldName string, fieldValue interfa
 	assert.Equal(t, expectedStatusCode, err.(*echo.HTTPError).Code)
 	assert.Contains(t, err.(*echo.HTTPError).Message, expectedErrorMessage)
 }
+
+// newTestSPIFFEBundleWithoutSequenceNumber returns a valid SPIFFE bundle of td1 without sequence number.
+func newTestSPIFFEBundleWithoutSequenceNumber(t *testing.T) string {
+	cert, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+
+	bundle := spiffebundle.FromX509Authorities(spiffeid.RequireTrustDomainFromString(td1), []*x509.Certificate{cert})
+
+	data, err := bundle.Marshal()
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,261 @@
+package endpoints
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+)
+
+const (
+	defaultServerCertificateTTL = 1 * time.Hour
+	defaultTLSReloadInterval    = 1 * time.Minute
+)
+
+// TLSConfig configures the TLS certificate that the Galadriel Server presents to the Harvesters.
+type TLSConfig struct {
+	// DNSNames and IPAddresses are the SANs of the certificates issued by the X509CA.
+	// The certificates are issued for the DNS name galadriel-server when none is set.
+	DNSNames    []string
+	IPAddresses []net.IP
+
+	// TTL of the certificates issued by the X509CA, which are renewed once half of it has elapsed.
+	// Defaults to 1 hour.
+	TTL time.Duration
+
+	// CertFilePath and KeyFilePath are the PEM files of a certificate, along with its intermediates, and key that
+	// are served instead of the certificates issued by the X509CA. The files are reloaded when they change, so they
+	// can be renewed by an external process.
+	CertFilePath string
+	KeyFilePath  string
+
+	// ReloadInterval is how often the certificate files are checked for changes. Defaults to 1 minute.
+	ReloadInterval time.Duration
+}
+
+// useCertificateFiles tells if the certificate is loaded from disk instead of being issued by the X509CA.
+func (c *TLSConfig) useCertificateFiles() bool {
+	return c.CertFilePath != ""
+}
+
+func (c *TLSConfig) withDefaults() *TLSConfig {
+	cfg := TLSConfig{}
+	if c != nil {
+		cfg = *c
+	}
+
+	if len(cfg.DNSNames) == 0 && len(cfg.IPAddresses) == 0 {
+		cfg.DNSNames = []string{constants.GaladrielServerName}
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = defaultServerCertificateTTL
+	}
+	if cfg.ReloadInterval == 0 {
+		cfg.ReloadInterval = defaultTLSReloadInterval
+	}
+
+	return &cfg
+}
+
+type certificateSource struct {
+	mu   sync.RWMutex
+	cert *tls.Certificate
+
+	// PEM contents of the loaded certificate files, used to detect changes
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func (t *certificateSource) setTLSCertificate(cert *tls.Certificate) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cert = cert
+}
+
+func (t *certificateSource) getTLSCertificate() *tls.Certificate {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.cert
+}
+
+// loadTLSCertificate returns the initial TLS certificate of the server, either loaded from disk or issued by the X509CA.
+func (e *Endpoints) loadTLSCertificate(ctx context.Context) (*certificateSource, error) {
+	if !e.tls.useCertificateFiles() {
+		cert, err := e.getTLSCertificate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &certificateSource{cert: cert}, nil
+	}
+
+	certPEM, keyPEM, err := e.readTLSCertificateFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := parseTLSCertificate(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificateSource{cert: cert, certPEM: certPEM, keyPEM: keyPEM}, nil
+}
+
+func (e *Endpoints) startTLSCertificateRotation(ctx context.Context, errChan chan error) {
+	if e.tls.useCertificateFiles() {
+		e.startTLSCertificateReload(ctx)
+		return
+	}
+
+	e.logger.Info("Started TLS certificate rotator")
+
+	// Start a ticker that rotates the certificate once half of its lifetime has elapsed
+	certRotationInterval := e.tls.TTL / 2
+	ticker := time.NewTicker(certRotationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.logger.Debug("Rotating Server TLS certificate")
+			cert, err := e.getTLSCertificate(ctx)
+			if err != nil {
+				errChan <- fmt.Errorf("failed to rotate Server TLS certificate: %w", err)
+				continue
+			}
+			e.certsStore.setTLSCertificate(cert)
+		case <-ctx.Done():
+			e.logger.Info("Stopped Server TLS certificate rotator")
+			return
+		}
+	}
+}
+
+func (e *Endpoints) getTLSCertificate(ctx context.Context) (*tls.Certificate, error) {
+	privateKey, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create private key: %w", err)
+	}
+
+	commonName := constants.GaladrielServerName
+	if len(e.tls.DNSNames) > 0 {
+		commonName = e.tls.DNSNames[0]
+	}
+
+	params := &x509ca.X509CertificateParams{
+		Subject: pkix.Name{
+			CommonName: commonName,
+		},
+		TTL:         e.tls.TTL,
+		PublicKey:   privateKey.Public(),
+		DNSNames:    e.tls.DNSNames,
+		IPAddresses: e.tls.IPAddresses,
+	}
+	if err := params.SetCertificateRequest(privateKey); err != nil {
+		return nil, err
+	}
+	cert, err := e.x509CA.IssueX509Certificate(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue TLS certificate: %w", err)
+	}
+
+	chain := make([][]byte, 0, len(cert))
+	for _, c := range cert {
+		chain = append(chain, c.Raw)
+	}
+
+	return &tls.Certificate{
+		Certificate: chain,
+		PrivateKey:  privateKey,
+		Leaf:        cert[0],
+	}, nil
+}
+
+// startTLSCertificateReload reloads the TLS certificate from disk when the certificate files change.
+func (e *Endpoints) startTLSCertificateReload(ctx context.Context) {
+	e.logger.Info("Started TLS certificate reloader")
+
+	ticker := time.NewTicker(e.tls.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.reloadTLSCertificate(); err != nil {
+				e.logger.WithError(err).Error("Failed to reload Server TLS certificate, keeping the current one")
+			}
+		case <-ctx.Done():
+			e.logger.Info("Stopped Server TLS certificate reloader")
+			return
+		}
+	}
+}
+
+// reloadTLSCertificate loads the TLS certificate from disk if the certificate files changed since the last load.
+// The current certificate is kept when the files cannot be loaded, for instance while they are being replaced.
+func (e *Endpoints) reloadTLSCertificate() error {
+	certPEM, keyPEM, err := e.readTLSCertificateFiles()
+	if err != nil {
+		return err
+	}
+
+	store := e.certsStore
+	store.mu.RLock()
+	unchanged := bytes.Equal(certPEM, store.certPEM) && bytes.Equal(keyPEM, store.keyPEM)
+	store.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	cert, err := parseTLSCertificate(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	store.cert = cert
+	store.certPEM = certPEM
+	store.keyPEM = keyPEM
+	store.mu.Unlock()
+
+	e.logger.WithField(telemetry.ExpiresAt, cert.Leaf.NotAfter).Info("Reloaded Server TLS certificate")
+
+	return nil
+}
+
+func (e *Endpoints) readTLSCertificateFiles() ([]byte, []byte, error) {
+	certPEM, err := os.ReadFile(e.tls.CertFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read TLS certificate file: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(e.tls.KeyFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read TLS key file: %w", err)
+	}
+
+	return certPEM, keyPEM, nil
+}
+
+func parseTLSCertificate(certPEM, keyPEM []byte) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TLS certificate: %w", err)
+	}
+
+	return &cert, nil
+}
@@ -0,0 +1,149 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTLSCertificate(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ca := newTestX509CA(t)
+
+	t.Run("Certificates are issued for galadriel-server by default", func(t *testing.T) {
+		e := &Endpoints{x509CA: ca, tls: (*TLSConfig)(nil).withDefaults(), logger: logger}
+
+		cert, err := e.getTLSCertificate(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, constants.GaladrielServerName, cert.Leaf.Subject.CommonName)
+		assert.Equal(t, []string{constants.GaladrielServerName}, cert.Leaf.DNSNames)
+		assert.Empty(t, cert.Leaf.IPAddresses)
+		assert.WithinDuration(t, time.Now().Add(defaultServerCertificateTTL), cert.Leaf.NotAfter, time.Minute)
+	})
+
+	t.Run("Certificates are issued with the configured SANs and TTL", func(t *testing.T) {
+		tlsConfig := &TLSConfig{
+			DNSNames:    []string{"galadriel.example.org", "galadriel-lb.example.org"},
+			IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			TTL:         6 * time.Hour,
+		}
+		e := &Endpoints{x509CA: ca, tls: tlsConfig.withDefaults(), logger: logger}
+
+		cert, err := e.getTLSCertificate(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "galadriel.example.org", cert.Leaf.Subject.CommonName)
+		assert.Equal(t, tlsConfig.DNSNames, cert.Leaf.DNSNames)
+		require.Len(t, cert.Leaf.IPAddresses, 1)
+		assert.True(t, net.ParseIP("10.0.0.1").Equal(cert.Leaf.IPAddresses[0]))
+		assert.WithinDuration(t, time.Now().Add(6*time.Hour), cert.Leaf.NotAfter, time.Minute)
+
+		// the certificate is valid for the configured names
+		roots, err := ca.GetX509Authorities(context.Background())
+		require.NoError(t, err)
+		pool := x509.NewCertPool()
+		pool.AddCert(roots[0])
+		for _, name := range []string{"galadriel-lb.example.org", "10.0.0.1"} {
+			_, err = cert.Leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: pool})
+			assert.NoError(t, err)
+		}
+	})
+}
+
+func TestGetTLSCertificateWithIntermediateCA(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	certsFolder := certtest.CreateTestCACertificates(t, clock.New())
+	defer os.RemoveAll(certsFolder)
+
+	ca, err := disk.New()
+	require.NoError(t, err)
+	err = ca.Configure(&disk.Config{
+		CertFilePath:   certsFolder + "/intermediate-ca-2.crt",
+		KeyFilePath:    certsFolder + "/intermediate-ca-2.key",
+		ChainFilePath:  certsFolder + "/intermediate-ca.crt",
+		BundleFilePath: certsFolder + "/root-ca.crt",
+	})
+	require.NoError(t, err)
+
+	e := &Endpoints{x509CA: ca, tls: (*TLSConfig)(nil).withDefaults(), logger: logger}
+	cert, err := e.getTLSCertificate(context.Background())
+	require.NoError(t, err)
+
+	// the full chain is served, so that clients trusting only the ROOT CA can verify the certificate
+	require.Len(t, cert.Certificate, 3)
+	intermediates := x509.NewCertPool()
+	for _, raw := range cert.Certificate[1:] {
+		intermediate, err := x509.ParseCertificate(raw)
+		require.NoError(t, err)
+		intermediates.AddCert(intermediate)
+	}
+
+	roots, err := ca.GetX509Authorities(context.Background())
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(roots[0])
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{DNSName: constants.GaladrielServerName, Roots: pool, Intermediates: intermediates})
+	assert.NoError(t, err)
+}
+
+func TestReloadTLSCertificate(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	dir := t.TempDir()
+	certFilePath := filepath.Join(dir, "server.crt")
+	keyFilePath := filepath.Join(dir, "server.key")
+
+	ca, caKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	writeCertificate := func(t *testing.T, cn string) {
+		key, err := cryptoutil.GenerateSigner(cryptoutil.RSA2048)
+		require.NoError(t, err)
+		template, err := cryptoutil.CreateX509Template(clock.New(), key.Public(), pkix.Name{CommonName: cn}, nil, []string{cn}, time.Hour)
+		require.NoError(t, err)
+		cert, err := cryptoutil.SignX509(template, ca, caKey)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(certFilePath, cryptoutil.EncodeCertificate(cert), 0600))
+		require.NoError(t, os.WriteFile(keyFilePath, cryptoutil.EncodeRSAPrivateKey(key.(*rsa.PrivateKey)), 0600))
+	}
+
+	writeCertificate(t, "galadriel-1.example.org")
+
+	tlsConfig := &TLSConfig{CertFilePath: certFilePath, KeyFilePath: keyFilePath}
+	e := &Endpoints{tls: tlsConfig.withDefaults(), logger: logger}
+
+	certsStore, err := e.loadTLSCertificate(context.Background())
+	require.NoError(t, err)
+	e.certsStore = certsStore
+	assert.Equal(t, "galadriel-1.example.org", e.certsStore.getTLSCertificate().Leaf.Subject.CommonName)
+
+	// unchanged files are not reloaded
+	loaded := e.certsStore.getTLSCertificate()
+	require.NoError(t, e.reloadTLSCertificate())
+	assert.Same(t, loaded, e.certsStore.getTLSCertificate())
+
+	// renewed files are reloaded
+	writeCertificate(t, "galadriel-2.example.org")
+	require.NoError(t, e.reloadTLSCertificate())
+	assert.Equal(t, "galadriel-2.example.org", e.certsStore.getTLSCertificate().Leaf.Subject.CommonName)
+
+	// the current certificate is kept when the files are invalid
+	require.NoError(t, os.WriteFile(keyFilePath, []byte("not a key"), 0600))
+	err = e.reloadTLSCertificate()
+	assert.ErrorContains(t, err, "failed to load TLS certificate")
+	assert.Equal(t, "galadriel-2.example.org", e.certsStore.getTLSCertificate().Leaf.Subject.CommonName)
+}
@@ -0,0 +1,236 @@
+// Package federationgroup keeps the relationships between the members of the federation groups in line with
+// their membership: every pair of members of a group is federated through a relationship managed by the group.
+package federationgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/ha"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultReconcileInterval is how often the relationships of the federation groups are reconciled
+	// with their membership.
+	DefaultReconcileInterval = 30 * time.Second
+
+	// reconcilerJob is the job that reconciles the federation groups, run by a single server replica at a time.
+	reconcilerJob = "federation-group-reconciler"
+)
+
+// Config conveys the configuration of the federation group Reconciler.
+type Config struct {
+	Datastore   db.Datastore
+	Notifier    notification.Notifier
+	Coordinator ha.Coordinator
+	Logger      logrus.FieldLogger
+
+	// ReconcileInterval is how often the federation groups are reconciled. DefaultReconcileInterval when zero.
+	ReconcileInterval time.Duration
+}
+
+// Reconciler creates the relationships between the members of the federation groups, and deletes the relationships
+// managed by a group once their trust domains no longer share a group. Relationships created by an admin are
+// never touched.
+type Reconciler struct {
+	c        *Config
+	interval time.Duration
+}
+
+// pair is an unordered pair of trust domains.
+type pair [2]uuid.UUID
+
+func newPair(a, b uuid.UUID) pair {
+	if a.String() > b.String() {
+		a, b = b, a
+	}
+	return pair{a, b}
+}
+
+// desiredPair is a pair of members of one or more federation groups.
+type desiredPair struct {
+	// owner is the oldest group the pair belongs to, which creates the relationship of the pair.
+	owner *entity.FederationGroup
+	// groups are all the groups the pair belongs to.
+	groups map[uuid.UUID]bool
+}
+
+// New creates a new federation group Reconciler.
+func New(c *Config) *Reconciler {
+	interval := c.ReconcileInterval
+	if interval == 0 {
+		interval = DefaultReconcileInterval
+	}
+
+	return &Reconciler{
+		c:        c,
+		interval: interval,
+	}
+}
+
+// Run reconciles the federation groups until the context is canceled.
+// In high availability, only the replica elected leader of the reconciler job reconciles them.
+func (r *Reconciler) Run(ctx context.Context) error {
+	r.c.Logger.Info("Starting federation group reconciler")
+
+	err := r.c.Coordinator.RunAsLeader(ctx, reconcilerJob, r.run)
+	if ctx.Err() != nil {
+		r.c.Logger.Info("Federation group reconciler stopped")
+		return nil
+	}
+	return err
+}
+
+func (r *Reconciler) run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Reconcile(ctx); err != nil {
+			r.c.Logger.WithError(err).Error("Failed to reconcile federation groups")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Reconcile creates the missing relationships between the members of the federation groups, with the default consent
+// of the group, and deletes the relationships managed by a group that are no longer wanted by any group. A pair that
+// fails to be reconciled doesn't stop the reconciliation of the others, the errors are returned together.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	desired, err := r.desiredPairs(ctx)
+	if err != nil {
+		return err
+	}
+
+	relationships, err := r.c.Datastore.ListRelationships(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed listing relationships: %w", err)
+	}
+
+	var errs []error
+	existing := make(map[pair]bool)
+	for _, rel := range relationships {
+		p := newPair(rel.TrustDomainAID, rel.TrustDomainBID)
+		existing[p] = true
+
+		if !rel.FederationGroupID.Valid {
+			continue
+		}
+
+		d, ok := desired[p]
+		switch {
+		case !ok:
+			if err := r.deleteRelationship(ctx, rel); err != nil {
+				errs = append(errs, err)
+			}
+		case !d.groups[rel.FederationGroupID.UUID]:
+			// the group that created the relationship no longer wants it, but another one does
+			rel.FederationGroupID = d.owner.ID
+			if _, err := r.c.Datastore.CreateOrUpdateRelationship(ctx, rel); err != nil {
+				errs = append(errs, fmt.Errorf("failed updating relationship %s: %w", rel.ID.UUID, err))
+			}
+		}
+	}
+
+	for p, d := range desired {
+		if existing[p] {
+			continue
+		}
+
+		if err := r.createRelationship(ctx, p, d.owner); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// desiredPairs returns the pairs of members of every federation group.
+func (r *Reconciler) desiredPairs(ctx context.Context) (map[pair]*desiredPair, error) {
+	groups, err := r.c.Datastore.ListFederationGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing federation groups: %w", err)
+	}
+
+	desired := make(map[pair]*desiredPair)
+	for _, group := range groups {
+		members, err := r.c.Datastore.FindFederationGroupMembersByGroupID(ctx, group.ID.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed listing members of federation group %q: %w", group.Name, err)
+		}
+
+		for i := 0; i < len(members); i++ {
+			for j := i + 1; j < len(members); j++ {
+				p := newPair(members[i].TrustDomainID, members[j].TrustDomainID)
+				d, ok := desired[p]
+				if !ok {
+					// groups are listed oldest first
+					d = &desiredPair{owner: group, groups: make(map[uuid.UUID]bool)}
+					desired[p] = d
+				}
+				d.groups[group.ID.UUID] = true
+			}
+		}
+	}
+
+	return desired, nil
+}
+
+func (r *Reconciler) createRelationship(ctx context.Context, p pair, group *entity.FederationGroup) error {
+	consent := group.DefaultConsent
+	if consent == "" {
+		consent = entity.ConsentStatusPending
+	}
+
+	rel, err := r.c.Datastore.CreateOrUpdateRelationship(ctx, &entity.Relationship{
+		TrustDomainAID:      p[0],
+		TrustDomainBID:      p[1],
+		TrustDomainAConsent: consent,
+		TrustDomainBConsent: consent,
+		Direction:           entity.RelationshipBidirectional,
+		FederationGroupID:   group.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed creating relationship: %w", err)
+	}
+
+	if _, err := db.PopulateTrustDomainNames(ctx, r.c.Datastore, rel); err != nil {
+		return fmt.Errorf("failed populating relationship: %w", err)
+	}
+
+	r.c.Logger.WithField(telemetry.FederationGroup, group.Name).
+		Infof("Created relationship between trust domains %s and %s", rel.TrustDomainAName, rel.TrustDomainBName)
+	r.c.Notifier.Notify(notification.NewRelationshipEvent(notification.EventRelationshipCreated, rel))
+
+	return nil
+}
+
+func (r *Reconciler) deleteRelationship(ctx context.Context, rel *entity.Relationship) error {
+	// the names are populated before the deletion, for the notification
+	if _, err := db.PopulateTrustDomainNames(ctx, r.c.Datastore, rel); err != nil {
+		return fmt.Errorf("failed populating relationship %s: %w", rel.ID.UUID, err)
+	}
+
+	if err := r.c.Datastore.DeleteRelationship(ctx, rel.ID.UUID); err != nil {
+		return fmt.Errorf("failed deleting relationship %s: %w", rel.ID.UUID, err)
+	}
+
+	r.c.Logger.Infof("Deleted relationship between trust domains %s and %s, no longer wanted by a federation group",
+		rel.TrustDomainAName, rel.TrustDomainBName)
+	r.c.Notifier.Notify(notification.NewRelationshipEvent(notification.EventRelationshipDeleted, rel))
+
+	return nil
+}
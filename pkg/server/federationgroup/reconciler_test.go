@@ -0,0 +1,288 @@
+package federationgroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/server/ha"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakenotifier"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	td1 = newTrustDomain("td1.org")
+	td2 = newTrustDomain("td2.org")
+	td3 = newTrustDomain("td3.org")
+)
+
+type testSetup struct {
+	reconciler *Reconciler
+	datastore  *fakedatastore.FakeDatabase
+	notifier   *fakenotifier.Notifier
+}
+
+func TestReconcileCreatesFullMesh(t *testing.T) {
+	setup := newTestSetup(t)
+	group := newGroup("mesh", entity.ConsentStatusApproved, time.Now())
+	setup.datastore.WithFederationGroups(group)
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1), newMember(group, td2), newMember(group, td3))
+
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+
+	relationships := setup.relationships(t)
+	require.Len(t, relationships, 3)
+	for _, rel := range relationships {
+		assert.Equal(t, group.ID, rel.FederationGroupID)
+		assert.Equal(t, entity.ConsentStatusApproved, rel.TrustDomainAConsent)
+		assert.Equal(t, entity.ConsentStatusApproved, rel.TrustDomainBConsent)
+		assert.Equal(t, entity.RelationshipBidirectional, rel.Direction)
+	}
+	assert.True(t, setup.related(t, td1, td2))
+	assert.True(t, setup.related(t, td1, td3))
+	assert.True(t, setup.related(t, td2, td3))
+
+	events := setup.notifier.Events()
+	require.Len(t, events, 3)
+	for _, event := range events {
+		assert.Equal(t, notification.EventRelationshipCreated, event.Type)
+		assert.Len(t, event.TrustDomains, 2)
+	}
+
+	// reconciling again changes nothing
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	assert.Len(t, setup.relationships(t), 3)
+	assert.Len(t, setup.notifier.Events(), 3)
+}
+
+func TestReconcileRemovedMember(t *testing.T) {
+	setup := newTestSetup(t)
+	group := newGroup("mesh", entity.ConsentStatusPending, time.Now())
+	setup.datastore.WithFederationGroups(group)
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1), newMember(group, td2), newMember(group, td3))
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	require.Len(t, setup.relationships(t), 3)
+
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1), newMember(group, td2))
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+
+	relationships := setup.relationships(t)
+	require.Len(t, relationships, 1)
+	assert.True(t, setup.related(t, td1, td2))
+	assert.Equal(t, entity.ConsentStatusPending, relationships[0].TrustDomainAConsent)
+
+	// the deletion of the relationships of the removed member is notified
+	events := setup.notifier.Events()
+	require.Len(t, events, 5)
+	for _, event := range events[3:] {
+		assert.Equal(t, notification.EventRelationshipDeleted, event.Type)
+		assert.Contains(t, event.TrustDomains, td3.Name.String())
+	}
+}
+
+func TestReconcileDeletedGroup(t *testing.T) {
+	setup := newTestSetup(t)
+	group := newGroup("mesh", entity.ConsentStatusPending, time.Now())
+	setup.datastore.WithFederationGroups(group)
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1), newMember(group, td2))
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	require.Len(t, setup.relationships(t), 1)
+
+	setup.datastore.WithFederationGroups()
+	setup.datastore.WithFederationGroupMembers()
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+
+	assert.Empty(t, setup.relationships(t))
+}
+
+func TestReconcileKeepsAdminRelationships(t *testing.T) {
+	setup := newTestSetup(t)
+	manual := &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      td2.ID.UUID,
+		TrustDomainBID:      td1.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusDenied,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+		Direction:           entity.RelationshipATrustsB,
+	}
+	setup.datastore.WithRelationships(manual)
+
+	group := newGroup("mesh", entity.ConsentStatusApproved, time.Now())
+	setup.datastore.WithFederationGroups(group)
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1), newMember(group, td2))
+
+	// the pair already has a relationship, created by an admin, which is not adopted by the group
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	relationships := setup.relationships(t)
+	require.Len(t, relationships, 1)
+	assert.Equal(t, manual, relationships[0])
+	assert.False(t, relationships[0].FederationGroupID.Valid)
+
+	// nor deleted when the pair leaves the group
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1))
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	relationships = setup.relationships(t)
+	require.Len(t, relationships, 1)
+	assert.Equal(t, manual, relationships[0])
+	assert.Empty(t, setup.notifier.Events())
+}
+
+func TestReconcileOverlappingGroups(t *testing.T) {
+	setup := newTestSetup(t)
+	now := time.Now()
+	older := newGroup("older", entity.ConsentStatusApproved, now.Add(-time.Hour))
+	newer := newGroup("newer", entity.ConsentStatusPending, now)
+	setup.datastore.WithFederationGroups(older, newer)
+	setup.datastore.WithFederationGroupMembers(
+		newMember(older, td1), newMember(older, td2),
+		newMember(newer, td1), newMember(newer, td2), newMember(newer, td3),
+	)
+
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	relationships := setup.relationships(t)
+	require.Len(t, relationships, 3)
+	shared := setup.relationship(t, td1, td2)
+	require.NotNil(t, shared)
+	assert.Equal(t, older.ID, shared.FederationGroupID)
+	assert.Equal(t, entity.ConsentStatusApproved, shared.TrustDomainAConsent)
+
+	// the relationship is handed over to the remaining group, keeping its consents
+	setup.datastore.WithFederationGroups(newer)
+	setup.datastore.WithFederationGroupMembers(newMember(newer, td1), newMember(newer, td2), newMember(newer, td3))
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	relationships = setup.relationships(t)
+	require.Len(t, relationships, 3)
+	shared = setup.relationship(t, td1, td2)
+	require.NotNil(t, shared)
+	assert.Equal(t, newer.ID, shared.FederationGroupID)
+	assert.Equal(t, entity.ConsentStatusApproved, shared.TrustDomainAConsent)
+	assert.Len(t, setup.notifier.Events(), 3)
+}
+
+func TestReconcileDatastoreError(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.datastore.AppendNextError(errors.New("datastore error"))
+
+	err := setup.reconciler.Reconcile(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed listing federation groups")
+}
+
+func TestReconcileContinuesAfterPairError(t *testing.T) {
+	setup := newTestSetup(t)
+	group := newGroup("mesh", entity.ConsentStatusPending, time.Now())
+	setup.datastore.WithFederationGroups(group)
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1), newMember(group, td2), newMember(group, td3))
+
+	// listing the groups, their members and the relationships succeeds, creating the first relationship fails
+	setup.datastore.AppendNextError(nil)
+	setup.datastore.AppendNextError(nil)
+	setup.datastore.AppendNextError(nil)
+	setup.datastore.AppendNextError(errors.New("datastore error"))
+
+	err := setup.reconciler.Reconcile(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed creating relationship: datastore error")
+
+	// the other pairs are reconciled
+	assert.Len(t, setup.relationships(t), 2)
+	assert.Len(t, setup.notifier.Events(), 2)
+
+	// and the failed one is retried on the next reconciliation
+	require.NoError(t, setup.reconciler.Reconcile(context.Background()))
+	assert.Len(t, setup.relationships(t), 3)
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	setup := newTestSetup(t)
+	group := newGroup("mesh", entity.ConsentStatusPending, time.Now())
+	setup.datastore.WithFederationGroups(group)
+	setup.datastore.WithFederationGroupMembers(newMember(group, td1), newMember(group, td2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- setup.reconciler.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(setup.relationships(t)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reconciler did not stop")
+	}
+}
+
+func newTestSetup(t *testing.T) *testSetup {
+	datastore := fakedatastore.NewFakeDB()
+	datastore.WithTrustDomains(td1, td2, td3)
+	notifier := fakenotifier.New()
+	logger, _ := test.NewNullLogger()
+
+	return &testSetup{
+		reconciler: New(&Config{
+			Datastore:   datastore,
+			Notifier:    notifier,
+			Coordinator: ha.NewLocalCoordinator(),
+			Logger:      logger,
+		}),
+		datastore: datastore,
+		notifier:  notifier,
+	}
+}
+
+func (s *testSetup) relationships(t *testing.T) []*entity.Relationship {
+	relationships, err := s.datastore.ListRelationships(context.Background(), nil)
+	require.NoError(t, err)
+	return relationships
+}
+
+func (s *testSetup) relationship(t *testing.T, a, b *entity.TrustDomain) *entity.Relationship {
+	for _, rel := range s.relationships(t) {
+		if newPair(rel.TrustDomainAID, rel.TrustDomainBID) == newPair(a.ID.UUID, b.ID.UUID) {
+			return rel
+		}
+	}
+	return nil
+}
+
+func (s *testSetup) related(t *testing.T, a, b *entity.TrustDomain) bool {
+	return s.relationship(t, a, b) != nil
+}
+
+func newTrustDomain(name string) *entity.TrustDomain {
+	return &entity.TrustDomain{
+		ID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Name: spiffeid.RequireTrustDomainFromString(name),
+	}
+}
+
+func newGroup(name string, defaultConsent entity.ConsentStatus, createdAt time.Time) *entity.FederationGroup {
+	return &entity.FederationGroup{
+		ID:             uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Name:           name,
+		DefaultConsent: defaultConsent,
+		CreatedAt:      createdAt,
+	}
+}
+
+func newMember(group *entity.FederationGroup, td *entity.TrustDomain) *entity.FederationGroupMember {
+	return &entity.FederationGroupMember{
+		FederationGroupID: group.ID.UUID,
+		TrustDomainID:     td.ID.UUID,
+		TrustDomainName:   td.Name,
+	}
+}
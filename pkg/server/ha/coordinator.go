@@ -0,0 +1,75 @@
+// Package ha coordinates the replicas of a highly available Galadriel Server that share the same datastore.
+package ha
+
+import (
+	"context"
+	"sync"
+)
+
+// TopicSigningKeys is the topic published when the keys of the KeyManager change.
+const TopicSigningKeys = "signing_keys"
+
+// Coordinator coordinates the replicas of the Galadriel Server that share the same datastore.
+type Coordinator interface {
+	// RunAsLeader blocks until this replica is elected leader of the named job, and then runs the job.
+	// Only one replica at a time is the leader of a job. The context passed to the job is canceled when
+	// the leadership is lost, and the leadership is released when the job returns.
+	RunAsLeader(ctx context.Context, job string, fn func(ctx context.Context) error) error
+
+	// Publish notifies all the replicas, including this one, that the state of the topic changed
+	// and the caches that hold it have to be reloaded.
+	Publish(ctx context.Context, topic string) error
+
+	// Subscribe returns a channel that receives a value when the state of the topic changed.
+	// Notifications are coalesced: the channel holds at most one pending notification.
+	Subscribe(topic string) <-chan struct{}
+
+	// Run delivers the notifications published by the replicas to the subscribers until the context is canceled.
+	Run(ctx context.Context) error
+}
+
+// subscribers holds the channels of the subscribers of each topic.
+type subscribers struct {
+	mu     sync.Mutex
+	topics map[string][]chan struct{}
+}
+
+func newSubscribers() *subscribers {
+	return &subscribers{topics: make(map[string][]chan struct{})}
+}
+
+func (s *subscribers) subscribe(topic string) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	s.topics[topic] = append(s.topics[topic], ch)
+	return ch
+}
+
+func (s *subscribers) notify(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ch := range s.topics[topic] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// a notification is already pending
+		}
+	}
+}
+
+// notifyAll notifies the subscribers of all the topics, used when notifications may have been missed.
+func (s *subscribers) notifyAll() {
+	s.mu.Lock()
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	s.mu.Unlock()
+
+	for _, topic := range topics {
+		s.notify(topic)
+	}
+}
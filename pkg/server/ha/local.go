@@ -0,0 +1,66 @@
+package ha
+
+import (
+	"context"
+	"sync"
+)
+
+// Local is the Coordinator of a Galadriel Server that runs as a single replica.
+// The jobs are run in this process, one at a time per job name, and the
+// notifications are delivered to the subscribers of this process only.
+type Local struct {
+	*subscribers
+
+	mu   sync.Mutex
+	jobs map[string]chan struct{}
+}
+
+// NewLocalCoordinator creates a new Local coordinator.
+func NewLocalCoordinator() *Local {
+	return &Local{
+		subscribers: newSubscribers(),
+		jobs:        make(map[string]chan struct{}),
+	}
+}
+
+// RunAsLeader runs the job once no other call runs a job with the same name.
+func (l *Local) RunAsLeader(ctx context.Context, job string, fn func(ctx context.Context) error) error {
+	sem := l.jobSemaphore(job)
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-sem }()
+
+	return fn(ctx)
+}
+
+// Publish notifies the subscribers of the topic.
+func (l *Local) Publish(_ context.Context, topic string) error {
+	l.notify(topic)
+	return nil
+}
+
+// Subscribe returns a channel that receives a value when the topic is published.
+func (l *Local) Subscribe(topic string) <-chan struct{} {
+	return l.subscribe(topic)
+}
+
+// Run blocks until the context is canceled, the notifications are delivered on Publish.
+func (l *Local) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (l *Local) jobSemaphore(job string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sem, ok := l.jobs[job]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		l.jobs[job] = sem
+	}
+	return sem
+}
@@ -0,0 +1,89 @@
+package ha
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalRunAsLeader(t *testing.T) {
+	ctx := context.Background()
+	c := NewLocalCoordinator()
+
+	jobStarted := make(chan struct{})
+	releaseJob := make(chan struct{})
+	firstDone := make(chan error)
+	go func() {
+		firstDone <- c.RunAsLeader(ctx, "job", func(ctx context.Context) error {
+			close(jobStarted)
+			<-releaseJob
+			return errors.New("job error")
+		})
+	}()
+	<-jobStarted
+
+	// A job with another name is not blocked
+	ran := false
+	err := c.RunAsLeader(ctx, "other-job", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	// A job with the same name waits until the running one returns
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = c.RunAsLeader(timeoutCtx, "job", func(ctx context.Context) error {
+		t.Fatal("job must not run while another replica leads it")
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(releaseJob)
+	require.EqualError(t, <-firstDone, "job error")
+
+	ran = false
+	err = c.RunAsLeader(ctx, "job", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+func TestLocalPublish(t *testing.T) {
+	ctx := context.Background()
+	c := NewLocalCoordinator()
+
+	sub1 := c.Subscribe(TopicSigningKeys)
+	sub2 := c.Subscribe(TopicSigningKeys)
+	other := c.Subscribe("other")
+
+	// Notifications are coalesced
+	require.NoError(t, c.Publish(ctx, TopicSigningKeys))
+	require.NoError(t, c.Publish(ctx, TopicSigningKeys))
+
+	for _, sub := range []<-chan struct{}{sub1, sub2} {
+		select {
+		case <-sub:
+		default:
+			t.Fatal("expected a notification")
+		}
+		select {
+		case <-sub:
+			t.Fatal("expected a single notification")
+		default:
+		}
+	}
+
+	select {
+	case <-other:
+		t.Fatal("unexpected notification of another topic")
+	default:
+	}
+}
@@ -0,0 +1,216 @@
+package ha
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultPollInterval is the default interval of the leadership attempts and checks.
+	DefaultPollInterval = 5 * time.Second
+
+	// notificationChannel is the postgres channel the topics are published on.
+	notificationChannel = "galadriel_invalidations"
+
+	releaseTimeout = 5 * time.Second
+)
+
+// PostgresConfig conveys the configuration of a Postgres coordinator.
+type PostgresConfig struct {
+	// ConnString is the connection string of the postgres database shared by the replicas.
+	ConnString string
+
+	// PollInterval is how often a replica tries to become the leader of a job,
+	// and how often the leader checks that it still holds the leadership.
+	PollInterval time.Duration
+
+	Logger logrus.FieldLogger
+}
+
+// Postgres is a Coordinator for the replicas of a Galadriel Server that share a postgres database.
+// The leader of a job is the replica that holds a session-level advisory lock derived from the job
+// name, and the topics are published with NOTIFY on a channel that every replica LISTENs on.
+type Postgres struct {
+	*subscribers
+
+	connConfig   *pgx.ConnConfig
+	db           *sql.DB
+	pollInterval time.Duration
+	logger       logrus.FieldLogger
+}
+
+// NewPostgresCoordinator creates a new Postgres coordinator.
+func NewPostgresCoordinator(c *PostgresConfig) (*Postgres, error) {
+	connConfig, err := pgx.ParseConfig(c.ConnString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Postgres Connection URL: %w", err)
+	}
+
+	pollInterval := c.PollInterval
+	if pollInterval == 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &Postgres{
+		subscribers:  newSubscribers(),
+		connConfig:   connConfig,
+		db:           stdlib.OpenDB(*connConfig),
+		pollInterval: pollInterval,
+		logger:       c.Logger,
+	}, nil
+}
+
+// Close closes the connections to the database.
+func (p *Postgres) Close() error {
+	return p.db.Close()
+}
+
+// RunAsLeader blocks until this replica acquires the advisory lock of the job, and then runs the job.
+// The lock is held by a dedicated database session: the job context is canceled when the session is lost.
+func (p *Postgres) RunAsLeader(ctx context.Context, job string, fn func(ctx context.Context) error) error {
+	log := p.logger.WithField(telemetry.Job, job)
+
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	lockID := jobLockID(job)
+	for {
+		var acquired bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockID).Scan(&acquired); err != nil {
+			return fmt.Errorf("failed to acquire the leadership of job %q: %w", job, err)
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-time.After(p.pollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	log.Debug("Acquired job leadership")
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	watchDone := make(chan struct{})
+	go func() {
+		defer close(watchDone)
+		p.watchLeadership(jobCtx, cancel, conn, log)
+	}()
+
+	err = fn(jobCtx)
+
+	cancel()
+	<-watchDone
+	p.releaseLeadership(conn, lockID, log)
+
+	return err
+}
+
+// Publish notifies all the replicas that listen on the notification channel.
+func (p *Postgres) Publish(ctx context.Context, topic string) error {
+	if _, err := p.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", notificationChannel, topic); err != nil {
+		return fmt.Errorf("failed to publish topic %q: %w", topic, err)
+	}
+	return nil
+}
+
+// Subscribe returns a channel that receives a value when any replica publishes the topic.
+func (p *Postgres) Subscribe(topic string) <-chan struct{} {
+	return p.subscribe(topic)
+}
+
+// Run listens on the notification channel, reconnecting when the connection is lost.
+// All the subscribers are notified after connecting, since notifications published
+// while this replica was not listening are lost.
+func (p *Postgres) Run(ctx context.Context) error {
+	p.logger.Info("Listening for replica notifications")
+
+	for {
+		err := p.listen(ctx)
+		if ctx.Err() != nil {
+			p.logger.Info("Stopped listening for replica notifications")
+			return nil
+		}
+		p.logger.WithError(err).Warn("Failed to listen for replica notifications, reconnecting")
+
+		select {
+		case <-time.After(p.pollInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (p *Postgres) listen(ctx context.Context) error {
+	conn, err := pgx.ConnectConfig(ctx, p.connConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notificationChannel); err != nil {
+		return err
+	}
+	p.notifyAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		p.notify(notification.Payload)
+	}
+}
+
+// watchLeadership checks that the session holding the lock is alive until the context is done,
+// and cancels the job when it is lost, since postgres released the lock along with the session.
+func (p *Postgres) watchLeadership(ctx context.Context, cancel context.CancelFunc, conn *sql.Conn, log logrus.FieldLogger) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := conn.PingContext(ctx); err != nil && ctx.Err() == nil {
+				log.WithError(err).Error("Lost job leadership")
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *Postgres) releaseLeadership(conn *sql.Conn, lockID int64, log logrus.FieldLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockID); err != nil {
+		log.WithError(err).Warn("Failed to release job leadership, discarding the connection")
+		// the lock is released along with the session when the connection is closed
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		return
+	}
+	log.Debug("Released job leadership")
+}
+
+// jobLockID returns the key of the advisory lock of the job.
+func jobLockID(job string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("galadriel:" + job))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,141 @@
+package ha
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postgresEnv is the environment variable holding the connection string of the local postgres
+// database the Postgres coordinator tests run against. The tests are skipped when it is not set.
+const postgresEnv = "GALADRIEL_TEST_POSTGRES"
+
+const testPollInterval = 100 * time.Millisecond
+
+func TestPostgresRunAsLeader(t *testing.T) {
+	ctx := context.Background()
+	replica1 := newTestPostgresCoordinator(t)
+	replica2 := newTestPostgresCoordinator(t)
+
+	jobStarted := make(chan struct{})
+	releaseJob := make(chan struct{})
+	firstDone := make(chan error)
+	go func() {
+		firstDone <- replica1.RunAsLeader(ctx, "test-job", func(ctx context.Context) error {
+			close(jobStarted)
+			<-releaseJob
+			return nil
+		})
+	}()
+	<-jobStarted
+
+	// The other replica is not elected while the first one leads the job
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*testPollInterval)
+	defer cancel()
+	err := replica2.RunAsLeader(timeoutCtx, "test-job", func(ctx context.Context) error {
+		t.Fatal("job must not run while another replica leads it")
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(releaseJob)
+	require.NoError(t, <-firstDone)
+
+	// The leadership was released
+	ran := false
+	err = replica2.RunAsLeader(ctx, "test-job", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+func TestPostgresLeadershipLost(t *testing.T) {
+	ctx := context.Background()
+	replica := newTestPostgresCoordinator(t)
+
+	admin := openTestDB(t)
+
+	err := replica.RunAsLeader(ctx, "test-job-lost", func(ctx context.Context) error {
+		// Terminate the session holding the advisory lock, as a database failover would
+		_, err := admin.ExecContext(ctx, `SELECT pg_terminate_backend(pid) FROM pg_locks
+			WHERE locktype = 'advisory' AND pid <> pg_backend_pid()`)
+		require.NoError(t, err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(10 * testPollInterval):
+			t.Fatal("expected the job context to be canceled when the leadership is lost")
+			return nil
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestPostgresPublish(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replica1 := newTestPostgresCoordinator(t)
+	replica2 := newTestPostgresCoordinator(t)
+
+	sub := replica2.Subscribe(TopicSigningKeys)
+	go func() { _ = replica2.Run(ctx) }()
+
+	// Subscribers are notified when listening starts, since notifications may have been missed
+	waitNotification(t, sub)
+
+	require.NoError(t, replica1.Publish(ctx, TopicSigningKeys))
+	waitNotification(t, sub)
+}
+
+func waitNotification(t *testing.T, sub <-chan struct{}) {
+	select {
+	case <-sub:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a notification")
+	}
+}
+
+func newTestPostgresCoordinator(t *testing.T) *Postgres {
+	connString := os.Getenv(postgresEnv)
+	if connString == "" {
+		t.Skipf("%s is not set", postgresEnv)
+	}
+
+	logger, _ := test.NewNullLogger()
+	c, err := NewPostgresCoordinator(&PostgresConfig{
+		ConnString:   connString,
+		PollInterval: testPollInterval,
+		Logger:       logger,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+
+	return c
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	connConfig, err := pgx.ParseConfig(os.Getenv(postgresEnv))
+	require.NoError(t, err)
+
+	db := stdlib.OpenDB(*connConfig)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
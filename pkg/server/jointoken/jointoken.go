@@ -0,0 +1,100 @@
+// Package jointoken generates the join tokens with which Harvesters onboard, and verifies them against their
+// salted hash, so that the Galadriel Server never stores the secret part of a token.
+//
+// A join token has the form <prefix>.<secret>. The prefix is a random, non-secret identifier by which the token
+// is looked up, and the secret is only known by whoever was given the token when it was generated.
+package jointoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const (
+	prefixBytes = 8
+	secretBytes = 32
+	saltBytes   = 16
+
+	separator = "."
+)
+
+// ErrMalformedToken is returned when parsing a string that is not a join token.
+var ErrMalformedToken = errors.New("malformed join token")
+
+// Token is a newly generated join token, with the salted hash of its secret to be stored in its place.
+type Token struct {
+	Prefix string
+	Secret string
+	Salt   []byte
+	Hash   []byte
+}
+
+// String returns the join token to hand over to a Harvester.
+func (t *Token) String() string {
+	return t.Prefix + separator + t.Secret
+}
+
+// Generate generates a new join token with a random prefix, secret and salt.
+func Generate() (*Token, error) {
+	prefix := make([]byte, prefixBytes)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	t := &Token{
+		Prefix: hex.EncodeToString(prefix),
+		Secret: base64.RawURLEncoding.EncodeToString(secret),
+		Salt:   salt,
+	}
+	t.Hash = Hash(t.Secret, salt)
+
+	return t, nil
+}
+
+// Parse splits the given join token into its prefix and secret.
+func Parse(token string) (prefix, secret string, err error) {
+	prefix, secret, ok := strings.Cut(token, separator)
+	if !ok || prefix == "" || secret == "" {
+		return "", "", ErrMalformedToken
+	}
+
+	return prefix, secret, nil
+}
+
+// LegacyHash returns the hash by which a legacy join token is looked up. Legacy tokens are the tokens generated
+// before the tokens had a prefix. They are stored with the unsalted hash of the whole token, with which they
+// are verified, and are accepted until they expire.
+func LegacyHash(token string) []byte {
+	return Hash(token, nil)
+}
+
+// Hash returns the salted hash of the secret of a join token.
+func Hash(secret string, salt []byte) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(secret))
+	return h.Sum(nil)
+}
+
+// Verify tells whether the secret matches the salted hash stored for the join token, in constant time.
+func Verify(secret string, salt, hash []byte) bool {
+	if len(hash) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare(Hash(secret, salt), hash) == 1
+}
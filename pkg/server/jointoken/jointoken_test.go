@@ -0,0 +1,57 @@
+package jointoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	token, err := Generate()
+	require.NoError(t, err)
+
+	assert.Len(t, token.Prefix, 2*prefixBytes)
+	assert.NotEmpty(t, token.Secret)
+	assert.Len(t, token.Salt, saltBytes)
+	assert.Equal(t, Hash(token.Secret, token.Salt), token.Hash)
+	assert.NotContains(t, string(token.Hash), token.Secret)
+
+	other, err := Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, token.Prefix, other.Prefix)
+	assert.NotEqual(t, token.Secret, other.Secret)
+	assert.NotEqual(t, token.Salt, other.Salt)
+}
+
+func TestParse(t *testing.T) {
+	token, err := Generate()
+	require.NoError(t, err)
+
+	prefix, secret, err := Parse(token.String())
+	require.NoError(t, err)
+	assert.Equal(t, token.Prefix, prefix)
+	assert.Equal(t, token.Secret, secret)
+
+	for _, malformed := range []string{"", "no-separator", ".secret", "prefix.", "5a8c3f4e-0d2b-4c1a-9f6e-7b3d2a1c0e9f"} {
+		_, _, err := Parse(malformed)
+		assert.ErrorIs(t, err, ErrMalformedToken, malformed)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	token, err := Generate()
+	require.NoError(t, err)
+
+	assert.True(t, Verify(token.Secret, token.Salt, token.Hash))
+	assert.False(t, Verify(token.Secret+"x", token.Salt, token.Hash))
+	assert.False(t, Verify(token.Secret, []byte("other salt"), token.Hash))
+	assert.False(t, Verify(token.Secret, token.Salt, nil))
+}
+
+func TestLegacyHash(t *testing.T) {
+	legacyToken := "5a8c3f4e-0d2b-4c1a-9f6e-7b3d2a1c0e9f"
+
+	assert.True(t, Verify(legacyToken, nil, LegacyHash(legacyToken)))
+	assert.False(t, Verify(legacyToken+"x", nil, LegacyHash(legacyToken)))
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const collectTimeout = 10 * time.Second
+
+// DatastoreCollector is a prometheus.Collector that reports gauges computed from the Datastore at scrape time:
+// the number of relationships by consent status, and the age of the stored bundle of each trust domain.
+type DatastoreCollector struct {
+	datastore db.Datastore
+	logger    logrus.FieldLogger
+
+	// now is used to calculate the bundle age, overridden in tests
+	now func() time.Time
+
+	relationshipsDesc *prometheus.Desc
+	bundleAgeDesc     *prometheus.Desc
+}
+
+// NewDatastoreCollector creates a new DatastoreCollector.
+func NewDatastoreCollector(ds db.Datastore, logger logrus.FieldLogger) *DatastoreCollector {
+	return &DatastoreCollector{
+		datastore: ds,
+		logger:    logger,
+		now:       time.Now,
+		relationshipsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(telemetry.MetricsNamespace, subsystem, "relationships"),
+			"Number of relationships by consent status. A relationship is approved when both trust domains approved it, "+
+				"denied when any of them denied it, and pending otherwise.",
+			[]string{"status"}, nil),
+		bundleAgeDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(telemetry.MetricsNamespace, subsystem, "bundle_age_seconds"),
+			"Time since the bundle of the trust domain was last updated.",
+			[]string{"trust_domain"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *DatastoreCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.relationshipsDesc
+	ch <- c.bundleAgeDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *DatastoreCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	c.collectRelationships(ctx, ch)
+	c.collectBundleAges(ctx, ch)
+}
+
+func (c *DatastoreCollector) collectRelationships(ctx context.Context, ch chan<- prometheus.Metric) {
+	relationships, err := c.datastore.ListRelationships(ctx, &criteria.ListRelationshipsCriteria{})
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to list relationships for metrics")
+		return
+	}
+
+	counts := map[entity.ConsentStatus]int{
+		entity.ConsentStatusApproved: 0,
+		entity.ConsentStatusDenied:   0,
+		entity.ConsentStatusPending:  0,
+	}
+	for _, r := range relationships {
+		counts[relationshipStatus(r)]++
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.relationshipsDesc, prometheus.GaugeValue, float64(count), string(status))
+	}
+}
+
+func (c *DatastoreCollector) collectBundleAges(ctx context.Context, ch chan<- prometheus.Metric) {
+	bundles, err := c.datastore.ListBundles(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to list bundles for metrics")
+		return
+	}
+
+	trustDomains, err := c.datastore.ListTrustDomains(ctx, &criteria.ListTrustDomainCriteria{})
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to list trust domains for metrics")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(trustDomains))
+	for _, td := range trustDomains {
+		names[td.ID.UUID] = td.Name.String()
+	}
+
+	now := c.now()
+	for _, b := range bundles {
+		name, ok := names[b.TrustDomainID]
+		if !ok {
+			continue
+		}
+		age := now.Sub(b.UpdatedAt).Seconds()
+		ch <- prometheus.MustNewConstMetric(c.bundleAgeDesc, prometheus.GaugeValue, age, name)
+	}
+}
+
+func relationshipStatus(r *entity.Relationship) entity.ConsentStatus {
+	switch {
+	case r.TrustDomainAConsent == entity.ConsentStatusDenied || r.TrustDomainBConsent == entity.ConsentStatusDenied:
+		return entity.ConsentStatusDenied
+	case r.TrustDomainAConsent == entity.ConsentStatusApproved && r.TrustDomainBConsent == entity.ConsentStatusApproved:
+		return entity.ConsentStatusApproved
+	default:
+		return entity.ConsentStatusPending
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastoreCollector(t *testing.T) {
+	now := time.Now()
+
+	tdA := &entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("td-a.org"), ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	tdB := &entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("td-b.org"), ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+
+	ds := fakedatastore.NewFakeDB()
+	ds.WithTrustDomains(tdA, tdB)
+	ds.WithRelationships(
+		newRelationship(tdA, tdB, entity.ConsentStatusApproved, entity.ConsentStatusApproved),
+		newRelationship(tdA, tdB, entity.ConsentStatusApproved, entity.ConsentStatusPending),
+		newRelationship(tdA, tdB, entity.ConsentStatusPending, entity.ConsentStatusPending),
+		newRelationship(tdA, tdB, entity.ConsentStatusApproved, entity.ConsentStatusDenied),
+	)
+	ds.WithBundles(
+		&entity.Bundle{ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, TrustDomainID: tdA.ID.UUID, UpdatedAt: now.Add(-time.Minute)},
+		&entity.Bundle{ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, TrustDomainID: tdB.ID.UUID, UpdatedAt: now.Add(-time.Hour)},
+	)
+
+	collector := NewDatastoreCollector(ds, logrus.New())
+	collector.now = func() time.Time { return now }
+
+	expected := `
+# HELP galadriel_server_bundle_age_seconds Time since the bundle of the trust domain was last updated.
+# TYPE galadriel_server_bundle_age_seconds gauge
+galadriel_server_bundle_age_seconds{trust_domain="td-a.org"} 60
+galadriel_server_bundle_age_seconds{trust_domain="td-b.org"} 3600
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "galadriel_server_bundle_age_seconds")
+	require.NoError(t, err)
+
+	expected = `
+# HELP galadriel_server_relationships Number of relationships by consent status. A relationship is approved when both trust domains approved it, denied when any of them denied it, and pending otherwise.
+# TYPE galadriel_server_relationships gauge
+galadriel_server_relationships{status="approved"} 1
+galadriel_server_relationships{status="denied"} 1
+galadriel_server_relationships{status="pending"} 2
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expected), "galadriel_server_relationships")
+	require.NoError(t, err)
+}
+
+func TestDatastoreCollectorDatastoreError(t *testing.T) {
+	ds := fakedatastore.NewFakeDB()
+	ds.AppendNextError(errors.New("relationships error"))
+	ds.AppendNextError(errors.New("bundles error"))
+
+	collector := NewDatastoreCollector(ds, logrus.New())
+
+	require.Equal(t, 0, testutil.CollectAndCount(collector))
+}
+
+func newRelationship(a, b *entity.TrustDomain, aConsent, bConsent entity.ConsentStatus) *entity.Relationship {
+	return &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      a.ID.UUID,
+		TrustDomainBID:      b.ID.UUID,
+		TrustDomainAConsent: aConsent,
+		TrustDomainBConsent: bConsent,
+	}
+}
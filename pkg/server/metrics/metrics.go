@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const subsystem = "server"
+
+// Reasons an onboarding request can fail for.
+const (
+	OnboardFailureInvalidRequest         = "invalid_request"
+	OnboardFailureTokenNotFound          = "token_not_found"
+	OnboardFailureTokenExpired           = "token_expired"
+	OnboardFailureTokenUsed              = "token_used"
+	OnboardFailureSourceNotAllowed       = "source_not_allowed"
+	OnboardFailureTrustDomainNotFound    = "trust_domain_not_found"
+	OnboardFailureTrustDomainMismatch    = "trust_domain_mismatch"
+	OnboardFailureExternalTrustDomain    = "external_trust_domain"
+	OnboardFailureSVIDOnboardingDisabled = "svid_onboarding_disabled"
+	OnboardFailureSVIDMissing            = "svid_missing"
+	OnboardFailureSVIDInvalid            = "svid_invalid"
+	OnboardFailureSVIDIDMismatch         = "svid_id_mismatch"
+	OnboardFailureInvalidProof           = "invalid_proof"
+	OnboardFailureInternalError          = "internal_error"
+)
+
+var (
+	harvesterRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: telemetry.MetricsNamespace,
+		Subsystem: subsystem,
+		Name:      "harvester_requests_total",
+		Help:      "Number of requests received on the Harvester API, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	harvesterRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: telemetry.MetricsNamespace,
+		Subsystem: subsystem,
+		Name:      "harvester_request_duration_seconds",
+		Help:      "Latency of the requests served on the Harvester API, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	onboardFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: telemetry.MetricsNamespace,
+		Subsystem: subsystem,
+		Name:      "onboard_failures_total",
+		Help:      "Number of failed Harvester onboarding attempts, by reason.",
+	}, []string{"reason"})
+)
+
+// HarvesterAPIMiddleware records the count and latency of the requests served by the Harvester API.
+// The route label is the path template of the matched route, so that path parameters do not inflate cardinality.
+func HarvesterAPIMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		code := c.Response().Status
+		if httpErr, ok := err.(*echo.HTTPError); ok {
+			code = httpErr.Code
+		}
+
+		method := c.Request().Method
+		route := c.Path()
+		harvesterRequests.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+		harvesterRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// IncOnboardFailure increments the onboarding failures counter for the given reason.
+func IncOnboardFailure(reason string) {
+	onboardFailures.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHarvesterAPIMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(HarvesterAPIMiddleware)
+	e.GET("/trust-domain/:trustDomainName/ok", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/trust-domain/:trustDomainName/fail", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, "failed")
+	})
+
+	for _, path := range []string{"/trust-domain/td1/ok", "/trust-domain/td2/ok", "/trust-domain/td1/fail"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	okRoute := "/trust-domain/:trustDomainName/ok"
+	failRoute := "/trust-domain/:trustDomainName/fail"
+	assert.Equal(t, float64(2), testutil.ToFloat64(harvesterRequests.WithLabelValues(http.MethodGet, okRoute, "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(harvesterRequests.WithLabelValues(http.MethodGet, failRoute, "400")))
+	assert.Equal(t, 2, testutil.CollectAndCount(harvesterRequestDuration))
+}
+
+func TestIncOnboardFailure(t *testing.T) {
+	before := testutil.ToFloat64(onboardFailures.WithLabelValues(OnboardFailureTokenExpired))
+	IncOnboardFailure(OnboardFailureTokenExpired)
+	assert.Equal(t, before+1, testutil.ToFloat64(onboardFailures.WithLabelValues(OnboardFailureTokenExpired)))
+}
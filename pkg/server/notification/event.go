@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/google/uuid"
+)
+
+// EventType identifies the kind of federation event a notification is sent for.
+type EventType string
+
+const (
+	// EventRelationshipCreated is sent when a relationship between two trust domains is requested.
+	EventRelationshipCreated EventType = "relationship.created"
+	// EventRelationshipConsentUpdated is sent when a trust domain approves, denies or resets its consent on a relationship.
+	EventRelationshipConsentUpdated EventType = "relationship.consent_updated"
+	// EventRelationshipDeleted is sent when a relationship managed by a federation group is deleted.
+	EventRelationshipDeleted EventType = "relationship.deleted"
+	// EventBundleUpdated is sent when a harvester uploads a bundle whose content differs from the stored one.
+	EventBundleUpdated EventType = "bundle.updated"
+	// EventTrustDomainCreated is sent when a trust domain is registered.
+	EventTrustDomainCreated EventType = "trust_domain.created"
+	// EventTrustDomainUpdated is sent when a trust domain is updated.
+	EventTrustDomainUpdated EventType = "trust_domain.updated"
+	// EventTrustDomainDeleted is sent when a trust domain is deleted.
+	EventTrustDomainDeleted EventType = "trust_domain.deleted"
+)
+
+// EventTypes lists all the event types that can be notified.
+var EventTypes = []EventType{
+	EventRelationshipCreated,
+	EventRelationshipConsentUpdated,
+	EventRelationshipDeleted,
+	EventBundleUpdated,
+	EventTrustDomainCreated,
+	EventTrustDomainUpdated,
+	EventTrustDomainDeleted,
+}
+
+// Event is the payload delivered to the webhook targets.
+type Event struct {
+	ID        uuid.UUID `json:"id"`
+	Type      EventType `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// TrustDomains are the names of the trust domains the event concerns, used to filter the targets.
+	TrustDomains []string `json:"trust_domains"`
+
+	// Data holds the affected resource, in the same representation used by the APIs.
+	Data any `json:"data"`
+}
+
+// BundleData is the data of a bundle.updated event.
+type BundleData struct {
+	TrustDomain string `json:"trust_domain"`
+	Digest      string `json:"digest"` // Base64 encoded SHA-256 digest of the new bundle.
+}
+
+// NewRelationshipEvent creates an event of the given type for a relationship.
+// The trust domain names of the relationship are expected to be populated.
+func NewRelationshipEvent(eventType EventType, r *entity.Relationship) *Event {
+	return newEvent(eventType, api.RelationshipFromEntity(r), r.TrustDomainAName.String(), r.TrustDomainBName.String())
+}
+
+// NewTrustDomainEvent creates an event of the given type for a trust domain.
+func NewTrustDomainEvent(eventType EventType, td *entity.TrustDomain) *Event {
+	return newEvent(eventType, api.TrustDomainFromEntity(td), td.Name.String())
+}
+
+// NewBundleUpdatedEvent creates a bundle.updated event for the bundle of the given trust domain.
+func NewBundleUpdatedEvent(td *entity.TrustDomain, b *entity.Bundle) *Event {
+	data := &BundleData{
+		TrustDomain: td.Name.String(),
+		Digest:      encoding.EncodeToBase64(b.Digest),
+	}
+	return newEvent(EventBundleUpdated, data, td.Name.String())
+}
+
+func newEvent(eventType EventType, data any, trustDomains ...string) *Event {
+	return &Event{
+		ID:           uuid.New(),
+		Type:         eventType,
+		Timestamp:    time.Now().UTC(),
+		TrustDomains: trustDomains,
+		Data:         data,
+	}
+}
@@ -0,0 +1,287 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of delivery attempts made before a notification is dead-lettered.
+	DefaultMaxAttempts = 5
+	// DefaultInitialBackoff is the default time waited before the first retry. It doubles on each retry.
+	DefaultInitialBackoff = 1 * time.Second
+	// DefaultMaxBackoff is the default upper bound of the time waited between retries.
+	DefaultMaxBackoff = 1 * time.Minute
+
+	queueSize       = 256
+	deliveryTimeout = 10 * time.Second
+)
+
+// Notifier notifies federation events.
+type Notifier interface {
+	// Notify queues the event for delivery. It never blocks the caller.
+	Notify(event *Event)
+}
+
+// Config conveys the configuration of the webhook notifications.
+type Config struct {
+	Webhooks []*WebhookConfig
+
+	// MaxAttempts is the number of delivery attempts made to a webhook before the notification is dead-lettered.
+	MaxAttempts int
+
+	// InitialBackoff is the time waited before the first retry, doubled on each subsequent retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// WebhookConfig is a webhook target along with the events it subscribes to.
+type WebhookConfig struct {
+	Name string
+	URL  string
+
+	// Secret is the key used to compute the HMAC signature of the payloads.
+	Secret string
+
+	// Events the webhook is notified of. All the events are notified when empty.
+	Events []EventType
+
+	// TrustDomains restricts the notifications to the events concerning any of these trust domains.
+	// The events of all the trust domains are notified when empty.
+	TrustDomains []spiffeid.TrustDomain
+}
+
+// Dispatcher is a Notifier that delivers the events to the configured webhooks.
+// Failed deliveries are retried with exponential backoff, and stored in the
+// webhook dead letters table of the datastore when all the attempts fail.
+type Dispatcher struct {
+	c         *Config
+	datastore db.Datastore
+	logger    logrus.FieldLogger
+	client    *http.Client
+	queue     chan *Event
+
+	hooks struct {
+		// test hook used to signal that a delivery finished, either delivered or dead-lettered
+		delivered chan struct{}
+	}
+}
+
+// NewDispatcher creates a new Dispatcher. Zero values in the config are set to their defaults.
+func NewDispatcher(c *Config, ds db.Datastore, logger logrus.FieldLogger) (*Dispatcher, error) {
+	for _, w := range c.Webhooks {
+		if err := validateWebhook(w); err != nil {
+			return nil, err
+		}
+	}
+
+	config := *c
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = DefaultInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		return nil, fmt.Errorf("max backoff %s is less than initial backoff %s", config.MaxBackoff, config.InitialBackoff)
+	}
+
+	return &Dispatcher{
+		c:         &config,
+		datastore: ds,
+		logger:    logger,
+		client:    &http.Client{Timeout: deliveryTimeout},
+		queue:     make(chan *Event, queueSize),
+	}, nil
+}
+
+// Notify queues the event for delivery to the webhooks subscribed to it.
+// The event is dropped if the queue is full.
+func (d *Dispatcher) Notify(event *Event) {
+	if len(d.c.Webhooks) == 0 {
+		return
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		d.logger.WithField(telemetry.Event, event.Type).Warn("Notification queue is full, dropping event")
+	}
+}
+
+// Run delivers the queued events until the context is canceled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	d.logger.Info("Notification dispatcher started")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case event := <-d.queue:
+			payload, err := json.Marshal(event)
+			if err != nil {
+				d.logger.WithError(err).Error("Failed to marshal notification")
+				continue
+			}
+
+			for _, w := range d.c.Webhooks {
+				if !subscribed(w, event) {
+					continue
+				}
+				wg.Add(1)
+				go func(w *WebhookConfig) {
+					defer wg.Done()
+					d.deliverWithRetries(ctx, w, event, payload)
+				}(w)
+			}
+		case <-ctx.Done():
+			d.logger.Info("Notification dispatcher stopped")
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) deliverWithRetries(ctx context.Context, w *WebhookConfig, event *Event, payload []byte) {
+	defer d.triggerDeliveredHook()
+
+	log := d.logger.WithFields(logrus.Fields{
+		telemetry.Webhook: w.Name,
+		telemetry.Event:   event.Type,
+	})
+
+	var err error
+	backoff := d.c.InitialBackoff
+	for attempt := 1; attempt <= d.c.MaxAttempts; attempt++ {
+		if err = d.deliver(ctx, w, event, payload); err == nil {
+			log.Debug("Notification delivered")
+			return
+		}
+
+		if attempt == d.c.MaxAttempts {
+			break
+		}
+
+		log.WithError(err).Warnf("Notification delivery failed, retrying in %s", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			log.Warn("Notification delivery aborted on shutdown")
+			return
+		}
+
+		backoff *= 2
+		if backoff > d.c.MaxBackoff {
+			backoff = d.c.MaxBackoff
+		}
+	}
+
+	log.WithError(err).Errorf("Notification delivery failed after %d attempts", d.c.MaxAttempts)
+
+	deadLetter := &entity.WebhookDeadLetter{
+		Webhook:   w.Name,
+		URL:       w.URL,
+		EventID:   event.ID,
+		EventType: string(event.Type),
+		Payload:   payload,
+		Attempts:  d.c.MaxAttempts,
+		LastError: err.Error(),
+	}
+	if _, err := d.datastore.CreateWebhookDeadLetter(ctx, deadLetter); err != nil {
+		log.WithError(err).Error("Failed to store webhook dead letter")
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, w *WebhookConfig, event *Event, payload []byte) error {
+	req, err := newWebhookRequest(ctx, w, event, payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) triggerDeliveredHook() {
+	if d.hooks.delivered != nil {
+		d.hooks.delivered <- struct{}{}
+	}
+}
+
+func subscribed(w *WebhookConfig, event *Event) bool {
+	if len(w.Events) > 0 && !containsEvent(w.Events, event.Type) {
+		return false
+	}
+
+	if len(w.TrustDomains) == 0 {
+		return true
+	}
+	for _, td := range w.TrustDomains {
+		for _, name := range event.TrustDomains {
+			if td.String() == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func containsEvent(events []EventType, eventType EventType) bool {
+	for _, e := range events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func validateWebhook(w *WebhookConfig) error {
+	if w.Name == "" {
+		return errors.New("webhook name is required")
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL for webhook %q: %w", w.Name, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("invalid URL for webhook %q: scheme must be http or https", w.Name)
+	}
+
+	if w.Secret == "" {
+		return fmt.Errorf("secret is required for webhook %q", w.Name)
+	}
+
+	for _, e := range w.Events {
+		if !containsEvent(EventTypes, e) {
+			return fmt.Errorf("unknown event %q for webhook %q", e, w.Name)
+		}
+	}
+
+	return nil
+}
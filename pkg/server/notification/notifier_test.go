@@ -0,0 +1,302 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+var (
+	tdA = &entity.TrustDomain{ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: spiffeid.RequireTrustDomainFromString("td-a.org")}
+	tdB = &entity.TrustDomain{ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: spiffeid.RequireTrustDomainFromString("td-b.org")}
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type fakeReceiver struct {
+	mu       sync.Mutex
+	requests []receivedRequest
+
+	// statuses returned on each request, 200 once they are exhausted
+	statuses []int
+}
+
+func (f *fakeReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, receivedRequest{header: r.Header.Clone(), body: body})
+
+	status := http.StatusOK
+	if len(f.statuses) > 0 {
+		status = f.statuses[0]
+		f.statuses = f.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (f *fakeReceiver) received() []receivedRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]receivedRequest(nil), f.requests...)
+}
+
+func TestDispatcherDeliversSignedEvent(t *testing.T) {
+	receiver := &fakeReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	d, _ := setupDispatcher(t, &WebhookConfig{Name: "ops", URL: server.URL, Secret: testSecret})
+
+	event := NewTrustDomainEvent(EventTrustDomainCreated, tdA)
+	d.Notify(event)
+	waitDelivered(t, d)
+
+	requests := receiver.received()
+	require.Len(t, requests, 1)
+
+	req := requests[0]
+	assert.Equal(t, string(EventTrustDomainCreated), req.header.Get(EventHeader))
+	assert.Equal(t, event.ID.String(), req.header.Get(DeliveryHeader))
+	assert.True(t, VerifySignature(testSecret, req.body, req.header.Get(TimestampHeader), req.header.Get(SignatureHeader), DefaultSignatureTolerance))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(req.body, &got))
+	assert.Equal(t, event.ID.String(), got["id"])
+	assert.Equal(t, string(EventTrustDomainCreated), got["type"])
+	assert.Equal(t, []any{tdA.Name.String()}, got["trust_domains"])
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	receiver := &fakeReceiver{statuses: []int{http.StatusInternalServerError, http.StatusBadGateway}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	d, ds := setupDispatcher(t, &WebhookConfig{Name: "ops", URL: server.URL, Secret: testSecret})
+
+	event := NewBundleUpdatedEvent(tdA, &entity.Bundle{Digest: []byte("digest")})
+	d.Notify(event)
+	waitDelivered(t, d)
+
+	requests := receiver.received()
+	require.Len(t, requests, 3)
+	for _, r := range requests {
+		assert.Equal(t, event.ID.String(), r.header.Get(DeliveryHeader))
+	}
+
+	deadLetters, err := ds.ListWebhookDeadLetters(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
+
+func TestDispatcherStoresDeadLetter(t *testing.T) {
+	receiver := &fakeReceiver{statuses: []int{500, 500, 500}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	d, ds := setupDispatcher(t, &WebhookConfig{Name: "ops", URL: server.URL, Secret: testSecret})
+
+	event := NewTrustDomainEvent(EventTrustDomainDeleted, tdA)
+	d.Notify(event)
+	waitDelivered(t, d)
+
+	assert.Len(t, receiver.received(), d.c.MaxAttempts)
+
+	deadLetters, err := ds.ListWebhookDeadLetters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+
+	dl := deadLetters[0]
+	assert.Equal(t, "ops", dl.Webhook)
+	assert.Equal(t, server.URL, dl.URL)
+	assert.Equal(t, event.ID, dl.EventID)
+	assert.Equal(t, string(EventTrustDomainDeleted), dl.EventType)
+	assert.Equal(t, d.c.MaxAttempts, dl.Attempts)
+	assert.Equal(t, "unexpected status code 500", dl.LastError)
+	assert.Equal(t, receiver.received()[0].body, dl.Payload)
+}
+
+func TestDispatcherFiltersEvents(t *testing.T) {
+	relationshipsReceiver := &fakeReceiver{}
+	relationshipsServer := httptest.NewServer(relationshipsReceiver)
+	defer relationshipsServer.Close()
+
+	tdBReceiver := &fakeReceiver{}
+	tdBServer := httptest.NewServer(tdBReceiver)
+	defer tdBServer.Close()
+
+	d, _ := setupDispatcher(t,
+		&WebhookConfig{
+			Name:   "relationships",
+			URL:    relationshipsServer.URL,
+			Secret: testSecret,
+			Events: []EventType{EventRelationshipCreated, EventRelationshipConsentUpdated},
+		},
+		&WebhookConfig{
+			Name:         "td-b",
+			URL:          tdBServer.URL,
+			Secret:       testSecret,
+			TrustDomains: []spiffeid.TrustDomain{tdB.Name},
+		},
+	)
+
+	rel := &entity.Relationship{
+		ID:               uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:   tdA.ID.UUID,
+		TrustDomainBID:   tdB.ID.UUID,
+		TrustDomainAName: tdA.Name,
+		TrustDomainBName: tdB.Name,
+	}
+
+	// delivered to both webhooks
+	d.Notify(NewRelationshipEvent(EventRelationshipCreated, rel))
+	waitDelivered(t, d)
+	waitDelivered(t, d)
+
+	// delivered to none
+	d.Notify(NewTrustDomainEvent(EventTrustDomainUpdated, tdA))
+
+	// delivered to the td-b webhook only
+	d.Notify(NewBundleUpdatedEvent(tdB, &entity.Bundle{Digest: []byte("digest")}))
+	waitDelivered(t, d)
+
+	require.Len(t, relationshipsReceiver.received(), 1)
+	assert.Equal(t, string(EventRelationshipCreated), relationshipsReceiver.received()[0].header.Get(EventHeader))
+
+	require.Len(t, tdBReceiver.received(), 2)
+	assert.Equal(t, string(EventRelationshipCreated), tdBReceiver.received()[0].header.Get(EventHeader))
+	assert.Equal(t, string(EventBundleUpdated), tdBReceiver.received()[1].header.Get(EventHeader))
+}
+
+func TestNewDispatcher(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ds := fakedatastore.NewFakeDB()
+
+	d, err := NewDispatcher(&Config{}, ds, logger)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMaxAttempts, d.c.MaxAttempts)
+	assert.Equal(t, DefaultInitialBackoff, d.c.InitialBackoff)
+	assert.Equal(t, DefaultMaxBackoff, d.c.MaxBackoff)
+
+	testCases := []struct {
+		name   string
+		config *Config
+		err    string
+	}{
+		{
+			name:   "missing name",
+			config: &Config{Webhooks: []*WebhookConfig{{URL: "https://example.org", Secret: testSecret}}},
+			err:    "webhook name is required",
+		},
+		{
+			name:   "invalid scheme",
+			config: &Config{Webhooks: []*WebhookConfig{{Name: "ops", URL: "ftp://example.org", Secret: testSecret}}},
+			err:    `invalid URL for webhook "ops": scheme must be http or https`,
+		},
+		{
+			name:   "missing secret",
+			config: &Config{Webhooks: []*WebhookConfig{{Name: "ops", URL: "https://example.org"}}},
+			err:    `secret is required for webhook "ops"`,
+		},
+		{
+			name:   "unknown event",
+			config: &Config{Webhooks: []*WebhookConfig{{Name: "ops", URL: "https://example.org", Secret: testSecret, Events: []EventType{"unknown"}}}},
+			err:    `unknown event "unknown" for webhook "ops"`,
+		},
+		{
+			name:   "max backoff less than initial backoff",
+			config: &Config{InitialBackoff: time.Minute, MaxBackoff: time.Second},
+			err:    "max backoff 1s is less than initial backoff 1m0s",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewDispatcher(tc.config, ds, logger)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"type":"bundle.updated"}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	signature := Sign(testSecret, timestamp, payload)
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.True(t, VerifySignature(testSecret, payload, timestamp, signature, DefaultSignatureTolerance))
+	assert.False(t, VerifySignature("other-secret", payload, timestamp, signature, DefaultSignatureTolerance))
+	assert.False(t, VerifySignature(testSecret, []byte("tampered"), timestamp, signature, DefaultSignatureTolerance))
+	assert.False(t, VerifySignature(testSecret, payload, timestamp, signature[7:], DefaultSignatureTolerance))
+
+	// the timestamp is covered by the signature
+	otherTimestamp := strconv.FormatInt(time.Now().Unix()+1, 10)
+	assert.False(t, VerifySignature(testSecret, payload, otherTimestamp, signature, DefaultSignatureTolerance))
+	assert.False(t, VerifySignature(testSecret, payload, "not-a-timestamp", Sign(testSecret, "not-a-timestamp", payload), DefaultSignatureTolerance))
+
+	// captured deliveries are rejected once outside of the tolerance
+	oldTimestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	oldSignature := Sign(testSecret, oldTimestamp, payload)
+	assert.False(t, VerifySignature(testSecret, payload, oldTimestamp, oldSignature, DefaultSignatureTolerance))
+	assert.True(t, VerifySignature(testSecret, payload, oldTimestamp, oldSignature, time.Hour))
+
+	futureTimestamp := strconv.FormatInt(time.Now().Add(10*time.Minute).Unix(), 10)
+	assert.False(t, VerifySignature(testSecret, payload, futureTimestamp, Sign(testSecret, futureTimestamp, payload), DefaultSignatureTolerance))
+}
+
+func setupDispatcher(t *testing.T, webhooks ...*WebhookConfig) (*Dispatcher, *fakedatastore.FakeDatabase) {
+	logger, _ := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	ds := fakedatastore.NewFakeDB()
+
+	d, err := NewDispatcher(&Config{
+		Webhooks:       webhooks,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}, ds, logger)
+	require.NoError(t, err)
+	d.hooks.delivered = make(chan struct{}, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- d.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	return d, ds
+}
+
+func waitDelivered(t *testing.T, d *Dispatcher) {
+	select {
+	case <-d.hooks.delivered:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the notification delivery")
+	}
+}
@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+)
+
+// Headers set on the webhook requests.
+const (
+	// EventHeader carries the type of the event.
+	EventHeader = "X-Galadriel-Event"
+	// DeliveryHeader carries the ID of the event, the same on every retry so that receivers can deduplicate.
+	DeliveryHeader = "X-Galadriel-Delivery"
+	// TimestampHeader carries the time the request was sent, as seconds since the Unix epoch. It is covered by the
+	// signature, and set again on each retry.
+	TimestampHeader = "X-Galadriel-Timestamp"
+	// SignatureHeader carries the HMAC-SHA256 signature of the timestamp and the payload, as "sha256=<hex encoded MAC>".
+	SignatureHeader = "X-Galadriel-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// DefaultSignatureTolerance is the recommended maximum difference between the timestamp of a request and the clock
+// of the receiver. Requests outside of it should be rejected, so that captured deliveries cannot be replayed.
+const DefaultSignatureTolerance = 5 * time.Minute
+
+// Sign returns the value of the signature header for the timestamp header value and the payload, computed with the
+// webhook secret. The signed bytes are the timestamp, a '.' and the payload.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks that the signature header value matches the timestamp header value, the payload and the
+// webhook secret, and that the timestamp is within the tolerance of the current time.
+func VerifySignature(secret string, payload []byte, timestamp, signature string, tolerance time.Duration) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	skew := time.Since(time.Unix(seconds, 0))
+	if skew > tolerance || skew < -tolerance {
+		return false
+	}
+
+	expected := Sign(secret, timestamp, payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func newWebhookRequest(ctx context.Context, w *WebhookConfig, event *Event, payload []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", constants.GaladrielServerName)
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, event.ID.String())
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, payload))
+
+	return req, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
@@ -12,12 +14,29 @@ import (
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundleendpoint"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlefetcher"
+	"github.com/HewlettPackard/galadriel/pkg/server/bundlepolicy"
 	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
 	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
+	"github.com/HewlettPackard/galadriel/pkg/server/federationgroup"
+	"github.com/HewlettPackard/galadriel/pkg/server/ha"
+	"github.com/HewlettPackard/galadriel/pkg/server/metrics"
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	tracingShutdownTimeout = 5 * time.Second
+
+	// sharedJWTKeyID is the default ID of the JWT signing key shared by the replicas of a highly available server.
+	sharedJWTKeyID = "galadriel-server-jwt"
+	// sharedJWTKeyJob is the job that looks up or generates the shared JWT signing key.
+	sharedJWTKeyJob = "shared-jwt-key"
+)
+
 // Server represents a Galadriel Server.
 type Server struct {
 	config *Config
@@ -25,10 +44,43 @@ type Server struct {
 
 // Config conveys configurations for the Galadriel Server
 type Config struct {
-	TCPAddress      *net.TCPAddr
-	LocalAddress    net.Addr
-	Logger          logrus.FieldLogger
-	ProvidersConfig *catalog.ProvidersConfig
+	TCPAddress       *net.TCPAddr
+	LocalAddress     net.Addr
+	MetricsAddress   *net.TCPAddr             // TCP address the Prometheus metrics endpoint listens on, disabled when nil
+	Tracing          *telemetry.TracingConfig // OpenTelemetry tracing configuration, disabled when nil
+	Notifications    *notification.Config     // Webhook notifications configuration, disabled when nil
+	BundlePolicy     *bundlepolicy.Config     // Validation policy of the uploaded bundles, the defaults are used when nil
+	HighAvailability *HighAvailabilityConfig  // Coordination with the other server replicas, disabled when nil
+	BundleEndpoint   *bundleendpoint.Config   // SPIFFE bundle endpoint serving the stored bundles, disabled when nil
+	TLS              *endpoints.TLSConfig     // TLS certificate presented to the Harvesters, the defaults are used when nil
+	Logger           logrus.FieldLogger
+	ProvidersConfig  *catalog.ProvidersConfig
+}
+
+// HighAvailabilityConfig conveys the configuration of a Galadriel Server that runs as one of several
+// replicas sharing the same postgres datastore.
+type HighAvailabilityConfig struct {
+	// PollInterval is how often a replica tries to become the leader of a job, and how often the
+	// leader checks that it still holds the leadership.
+	PollInterval time.Duration
+
+	// JWTKeyID is the ID of the shared key that signs the JWTs, generated when it does not exist.
+	// Defaults to "galadriel-server-jwt". Changing it rotates the key.
+	JWTKeyID string
+
+	// RetiredJWTKeyIDs are the IDs of the shared keys that were rotated out, deleted from the KeyManager
+	// so that the JWTs they signed are no longer valid.
+	RetiredJWTKeyIDs []string
+}
+
+// keyReloader is a KeyManager that caches keys stored by other processes.
+type keyReloader interface {
+	Reload(ctx context.Context) error
+}
+
+// keyDeleter is a KeyManager that can delete keys.
+type keyDeleter interface {
+	DeleteKey(ctx context.Context, id string) error
 }
 
 // New creates a new instance of the Galadriel Server.
@@ -38,21 +90,44 @@ func New(config *Config) *Server {
 
 // Run starts the Galadriel Server, initializing the components and listening for incoming requests.
 // It performs the following steps:
-// 1. Loads catalogs from the providers configuration.
-// 2. Creates a JWT issuer based on the key manager from the catalogs.
-// 3. Sets up a JWT validator.
-// 4. Creates the endpoints server, which handles incoming requests.
-// 5. Starts the endpoints server and listens for requests until the context is canceled.
+// 1. Sets up tracing, if configured.
+// 2. Loads catalogs from the providers configuration.
+// 3. Creates the coordinator of the server replicas, which is local unless high availability is configured.
+// 4. Creates a JWT issuer based on the key manager from the catalogs, shared by the replicas in high availability.
+// 5. Sets up a JWT validator.
+// 6. Creates the notifications dispatcher, which delivers the federation events to the configured webhooks.
+// 7. Creates the endpoints server, which handles incoming requests.
+// 8. Creates the fetcher of the bundles of the external trust domains, which don't run a Harvester.
+// 9. Creates the metrics server, if a metrics address is configured.
+// 10. Creates the SPIFFE bundle endpoint server, if configured.
+// 11. Starts the endpoints server and listens for requests until the context is canceled.
 func (s *Server) Run(ctx context.Context) error {
 	s.config.Logger.Info("Starting Galadriel Server")
 
+	if s.config.Tracing != nil {
+		shutdownTracing, err := telemetry.SetupTracing(ctx, s.config.Tracing)
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer s.shutdownTracing(shutdownTracing)
+	}
+
 	cat := catalog.New()
 	err := cat.LoadFromProvidersConfig(s.config.ProvidersConfig)
 	if err != nil {
 		return fmt.Errorf("failed to load catalogs from providers config: %w", err)
 	}
+	defer cat.Close()
+
+	coordinator, err := s.newCoordinator()
+	if err != nil {
+		return fmt.Errorf("failed to create high availability coordinator: %w", err)
+	}
+	if closer, ok := coordinator.(io.Closer); ok {
+		defer closer.Close()
+	}
 
-	jwtIssuer, err := s.createJWTIssuer(ctx, cat.GetKeyManager())
+	jwtIssuer, err := s.createJWTIssuer(ctx, cat.GetKeyManager(), coordinator)
 	if err != nil {
 		return fmt.Errorf("failed to create JWT issuer: %w", err)
 	}
@@ -63,24 +138,84 @@ func (s *Server) Run(ctx context.Context) error {
 	}
 	jwtValidator := jwt.NewDefaultJWTValidator(c)
 
-	endpointsServer, err := s.newEndpointsServer(cat, jwtIssuer, jwtValidator)
+	dispatcher, err := s.newNotificationDispatcher(cat)
+	if err != nil {
+		return fmt.Errorf("failed to create notification dispatcher: %w", err)
+	}
+
+	bundlePolicy, err := bundlepolicy.New(s.config.BundlePolicy)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle policy: %w", err)
+	}
+
+	endpointsServer, err := s.newEndpointsServer(cat, jwtIssuer, jwtValidator, dispatcher, bundlePolicy)
 	if err != nil {
 		return fmt.Errorf("failed to create endpoints server: %w", err)
 	}
 
-	err = util.RunTasks(ctx, endpointsServer.ListenAndServe)
+	fetcher := bundlefetcher.New(&bundlefetcher.Config{
+		Datastore:    cat.GetDatastore(),
+		BundlePolicy: bundlePolicy,
+		Notifier:     dispatcher,
+		Coordinator:  coordinator,
+		Logger:       s.config.Logger.WithField(telemetry.SubsystemName, telemetry.BundleFetcher),
+	})
+
+	reconciler := federationgroup.New(&federationgroup.Config{
+		Datastore:   cat.GetDatastore(),
+		Notifier:    dispatcher,
+		Coordinator: coordinator,
+		Logger:      s.config.Logger.WithField(telemetry.SubsystemName, telemetry.FederationGroupReconciler),
+	})
+
+	tasks := []func(ctx context.Context) error{
+		endpointsServer.ListenAndServe,
+		dispatcher.Run,
+		coordinator.Run,
+		fetcher.Run,
+		reconciler.Run,
+	}
+
+	if km, ok := cat.GetKeyManager().(keyReloader); ok {
+		keysChanged := coordinator.Subscribe(ha.TopicSigningKeys)
+		tasks = append(tasks, func(ctx context.Context) error {
+			return s.reloadKeysOnChange(ctx, km, keysChanged)
+		})
+	}
+
+	if s.config.MetricsAddress != nil {
+		metricsServer, err := s.newMetricsServer(cat)
+		if err != nil {
+			return fmt.Errorf("failed to create metrics server: %w", err)
+		}
+		tasks = append(tasks, metricsServer.ListenAndServe)
+	}
+
+	if s.config.BundleEndpoint != nil {
+		logger := s.config.Logger.WithField(telemetry.SubsystemName, telemetry.BundleEndpoint)
+		bundleEndpoint, err := bundleendpoint.New(s.config.BundleEndpoint, cat.GetDatastore(), cat.GetX509CA(), logger)
+		if err != nil {
+			return fmt.Errorf("failed to create bundle endpoint server: %w", err)
+		}
+		tasks = append(tasks, bundleEndpoint.ListenAndServe)
+	}
+
+	err = util.RunTasks(ctx, tasks...)
 	if errors.Is(err, context.Canceled) {
 		err = nil
 	}
 	return err
 }
 
-func (s *Server) newEndpointsServer(catalog catalog.Catalog, jwtIssuer jwt.Issuer, jwtValidator jwt.Validator) (endpoints.Server, error) {
+func (s *Server) newEndpointsServer(catalog catalog.Catalog, jwtIssuer jwt.Issuer, jwtValidator jwt.Validator, notifier notification.Notifier, bundlePolicy *bundlepolicy.Policy) (endpoints.Server, error) {
 	config := &endpoints.Config{
 		TCPAddress:   s.config.TCPAddress,
 		LocalAddress: s.config.LocalAddress,
 		Logger:       s.config.Logger.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Catalog:      catalog,
+		Notifier:     notifier,
+		BundlePolicy: bundlePolicy,
+		TLS:          s.config.TLS,
 		JWTIssuer:    jwtIssuer,
 		JWTValidator: jwtValidator,
 	}
@@ -88,12 +223,64 @@ func (s *Server) newEndpointsServer(catalog catalog.Catalog, jwtIssuer jwt.Issue
 	return endpoints.New(config)
 }
 
-func (s *Server) createJWTIssuer(ctx context.Context, keyManager keymanager.KeyManager) (jwt.Issuer, error) {
-	keyID, err := uuid.NewUUID()
+func (s *Server) newNotificationDispatcher(catalog catalog.Catalog) (*notification.Dispatcher, error) {
+	config := s.config.Notifications
+	if config == nil {
+		config = &notification.Config{}
+	}
+
+	logger := s.config.Logger.WithField(telemetry.SubsystemName, telemetry.Notifications)
+	return notification.NewDispatcher(config, catalog.GetDatastore(), logger)
+}
+
+func (s *Server) shutdownTracing(shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		s.config.Logger.WithError(err).Error("Failed to shut down tracing")
+	}
+}
+
+func (s *Server) newMetricsServer(catalog catalog.Catalog) (*telemetry.MetricsServer, error) {
+	logger := s.config.Logger.WithField(telemetry.SubsystemName, telemetry.Metrics)
+
+	collector := metrics.NewDatastoreCollector(catalog.GetDatastore(), logger)
+	if err := prometheus.Register(collector); err != nil {
+		return nil, fmt.Errorf("failed to register datastore metrics collector: %w", err)
+	}
+
+	return telemetry.NewMetricsServer(&telemetry.MetricsServerConfig{
+		Address: s.config.MetricsAddress,
+		Logger:  logger,
+	})
+}
+
+func (s *Server) newCoordinator() (ha.Coordinator, error) {
+	if s.config.HighAvailability == nil {
+		return ha.NewLocalCoordinator(), nil
+	}
+
+	connString, err := s.config.ProvidersConfig.HighAvailabilityConnString()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate Key ID: %v", err)
+		return nil, err
+	}
+
+	return ha.NewPostgresCoordinator(&ha.PostgresConfig{
+		ConnString:   connString,
+		PollInterval: s.config.HighAvailability.PollInterval,
+		Logger:       s.config.Logger.WithField(telemetry.SubsystemName, telemetry.HighAvailability),
+	})
+}
+
+func (s *Server) createJWTIssuer(ctx context.Context, keyManager keymanager.KeyManager, coordinator ha.Coordinator) (jwt.Issuer, error) {
+	var key keymanager.Key
+	var err error
+	if s.config.HighAvailability != nil {
+		key, err = s.sharedJWTKey(ctx, keyManager, coordinator)
+	} else {
+		key, err = generateJWTKey(ctx, keyManager)
 	}
-	key, err := keyManager.GenerateKey(ctx, keyID.String(), cryptoutil.DefaultKeyType)
 	if err != nil {
 		return nil, err
 	}
@@ -108,3 +295,104 @@ func (s *Server) createJWTIssuer(ctx context.Context, keyManager keymanager.KeyM
 
 	return jwtIssuer, nil
 }
+
+// sharedJWTKey returns the JWT signing key shared by the server replicas, generating it when no replica did yet,
+// and deletes the retired keys. It runs as the leader of a job, so that replicas starting at the same time don't
+// generate different keys.
+func (s *Server) sharedJWTKey(ctx context.Context, keyManager keymanager.KeyManager, coordinator ha.Coordinator) (keymanager.Key, error) {
+	keyID := s.config.HighAvailability.JWTKeyID
+	if keyID == "" {
+		keyID = sharedJWTKeyID
+	}
+
+	var key keymanager.Key
+	err := coordinator.RunAsLeader(ctx, sharedJWTKeyJob, func(ctx context.Context) error {
+		// load the keys generated or deleted by the replicas that ran the job before
+		if km, ok := keyManager.(keyReloader); ok {
+			if err := km.Reload(ctx); err != nil {
+				return err
+			}
+		}
+
+		changed, err := s.deleteRetiredJWTKeys(ctx, keyManager, keyID)
+		if err != nil {
+			return err
+		}
+
+		key, err = keyManager.GetKey(ctx, keyID)
+		switch {
+		case errors.Is(err, keymanager.ErrKeyNotFound):
+			key, err = keyManager.GenerateKey(ctx, keyID, cryptoutil.DefaultKeyType)
+			if err != nil {
+				return err
+			}
+			s.config.Logger.WithField(telemetry.KeyID, keyID).Info("Generated the shared JWT signing key")
+			changed = true
+		case err != nil:
+			return err
+		}
+
+		if !changed {
+			return nil
+		}
+		return coordinator.Publish(ctx, ha.TopicSigningKeys)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the shared JWT signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+// deleteRetiredJWTKeys deletes the retired JWT signing keys that are still stored, returning whether any was.
+func (s *Server) deleteRetiredJWTKeys(ctx context.Context, keyManager keymanager.KeyManager, activeKeyID string) (bool, error) {
+	deleted := false
+	for _, id := range s.config.HighAvailability.RetiredJWTKeyIDs {
+		if id == activeKeyID {
+			return false, fmt.Errorf("the retired JWT signing key %q is the active one", id)
+		}
+
+		_, err := keyManager.GetKey(ctx, id)
+		if errors.Is(err, keymanager.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		deleter, ok := keyManager.(keyDeleter)
+		if !ok {
+			return false, fmt.Errorf("the KeyManager cannot delete the retired JWT signing key %q", id)
+		}
+		if err := deleter.DeleteKey(ctx, id); err != nil {
+			return false, err
+		}
+		s.config.Logger.WithField(telemetry.KeyID, id).Info("Deleted the retired JWT signing key")
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+// reloadKeysOnChange reloads the keys of the KeyManager when another replica changes them.
+func (s *Server) reloadKeysOnChange(ctx context.Context, keyManager keyReloader, changes <-chan struct{}) error {
+	for {
+		select {
+		case <-changes:
+			if err := keyManager.Reload(ctx); err != nil {
+				s.config.Logger.WithError(err).Warn("Failed to reload the keys of the KeyManager")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func generateJWTKey(ctx context.Context, keyManager keymanager.KeyManager) (keymanager.Key, error) {
+	keyID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Key ID: %v", err)
+	}
+
+	return keyManager.GenerateKey(ctx, keyID.String(), cryptoutil.DefaultKeyType)
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/pkg/server/ha"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateJWTIssuerSharedKey(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := test.NewNullLogger()
+	config := &Config{
+		Logger:           logger,
+		HighAvailability: &HighAvailabilityConfig{},
+	}
+
+	// Two replicas whose KeyManagers share the same store
+	store := &fakeKeyStore{keys: make(map[string][]byte)}
+	coordinator := ha.NewLocalCoordinator()
+	km1 := keymanager.NewSharedKeyManager(nil, store)
+	km2 := keymanager.NewSharedKeyManager(nil, store)
+	keysChanged := coordinator.Subscribe(ha.TopicSigningKeys)
+
+	issuer1, err := New(config).createJWTIssuer(ctx, km1, coordinator)
+	require.NoError(t, err)
+	require.Len(t, store.keys, 1)
+	assert.Contains(t, store.keys, sharedJWTKeyID)
+
+	select {
+	case <-keysChanged:
+	default:
+		t.Fatal("expected the replicas to be notified of the generated key")
+	}
+
+	// The second replica reuses the key generated by the first one
+	issuer2, err := New(config).createJWTIssuer(ctx, km2, coordinator)
+	require.NoError(t, err)
+	require.Len(t, store.keys, 1)
+
+	// A token issued by a replica is valid on the other one
+	params := &jwt.JWTParams{
+		Issuer:   constants.GaladrielServerName,
+		Subject:  spiffeid.RequireTrustDomainFromString("td1.org"),
+		Audience: []string{constants.GaladrielServerName},
+		TTL:      time.Minute,
+	}
+	validator1 := jwt.NewDefaultJWTValidator(&jwt.ValidatorConfig{KeyManager: km1, ExpectedAudience: params.Audience})
+	validator2 := jwt.NewDefaultJWTValidator(&jwt.ValidatorConfig{KeyManager: km2, ExpectedAudience: params.Audience})
+
+	token, err := issuer1.IssueJWT(ctx, params)
+	require.NoError(t, err)
+	_, err = validator2.ValidateToken(ctx, token)
+	require.NoError(t, err)
+
+	token, err = issuer2.IssueJWT(ctx, params)
+	require.NoError(t, err)
+	_, err = validator1.ValidateToken(ctx, token)
+	require.NoError(t, err)
+}
+
+func TestCreateJWTIssuerRotateSharedKey(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := test.NewNullLogger()
+
+	store := &fakeKeyStore{keys: make(map[string][]byte)}
+	coordinator := ha.NewLocalCoordinator()
+	km1 := keymanager.NewSharedKeyManager(nil, store)
+	km2 := keymanager.NewSharedKeyManager(nil, store)
+
+	params := &jwt.JWTParams{
+		Issuer:   constants.GaladrielServerName,
+		Subject:  spiffeid.RequireTrustDomainFromString("td1.org"),
+		Audience: []string{constants.GaladrielServerName},
+		TTL:      time.Minute,
+	}
+	validator := jwt.NewDefaultJWTValidator(&jwt.ValidatorConfig{KeyManager: km2, ExpectedAudience: params.Audience})
+
+	issuer, err := New(&Config{Logger: logger, HighAvailability: &HighAvailabilityConfig{}}).createJWTIssuer(ctx, km1, coordinator)
+	require.NoError(t, err)
+	oldToken, err := issuer.IssueJWT(ctx, params)
+	require.NoError(t, err)
+
+	// Rotating the key keeps the tokens signed by the old key valid
+	config := &Config{
+		Logger:           logger,
+		HighAvailability: &HighAvailabilityConfig{JWTKeyID: "jwt-2"},
+	}
+	issuer, err = New(config).createJWTIssuer(ctx, km1, coordinator)
+	require.NoError(t, err)
+	require.Len(t, store.keys, 2)
+	assert.Contains(t, store.keys, "jwt-2")
+
+	newToken, err := issuer.IssueJWT(ctx, params)
+	require.NoError(t, err)
+
+	// The other replicas validate the tokens signed by the new key once they reload their keys
+	_, err = validator.ValidateToken(ctx, newToken)
+	require.Error(t, err)
+	require.NoError(t, km2.Reload(ctx))
+	_, err = validator.ValidateToken(ctx, oldToken)
+	require.NoError(t, err)
+	_, err = validator.ValidateToken(ctx, newToken)
+	require.NoError(t, err)
+
+	// Retiring the old key invalidates its tokens, once the other replicas reload their keys
+	keysChanged := coordinator.Subscribe(ha.TopicSigningKeys)
+	config.HighAvailability.RetiredJWTKeyIDs = []string{sharedJWTKeyID}
+	_, err = New(config).createJWTIssuer(ctx, km1, coordinator)
+	require.NoError(t, err)
+	require.Len(t, store.keys, 1)
+	assert.Contains(t, store.keys, "jwt-2")
+
+	select {
+	case <-keysChanged:
+	default:
+		t.Fatal("expected the replicas to be notified of the deleted key")
+	}
+
+	require.NoError(t, km2.Reload(ctx))
+	_, err = validator.ValidateToken(ctx, oldToken)
+	require.Error(t, err)
+	_, err = validator.ValidateToken(ctx, newToken)
+	require.NoError(t, err)
+
+	// The active key cannot be retired
+	config.HighAvailability.RetiredJWTKeyIDs = []string{"jwt-2"}
+	_, err = New(config).createJWTIssuer(ctx, km1, coordinator)
+	require.EqualError(t, err, `failed to get the shared JWT signing key: the retired JWT signing key "jwt-2" is the active one`)
+}
+
+func TestCreateJWTIssuerWithoutHighAvailability(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := test.NewNullLogger()
+	config := &Config{Logger: logger}
+
+	km := keymanager.NewMemoryKeyManager(nil)
+	coordinator := ha.NewLocalCoordinator()
+
+	_, err := New(config).createJWTIssuer(ctx, km, coordinator)
+	require.NoError(t, err)
+	_, err = New(config).createJWTIssuer(ctx, km, coordinator)
+	require.NoError(t, err)
+
+	// Each run generates its own key
+	keys, err := km.GetKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+type fakeKeyStore struct {
+	mu   sync.Mutex
+	keys map[string][]byte
+}
+
+func (s *fakeKeyStore) PutKey(_ context.Context, id string, keyPEM []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[id] = keyPEM
+	return nil
+}
+
+func (s *fakeKeyStore) GetKeys(context.Context) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make(map[string][]byte, len(s.keys))
+	for id, keyPEM := range s.keys {
+		keys[id] = keyPEM
+	}
+	return keys, nil
+}
+
+func (s *fakeKeyStore) DeleteKey(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, id)
+	return nil
+}
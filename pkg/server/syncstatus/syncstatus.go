@@ -0,0 +1,191 @@
+package syncstatus
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// Status is the distribution status of the federated bundles, computed from the bundle sync states reported by
+// the harvesters.
+type Status struct {
+	Relationships     []*RelationshipStatus
+	UnexpectedBundles []*UnexpectedBundle
+}
+
+// RelationshipStatus is the distribution status of the bundles of an approved relationship, for each side of it.
+// The side that does not trust its peer in a one-way relationship has no distribution.
+type RelationshipStatus struct {
+	RelationshipID uuid.UUID
+	TrustDomainA   *Distribution // Whether trust domain A holds the latest bundle of trust domain B, nil if A does not trust B.
+	TrustDomainB   *Distribution // Whether trust domain B holds the latest bundle of trust domain A, nil if B does not trust A.
+}
+
+// Distribution tells whether a trust domain holds the latest bundle of its peer. A trust domain with several
+// harvester instances only holds it when all the instances that reported holding the peer bundle hold the latest one.
+type Distribution struct {
+	TrustDomain     spiffeid.TrustDomain
+	PeerTrustDomain spiffeid.TrustDomain
+
+	// ExpectedDigest is the digest of the latest bundle of the peer, nil if the peer has not uploaded a bundle.
+	ExpectedDigest []byte
+	// ReportedDigest is the digest of the peer bundle reported by the harvester instances, nil if none reported one.
+	// It is the digest reported by an instance that does not hold the latest bundle when there is one.
+	ReportedDigest []byte
+	// ReportedAt is the time of the bundle sync that reported ReportedDigest, nil if no instance reported one.
+	ReportedAt *time.Time
+
+	InSync bool
+	// Lag is how long the latest bundle of the peer has been available without the trust domain holding it.
+	Lag time.Duration
+}
+
+// UnexpectedBundle is a bundle held by a harvester that no relationship of its trust domain justifies. It is reported
+// once per trust domain, with the most recently reported state among its harvester instances.
+type UnexpectedBundle struct {
+	TrustDomain          spiffeid.TrustDomain
+	FederatedTrustDomain spiffeid.TrustDomain
+	Digest               []byte
+	ReportedAt           time.Time
+}
+
+// Compute computes the distribution status of the bundles of the approved relationships, i.e. the ones approved by
+// both trust domains, and the bundles held by the harvesters that are not justified by a relationship approved by
+// their trust domain. The states reported by the harvester instances of a trust domain are combined.
+func Compute(trustDomains []*entity.TrustDomain, relationships []*entity.Relationship, bundles []*entity.Bundle, states []*entity.BundleSyncState, now time.Time) *Status {
+	names := make(map[uuid.UUID]spiffeid.TrustDomain, len(trustDomains))
+	for _, td := range trustDomains {
+		names[td.ID.UUID] = td.Name
+	}
+
+	bundlesByTD := make(map[uuid.UUID]*entity.Bundle, len(bundles))
+	for _, b := range bundles {
+		bundlesByTD[b.TrustDomainID] = b
+	}
+
+	type stateKey struct {
+		trustDomainID uuid.UUID
+		federatedTD   spiffeid.TrustDomain
+	}
+	statesByKey := make(map[stateKey][]*entity.BundleSyncState, len(states))
+	var keys []stateKey
+	for _, s := range states {
+		key := stateKey{s.TrustDomainID, s.FederatedTrustDomain}
+		if _, ok := statesByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		statesByKey[key] = append(statesByKey[key], s)
+	}
+	for _, instanceStates := range statesByKey {
+		sort.Slice(instanceStates, func(i, j int) bool {
+			return instanceStates[i].HarvesterInstanceID < instanceStates[j].HarvesterInstanceID
+		})
+	}
+
+	// bundles justified by a relationship approved by the trust domain holding them, in which it trusts its peer
+	justified := make(map[stateKey]bool)
+
+	status := &Status{
+		Relationships:     []*RelationshipStatus{},
+		UnexpectedBundles: []*UnexpectedBundle{},
+	}
+	for _, r := range relationships {
+		nameA, nameB := names[r.TrustDomainAID], names[r.TrustDomainBID]
+		aTrustsB, bTrustsA := r.Trusts(r.TrustDomainAID), r.Trusts(r.TrustDomainBID)
+		if r.TrustDomainAConsent == entity.ConsentStatusApproved && aTrustsB {
+			justified[stateKey{r.TrustDomainAID, nameB}] = true
+		}
+		if r.TrustDomainBConsent == entity.ConsentStatusApproved && bTrustsA {
+			justified[stateKey{r.TrustDomainBID, nameA}] = true
+		}
+
+		if r.TrustDomainAConsent != entity.ConsentStatusApproved || r.TrustDomainBConsent != entity.ConsentStatusApproved {
+			continue
+		}
+
+		relationshipStatus := &RelationshipStatus{RelationshipID: r.ID.UUID}
+		if aTrustsB {
+			relationshipStatus.TrustDomainA = distribution(nameA, nameB, bundlesByTD[r.TrustDomainBID], statesByKey[stateKey{r.TrustDomainAID, nameB}], now)
+		}
+		if bTrustsA {
+			relationshipStatus.TrustDomainB = distribution(nameB, nameA, bundlesByTD[r.TrustDomainAID], statesByKey[stateKey{r.TrustDomainBID, nameA}], now)
+		}
+		status.Relationships = append(status.Relationships, relationshipStatus)
+	}
+
+	for _, key := range keys {
+		holder := names[key.trustDomainID]
+		if justified[key] || holder == key.federatedTD {
+			continue
+		}
+		s := latestState(statesByKey[key])
+		status.UnexpectedBundles = append(status.UnexpectedBundles, &UnexpectedBundle{
+			TrustDomain:          holder,
+			FederatedTrustDomain: s.FederatedTrustDomain,
+			Digest:               s.Digest,
+			ReportedAt:           s.ReportedAt,
+		})
+	}
+
+	sort.Slice(status.UnexpectedBundles, func(i, j int) bool {
+		a, b := status.UnexpectedBundles[i], status.UnexpectedBundles[j]
+		if a.TrustDomain != b.TrustDomain {
+			return a.TrustDomain.String() < b.TrustDomain.String()
+		}
+		return a.FederatedTrustDomain.String() < b.FederatedTrustDomain.String()
+	})
+
+	return status
+}
+
+func distribution(td, peer spiffeid.TrustDomain, peerBundle *entity.Bundle, states []*entity.BundleSyncState, now time.Time) *Distribution {
+	d := &Distribution{
+		TrustDomain:     td,
+		PeerTrustDomain: peer,
+	}
+	if peerBundle != nil {
+		d.ExpectedDigest = peerBundle.Digest
+	}
+
+	// an instance lagging behind makes the whole trust domain lag behind
+	var state *entity.BundleSyncState
+	for _, s := range states {
+		if !bytes.Equal(d.ExpectedDigest, s.Digest) {
+			state = s
+			break
+		}
+	}
+	if state == nil {
+		state = latestState(states)
+	}
+	if state != nil {
+		d.ReportedDigest = state.Digest
+		reportedAt := state.ReportedAt
+		d.ReportedAt = &reportedAt
+	}
+
+	d.InSync = bytes.Equal(d.ExpectedDigest, d.ReportedDigest)
+	if !d.InSync && peerBundle != nil {
+		d.Lag = now.Sub(peerBundle.UpdatedAt)
+		if d.Lag < 0 {
+			d.Lag = 0
+		}
+	}
+
+	return d
+}
+
+// latestState returns the most recently reported of the states, nil if there are none.
+func latestState(states []*entity.BundleSyncState) *entity.BundleSyncState {
+	var latest *entity.BundleSyncState
+	for _, s := range states {
+		if latest == nil || s.ReportedAt.After(latest.ReportedAt) {
+			latest = s
+		}
+	}
+	return latest
+}
@@ -0,0 +1,199 @@
+package syncstatus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now = time.Now()
+
+	td1 = &entity.TrustDomain{ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: spiffeid.RequireTrustDomainFromString("td1.org")}
+	td2 = &entity.TrustDomain{ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: spiffeid.RequireTrustDomainFromString("td2.org")}
+	td3 = &entity.TrustDomain{ID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Name: spiffeid.RequireTrustDomainFromString("td3.org")}
+)
+
+func TestCompute(t *testing.T) {
+	approved := &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      td1.ID.UUID,
+		TrustDomainBID:      td2.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusApproved,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+	}
+	// only approved by td3, so td3 can hold the bundle of td1 but the relationship is not approved
+	pending := &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      td1.ID.UUID,
+		TrustDomainBID:      td3.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusPending,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+	}
+
+	bundles := []*entity.Bundle{
+		{TrustDomainID: td1.ID.UUID, Digest: []byte("td1-latest"), UpdatedAt: now.Add(-10 * time.Minute)},
+		{TrustDomainID: td2.ID.UUID, Digest: []byte("td2-latest"), UpdatedAt: now.Add(-time.Hour)},
+	}
+
+	states := []*entity.BundleSyncState{
+		// td1 holds the latest bundle of td2
+		{TrustDomainID: td1.ID.UUID, FederatedTrustDomain: td2.Name, Digest: []byte("td2-latest"), ReportedAt: now},
+		// td2 holds an old bundle of td1
+		{TrustDomainID: td2.ID.UUID, FederatedTrustDomain: td1.Name, Digest: []byte("td1-old"), ReportedAt: now},
+		// td3 holds the bundle of td1, justified by its approval of the pending relationship
+		{TrustDomainID: td3.ID.UUID, FederatedTrustDomain: td1.Name, Digest: []byte("td1-latest"), ReportedAt: now},
+		// td3 holds a bundle of td2, without any relationship
+		{TrustDomainID: td3.ID.UUID, FederatedTrustDomain: td2.Name, Digest: []byte("td2-latest"), ReportedAt: now},
+		// td1 holds a bundle of an unknown trust domain
+		{TrustDomainID: td1.ID.UUID, FederatedTrustDomain: spiffeid.RequireTrustDomainFromString("unknown.org"), Digest: []byte("unknown"), ReportedAt: now},
+	}
+
+	status := Compute([]*entity.TrustDomain{td1, td2, td3}, []*entity.Relationship{approved, pending}, bundles, states, now)
+
+	require.Len(t, status.Relationships, 1)
+	rs := status.Relationships[0]
+	assert.Equal(t, approved.ID.UUID, rs.RelationshipID)
+
+	assert.Equal(t, td1.Name, rs.TrustDomainA.TrustDomain)
+	assert.Equal(t, td2.Name, rs.TrustDomainA.PeerTrustDomain)
+	assert.True(t, rs.TrustDomainA.InSync)
+	assert.Zero(t, rs.TrustDomainA.Lag)
+	assert.Equal(t, []byte("td2-latest"), rs.TrustDomainA.ExpectedDigest)
+	assert.Equal(t, []byte("td2-latest"), rs.TrustDomainA.ReportedDigest)
+
+	assert.Equal(t, td2.Name, rs.TrustDomainB.TrustDomain)
+	assert.Equal(t, td1.Name, rs.TrustDomainB.PeerTrustDomain)
+	assert.False(t, rs.TrustDomainB.InSync)
+	assert.Equal(t, 10*time.Minute, rs.TrustDomainB.Lag)
+	assert.Equal(t, []byte("td1-latest"), rs.TrustDomainB.ExpectedDigest)
+	assert.Equal(t, []byte("td1-old"), rs.TrustDomainB.ReportedDigest)
+
+	assert.Equal(t, []*UnexpectedBundle{
+		{TrustDomain: td1.Name, FederatedTrustDomain: spiffeid.RequireTrustDomainFromString("unknown.org"), Digest: []byte("unknown"), ReportedAt: now},
+		{TrustDomain: td3.Name, FederatedTrustDomain: td2.Name, Digest: []byte("td2-latest"), ReportedAt: now},
+	}, status.UnexpectedBundles)
+}
+
+func TestComputeNotReported(t *testing.T) {
+	approved := &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      td1.ID.UUID,
+		TrustDomainBID:      td2.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusApproved,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+	}
+	bundles := []*entity.Bundle{
+		{TrustDomainID: td1.ID.UUID, Digest: []byte("td1-latest"), UpdatedAt: now.Add(-time.Minute)},
+	}
+
+	status := Compute([]*entity.TrustDomain{td1, td2}, []*entity.Relationship{approved}, bundles, nil, now)
+	require.Len(t, status.Relationships, 1)
+
+	// td2 has not uploaded a bundle, so there is nothing for td1 to hold
+	a := status.Relationships[0].TrustDomainA
+	assert.True(t, a.InSync)
+	assert.Nil(t, a.ExpectedDigest)
+	assert.Nil(t, a.ReportedAt)
+
+	// td2 never reported the bundle of td1
+	b := status.Relationships[0].TrustDomainB
+	assert.False(t, b.InSync)
+	assert.Equal(t, time.Minute, b.Lag)
+	assert.Nil(t, b.ReportedDigest)
+	assert.Nil(t, b.ReportedAt)
+
+	assert.Empty(t, status.UnexpectedBundles)
+}
+
+func TestComputeOneWay(t *testing.T) {
+	// td1 trusts td2, but td2 does not trust td1
+	oneWay := &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      td1.ID.UUID,
+		TrustDomainBID:      td2.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusApproved,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+		Direction:           entity.RelationshipATrustsB,
+	}
+	bundles := []*entity.Bundle{
+		{TrustDomainID: td1.ID.UUID, Digest: []byte("td1-latest"), UpdatedAt: now},
+		{TrustDomainID: td2.ID.UUID, Digest: []byte("td2-latest"), UpdatedAt: now},
+	}
+	states := []*entity.BundleSyncState{
+		{TrustDomainID: td1.ID.UUID, FederatedTrustDomain: td2.Name, Digest: []byte("td2-latest"), ReportedAt: now},
+		// td2 holds the bundle of td1, which the relationship does not justify
+		{TrustDomainID: td2.ID.UUID, FederatedTrustDomain: td1.Name, Digest: []byte("td1-latest"), ReportedAt: now},
+	}
+
+	status := Compute([]*entity.TrustDomain{td1, td2}, []*entity.Relationship{oneWay}, bundles, states, now)
+	require.Len(t, status.Relationships, 1)
+
+	rs := status.Relationships[0]
+	require.NotNil(t, rs.TrustDomainA)
+	assert.True(t, rs.TrustDomainA.InSync)
+	assert.Nil(t, rs.TrustDomainB)
+
+	assert.Equal(t, []*UnexpectedBundle{
+		{TrustDomain: td2.Name, FederatedTrustDomain: td1.Name, Digest: []byte("td1-latest"), ReportedAt: now},
+	}, status.UnexpectedBundles)
+}
+
+func TestComputeMultipleInstances(t *testing.T) {
+	approved := &entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TrustDomainAID:      td1.ID.UUID,
+		TrustDomainBID:      td2.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusApproved,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+	}
+	bundles := []*entity.Bundle{
+		{TrustDomainID: td1.ID.UUID, Digest: []byte("td1-latest"), UpdatedAt: now.Add(-10 * time.Minute)},
+		{TrustDomainID: td2.ID.UUID, Digest: []byte("td2-latest"), UpdatedAt: now.Add(-time.Hour)},
+	}
+	states := []*entity.BundleSyncState{
+		// both instances of td1 hold the latest bundle of td2
+		{TrustDomainID: td1.ID.UUID, HarvesterInstanceID: "spire-1", FederatedTrustDomain: td2.Name, Digest: []byte("td2-latest"), ReportedAt: now.Add(-time.Minute)},
+		{TrustDomainID: td1.ID.UUID, HarvesterInstanceID: "spire-2", FederatedTrustDomain: td2.Name, Digest: []byte("td2-latest"), ReportedAt: now},
+		// only one of the instances of td2 holds the latest bundle of td1, reported after the lagging one
+		{TrustDomainID: td2.ID.UUID, HarvesterInstanceID: "spire-1", FederatedTrustDomain: td1.Name, Digest: []byte("td1-old"), ReportedAt: now.Add(-time.Minute)},
+		{TrustDomainID: td2.ID.UUID, HarvesterInstanceID: "spire-2", FederatedTrustDomain: td1.Name, Digest: []byte("td1-latest"), ReportedAt: now},
+		// both instances of td1 hold a bundle that no relationship justifies
+		{TrustDomainID: td1.ID.UUID, HarvesterInstanceID: "spire-1", FederatedTrustDomain: td3.Name, Digest: []byte("td3-new"), ReportedAt: now},
+		{TrustDomainID: td1.ID.UUID, HarvesterInstanceID: "spire-2", FederatedTrustDomain: td3.Name, Digest: []byte("td3-old"), ReportedAt: now.Add(-time.Minute)},
+	}
+
+	// the result does not depend on the order of the states
+	for _, ordered := range [][]*entity.BundleSyncState{states, reversed(states)} {
+		status := Compute([]*entity.TrustDomain{td1, td2, td3}, []*entity.Relationship{approved}, bundles, ordered, now)
+		require.Len(t, status.Relationships, 1)
+
+		a := status.Relationships[0].TrustDomainA
+		assert.True(t, a.InSync)
+		assert.Equal(t, []byte("td2-latest"), a.ReportedDigest)
+		assert.Equal(t, now, *a.ReportedAt)
+
+		b := status.Relationships[0].TrustDomainB
+		assert.False(t, b.InSync)
+		assert.Equal(t, 10*time.Minute, b.Lag)
+		assert.Equal(t, []byte("td1-old"), b.ReportedDigest)
+		assert.Equal(t, now.Add(-time.Minute), *b.ReportedAt)
+
+		assert.Equal(t, []*UnexpectedBundle{
+			{TrustDomain: td1.Name, FederatedTrustDomain: td3.Name, Digest: []byte("td3-new"), ReportedAt: now},
+		}, status.UnexpectedBundles)
+	}
+}
+
+func reversed(states []*entity.BundleSyncState) []*entity.BundleSyncState {
+	result := make([]*entity.BundleSyncState, 0, len(states))
+	for i := len(states) - 1; i >= 0; i-- {
+		result = append(result, states[i])
+	}
+	return result
+}
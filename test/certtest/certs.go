@@ -5,12 +5,14 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"net/url"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/jmhodges/clock"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,6 +51,20 @@ func CreateTestIntermediateCACertificate(t *testing.T, clk clock.Clock, parent *
 	return caCert, signer
 }
 
+// CreateTestX509SVID creates an X509-SVID with the given SPIFFE ID signed by the given CA for testing purposes.
+func CreateTestX509SVID(t *testing.T, clk clock.Clock, parent *x509.Certificate, parentKey crypto.PrivateKey, id spiffeid.ID) (*x509.Certificate, crypto.PrivateKey) {
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	require.NoError(t, err)
+
+	template, err := cryptoutil.CreateX509Template(clk, signer.Public(), pkix.Name{}, []*url.URL{id.URL()}, nil, oneHour)
+	require.NoError(t, err)
+
+	svid, err := cryptoutil.SignX509(template, parent, parentKey)
+	require.NoError(t, err)
+
+	return svid, signer
+}
+
 // CreateTestCACertificates creates a self-signed CA and an intermediate CA for testing purposes.
 // It returns the path to the temporary directory where the certificates are stored.
 func CreateTestCACertificates(t *testing.T, clk clock.Clock) string {
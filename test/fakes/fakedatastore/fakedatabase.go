@@ -1,7 +1,10 @@
 package fakedatastore
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -20,6 +23,21 @@ type FakeDatabase struct {
 	tokens        map[uuid.UUID]*entity.JoinToken
 	trustDomains  map[uuid.UUID]*entity.TrustDomain
 	relationships map[uuid.UUID]*entity.Relationship
+	consents      map[uuid.UUID]*entity.RelationshipConsent
+	deadLetters   map[uuid.UUID]*entity.WebhookDeadLetter
+	harvesters    map[harvesterKey]*entity.Harvester
+	syncStates    map[uuid.UUID]*entity.BundleSyncState
+	signingKeys   map[string]*entity.SigningKey
+	externalTDs   map[uuid.UUID]*entity.ExternalTrustDomain
+	groups        map[uuid.UUID]*entity.FederationGroup
+	groupMembers  map[uuid.UUID]*entity.FederationGroupMember
+	dpopProofs    map[string]time.Time
+}
+
+// harvesterKey identifies a harvester instance of a trust domain
+type harvesterKey struct {
+	trustDomainID uuid.UUID
+	instanceID    string
 }
 
 func NewFakeDB() *FakeDatabase {
@@ -31,6 +49,15 @@ func NewFakeDB() *FakeDatabase {
 		tokens:        make(map[uuid.UUID]*entity.JoinToken),
 		trustDomains:  make(map[uuid.UUID]*entity.TrustDomain),
 		relationships: make(map[uuid.UUID]*entity.Relationship),
+		consents:      make(map[uuid.UUID]*entity.RelationshipConsent),
+		deadLetters:   make(map[uuid.UUID]*entity.WebhookDeadLetter),
+		harvesters:    make(map[harvesterKey]*entity.Harvester),
+		syncStates:    make(map[uuid.UUID]*entity.BundleSyncState),
+		signingKeys:   make(map[string]*entity.SigningKey),
+		externalTDs:   make(map[uuid.UUID]*entity.ExternalTrustDomain),
+		groups:        make(map[uuid.UUID]*entity.FederationGroup),
+		groupMembers:  make(map[uuid.UUID]*entity.FederationGroupMember),
+		dpopProofs:    make(map[string]time.Time),
 	}
 }
 
@@ -56,6 +83,31 @@ func (db *FakeDatabase) WithTrustDomains(trustDomains ...*entity.TrustDomain) {
 	}
 }
 
+// WithHarvesters overrides all harvesters
+func (db *FakeDatabase) WithHarvesters(harvesters ...*entity.Harvester) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.harvesters = make(map[harvesterKey]*entity.Harvester)
+	for _, h := range harvesters {
+		db.harvesters[harvesterKey{h.TrustDomainID, h.InstanceID}] = h
+	}
+}
+
+// WithBundleSyncStates overrides all bundle sync states
+func (db *FakeDatabase) WithBundleSyncStates(states ...*entity.BundleSyncState) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.syncStates = make(map[uuid.UUID]*entity.BundleSyncState)
+	for _, s := range states {
+		if !s.ID.Valid {
+			s.ID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		}
+		db.syncStates[s.ID.UUID] = s
+	}
+}
+
 // WithBundles overrides all bundles
 func (db *FakeDatabase) WithBundles(bundles ...*entity.Bundle) {
 	db.mutex.Lock()
@@ -67,6 +119,42 @@ func (db *FakeDatabase) WithBundles(bundles ...*entity.Bundle) {
 	}
 }
 
+// WithExternalTrustDomains overrides all external trust domains
+func (db *FakeDatabase) WithExternalTrustDomains(externalTDs ...*entity.ExternalTrustDomain) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.externalTDs = make(map[uuid.UUID]*entity.ExternalTrustDomain)
+	for _, e := range externalTDs {
+		db.externalTDs[e.TrustDomainID] = e
+	}
+}
+
+// WithFederationGroups overrides all federation groups
+func (db *FakeDatabase) WithFederationGroups(groups ...*entity.FederationGroup) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.groups = make(map[uuid.UUID]*entity.FederationGroup)
+	for _, g := range groups {
+		db.groups[g.ID.UUID] = g
+	}
+}
+
+// WithFederationGroupMembers overrides all federation group members
+func (db *FakeDatabase) WithFederationGroupMembers(members ...*entity.FederationGroupMember) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.groupMembers = make(map[uuid.UUID]*entity.FederationGroupMember)
+	for _, m := range members {
+		if !m.ID.Valid {
+			m.ID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		}
+		db.groupMembers[m.ID.UUID] = m
+	}
+}
+
 // WithTokens overrides all tokens
 func (db *FakeDatabase) WithTokens(bundles ...*entity.JoinToken) {
 	db.mutex.Lock()
@@ -217,6 +305,41 @@ func (db *FakeDatabase) CreateOrUpdateBundle(ctx context.Context, req *entity.Bu
 	return req, nil
 }
 
+func (db *FakeDatabase) CreateOrUpdateBundleIfUnchanged(ctx context.Context, req *entity.Bundle, previousDigest []byte) (*entity.Bundle, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	var stored *entity.Bundle
+	for _, b := range db.bundles {
+		if b.TrustDomainID == req.TrustDomainID {
+			stored = b
+		}
+	}
+
+	switch {
+	case !req.ID.Valid && stored != nil:
+		return nil, nil
+	case req.ID.Valid && (stored == nil || stored.ID != req.ID || !bytes.Equal(stored.Digest, previousDigest)):
+		return nil, nil
+	}
+
+	if !req.ID.Valid {
+		req.ID = uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		}
+	}
+
+	req.UpdatedAt = time.Now()
+	db.bundles[req.ID.UUID] = req
+
+	return req, nil
+}
+
 func (db *FakeDatabase) FindBundleByID(ctx context.Context, bundleID uuid.UUID) (*entity.Bundle, error) {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
@@ -301,6 +424,10 @@ func (db *FakeDatabase) CreateJoinToken(ctx context.Context, req *entity.JoinTok
 	}
 
 	req.Used = false
+	req.UseCount = 0
+	if req.MaxUses < 1 {
+		req.MaxUses = 1
+	}
 	req.CreatedAt = time.Now()
 	req.UpdatedAt = time.Now()
 	req.ExpiresAt = time.Now().Add(1 * time.Hour)
@@ -380,6 +507,34 @@ func (db *FakeDatabase) UpdateJoinToken(ctx context.Context, joinTokenID uuid.UU
 	return nil, nil
 }
 
+func (db *FakeDatabase) RecordJoinTokenUse(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	jt, ok := db.tokens[joinTokenID]
+	if !ok || jt.Used {
+		return nil, nil
+	}
+
+	maxUses := jt.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+	if jt.UseCount >= maxUses {
+		return nil, nil
+	}
+
+	jt.UseCount++
+	jt.Used = jt.UseCount >= maxUses
+	jt.UpdatedAt = time.Now()
+
+	return jt, nil
+}
+
 func (db *FakeDatabase) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
@@ -400,7 +555,24 @@ func (db *FakeDatabase) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UU
 	return nil
 }
 
-func (db *FakeDatabase) FindJoinToken(ctx context.Context, token string) (*entity.JoinToken, error) {
+func (db *FakeDatabase) FindJoinTokenByPrefix(ctx context.Context, prefix string) (*entity.JoinToken, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	for _, jt := range db.tokens {
+		if prefix == jt.TokenPrefix {
+			return jt, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (db *FakeDatabase) FindLegacyJoinTokenByHash(ctx context.Context, hash []byte) (*entity.JoinToken, error) {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
@@ -409,7 +581,7 @@ func (db *FakeDatabase) FindJoinToken(ctx context.Context, token string) (*entit
 	}
 
 	for _, jt := range db.tokens {
-		if token == jt.Token {
+		if len(jt.TokenSalt) == 0 && bytes.Equal(hash, jt.TokenHash) {
 			return jt, nil
 		}
 	}
@@ -541,3 +713,618 @@ func (db *FakeDatabase) DeleteRelationship(ctx context.Context, relationshipID u
 
 	return nil
 }
+
+func (db *FakeDatabase) CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, c := range db.consents {
+		if c.RelationshipID == req.RelationshipID && c.TrustDomainID == req.TrustDomainID {
+			req.ID = c.ID
+			req.CreatedAt = c.CreatedAt
+		}
+	}
+
+	if !req.ID.Valid {
+		req.ID = uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		}
+		req.CreatedAt = now
+	}
+	req.UpdatedAt = now
+
+	db.consents[req.ID.UUID] = req
+
+	return req, nil
+}
+
+func (db *FakeDatabase) FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	consents := []*entity.RelationshipConsent{}
+	for _, c := range db.consents {
+		if c.RelationshipID == relationshipID {
+			consents = append(consents, c)
+		}
+	}
+
+	sort.Slice(consents, func(i, j int) bool {
+		return consents[i].CreatedAt.Before(consents[j].CreatedAt)
+	})
+
+	return consents, nil
+}
+
+func (db *FakeDatabase) DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	for id, c := range db.consents {
+		if c.RelationshipID == relationshipID && c.TrustDomainID == trustDomainID {
+			delete(db.consents, id)
+		}
+	}
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateWebhookDeadLetter(ctx context.Context, req *entity.WebhookDeadLetter) (*entity.WebhookDeadLetter, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	req.ID = uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	}
+	req.CreatedAt = time.Now()
+
+	db.deadLetters[req.ID.UUID] = req
+
+	return req, nil
+}
+
+func (db *FakeDatabase) ListWebhookDeadLetters(ctx context.Context) ([]*entity.WebhookDeadLetter, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	deadLetters := []*entity.WebhookDeadLetter{}
+	for _, dl := range db.deadLetters {
+		deadLetters = append(deadLetters, dl)
+	}
+
+	return deadLetters, nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateBundleSyncState(ctx context.Context, req *entity.BundleSyncState) (*entity.BundleSyncState, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, s := range db.syncStates {
+		if s.TrustDomainID == req.TrustDomainID && s.HarvesterInstanceID == req.HarvesterInstanceID && s.FederatedTrustDomain == req.FederatedTrustDomain {
+			req.ID = s.ID
+			req.CreatedAt = s.CreatedAt
+			if bytes.Equal(s.Digest, req.Digest) {
+				req.DigestUpdatedAt = s.DigestUpdatedAt
+			}
+		}
+	}
+
+	if !req.ID.Valid {
+		req.ID = uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		}
+		req.CreatedAt = now
+	}
+	req.UpdatedAt = now
+
+	db.syncStates[req.ID.UUID] = req
+
+	return req, nil
+}
+
+func (db *FakeDatabase) FindBundleSyncStatesByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleSyncState, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	states := []*entity.BundleSyncState{}
+	for _, s := range db.syncStates {
+		if s.TrustDomainID == trustDomainID {
+			states = append(states, s)
+		}
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].FederatedTrustDomain != states[j].FederatedTrustDomain {
+			return states[i].FederatedTrustDomain.String() < states[j].FederatedTrustDomain.String()
+		}
+		return states[i].HarvesterInstanceID < states[j].HarvesterInstanceID
+	})
+
+	return states, nil
+}
+
+func (db *FakeDatabase) ListBundleSyncStates(ctx context.Context) ([]*entity.BundleSyncState, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	states := []*entity.BundleSyncState{}
+	for _, s := range db.syncStates {
+		states = append(states, s)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].CreatedAt.Before(states[j].CreatedAt)
+	})
+
+	return states, nil
+}
+
+func (db *FakeDatabase) DeleteStaleBundleSyncStates(ctx context.Context, trustDomainID uuid.UUID, instanceID string, reportedBefore time.Time) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	for id, s := range db.syncStates {
+		if s.TrustDomainID == trustDomainID && s.HarvesterInstanceID == instanceID && s.ReportedAt.Before(reportedBefore) {
+			delete(db.syncStates, id)
+		}
+	}
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateHarvester(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	key := harvesterKey{req.TrustDomainID, req.InstanceID}
+	if h, ok := db.harvesters[key]; ok {
+		req.ID = h.ID
+		req.CreatedAt = h.CreatedAt
+		req.LastBundleUploadAt = h.LastBundleUploadAt
+		req.LastBundleDigest = h.LastBundleDigest
+		req.LastBundleUploadStatus = h.LastBundleUploadStatus
+	} else {
+		req.ID = uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		}
+		req.CreatedAt = now
+	}
+	req.UpdatedAt = now
+
+	db.harvesters[key] = req
+
+	return req, nil
+}
+
+func (db *FakeDatabase) UpdateHarvesterBundleUpload(ctx context.Context, req *entity.Harvester) (*entity.Harvester, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	h, ok := db.harvesters[harvesterKey{req.TrustDomainID, req.InstanceID}]
+	if !ok {
+		return nil, fmt.Errorf("harvester instance %q of trust domain ID=%q not found", req.InstanceID, req.TrustDomainID)
+	}
+
+	h.LastBundleUploadAt = req.LastBundleUploadAt
+	h.LastBundleDigest = req.LastBundleDigest
+	h.LastBundleUploadStatus = req.LastBundleUploadStatus
+	h.UpdatedAt = time.Now()
+
+	return h, nil
+}
+
+func (db *FakeDatabase) FindHarvestersByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Harvester, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	harvesters := []*entity.Harvester{}
+	for _, h := range db.harvesters {
+		if h.TrustDomainID == trustDomainID {
+			harvesters = append(harvesters, h)
+		}
+	}
+
+	sort.Slice(harvesters, func(i, j int) bool {
+		return harvesters[i].InstanceID < harvesters[j].InstanceID
+	})
+
+	return harvesters, nil
+}
+
+func (db *FakeDatabase) ListHarvesters(ctx context.Context) ([]*entity.Harvester, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	harvesters := []*entity.Harvester{}
+	for _, h := range db.harvesters {
+		harvesters = append(harvesters, h)
+	}
+
+	sort.Slice(harvesters, func(i, j int) bool {
+		return harvesters[i].CreatedAt.Before(harvesters[j].CreatedAt)
+	})
+
+	return harvesters, nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if k, ok := db.signingKeys[req.ID]; ok {
+		req.CreatedAt = k.CreatedAt
+	} else {
+		req.CreatedAt = now
+	}
+	req.UpdatedAt = now
+
+	db.signingKeys[req.ID] = req
+
+	return req, nil
+}
+
+func (db *FakeDatabase) ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	signingKeys := []*entity.SigningKey{}
+	for _, k := range db.signingKeys {
+		signingKeys = append(signingKeys, k)
+	}
+
+	sort.Slice(signingKeys, func(i, j int) bool {
+		return signingKeys[i].CreatedAt.Before(signingKeys[j].CreatedAt)
+	})
+
+	return signingKeys, nil
+}
+
+func (db *FakeDatabase) DeleteSigningKey(ctx context.Context, id string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	delete(db.signingKeys, id)
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateExternalTrustDomain(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	externalTD := *req
+	if e, ok := db.externalTDs[req.TrustDomainID]; ok {
+		externalTD.ID = e.ID
+		externalTD.CreatedAt = e.CreatedAt
+	} else {
+		externalTD.ID = uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		}
+		externalTD.CreatedAt = now
+	}
+	externalTD.LastRefreshAt = time.Time{}
+	externalTD.LastRefreshError = ""
+	externalTD.UpdatedAt = now
+
+	db.externalTDs[req.TrustDomainID] = &externalTD
+
+	return &externalTD, nil
+}
+
+func (db *FakeDatabase) UpdateExternalTrustDomainRefresh(ctx context.Context, req *entity.ExternalTrustDomain) (*entity.ExternalTrustDomain, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	e, ok := db.externalTDs[req.TrustDomainID]
+	if !ok {
+		return nil, fmt.Errorf("external trust domain ID=%q not found", req.TrustDomainID)
+	}
+
+	externalTD := *e
+	externalTD.LastRefreshAt = req.LastRefreshAt
+	externalTD.LastRefreshError = req.LastRefreshError
+	externalTD.UpdatedAt = time.Now()
+	db.externalTDs[req.TrustDomainID] = &externalTD
+
+	return &externalTD, nil
+}
+
+func (db *FakeDatabase) FindExternalTrustDomainByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.ExternalTrustDomain, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	return db.externalTDs[trustDomainID], nil
+}
+
+func (db *FakeDatabase) ListExternalTrustDomains(ctx context.Context) ([]*entity.ExternalTrustDomain, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	externalTDs := []*entity.ExternalTrustDomain{}
+	for _, e := range db.externalTDs {
+		externalTDs = append(externalTDs, e)
+	}
+
+	sort.Slice(externalTDs, func(i, j int) bool {
+		return externalTDs[i].CreatedAt.Before(externalTDs[j].CreatedAt)
+	})
+
+	return externalTDs, nil
+}
+
+func (db *FakeDatabase) DeleteExternalTrustDomain(ctx context.Context, trustDomainID uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	delete(db.externalTDs, trustDomainID)
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateFederationGroup(ctx context.Context, req *entity.FederationGroup) (*entity.FederationGroup, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	group := *req
+	if group.DefaultConsent == "" {
+		group.DefaultConsent = entity.ConsentStatusPending
+	}
+	if g, ok := db.groups[req.ID.UUID]; ok && req.ID.Valid {
+		group.Name = g.Name
+		group.CreatedAt = g.CreatedAt
+	} else {
+		for _, g := range db.groups {
+			if g.Name == req.Name {
+				return nil, fmt.Errorf("federation group %q already exists", req.Name)
+			}
+		}
+		group.ID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		group.CreatedAt = now
+	}
+	group.UpdatedAt = now
+
+	db.groups[group.ID.UUID] = &group
+
+	return &group, nil
+}
+
+func (db *FakeDatabase) FindFederationGroupByName(ctx context.Context, name string) (*entity.FederationGroup, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	for _, g := range db.groups {
+		if g.Name == name {
+			return g, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (db *FakeDatabase) ListFederationGroups(ctx context.Context) ([]*entity.FederationGroup, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	groups := []*entity.FederationGroup{}
+	for _, g := range db.groups {
+		groups = append(groups, g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if !groups[i].CreatedAt.Equal(groups[j].CreatedAt) {
+			return groups[i].CreatedAt.Before(groups[j].CreatedAt)
+		}
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups, nil
+}
+
+func (db *FakeDatabase) DeleteFederationGroup(ctx context.Context, groupID uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	delete(db.groups, groupID)
+	for id, m := range db.groupMembers {
+		if m.FederationGroupID == groupID {
+			delete(db.groupMembers, id)
+		}
+	}
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateFederationGroupMember(ctx context.Context, req *entity.FederationGroupMember) (*entity.FederationGroupMember, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	for _, m := range db.groupMembers {
+		if m.FederationGroupID == req.FederationGroupID && m.TrustDomainID == req.TrustDomainID {
+			return nil, fmt.Errorf("trust domain ID=%q is already a member of federation group ID=%q", req.TrustDomainID, req.FederationGroupID)
+		}
+	}
+
+	member := *req
+	member.ID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	member.CreatedAt = time.Now()
+	db.groupMembers[member.ID.UUID] = &member
+
+	return &member, nil
+}
+
+func (db *FakeDatabase) FindFederationGroupMembersByGroupID(ctx context.Context, groupID uuid.UUID) ([]*entity.FederationGroupMember, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	members := []*entity.FederationGroupMember{}
+	for _, m := range db.groupMembers {
+		if m.FederationGroupID == groupID {
+			members = append(members, m)
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+
+	return members, nil
+}
+
+func (db *FakeDatabase) DeleteFederationGroupMember(ctx context.Context, groupID, trustDomainID uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	for id, m := range db.groupMembers {
+		if m.FederationGroupID == groupID && m.TrustDomainID == trustDomainID {
+			delete(db.groupMembers, id)
+		}
+	}
+
+	return nil
+}
+
+func (db *FakeDatabase) RecordDPoPProof(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	for proofID, proofExpiresAt := range db.dpopProofs {
+		if proofExpiresAt.Before(now) {
+			delete(db.dpopProofs, proofID)
+		}
+	}
+
+	if _, ok := db.dpopProofs[id]; ok {
+		return false, nil
+	}
+	db.dpopProofs[id] = expiresAt
+
+	return true, nil
+}
@@ -17,6 +17,9 @@ import (
 type JWTIssuer struct {
 	Token  string
 	Signer crypto.Signer
+
+	// Params are the params of the last issued token.
+	Params *jwt.JWTParams
 }
 
 func New(t *testing.T, kid string, sub string, aud []string) *JWTIssuer {
@@ -29,6 +32,7 @@ func New(t *testing.T, kid string, sub string, aud []string) *JWTIssuer {
 	}
 }
 
-func (j JWTIssuer) IssueJWT(ctx context.Context, params *jwt.JWTParams) (string, error) {
+func (j *JWTIssuer) IssueJWT(ctx context.Context, params *jwt.JWTParams) (string, error) {
+	j.Params = params
 	return j.Token, nil
 }
@@ -0,0 +1,30 @@
+package fakenotifier
+
+import (
+	"sync"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/notification"
+)
+
+// Notifier is a fake implementation of the notification.Notifier interface that records the notified events.
+type Notifier struct {
+	mu     sync.Mutex
+	events []*notification.Event
+}
+
+func New() *Notifier {
+	return &Notifier{}
+}
+
+func (n *Notifier) Notify(event *notification.Event) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+}
+
+// Events returns the events notified so far.
+func (n *Notifier) Events() []*notification.Event {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*notification.Event(nil), n.events...)
+}